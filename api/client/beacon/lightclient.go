@@ -0,0 +1,119 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+)
+
+const (
+	getGenesisPath                     = "/eth/v1/beacon/genesis"
+	getLightClientBootstrapPath        = "/eth/v1/beacon/light_client/bootstrap"
+	getLightClientUpdatesPath          = "/eth/v1/beacon/light_client/updates"
+	getLightClientFinalityUpdatePath   = "/eth/v1/beacon/light_client/finality_update"
+	getLightClientOptimisticUpdatePath = "/eth/v1/beacon/light_client/optimistic_update"
+)
+
+// GetGenesis retrieves the genesis time, genesis validators root and genesis fork version of the chain.
+func (c *Client) GetGenesis(ctx context.Context) (*structs.Genesis, error) {
+	body, err := c.Get(ctx, getGenesisPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting genesis")
+	}
+	resp := &structs.GetGenesisResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetGenesis")
+	}
+	if resp.Data == nil {
+		return nil, errors.New("empty genesis response")
+	}
+	return resp.Data, nil
+}
+
+// GetLightClientBootstrap retrieves the light client bootstrap for the given trusted block root.
+func (c *Client) GetLightClientBootstrap(ctx context.Context, blockRoot [32]byte) (*ethpbv2.LightClientBootstrap, error) {
+	body, err := c.Get(ctx, getLightClientBootstrapPath+"/"+hexutil.Encode(blockRoot[:]))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting light client bootstrap for block root %#x", blockRoot)
+	}
+	resp := &structs.LightClientBootstrapResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetLightClientBootstrap")
+	}
+	return resp.ToConsensus()
+}
+
+// GetLightClientUpdatesByRange retrieves the best light client updates of count sync committee periods,
+// starting at startPeriod. The beacon node may return fewer updates than requested.
+func (c *Client) GetLightClientUpdatesByRange(ctx context.Context, startPeriod, count uint64) ([]*ethpbv2.LightClientUpdate, error) {
+	query := url.Values{}
+	query.Set("start_period", strconv.FormatUint(startPeriod, 10))
+	query.Set("count", strconv.FormatUint(count, 10))
+	body, err := c.Get(ctx, getLightClientUpdatesPath, client.WithQueryParams(query))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting light client updates from period %d", startPeriod)
+	}
+	var resp []*structs.LightClientUpdateWithVersion
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetLightClientUpdatesByRange")
+	}
+	updates := make([]*ethpbv2.LightClientUpdate, len(resp))
+	for i, u := range resp {
+		if u == nil {
+			return nil, fmt.Errorf("empty light client update at index %d", i)
+		}
+		updates[i], err = u.ToConsensus()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not convert light client update at index %d", i)
+		}
+	}
+	return updates, nil
+}
+
+// GetLightClientFinalityUpdate retrieves the latest light client finality update known to the beacon node.
+func (c *Client) GetLightClientFinalityUpdate(ctx context.Context) (*ethpbv2.LightClientFinalityUpdate, error) {
+	update, err := c.getLightClientUpdate(ctx, getLightClientFinalityUpdatePath)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpbv2.LightClientFinalityUpdate{
+		AttestedHeader:  update.AttestedHeader,
+		FinalizedHeader: update.FinalizedHeader,
+		FinalityBranch:  update.FinalityBranch,
+		SyncAggregate:   update.SyncAggregate,
+		SignatureSlot:   update.SignatureSlot,
+	}, nil
+}
+
+// GetLightClientOptimisticUpdate retrieves the latest light client optimistic update known to the beacon node.
+func (c *Client) GetLightClientOptimisticUpdate(ctx context.Context) (*ethpbv2.LightClientOptimisticUpdate, error) {
+	update, err := c.getLightClientUpdate(ctx, getLightClientOptimisticUpdatePath)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpbv2.LightClientOptimisticUpdate{
+		AttestedHeader: update.AttestedHeader,
+		SyncAggregate:  update.SyncAggregate,
+		SignatureSlot:  update.SignatureSlot,
+	}, nil
+}
+
+func (c *Client) getLightClientUpdate(ctx context.Context, path string) (*ethpbv2.LightClientUpdate, error) {
+	body, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting %s", path)
+	}
+	resp := &structs.LightClientUpdateWithVersion{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrapf(err, "error decoding json response from %s", path)
+	}
+	return resp.ToConsensus()
+}
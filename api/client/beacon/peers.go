@@ -0,0 +1,79 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+)
+
+const (
+	getPeerReputationsPath = "/prysm/v1/node/peer_reputations"
+	bannedPeersPath        = "/prysm/v1/node/banned_peers"
+)
+
+// GetPeerReputations retrieves the reputation of all peers known to a Prysm beacon node.
+func (c *Client) GetPeerReputations(ctx context.Context) ([]*structs.PeerReputation, error) {
+	body, err := c.Get(ctx, getPeerReputationsPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting peer reputations")
+	}
+	resp := &structs.PeerReputationsResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetPeerReputations")
+	}
+	return resp.Data, nil
+}
+
+// BanPeer bans a peer of a Prysm beacon node for the given duration, a zero duration bans the peer indefinitely.
+// The duration is rounded up to the second, so that a sub-second duration does not ban the peer indefinitely.
+func (c *Client) BanPeer(ctx context.Context, peerID string, duration time.Duration, reason string) error {
+	if duration < 0 {
+		return errors.Errorf("invalid negative ban duration %s", duration)
+	}
+	body, err := json.Marshal(&structs.BanPeerRequest{
+		PeerId:   peerID,
+		Duration: strconv.FormatUint(uint64((duration+time.Second-1)/time.Second), 10),
+		Reason:   reason,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal JSON")
+	}
+	return c.send(ctx, http.MethodPost, bannedPeersPath, body)
+}
+
+// UnbanPeer lifts the ban of a peer of a Prysm beacon node.
+func (c *Client) UnbanPeer(ctx context.Context, peerID string) error {
+	return c.send(ctx, http.MethodDelete, bannedPeersPath+"/"+url.PathEscape(peerID), nil)
+}
+
+func (c *Client) send(ctx context.Context, method, path string, body []byte) error {
+	u := c.BaseURL().ResolveReference(&url.URL{Path: path})
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create new %s request object", method)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close response body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return client.Non200Err(resp)
+	}
+	return nil
+}
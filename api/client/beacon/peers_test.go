@@ -0,0 +1,50 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestBanPeer(t *testing.T) {
+	var got *structs.BanPeerRequest
+	trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, bannedPeersPath, req.URL.Path)
+		got = &structs.BanPeerRequest{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(got))
+		return &http.Response{Request: req, StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer(nil))}, nil
+	}}
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(trans))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	cases := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{duration: 0, want: "0"},
+		{duration: time.Millisecond, want: "1"},
+		{duration: time.Second, want: "1"},
+		{duration: 90 * time.Second, want: "90"},
+		{duration: 90*time.Second + time.Millisecond, want: "91"},
+	}
+	for _, tc := range cases {
+		require.NoError(t, c.BanPeer(ctx, "peer", tc.duration, "spam"))
+		assert.Equal(t, tc.want, got.Duration, tc.duration.String())
+		assert.Equal(t, "peer", got.PeerId)
+		assert.Equal(t, "spam", got.Reason)
+	}
+
+	got = nil
+	require.ErrorContains(t, "invalid negative ban duration", c.BanPeer(ctx, "peer", -time.Second, ""))
+	assert.Equal(t, true, got == nil)
+}
@@ -28,6 +28,8 @@ const (
 	EventLightClientOptimisticUpdate = "light_client_optimistic_update"
 	EventPayloadAttributes           = "payload_attributes"
 	EventBlobSidecar                 = "blob_sidecar"
+	EventSlasherAttestation          = "slasher_attestation"
+	EventSlasherBlockHeader          = "slasher_block_header"
 	EventError                       = "error"
 	EventConnectionError             = "connection_error"
 )
@@ -84,6 +86,7 @@ func (h *EventStream) Subscribe(eventsChannel chan<- *Event) {
 			EventType: EventConnectionError,
 			Data:      []byte(errors.Wrap(err, "failed to create HTTP request").Error()),
 		}
+		return
 	}
 	req.Header.Set("Accept", api.EventStreamMediaType)
 	req.Header.Set("Connection", api.KeepAlive)
@@ -93,6 +96,7 @@ func (h *EventStream) Subscribe(eventsChannel chan<- *Event) {
 			EventType: EventConnectionError,
 			Data:      []byte(errors.Wrap(err, client.ErrConnectionIssue.Error()).Error()),
 		}
+		return
 	}
 
 	defer func() {
@@ -100,6 +104,13 @@ func (h *EventStream) Subscribe(eventsChannel chan<- *Event) {
 			log.WithError(closeErr).Error("Failed to close events response body")
 		}
 	}()
+	if resp.StatusCode != http.StatusOK {
+		eventsChannel <- &Event{
+			EventType: EventConnectionError,
+			Data:      []byte(client.Non200Err(resp).Error()),
+		}
+		return
+	}
 	// Create a new scanner to read lines from the response body
 	scanner := bufio.NewScanner(resp.Body)
 	// Set the split function for the scanning operation
@@ -79,3 +79,21 @@ func TestEventStream(t *testing.T) {
 		}
 	}
 }
+
+func TestEventStream_Non200Response(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/events", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Invalid topic: topic1", http.StatusBadRequest)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	eventsChannel := make(chan *Event, 1)
+	stream, err := NewEventStream(context.Background(), http.DefaultClient, server.URL, []string{"topic1"})
+	require.NoError(t, err)
+	go stream.Subscribe(eventsChannel)
+
+	event := <-eventsChannel
+	require.Equal(t, EventConnectionError, event.EventType)
+	require.StringContains(t, "Invalid topic: topic1", string(event.Data))
+}
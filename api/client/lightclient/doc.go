@@ -0,0 +1,7 @@
+/*
+Package lightclient implements a light client that follows the beacon chain through the light client
+REST API of a beacon node. Starting from a trusted block root, it verifies every update it receives
+against the sync committees it tracks, and exposes the latest verified finalized and optimistic
+headers, along with their execution payload headers, without running a full beacon node.
+*/
+package lightclient
@@ -0,0 +1,229 @@
+package lightclient
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// BeaconClient is the subset of the beacon node REST API used by the Follower.
+type BeaconClient interface {
+	GetGenesis(ctx context.Context) (*structs.Genesis, error)
+	GetLightClientBootstrap(ctx context.Context, blockRoot [32]byte) (*ethpbv2.LightClientBootstrap, error)
+	GetLightClientUpdatesByRange(ctx context.Context, startPeriod, count uint64) ([]*ethpbv2.LightClientUpdate, error)
+	GetLightClientFinalityUpdate(ctx context.Context) (*ethpbv2.LightClientFinalityUpdate, error)
+	GetLightClientOptimisticUpdate(ctx context.Context) (*ethpbv2.LightClientOptimisticUpdate, error)
+}
+
+// Header is a verified light client header.
+type Header struct {
+	Beacon *ethpbv1.BeaconBlockHeader
+	// Execution is nil for headers that do not carry an execution payload header.
+	Execution interfaces.ExecutionData
+}
+
+// Follower tracks the head of the chain from light client updates, starting at a trusted block root.
+// It is safe for concurrent use.
+type Follower struct {
+	client      BeaconClient
+	genesisTime uint64
+	currentSlot func() primitives.Slot
+
+	lock  sync.RWMutex
+	store *lightclient.Store
+}
+
+// NewFollower bootstraps a light client from the given trusted block root. The bootstrap served by the
+// beacon node is only accepted if it matches the trusted block root and proves its sync committee.
+func NewFollower(ctx context.Context, c BeaconClient, trustedBlockRoot [32]byte) (*Follower, error) {
+	genesis, err := c.GetGenesis(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get genesis")
+	}
+	genesisTime, err := strconv.ParseUint(genesis.GenesisTime, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid genesis time %s", genesis.GenesisTime)
+	}
+	genesisValidatorsRoot, err := bytesutil.DecodeHexWithLength(genesis.GenesisValidatorsRoot, fieldparams.RootLength)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid genesis validators root %s", genesis.GenesisValidatorsRoot)
+	}
+	bootstrap, err := c.GetLightClientBootstrap(ctx, trustedBlockRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get light client bootstrap")
+	}
+	store, err := lightclient.InitializeStore(trustedBlockRoot, bootstrap, genesisValidatorsRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize light client store")
+	}
+	return &Follower{
+		client:      c,
+		genesisTime: genesisTime,
+		currentSlot: func() primitives.Slot { return slots.CurrentSlot(genesisTime) },
+		store:       store,
+	}, nil
+}
+
+// Sync brings the light client up to date with the beacon node. It first catches up with the sync
+// committee periods it missed using the best update of every period, then applies the latest finality
+// and optimistic updates.
+func (f *Follower) Sync(ctx context.Context) error {
+	currentPeriod := slots.SyncCommitteePeriod(slots.ToEpoch(f.currentSlot()))
+	for {
+		f.lock.RLock()
+		period := slots.SyncCommitteePeriod(slots.ToEpoch(f.store.FinalizedSlot()))
+		nextKnown := f.store.NextSyncCommittee != nil
+		f.lock.RUnlock()
+		if nextKnown && period >= currentPeriod {
+			break
+		}
+		updates, err := f.client.GetLightClientUpdatesByRange(ctx, period, params.BeaconConfig().MaxRequestLightClientUpdates)
+		if err != nil {
+			return errors.Wrapf(err, "could not get light client updates from period %d", period)
+		}
+		progress := false
+		for _, update := range updates {
+			applied, err := f.process(func(s *lightclient.Store) error { return s.ProcessUpdate(update, f.currentSlot()) })
+			if err != nil {
+				return errors.Wrap(err, "could not process light client update")
+			}
+			progress = progress || applied
+		}
+		if !progress {
+			break
+		}
+	}
+
+	finalityUpdate, err := f.client.GetLightClientFinalityUpdate(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get light client finality update")
+	}
+	if _, err := f.process(func(s *lightclient.Store) error { return s.ProcessFinalityUpdate(finalityUpdate, f.currentSlot()) }); err != nil {
+		return errors.Wrap(err, "could not process light client finality update")
+	}
+	optimisticUpdate, err := f.client.GetLightClientOptimisticUpdate(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get light client optimistic update")
+	}
+	if _, err := f.process(func(s *lightclient.Store) error { return s.ProcessOptimisticUpdate(optimisticUpdate, f.currentSlot()) }); err != nil {
+		return errors.Wrap(err, "could not process light client optimistic update")
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.store.ProcessForceUpdate(f.currentSlot())
+}
+
+// Follow syncs the light client once per slot until the context is canceled. Sync errors are logged
+// and retried on the next slot. The callback, if not nil, is called whenever the optimistic or the
+// finalized header changes.
+func (f *Follower) Follow(ctx context.Context, onHead func(finalized, optimistic *Header)) {
+	ticker := slots.NewSlotTickerWithOffset(time.Unix(int64(f.genesisTime), 0), slotOffset(), params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
+	var lastFinalized, lastOptimistic primitives.Slot
+	for {
+		if err := f.Sync(ctx); err != nil {
+			log.WithError(err).Warn("Could not sync light client")
+		}
+		f.lock.RLock()
+		finalizedSlot, optimisticSlot := f.store.FinalizedSlot(), f.store.OptimisticSlot()
+		f.lock.RUnlock()
+		if finalizedSlot != lastFinalized || optimisticSlot != lastOptimistic {
+			lastFinalized, lastOptimistic = finalizedSlot, optimisticSlot
+			log.WithFields(logrus.Fields{
+				"finalizedSlot":  finalizedSlot,
+				"optimisticSlot": optimisticSlot,
+			}).Info("Light client head updated")
+			if onHead != nil {
+				finalized, optimistic, err := f.headers()
+				if err != nil {
+					log.WithError(err).Error("Could not read light client headers")
+				} else {
+					onHead(finalized, optimistic)
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// FinalizedHeader returns the latest verified finalized header.
+func (f *Follower) FinalizedHeader() (*Header, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return newHeader(f.store.FinalizedHeader)
+}
+
+// OptimisticHeader returns the latest verified header attested by the sync committee.
+func (f *Follower) OptimisticHeader() (*Header, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return newHeader(f.store.OptimisticHeader)
+}
+
+func (f *Follower) headers() (*Header, *Header, error) {
+	finalized, err := f.FinalizedHeader()
+	if err != nil {
+		return nil, nil, err
+	}
+	optimistic, err := f.OptimisticHeader()
+	if err != nil {
+		return nil, nil, err
+	}
+	return finalized, optimistic, nil
+}
+
+// process applies an update to the store and reports whether the store moved. Updates that the store
+// already knows about are not errors.
+func (f *Follower) process(apply func(s *lightclient.Store) error) (bool, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	finalized, optimistic, next := f.store.FinalizedSlot(), f.store.OptimisticSlot(), f.store.NextSyncCommittee
+	if err := apply(f.store); err != nil {
+		if errors.Is(err, lightclient.ErrIrrelevantUpdate) {
+			return false, nil
+		}
+		return false, err
+	}
+	return f.store.FinalizedSlot() != finalized || f.store.OptimisticSlot() != optimistic || f.store.NextSyncCommittee != next, nil
+}
+
+func newHeader(header *ethpbv2.LightClientHeaderContainer) (*Header, error) {
+	beacon, err := header.GetBeacon()
+	if err != nil {
+		return nil, err
+	}
+	h := &Header{Beacon: beacon}
+	if header.GetHeaderAltair() == nil {
+		h.Execution, err = lightclient.ExecutionPayloadHeader(header)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get execution payload header")
+		}
+	}
+	return h, nil
+}
+
+// slotOffset delays the sync to a third of the slot, once the block of the slot had time to propagate
+// and the beacon node had time to compute the new updates.
+func slotOffset() time.Duration {
+	cfg := params.BeaconConfig()
+	return time.Duration(cfg.SecondsPerSlot/cfg.IntervalsPerSlot) * time.Second
+}
@@ -0,0 +1,96 @@
+package lightclient
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+type mockBeaconClient struct {
+	bootstrap      *ethpbv2.LightClientBootstrap
+	updates        []*ethpbv2.LightClientUpdate
+	finality       *ethpbv2.LightClientFinalityUpdate
+	optimistic     *ethpbv2.LightClientOptimisticUpdate
+	requestedStart []uint64
+}
+
+func (m *mockBeaconClient) GetGenesis(_ context.Context) (*structs.Genesis, error) {
+	return &structs.Genesis{
+		GenesisTime:           strconv.Itoa(0),
+		GenesisValidatorsRoot: hexutil.Encode(make([]byte, 32)),
+	}, nil
+}
+
+func (m *mockBeaconClient) GetLightClientBootstrap(_ context.Context, _ [32]byte) (*ethpbv2.LightClientBootstrap, error) {
+	return m.bootstrap, nil
+}
+
+func (m *mockBeaconClient) GetLightClientUpdatesByRange(_ context.Context, startPeriod, _ uint64) ([]*ethpbv2.LightClientUpdate, error) {
+	m.requestedStart = append(m.requestedStart, startPeriod)
+	return m.updates, nil
+}
+
+func (m *mockBeaconClient) GetLightClientFinalityUpdate(_ context.Context) (*ethpbv2.LightClientFinalityUpdate, error) {
+	if m.finality == nil {
+		return nil, errors.New("not found")
+	}
+	return m.finality, nil
+}
+
+func (m *mockBeaconClient) GetLightClientOptimisticUpdate(_ context.Context) (*ethpbv2.LightClientOptimisticUpdate, error) {
+	if m.optimistic == nil {
+		return nil, errors.New("not found")
+	}
+	return m.optimistic, nil
+}
+
+func TestNewFollower(t *testing.T) {
+	l := util.NewTestLightClient(t).SetupTestDeneb()
+	bootstrap, err := lightclient.NewLightClientBootstrapFromBeaconState(l.Ctx, l.State, l.Block)
+	require.NoError(t, err)
+	root, err := l.Block.Block().HashTreeRoot()
+	require.NoError(t, err)
+	c := &mockBeaconClient{bootstrap: bootstrap}
+
+	_, err = NewFollower(context.Background(), c, [32]byte{'a'})
+	require.ErrorIs(t, err, lightclient.ErrUntrustedBootstrap)
+
+	f, err := NewFollower(context.Background(), c, root)
+	require.NoError(t, err)
+	finalized, err := f.FinalizedHeader()
+	require.NoError(t, err)
+	require.Equal(t, l.Block.Block().Slot(), finalized.Beacon.Slot)
+	require.NotNil(t, finalized.Execution)
+	optimistic, err := f.OptimisticHeader()
+	require.NoError(t, err)
+	require.Equal(t, finalized.Beacon.Slot, optimistic.Beacon.Slot)
+}
+
+func TestFollower_Sync(t *testing.T) {
+	l := util.NewTestLightClient(t).SetupTestAltair()
+	bootstrap, err := lightclient.NewLightClientBootstrapFromBeaconState(l.Ctx, l.State, l.Block)
+	require.NoError(t, err)
+	root, err := l.Block.Block().HashTreeRoot()
+	require.NoError(t, err)
+	c := &mockBeaconClient{bootstrap: bootstrap}
+	f, err := NewFollower(context.Background(), c, root)
+	require.NoError(t, err)
+	f.currentSlot = func() primitives.Slot { return l.Block.Block().Slot() + 1 }
+
+	// Without any update the beacon node is asked once for the updates of the bootstrap period, and the
+	// missing finality update is reported.
+	err = f.Sync(context.Background())
+	require.ErrorContains(t, "could not get light client finality update", err)
+	require.DeepEqual(t, []uint64{slots.SyncCommitteePeriod(slots.ToEpoch(l.Block.Block().Slot()))}, c.requestedStart)
+	require.Equal(t, l.Block.Block().Slot(), f.store.FinalizedSlot())
+}
@@ -0,0 +1,5 @@
+package lightclient
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "lightclient")
@@ -209,6 +209,69 @@ func SignedValidatorRegistrationFromConsensus(vr *eth.SignedValidatorRegistratio
 	}
 }
 
+func (p *BuilderPolicy) ToConsensus() (*eth.BuilderPolicy, error) {
+	policy := &eth.BuilderPolicy{}
+	fields := []struct {
+		name  string
+		value string
+		dst   **uint64
+	}{
+		{"LocalBlockValueBoost", p.LocalBlockValueBoost, &policy.LocalBlockValueBoost},
+		{"MinBuilderBid", p.MinBuilderBid, &policy.MinBuilderBid},
+		{"MinBuilderToLocalDifference", p.MinBuilderToLocalDifference, &policy.MinBuilderToLocalDifference},
+		{"MaxBuilderConsecutiveMissedSlots", p.MaxBuilderConsecutiveMissedSlots, &policy.MaxBuilderConsecutiveMissedSlots},
+		{"MaxBuilderEpochMissedSlots", p.MaxBuilderEpochMissedSlots, &policy.MaxBuilderEpochMissedSlots},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		v, err := strconv.ParseUint(f.value, 10, 64)
+		if err != nil {
+			return nil, server.NewDecodeError(err, f.name)
+		}
+		*f.dst = &v
+	}
+	return policy, nil
+}
+
+func BuilderPolicyFromConsensus(p *eth.BuilderPolicy) *BuilderPolicy {
+	if p == nil {
+		return nil
+	}
+	policy := &BuilderPolicy{}
+	if p.LocalBlockValueBoost != nil {
+		policy.LocalBlockValueBoost = fmt.Sprintf("%d", *p.LocalBlockValueBoost)
+	}
+	if p.MinBuilderBid != nil {
+		policy.MinBuilderBid = fmt.Sprintf("%d", *p.MinBuilderBid)
+	}
+	if p.MinBuilderToLocalDifference != nil {
+		policy.MinBuilderToLocalDifference = fmt.Sprintf("%d", *p.MinBuilderToLocalDifference)
+	}
+	if p.MaxBuilderConsecutiveMissedSlots != nil {
+		policy.MaxBuilderConsecutiveMissedSlots = fmt.Sprintf("%d", *p.MaxBuilderConsecutiveMissedSlots)
+	}
+	if p.MaxBuilderEpochMissedSlots != nil {
+		policy.MaxBuilderEpochMissedSlots = fmt.Sprintf("%d", *p.MaxBuilderEpochMissedSlots)
+	}
+	return policy
+}
+
+func ProposalDecisionFromConsensus(d *eth.ProposalDecision) *ProposalDecision {
+	return &ProposalDecision{
+		Slot:               fmt.Sprintf("%d", d.Slot),
+		ProposerIndex:      fmt.Sprintf("%d", d.ProposerIndex),
+		BuilderSelected:    d.BuilderSelected,
+		Reason:             d.Reason,
+		BuilderValueGwei:   fmt.Sprintf("%d", d.BuilderValueGwei),
+		LocalValueGwei:     fmt.Sprintf("%d", d.LocalValueGwei),
+		BuilderBoostFactor: fmt.Sprintf("%d", d.BuilderBoostFactor),
+		Relay:              d.Relay,
+		Policy:             BuilderPolicyFromConsensus(d.Policy),
+	}
+}
+
 func (s *SignedContributionAndProof) ToConsensus() (*eth.SignedContributionAndProof, error) {
 	msg, err := s.Message.ToConsensus()
 	if err != nil {
@@ -756,6 +819,30 @@ func (a *IndexedAttestationElectra) ToConsensus() (*eth.IndexedAttestationElectr
 	}, nil
 }
 
+func IndexedAttFromConsensus(a *eth.IndexedAttestation) *IndexedAttestation {
+	indices := make([]string, len(a.AttestingIndices))
+	for i, ix := range a.AttestingIndices {
+		indices[i] = fmt.Sprintf("%d", ix)
+	}
+	return &IndexedAttestation{
+		AttestingIndices: indices,
+		Data:             AttDataFromConsensus(a.Data),
+		Signature:        hexutil.Encode(a.Signature),
+	}
+}
+
+func IndexedAttElectraFromConsensus(a *eth.IndexedAttestationElectra) *IndexedAttestationElectra {
+	indices := make([]string, len(a.AttestingIndices))
+	for i, ix := range a.AttestingIndices {
+		indices[i] = fmt.Sprintf("%d", ix)
+	}
+	return &IndexedAttestationElectra{
+		AttestingIndices: indices,
+		Data:             AttDataFromConsensus(a.Data),
+		Signature:        hexutil.Encode(a.Signature),
+	}
+}
+
 func WithdrawalsFromConsensus(ws []*enginev1.Withdrawal) []*Withdrawal {
 	result := make([]*Withdrawal, len(ws))
 	for i, w := range ws {
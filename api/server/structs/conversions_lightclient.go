@@ -1,3 +1,359 @@
 package structs
 
-//
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+func (b *LightClientBootstrapResponse) ToConsensus() (*ethpbv2.LightClientBootstrap, error) {
+	if b.Data == nil {
+		return nil, errors.New("bootstrap data is empty")
+	}
+	v, err := version.FromString(b.Version)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Version")
+	}
+	header, err := lightClientHeaderToConsensus(b.Data.Header, v)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Data.Header")
+	}
+	committee, err := syncCommitteeToV2(b.Data.CurrentSyncCommittee)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Data.CurrentSyncCommittee")
+	}
+	branch, err := branchToConsensus(b.Data.CurrentSyncCommitteeBranch)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Data.CurrentSyncCommitteeBranch")
+	}
+	return &ethpbv2.LightClientBootstrap{
+		Header:                     header,
+		CurrentSyncCommittee:       committee,
+		CurrentSyncCommitteeBranch: branch,
+	}, nil
+}
+
+// ToConsensus converts the update to its consensus representation. The finalized header, the next
+// sync committee and their branches are optional, as they are absent from optimistic updates.
+func (u *LightClientUpdateWithVersion) ToConsensus() (*ethpbv2.LightClientUpdate, error) {
+	if u.Data == nil {
+		return nil, errors.New("update data is empty")
+	}
+	v, err := version.FromString(u.Version)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Version")
+	}
+	attestedHeader, err := lightClientHeaderToConsensus(u.Data.AttestedHeader, v)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Data.AttestedHeader")
+	}
+	if u.Data.SyncAggregate == nil {
+		return nil, server.NewDecodeError(errors.New("sync aggregate is empty"), "Data.SyncAggregate")
+	}
+	bits, err := bytesutil.DecodeHexWithLength(u.Data.SyncAggregate.SyncCommitteeBits, fieldparams.SyncAggregateSyncCommitteeBytesLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Data.SyncAggregate.SyncCommitteeBits")
+	}
+	sig, err := bytesutil.DecodeHexWithLength(u.Data.SyncAggregate.SyncCommitteeSignature, fieldparams.BLSSignatureLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Data.SyncAggregate.SyncCommitteeSignature")
+	}
+	signatureSlot, err := strconv.ParseUint(u.Data.SignatureSlot, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Data.SignatureSlot")
+	}
+	result := &ethpbv2.LightClientUpdate{
+		AttestedHeader: attestedHeader,
+		SyncAggregate: &ethpbv1.SyncAggregate{
+			SyncCommitteeBits:      bits,
+			SyncCommitteeSignature: sig,
+		},
+		SignatureSlot: primitives.Slot(signatureSlot),
+	}
+
+	if len(u.Data.FinalityBranch) != 0 {
+		result.FinalizedHeader, err = lightClientHeaderToConsensus(u.Data.FinalizedHeader, v)
+		if err != nil {
+			return nil, server.NewDecodeError(err, "Data.FinalizedHeader")
+		}
+		result.FinalityBranch, err = branchToConsensus(u.Data.FinalityBranch)
+		if err != nil {
+			return nil, server.NewDecodeError(err, "Data.FinalityBranch")
+		}
+	}
+	if len(u.Data.NextSyncCommitteeBranch) != 0 {
+		result.NextSyncCommittee, err = syncCommitteeToV2(u.Data.NextSyncCommittee)
+		if err != nil {
+			return nil, server.NewDecodeError(err, "Data.NextSyncCommittee")
+		}
+		result.NextSyncCommitteeBranch, err = branchToConsensus(u.Data.NextSyncCommitteeBranch)
+		if err != nil {
+			return nil, server.NewDecodeError(err, "Data.NextSyncCommitteeBranch")
+		}
+	}
+	return result, nil
+}
+
+func (h *ExecutionPayloadHeaderCapella) ToConsensus() (*enginev1.ExecutionPayloadHeaderCapella, error) {
+	parentHash, err := bytesutil.DecodeHexWithLength(h.ParentHash, common.HashLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "ParentHash")
+	}
+	feeRecipient, err := bytesutil.DecodeHexWithLength(h.FeeRecipient, fieldparams.FeeRecipientLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "FeeRecipient")
+	}
+	stateRoot, err := bytesutil.DecodeHexWithLength(h.StateRoot, fieldparams.RootLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "StateRoot")
+	}
+	receiptsRoot, err := bytesutil.DecodeHexWithLength(h.ReceiptsRoot, fieldparams.RootLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "ReceiptsRoot")
+	}
+	logsBloom, err := bytesutil.DecodeHexWithLength(h.LogsBloom, fieldparams.LogsBloomLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "LogsBloom")
+	}
+	prevRandao, err := bytesutil.DecodeHexWithLength(h.PrevRandao, fieldparams.RootLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "PrevRandao")
+	}
+	blockNumber, err := strconv.ParseUint(h.BlockNumber, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "BlockNumber")
+	}
+	gasLimit, err := strconv.ParseUint(h.GasLimit, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "GasLimit")
+	}
+	gasUsed, err := strconv.ParseUint(h.GasUsed, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "GasUsed")
+	}
+	timestamp, err := strconv.ParseUint(h.Timestamp, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Timestamp")
+	}
+	extraData, err := bytesutil.DecodeHexWithMaxLength(h.ExtraData, fieldparams.RootLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "ExtraData")
+	}
+	baseFeePerGas, err := bytesutil.Uint256ToSSZBytes(h.BaseFeePerGas)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "BaseFeePerGas")
+	}
+	blockHash, err := bytesutil.DecodeHexWithLength(h.BlockHash, common.HashLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "BlockHash")
+	}
+	transactionsRoot, err := bytesutil.DecodeHexWithLength(h.TransactionsRoot, fieldparams.RootLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "TransactionsRoot")
+	}
+	withdrawalsRoot, err := bytesutil.DecodeHexWithLength(h.WithdrawalsRoot, fieldparams.RootLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "WithdrawalsRoot")
+	}
+	return &enginev1.ExecutionPayloadHeaderCapella{
+		ParentHash:       parentHash,
+		FeeRecipient:     feeRecipient,
+		StateRoot:        stateRoot,
+		ReceiptsRoot:     receiptsRoot,
+		LogsBloom:        logsBloom,
+		PrevRandao:       prevRandao,
+		BlockNumber:      blockNumber,
+		GasLimit:         gasLimit,
+		GasUsed:          gasUsed,
+		Timestamp:        timestamp,
+		ExtraData:        extraData,
+		BaseFeePerGas:    baseFeePerGas,
+		BlockHash:        blockHash,
+		TransactionsRoot: transactionsRoot,
+		WithdrawalsRoot:  withdrawalsRoot,
+	}, nil
+}
+
+func (h *ExecutionPayloadHeaderDeneb) ToConsensus() (*enginev1.ExecutionPayloadHeaderDeneb, error) {
+	capella, err := (&ExecutionPayloadHeaderCapella{
+		ParentHash:       h.ParentHash,
+		FeeRecipient:     h.FeeRecipient,
+		StateRoot:        h.StateRoot,
+		ReceiptsRoot:     h.ReceiptsRoot,
+		LogsBloom:        h.LogsBloom,
+		PrevRandao:       h.PrevRandao,
+		BlockNumber:      h.BlockNumber,
+		GasLimit:         h.GasLimit,
+		GasUsed:          h.GasUsed,
+		Timestamp:        h.Timestamp,
+		ExtraData:        h.ExtraData,
+		BaseFeePerGas:    h.BaseFeePerGas,
+		BlockHash:        h.BlockHash,
+		TransactionsRoot: h.TransactionsRoot,
+		WithdrawalsRoot:  h.WithdrawalsRoot,
+	}).ToConsensus()
+	if err != nil {
+		return nil, err
+	}
+	blobGasUsed, err := strconv.ParseUint(h.BlobGasUsed, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "BlobGasUsed")
+	}
+	excessBlobGas, err := strconv.ParseUint(h.ExcessBlobGas, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "ExcessBlobGas")
+	}
+	return &enginev1.ExecutionPayloadHeaderDeneb{
+		ParentHash:       capella.ParentHash,
+		FeeRecipient:     capella.FeeRecipient,
+		StateRoot:        capella.StateRoot,
+		ReceiptsRoot:     capella.ReceiptsRoot,
+		LogsBloom:        capella.LogsBloom,
+		PrevRandao:       capella.PrevRandao,
+		BlockNumber:      capella.BlockNumber,
+		GasLimit:         capella.GasLimit,
+		GasUsed:          capella.GasUsed,
+		Timestamp:        capella.Timestamp,
+		ExtraData:        capella.ExtraData,
+		BaseFeePerGas:    capella.BaseFeePerGas,
+		BlockHash:        capella.BlockHash,
+		TransactionsRoot: capella.TransactionsRoot,
+		WithdrawalsRoot:  capella.WithdrawalsRoot,
+		BlobGasUsed:      blobGasUsed,
+		ExcessBlobGas:    excessBlobGas,
+	}, nil
+}
+
+// lightClientHeaderToConsensus decodes a light client header of the given fork version. Headers of
+// Capella and later forks that are served without an execution payload are decoded as Altair headers.
+func lightClientHeaderToConsensus(raw json.RawMessage, v int) (*ethpbv2.LightClientHeaderContainer, error) {
+	if len(raw) == 0 {
+		return nil, errors.New("header is empty")
+	}
+	switch {
+	case v < version.Altair:
+		return nil, fmt.Errorf("light client headers are not supported for %s", version.String(v))
+	case v >= version.Deneb:
+		h := &LightClientHeaderDeneb{}
+		if err := json.Unmarshal(raw, h); err != nil {
+			return nil, err
+		}
+		if h.Execution == nil {
+			return altairLightClientHeaderToConsensus(h.Beacon)
+		}
+		beacon, err := beaconBlockHeaderToV1(h.Beacon)
+		if err != nil {
+			return nil, err
+		}
+		execution, err := h.Execution.ToConsensus()
+		if err != nil {
+			return nil, server.NewDecodeError(err, "Execution")
+		}
+		branch, err := branchToConsensus(h.ExecutionBranch)
+		if err != nil {
+			return nil, server.NewDecodeError(err, "ExecutionBranch")
+		}
+		return &ethpbv2.LightClientHeaderContainer{
+			Header: &ethpbv2.LightClientHeaderContainer_HeaderDeneb{
+				HeaderDeneb: &ethpbv2.LightClientHeaderDeneb{Beacon: beacon, Execution: execution, ExecutionBranch: branch},
+			},
+		}, nil
+	case v >= version.Capella:
+		h := &LightClientHeaderCapella{}
+		if err := json.Unmarshal(raw, h); err != nil {
+			return nil, err
+		}
+		if h.Execution == nil {
+			return altairLightClientHeaderToConsensus(h.Beacon)
+		}
+		beacon, err := beaconBlockHeaderToV1(h.Beacon)
+		if err != nil {
+			return nil, err
+		}
+		execution, err := h.Execution.ToConsensus()
+		if err != nil {
+			return nil, server.NewDecodeError(err, "Execution")
+		}
+		branch, err := branchToConsensus(h.ExecutionBranch)
+		if err != nil {
+			return nil, server.NewDecodeError(err, "ExecutionBranch")
+		}
+		return &ethpbv2.LightClientHeaderContainer{
+			Header: &ethpbv2.LightClientHeaderContainer_HeaderCapella{
+				HeaderCapella: &ethpbv2.LightClientHeaderCapella{Beacon: beacon, Execution: execution, ExecutionBranch: branch},
+			},
+		}, nil
+	default:
+		h := &LightClientHeader{}
+		if err := json.Unmarshal(raw, h); err != nil {
+			return nil, err
+		}
+		return altairLightClientHeaderToConsensus(h.Beacon)
+	}
+}
+
+func altairLightClientHeaderToConsensus(h *BeaconBlockHeader) (*ethpbv2.LightClientHeaderContainer, error) {
+	beacon, err := beaconBlockHeaderToV1(h)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpbv2.LightClientHeaderContainer{
+		Header: &ethpbv2.LightClientHeaderContainer_HeaderAltair{
+			HeaderAltair: &ethpbv2.LightClientHeader{Beacon: beacon},
+		},
+	}, nil
+}
+
+func beaconBlockHeaderToV1(h *BeaconBlockHeader) (*ethpbv1.BeaconBlockHeader, error) {
+	if h == nil {
+		return nil, server.NewDecodeError(errors.New("beacon header is empty"), "Beacon")
+	}
+	header, err := h.ToConsensus()
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Beacon")
+	}
+	return &ethpbv1.BeaconBlockHeader{
+		Slot:          header.Slot,
+		ProposerIndex: header.ProposerIndex,
+		ParentRoot:    header.ParentRoot,
+		StateRoot:     header.StateRoot,
+		BodyRoot:      header.BodyRoot,
+	}, nil
+}
+
+func syncCommitteeToV2(sc *SyncCommittee) (*ethpbv2.SyncCommittee, error) {
+	if sc == nil {
+		return nil, errors.New("sync committee is empty")
+	}
+	committee, err := sc.ToConsensus()
+	if err != nil {
+		return nil, err
+	}
+	return &ethpbv2.SyncCommittee{
+		Pubkeys:         committee.Pubkeys,
+		AggregatePubkey: committee.AggregatePubkey,
+	}, nil
+}
+
+func branchToConsensus(branch []string) ([][]byte, error) {
+	result := make([][]byte, len(branch))
+	for i, node := range branch {
+		b, err := bytesutil.DecodeHexWithLength(node, fieldparams.RootLength)
+		if err != nil {
+			return nil, server.NewDecodeError(err, fmt.Sprintf("[%d]", i))
+		}
+		result[i] = b
+	}
+	return result, nil
+}
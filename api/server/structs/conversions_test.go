@@ -1,8 +1,14 @@
 package structs
 
 import (
+	"bytes"
+	"encoding/json"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 )
@@ -24,3 +30,74 @@ func TestDepositSnapshotFromConsensus(t *testing.T) {
 	require.Equal(t, "0x1234", res.ExecutionBlockHash)
 	require.Equal(t, "67890", res.ExecutionBlockHeight)
 }
+
+func TestLightClientUpdateWithVersion_ToConsensus(t *testing.T) {
+	root := hexutil.Encode(bytes.Repeat([]byte{1}, fieldparams.RootLength))
+	beacon := &BeaconBlockHeader{Slot: "10", ProposerIndex: "1", ParentRoot: root, StateRoot: root, BodyRoot: root}
+	execution, err := ExecutionPayloadHeaderDenebFromConsensus(&enginev1.ExecutionPayloadHeaderDeneb{
+		ParentHash:       bytes.Repeat([]byte{1}, fieldparams.RootLength),
+		FeeRecipient:     bytes.Repeat([]byte{2}, fieldparams.FeeRecipientLength),
+		StateRoot:        bytes.Repeat([]byte{3}, fieldparams.RootLength),
+		ReceiptsRoot:     bytes.Repeat([]byte{4}, fieldparams.RootLength),
+		LogsBloom:        bytes.Repeat([]byte{5}, fieldparams.LogsBloomLength),
+		PrevRandao:       bytes.Repeat([]byte{6}, fieldparams.RootLength),
+		BlockNumber:      7,
+		GasLimit:         8,
+		GasUsed:          9,
+		Timestamp:        10,
+		ExtraData:        []byte{11},
+		BaseFeePerGas:    bytes.Repeat([]byte{12}, fieldparams.RootLength),
+		BlockHash:        bytes.Repeat([]byte{13}, fieldparams.RootLength),
+		TransactionsRoot: bytes.Repeat([]byte{14}, fieldparams.RootLength),
+		WithdrawalsRoot:  bytes.Repeat([]byte{15}, fieldparams.RootLength),
+		BlobGasUsed:      16,
+		ExcessBlobGas:    17,
+	})
+	require.NoError(t, err)
+	attested, err := json.Marshal(&LightClientHeaderDeneb{Beacon: beacon, Execution: execution, ExecutionBranch: []string{root, root, root, root}})
+	require.NoError(t, err)
+	finalized, err := json.Marshal(&LightClientHeader{Beacon: beacon})
+	require.NoError(t, err)
+
+	update := &LightClientUpdateWithVersion{
+		Version: "deneb",
+		Data: &LightClientUpdate{
+			AttestedHeader:  attested,
+			FinalizedHeader: finalized,
+			FinalityBranch:  []string{root, root, root, root, root, root},
+			SyncAggregate: &SyncAggregate{
+				SyncCommitteeBits:      hexutil.Encode(make([]byte, fieldparams.SyncAggregateSyncCommitteeBytesLength)),
+				SyncCommitteeSignature: hexutil.Encode(make([]byte, fieldparams.BLSSignatureLength)),
+			},
+			SignatureSlot: "11",
+		},
+	}
+	result, err := update.ToConsensus()
+	require.NoError(t, err)
+	require.Equal(t, uint64(16), result.AttestedHeader.GetHeaderDeneb().Execution.BlobGasUsed)
+	require.Equal(t, 4, len(result.AttestedHeader.GetHeaderDeneb().ExecutionBranch))
+	// Headers served without an execution payload are decoded as Altair headers.
+	require.Equal(t, primitives.Slot(10), result.FinalizedHeader.GetHeaderAltair().Beacon.Slot)
+	require.Equal(t, 6, len(result.FinalityBranch))
+	require.Equal(t, true, result.NextSyncCommittee == nil)
+	require.Equal(t, primitives.Slot(11), result.SignatureSlot)
+
+	update.Version = "phase0"
+	_, err = update.ToConsensus()
+	require.ErrorContains(t, "not supported", err)
+}
+
+func TestBuilderPolicy_RoundTrip(t *testing.T) {
+	boost, maxMissed := uint64(10), uint64(0)
+	p := &eth.BuilderPolicy{LocalBlockValueBoost: &boost, MaxBuilderEpochMissedSlots: &maxMissed}
+	s := BuilderPolicyFromConsensus(p)
+	require.Equal(t, "10", s.LocalBlockValueBoost)
+	require.Equal(t, "", s.MinBuilderBid)
+	require.Equal(t, "0", s.MaxBuilderEpochMissedSlots)
+	got, err := s.ToConsensus()
+	require.NoError(t, err)
+	require.DeepEqual(t, p, got)
+
+	_, err = (&BuilderPolicy{MinBuilderBid: "foo"}).ToConsensus()
+	require.ErrorContains(t, "MinBuilderBid", err)
+}
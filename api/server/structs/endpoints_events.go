@@ -48,6 +48,15 @@ type ChainReorgEvent struct {
 	ExecutionOptimistic bool   `json:"execution_optimistic"`
 }
 
+type DutiesInvalidatedEvent struct {
+	Epoch                 string `json:"epoch"`
+	ProposerDutiesChanged bool   `json:"proposer_duties_changed"`
+	AttesterDutiesChanged bool   `json:"attester_duties_changed"`
+	ProposerDependentRoot string `json:"proposer_dependent_root"`
+	AttesterDependentRoot string `json:"attester_dependent_root"`
+	NewHeadBlock          string `json:"new_head_block"`
+}
+
 type PayloadAttributesEvent struct {
 	Version string          `json:"version"`
 	Data    json.RawMessage `json:"data"`
@@ -91,6 +100,54 @@ type BlobSidecarEvent struct {
 	VersionedHash string `json:"versioned_hash"`
 }
 
+type BlockGossipEvent struct {
+	Slot          string `json:"slot"`
+	Block         string `json:"block"`
+	ProposerIndex string `json:"proposer_index"`
+}
+
+type BlobSidecarAvailabilityEvent struct {
+	Slot          string `json:"slot"`
+	Block         string `json:"block"`
+	ProposerIndex string `json:"proposer_index"`
+	BlobCount     string `json:"blob_count"`
+	WaitedTimeMs  string `json:"waited_time_ms"`
+}
+
+type DataColumnAvailabilityEvent struct {
+	Slot          string   `json:"slot"`
+	Block         string   `json:"block"`
+	ProposerIndex string   `json:"proposer_index"`
+	Columns       []string `json:"columns"`
+	WaitedTimeMs  string   `json:"waited_time_ms"`
+}
+
+type ValidatorStatusChangeEvent struct {
+	ValidatorIndex string `json:"validator_index"`
+	Epoch          string `json:"epoch"`
+	PreviousStatus string `json:"previous_status"`
+	Status         string `json:"status"`
+}
+
+type JustifiedCheckpointEvent struct {
+	Block string `json:"block"`
+	Epoch string `json:"epoch"`
+}
+
+type ExecutionPayloadInvalidEvent struct {
+	Slot            string `json:"slot"`
+	Block           string `json:"block"`
+	ProposerIndex   string `json:"proposer_index"`
+	BlockHash       string `json:"block_hash"`
+	LatestValidHash string `json:"latest_valid_hash"`
+}
+
+// SlasherAttestationEvent holds an IndexedAttestation, or an IndexedAttestationElectra from Electra on.
+type SlasherAttestationEvent struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
 type LightClientFinalityUpdateEvent struct {
 	Version string                     `json:"version"`
 	Data    *LightClientFinalityUpdate `json:"data"`
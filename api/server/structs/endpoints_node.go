@@ -71,3 +71,61 @@ type AddrRequest struct {
 type PeersResponse struct {
 	Peers []*Peer `json:"peers"`
 }
+
+type BanPeerRequest struct {
+	PeerId   string `json:"peer_id"`
+	Duration string `json:"duration"`
+	Reason   string `json:"reason"`
+}
+
+type PeerReputationsResponse struct {
+	Data []*PeerReputation `json:"data"`
+}
+
+type PeerReputation struct {
+	PeerId             string     `json:"peer_id"`
+	Enr                string     `json:"enr"`
+	LastSeenP2PAddress string     `json:"last_seen_p2p_address"`
+	State              string     `json:"state"`
+	LastSeen           string     `json:"last_seen"`
+	Score              string     `json:"score"`
+	BadResponses       string     `json:"bad_responses"`
+	ProcessedBlocks    string     `json:"processed_blocks"`
+	GossipScore        string     `json:"gossip_score"`
+	BehaviourPenalty   string     `json:"behaviour_penalty"`
+	Banned             bool       `json:"banned"`
+	Bans               []*PeerBan `json:"bans"`
+}
+
+type PeerBan struct {
+	Reason string `json:"reason"`
+	Start  string `json:"start"`
+	Until  string `json:"until"`
+	Lifted string `json:"lifted"`
+}
+
+type PeerBandwidthResponse struct {
+	Data []*PeerBandwidth `json:"data"`
+}
+
+type PeerBandwidth struct {
+	PeerId          string                       `json:"peer_id"`
+	Class           string                       `json:"class"`
+	RpcQuota        string                       `json:"rpc_quota"`
+	GossipQuota     string                       `json:"gossip_quota"`
+	RpcLoad         string                       `json:"rpc_load"`
+	GossipLoad      string                       `json:"gossip_load"`
+	LastRpcLoad     string                       `json:"last_rpc_load"`
+	LastGossipLoad  string                       `json:"last_gossip_load"`
+	BytesIn         string                       `json:"bytes_in"`
+	BytesOut        string                       `json:"bytes_out"`
+	Violations      string                       `json:"violations"`
+	QuotaViolations string                       `json:"quota_violations"`
+	Protocols       map[string]*BandwidthTraffic `json:"protocols"`
+	Topics          map[string]*BandwidthTraffic `json:"topics"`
+}
+
+type BandwidthTraffic struct {
+	BytesIn  string `json:"bytes_in"`
+	BytesOut string `json:"bytes_out"`
+}
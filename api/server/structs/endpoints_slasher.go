@@ -0,0 +1,34 @@
+package structs
+
+type GetSlasherSpansResponse struct {
+	Data []*SlasherEpochSpans `json:"data"`
+}
+
+type SlasherEpochSpans struct {
+	Epoch   string `json:"epoch"`
+	MinSpan string `json:"min_span"`
+	MaxSpan string `json:"max_span"`
+}
+
+type GetSlasherAttestationsResponse struct {
+	Data []*SlasherAttestationRecord `json:"data"`
+}
+
+type SlasherAttestationRecord struct {
+	DataRoot    string              `json:"data_root"`
+	Attestation *IndexedAttestation `json:"attestation"`
+}
+
+type GetSlasherSlashingsResponse struct {
+	Data *SlasherSlashings `json:"data"`
+}
+
+type SlasherSlashings struct {
+	AttesterSlashings []*AttesterSlashing `json:"attester_slashings"`
+	ProposerSlashings []*ProposerSlashing `json:"proposer_slashings"`
+}
+
+type CheckSlasherAttestationResponse struct {
+	Slashable bool                `json:"slashable"`
+	Data      []*AttesterSlashing `json:"data"`
+}
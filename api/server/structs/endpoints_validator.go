@@ -73,6 +73,21 @@ type SyncCommitteeDuty struct {
 	ValidatorSyncCommitteeIndices []string `json:"validator_sync_committee_indices"`
 }
 
+type GetDutiesLookaheadResponse struct {
+	ExecutionOptimistic bool           `json:"execution_optimistic"`
+	Data                []*EpochDuties `json:"data"`
+}
+
+type EpochDuties struct {
+	Epoch                 string               `json:"epoch"`
+	ProposerDependentRoot string               `json:"proposer_dependent_root"`
+	AttesterDependentRoot string               `json:"attester_dependent_root"`
+	ProposerDuties        []*ProposerDuty      `json:"proposer_duties"`
+	AttesterDuties        []*AttesterDuty      `json:"attester_duties"`
+	SyncCommitteeDuties   []*SyncCommitteeDuty `json:"sync_committee_duties"`
+	Tentative             bool                 `json:"tentative"`
+}
+
 // ProduceBlockV3Response is a wrapper json object for the returned block from the ProduceBlockV3 endpoint
 type ProduceBlockV3Response struct {
 	Version                 string          `json:"version"`
@@ -149,3 +164,27 @@ type ActiveSetChanges struct {
 	EjectedPublicKeys   []string `json:"ejected_public_keys"`
 	EjectedIndices      []string `json:"ejected_indices"`
 }
+
+type GetProposalDecisionsResponse struct {
+	Data []*ProposalDecision `json:"data"`
+}
+
+type ProposalDecision struct {
+	Slot               string         `json:"slot"`
+	ProposerIndex      string         `json:"proposer_index"`
+	BuilderSelected    bool           `json:"builder_selected"`
+	Reason             string         `json:"reason"`
+	BuilderValueGwei   string         `json:"builder_value_gwei"`
+	LocalValueGwei     string         `json:"local_value_gwei"`
+	BuilderBoostFactor string         `json:"builder_boost_factor"`
+	Relay              string         `json:"relay,omitempty"`
+	Policy             *BuilderPolicy `json:"policy"`
+}
+
+type BuilderPolicy struct {
+	LocalBlockValueBoost             string `json:"local_block_value_boost,omitempty"`
+	MinBuilderBid                    string `json:"min_builder_bid,omitempty"`
+	MinBuilderToLocalDifference      string `json:"min_builder_to_local_difference,omitempty"`
+	MaxBuilderConsecutiveMissedSlots string `json:"max_builder_consecutive_missed_slots,omitempty"`
+	MaxBuilderEpochMissedSlots       string `json:"max_builder_epoch_missed_slots,omitempty"`
+}
@@ -61,7 +61,12 @@ func (s *Service) ForkChoiceDump(ctx context.Context) (*forkchoice.Dump, error)
 func (s *Service) NewSlot(ctx context.Context, slot primitives.Slot) error {
 	s.cfg.ForkChoiceStore.Lock()
 	defer s.cfg.ForkChoiceStore.Unlock()
-	return s.cfg.ForkChoiceStore.NewSlot(ctx, slot)
+	justified := *s.cfg.ForkChoiceStore.JustifiedCheckpoint()
+	if err := s.cfg.ForkChoiceStore.NewSlot(ctx, slot); err != nil {
+		return err
+	}
+	s.notifyJustifiedCheckpointChange(justified)
+	return nil
 }
 
 // ProposerBoost wraps the corresponding method from forkchoice
@@ -9,6 +9,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
@@ -89,6 +91,7 @@ func (s *Service) notifyForkchoiceUpdate(ctx context.Context, arg *fcuConfig) (*
 			if len(lastValidHash) == 0 {
 				lastValidHash = defaultLatestValidHash
 			}
+			s.sendInvalidPayloadEvent(headBlk, headRoot, headPayload.BlockHash(), bytesutil.ToBytes32(lastValidHash))
 			invalidRoots, err := s.cfg.ForkChoiceStore.SetOptimisticToInvalid(ctx, headRoot, headBlk.ParentRoot(), bytesutil.ToBytes32(lastValidHash))
 			if err != nil {
 				log.WithError(err).Error("Could not set head root to invalid")
@@ -243,6 +246,12 @@ func (s *Service) notifyNewPayload(ctx context.Context, preStateVersion int,
 		return false, nil
 	case errors.Is(err, execution.ErrInvalidPayloadStatus):
 		lvh := bytesutil.ToBytes32(lastValidHash)
+		root, err := blk.Block().HashTreeRoot()
+		if err != nil {
+			log.WithError(err).Error("Could not compute root of block with invalid payload")
+		} else {
+			s.sendInvalidPayloadEvent(blk.Block(), root, payload.BlockHash(), lvh)
+		}
 		return false, invalidBlock{
 			error:         ErrInvalidPayload,
 			lastValidHash: lvh,
@@ -252,6 +261,20 @@ func (s *Service) notifyNewPayload(ctx context.Context, preStateVersion int,
 	}
 }
 
+// sendInvalidPayloadEvent notifies that the execution layer reported the payload of the block as invalid.
+func (s *Service) sendInvalidPayloadEvent(blk interfaces.ReadOnlyBeaconBlock, root [32]byte, blockHash []byte, lvh [32]byte) {
+	s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.InvalidExecutionPayload,
+		Data: &statefeed.InvalidExecutionPayloadData{
+			Slot:            blk.Slot(),
+			BlockRoot:       root,
+			ProposerIndex:   blk.ProposerIndex(),
+			BlockHash:       bytesutil.ToBytes32(blockHash),
+			LatestValidHash: lvh,
+		},
+	})
+}
+
 // reportInvalidBlock deals with the event that an invalid block was detected by the execution layer
 func (s *Service) pruneInvalidBlock(ctx context.Context, root, parentRoot, lvh [32]byte) error {
 	newPayloadInvalidNodeCount.Inc()
@@ -0,0 +1,47 @@
+package blockchain
+
+import (
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/recorder"
+)
+
+// startForkchoiceRecording records the inputs of fork choice to the configured file, if any.
+// It must be called once fork choice has been initialized.
+func (s *Service) startForkchoiceRecording() {
+	if s.cfg.ForkchoiceRecordingFile == "" {
+		return
+	}
+	r, err := recorder.NewFileRecorder(s.cfg.ForkchoiceRecordingFile)
+	if err != nil {
+		log.WithError(err).Error("Could not start fork choice recording")
+		return
+	}
+	s.cfg.ForkChoiceStore.Lock()
+	defer s.cfg.ForkChoiceStore.Unlock()
+	if err := s.cfg.ForkChoiceStore.SetRecorder(s.ctx, r); err != nil {
+		log.WithError(err).Error("Could not start fork choice recording")
+		if err := r.Close(); err != nil {
+			log.WithError(err).Error("Could not close fork choice recording")
+		}
+		return
+	}
+	s.forkchoiceRecorder = r
+	log.WithField("file", s.cfg.ForkchoiceRecordingFile).Warn("Recording fork choice, the recording file grows quickly")
+}
+
+// stopForkchoiceRecording stops recording fork choice and closes the recording file.
+func (s *Service) stopForkchoiceRecording() error {
+	if s.cfg.ForkchoiceRecordingFile == "" {
+		return nil
+	}
+	s.cfg.ForkChoiceStore.Lock()
+	defer s.cfg.ForkChoiceStore.Unlock()
+	if s.forkchoiceRecorder == nil {
+		return nil
+	}
+	if err := s.cfg.ForkChoiceStore.SetRecorder(s.ctx, nil); err != nil {
+		return err
+	}
+	r := s.forkchoiceRecorder
+	s.forkchoiceRecorder = nil
+	return r.Close()
+}
@@ -0,0 +1,58 @@
+package blockchain
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/recorder"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestService_ForkchoiceRecording(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "forkchoice.jsonl")
+	service, tr := minimalTestService(t, WithForkchoiceRecordingFile(path))
+	ctx, fcs := tr.ctx, tr.fcs
+
+	zeroCheckpoint := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	st, root, err := prepareForkchoiceState(ctx, 0, [32]byte{'a'}, [32]byte{}, [32]byte{'A'}, zeroCheckpoint, zeroCheckpoint)
+	require.NoError(t, err)
+	require.NoError(t, fcs.InsertNode(ctx, st, root))
+
+	service.startForkchoiceRecording()
+	st, root, err = prepareForkchoiceState(ctx, 1, [32]byte{'b'}, [32]byte{'a'}, [32]byte{'B'}, zeroCheckpoint, zeroCheckpoint)
+	require.NoError(t, err)
+	fcs.Lock()
+	require.NoError(t, fcs.InsertNode(ctx, st, root))
+	fcs.ProcessAttestation(ctx, []uint64{1}, root, 0)
+	fcs.Unlock()
+	require.NoError(t, service.stopForkchoiceRecording())
+	// Fork choice is not recorded anymore.
+	fcs.ProcessAttestation(ctx, []uint64{2}, root, 0)
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	r := recorder.NewReader(f)
+	var kinds []forkchoicetypes.EventKind
+	for {
+		e, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		kinds = append(kinds, e.Kind)
+	}
+	assert.DeepEqual(t, []forkchoicetypes.EventKind{
+		forkchoicetypes.EventAnchor,
+		forkchoicetypes.EventBlock,
+		forkchoicetypes.EventAttestation,
+	}, kinds)
+}
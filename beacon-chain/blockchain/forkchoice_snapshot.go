@@ -0,0 +1,108 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// saveForkchoiceSnapshot saves a copy of fork choice to the database, replacing the previous one.
+func (s *Service) saveForkchoiceSnapshot(ctx context.Context) error {
+	s.cfg.ForkChoiceStore.RLock()
+	snapshot, err := s.cfg.ForkChoiceStore.Snapshot(ctx)
+	s.cfg.ForkChoiceStore.RUnlock()
+	if err != nil {
+		return errors.Wrap(err, "could not snapshot fork choice")
+	}
+	if err := s.cfg.BeaconDB.SaveForkchoiceSnapshot(ctx, snapshot); err != nil {
+		return errors.Wrap(err, "could not save fork choice snapshot")
+	}
+	log.WithField("nodeCount", len(snapshot.Nodes)).Debug("Saved fork choice snapshot")
+	return nil
+}
+
+// runForkchoiceSnapshotRoutine saves fork choice to the database at the start of every epoch.
+func (s *Service) runForkchoiceSnapshotRoutine() {
+	clock, err := s.clockWaiter.WaitForClock(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Fork choice snapshot routine failed to receive genesis data")
+		return
+	}
+	ticker := slots.NewSlotTicker(clock.GenesisTime(), params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
+	for {
+		select {
+		case slot := <-ticker.C():
+			if !slots.IsEpochStart(slot) {
+				continue
+			}
+			if err := s.saveForkchoiceSnapshot(s.ctx); err != nil {
+				log.WithError(err).Error("Could not save fork choice snapshot")
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting routine")
+			return
+		}
+	}
+}
+
+// restoreForkchoiceSnapshot loads the fork choice snapshot saved in the database if it is consistent with the
+// finalized checkpoint and the head of the database, and if every block it contains is in the database.
+// It returns false when fork choice must instead be initialized from the finalized checkpoint.
+// The caller must hold the fork choice lock.
+func (s *Service) restoreForkchoiceSnapshot(ctx context.Context, finalized *ethpb.Checkpoint) (bool, error) {
+	snapshot, err := s.cfg.BeaconDB.ForkchoiceSnapshot(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "could not get fork choice snapshot")
+	}
+	fc := snapshot.FinalizedCheckpoint
+	if fc == nil || fc.Epoch != uint64(finalized.Epoch) || !bytes.Equal(fc.Root, finalized.Root) {
+		log.Info("Fork choice snapshot is older than the finalized checkpoint, ignoring it")
+		return false, nil
+	}
+	if snapshot.GenesisTime != uint64(s.genesisTime.Unix()) {
+		log.Warn("Fork choice snapshot is for a different genesis time, ignoring it")
+		return false, nil
+	}
+	headBlock, err := s.cfg.BeaconDB.HeadBlock(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "could not get head block")
+	}
+	var headRoot [32]byte
+	if headBlock != nil && !headBlock.IsNil() {
+		headRoot, err = headBlock.Block().HashTreeRoot()
+		if err != nil {
+			return false, errors.Wrap(err, "could not get head block root")
+		}
+	}
+	headFound := false
+	for _, n := range snapshot.Nodes {
+		root := bytesutil.ToBytes32(n.Root)
+		if root == headRoot {
+			headFound = true
+		}
+		if !s.cfg.BeaconDB.HasBlock(ctx, root) {
+			log.WithField("root", fmt.Sprintf("%#x", root)).Info("Block of the fork choice snapshot is missing from the database, ignoring it")
+			return false, nil
+		}
+	}
+	if !headFound {
+		log.Info("Fork choice snapshot does not contain the head block of the database, ignoring it")
+		return false, nil
+	}
+	if err := s.cfg.ForkChoiceStore.RestoreSnapshot(ctx, snapshot); err != nil {
+		return false, errors.Wrap(err, "could not restore fork choice snapshot")
+	}
+	log.WithField("nodeCount", len(snapshot.Nodes)).Info("Restored fork choice from snapshot")
+	return true, nil
+}
@@ -0,0 +1,73 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestService_ForkchoiceSnapshot_SaveAndRestore(t *testing.T) {
+	service, tr := minimalTestService(t)
+	ctx, beaconDB, fcs := tr.ctx, tr.db, tr.fcs
+	service.genesisTime = time.Unix(1000, 0)
+	fcs.SetGenesisTime(1000)
+
+	zeroCheckpoint := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	genesis := util.NewBeaconBlock()
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	head := util.NewBeaconBlock()
+	head.Block.Slot = 1
+	head.Block.ParentRoot = bytesutil.PadTo(genesisRoot[:], 32)
+	headRoot, err := head.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, head)
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 1, Root: headRoot[:]}))
+	require.NoError(t, beaconDB.SaveHeadBlockRoot(ctx, headRoot))
+
+	st, root, err := prepareForkchoiceState(ctx, 0, genesisRoot, [32]byte{}, [32]byte{'g'}, zeroCheckpoint, zeroCheckpoint)
+	require.NoError(t, err)
+	require.NoError(t, fcs.InsertNode(ctx, st, root))
+	st, root, err = prepareForkchoiceState(ctx, 1, headRoot, genesisRoot, [32]byte{'h'}, zeroCheckpoint, zeroCheckpoint)
+	require.NoError(t, err)
+	require.NoError(t, fcs.InsertNode(ctx, st, root))
+
+	// Nothing saved yet.
+	restored, err := service.restoreForkchoiceSnapshot(ctx, zeroCheckpoint)
+	require.NoError(t, err)
+	assert.Equal(t, false, restored)
+
+	require.NoError(t, service.saveForkchoiceSnapshot(ctx))
+	snapshot, err := beaconDB.ForkchoiceSnapshot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(snapshot.Nodes))
+
+	// A snapshot older than the finalized checkpoint is ignored.
+	restored, err = service.restoreForkchoiceSnapshot(ctx, &ethpb.Checkpoint{Epoch: 1, Root: headRoot[:]})
+	require.NoError(t, err)
+	assert.Equal(t, false, restored)
+
+	service.cfg.ForkChoiceStore.Lock()
+	restored, err = service.restoreForkchoiceSnapshot(ctx, zeroCheckpoint)
+	service.cfg.ForkChoiceStore.Unlock()
+	require.NoError(t, err)
+	assert.Equal(t, true, restored)
+	assert.Equal(t, 2, fcs.NodeCount())
+	assert.Equal(t, true, fcs.HasNode(headRoot))
+
+	// A snapshot with a block that is not in the database is ignored.
+	st, root, err = prepareForkchoiceState(ctx, 2, [32]byte{'m'}, headRoot, [32]byte{'m'}, zeroCheckpoint, zeroCheckpoint)
+	require.NoError(t, err)
+	require.NoError(t, fcs.InsertNode(ctx, st, root))
+	require.NoError(t, service.saveForkchoiceSnapshot(ctx))
+	restored, err = service.restoreForkchoiceSnapshot(ctx, zeroCheckpoint)
+	require.NoError(t, err)
+	assert.Equal(t, false, restored)
+}
@@ -37,6 +37,14 @@ func WithWeakSubjectivityCheckpoint(c *ethpb.Checkpoint) Option {
 	}
 }
 
+// WithForkchoiceRecordingFile to record the inputs of fork choice to the given file.
+func WithForkchoiceRecordingFile(path string) Option {
+	return func(s *Service) error {
+		s.cfg.ForkchoiceRecordingFile = path
+		return nil
+	}
+}
+
 // WithDatabase for head access.
 func WithDatabase(beaconDB db.HeadAccessDatabase) Option {
 	return func(s *Service) error {
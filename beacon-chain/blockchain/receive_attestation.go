@@ -101,9 +101,12 @@ func (s *Service) spawnProcessAttestationsRoutine() {
 					}
 				} else {
 					s.cfg.ForkChoiceStore.Lock()
+					justified := *s.cfg.ForkChoiceStore.JustifiedCheckpoint()
 					if err := s.cfg.ForkChoiceStore.NewSlot(s.ctx, slotInterval.Slot); err != nil {
 						log.WithError(err).Error("could not process new slot")
 					}
+					// The unrealized justification of the previous epoch is realized on the first slot of an epoch.
+					s.notifyJustifiedCheckpointChange(justified)
 					s.cfg.ForkChoiceStore.Unlock()
 
 					s.UpdateHead(s.ctx, slotInterval.Slot)
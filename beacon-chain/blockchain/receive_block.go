@@ -13,6 +13,7 @@ import (
 	coreTime "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
@@ -116,7 +117,7 @@ func (s *Service) ReceiveBlock(ctx context.Context, block interfaces.ReadOnlySig
 		return err
 	}
 	// If slasher is configured, forward the attestations in the block via an event feed for processing.
-	if features.Get().EnableSlasher {
+	if features.Get().EnableSlasher || features.Get().EnableSlasherStream {
 		go s.sendBlockAttestationsToSlasher(blockCopy, preState)
 	}
 
@@ -238,9 +239,31 @@ func (s *Service) handleDA(
 	}
 	daWaitedTime := time.Since(daStartTime)
 	dataAvailWaitedTime.Observe(float64(daWaitedTime.Milliseconds()))
+	s.sendDataAvailableEvent(block, blockRoot, daWaitedTime)
 	return daWaitedTime, nil
 }
 
+// sendDataAvailableEvent notifies that all the blob sidecars committed to in the block are available.
+func (s *Service) sendDataAvailableEvent(block interfaces.ReadOnlySignedBeaconBlock, blockRoot [32]byte, waited time.Duration) {
+	if block.Version() < version.Deneb {
+		return
+	}
+	commitments, err := block.Block().Body().BlobKzgCommitments()
+	if err != nil || len(commitments) == 0 {
+		return
+	}
+	s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.DataAvailable,
+		Data: &statefeed.DataAvailableData{
+			Slot:          block.Block().Slot(),
+			BlockRoot:     blockRoot,
+			ProposerIndex: block.Block().ProposerIndex(),
+			BlobCount:     len(commitments),
+			WaitedTime:    waited,
+		},
+	})
+}
+
 func (s *Service) reportPostBlockProcessing(
 	block interfaces.SignedBeaconBlock,
 	blockRoot [32]byte,
@@ -482,9 +505,32 @@ func (s *Service) updateJustificationOnBlock(ctx context.Context, preState, post
 			return err
 		}
 	}
+	if justified.Epoch > preJustifiedEpoch {
+		s.sendJustifiedCheckpointEvent(justified)
+	}
 	return nil
 }
 
+// notifyJustifiedCheckpointChange sends a justified checkpoint event if the justified checkpoint of
+// fork choice is no longer the previous one. The caller must hold the fork choice lock.
+func (s *Service) notifyJustifiedCheckpointChange(previous forkchoicetypes.Checkpoint) {
+	justified := s.cfg.ForkChoiceStore.JustifiedCheckpoint()
+	if *justified == previous {
+		return
+	}
+	s.sendJustifiedCheckpointEvent(justified)
+}
+
+func (s *Service) sendJustifiedCheckpointEvent(justified *forkchoicetypes.Checkpoint) {
+	s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.JustifiedCheckpoint,
+		Data: &statefeed.JustifiedCheckpointData{
+			Epoch: justified.Epoch,
+			Root:  justified.Root,
+		},
+	})
+}
+
 // updateFinalizationOnBlock performs some duties when the incoming block
 // changes the finalized checkpoint. It returns true when this has happened.
 func (s *Service) updateFinalizationOnBlock(ctx context.Context, preState, postState state.BeaconState, preFinalizedEpoch primitives.Epoch) (bool, error) {
@@ -11,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/voluntaryexits"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -419,6 +420,27 @@ func Test_sendNewFinalizedEvent(t *testing.T) {
 	assert.Equal(t, false, fc.ExecutionOptimistic)
 }
 
+func Test_notifyJustifiedCheckpointChange(t *testing.T) {
+	s, _ := minimalTestService(t)
+	notifier := &blockchainTesting.MockStateNotifier{RecordEvents: true}
+	s.cfg.StateNotifier = notifier
+	s.cfg.ForkChoiceStore.SetBalancesByRooter(func(_ context.Context, _ [32]byte) ([]uint64, error) { return []uint64{}, nil })
+	previous := *s.cfg.ForkChoiceStore.JustifiedCheckpoint()
+
+	s.notifyJustifiedCheckpointChange(previous)
+	require.Equal(t, 0, len(notifier.ReceivedEvents()))
+
+	require.NoError(t, s.cfg.ForkChoiceStore.UpdateJustifiedCheckpoint(s.ctx, &forkchoicetypes.Checkpoint{Epoch: 2, Root: [32]byte{'j'}}))
+	s.notifyJustifiedCheckpointChange(previous)
+	require.Equal(t, 1, len(notifier.ReceivedEvents()))
+	e := notifier.ReceivedEvents()[0]
+	assert.Equal(t, statefeed.JustifiedCheckpoint, int(e.Type))
+	jc, ok := e.Data.(*statefeed.JustifiedCheckpointData)
+	require.Equal(t, true, ok, "event has wrong data type")
+	assert.Equal(t, primitives.Epoch(2), jc.Epoch)
+	assert.Equal(t, [32]byte{'j'}, jc.Root)
+}
+
 func Test_executePostFinalizationTasks(t *testing.T) {
 	logHook := logTest.NewGlobal()
 
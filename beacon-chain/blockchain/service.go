@@ -23,6 +23,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
 	f "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/recorder"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/blstoexec"
@@ -67,6 +68,7 @@ type Service struct {
 	blockBeingSynced              *currentlySyncingBlock
 	blobStorage                   *filesystem.BlobStorage
 	lastPublishedLightClientEpoch primitives.Epoch
+	forkchoiceRecorder            *recorder.FileRecorder
 }
 
 // config options for the service.
@@ -93,6 +95,7 @@ type config struct {
 	FinalizedStateAtStartUp state.BeaconState
 	ExecutionEngineCaller   execution.EngineCaller
 	SyncChecker             Checker
+	ForkchoiceRecordingFile string
 }
 
 // Checker is an interface used to determine if a node is in initial sync
@@ -204,6 +207,7 @@ func (s *Service) Start() {
 		if err := s.StartFromSavedState(saved); err != nil {
 			log.Fatal(err)
 		}
+		s.startForkchoiceRecording()
 	} else {
 		if err := s.startFromExecutionChain(); err != nil {
 			log.Fatal(err)
@@ -211,12 +215,19 @@ func (s *Service) Start() {
 	}
 	s.spawnProcessAttestationsRoutine()
 	go s.runLateBlockTasks()
+	if features.Get().EnableForkchoicePersistence {
+		go s.runForkchoiceSnapshotRoutine()
+	}
 }
 
 // Stop the blockchain service's main event loop and associated goroutines.
 func (s *Service) Stop() error {
 	defer s.cancel()
 
+	if err := s.stopForkchoiceRecording(); err != nil {
+		log.WithError(err).Error("Could not close fork choice recording")
+	}
+
 	// lock before accessing s.head, s.head.state, s.head.state.FinalizedCheckpoint().Root
 	s.headLock.RLock()
 	if s.cfg.StateGen != nil && s.head != nil && s.head.state != nil {
@@ -230,7 +241,14 @@ func (s *Service) Stop() error {
 		s.headLock.RUnlock()
 	}
 	// Save initial sync cached blocks to the DB before stop.
-	return s.cfg.BeaconDB.SaveBlocks(s.ctx, s.getInitSyncBlocks())
+	if err := s.cfg.BeaconDB.SaveBlocks(s.ctx, s.getInitSyncBlocks()); err != nil {
+		return err
+	}
+	// Save fork choice last, so that all of its blocks are in the DB when it is restored.
+	if features.Get().EnableForkchoicePersistence && s.cfg.ForkChoiceStore.NodeCount() > 0 {
+		return s.saveForkchoiceSnapshot(s.ctx)
+	}
+	return nil
 }
 
 // Status always returns nil unless there is an error condition that causes
@@ -299,21 +317,30 @@ func (s *Service) StartFromSavedState(saved state.BeaconState) error {
 	}
 	s.cfg.ForkChoiceStore.SetGenesisTime(uint64(s.genesisTime.Unix()))
 
-	st, err := s.cfg.StateGen.StateByRoot(s.ctx, fRoot)
-	if err != nil {
-		return errors.Wrap(err, "could not get finalized checkpoint state")
-	}
-	if err := s.cfg.ForkChoiceStore.InsertNode(s.ctx, st, fRoot); err != nil {
-		return errors.Wrap(err, "could not insert finalized block to forkchoice")
+	restored := false
+	if features.Get().EnableForkchoicePersistence {
+		restored, err = s.restoreForkchoiceSnapshot(s.ctx, finalized)
+		if err != nil {
+			log.WithError(err).Error("Could not restore fork choice snapshot, starting from the finalized checkpoint")
+		}
 	}
-	if !features.Get().EnableStartOptimistic {
-		lastValidatedCheckpoint, err := s.cfg.BeaconDB.LastValidatedCheckpoint(s.ctx)
+	if !restored {
+		st, err := s.cfg.StateGen.StateByRoot(s.ctx, fRoot)
 		if err != nil {
-			return errors.Wrap(err, "could not get last validated checkpoint")
+			return errors.Wrap(err, "could not get finalized checkpoint state")
 		}
-		if bytes.Equal(finalized.Root, lastValidatedCheckpoint.Root) {
-			if err := s.cfg.ForkChoiceStore.SetOptimisticToValid(s.ctx, fRoot); err != nil {
-				return errors.Wrap(err, "could not set finalized block as validated")
+		if err := s.cfg.ForkChoiceStore.InsertNode(s.ctx, st, fRoot); err != nil {
+			return errors.Wrap(err, "could not insert finalized block to forkchoice")
+		}
+		if !features.Get().EnableStartOptimistic {
+			lastValidatedCheckpoint, err := s.cfg.BeaconDB.LastValidatedCheckpoint(s.ctx)
+			if err != nil {
+				return errors.Wrap(err, "could not get last validated checkpoint")
+			}
+			if bytes.Equal(finalized.Root, lastValidatedCheckpoint.Root) {
+				if err := s.cfg.ForkChoiceStore.SetOptimisticToValid(s.ctx, fRoot); err != nil {
+					return errors.Wrap(err, "could not set finalized block as validated")
+				}
 			}
 		}
 	}
@@ -445,6 +472,7 @@ func (s *Service) onExecutionChainStart(ctx context.Context, genesisTime time.Ti
 	if err != nil {
 		log.WithError(err).Fatal("Could not initialize beacon chain")
 	}
+	s.startForkchoiceRecording()
 	// We start a counter to genesis, if needed.
 	gRoot, err := initializedState.HashTreeRoot(s.ctx)
 	if err != nil {
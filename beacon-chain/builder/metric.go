@@ -27,4 +27,33 @@ var (
 			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
 		},
 	)
+	relayRequestLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "builder_relay_request_latency_milliseconds",
+			Help:    "Captures latency of requests to each relay in milliseconds",
+			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
+		},
+		[]string{"relay", "method"},
+	)
+	relayBidsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "builder_relay_bids_total",
+			Help: "Count of header requests to each relay, by result (valid, invalid or error)",
+		},
+		[]string{"relay", "result"},
+	)
+	relayBidsWonTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "builder_relay_bids_won_total",
+			Help: "Count of bids of each relay selected as the highest valid bid",
+		},
+		[]string{"relay"},
+	)
+	relayUp = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "builder_relay_up",
+			Help: "Whether the last status check of each relay succeeded (1) or not (0)",
+		},
+		[]string{"relay"},
+	)
 )
@@ -1,6 +1,9 @@
 package builder
 
 import (
+	"reflect"
+	"time"
+
 	"github.com/prysmaticlabs/prysm/v5/api/client/builder"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
@@ -13,25 +16,41 @@ type Option func(s *Service) error
 
 // FlagOptions for builder service flag configurations.
 func FlagOptions(c *cli.Context) ([]Option, error) {
-	endpoint := c.String(flags.MevRelayEndpoint.Name)
-	var client *builder.Client
-	if endpoint != "" {
-		var err error
-		client, err = builder.NewClient(endpoint)
+	opts := []Option{
+		WithRelayTimeout(c.Duration(flags.MevRelayTimeout.Name)),
+	}
+	endpoints := append([]string{c.String(flags.MevRelayEndpoint.Name)}, c.StringSlice(flags.MevRelayEndpoints.Name)...)
+	for _, endpoint := range endpoints {
+		if endpoint == "" {
+			continue
+		}
+		client, err := builder.NewClient(endpoint)
 		if err != nil {
 			return nil, err
 		}
-	}
-	opts := []Option{
-		WithBuilderClient(client),
+		opts = append(opts, WithBuilderClient(client))
 	}
 	return opts, nil
 }
 
-// WithBuilderClient sets the builder client for the beacon chain builder service.
+// WithBuilderClient adds a relay builder client to the beacon chain builder service. It can be used several times to
+// configure multiple relays. Nil clients are ignored.
 func WithBuilderClient(client builder.BuilderClient) Option {
 	return func(s *Service) error {
-		s.cfg.builderClient = client
+		if client == nil || reflect.ValueOf(client).IsNil() {
+			return nil
+		}
+		s.cfg.builderClients = append(s.cfg.builderClients, client)
+		return nil
+	}
+}
+
+// WithRelayTimeout sets the time given to each relay to answer a header request.
+func WithRelayTimeout(timeout time.Duration) Option {
+	return func(s *Service) error {
+		if timeout > 0 {
+			s.cfg.relayTimeout = timeout
+		}
 		return nil
 	}
 }
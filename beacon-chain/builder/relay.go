@@ -0,0 +1,127 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/builder"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// defaultRelayTimeout is the time given to each relay to answer a header request when no timeout is configured.
+const defaultRelayTimeout = time.Second
+
+var (
+	errNilBid            = errors.New("relay returned nil bid")
+	errZeroBid           = errors.New("relay returned bid with 0 value")
+	errInvalidParentHash = errors.New("relay returned bid with incorrect parent hash")
+	errInvalidSignature  = errors.New("relay returned bid with invalid builder signature")
+)
+
+// relay wraps the builder client of a single MEV relay along with its health status.
+type relay struct {
+	client  builder.BuilderClient
+	name    string
+	healthy atomic.Bool
+}
+
+func newRelay(name string, client builder.BuilderClient) *relay {
+	return &relay{client: client, name: name}
+}
+
+// relayNames returns a label identifying each relay in logs and metrics. Relay URLs usually embed the relay public
+// key as user info, so it is left out along with the query, keeping the scheme, host, port and path. Relays that
+// would share a label get their index appended, so that each relay has its own metric series.
+func relayNames(clients []builder.BuilderClient) []string {
+	names := make([]string, len(clients))
+	seen := make(map[string]int, len(clients))
+	for i, c := range clients {
+		names[i] = fmt.Sprintf("relay-%d", i)
+		if u, err := url.Parse(c.NodeURL()); err == nil && u.Host != "" {
+			names[i] = (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String()
+		}
+		seen[names[i]]++
+	}
+	for i := range names {
+		if seen[names[i]] > 1 {
+			names[i] = fmt.Sprintf("%s#%d", names[i], i)
+		}
+	}
+	return names
+}
+
+// checkStatus calls the status endpoint of the relay and records the result.
+func (r *relay) checkStatus(ctx context.Context) error {
+	err := r.client.Status(ctx)
+	r.healthy.Store(err == nil)
+	if err != nil {
+		relayUp.WithLabelValues(r.name).Set(0)
+		return err
+	}
+	relayUp.WithLabelValues(r.name).Set(1)
+	return nil
+}
+
+// getHeader requests a bid from the relay within the relay timeout, and validates it.
+func (r *relay) getHeader(ctx context.Context, timeout time.Duration, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte) (builder.SignedBid, *big.Int, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	signedBid, err := r.client.GetHeader(ctx, slot, parentHash, pubKey)
+	relayRequestLatency.WithLabelValues(r.name, "get_header").Observe(float64(time.Since(start).Milliseconds()))
+	if err != nil {
+		relayBidsTotal.WithLabelValues(r.name, "error").Inc()
+		return nil, nil, err
+	}
+	value, err := validateBid(signedBid, parentHash)
+	if err != nil {
+		relayBidsTotal.WithLabelValues(r.name, "invalid").Inc()
+		return nil, nil, err
+	}
+	relayBidsTotal.WithLabelValues(r.name, "valid").Inc()
+	return signedBid, value, nil
+}
+
+// validateBid checks that the bid builds on the requested parent, has a non-zero value and is signed by the builder.
+// It returns the value of the bid in wei.
+func validateBid(signedBid builder.SignedBid, parentHash [32]byte) (*big.Int, error) {
+	if signedBid == nil || signedBid.IsNil() {
+		return nil, errNilBid
+	}
+	bid, err := signedBid.Message()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get bid")
+	}
+	if bid == nil || bid.IsNil() {
+		return nil, errNilBid
+	}
+	header, err := bid.Header()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get bid header")
+	}
+	if !bytes.Equal(header.ParentHash(), parentHash[:]) {
+		return nil, errors.Wrapf(errInvalidParentHash, "%#x != %#x", header.ParentHash(), parentHash)
+	}
+	value := primitives.WeiToBigInt(bid.Value())
+	if value == nil || value.Sign() <= 0 {
+		return nil, errZeroBid
+	}
+	d, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder,
+		nil, /* fork version */
+		nil /* genesis val root */)
+	if err != nil {
+		return nil, err
+	}
+	if err := signing.VerifySigningRoot(bid, bid.Pubkey(), signedBid.Signature(), d); err != nil {
+		return nil, errors.Wrap(errInvalidSignature, err.Error())
+	}
+	return value, nil
+}
@@ -0,0 +1,246 @@
+package builder
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/builder"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	v1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/urfave/cli/v2"
+)
+
+// testRelay is a relay client returning a fixed bid and recording the calls it receives.
+type testRelay struct {
+	url        string
+	bid        builder.SignedBid
+	err        error
+	delay      time.Duration
+	registered int
+	submitted  int
+}
+
+func (r *testRelay) NodeURL() string {
+	return r.url
+}
+
+func (r *testRelay) GetHeader(ctx context.Context, _ primitives.Slot, _ [32]byte, _ [48]byte) (builder.SignedBid, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(r.delay):
+	}
+	return r.bid, r.err
+}
+
+func (r *testRelay) RegisterValidator(_ context.Context, _ []*ethpb.SignedValidatorRegistrationV1) error {
+	r.registered++
+	return r.err
+}
+
+func (r *testRelay) SubmitBlindedBlock(_ context.Context, _ interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, *v1.BlobsBundle, error) {
+	r.submitted++
+	return nil, nil, r.err
+}
+
+func (r *testRelay) Status(_ context.Context) error {
+	return r.err
+}
+
+// testBid returns a capella bid signed by a random builder key.
+func testBid(t *testing.T, parentHash [32]byte, blockHash byte, value uint64) builder.SignedBid {
+	signed, err := builder.WrappedSignedBuilderBidCapella(testBidProto(t, parentHash, blockHash, value))
+	require.NoError(t, err)
+	return signed
+}
+
+func testBidProto(t *testing.T, parentHash [32]byte, blockHash byte, value uint64) *ethpb.SignedBuilderBidCapella {
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	bid := &ethpb.BuilderBidCapella{
+		Header: &v1.ExecutionPayloadHeaderCapella{
+			ParentHash:       parentHash[:],
+			FeeRecipient:     make([]byte, fieldparams.FeeRecipientLength),
+			StateRoot:        make([]byte, fieldparams.RootLength),
+			ReceiptsRoot:     make([]byte, fieldparams.RootLength),
+			LogsBloom:        make([]byte, fieldparams.LogsBloomLength),
+			PrevRandao:       make([]byte, fieldparams.RootLength),
+			ExtraData:        make([]byte, 0),
+			BaseFeePerGas:    make([]byte, fieldparams.RootLength),
+			BlockHash:        bytesutil.PadTo([]byte{blockHash}, fieldparams.RootLength),
+			TransactionsRoot: bytesutil.PadTo([]byte{1}, fieldparams.RootLength),
+			WithdrawalsRoot:  make([]byte, fieldparams.RootLength),
+		},
+		Pubkey: sk.PublicKey().Marshal(),
+		Value:  bytesutil.PadTo(bytesutil.Uint64ToBytesLittleEndian(value), 32),
+	}
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder, nil, nil)
+	require.NoError(t, err)
+	sr, err := signing.ComputeSigningRoot(bid, domain)
+	require.NoError(t, err)
+	return &ethpb.SignedBuilderBidCapella{
+		Message:   bid,
+		Signature: sk.Sign(sr[:]).Marshal(),
+	}
+}
+
+func blindedBlockWithHash(t *testing.T, blockHash byte) interfaces.ReadOnlySignedBeaconBlock {
+	b := util.NewBlindedBeaconBlockCapella()
+	b.Block.Body.ExecutionPayloadHeader.BlockHash = bytesutil.PadTo([]byte{blockHash}, fieldparams.RootLength)
+	wb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	return wb
+}
+
+func TestValidateBid(t *testing.T) {
+	parentHash := [32]byte{'p'}
+	valid := testBid(t, parentHash, 1, 10)
+	value, err := validateBid(valid, parentHash)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), value.Uint64())
+
+	_, err = validateBid(valid, [32]byte{'x'})
+	require.ErrorIs(t, err, errInvalidParentHash)
+
+	_, err = validateBid(testBid(t, parentHash, 1, 0), parentHash)
+	require.ErrorIs(t, err, errZeroBid)
+
+	_, err = validateBid(nil, parentHash)
+	require.ErrorIs(t, err, errNilBid)
+
+	// Swap the signature with the one of another bid.
+	p := testBidProto(t, parentHash, 1, 10)
+	p.Signature = testBidProto(t, parentHash, 2, 10).Signature
+	tampered, err := builder.WrappedSignedBuilderBidCapella(p)
+	require.NoError(t, err)
+	_, err = validateBid(tampered, parentHash)
+	require.ErrorIs(t, err, errInvalidSignature)
+}
+
+func TestRelayNames(t *testing.T) {
+	names := relayNames([]builder.BuilderClient{
+		&testRelay{url: "https://0xaa@relay.example.com"},
+		&testRelay{url: "https://0xbb@relay.example.com/eu"},
+		&testRelay{url: "https://0xcc@relay.example.com:8443/eu?id=1"},
+		&testRelay{url: "http://dup:18550"},
+		&testRelay{url: "http://dup:18550"},
+		&testRelay{url: "not a url"},
+	})
+	require.DeepEqual(t, []string{
+		"https://relay.example.com",
+		"https://relay.example.com/eu",
+		"https://relay.example.com:8443/eu",
+		"http://dup:18550#3",
+		"http://dup:18550#4",
+		"relay-5",
+	}, names)
+}
+
+func TestService_GetHeader_MultipleRelays(t *testing.T) {
+	ctx := context.Background()
+	parentHash := [32]byte{'p'}
+	low := &testRelay{url: "http://low:18550", bid: testBid(t, parentHash, 1, 10)}
+	high := &testRelay{url: "http://high:18550", bid: testBid(t, parentHash, 2, 30)}
+	wrongParent := &testRelay{url: "http://wrong:18550", bid: testBid(t, [32]byte{'x'}, 3, 100)}
+	slow := &testRelay{url: "http://slow:18550", bid: testBid(t, parentHash, 4, 200), delay: time.Second}
+	failing := &testRelay{url: "http://failing:18550", err: errors.New("boom")}
+	s, err := NewService(ctx,
+		WithRelayTimeout(50*time.Millisecond),
+		WithBuilderClient(low),
+		WithBuilderClient(high),
+		WithBuilderClient(wrongParent),
+		WithBuilderClient(slow),
+		WithBuilderClient(failing),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(s.relays))
+	require.Equal(t, "http://high:18550", s.relays[1].name)
+	require.Equal(t, true, s.relays[1].healthy.Load())
+	require.Equal(t, false, s.relays[4].healthy.Load())
+
+	bid, err := s.GetHeader(ctx, 1, parentHash, [48]byte{})
+	require.NoError(t, err)
+	require.DeepEqual(t, high.bid, bid)
+	require.Equal(t, "http://high:18550", s.WinningRelay(bytesutil.ToBytes32(bytesutil.PadTo([]byte{2}, fieldparams.RootLength))))
+	require.Equal(t, "", s.WinningRelay([32]byte{9}))
+
+	// The blinded block is only revealed to the relay that won.
+	_, _, err = s.SubmitBlindedBlock(ctx, blindedBlockWithHash(t, 2))
+	require.NoError(t, err)
+	require.Equal(t, 1, high.submitted)
+	require.Equal(t, 0, low.submitted+wrongParent.submitted+slow.submitted+failing.submitted)
+
+	// Blocks with a payload no relay bid for are not submitted.
+	_, _, err = s.SubmitBlindedBlock(ctx, blindedBlockWithHash(t, 9))
+	require.ErrorContains(t, "no relay bid was selected", err)
+}
+
+func TestService_GetHeader_NoValidBid(t *testing.T) {
+	ctx := context.Background()
+	parentHash := [32]byte{'p'}
+	s, err := NewService(ctx,
+		WithBuilderClient(&testRelay{url: "http://a:18550", bid: testBid(t, [32]byte{'x'}, 1, 10)}),
+		WithBuilderClient(&testRelay{url: "http://b:18550", err: errors.New("boom")}),
+	)
+	require.NoError(t, err)
+	_, err = s.GetHeader(ctx, 1, parentHash, [48]byte{})
+	require.ErrorContains(t, "no valid bid from 2 relay(s)", err)
+}
+
+func TestService_RegisterValidator_MultipleRelays(t *testing.T) {
+	ctx := context.Background()
+	ok := &testRelay{url: "http://ok:18550"}
+	failing := &testRelay{url: "http://failing:18550", err: errors.New("boom")}
+	s, err := NewService(ctx, WithBuilderClient(ok), WithBuilderClient(failing))
+	require.NoError(t, err)
+	require.NoError(t, s.registerWithRelays(ctx, nil))
+	require.Equal(t, 1, ok.registered)
+	require.Equal(t, 1, failing.registered)
+
+	ok.err = errors.New("down")
+	require.ErrorContains(t, "all 2 relays rejected the registrations", s.registerWithRelays(ctx, nil))
+}
+
+func TestFlagOptions_RelaysFromConfigFile(t *testing.T) {
+	// A config file setting --http-mev-relay to a single url keeps working along with additional relays.
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(config, []byte("http-mev-relay: http://relay-a\nhttp-mev-relays:\n  - http://relay-b\n  - http://relay-c\n"), 0600))
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	require.NoError(t, set.Parse([]string{"test-command", "--" + cmd.ConfigFileFlag.Name, config}))
+	cliFlags := cmd.WrapFlags([]cli.Flag{cmd.ConfigFileFlag, flags.MevRelayEndpoint, flags.MevRelayEndpoints, flags.MevRelayTimeout})
+	command := &cli.Command{
+		Name:  "test-command",
+		Flags: cliFlags,
+		Before: func(cliCtx *cli.Context) error {
+			return cmd.LoadFlagsFromConfig(cliCtx, cliFlags)
+		},
+		Action: func(cliCtx *cli.Context) error {
+			opts, err := FlagOptions(cliCtx)
+			require.NoError(t, err)
+			s, err := NewService(context.Background(), opts...)
+			require.NoError(t, err)
+			require.DeepEqual(t, []string{"http://relay-a", "http://relay-b", "http://relay-c"}, relayNames(s.cfg.builderClients))
+			return nil
+		},
+	}
+	ctx := cli.NewContext(&app, set, nil)
+	require.NoError(t, command.Run(ctx, ctx.Args().Slice()...))
+}
@@ -2,7 +2,9 @@ package builder
 
 import (
 	"context"
-	"reflect"
+	"fmt"
+	"math/big"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -10,6 +12,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
@@ -29,50 +32,65 @@ type BlockBuilder interface {
 	GetHeader(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte) (builder.SignedBid, error)
 	RegisterValidator(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1) error
 	RegistrationByValidatorID(ctx context.Context, id primitives.ValidatorIndex) (*ethpb.ValidatorRegistrationV1, error)
+	WinningRelay(blockHash [32]byte) string
 	Configured() bool
 }
 
 // config defines a config struct for dependencies into the service.
 type config struct {
-	builderClient builder.BuilderClient
-	beaconDB      db.HeadAccessDatabase
-	headFetcher   blockchain.HeadFetcher
+	builderClients []builder.BuilderClient
+	relayTimeout   time.Duration
+	beaconDB       db.HeadAccessDatabase
+	headFetcher    blockchain.HeadFetcher
+}
+
+// winningBid records the relay whose bid was selected for a payload, so that the blinded block is only revealed to it.
+type winningBid struct {
+	relay *relay
+	slot  primitives.Slot
 }
 
 // Service defines a service that provides a client for interacting with the beacon chain and MEV relay network.
 type Service struct {
 	cfg               *config
-	c                 builder.BuilderClient
+	relays            []*relay
 	ctx               context.Context
 	cancel            context.CancelFunc
 	registrationCache *cache.RegistrationCache
+	winnersLock       sync.Mutex
+	winners           map[[32]byte]*winningBid
 }
 
 // NewService instantiates a new service.
 func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	s := &Service{
-		ctx:    ctx,
-		cancel: cancel,
-		cfg:    &config{},
+		ctx:     ctx,
+		cancel:  cancel,
+		cfg:     &config{relayTimeout: defaultRelayTimeout},
+		winners: make(map[[32]byte]*winningBid),
 	}
 	for _, opt := range opts {
 		if err := opt(s); err != nil {
 			return nil, err
 		}
 	}
-	if s.cfg.builderClient != nil && !reflect.ValueOf(s.cfg.builderClient).IsNil() {
-		s.c = s.cfg.builderClient
+	names := relayNames(s.cfg.builderClients)
+	for i, c := range s.cfg.builderClients {
+		r := newRelay(names[i], c)
+		s.relays = append(s.relays, r)
 
 		// Is the builder up?
-		if err := s.c.Status(ctx); err != nil {
-			log.WithError(err).Error("Failed to check builder status")
+		if err := r.checkStatus(ctx); err != nil {
+			log.WithError(err).WithField("relay", r.name).Error("Failed to check builder status")
 		} else {
-			log.WithField("endpoint", s.c.NodeURL()).Info("Builder has been configured")
-			log.Warn("Outsourcing block construction to external builders adds non-trivial delay to block propagation time.  " +
-				"Builder-constructed blocks or fallback blocks may get orphaned. Use at your own risk!")
+			log.WithField("endpoint", r.client.NodeURL()).Info("Builder has been configured")
 		}
 	}
+	if s.Configured() {
+		log.Warn("Outsourcing block construction to external builders adds non-trivial delay to block propagation time.  " +
+			"Builder-constructed blocks or fallback blocks may get orphaned. Use at your own risk!")
+	}
 	return s, nil
 }
 
@@ -87,7 +105,7 @@ func (s *Service) Stop() error {
 	return nil
 }
 
-// SubmitBlindedBlock submits a blinded block to the builder relay network.
+// SubmitBlindedBlock submits a blinded block to the relay whose bid was selected for its payload.
 func (s *Service) SubmitBlindedBlock(ctx context.Context, b interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, *v1.BlobsBundle, error) {
 	ctx, span := trace.StartSpan(ctx, "builder.SubmitBlindedBlock")
 	defer span.End()
@@ -95,14 +113,29 @@ func (s *Service) SubmitBlindedBlock(ctx context.Context, b interfaces.ReadOnlyS
 	defer func() {
 		submitBlindedBlockLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
-	if s.c == nil {
+	if !s.Configured() {
 		return nil, nil, ErrNoBuilder
 	}
 
-	return s.c.SubmitBlindedBlock(ctx, b)
+	r, err := s.relayForBlock(b)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return nil, nil, err
+	}
+	relayStart := time.Now()
+	payload, blobs, err := r.client.SubmitBlindedBlock(ctx, b)
+	relayRequestLatency.WithLabelValues(r.name, "submit_blinded_block").Observe(float64(time.Since(relayStart).Milliseconds()))
+	if err != nil {
+		err = errors.Wrapf(err, "could not submit blinded block to relay %s", r.name)
+		tracing.AnnotateError(span, err)
+		return nil, nil, err
+	}
+	return payload, blobs, nil
 }
 
-// GetHeader retrieves the header for a given slot and parent hash from the builder relay network.
+// GetHeader requests a header for the given slot and parent hash from all relays in parallel, and returns the
+// highest valid bid. Relays that fail to answer within the relay timeout, or that return an invalid bid, are ignored.
+// The builder signature of the returned bid has been verified.
 func (s *Service) GetHeader(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte) (builder.SignedBid, error) {
 	ctx, span := trace.StartSpan(ctx, "builder.GetHeader")
 	defer span.End()
@@ -110,20 +143,71 @@ func (s *Service) GetHeader(ctx context.Context, slot primitives.Slot, parentHas
 	defer func() {
 		getHeaderLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
-	if s.c == nil {
+	if !s.Configured() {
 		tracing.AnnotateError(span, ErrNoBuilder)
 		return nil, ErrNoBuilder
 	}
 
-	h, err := s.c.GetHeader(ctx, slot, parentHash, pubKey)
-	tracing.AnnotateError(span, err)
-	return h, err
+	type relayBid struct {
+		bid   builder.SignedBid
+		value *big.Int
+		err   error
+	}
+	bids := make([]relayBid, len(s.relays))
+	var wg sync.WaitGroup
+	for i, r := range s.relays {
+		wg.Add(1)
+		go func(i int, r *relay) {
+			defer wg.Done()
+			bid, value, err := r.getHeader(ctx, s.cfg.relayTimeout, slot, parentHash, pubKey)
+			bids[i] = relayBid{bid: bid, value: value, err: err}
+		}(i, r)
+	}
+	wg.Wait()
+
+	// Ties go to the relay configured first.
+	best := -1
+	var lastErr error
+	for i, b := range bids {
+		if b.err != nil {
+			log.WithError(b.err).WithFields(log.Fields{
+				"relay": s.relays[i].name,
+				"slot":  slot,
+			}).Warn("Could not get header from relay")
+			lastErr = b.err
+			continue
+		}
+		if best < 0 || b.value.Cmp(bids[best].value) > 0 {
+			best = i
+		}
+	}
+	if best < 0 {
+		err := errors.Wrapf(lastErr, "no valid bid from %d relay(s)", len(s.relays))
+		tracing.AnnotateError(span, err)
+		return nil, err
+	}
+
+	winner := s.relays[best]
+	if err := s.recordWinner(slot, bids[best].bid, winner); err != nil {
+		tracing.AnnotateError(span, err)
+		return nil, err
+	}
+	relayBidsWonTotal.WithLabelValues(winner.name).Inc()
+	if len(s.relays) > 1 {
+		log.WithFields(log.Fields{
+			"relay":     winner.name,
+			"slot":      slot,
+			"weiValue":  bids[best].value.String(),
+			"relayBids": len(s.relays),
+		}).Debug("Selected highest relay bid")
+	}
+	return bids[best].bid, nil
 }
 
 // Status retrieves the status of the builder relay network.
 func (s *Service) Status() error {
 	// Return early if builder isn't initialized in service.
-	if s.c == nil {
+	if !s.Configured() {
 		return nil
 	}
 
@@ -139,7 +223,7 @@ func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedVali
 	defer func() {
 		registerValidatorLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
-	if s.c == nil {
+	if !s.Configured() {
 		return ErrNoBuilder
 	}
 
@@ -164,7 +248,7 @@ func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedVali
 		valid = append(valid, r)
 		indexToRegistration[nx] = r.Message
 	}
-	if err := s.c.RegisterValidator(ctx, valid); err != nil {
+	if err := s.registerWithRelays(ctx, valid); err != nil {
 		return errors.Wrap(err, "could not register validator(s)")
 	}
 
@@ -191,9 +275,98 @@ func (s *Service) RegistrationByValidatorID(ctx context.Context, id primitives.V
 	}
 }
 
+// WinningRelay returns the name of the relay whose bid was selected for the payload with the given block hash,
+// or an empty string if no such bid is known.
+func (s *Service) WinningRelay(blockHash [32]byte) string {
+	s.winnersLock.Lock()
+	defer s.winnersLock.Unlock()
+	w, ok := s.winners[blockHash]
+	if !ok {
+		return ""
+	}
+	return w.relay.name
+}
+
 // Configured returns true if the user has configured a builder client.
 func (s *Service) Configured() bool {
-	return s.c != nil && !reflect.ValueOf(s.c).IsNil()
+	return len(s.relays) > 0
+}
+
+// registerWithRelays sends the registrations to all relays in parallel. It only fails if no relay accepted them.
+func (s *Service) registerWithRelays(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1) error {
+	errs := make([]error, len(s.relays))
+	var wg sync.WaitGroup
+	for i, r := range s.relays {
+		wg.Add(1)
+		go func(i int, r *relay) {
+			defer wg.Done()
+			start := time.Now()
+			errs[i] = r.client.RegisterValidator(ctx, reg)
+			relayRequestLatency.WithLabelValues(r.name, "register_validator").Observe(float64(time.Since(start).Milliseconds()))
+		}(i, r)
+	}
+	wg.Wait()
+
+	var lastErr error
+	for i, err := range errs {
+		if err != nil {
+			log.WithError(err).WithField("relay", s.relays[i].name).Warn("Could not register validators with relay")
+			lastErr = err
+		}
+	}
+	if lastErr != nil && len(s.relays) == 1 {
+		return lastErr
+	}
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+	}
+	return errors.Wrapf(lastErr, "all %d relays rejected the registrations", len(s.relays))
+}
+
+// recordWinner remembers which relay provided the selected bid, keyed by the block hash of its payload header.
+// Winners of past slots are pruned as new bids are selected.
+func (s *Service) recordWinner(slot primitives.Slot, signedBid builder.SignedBid, r *relay) error {
+	bid, err := signedBid.Message()
+	if err != nil {
+		return errors.Wrap(err, "could not get bid")
+	}
+	header, err := bid.Header()
+	if err != nil {
+		return errors.Wrap(err, "could not get bid header")
+	}
+	s.winnersLock.Lock()
+	defer s.winnersLock.Unlock()
+	for hash, w := range s.winners {
+		if w.slot+params.BeaconConfig().SlotsPerEpoch < slot {
+			delete(s.winners, hash)
+		}
+	}
+	s.winners[bytesutil.ToBytes32(header.BlockHash())] = &winningBid{relay: r, slot: slot}
+	return nil
+}
+
+// relayForBlock returns the relay whose bid was selected for the payload of the blinded block. With a single relay,
+// that relay is always used.
+func (s *Service) relayForBlock(b interfaces.ReadOnlySignedBeaconBlock) (*relay, error) {
+	if len(s.relays) == 1 {
+		return s.relays[0], nil
+	}
+	if b == nil || b.IsNil() {
+		return nil, errors.New("nil blinded block")
+	}
+	header, err := b.Block().Body().Execution()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get execution header")
+	}
+	s.winnersLock.Lock()
+	defer s.winnersLock.Unlock()
+	w, ok := s.winners[bytesutil.ToBytes32(header.BlockHash())]
+	if !ok {
+		return nil, fmt.Errorf("no relay bid was selected for block hash %#x", header.BlockHash())
+	}
+	return w.relay, nil
 }
 
 func (s *Service) pollRelayerStatus(ctx context.Context) {
@@ -202,9 +375,12 @@ func (s *Service) pollRelayerStatus(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
-			if s.c != nil {
-				if err := s.c.Status(ctx); err != nil {
-					log.WithError(err).Error("Failed to call relayer status endpoint, perhaps mev-boost or relayers are down")
+			for _, r := range s.relays {
+				wasHealthy := r.healthy.Load()
+				if err := r.checkStatus(ctx); err != nil {
+					log.WithError(err).WithField("relay", r.name).Error("Failed to call relayer status endpoint, perhaps mev-boost or relayers are down")
+				} else if !wasHealthy {
+					log.WithField("relay", r.name).Info("Relayer status endpoint is reachable again")
 				}
 			}
 		case <-ctx.Done():
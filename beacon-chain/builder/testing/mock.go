@@ -36,6 +36,7 @@ type MockBuilderService struct {
 	RegistrationCache     *cache.RegistrationCache
 	ErrGetHeader          error
 	ErrRegisterValidator  error
+	Relay                 string
 	Cfg                   *Config
 }
 
@@ -96,6 +97,11 @@ func (s *MockBuilderService) RegistrationByValidatorID(ctx context.Context, id p
 	return nil, cache.ErrNotFoundRegistration
 }
 
+// WinningRelay for mocking.
+func (s *MockBuilderService) WinningRelay([32]byte) string {
+	return s.Relay
+}
+
 // RegisterValidator for mocking.
 func (s *MockBuilderService) RegisterValidator(context.Context, []*ethpb.SignedValidatorRegistrationV1) error {
 	return s.ErrRegisterValidator
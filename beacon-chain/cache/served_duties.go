@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// DutyDependentRoots are the dependent roots of the proposer and attester duties of an epoch.
+type DutyDependentRoots struct {
+	Proposer [32]byte
+	Attester [32]byte
+}
+
+// ServedDutiesCache keeps track of the dependent roots of the duties served to validator
+// clients, so that the duties invalidated by a reorg can be detected.
+type ServedDutiesCache struct {
+	sync.Mutex
+	roots map[primitives.Epoch]DutyDependentRoots
+}
+
+// NewServedDutiesCache returns a newly created cache.
+func NewServedDutiesCache() *ServedDutiesCache {
+	return &ServedDutiesCache{
+		roots: make(map[primitives.Epoch]DutyDependentRoots),
+	}
+}
+
+// Set records the dependent roots of the duties served for the epoch.
+func (c *ServedDutiesCache) Set(epoch primitives.Epoch, roots DutyDependentRoots) {
+	c.Lock()
+	defer c.Unlock()
+	c.roots[epoch] = roots
+}
+
+// Roots returns a copy of the dependent roots of the served duties, keyed by epoch.
+func (c *ServedDutiesCache) Roots() map[primitives.Epoch]DutyDependentRoots {
+	c.Lock()
+	defer c.Unlock()
+	roots := make(map[primitives.Epoch]DutyDependentRoots, len(c.roots))
+	for e, r := range c.roots {
+		roots[e] = r
+	}
+	return roots
+}
+
+// Prune removes the dependent roots of the duties served for epochs before the given epoch.
+func (c *ServedDutiesCache) Prune(epoch primitives.Epoch) {
+	c.Lock()
+	defer c.Unlock()
+	for e := range c.roots {
+		if e < epoch {
+			delete(c.roots, e)
+		}
+	}
+}
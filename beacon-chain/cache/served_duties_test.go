@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestServedDutiesCache(t *testing.T) {
+	c := NewServedDutiesCache()
+	require.Equal(t, 0, len(c.Roots()))
+
+	c.Set(1, DutyDependentRoots{Proposer: [32]byte{'a'}, Attester: [32]byte{'b'}})
+	c.Set(2, DutyDependentRoots{Proposer: [32]byte{'c'}, Attester: [32]byte{'d'}})
+	c.Set(2, DutyDependentRoots{Proposer: [32]byte{'e'}, Attester: [32]byte{'f'}})
+	roots := c.Roots()
+	require.Equal(t, 2, len(roots))
+	require.Equal(t, [32]byte{'e'}, roots[2].Proposer)
+	require.Equal(t, [32]byte{'f'}, roots[2].Attester)
+
+	// The returned map is a copy.
+	delete(roots, 1)
+	require.Equal(t, 2, len(c.Roots()))
+
+	c.Prune(2)
+	roots = c.Roots()
+	require.Equal(t, 1, len(roots))
+	_, ok := roots[primitives.Epoch(2)]
+	require.Equal(t, true, ok)
+}
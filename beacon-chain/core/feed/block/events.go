@@ -7,6 +7,8 @@ import "github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 const (
 	// ReceivedBlock is sent after a block has been received by the beacon node via p2p or RPC.
 	ReceivedBlock = iota + 1
+	// GossipValidatedBlock is sent after a block received via gossip passed validation, before it is imported.
+	GossipValidatedBlock
 )
 
 // ReceivedBlockData is the data sent with ReceivedBlock events.
@@ -14,3 +16,9 @@ type ReceivedBlockData struct {
 	SignedBlock  interfaces.ReadOnlySignedBeaconBlock
 	IsOptimistic bool
 }
+
+// GossipValidatedBlockData is the data sent with GossipValidatedBlock events.
+type GossipValidatedBlockData struct {
+	SignedBlock interfaces.ReadOnlySignedBeaconBlock
+	BlockRoot   [32]byte
+}
@@ -31,6 +31,12 @@ const (
 	LightClientFinalityUpdate
 	// LightClientOptimisticUpdate event
 	LightClientOptimisticUpdate
+	// JustifiedCheckpoint is sent when the justified checkpoint of fork choice changes.
+	JustifiedCheckpoint
+	// DataAvailable is sent when all the blob sidecars or data column sidecars a block depends on are available.
+	DataAvailable
+	// InvalidExecutionPayload is sent when the execution client considers the payload of a block invalid.
+	InvalidExecutionPayload
 )
 
 // BlockProcessedData is the data sent with BlockProcessed events.
@@ -66,3 +72,42 @@ type InitializedData struct {
 	// GenesisValidatorsRoot represents state.validators.HashTreeRoot().
 	GenesisValidatorsRoot []byte
 }
+
+// JustifiedCheckpointData is the data sent with JustifiedCheckpoint events.
+type JustifiedCheckpointData struct {
+	// Epoch of the justified checkpoint.
+	Epoch primitives.Epoch
+	// Root of the justified checkpoint block.
+	Root [32]byte
+}
+
+// DataAvailableData is the data sent with DataAvailable events.
+type DataAvailableData struct {
+	// Slot of the block.
+	Slot primitives.Slot
+	// BlockRoot of the block.
+	BlockRoot [32]byte
+	// ProposerIndex of the block.
+	ProposerIndex primitives.ValidatorIndex
+	// BlobCount is the number of blob sidecars committed to in the block.
+	BlobCount int
+	// DataColumns are the indices of the data column sidecars which were checked, when the availability
+	// of the data is checked with data columns rather than blob sidecars.
+	DataColumns []uint64
+	// WaitedTime is the time spent waiting for the sidecars.
+	WaitedTime time.Duration
+}
+
+// InvalidExecutionPayloadData is the data sent with InvalidExecutionPayload events.
+type InvalidExecutionPayloadData struct {
+	// Slot of the block.
+	Slot primitives.Slot
+	// BlockRoot of the block.
+	BlockRoot [32]byte
+	// ProposerIndex of the block.
+	ProposerIndex primitives.ValidatorIndex
+	// BlockHash of the invalid execution payload.
+	BlockHash [32]byte
+	// LatestValidHash returned by the execution client.
+	LatestValidHash [32]byte
+}
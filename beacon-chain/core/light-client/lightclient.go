@@ -25,7 +25,8 @@ import (
 )
 
 const (
-	FinalityBranchNumOfLeaves = 6
+	FinalityBranchNumOfLeaves  = 6
+	executionBranchNumOfLeaves = 4
 )
 
 // CreateLightClientFinalityUpdate - implements https://github.com/ethereum/consensus-specs/blob/3d235740e5f1e641d3b160c8688f26e7dc5a1894/specs/altair/light-client/full-node.md#create_light_client_finality_update
@@ -252,7 +253,7 @@ func NewLightClientFinalityUpdateFromBeaconState(
 			if err != nil {
 				return nil, errors.Wrap(err, "could not get finalized header")
 			}
-			finalizedHeaderBeacon := migration.V1Alpha1SignedHeaderToV1(tempFinalizedHeader).GetMessage()
+			finalizedHeaderBeacon = migration.V1Alpha1SignedHeaderToV1(tempFinalizedHeader).GetMessage()
 
 			finalizedHeaderRoot, err := finalizedHeaderBeacon.HashTreeRoot()
 			if err != nil {
@@ -327,7 +328,7 @@ func NewLightClientFinalityUpdateFromBeaconState(
 			result.FinalityBranch = finalityBranch
 		} else {
 			execution := createEmptyExecutionPayloadHeaderCapella()
-			executionBranch := make([][]byte, 0)
+			executionBranch := emptyExecutionBranch()
 
 			result.FinalizedHeader = &ethpbv2.LightClientHeaderContainer{
 				Header: &ethpbv2.LightClientHeaderContainer_HeaderCapella{
@@ -364,7 +365,7 @@ func NewLightClientFinalityUpdateFromBeaconState(
 			result.FinalityBranch = finalityBranch
 		} else {
 			execution := createEmptyExecutionPayloadHeaderDeneb()
-			executionBranch := make([][]byte, 0)
+			executionBranch := emptyExecutionBranch()
 
 			result.FinalizedHeader = &ethpbv2.LightClientHeaderContainer{
 				Header: &ethpbv2.LightClientHeaderContainer_HeaderDeneb{
@@ -385,6 +386,15 @@ func NewLightClientFinalityUpdateFromBeaconState(
 	return result, nil
 }
 
+// emptyExecutionBranch returns the zeroed execution branch of a header without an execution payload.
+func emptyExecutionBranch() [][]byte {
+	branch := make([][]byte, executionBranchNumOfLeaves)
+	for i := range branch {
+		branch[i] = make([]byte, fieldparams.RootLength)
+	}
+	return branch
+}
+
 func createEmptyExecutionPayloadHeaderCapella() *enginev1.ExecutionPayloadHeaderCapella {
 	return &enginev1.ExecutionPayloadHeaderCapella{
 		ParentHash:       make([]byte, 32),
@@ -551,3 +561,120 @@ func NewLightClientUpdateFromOptimisticUpdate(update *ethpbv2.LightClientOptimis
 		SignatureSlot:  update.SignatureSlot,
 	}
 }
+
+// NewLightClientBootstrapFromBeaconState - implements https://github.com/ethereum/consensus-specs/blob/3d235740e5f1e641d3b160c8688f26e7dc5a1894/specs/altair/light-client/full-node.md#create_light_client_bootstrap
+// def create_light_client_bootstrap(state: BeaconState, block: SignedBeaconBlock) -> LightClientBootstrap:
+//
+//	assert compute_epoch_at_slot(state.slot) >= ALTAIR_FORK_EPOCH
+//	assert state.slot == state.latest_block_header.slot
+//	header = state.latest_block_header.copy()
+//	header.state_root = hash_tree_root(state)
+//	assert hash_tree_root(header) == hash_tree_root(block.message)
+//
+//	return LightClientBootstrap(
+//	    header=block_to_light_client_header(block),
+//	    current_sync_committee=state.current_sync_committee,
+//	    current_sync_committee_branch=compute_merkle_proof_for_state(state, CURRENT_SYNC_COMMITTEE_INDEX)
+//	)
+func NewLightClientBootstrapFromBeaconState(
+	ctx context.Context,
+	state state.BeaconState,
+	block interfaces.ReadOnlySignedBeaconBlock) (*ethpbv2.LightClientBootstrap, error) {
+	if slots.ToEpoch(state.Slot()) < params.BeaconConfig().AltairForkEpoch {
+		return nil, fmt.Errorf("light client bootstrap is not supported before Altair, invalid slot %d", state.Slot())
+	}
+
+	header := state.LatestBlockHeader()
+	if state.Slot() != header.Slot {
+		return nil, fmt.Errorf("state slot %d not equal to latest block header slot %d", state.Slot(), header.Slot)
+	}
+	stateRoot, err := state.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get state root")
+	}
+	header.StateRoot = stateRoot[:]
+	headerRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get header root")
+	}
+	blockRoot, err := block.Block().HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get block root")
+	}
+	if headerRoot != blockRoot {
+		return nil, fmt.Errorf("header root %#x not equal to block root %#x", headerRoot, blockRoot)
+	}
+
+	committee, err := state.CurrentSyncCommittee()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current sync committee")
+	}
+	branch, err := state.CurrentSyncCommitteeProof(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current sync committee proof")
+	}
+
+	beacon := &ethpbv1.BeaconBlockHeader{
+		Slot:          header.Slot,
+		ProposerIndex: header.ProposerIndex,
+		ParentRoot:    header.ParentRoot,
+		StateRoot:     header.StateRoot,
+		BodyRoot:      header.BodyRoot,
+	}
+	result := &ethpbv2.LightClientBootstrap{
+		CurrentSyncCommittee: &ethpbv2.SyncCommittee{
+			Pubkeys:         committee.Pubkeys,
+			AggregatePubkey: committee.AggregatePubkey,
+		},
+		CurrentSyncCommitteeBranch: branch,
+	}
+
+	switch block.Version() {
+	case version.Altair, version.Bellatrix:
+		result.Header = &ethpbv2.LightClientHeaderContainer{
+			Header: &ethpbv2.LightClientHeaderContainer_HeaderAltair{
+				HeaderAltair: &ethpbv2.LightClientHeader{Beacon: beacon},
+			},
+		}
+	case version.Capella:
+		execution, err := getExecutionPayloadHeaderCapella(block)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get execution payload header")
+		}
+		executionBranch, err := blocks.PayloadProof(ctx, block.Block())
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get execution payload proof")
+		}
+		result.Header = &ethpbv2.LightClientHeaderContainer{
+			Header: &ethpbv2.LightClientHeaderContainer_HeaderCapella{
+				HeaderCapella: &ethpbv2.LightClientHeaderCapella{
+					Beacon:          beacon,
+					Execution:       execution,
+					ExecutionBranch: executionBranch,
+				},
+			},
+		}
+	case version.Deneb, version.Electra:
+		execution, err := getExecutionPayloadHeaderDeneb(block)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get execution payload header")
+		}
+		executionBranch, err := blocks.PayloadProof(ctx, block.Block())
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get execution payload proof")
+		}
+		result.Header = &ethpbv2.LightClientHeaderContainer{
+			Header: &ethpbv2.LightClientHeaderContainer_HeaderDeneb{
+				HeaderDeneb: &ethpbv2.LightClientHeaderDeneb{
+					Beacon:          beacon,
+					Execution:       execution,
+					ExecutionBranch: executionBranch,
+				},
+			},
+		}
+	default:
+		return nil, fmt.Errorf("unsupported block version %s", version.String(block.Version()))
+	}
+
+	return result, nil
+}
@@ -140,3 +140,38 @@ func TestLightClient_NewLightClientFinalityUpdateFromBeaconStateDeneb(t *testing
 }
 
 // TODO - add finality update tests with non-nil finalized block for different versions
+
+func TestLightClient_NewLightClientBootstrapFromBeaconState(t *testing.T) {
+	for name, setup := range map[string]func(*util.TestLightClient) *util.TestLightClient{
+		"altair":  (*util.TestLightClient).SetupTestAltair,
+		"capella": (*util.TestLightClient).SetupTestCapella,
+		"deneb":   (*util.TestLightClient).SetupTestDeneb,
+	} {
+		t.Run(name, func(t *testing.T) {
+			l := setup(util.NewTestLightClient(t))
+
+			bootstrap, err := lightClient.NewLightClientBootstrapFromBeaconState(l.Ctx, l.State, l.Block)
+			require.NoError(t, err)
+
+			blockRoot, err := l.Block.Block().HashTreeRoot()
+			require.NoError(t, err)
+			beacon, err := bootstrap.Header.GetBeacon()
+			require.NoError(t, err)
+			headerRoot, err := beacon.HashTreeRoot()
+			require.NoError(t, err)
+			require.Equal(t, blockRoot, headerRoot, "Header root is not equal to block root")
+
+			committee, err := l.State.CurrentSyncCommittee()
+			require.NoError(t, err)
+			require.DeepSSZEqual(t, committee.Pubkeys, bootstrap.CurrentSyncCommittee.Pubkeys, "Sync committee is not equal")
+
+			// The bootstrap must be encodable for the req/resp domain.
+			_, err = bootstrap.MarshalSSZ()
+			require.NoError(t, err)
+		})
+	}
+
+	l := util.NewTestLightClient(t).SetupTestAltair()
+	_, err := lightClient.NewLightClientBootstrapFromBeaconState(l.Ctx, l.AttestedState, l.Block)
+	require.ErrorContains(t, "not equal to block root", err)
+}
@@ -0,0 +1,373 @@
+package light_client
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	consensus_types "github.com/prysmaticlabs/prysm/v5/consensus-types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrUntrustedBootstrap     = errors.New("bootstrap header does not match the trusted block root")
+	ErrNotEnoughParticipants  = errors.New("not enough sync committee participants")
+	ErrIrrelevantUpdate       = errors.New("update is not relevant to the store")
+	ErrUnexpectedUpdatePeriod = errors.New("update signature period is not covered by the store")
+)
+
+// Store is the state of a light client following the chain through sync committee periods, as
+// described in https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#lightclientstore
+// A Store is not safe for concurrent use.
+type Store struct {
+	// FinalizedHeader is the latest finalized header known to the light client.
+	FinalizedHeader *ethpbv2.LightClientHeaderContainer
+	// CurrentSyncCommittee is the sync committee of the period of the finalized header.
+	CurrentSyncCommittee *ethpbv2.SyncCommittee
+	// NextSyncCommittee is the sync committee of the period following the finalized header, nil if unknown.
+	NextSyncCommittee *ethpbv2.SyncCommittee
+	// BestValidUpdate is the best update seen in the current period, applied when the update timeout expires.
+	BestValidUpdate *ethpbv2.LightClientUpdate
+	// OptimisticHeader is the most recent header attested by enough of the sync committee.
+	OptimisticHeader *ethpbv2.LightClientHeaderContainer
+
+	PreviousMaxActiveParticipants uint64
+	CurrentMaxActiveParticipants  uint64
+
+	genesisValidatorsRoot []byte
+}
+
+// InitializeStore - implements https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#initialize_light_client_store
+// The bootstrap is only trusted if its header hashes to the trusted block root.
+func InitializeStore(trustedBlockRoot [32]byte, bootstrap *ethpbv2.LightClientBootstrap, genesisValidatorsRoot []byte) (*Store, error) {
+	if bootstrap == nil {
+		return nil, errors.New("nil bootstrap")
+	}
+	if err := IsValidLightClientHeader(bootstrap.Header); err != nil {
+		return nil, errors.Wrap(err, "invalid bootstrap header")
+	}
+	beacon, err := bootstrap.Header.GetBeacon()
+	if err != nil {
+		return nil, err
+	}
+	root, err := beacon.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute bootstrap header root")
+	}
+	if root != trustedBlockRoot {
+		return nil, errors.Wrapf(ErrUntrustedBootstrap, "got %#x, wanted %#x", root, trustedBlockRoot)
+	}
+	if err := VerifyCurrentSyncCommitteeBranch(bootstrap.CurrentSyncCommittee, bootstrap.CurrentSyncCommitteeBranch, beacon.StateRoot); err != nil {
+		return nil, err
+	}
+	return &Store{
+		FinalizedHeader:       bootstrap.Header,
+		CurrentSyncCommittee:  bootstrap.CurrentSyncCommittee,
+		OptimisticHeader:      bootstrap.Header,
+		genesisValidatorsRoot: genesisValidatorsRoot,
+	}, nil
+}
+
+// FinalizedSlot returns the slot of the finalized header.
+func (s *Store) FinalizedSlot() primitives.Slot {
+	return headerSlot(s.FinalizedHeader)
+}
+
+// OptimisticSlot returns the slot of the optimistic header.
+func (s *Store) OptimisticSlot() primitives.Slot {
+	return headerSlot(s.OptimisticHeader)
+}
+
+// ValidateUpdate - implements https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#validate_light_client_update
+// It checks the update against the store without modifying it.
+func (s *Store) ValidateUpdate(update *ethpbv2.LightClientUpdate, currentSlot primitives.Slot) error {
+	if update == nil || update.SyncAggregate == nil {
+		return errors.New("nil update or sync aggregate")
+	}
+	// Verify sync committee has sufficient participants.
+	if update.SyncAggregate.SyncCommitteeBits.Count() < params.BeaconConfig().MinSyncCommitteeParticipants {
+		return ErrNotEnoughParticipants
+	}
+
+	// Verify update does not skip a sync committee period.
+	if err := IsValidLightClientHeader(update.AttestedHeader); err != nil {
+		return errors.Wrap(err, "invalid attested header")
+	}
+	attested, err := update.AttestedHeader.GetBeacon()
+	if err != nil {
+		return err
+	}
+	finalizedSlot := primitives.Slot(0)
+	if update.FinalizedHeader != nil {
+		finalizedSlot = headerSlot(update.FinalizedHeader)
+	}
+	if currentSlot < update.SignatureSlot || update.SignatureSlot <= attested.Slot || attested.Slot < finalizedSlot {
+		return fmt.Errorf("invalid update slots: current %d, signature %d, attested %d, finalized %d",
+			currentSlot, update.SignatureSlot, attested.Slot, finalizedSlot)
+	}
+	storePeriod := slots.SyncCommitteePeriod(slots.ToEpoch(s.FinalizedSlot()))
+	signaturePeriod := slots.SyncCommitteePeriod(slots.ToEpoch(update.SignatureSlot))
+	if s.isNextSyncCommitteeKnown() {
+		if signaturePeriod != storePeriod && signaturePeriod != storePeriod+1 {
+			return ErrUnexpectedUpdatePeriod
+		}
+	} else if signaturePeriod != storePeriod {
+		return ErrUnexpectedUpdatePeriod
+	}
+
+	// Verify update is relevant.
+	attestedPeriod := slots.SyncCommitteePeriod(slots.ToEpoch(attested.Slot))
+	hasNextSyncCommittee := !s.isNextSyncCommitteeKnown() && IsSyncCommitteeUpdate(update) && attestedPeriod == storePeriod
+	if attested.Slot <= s.FinalizedSlot() && !hasNextSyncCommittee {
+		return ErrIrrelevantUpdate
+	}
+
+	// Verify that the finality branch, if present, confirms the finalized header to match the
+	// finalized checkpoint root saved in the state of the attested header.
+	if IsFinalityUpdate(update) {
+		if update.FinalizedHeader == nil {
+			return errors.New("nil finalized header")
+		}
+		if err := VerifyFinalityBranch(update.FinalizedHeader, update.FinalityBranch, attested.StateRoot); err != nil {
+			return err
+		}
+	}
+
+	// Verify that the next sync committee, if present, actually is the next sync committee saved in
+	// the state of the attested header.
+	if IsSyncCommitteeUpdate(update) {
+		if attestedPeriod == storePeriod && s.isNextSyncCommitteeKnown() && !proto.Equal(update.NextSyncCommittee, s.NextSyncCommittee) {
+			return errors.New("next sync committee does not match the known one")
+		}
+		if err := VerifyNextSyncCommitteeBranch(update.NextSyncCommittee, update.NextSyncCommitteeBranch, attested.StateRoot); err != nil {
+			return err
+		}
+	}
+
+	// Verify sync committee aggregate signature.
+	committee := s.CurrentSyncCommittee
+	if signaturePeriod != storePeriod {
+		committee = s.NextSyncCommittee
+	}
+	return VerifySyncAggregate(committee, update.SyncAggregate, attested, update.SignatureSlot, s.genesisValidatorsRoot)
+}
+
+// ProcessUpdate - implements https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#process_light_client_update
+func (s *Store) ProcessUpdate(update *ethpbv2.LightClientUpdate, currentSlot primitives.Slot) error {
+	if err := s.ValidateUpdate(update, currentSlot); err != nil {
+		return err
+	}
+	participation := update.SyncAggregate.SyncCommitteeBits
+
+	// Update the best update in case we have to force-update to it if the timeout elapses.
+	if s.BestValidUpdate == nil {
+		s.BestValidUpdate = update
+	} else {
+		better, err := IsBetterUpdate(update, s.BestValidUpdate)
+		if err != nil {
+			return errors.Wrap(err, "could not compare updates")
+		}
+		if better {
+			s.BestValidUpdate = update
+		}
+	}
+
+	// Track the maximum number of active participants in the committee signatures.
+	if participation.Count() > s.CurrentMaxActiveParticipants {
+		s.CurrentMaxActiveParticipants = participation.Count()
+	}
+
+	// Update the optimistic header.
+	attestedSlot := headerSlot(update.AttestedHeader)
+	if participation.Count() > s.safetyThreshold() && attestedSlot > s.OptimisticSlot() {
+		s.OptimisticHeader = update.AttestedHeader
+	}
+
+	// Update the finalized header.
+	hasFinalizedNextSyncCommittee := !s.isNextSyncCommitteeKnown() &&
+		IsSyncCommitteeUpdate(update) && IsFinalityUpdate(update) &&
+		slots.SyncCommitteePeriod(slots.ToEpoch(headerSlot(update.FinalizedHeader))) == slots.SyncCommitteePeriod(slots.ToEpoch(attestedSlot))
+	hasNewerFinalizedHeader := IsFinalityUpdate(update) && headerSlot(update.FinalizedHeader) > s.FinalizedSlot()
+	if participation.Count()*3 >= participation.Len()*2 && (hasNewerFinalizedHeader || hasFinalizedNextSyncCommittee) {
+		// Normal update through 2/3 threshold.
+		if err := s.applyUpdate(update); err != nil {
+			return err
+		}
+		s.BestValidUpdate = nil
+	}
+	return nil
+}
+
+// IsBetterUpdate - implements https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#is_better_update
+func IsBetterUpdate(newUpdate, oldUpdate *ethpbv2.LightClientUpdate) (bool, error) {
+	maxActiveParticipants := newUpdate.SyncAggregate.SyncCommitteeBits.Len()
+	newNumActiveParticipants := newUpdate.SyncAggregate.SyncCommitteeBits.Count()
+	oldNumActiveParticipants := oldUpdate.SyncAggregate.SyncCommitteeBits.Count()
+	newHasSupermajority := newNumActiveParticipants*3 >= maxActiveParticipants*2
+	oldHasSupermajority := oldNumActiveParticipants*3 >= maxActiveParticipants*2
+
+	if newHasSupermajority != oldHasSupermajority {
+		return newHasSupermajority, nil
+	}
+	if !newHasSupermajority && newNumActiveParticipants != oldNumActiveParticipants {
+		return newNumActiveParticipants > oldNumActiveParticipants, nil
+	}
+
+	newUpdateAttestedHeaderBeacon, err := newUpdate.AttestedHeader.GetBeacon()
+	if err != nil {
+		return false, errors.Wrap(err, "could not get attested header beacon")
+	}
+	oldUpdateAttestedHeaderBeacon, err := oldUpdate.AttestedHeader.GetBeacon()
+	if err != nil {
+		return false, errors.Wrap(err, "could not get attested header beacon")
+	}
+
+	// Compare presence of relevant sync committee
+	newHasRelevantSyncCommittee := IsSyncCommitteeUpdate(newUpdate) && (slots.SyncCommitteePeriod(slots.ToEpoch(newUpdateAttestedHeaderBeacon.Slot)) == slots.SyncCommitteePeriod(slots.ToEpoch(newUpdate.SignatureSlot)))
+	oldHasRelevantSyncCommittee := IsSyncCommitteeUpdate(oldUpdate) && (slots.SyncCommitteePeriod(slots.ToEpoch(oldUpdateAttestedHeaderBeacon.Slot)) == slots.SyncCommitteePeriod(slots.ToEpoch(oldUpdate.SignatureSlot)))
+
+	if newHasRelevantSyncCommittee != oldHasRelevantSyncCommittee {
+		return newHasRelevantSyncCommittee, nil
+	}
+
+	// Compare indication of any finality
+	newHasFinality := IsFinalityUpdate(newUpdate)
+	oldHasFinality := IsFinalityUpdate(oldUpdate)
+	if newHasFinality != oldHasFinality {
+		return newHasFinality, nil
+	}
+
+	// Compare sync committee finality
+	if newHasFinality {
+		newUpdateFinalizedHeaderBeacon, err := newUpdate.FinalizedHeader.GetBeacon()
+		if err != nil {
+			return false, errors.Wrap(err, "could not get finalized header beacon")
+		}
+		oldUpdateFinalizedHeaderBeacon, err := oldUpdate.FinalizedHeader.GetBeacon()
+		if err != nil {
+			return false, errors.Wrap(err, "could not get finalized header beacon")
+		}
+		newHasSyncCommitteeFinality := slots.SyncCommitteePeriod(slots.ToEpoch(newUpdateFinalizedHeaderBeacon.Slot)) == slots.SyncCommitteePeriod(slots.ToEpoch(newUpdateAttestedHeaderBeacon.Slot))
+		oldHasSyncCommitteeFinality := slots.SyncCommitteePeriod(slots.ToEpoch(oldUpdateFinalizedHeaderBeacon.Slot)) == slots.SyncCommitteePeriod(slots.ToEpoch(oldUpdateAttestedHeaderBeacon.Slot))
+
+		if newHasSyncCommitteeFinality != oldHasSyncCommitteeFinality {
+			return newHasSyncCommitteeFinality, nil
+		}
+	}
+
+	// Tiebreaker 1: Sync committee participation beyond supermajority
+	if newNumActiveParticipants != oldNumActiveParticipants {
+		return newNumActiveParticipants > oldNumActiveParticipants, nil
+	}
+
+	// Tiebreaker 2: Prefer older data (fewer changes to best)
+	if newUpdateAttestedHeaderBeacon.Slot != oldUpdateAttestedHeaderBeacon.Slot {
+		return newUpdateAttestedHeaderBeacon.Slot < oldUpdateAttestedHeaderBeacon.Slot, nil
+	}
+	return newUpdate.SignatureSlot < oldUpdate.SignatureSlot, nil
+}
+
+// ProcessFinalityUpdate - implements https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#process_light_client_finality_update
+func (s *Store) ProcessFinalityUpdate(update *ethpbv2.LightClientFinalityUpdate, currentSlot primitives.Slot) error {
+	return s.ProcessUpdate(NewLightClientUpdateFromFinalityUpdate(update), currentSlot)
+}
+
+// ProcessOptimisticUpdate - implements https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#process_light_client_optimistic_update
+func (s *Store) ProcessOptimisticUpdate(update *ethpbv2.LightClientOptimisticUpdate, currentSlot primitives.Slot) error {
+	return s.ProcessUpdate(NewLightClientUpdateFromOptimisticUpdate(update), currentSlot)
+}
+
+// ProcessForceUpdate - implements https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#process_light_client_store_force_update
+// When no finalized update was applied for a whole sync committee period, the best valid update is applied
+// with its attested header treated as finalized, so that the light client can make progress.
+func (s *Store) ProcessForceUpdate(currentSlot primitives.Slot) error {
+	cfg := params.BeaconConfig()
+	updateTimeout := primitives.Slot(uint64(cfg.SlotsPerEpoch) * uint64(cfg.EpochsPerSyncCommitteePeriod))
+	if currentSlot <= s.FinalizedSlot()+updateTimeout || s.BestValidUpdate == nil {
+		return nil
+	}
+	update := proto.Clone(s.BestValidUpdate).(*ethpbv2.LightClientUpdate)
+	if update.FinalizedHeader == nil || headerSlot(update.FinalizedHeader) <= s.FinalizedSlot() {
+		update.FinalizedHeader = update.AttestedHeader
+	}
+	if err := s.applyUpdate(update); err != nil {
+		return err
+	}
+	s.BestValidUpdate = nil
+	return nil
+}
+
+// applyUpdate - implements https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#apply_light_client_update
+func (s *Store) applyUpdate(update *ethpbv2.LightClientUpdate) error {
+	storePeriod := slots.SyncCommitteePeriod(slots.ToEpoch(s.FinalizedSlot()))
+	finalizedPeriod := slots.SyncCommitteePeriod(slots.ToEpoch(headerSlot(update.FinalizedHeader)))
+	if !s.isNextSyncCommitteeKnown() {
+		if finalizedPeriod != storePeriod {
+			return fmt.Errorf("finalized period %d does not match store period %d", finalizedPeriod, storePeriod)
+		}
+		s.NextSyncCommittee = nextSyncCommittee(update)
+	} else if finalizedPeriod == storePeriod+1 {
+		s.CurrentSyncCommittee = s.NextSyncCommittee
+		s.NextSyncCommittee = nextSyncCommittee(update)
+		s.PreviousMaxActiveParticipants = s.CurrentMaxActiveParticipants
+		s.CurrentMaxActiveParticipants = 0
+	}
+	if headerSlot(update.FinalizedHeader) > s.FinalizedSlot() {
+		s.FinalizedHeader = update.FinalizedHeader
+		if s.FinalizedSlot() > s.OptimisticSlot() {
+			s.OptimisticHeader = s.FinalizedHeader
+		}
+	}
+	return nil
+}
+
+func (s *Store) isNextSyncCommitteeKnown() bool {
+	return s.NextSyncCommittee != nil
+}
+
+func (s *Store) safetyThreshold() uint64 {
+	return max(s.PreviousMaxActiveParticipants, s.CurrentMaxActiveParticipants) / 2
+}
+
+// nextSyncCommittee returns the next sync committee of the update, or nil when the update does not carry one.
+func nextSyncCommittee(update *ethpbv2.LightClientUpdate) *ethpbv2.SyncCommittee {
+	if !IsSyncCommitteeUpdate(update) {
+		return nil
+	}
+	return update.NextSyncCommittee
+}
+
+// headerSlot returns the beacon slot of the header, or zero for an empty header.
+func headerSlot(header *ethpbv2.LightClientHeaderContainer) primitives.Slot {
+	if header == nil {
+		return 0
+	}
+	beacon, err := header.GetBeacon()
+	if err != nil || beacon == nil {
+		return 0
+	}
+	return beacon.Slot
+}
+
+// ExecutionPayloadHeader returns the execution payload header of a light client header. Altair headers
+// do not carry an execution payload header, in which case consensus_types.ErrUnsupportedField is returned.
+func ExecutionPayloadHeader(header *ethpbv2.LightClientHeaderContainer) (interfaces.ExecutionData, error) {
+	if header == nil {
+		return nil, errors.New("nil light client header")
+	}
+	switch h := header.Header.(type) {
+	case *ethpbv2.LightClientHeaderContainer_HeaderCapella:
+		return blocks.WrappedExecutionPayloadHeaderCapella(h.HeaderCapella.Execution)
+	case *ethpbv2.LightClientHeaderContainer_HeaderDeneb:
+		return blocks.WrappedExecutionPayloadHeaderDeneb(h.HeaderDeneb.Execution)
+	case *ethpbv2.LightClientHeaderContainer_HeaderAltair:
+		return nil, errors.Wrap(consensus_types.ErrUnsupportedField, "execution payload header")
+	default:
+		return nil, fmt.Errorf("unknown header type: %T", h)
+	}
+}
@@ -1,14 +1,27 @@
-package lightclient
+package light_client_test
 
 import (
+	"bytes"
+	"context"
 	"testing"
 
-	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
-
+	"github.com/prysmaticlabs/go-bitfield"
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	consensus_types "github.com/prysmaticlabs/prysm/v5/consensus-types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
 	ethpbv1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"google.golang.org/protobuf/proto"
 )
 
 // When the update has relevant sync committee
@@ -20,7 +33,7 @@ func createNonEmptySyncCommitteeBranch() [][]byte {
 
 // When the update has finality
 func createNonEmptyFinalityBranch() [][]byte {
-	res := make([][]byte, lightclient.FinalityBranchNumOfLeaves)
+	res := make([][]byte, lightClient.FinalityBranchNumOfLeaves)
 	res[0] = []byte("xyz")
 	return res
 }
@@ -167,7 +180,7 @@ func TestIsBetterUpdate(t *testing.T) {
 				},
 				NextSyncCommitteeBranch: createNonEmptySyncCommitteeBranch(),
 				SignatureSlot:           9999,
-				FinalityBranch:          make([][]byte, lightclient.FinalityBranchNumOfLeaves),
+				FinalityBranch:          make([][]byte, lightClient.FinalityBranchNumOfLeaves),
 			},
 			newUpdate: &ethpbv2.LightClientUpdate{
 				SyncAggregate: &ethpbv1.SyncAggregate{
@@ -216,7 +229,7 @@ func TestIsBetterUpdate(t *testing.T) {
 				},
 				NextSyncCommitteeBranch: createNonEmptySyncCommitteeBranch(),
 				SignatureSlot:           9999,
-				FinalityBranch:          make([][]byte, lightclient.FinalityBranchNumOfLeaves),
+				FinalityBranch:          make([][]byte, lightClient.FinalityBranchNumOfLeaves),
 			},
 			expectedResult: false,
 		},
@@ -608,9 +621,254 @@ func TestIsBetterUpdate(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			result, err := IsBetterUpdate(testCase.newUpdate, testCase.oldUpdate)
+			result, err := lightClient.IsBetterUpdate(testCase.newUpdate, testCase.oldUpdate)
 			assert.NoError(t, err)
 			assert.Equal(t, testCase.expectedResult, result)
 		})
 	}
 }
+
+type testCommittee struct {
+	keys      []bls.SecretKey
+	committee *ethpbv2.SyncCommittee
+}
+
+func newTestCommittee(t *testing.T) *testCommittee {
+	c := &testCommittee{
+		keys:      make([]bls.SecretKey, fieldparams.SyncCommitteeLength),
+		committee: &ethpbv2.SyncCommittee{Pubkeys: make([][]byte, fieldparams.SyncCommitteeLength)},
+	}
+	for i := range c.keys {
+		k, err := bls.RandKey()
+		require.NoError(t, err)
+		c.keys[i] = k
+		c.committee.Pubkeys[i] = k.PublicKey().Marshal()
+	}
+	agg, err := bls.AggregatePublicKeys(c.committee.Pubkeys)
+	require.NoError(t, err)
+	c.committee.AggregatePubkey = agg.Marshal()
+	return c
+}
+
+func (c *testCommittee) v1alpha1() *ethpb.SyncCommittee {
+	return &ethpb.SyncCommittee{Pubkeys: c.committee.Pubkeys, AggregatePubkey: c.committee.AggregatePubkey}
+}
+
+// sign returns a sync aggregate over the header signed by the first participants members of the committee.
+func (c *testCommittee) sign(t *testing.T, header *ethpbv1.BeaconBlockHeader, signatureSlot primitives.Slot, participants int) *ethpbv1.SyncAggregate {
+	fork, err := forks.Fork(slots.ToEpoch(signatureSlot - 1))
+	require.NoError(t, err)
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainSyncCommittee, fork.CurrentVersion, testGenesisValidatorsRoot)
+	require.NoError(t, err)
+	root, err := signing.ComputeSigningRoot(header, domain)
+	require.NoError(t, err)
+	participation := bitfield.NewBitvector512()
+	sigs := make([]bls.Signature, participants)
+	for i := 0; i < participants; i++ {
+		participation.SetBitAt(uint64(i), true)
+		sigs[i] = c.keys[i].Sign(root[:])
+	}
+	return &ethpbv1.SyncAggregate{
+		SyncCommitteeBits:      participation,
+		SyncCommitteeSignature: bls.AggregateSignatures(sigs).Marshal(),
+	}
+}
+
+var testGenesisValidatorsRoot = bytes.Repeat([]byte{'A'}, fieldparams.RootLength)
+
+func altairHeader(beacon *ethpbv1.BeaconBlockHeader) *ethpbv2.LightClientHeaderContainer {
+	return &ethpbv2.LightClientHeaderContainer{
+		Header: &ethpbv2.LightClientHeaderContainer_HeaderAltair{
+			HeaderAltair: &ethpbv2.LightClientHeader{Beacon: beacon},
+		},
+	}
+}
+
+func testBeaconHeader(slot primitives.Slot, stateRoot []byte) *ethpbv1.BeaconBlockHeader {
+	return &ethpbv1.BeaconBlockHeader{
+		Slot:       slot,
+		ParentRoot: make([]byte, fieldparams.RootLength),
+		StateRoot:  stateRoot,
+		BodyRoot:   make([]byte, fieldparams.RootLength),
+	}
+}
+
+// testUpdate builds an update attested at attestedSlot, whose attested state carries the next sync
+// committee and the finalized header.
+func testUpdate(t *testing.T, signer, next *testCommittee, finalized *ethpbv1.BeaconBlockHeader, attestedSlot, signatureSlot primitives.Slot) *ethpbv2.LightClientUpdate {
+	ctx := context.Background()
+	finalizedRoot, err := finalized.HashTreeRoot()
+	require.NoError(t, err)
+	st, err := util.NewBeaconStateAltair()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(attestedSlot))
+	require.NoError(t, st.SetNextSyncCommittee(next.v1alpha1()))
+	require.NoError(t, st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: slots.ToEpoch(finalized.Slot), Root: finalizedRoot[:]}))
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	nextBranch, err := st.NextSyncCommitteeProof(ctx)
+	require.NoError(t, err)
+	finalityBranch, err := st.FinalizedRootProof(ctx)
+	require.NoError(t, err)
+
+	attested := testBeaconHeader(attestedSlot, stateRoot[:])
+	return &ethpbv2.LightClientUpdate{
+		AttestedHeader:          altairHeader(attested),
+		NextSyncCommittee:       next.committee,
+		NextSyncCommitteeBranch: nextBranch,
+		FinalizedHeader:         altairHeader(finalized),
+		FinalityBranch:          finalityBranch,
+		SyncAggregate:           signer.sign(t, attested, signatureSlot, fieldparams.SyncCommitteeLength),
+		SignatureSlot:           signatureSlot,
+	}
+}
+
+func testBootstrap(t *testing.T, current *testCommittee, slot primitives.Slot) (*ethpbv2.LightClientBootstrap, [32]byte) {
+	ctx := context.Background()
+	st, err := util.NewBeaconStateAltair()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(slot))
+	require.NoError(t, st.SetCurrentSyncCommittee(current.v1alpha1()))
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	branch, err := st.CurrentSyncCommitteeProof(ctx)
+	require.NoError(t, err)
+	header := testBeaconHeader(slot, stateRoot[:])
+	root, err := header.HashTreeRoot()
+	require.NoError(t, err)
+	return &ethpbv2.LightClientBootstrap{
+		Header:                     altairHeader(header),
+		CurrentSyncCommittee:       current.committee,
+		CurrentSyncCommitteeBranch: branch,
+	}, root
+}
+
+func TestInitializeStore(t *testing.T) {
+	c := newTestCommittee(t)
+	bootstrap, root := testBootstrap(t, c, 8)
+
+	_, err := lightClient.InitializeStore([32]byte{'b'}, bootstrap, testGenesisValidatorsRoot)
+	require.ErrorIs(t, err, lightClient.ErrUntrustedBootstrap)
+
+	bootstrap.CurrentSyncCommitteeBranch[0] = bytes.Repeat([]byte{1}, fieldparams.RootLength)
+	_, err = lightClient.InitializeStore(root, bootstrap, testGenesisValidatorsRoot)
+	require.ErrorIs(t, err, lightClient.ErrInvalidSyncCommitteeBranch)
+
+	for name, setup := range map[string]func(*util.TestLightClient) *util.TestLightClient{
+		"altair":  (*util.TestLightClient).SetupTestAltair,
+		"capella": (*util.TestLightClient).SetupTestCapella,
+		"deneb":   (*util.TestLightClient).SetupTestDeneb,
+	} {
+		t.Run(name, func(t *testing.T) {
+			l := setup(util.NewTestLightClient(t))
+			bootstrap, err := lightClient.NewLightClientBootstrapFromBeaconState(l.Ctx, l.State, l.Block)
+			require.NoError(t, err)
+			root, err := l.Block.Block().HashTreeRoot()
+			require.NoError(t, err)
+			store, err := lightClient.InitializeStore(root, bootstrap, testGenesisValidatorsRoot)
+			require.NoError(t, err)
+			require.Equal(t, l.Block.Block().Slot(), store.FinalizedSlot())
+			require.Equal(t, l.Block.Block().Slot(), store.OptimisticSlot())
+
+			execution, err := lightClient.ExecutionPayloadHeader(store.FinalizedHeader)
+			if name == "altair" {
+				require.ErrorIs(t, err, consensus_types.ErrUnsupportedField)
+				return
+			}
+			require.NoError(t, err)
+			payload, err := l.Block.Block().Body().Execution()
+			require.NoError(t, err)
+			require.DeepEqual(t, payload.BlockHash(), execution.BlockHash())
+		})
+	}
+}
+
+func TestStore_ProcessUpdate(t *testing.T) {
+	current, next := newTestCommittee(t), newTestCommittee(t)
+	bootstrap, root := testBootstrap(t, current, 8)
+	store, err := lightClient.InitializeStore(root, bootstrap, testGenesisValidatorsRoot)
+	require.NoError(t, err)
+
+	finalized := testBeaconHeader(32, bytes.Repeat([]byte{2}, fieldparams.RootLength))
+	update := testUpdate(t, current, next, finalized, 40, 41)
+
+	t.Run("invalid signature", func(t *testing.T) {
+		bad := proto.Clone(update).(*ethpbv2.LightClientUpdate)
+		bad.SyncAggregate = next.sign(t, bad.AttestedHeader.GetHeaderAltair().Beacon, 41, fieldparams.SyncCommitteeLength)
+		require.ErrorIs(t, store.ProcessUpdate(bad, 41), lightClient.ErrInvalidSyncAggregate)
+	})
+	t.Run("invalid finality branch", func(t *testing.T) {
+		bad := proto.Clone(update).(*ethpbv2.LightClientUpdate)
+		bad.FinalizedHeader = altairHeader(testBeaconHeader(33, finalized.StateRoot))
+		require.ErrorIs(t, store.ProcessUpdate(bad, 41), lightClient.ErrInvalidFinalityBranch)
+	})
+	t.Run("invalid next sync committee branch", func(t *testing.T) {
+		bad := proto.Clone(update).(*ethpbv2.LightClientUpdate)
+		bad.NextSyncCommittee = current.committee
+		require.ErrorIs(t, store.ProcessUpdate(bad, 41), lightClient.ErrInvalidSyncCommitteeBranch)
+	})
+	t.Run("signature slot in the future", func(t *testing.T) {
+		require.ErrorContains(t, "invalid update slots", store.ProcessUpdate(update, 40))
+	})
+	require.Equal(t, primitives.Slot(8), store.FinalizedSlot())
+
+	// A finalized update with a supermajority moves the finalized header and learns the next sync committee.
+	require.NoError(t, store.ProcessUpdate(update, 41))
+	require.Equal(t, primitives.Slot(32), store.FinalizedSlot())
+	require.Equal(t, primitives.Slot(40), store.OptimisticSlot())
+	require.DeepEqual(t, next.committee, store.NextSyncCommittee)
+	require.Equal(t, true, store.BestValidUpdate == nil)
+
+	// Updates attested before the finalized header are no longer relevant.
+	stale := testBeaconHeader(20, bytes.Repeat([]byte{3}, fieldparams.RootLength))
+	require.ErrorIs(t, store.ProcessOptimisticUpdate(&ethpbv2.LightClientOptimisticUpdate{
+		AttestedHeader: altairHeader(stale),
+		SyncAggregate:  current.sign(t, stale, 41, fieldparams.SyncCommitteeLength),
+		SignatureSlot:  41,
+	}, 41), lightClient.ErrIrrelevantUpdate)
+
+	// An optimistic update only moves the optimistic header.
+	attested := testBeaconHeader(50, bytes.Repeat([]byte{3}, fieldparams.RootLength))
+	require.NoError(t, store.ProcessOptimisticUpdate(&ethpbv2.LightClientOptimisticUpdate{
+		AttestedHeader: altairHeader(attested),
+		SyncAggregate:  current.sign(t, attested, 51, fieldparams.SyncCommitteeLength),
+		SignatureSlot:  51,
+	}, 51))
+	require.Equal(t, primitives.Slot(32), store.FinalizedSlot())
+	require.Equal(t, primitives.Slot(50), store.OptimisticSlot())
+
+	// Finalizing a header of the next period rotates the sync committees.
+	periodStart := primitives.Slot(uint64(params.BeaconConfig().SlotsPerEpoch) * uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod))
+	finalized = testBeaconHeader(periodStart+32, bytes.Repeat([]byte{4}, fieldparams.RootLength))
+	update = testUpdate(t, next, newTestCommittee(t), finalized, periodStart+40, periodStart+41)
+	require.NoError(t, store.ProcessUpdate(update, periodStart+41))
+	require.Equal(t, periodStart+32, store.FinalizedSlot())
+	require.DeepEqual(t, next.committee, store.CurrentSyncCommittee)
+	require.DeepEqual(t, update.NextSyncCommittee, store.NextSyncCommittee)
+	require.Equal(t, uint64(fieldparams.SyncCommitteeLength), store.PreviousMaxActiveParticipants)
+}
+
+func TestStore_ProcessForceUpdate(t *testing.T) {
+	current, next := newTestCommittee(t), newTestCommittee(t)
+	bootstrap, root := testBootstrap(t, current, 8)
+	store, err := lightClient.InitializeStore(root, bootstrap, testGenesisValidatorsRoot)
+	require.NoError(t, err)
+
+	// Without a supermajority, the update is only kept as the best valid update.
+	finalized := testBeaconHeader(32, bytes.Repeat([]byte{2}, fieldparams.RootLength))
+	update := testUpdate(t, current, next, finalized, 40, 41)
+	update.SyncAggregate = current.sign(t, update.AttestedHeader.GetHeaderAltair().Beacon, 41, fieldparams.SyncCommitteeLength/2)
+	require.NoError(t, store.ProcessUpdate(update, 41))
+	require.Equal(t, primitives.Slot(8), store.FinalizedSlot())
+	require.NotNil(t, store.BestValidUpdate)
+
+	cfg := params.BeaconConfig()
+	timeout := primitives.Slot(uint64(cfg.SlotsPerEpoch) * uint64(cfg.EpochsPerSyncCommitteePeriod))
+	require.NoError(t, store.ProcessForceUpdate(8+timeout))
+	require.Equal(t, primitives.Slot(8), store.FinalizedSlot())
+
+	require.NoError(t, store.ProcessForceUpdate(9+timeout))
+	require.Equal(t, primitives.Slot(32), store.FinalizedSlot())
+	require.DeepEqual(t, next.committee, store.NextSyncCommittee)
+	require.Equal(t, true, store.BestValidUpdate == nil)
+}
@@ -0,0 +1,222 @@
+package light_client
+
+import (
+	"bytes"
+	"fmt"
+	"math/bits"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/container/trie"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// Generalized indices of the objects proven by light client branches.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#constants
+const (
+	FinalizedRootGeneralizedIndex        = uint64(105)
+	CurrentSyncCommitteeGeneralizedIndex = uint64(54)
+	NextSyncCommitteeGeneralizedIndex    = uint64(55)
+	ExecutionPayloadGeneralizedIndex     = uint64(25)
+)
+
+var (
+	ErrInvalidExecutionBranch     = errors.New("invalid execution payload branch")
+	ErrInvalidSyncCommitteeBranch = errors.New("invalid sync committee branch")
+	ErrInvalidFinalityBranch      = errors.New("invalid finality branch")
+	ErrInvalidSyncAggregate       = errors.New("invalid sync committee signature")
+)
+
+// IsValidLightClientHeader - implements https://github.com/ethereum/consensus-specs/blob/dev/specs/capella/light-client/sync-protocol.md#modified-is_valid_light_client_header
+// Altair headers carry no execution payload and are always valid, Capella and later headers must prove their
+// execution payload header against the beacon block body root.
+func IsValidLightClientHeader(header *ethpbv2.LightClientHeaderContainer) error {
+	if header == nil {
+		return errors.New("nil light client header")
+	}
+	var beacon *ethpbv1.BeaconBlockHeader
+	var executionRoot [32]byte
+	var branch [][]byte
+	var err error
+	switch h := header.Header.(type) {
+	case *ethpbv2.LightClientHeaderContainer_HeaderAltair:
+		if h.HeaderAltair.Beacon == nil {
+			return errors.New("nil beacon header")
+		}
+		return nil
+	case *ethpbv2.LightClientHeaderContainer_HeaderCapella:
+		if h.HeaderCapella.Beacon == nil || h.HeaderCapella.Execution == nil {
+			return errors.New("nil beacon or execution header")
+		}
+		beacon, branch = h.HeaderCapella.Beacon, h.HeaderCapella.ExecutionBranch
+		executionRoot, err = h.HeaderCapella.Execution.HashTreeRoot()
+	case *ethpbv2.LightClientHeaderContainer_HeaderDeneb:
+		if h.HeaderDeneb.Beacon == nil || h.HeaderDeneb.Execution == nil {
+			return errors.New("nil beacon or execution header")
+		}
+		beacon, branch = h.HeaderDeneb.Beacon, h.HeaderDeneb.ExecutionBranch
+		executionRoot, err = h.HeaderDeneb.Execution.HashTreeRoot()
+	default:
+		return fmt.Errorf("unknown header type: %T", h)
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not compute execution payload header root")
+	}
+	if slots.ToEpoch(beacon.Slot) < params.BeaconConfig().CapellaForkEpoch {
+		// Pre-Capella headers of a later fork carry an empty execution payload header and branch.
+		if !isEmptyBranch(branch) {
+			return ErrInvalidExecutionBranch
+		}
+		return nil
+	}
+	if !isValidMerkleBranch(executionRoot[:], branch, ExecutionPayloadGeneralizedIndex, beacon.BodyRoot) {
+		return ErrInvalidExecutionBranch
+	}
+	return nil
+}
+
+// VerifyCurrentSyncCommitteeBranch verifies that the sync committee is the current sync committee
+// in the state with the given root.
+func VerifyCurrentSyncCommitteeBranch(committee *ethpbv2.SyncCommittee, branch [][]byte, stateRoot []byte) error {
+	return verifySyncCommitteeBranch(committee, branch, CurrentSyncCommitteeGeneralizedIndex, stateRoot)
+}
+
+// VerifyNextSyncCommitteeBranch verifies that the sync committee is the next sync committee
+// in the state with the given root.
+func VerifyNextSyncCommitteeBranch(committee *ethpbv2.SyncCommittee, branch [][]byte, stateRoot []byte) error {
+	return verifySyncCommitteeBranch(committee, branch, NextSyncCommitteeGeneralizedIndex, stateRoot)
+}
+
+func verifySyncCommitteeBranch(committee *ethpbv2.SyncCommittee, branch [][]byte, gIndex uint64, stateRoot []byte) error {
+	if committee == nil {
+		return errors.New("nil sync committee")
+	}
+	root, err := committee.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute sync committee root")
+	}
+	if !isValidMerkleBranch(root[:], branch, gIndex, stateRoot) {
+		return ErrInvalidSyncCommitteeBranch
+	}
+	return nil
+}
+
+// VerifyFinalityBranch verifies that the finalized header is the finalized checkpoint of the state
+// with the given root. The genesis finalized checkpoint is represented by a zero root.
+func VerifyFinalityBranch(finalizedHeader *ethpbv2.LightClientHeaderContainer, branch [][]byte, stateRoot []byte) error {
+	if finalizedHeader == nil {
+		return errors.New("nil finalized header")
+	}
+	beacon, err := finalizedHeader.GetBeacon()
+	if err != nil {
+		return err
+	}
+	if beacon == nil {
+		return errors.New("nil finalized beacon header")
+	}
+	var finalizedRoot [32]byte
+	if beacon.Slot != params.BeaconConfig().GenesisSlot {
+		if err := IsValidLightClientHeader(finalizedHeader); err != nil {
+			return errors.Wrap(err, "invalid finalized header")
+		}
+		finalizedRoot, err = beacon.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not compute finalized header root")
+		}
+	}
+	if !isValidMerkleBranch(finalizedRoot[:], branch, FinalizedRootGeneralizedIndex, stateRoot) {
+		return ErrInvalidFinalityBranch
+	}
+	return nil
+}
+
+// VerifySyncAggregate verifies the sync committee signature over the attested header. The fork version
+// used for the signing domain is the one of the slot preceding the signature slot.
+func VerifySyncAggregate(
+	committee *ethpbv2.SyncCommittee,
+	aggregate *ethpbv1.SyncAggregate,
+	attestedHeader *ethpbv1.BeaconBlockHeader,
+	signatureSlot primitives.Slot,
+	genesisValidatorsRoot []byte,
+) error {
+	if committee == nil || aggregate == nil || attestedHeader == nil {
+		return errors.New("nil sync committee, sync aggregate or attested header")
+	}
+	participation := aggregate.SyncCommitteeBits
+	if participation.Len() != uint64(len(committee.Pubkeys)) {
+		return fmt.Errorf("sync committee bits length %d does not match committee size %d", participation.Len(), len(committee.Pubkeys))
+	}
+	pubkeys := make([]bls.PublicKey, 0, participation.Count())
+	for _, i := range participation.BitIndices() {
+		pk, err := bls.PublicKeyFromBytes(committee.Pubkeys[i])
+		if err != nil {
+			return errors.Wrapf(err, "could not parse public key of sync committee member %d", i)
+		}
+		pubkeys = append(pubkeys, pk)
+	}
+	sig, err := bls.SignatureFromBytes(aggregate.SyncCommitteeSignature)
+	if err != nil {
+		return errors.Wrap(err, "could not parse sync committee signature")
+	}
+
+	forkVersionSlot := signatureSlot
+	if forkVersionSlot > 0 {
+		forkVersionSlot--
+	}
+	fork, err := forks.Fork(slots.ToEpoch(forkVersionSlot))
+	if err != nil {
+		return errors.Wrap(err, "could not get fork version")
+	}
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainSyncCommittee, fork.CurrentVersion, genesisValidatorsRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not compute signing domain")
+	}
+	signingRoot, err := signing.ComputeSigningRoot(attestedHeader, domain)
+	if err != nil {
+		return errors.Wrap(err, "could not compute signing root")
+	}
+	if !sig.FastAggregateVerify(pubkeys, signingRoot) {
+		return ErrInvalidSyncAggregate
+	}
+	return nil
+}
+
+// IsSyncCommitteeUpdate returns true if the update carries a next sync committee branch.
+func IsSyncCommitteeUpdate(update *ethpbv2.LightClientUpdate) bool {
+	return !isEmptyBranch(update.NextSyncCommitteeBranch)
+}
+
+// IsFinalityUpdate returns true if the update carries a finality branch.
+func IsFinalityUpdate(update *ethpbv2.LightClientUpdate) bool {
+	return !isEmptyBranch(update.FinalityBranch)
+}
+
+// isValidMerkleBranch checks a branch proving the leaf at the generalized index against the root.
+func isValidMerkleBranch(leaf []byte, branch [][]byte, gIndex uint64, root []byte) bool {
+	if len(branch) != bits.Len64(gIndex)-1 {
+		return false
+	}
+	for _, node := range branch {
+		if len(node) != fieldparams.RootLength {
+			return false
+		}
+	}
+	return trie.VerifyMerkleProof(root, leaf, gIndex, branch)
+}
+
+// isEmptyBranch returns true if the branch has no nodes or only zero nodes.
+func isEmptyBranch(branch [][]byte) bool {
+	for _, node := range branch {
+		if len(bytes.Trim(node, "\x00")) != 0 {
+			return false
+		}
+	}
+	return true
+}
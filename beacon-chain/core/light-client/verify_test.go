@@ -0,0 +1,42 @@
+package light_client_test
+
+import (
+	"bytes"
+	"testing"
+
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestIsValidLightClientHeader(t *testing.T) {
+	l := util.NewTestLightClient(t).SetupTestDeneb()
+	bootstrap, err := lightClient.NewLightClientBootstrapFromBeaconState(l.Ctx, l.State, l.Block)
+	require.NoError(t, err)
+	require.NoError(t, lightClient.IsValidLightClientHeader(bootstrap.Header))
+
+	header := bootstrap.Header.GetHeaderDeneb()
+	header.Execution.BlockNumber++
+	require.ErrorIs(t, lightClient.IsValidLightClientHeader(bootstrap.Header), lightClient.ErrInvalidExecutionBranch)
+	header.Execution.BlockNumber--
+
+	header.ExecutionBranch = header.ExecutionBranch[1:]
+	require.ErrorIs(t, lightClient.IsValidLightClientHeader(bootstrap.Header), lightClient.ErrInvalidExecutionBranch)
+
+	require.ErrorContains(t, "nil light client header", lightClient.IsValidLightClientHeader(nil))
+}
+
+func TestVerifySyncAggregate(t *testing.T) {
+	c := newTestCommittee(t)
+	header := testBeaconHeader(10, bytes.Repeat([]byte{1}, fieldparams.RootLength))
+	aggregate := c.sign(t, header, 11, 10)
+	require.NoError(t, lightClient.VerifySyncAggregate(c.committee, aggregate, header, 11, testGenesisValidatorsRoot))
+
+	// The signing domain depends on the genesis validators root.
+	require.ErrorIs(t, lightClient.VerifySyncAggregate(c.committee, aggregate, header, 11, make([]byte, fieldparams.RootLength)), lightClient.ErrInvalidSyncAggregate)
+
+	// The participation must match the signers.
+	aggregate.SyncCommitteeBits.SetBitAt(10, true)
+	require.ErrorIs(t, lightClient.VerifySyncAggregate(c.committee, aggregate, header, 11, testGenesisValidatorsRoot), lightClient.ErrInvalidSyncAggregate)
+}
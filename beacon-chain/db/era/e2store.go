@@ -0,0 +1,124 @@
+package era
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// e2store entries are a simple type-length-value encoding. Every entry starts with an 8 byte header
+// containing a 2 byte type, a 4 byte little-endian length and 2 reserved bytes that must be zero.
+// See https://github.com/status-im/nimbus-eth2/blob/stable/docs/e2store.md.
+const headerSize = 8
+
+// EntryType identifies the kind of data held in an e2store entry.
+type EntryType [2]byte
+
+var (
+	// TypeEmpty is an entry with no meaning, which readers must skip.
+	TypeEmpty = EntryType{0x00, 0x00}
+	// TypeCompressedSignedBeaconBlock holds a snappy framed, ssz encoded SignedBeaconBlock.
+	TypeCompressedSignedBeaconBlock = EntryType{0x01, 0x00}
+	// TypeCompressedBeaconState holds a snappy framed, ssz encoded BeaconState.
+	TypeCompressedBeaconState = EntryType{0x02, 0x00}
+	// TypeVersion marks the start of an e2store group and must be the first entry in a file.
+	TypeVersion = EntryType{0x65, 0x32}
+	// TypeSlotIndex holds the offsets of the entries in a group, indexed by slot.
+	TypeSlotIndex = EntryType{0x69, 0x32}
+)
+
+var (
+	errReservedNotZero = errors.New("e2store entry header reserved bytes are not zero")
+	errEntryTooLarge   = errors.New("e2store entry is larger than the maximum entry size")
+)
+
+// Entry is a single e2store record.
+type Entry struct {
+	Type EntryType
+	Data []byte
+}
+
+func (t EntryType) String() string {
+	switch t {
+	case TypeEmpty:
+		return "empty"
+	case TypeCompressedSignedBeaconBlock:
+		return "compressed_signed_beacon_block"
+	case TypeCompressedBeaconState:
+		return "compressed_beacon_state"
+	case TypeVersion:
+		return "version"
+	case TypeSlotIndex:
+		return "slot_index"
+	default:
+		return fmt.Sprintf("unknown(%#x)", t[:])
+	}
+}
+
+// e2Writer appends e2store entries to an underlying io.Writer, keeping track of the offset
+// of each entry so that slot indices can be computed.
+type e2Writer struct {
+	w      io.Writer
+	offset int64
+}
+
+func newE2Writer(w io.Writer) *e2Writer {
+	return &e2Writer{w: w}
+}
+
+// write appends a single entry and returns the offset of its header relative to the start of the stream.
+func (w *e2Writer) write(t EntryType, data []byte) (int64, error) {
+	if uint64(len(data)) > maxEntrySize {
+		return 0, errors.Wrapf(errEntryTooLarge, "type=%s, size=%d", t, len(data))
+	}
+	var header [headerSize]byte
+	copy(header[:2], t[:])
+	binary.LittleEndian.PutUint32(header[2:6], uint32(len(data)))
+	pos := w.offset
+	n, err := w.w.Write(header[:])
+	w.offset += int64(n)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not write e2store entry header")
+	}
+	n, err = w.w.Write(data)
+	w.offset += int64(n)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not write e2store entry data")
+	}
+	return pos, nil
+}
+
+// maxEntrySize is the largest entry that can be described by the 4 byte length field of an entry header.
+const maxEntrySize = uint64(^uint32(0))
+
+// readEntryAt reads the entry whose header starts at the given offset.
+func readEntryAt(r io.ReaderAt, off int64) (*Entry, error) {
+	t, size, err := readEntryHeaderAt(r, off)
+	if err != nil {
+		return nil, err
+	}
+	e := &Entry{Type: t, Data: make([]byte, size)}
+	if size == 0 {
+		return e, nil
+	}
+	if _, err := r.ReadAt(e.Data, off+headerSize); err != nil {
+		return nil, errors.Wrapf(err, "could not read e2store %s entry data at offset %d", t, off)
+	}
+	return e, nil
+}
+
+// readEntryHeaderAt reads only the type and length of the entry at the given offset.
+func readEntryHeaderAt(r io.ReaderAt, off int64) (EntryType, uint32, error) {
+	var header [headerSize]byte
+	if _, err := r.ReadAt(header[:], off); err != nil {
+		return EntryType{}, 0, errors.Wrapf(err, "could not read e2store entry header at offset %d", off)
+	}
+	if header[6] != 0 || header[7] != 0 {
+		return EntryType{}, 0, errors.Wrapf(errReservedNotZero, "offset=%d", off)
+	}
+	var t EntryType
+	copy(t[:], header[:2])
+	return t, binary.LittleEndian.Uint32(header[2:6]), nil
+}
@@ -0,0 +1,509 @@
+package era
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// An era file is an e2store file holding the blocks of SLOTS_PER_HISTORICAL_ROOT slots together with
+// the state at the end of that span. Era N contains the blocks in slots [(N-1)*SLOTS_PER_HISTORICAL_ROOT,
+// N*SLOTS_PER_HISTORICAL_ROOT) and the canonical state at slot N*SLOTS_PER_HISTORICAL_ROOT. Era 0 only contains
+// the genesis state. The layout of a file is:
+//
+//	Version | block* | state | slot-index(block)? | slot-index(state)
+//
+// See https://github.com/status-im/nimbus-eth2/blob/stable/docs/e2store.md#era-files.
+const extension = ".era"
+
+var (
+	// ErrNotFound is returned when an era file does not contain an entry for the requested slot.
+	ErrNotFound = errors.New("no entry in era file")
+	// ErrInvalidEra is returned for files that do not follow the era layout.
+	ErrInvalidEra = errors.New("invalid era file")
+)
+
+var fileNameRegex = regexp.MustCompile(`^(.+)-(\d{5,})-([0-9a-f]{8})\.era$`)
+
+// SlotsPerEra is the number of block slots covered by a single era file.
+func SlotsPerEra() primitives.Slot {
+	return params.BeaconConfig().SlotsPerHistoricalRoot
+}
+
+// StateSlot is the slot of the state stored in the given era.
+func StateSlot(era uint64) primitives.Slot {
+	return primitives.Slot(era) * SlotsPerEra()
+}
+
+// BlockStartSlot is the lowest slot for which the given era may hold a block. Era 0 holds no blocks,
+// so its start slot is equal to its state slot.
+func BlockStartSlot(era uint64) primitives.Slot {
+	if era == 0 {
+		return 0
+	}
+	return StateSlot(era - 1)
+}
+
+// ForSlot returns the number of the era holding the block at the given slot.
+func ForSlot(slot primitives.Slot) uint64 {
+	return uint64(slot/SlotsPerEra()) + 1
+}
+
+// FileName computes the standard era file name: <config-name>-<era-number>-<short-historical-root>.era.
+func FileName(configName string, era uint64, historicalRoot [32]byte) string {
+	return fmt.Sprintf("%s-%05d-%x%s", configName, era, historicalRoot[:4], extension)
+}
+
+// ParseFileName extracts the config name and era number from a standard era file name.
+func ParseFileName(name string) (string, uint64, error) {
+	m := fileNameRegex.FindStringSubmatch(filepath.Base(name))
+	if m == nil {
+		return "", 0, errors.Wrapf(ErrInvalidEra, "unrecognized file name %s", name)
+	}
+	era, err := strconv.ParseUint(m[2], 10, 64)
+	if err != nil {
+		return "", 0, errors.Wrapf(ErrInvalidEra, "unrecognized era number in file name %s", name)
+	}
+	return m[1], era, nil
+}
+
+// ListFiles returns the era files found in dir for the given config name, keyed by era number.
+func ListFiles(dir, configName string) (map[uint64]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read era directory %s", dir)
+	}
+	files := make(map[uint64]string)
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != extension {
+			continue
+		}
+		name, era, err := ParseFileName(e.Name())
+		if err != nil {
+			log.WithError(err).WithField("file", e.Name()).Debug("Skipping file with unexpected name in era directory")
+			continue
+		}
+		if name != configName {
+			continue
+		}
+		if prev, ok := files[era]; ok {
+			return nil, fmt.Errorf("found more than one file for era %d: %s, %s", era, prev, e.Name())
+		}
+		files[era] = filepath.Join(dir, e.Name())
+	}
+	return files, nil
+}
+
+// SortedEras returns the era numbers of a ListFiles result in ascending order.
+func SortedEras(files map[uint64]string) []uint64 {
+	eras := make([]uint64, 0, len(files))
+	for e := range files {
+		eras = append(eras, e)
+	}
+	sort.Slice(eras, func(i, j int) bool { return eras[i] < eras[j] })
+	return eras
+}
+
+// HistoricalRoot computes the root used in the name of an era file from the state of that era.
+// For era 0 this is the genesis validators root; afterwards it is the historical root for the era
+// preceding the state, or the hash tree root of the corresponding historical summary after Capella.
+func HistoricalRoot(era uint64, st state.ReadOnlyBeaconState) ([32]byte, error) {
+	if era == 0 {
+		var r [32]byte
+		copy(r[:], st.GenesisValidatorsRoot())
+		return r, nil
+	}
+	idx := era - 1
+	roots, err := st.HistoricalRoots()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if idx < uint64(len(roots)) {
+		var r [32]byte
+		copy(r[:], roots[idx])
+		return r, nil
+	}
+	if st.Version() < version.Capella {
+		return [32]byte{}, fmt.Errorf("state at slot %d has no historical root for era %d", st.Slot(), era)
+	}
+	summaries, err := st.HistoricalSummaries()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	idx -= uint64(len(roots))
+	if idx >= uint64(len(summaries)) {
+		return [32]byte{}, fmt.Errorf("state at slot %d has no historical summary for era %d", st.Slot(), era)
+	}
+	return summaries[idx].HashTreeRoot()
+}
+
+// slotIndex records the offsets of the entries for a contiguous range of slots, relative to the
+// position of the slot index entry itself. Slots without an entry have an offset of zero.
+type slotIndex struct {
+	start   primitives.Slot
+	offsets []int64
+}
+
+func (si *slotIndex) marshal() []byte {
+	buf := make([]byte, 8*(len(si.offsets)+2))
+	binary.LittleEndian.PutUint64(buf, uint64(si.start))
+	for i, o := range si.offsets {
+		binary.LittleEndian.PutUint64(buf[8*(i+1):], uint64(o))
+	}
+	binary.LittleEndian.PutUint64(buf[len(buf)-8:], uint64(len(si.offsets)))
+	return buf
+}
+
+func unmarshalSlotIndex(b []byte) (*slotIndex, error) {
+	if len(b) < 16 || len(b)%8 != 0 {
+		return nil, errors.Wrapf(ErrInvalidEra, "slot index has invalid size %d", len(b))
+	}
+	count := binary.LittleEndian.Uint64(b[len(b)-8:])
+	if count != uint64(len(b)/8-2) {
+		return nil, errors.Wrapf(ErrInvalidEra, "slot index count %d does not match size %d", count, len(b))
+	}
+	si := &slotIndex{
+		start:   primitives.Slot(binary.LittleEndian.Uint64(b)),
+		offsets: make([]int64, count),
+	}
+	for i := range si.offsets {
+		si.offsets[i] = int64(binary.LittleEndian.Uint64(b[8*(i+1):]))
+	}
+	return si, nil
+}
+
+// slotIndexSize is the size in bytes of a complete slot index entry, including its header.
+func slotIndexSize(count uint64) int64 {
+	return headerSize + int64(8*(count+2))
+}
+
+func compress(b []byte) ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, len(b)/2))
+	w := snappy.NewBufferedWriter(buf)
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(b []byte) ([]byte, error) {
+	return io.ReadAll(snappy.NewReader(bytes.NewReader(b)))
+}
+
+// Writer builds a single era file. Blocks must be added in increasing slot order, followed by the state.
+type Writer struct {
+	era       uint64
+	w         *e2Writer
+	blocks    []int64
+	state     int64
+	lastSlot  primitives.Slot
+	hasBlocks bool
+	done      bool
+}
+
+// NewWriter initializes a Writer for the given era and writes the leading version entry.
+func NewWriter(w io.Writer, era uint64) (*Writer, error) {
+	ew := &Writer{
+		era:   era,
+		w:     newE2Writer(w),
+		state: -1,
+	}
+	if era > 0 {
+		ew.blocks = make([]int64, SlotsPerEra())
+	}
+	if _, err := ew.w.write(TypeVersion, nil); err != nil {
+		return nil, err
+	}
+	return ew, nil
+}
+
+// AddBlock appends a block to the era file.
+func (w *Writer) AddBlock(b interfaces.ReadOnlySignedBeaconBlock) error {
+	if w.state >= 0 {
+		return errors.New("blocks can not be added to an era file after its state")
+	}
+	if b.IsBlinded() {
+		return fmt.Errorf("block at slot %d is blinded, era files require full execution payloads", b.Block().Slot())
+	}
+	slot := b.Block().Slot()
+	start := BlockStartSlot(w.era)
+	if w.era == 0 || slot < start || slot >= StateSlot(w.era) {
+		return fmt.Errorf("block at slot %d does not belong to era %d", slot, w.era)
+	}
+	if w.hasBlocks && slot <= w.lastSlot {
+		return fmt.Errorf("block at slot %d added after block at slot %d", slot, w.lastSlot)
+	}
+	enc, err := b.MarshalSSZ()
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal block at slot %d", slot)
+	}
+	data, err := compress(enc)
+	if err != nil {
+		return errors.Wrapf(err, "could not compress block at slot %d", slot)
+	}
+	pos, err := w.w.write(TypeCompressedSignedBeaconBlock, data)
+	if err != nil {
+		return err
+	}
+	w.blocks[slot-start] = pos
+	w.lastSlot = slot
+	w.hasBlocks = true
+	return nil
+}
+
+// SetState appends the era state. It must be the canonical state at StateSlot(era).
+func (w *Writer) SetState(st state.ReadOnlyBeaconState) error {
+	if w.state >= 0 {
+		return errors.New("era file state has already been written")
+	}
+	if st.Slot() != StateSlot(w.era) {
+		return fmt.Errorf("state at slot %d does not belong to era %d", st.Slot(), w.era)
+	}
+	enc, err := st.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal era state")
+	}
+	data, err := compress(enc)
+	if err != nil {
+		return errors.Wrap(err, "could not compress era state")
+	}
+	pos, err := w.w.write(TypeCompressedBeaconState, data)
+	if err != nil {
+		return err
+	}
+	w.state = pos
+	return nil
+}
+
+// Finish writes the slot indices that terminate the era file.
+func (w *Writer) Finish() error {
+	if w.done {
+		return nil
+	}
+	if w.state < 0 {
+		return errors.New("era file can not be finished without a state")
+	}
+	if w.era > 0 {
+		si := &slotIndex{start: BlockStartSlot(w.era), offsets: make([]int64, len(w.blocks))}
+		pos := w.w.offset
+		for i, o := range w.blocks {
+			if o != 0 {
+				si.offsets[i] = o - pos
+			}
+		}
+		if _, err := w.w.write(TypeSlotIndex, si.marshal()); err != nil {
+			return err
+		}
+	}
+	si := &slotIndex{start: StateSlot(w.era), offsets: []int64{w.state - w.w.offset}}
+	if _, err := w.w.write(TypeSlotIndex, si.marshal()); err != nil {
+		return err
+	}
+	w.done = true
+	return nil
+}
+
+// ReaderAtCloser is the file handle interface needed by Reader.
+type ReaderAtCloser interface {
+	io.ReaderAt
+	io.Closer
+}
+
+// Reader provides random access to the blocks and state in an era file.
+type Reader struct {
+	era        uint64
+	r          ReaderAtCloser
+	blockIndex *slotIndex
+	blockPos   int64
+	stateIndex *slotIndex
+	statePos   int64
+}
+
+// Open opens the era file at the given path.
+func Open(path string) (*Reader, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is provided by the node operator.
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		closeFile(f)
+		return nil, err
+	}
+	r, err := NewReader(f, fi.Size())
+	if err != nil {
+		closeFile(f)
+		return nil, errors.Wrapf(err, "could not read era file %s", path)
+	}
+	return r, nil
+}
+
+func closeFile(f *os.File) {
+	if err := f.Close(); err != nil {
+		log.WithError(err).WithField("file", f.Name()).Error("Could not close era file")
+	}
+}
+
+// NewReader parses the slot indices at the end of an era file of the given size.
+func NewReader(r ReaderAtCloser, size int64) (*Reader, error) {
+	t, _, err := readEntryHeaderAt(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if t != TypeVersion {
+		return nil, errors.Wrapf(ErrInvalidEra, "first entry has type %s, expected %s", t, TypeVersion)
+	}
+	er := &Reader{r: r}
+	er.stateIndex, er.statePos, err = readTrailingIndex(r, size)
+	if err != nil {
+		return nil, err
+	}
+	if len(er.stateIndex.offsets) != 1 {
+		return nil, errors.Wrapf(ErrInvalidEra, "state index has %d entries", len(er.stateIndex.offsets))
+	}
+	if er.stateIndex.start%SlotsPerEra() != 0 {
+		return nil, errors.Wrapf(ErrInvalidEra, "state slot %d is not at an era boundary", er.stateIndex.start)
+	}
+	er.era = uint64(er.stateIndex.start / SlotsPerEra())
+	if er.era == 0 {
+		return er, nil
+	}
+	er.blockIndex, er.blockPos, err = readTrailingIndex(r, er.statePos)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read block index")
+	}
+	if er.blockIndex.start != BlockStartSlot(er.era) || len(er.blockIndex.offsets) != int(SlotsPerEra()) {
+		return nil, errors.Wrapf(ErrInvalidEra, "block index for slots [%d, %d) does not match era %d",
+			er.blockIndex.start, er.blockIndex.start+primitives.Slot(len(er.blockIndex.offsets)), er.era)
+	}
+	return er, nil
+}
+
+// readTrailingIndex reads the slot index entry that ends at the given offset.
+func readTrailingIndex(r io.ReaderAt, end int64) (*slotIndex, int64, error) {
+	if end < slotIndexSize(1) {
+		return nil, 0, errors.Wrap(ErrInvalidEra, "file too small to hold a slot index")
+	}
+	var cb [8]byte
+	if _, err := r.ReadAt(cb[:], end-8); err != nil {
+		return nil, 0, errors.Wrap(err, "could not read slot index count")
+	}
+	count := binary.LittleEndian.Uint64(cb[:])
+	if count > uint64(end) {
+		return nil, 0, errors.Wrapf(ErrInvalidEra, "slot index count %d is larger than the file", count)
+	}
+	pos := end - slotIndexSize(count)
+	if pos < 0 {
+		return nil, 0, errors.Wrapf(ErrInvalidEra, "slot index count %d is larger than the file", count)
+	}
+	e, err := readEntryAt(r, pos)
+	if err != nil {
+		return nil, 0, err
+	}
+	if e.Type != TypeSlotIndex {
+		return nil, 0, errors.Wrapf(ErrInvalidEra, "entry at offset %d has type %s, expected %s", pos, e.Type, TypeSlotIndex)
+	}
+	si, err := unmarshalSlotIndex(e.Data)
+	if err != nil {
+		return nil, 0, err
+	}
+	return si, pos, nil
+}
+
+// Era returns the era number of the file.
+func (r *Reader) Era() uint64 {
+	return r.era
+}
+
+// Close closes the underlying file.
+func (r *Reader) Close() error {
+	return r.r.Close()
+}
+
+// State decodes the era state.
+func (r *Reader) State() (state.BeaconState, error) {
+	enc, err := r.readCompressed(r.statePos+r.stateIndex.offsets[0], TypeCompressedBeaconState)
+	if err != nil {
+		return nil, err
+	}
+	vu, err := detect.FromState(enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not detect era state version")
+	}
+	return vu.UnmarshalBeaconState(enc)
+}
+
+// Block decodes the block at the given slot. ErrNotFound is returned for empty slots.
+func (r *Reader) Block(slot primitives.Slot) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	if r.blockIndex == nil || slot < r.blockIndex.start || slot >= r.blockIndex.start+primitives.Slot(len(r.blockIndex.offsets)) {
+		return nil, errors.Wrapf(ErrNotFound, "slot %d is outside of era %d", slot, r.era)
+	}
+	off := r.blockIndex.offsets[slot-r.blockIndex.start]
+	if off == 0 {
+		return nil, errors.Wrapf(ErrNotFound, "no block at slot %d", slot)
+	}
+	enc, err := r.readCompressed(r.blockPos+off, TypeCompressedSignedBeaconBlock)
+	if err != nil {
+		return nil, err
+	}
+	vu, err := detect.FromBlock(enc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not detect version of block at slot %d", slot)
+	}
+	blk, err := vu.UnmarshalBeaconBlock(enc)
+	if err != nil {
+		return nil, err
+	}
+	if blk.Block().Slot() != slot {
+		return nil, errors.Wrapf(ErrInvalidEra, "block indexed at slot %d has slot %d", slot, blk.Block().Slot())
+	}
+	return blk, nil
+}
+
+// Blocks decodes all blocks in the era file, in increasing slot order.
+func (r *Reader) Blocks() ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	if r.blockIndex == nil {
+		return nil, nil
+	}
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, 0)
+	for i, off := range r.blockIndex.offsets {
+		if off == 0 {
+			continue
+		}
+		b, err := r.Block(r.blockIndex.start + primitives.Slot(i))
+		if err != nil {
+			return nil, err
+		}
+		blks = append(blks, b)
+	}
+	return blks, nil
+}
+
+func (r *Reader) readCompressed(pos int64, t EntryType) ([]byte, error) {
+	e, err := readEntryAt(r.r, pos)
+	if err != nil {
+		return nil, err
+	}
+	if e.Type != t {
+		return nil, errors.Wrapf(ErrInvalidEra, "entry at offset %d has type %s, expected %s", pos, e.Type, t)
+	}
+	return decompress(e.Data)
+}
@@ -0,0 +1,181 @@
+package era
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type nopCloser struct {
+	*bytes.Reader
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// testChain builds blocks at the given slots of era 1, each the child of the previous one, starting from parent.
+// It also returns a state at the era 1 state slot whose block_roots are consistent with the blocks.
+func testChain(t *testing.T, parent [32]byte, gvr []byte, blockSlots []primitives.Slot) ([]interfaces.ReadOnlySignedBeaconBlock, state.BeaconState) {
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, 0, len(blockSlots))
+	rootAt := make(map[primitives.Slot][32]byte)
+	for _, s := range blockSlots {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = s
+		b.Block.ParentRoot = parent[:]
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		parent, err = wsb.Block().HashTreeRoot()
+		require.NoError(t, err)
+		rootAt[s] = parent
+		blks = append(blks, wsb)
+	}
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(StateSlot(1)))
+	require.NoError(t, st.SetGenesisValidatorsRoot(gvr))
+	require.NoError(t, st.SetHistoricalRoots([][]byte{bytesutil.PadTo([]byte{0xde, 0xad, 0xbe, 0xef}, 32)}))
+	roots := make([][]byte, SlotsPerEra())
+	var latest [32]byte
+	for s := primitives.Slot(0); s < SlotsPerEra(); s++ {
+		if r, ok := rootAt[s]; ok {
+			latest = r
+		}
+		roots[s] = bytesutil.SafeCopyBytes(latest[:])
+	}
+	require.NoError(t, st.SetBlockRoots(roots))
+	return blks, st
+}
+
+func writeTestEra(t *testing.T, era uint64, blks []interfaces.ReadOnlySignedBeaconBlock, st state.ReadOnlyBeaconState) []byte {
+	buf := bytes.NewBuffer(nil)
+	w, err := NewWriter(buf, era)
+	require.NoError(t, err)
+	for _, b := range blks {
+		require.NoError(t, w.AddBlock(b))
+	}
+	require.NoError(t, w.SetState(st))
+	require.NoError(t, w.Finish())
+	return buf.Bytes()
+}
+
+func TestSlotIndex_RoundTrip(t *testing.T) {
+	si := &slotIndex{start: 8192, offsets: []int64{-100, 0, -20}}
+	got, err := unmarshalSlotIndex(si.marshal())
+	require.NoError(t, err)
+	require.DeepEqual(t, si, got)
+
+	_, err = unmarshalSlotIndex(make([]byte, 12))
+	require.ErrorIs(t, err, ErrInvalidEra)
+	bad := si.marshal()
+	bad[len(bad)-8] = 7
+	_, err = unmarshalSlotIndex(bad)
+	require.ErrorIs(t, err, ErrInvalidEra)
+}
+
+func TestFileName(t *testing.T) {
+	root := [32]byte{0x4b, 0x36, 0x3d, 0xb9}
+	name := FileName("mainnet", 12, root)
+	require.Equal(t, "mainnet-00012-4b363db9.era", name)
+	cfg, era, err := ParseFileName(filepath.Join("some", "dir", name))
+	require.NoError(t, err)
+	require.Equal(t, "mainnet", cfg)
+	require.Equal(t, uint64(12), era)
+
+	_, _, err = ParseFileName("mainnet-12-4b363db9.era")
+	require.ErrorIs(t, err, ErrInvalidEra)
+}
+
+func TestListFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"mainnet-00000-00000000.era", "mainnet-00001-4b363db9.era", "holesky-00001-aaaaaaaa.era", "notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte{}, 0600))
+	}
+	files, err := ListFiles(dir, "mainnet")
+	require.NoError(t, err)
+	require.Equal(t, 2, len(files))
+	require.DeepEqual(t, []uint64{0, 1}, SortedEras(files))
+	require.Equal(t, filepath.Join(dir, "mainnet-00001-4b363db9.era"), files[1])
+}
+
+func TestWriterReader_RoundTrip(t *testing.T) {
+	blks, st := testChain(t, [32]byte{'a'}, bytes.Repeat([]byte{1}, 32), []primitives.Slot{1, 2, 5, 100, SlotsPerEra() - 1})
+	enc := writeTestEra(t, 1, blks, st)
+
+	r, err := NewReader(nopCloser{bytes.NewReader(enc)}, int64(len(enc)))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), r.Era())
+
+	got, err := r.Blocks()
+	require.NoError(t, err)
+	require.Equal(t, len(blks), len(got))
+	for i := range blks {
+		want, err := blks[i].Block().HashTreeRoot()
+		require.NoError(t, err)
+		have, err := got[i].Block().HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, want, have)
+	}
+	_, err = r.Block(3)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = r.Block(StateSlot(1))
+	require.ErrorIs(t, err, ErrNotFound)
+
+	gotState, err := r.State()
+	require.NoError(t, err)
+	want, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	have, err := gotState.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, want, have)
+}
+
+func TestWriter_Errors(t *testing.T) {
+	blks, st := testChain(t, [32]byte{'a'}, make([]byte, 32), []primitives.Slot{2, 3})
+	w, err := NewWriter(bytes.NewBuffer(nil), 1)
+	require.NoError(t, err)
+	require.NoError(t, w.AddBlock(blks[1]))
+	require.ErrorContains(t, "added after block", w.AddBlock(blks[0]))
+	require.ErrorContains(t, "without a state", w.Finish())
+
+	w, err = NewWriter(bytes.NewBuffer(nil), 2)
+	require.NoError(t, err)
+	require.ErrorContains(t, "does not belong to era 2", w.AddBlock(blks[0]))
+	require.ErrorContains(t, "does not belong to era 2", w.SetState(st))
+}
+
+func TestReader_Era0(t *testing.T) {
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	enc := writeTestEra(t, 0, nil, st)
+	r, err := NewReader(nopCloser{bytes.NewReader(enc)}, int64(len(enc)))
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), r.Era())
+	blks, err := r.Blocks()
+	require.NoError(t, err)
+	require.Equal(t, 0, len(blks))
+	_, err = r.Block(1)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestReader_Invalid(t *testing.T) {
+	_, err := NewReader(nopCloser{bytes.NewReader([]byte{0x01, 0x00, 0, 0, 0, 0, 0, 0})}, 8)
+	require.ErrorIs(t, err, ErrInvalidEra)
+
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	enc := writeTestEra(t, 0, nil, st)
+	enc[len(enc)-1] = 0xff
+	_, err = NewReader(nopCloser{bytes.NewReader(enc)}, int64(len(enc)))
+	require.ErrorIs(t, err, ErrInvalidEra)
+}
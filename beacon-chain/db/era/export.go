@@ -0,0 +1,211 @@
+package era
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var errEraNotFinalized = errors.New("era is not finalized")
+
+// ExportDB describes the database methods needed to export era files.
+type ExportDB interface {
+	stategen.HistoryAccessor
+	Blocks(ctx context.Context, f *filters.QueryFilter) ([]interfaces.ReadOnlySignedBeaconBlock, [][32]byte, error)
+	IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
+	FinalizedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
+	GenesisState(ctx context.Context) (state.BeaconState, error)
+}
+
+// finalizedChecker satisfies stategen.CanonicalChecker using the finalized block index of the db.
+// Era files only hold finalized history, so every canonical block is also finalized.
+type finalizedChecker struct {
+	db ExportDB
+}
+
+func (c finalizedChecker) IsCanonical(ctx context.Context, blockRoot [32]byte) (bool, error) {
+	return c.db.IsFinalizedBlock(ctx, blockRoot), nil
+}
+
+type fixedSlot primitives.Slot
+
+func (s fixedSlot) CurrentSlot() primitives.Slot {
+	return primitives.Slot(s)
+}
+
+// Exporter writes era files from the finalized history in a beacon db.
+type Exporter struct {
+	db         ExportDB
+	dir        string
+	configName string
+}
+
+// NewExporter creates an Exporter writing era files for the current beacon config into dir.
+func NewExporter(db ExportDB, dir string) (*Exporter, error) {
+	if err := os.MkdirAll(dir, params.BeaconIoConfig().ReadWriteExecutePermissions); err != nil {
+		return nil, errors.Wrapf(err, "could not create era directory %s", dir)
+	}
+	return &Exporter{db: db, dir: dir, configName: params.BeaconConfig().ConfigName}, nil
+}
+
+// MaxEra returns the highest era that can be exported, ie the highest era whose state slot is finalized.
+func (e *Exporter) MaxEra(ctx context.Context) (uint64, error) {
+	cp, err := e.db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not read finalized checkpoint")
+	}
+	fs, err := slots.EpochStart(cp.Epoch)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(fs / SlotsPerEra()), nil
+}
+
+// Export writes the era files for eras in [start, end] and returns their paths.
+func (e *Exporter) Export(ctx context.Context, start, end uint64) ([]string, error) {
+	maxEra, err := e.MaxEra(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if end > maxEra {
+		return nil, errors.Wrapf(errEraNotFinalized, "requested era %d, highest finalized era is %d", end, maxEra)
+	}
+	paths := make([]string, 0, end-start+1)
+	for era := start; era <= end; era++ {
+		if ctx.Err() != nil {
+			return paths, ctx.Err()
+		}
+		p, err := e.ExportEra(ctx, era)
+		if err != nil {
+			return paths, errors.Wrapf(err, "could not export era %d", era)
+		}
+		paths = append(paths, p)
+	}
+	return paths, nil
+}
+
+// ExportEra writes a single era file and returns its path. The file is written to a temporary
+// location first and renamed once complete, so partially written eras are never left behind.
+func (e *Exporter) ExportEra(ctx context.Context, era uint64) (string, error) {
+	st, err := e.eraState(ctx, era)
+	if err != nil {
+		return "", err
+	}
+	blks, err := e.eraBlocks(ctx, era)
+	if err != nil {
+		return "", err
+	}
+	hr, err := HistoricalRoot(era, st)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(e.dir, FileName(e.configName, era, hr))
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions) // #nosec G304 -- path is derived from the operator provided directory.
+	if err != nil {
+		return "", errors.Wrapf(err, "could not create era file %s", tmp)
+	}
+	if err := writeEra(f, era, blks, st); err != nil {
+		closeFile(f)
+		return "", err
+	}
+	if err := f.Sync(); err != nil {
+		closeFile(f)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return "", errors.Wrapf(err, "could not move era file into place at %s", path)
+	}
+	log.WithFields(logrus.Fields{
+		"era":    era,
+		"blocks": len(blks),
+		"file":   path,
+	}).Info("Exported era file")
+	return path, nil
+}
+
+func writeEra(f *os.File, era uint64, blks []interfaces.ReadOnlySignedBeaconBlock, st state.ReadOnlyBeaconState) error {
+	w, err := NewWriter(f, era)
+	if err != nil {
+		return err
+	}
+	for _, b := range blks {
+		if err := w.AddBlock(b); err != nil {
+			return err
+		}
+	}
+	if err := w.SetState(st); err != nil {
+		return err
+	}
+	return w.Finish()
+}
+
+func (e *Exporter) eraState(ctx context.Context, era uint64) (state.BeaconState, error) {
+	if era == 0 {
+		st, err := e.db.GenesisState(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read genesis state")
+		}
+		if st == nil || st.IsNil() {
+			return nil, errors.New("genesis state not found in db")
+		}
+		return st, nil
+	}
+	target := StateSlot(era)
+	ch := stategen.NewCanonicalHistory(e.db, finalizedChecker{db: e.db}, fixedSlot(target))
+	st, err := ch.ReplayerForSlot(target).ReplayToSlot(ctx, target)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not compute state at slot %d", target)
+	}
+	return st, nil
+}
+
+// eraBlocks returns the finalized blocks of the era in increasing slot order. The genesis block is not
+// part of any era file, since it can be computed from the genesis state.
+func (e *Exporter) eraBlocks(ctx context.Context, era uint64) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	if era == 0 {
+		return nil, nil
+	}
+	start := BlockStartSlot(era)
+	if start == 0 {
+		start = 1
+	}
+	f := filters.NewFilter().SetStartSlot(start).SetEndSlot(StateSlot(era) - 1)
+	blks, roots, err := e.db.Blocks(ctx, f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read blocks for slots [%d, %d)", start, StateSlot(era))
+	}
+	finalized := make([]interfaces.ReadOnlySignedBeaconBlock, 0, len(blks))
+	seen := make(map[primitives.Slot]bool, len(blks))
+	for i := range blks {
+		if !e.db.IsFinalizedBlock(ctx, roots[i]) {
+			continue
+		}
+		slot := blks[i].Block().Slot()
+		if seen[slot] {
+			return nil, fmt.Errorf("found more than one finalized block at slot %d", slot)
+		}
+		seen[slot] = true
+		finalized = append(finalized, blks[i])
+	}
+	sort.Slice(finalized, func(i, j int) bool {
+		return finalized[i].Block().Slot() < finalized[j].Block().Slot()
+	})
+	return finalized, nil
+}
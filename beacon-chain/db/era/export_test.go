@@ -0,0 +1,95 @@
+package era
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestExporter_RoundTrip(t *testing.T) {
+	useTestConfig(t)
+	ctx := context.Background()
+	src, err := kv.NewKVStore(ctx, t.TempDir())
+	require.NoError(t, err)
+	gs, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, gs.SetGenesisValidatorsRoot(bytesutil.PadTo([]byte{0x01}, 32)))
+	require.NoError(t, src.SaveGenesisData(ctx, gs))
+	genesisRoot, err := src.GenesisBlockRoot(ctx)
+	require.NoError(t, err)
+
+	blks, st := testChain(t, genesisRoot, gs.GenesisValidatorsRoot(), []primitives.Slot{1, 3, 64, SlotsPerEra() - 1})
+	require.NoError(t, src.SaveBlocks(ctx, blks))
+
+	// Add a block on the era boundary, so the era state is its post-state.
+	parent, err := blks[len(blks)-1].Block().HashTreeRoot()
+	require.NoError(t, err)
+	b := util.NewBeaconBlock()
+	b.Block.Slot = SlotsPerEra()
+	b.Block.ParentRoot = parent[:]
+	bodyRoot, err := b.Block.Body.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, st.SetLatestBlockHeader(&ethpb.BeaconBlockHeader{
+		Slot:       b.Block.Slot,
+		ParentRoot: b.Block.ParentRoot,
+		StateRoot:  make([]byte, 32),
+		BodyRoot:   bodyRoot[:],
+	}))
+	sr, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	b.Block.StateRoot = sr[:]
+	util.SaveBlock(t, ctx, src, b)
+	boundary, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, src.SaveState(ctx, st, boundary))
+	require.NoError(t, src.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: slots.ToEpoch(SlotsPerEra()), Root: boundary[:]}))
+
+	e, err := NewExporter(src, t.TempDir())
+	require.NoError(t, err)
+	maxEra, err := e.MaxEra(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), maxEra)
+	_, err = e.Export(ctx, 0, 2)
+	require.ErrorIs(t, err, errEraNotFinalized)
+	paths, err := e.Export(ctx, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(paths))
+
+	r, err := Open(paths[1])
+	require.NoError(t, err)
+	exported, err := r.Blocks()
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	// The boundary block belongs to era 2.
+	require.Equal(t, len(blks), len(exported))
+
+	// Only one db can be open at a time, because each one registers the same metrics.
+	require.NoError(t, src.Close())
+
+	files := map[uint64]string{0: paths[0], 1: paths[1]}
+	dst := dbtest.SetupDB(t)
+	require.NoError(t, NewImporter(dst).Import(ctx, files))
+	var last [32]byte
+	for _, b := range blks {
+		r, err := b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, true, dst.HasBlock(ctx, r))
+		require.Equal(t, true, dst.HasStateSummary(ctx, r))
+		last = r
+	}
+	head, err := dst.HeadBlock(ctx)
+	require.NoError(t, err)
+	headRoot, err := head.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, last, headRoot)
+	// The boundary block is part of era 2, so the era state is only saved once that era is imported.
+	require.Equal(t, false, dst.HasState(ctx, boundary))
+}
@@ -0,0 +1,307 @@
+package era
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	errNonContiguousEras  = errors.New("era files are not contiguous")
+	errMissingGenesisEra  = errors.New("db has no genesis and era 0 was not provided")
+	errBlockRootMismatch  = errors.New("block root does not match the block_roots of the era state")
+	errParentRootMismatch = errors.New("block parent_root does not match the previous block")
+	errWrongChain         = errors.New("era file is for a different chain than the db")
+)
+
+// ImportDB describes the database methods needed to import era files.
+type ImportDB interface {
+	GenesisBlockRoot(ctx context.Context) ([32]byte, error)
+	GenesisState(ctx context.Context) (state.BeaconState, error)
+	SaveGenesisData(ctx context.Context, st state.BeaconState) error
+	Block(ctx context.Context, blockRoot [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error)
+	FinalizedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
+	HeadBlock(ctx context.Context) (interfaces.ReadOnlySignedBeaconBlock, error)
+	SaveROBlocks(ctx context.Context, blks []blocks.ROBlock, cache bool) error
+	HasState(ctx context.Context, blockRoot [32]byte) bool
+	SaveState(ctx context.Context, st state.ReadOnlyBeaconState, blockRoot [32]byte) error
+	SaveStateSummary(ctx context.Context, summary *ethpb.StateSummary) error
+	SaveStateSummaries(ctx context.Context, summaries []*ethpb.StateSummary) error
+	SaveFinalizedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error
+	SaveHeadBlockRoot(ctx context.Context, blockRoot [32]byte) error
+}
+
+// Importer loads era files into a beacon db. The db must either be empty, in which case era 0 is
+// required to initialize genesis, or hold a finalized chain that the first imported block builds upon.
+// Blocks are checked against the block_roots of the era state and against their parent, and the
+// finalized checkpoint is advanced to the era boundary after every era so that an interrupted import
+// can be resumed. The era state is saved as the state of the checkpoint, which is also an archived point,
+// and a state summary is saved for every block.
+type Importer struct {
+	db       ImportDB
+	gvr      []byte
+	lastRoot [32]byte
+	lastSlot primitives.Slot
+	// pending is the checkpoint of the previous era whose boundary block is the first block of the next era.
+	pending *eraCheckpoint
+}
+
+// eraCheckpoint is the checkpoint at the era boundary, with the era state as its state.
+type eraCheckpoint struct {
+	checkpoint *ethpb.Checkpoint
+	state      state.BeaconState
+}
+
+// NewImporter creates an Importer for the given db.
+func NewImporter(db ImportDB) *Importer {
+	return &Importer{db: db}
+}
+
+// Import loads the given era files, which must form a contiguous range.
+func (i *Importer) Import(ctx context.Context, files map[uint64]string) error {
+	eras := SortedEras(files)
+	if len(eras) == 0 {
+		return nil
+	}
+	for j := 1; j < len(eras); j++ {
+		if eras[j] != eras[j-1]+1 {
+			return errors.Wrapf(errNonContiguousEras, "era %d is followed by era %d", eras[j-1], eras[j])
+		}
+	}
+	if err := i.init(ctx, eras[0], files); err != nil {
+		return err
+	}
+	for _, era := range eras {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if era == 0 {
+			continue
+		}
+		if err := i.importFile(ctx, files[era]); err != nil {
+			return errors.Wrapf(err, "could not import era %d", era)
+		}
+	}
+	return i.db.SaveHeadBlockRoot(ctx, i.lastRoot)
+}
+
+// init determines where the import continues from, saving the genesis state from era 0 for an empty db.
+func (i *Importer) init(ctx context.Context, first uint64, files map[uint64]string) error {
+	_, err := i.db.GenesisBlockRoot(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		if first != 0 {
+			return errMissingGenesisEra
+		}
+		r, err := Open(files[0])
+		if err != nil {
+			return err
+		}
+		defer closeReader(r)
+		st, err := r.State()
+		if err != nil {
+			return errors.Wrap(err, "could not read genesis state from era 0")
+		}
+		if err := i.db.SaveGenesisData(ctx, st); err != nil {
+			return errors.Wrap(err, "could not save genesis data")
+		}
+		log.WithField("genesisValidatorsRoot", fmt.Sprintf("%#x", st.GenesisValidatorsRoot())).Info("Imported genesis state from era 0")
+	} else if err != nil {
+		return errors.Wrap(err, "could not read genesis block root")
+	}
+
+	gs, err := i.db.GenesisState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not read genesis state")
+	}
+	if gs == nil || gs.IsNil() {
+		return errors.New("genesis state not found in db")
+	}
+	i.gvr = gs.GenesisValidatorsRoot()
+
+	cp, err := i.db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not read finalized checkpoint")
+	}
+	root := bytesutil.ToBytes32(cp.Root)
+	if root == params.BeaconConfig().ZeroHash {
+		if root, err = i.db.GenesisBlockRoot(ctx); err != nil {
+			return errors.Wrap(err, "could not read genesis block root")
+		}
+	}
+	b, err := i.db.Block(ctx, root)
+	if err != nil {
+		return errors.Wrapf(err, "could not read finalized block %#x", root)
+	}
+	if err := blocks.BeaconBlockIsNil(b); err != nil {
+		return errors.Wrapf(err, "finalized block %#x not found", root)
+	}
+	i.lastRoot, i.lastSlot = root, b.Block().Slot()
+	// The checkpoint of the last era imported can lag behind its blocks, continue from the head in that case.
+	head, err := i.db.HeadBlock(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not read head block")
+	}
+	if blocks.BeaconBlockIsNil(head) == nil && head.Block().Slot() > i.lastSlot {
+		if i.lastRoot, err = head.Block().HashTreeRoot(); err != nil {
+			return errors.Wrap(err, "could not compute head block root")
+		}
+		i.lastSlot = head.Block().Slot()
+	}
+	if first > 0 && BlockStartSlot(first) > i.lastSlot+1 {
+		return errors.Wrapf(errNonContiguousEras, "db is finalized at slot %d, era %d starts at slot %d", i.lastSlot, first, BlockStartSlot(first))
+	}
+	return nil
+}
+
+func (i *Importer) importFile(ctx context.Context, path string) error {
+	r, err := Open(path)
+	if err != nil {
+		return err
+	}
+	defer closeReader(r)
+	st, err := r.State()
+	if err != nil {
+		return errors.Wrap(err, "could not read era state")
+	}
+	if !bytes.Equal(st.GenesisValidatorsRoot(), i.gvr) {
+		return errors.Wrapf(errWrongChain, "era genesis_validators_root=%#x, db=%#x", st.GenesisValidatorsRoot(), i.gvr)
+	}
+	blks, err := r.Blocks()
+	if err != nil {
+		return err
+	}
+	robs, err := i.verifiedBlocks(st, blks)
+	if err != nil {
+		return err
+	}
+	if len(robs) > 0 {
+		if err := i.db.SaveROBlocks(ctx, robs, false); err != nil {
+			return errors.Wrap(err, "could not save blocks")
+		}
+		// The summaries let the state of any imported block, such as the head, be regenerated
+		// from the closest archived point.
+		summaries := make([]*ethpb.StateSummary, len(robs))
+		for j, rob := range robs {
+			root := rob.Root()
+			summaries[j] = &ethpb.StateSummary{Slot: rob.Block().Slot(), Root: root[:]}
+		}
+		if err := i.db.SaveStateSummaries(ctx, summaries); err != nil {
+			return errors.Wrap(err, "could not save state summaries")
+		}
+		last := robs[len(robs)-1]
+		i.lastRoot, i.lastSlot = last.Root(), last.Block().Slot()
+	}
+	if i.pending != nil {
+		if len(robs) == 0 || robs[0].Root() != bytesutil.ToBytes32(i.pending.checkpoint.Root) {
+			return errors.Wrapf(errParentRootMismatch, "first block of the era is not the boundary block %#x of the previous era", i.pending.checkpoint.Root)
+		}
+		if err := i.finalize(ctx, i.pending); err != nil {
+			return err
+		}
+		i.pending = nil
+	}
+	cp, err := boundaryCheckpoint(ctx, st)
+	if err != nil {
+		return err
+	}
+	if bytesutil.ToBytes32(cp.checkpoint.Root) == i.lastRoot {
+		if err := i.finalize(ctx, cp); err != nil {
+			return err
+		}
+	} else {
+		// The boundary block is at the first slot of the next era, and is part of the next era file.
+		i.pending = cp
+	}
+	log.WithFields(logrus.Fields{
+		"era":    r.Era(),
+		"blocks": len(robs),
+		"slot":   i.lastSlot,
+	}).Info("Imported era file")
+	return nil
+}
+
+// verifiedBlocks checks each block against the block_roots of the era state and its parent, skipping
+// blocks that are already part of the db.
+func (i *Importer) verifiedBlocks(st state.ReadOnlyBeaconState, blks []interfaces.ReadOnlySignedBeaconBlock) ([]blocks.ROBlock, error) {
+	robs := make([]blocks.ROBlock, 0, len(blks))
+	parent := i.lastRoot
+	sphr := params.BeaconConfig().SlotsPerHistoricalRoot
+	for _, b := range blks {
+		slot := b.Block().Slot()
+		if slot <= i.lastSlot {
+			continue
+		}
+		rob, err := blocks.NewROBlock(b)
+		if err != nil {
+			return nil, err
+		}
+		expected, err := st.BlockRootAtIndex(uint64(slot % sphr))
+		if err != nil {
+			return nil, err
+		}
+		if rob.Root() != bytesutil.ToBytes32(expected) {
+			return nil, errors.Wrapf(errBlockRootMismatch, "slot=%d, root=%#x, block_roots=%#x", slot, rob.Root(), expected)
+		}
+		if rob.Block().ParentRoot() != parent {
+			return nil, errors.Wrapf(errParentRootMismatch, "slot=%d, parent_root=%#x, previous root=%#x", slot, rob.Block().ParentRoot(), parent)
+		}
+		parent = rob.Root()
+		robs = append(robs, rob)
+	}
+	return robs, nil
+}
+
+// boundaryCheckpoint returns the checkpoint of the epoch starting at the slot of the era state: its root
+// is the block at the boundary slot when there is one, and the last block of the era otherwise.
+func boundaryCheckpoint(ctx context.Context, st state.BeaconState) (*eraCheckpoint, error) {
+	epoch := slots.ToEpoch(st.Slot())
+	hdr := st.LatestBlockHeader().Copy()
+	if hdr.Slot != st.Slot() {
+		root, err := st.BlockRootAtIndex(uint64((st.Slot() - 1) % params.BeaconConfig().SlotsPerHistoricalRoot))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read era boundary block root")
+		}
+		return &eraCheckpoint{checkpoint: &ethpb.Checkpoint{Epoch: epoch, Root: root}, state: st}, nil
+	}
+	sr, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute era state root")
+	}
+	hdr.StateRoot = sr[:]
+	root, err := hdr.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute era boundary block root")
+	}
+	return &eraCheckpoint{checkpoint: &ethpb.Checkpoint{Epoch: epoch, Root: root[:]}, state: st}, nil
+}
+
+// finalize saves the era state for the boundary block of the checkpoint, which makes it an archived point,
+// and advances the finalized checkpoint to it. A state already saved for the block is kept, as it is the
+// post-state of the block when an entire era is empty.
+func (i *Importer) finalize(ctx context.Context, cp *eraCheckpoint) error {
+	root := bytesutil.ToBytes32(cp.checkpoint.Root)
+	if !i.db.HasState(ctx, root) {
+		if err := i.db.SaveState(ctx, cp.state, root); err != nil {
+			return errors.Wrapf(err, "could not save era state for block root %#x", root)
+		}
+		if err := i.db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: cp.state.Slot(), Root: root[:]}); err != nil {
+			return errors.Wrap(err, "could not save era state summary")
+		}
+	}
+	if err := i.db.SaveFinalizedCheckpoint(ctx, cp.checkpoint); err != nil {
+		return errors.Wrap(err, "could not update finalized checkpoint")
+	}
+	return nil
+}
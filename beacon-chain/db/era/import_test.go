@@ -0,0 +1,220 @@
+package era
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func writeTestEraFile(t *testing.T, dir string, era uint64, blks []interfaces.ReadOnlySignedBeaconBlock, st state.ReadOnlyBeaconState) string {
+	hr, err := HistoricalRoot(era, st)
+	require.NoError(t, err)
+	path := filepath.Join(dir, FileName(params.BeaconConfig().ConfigName, era, hr))
+	require.NoError(t, os.WriteFile(path, writeTestEra(t, era, blks, st), 0600))
+	return path
+}
+
+// useTestConfig renames the beacon config, so the db doesn't substitute the embedded mainnet genesis state.
+func useTestConfig(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.MainnetConfig().Copy()
+	cfg.ConfigName = "era-test"
+	params.OverrideBeaconConfig(cfg)
+}
+
+func genesisBlockRoot(t *testing.T, gs state.BeaconState) [32]byte {
+	b, err := blocks.NewGenesisBlockForState(context.Background(), gs)
+	require.NoError(t, err)
+	r, err := b.Block().HashTreeRoot()
+	require.NoError(t, err)
+	return r
+}
+
+func TestImporter_Import(t *testing.T) {
+	useTestConfig(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	gs, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, gs.SetGenesisValidatorsRoot(bytesutil.PadTo([]byte{0x01}, 32)))
+
+	// Build the era 1 chain on top of the genesis block that SaveGenesisData will create.
+	genesisRoot := genesisBlockRoot(t, gs)
+	blks, st := testChain(t, genesisRoot, gs.GenesisValidatorsRoot(), []primitives.Slot{1, 2, 10, SlotsPerEra() - 1})
+
+	files := map[uint64]string{
+		0: writeTestEraFile(t, dir, 0, nil, gs),
+		1: writeTestEraFile(t, dir, 1, blks, st),
+	}
+	d := dbtest.SetupDB(t)
+	require.NoError(t, NewImporter(d).Import(ctx, files))
+
+	for _, b := range blks {
+		r, err := b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, true, d.HasBlock(ctx, r))
+		require.Equal(t, true, d.IsFinalizedBlock(ctx, r))
+		require.Equal(t, true, d.HasStateSummary(ctx, r))
+	}
+	last, err := blks[len(blks)-1].Block().HashTreeRoot()
+	require.NoError(t, err)
+	// The boundary slot of the era is empty, so the checkpoint of its epoch is the last block of the era.
+	cp, err := d.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, last[:], cp.Root)
+	require.Equal(t, slots.ToEpoch(StateSlot(1)), cp.Epoch)
+	require.Equal(t, true, d.HasState(ctx, last))
+	require.Equal(t, last, d.ArchivedPointRoot(ctx, StateSlot(1)))
+	head, err := d.HeadBlock(ctx)
+	require.NoError(t, err)
+	require.Equal(t, SlotsPerEra()-1, head.Block().Slot())
+
+	// Importing again is a no-op, since every block is already below the finalized checkpoint.
+	require.NoError(t, NewImporter(d).Import(ctx, map[uint64]string{1: files[1]}))
+}
+
+func TestImporter_Import_BoundaryBlock(t *testing.T) {
+	useTestConfig(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	gs, err := util.NewBeaconState()
+	require.NoError(t, err)
+	genesisRoot := genesisBlockRoot(t, gs)
+	blks1, st1 := testChain(t, genesisRoot, gs.GenesisValidatorsRoot(), []primitives.Slot{1, SlotsPerEra() - 1})
+	last1, err := blks1[len(blks1)-1].Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	// The first block of era 2 is at the boundary slot, so the state of era 1 is its post-state.
+	b := util.NewBeaconBlock()
+	b.Block.Slot = StateSlot(1)
+	b.Block.ParentRoot = last1[:]
+	bodyRoot, err := b.Block.Body.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, st1.SetLatestBlockHeader(&ethpb.BeaconBlockHeader{
+		Slot:       b.Block.Slot,
+		ParentRoot: b.Block.ParentRoot,
+		StateRoot:  make([]byte, 32),
+		BodyRoot:   bodyRoot[:],
+	}))
+	sr, err := st1.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	b.Block.StateRoot = sr[:]
+	boundary, err := consensusblocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	boundaryRoot, err := boundary.Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	st2, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st2.SetSlot(StateSlot(2)))
+	require.NoError(t, st2.SetGenesisValidatorsRoot(gs.GenesisValidatorsRoot()))
+	require.NoError(t, st2.SetHistoricalRoots([][]byte{bytesutil.PadTo([]byte{0x01}, 32), bytesutil.PadTo([]byte{0x02}, 32)}))
+	roots := make([][]byte, SlotsPerEra())
+	for i := range roots {
+		roots[i] = boundaryRoot[:]
+	}
+	require.NoError(t, st2.SetBlockRoots(roots))
+
+	files := map[uint64]string{
+		0: writeTestEraFile(t, dir, 0, nil, gs),
+		1: writeTestEraFile(t, dir, 1, blks1, st1),
+	}
+	d, err := kv.NewKVStore(ctx, t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, NewImporter(d).Import(ctx, files))
+	// The boundary block of era 1 is not imported yet, so neither is its checkpoint nor the era state.
+	cp, err := d.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Epoch(0), cp.Epoch)
+	require.Equal(t, false, d.HasState(ctx, boundaryRoot))
+	require.Equal(t, false, d.HasState(ctx, last1))
+	// The head is the last block of era 1, whose state can be regenerated from its summary.
+	head, err := d.HeadBlock(ctx)
+	require.NoError(t, err)
+	require.Equal(t, SlotsPerEra()-1, head.Block().Slot())
+	require.Equal(t, true, d.HasStateSummary(ctx, last1))
+
+	// Resuming from the head, the era 1 checkpoint is lost but era 2 finalizes on top of the boundary block.
+	require.NoError(t, NewImporter(d).Import(ctx, map[uint64]string{2: writeTestEraFile(t, dir, 2, []interfaces.ReadOnlySignedBeaconBlock{boundary}, st2)}))
+	cp, err = d.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	require.Equal(t, slots.ToEpoch(StateSlot(2)), cp.Epoch)
+	require.DeepEqual(t, boundaryRoot[:], cp.Root)
+	require.Equal(t, true, d.IsFinalizedBlock(ctx, last1))
+
+	// Imported in a single run, the era 1 checkpoint is finalized once its boundary block is saved.
+	// Only one db can be open at a time, because each one registers the same metrics.
+	require.NoError(t, d.Close())
+	single := dbtest.SetupDB(t)
+	files[2] = writeTestEraFile(t, dir, 2, []interfaces.ReadOnlySignedBeaconBlock{boundary}, st2)
+	imp := NewImporter(single)
+	require.NoError(t, imp.init(ctx, 0, files))
+	require.NoError(t, imp.importFile(ctx, files[1]))
+	require.NotNil(t, imp.pending)
+	require.NoError(t, imp.importFile(ctx, files[2]))
+	// The post-state of the boundary block is kept rather than the later state of era 2 for the same block.
+	st, err := single.State(ctx, boundaryRoot)
+	require.NoError(t, err)
+	require.Equal(t, StateSlot(1), st.Slot())
+}
+
+func TestImporter_Errors(t *testing.T) {
+	useTestConfig(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	gs, err := util.NewBeaconState()
+	require.NoError(t, err)
+	genesisRoot := genesisBlockRoot(t, gs)
+
+	t.Run("missing genesis", func(t *testing.T) {
+		blks, st := testChain(t, genesisRoot, gs.GenesisValidatorsRoot(), []primitives.Slot{1})
+		err := NewImporter(dbtest.SetupDB(t)).Import(ctx, map[uint64]string{1: writeTestEraFile(t, t.TempDir(), 1, blks, st)})
+		require.ErrorIs(t, err, errMissingGenesisEra)
+	})
+	t.Run("non contiguous", func(t *testing.T) {
+		err := NewImporter(dbtest.SetupDB(t)).Import(ctx, map[uint64]string{0: "a", 2: "b"})
+		require.ErrorIs(t, err, errNonContiguousEras)
+	})
+	t.Run("block root mismatch", func(t *testing.T) {
+		blks, st := testChain(t, genesisRoot, gs.GenesisValidatorsRoot(), []primitives.Slot{1, 2})
+		roots := st.BlockRoots()
+		roots[2] = bytesutil.PadTo([]byte{0xff}, 32)
+		require.NoError(t, st.SetBlockRoots(roots))
+		files := map[uint64]string{
+			0: writeTestEraFile(t, dir, 0, nil, gs),
+			1: writeTestEraFile(t, t.TempDir(), 1, blks, st),
+		}
+		require.ErrorIs(t, NewImporter(dbtest.SetupDB(t)).Import(ctx, files), errBlockRootMismatch)
+	})
+	t.Run("disconnected chain", func(t *testing.T) {
+		blks, st := testChain(t, [32]byte{'x'}, gs.GenesisValidatorsRoot(), []primitives.Slot{1, 2})
+		files := map[uint64]string{
+			0: writeTestEraFile(t, dir, 0, nil, gs),
+			1: writeTestEraFile(t, t.TempDir(), 1, blks, st),
+		}
+		require.ErrorIs(t, NewImporter(dbtest.SetupDB(t)).Import(ctx, files), errParentRootMismatch)
+	})
+	t.Run("wrong chain", func(t *testing.T) {
+		blks, st := testChain(t, genesisRoot, bytesutil.PadTo([]byte{0x02}, 32), []primitives.Slot{1})
+		files := map[uint64]string{
+			0: writeTestEraFile(t, dir, 0, nil, gs),
+			1: writeTestEraFile(t, t.TempDir(), 1, blks, st),
+		}
+		require.ErrorIs(t, NewImporter(dbtest.SetupDB(t)).Import(ctx, files), errWrongChain)
+	})
+}
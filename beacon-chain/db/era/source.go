@@ -0,0 +1,78 @@
+package era
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// BlockSource serves ranges of blocks from a directory of era files. It is used by backfill to
+// fill history from local files instead of requesting it from peers.
+type BlockSource struct {
+	files map[uint64]string
+}
+
+// NewBlockSource indexes the era files in dir that match the current beacon config.
+func NewBlockSource(dir string) (*BlockSource, error) {
+	files, err := ListFiles(dir, params.BeaconConfig().ConfigName)
+	if err != nil {
+		return nil, err
+	}
+	log.WithField("dir", dir).WithField("files", len(files)).Info("Indexed era files")
+	return &BlockSource{files: files}, nil
+}
+
+// Covers determines if every slot in [start, end) is held by an era file of the source.
+func (s *BlockSource) Covers(start, end primitives.Slot) bool {
+	if end <= start {
+		return false
+	}
+	for era := ForSlot(start); era <= ForSlot(end-1); era++ {
+		if _, ok := s.files[era]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// BlocksByRange returns the blocks in [start, end), in increasing slot order. ErrNotFound is returned
+// when the range is not fully covered by the era files of the source.
+func (s *BlockSource) BlocksByRange(start, end primitives.Slot) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	if !s.Covers(start, end) {
+		return nil, errors.Wrapf(ErrNotFound, "slots [%d, %d) are not covered by era files", start, end)
+	}
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, 0)
+	for era := ForSlot(start); era <= ForSlot(end-1); era++ {
+		r, err := Open(s.files[era])
+		if err != nil {
+			return nil, err
+		}
+		from, to := BlockStartSlot(era), StateSlot(era)
+		if start > from {
+			from = start
+		}
+		if end < to {
+			to = end
+		}
+		for slot := from; slot < to; slot++ {
+			b, err := r.Block(slot)
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				closeReader(r)
+				return nil, err
+			}
+			blks = append(blks, b)
+		}
+		closeReader(r)
+	}
+	return blks, nil
+}
+
+func closeReader(r *Reader) {
+	if err := r.Close(); err != nil {
+		log.WithError(err).WithField("era", r.Era()).Error("Could not close era file")
+	}
+}
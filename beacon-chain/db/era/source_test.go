@@ -0,0 +1,30 @@
+package era
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestBlockSource_BlocksByRange(t *testing.T) {
+	useTestConfig(t)
+	dir := t.TempDir()
+	blks, st := testChain(t, [32]byte{'a'}, make([]byte, 32), []primitives.Slot{1, 2, 5, 100, SlotsPerEra() - 1})
+	writeTestEraFile(t, dir, 1, blks, st)
+
+	src, err := NewBlockSource(dir)
+	require.NoError(t, err)
+	require.Equal(t, true, src.Covers(0, SlotsPerEra()))
+	require.Equal(t, false, src.Covers(0, SlotsPerEra()+1))
+	require.Equal(t, false, src.Covers(5, 5))
+
+	got, err := src.BlocksByRange(2, 101)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(got))
+	require.Equal(t, primitives.Slot(2), got[0].Block().Slot())
+	require.Equal(t, primitives.Slot(100), got[2].Block().Slot())
+
+	_, err = src.BlocksByRange(SlotsPerEra()-1, SlotsPerEra()+1)
+	require.ErrorIs(t, err, ErrNotFound)
+}
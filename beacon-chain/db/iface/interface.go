@@ -43,6 +43,8 @@ type ReadOnlyDatabase interface {
 	StateSummary(ctx context.Context, blockRoot [32]byte) (*ethpb.StateSummary, error)
 	HasStateSummary(ctx context.Context, blockRoot [32]byte) bool
 	HighestSlotStatesBelow(ctx context.Context, slot primitives.Slot) ([]state.ReadOnlyBeaconState, error)
+	StateDiff(ctx context.Context, slot primitives.Slot) ([]byte, error)
+	HasStateDiff(ctx context.Context, slot primitives.Slot) bool
 	// Checkpoint operations.
 	JustifiedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
 	FinalizedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
@@ -58,6 +60,8 @@ type ReadOnlyDatabase interface {
 	// Fee recipients operations.
 	FeeRecipientByValidatorID(ctx context.Context, id primitives.ValidatorIndex) (common.Address, error)
 	RegistrationByValidatorID(ctx context.Context, id primitives.ValidatorIndex) (*ethpb.ValidatorRegistrationV1, error)
+	// Proposal decisions operations.
+	ProposalDecisions(ctx context.Context, startSlot, endSlot primitives.Slot) ([]*ethpb.ProposalDecision, error)
 	// light client operations
 	LightClientUpdates(ctx context.Context, startPeriod, endPeriod uint64) (map[uint64]*ethpbv2.LightClientUpdateWithVersion, error)
 	LightClientUpdate(ctx context.Context, period uint64) (*ethpbv2.LightClientUpdateWithVersion, error)
@@ -84,6 +88,7 @@ type NoHeadAccessDatabase interface {
 	DeleteStates(ctx context.Context, blockRoots [][32]byte) error
 	SaveStateSummary(ctx context.Context, summary *ethpb.StateSummary) error
 	SaveStateSummaries(ctx context.Context, summaries []*ethpb.StateSummary) error
+	SaveStateDiff(ctx context.Context, slot primitives.Slot, enc []byte) error
 	// Checkpoint operations.
 	SaveJustifiedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error
 	SaveFinalizedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error
@@ -97,6 +102,9 @@ type NoHeadAccessDatabase interface {
 	// Fee recipients operations.
 	SaveFeeRecipientsByValidatorIDs(ctx context.Context, ids []primitives.ValidatorIndex, addrs []common.Address) error
 	SaveRegistrationsByValidatorIDs(ctx context.Context, ids []primitives.ValidatorIndex, regs []*ethpb.ValidatorRegistrationV1) error
+	// Proposal decisions operations.
+	SaveProposalDecision(ctx context.Context, decision *ethpb.ProposalDecision) error
+	PruneProposalDecisions(ctx context.Context, slot primitives.Slot) error
 	// light client operations
 	SaveLightClientUpdate(ctx context.Context, period uint64, update *ethpbv2.LightClientUpdateWithVersion) error
 
@@ -111,6 +119,10 @@ type HeadAccessDatabase interface {
 	HeadBlock(ctx context.Context) (interfaces.ReadOnlySignedBeaconBlock, error)
 	SaveHeadBlockRoot(ctx context.Context, blockRoot [32]byte) error
 
+	// Fork choice persistence.
+	ForkchoiceSnapshot(ctx context.Context) (*dbval.ForkchoiceSnapshot, error)
+	SaveForkchoiceSnapshot(ctx context.Context, snapshot *dbval.ForkchoiceSnapshot) error
+
 	// Genesis operations.
 	LoadGenesis(ctx context.Context, stateBytes []byte) error
 	SaveGenesisData(ctx context.Context, state state.BeaconState) error
@@ -166,6 +178,12 @@ type SlasherDatabase interface {
 		ctx context.Context,
 		indices []primitives.ValidatorIndex,
 	) ([]*ethpb.HighestAttestation, error)
+	LastBackfilledEpoch(ctx context.Context) (primitives.Epoch, bool, error)
+	SaveLastBackfilledEpoch(ctx context.Context, epoch primitives.Epoch) error
+	SaveAttesterSlashings(ctx context.Context, slashings []ethpb.AttSlashing) error
+	SaveProposerSlashings(ctx context.Context, slashings []*ethpb.ProposerSlashing) error
+	AttesterSlashingsForValidator(ctx context.Context, validatorIdx primitives.ValidatorIndex) ([]ethpb.AttSlashing, error)
+	ProposerSlashingsForValidator(ctx context.Context, validatorIdx primitives.ValidatorIndex) ([]*ethpb.ProposerSlashing, error)
 	DatabasePath() string
 	ClearDB() error
 	Migrate(ctx context.Context, headEpoch, maxPruningEpoch primitives.Epoch, batchSize int) error
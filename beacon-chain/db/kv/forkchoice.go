@@ -0,0 +1,42 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// SaveForkchoiceSnapshot encodes the given fork choice snapshot and writes it to a single key in the db,
+// replacing the previous snapshot. It is used to restore fork choice with its non-finalized branches on restart.
+func (s *Store) SaveForkchoiceSnapshot(ctx context.Context, snapshot *dbval.ForkchoiceSnapshot) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveForkchoiceSnapshot")
+	defer span.End()
+	enc, err := proto.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(chainMetadataBucket)
+		return bucket.Put(forkchoiceSnapshotKey, enc)
+	})
+}
+
+// ForkchoiceSnapshot retrieves the most recently saved fork choice snapshot.
+func (s *Store) ForkchoiceSnapshot(ctx context.Context) (*dbval.ForkchoiceSnapshot, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ForkchoiceSnapshot")
+	defer span.End()
+	snapshot := &dbval.ForkchoiceSnapshot{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(chainMetadataBucket)
+		enc := bucket.Get(forkchoiceSnapshotKey)
+		if len(enc) == 0 {
+			return errors.Wrap(ErrNotFound, "ForkchoiceSnapshot not found")
+		}
+		return proto.Unmarshal(enc, snapshot)
+	})
+	return snapshot, err
+}
@@ -0,0 +1,40 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestForkchoiceSnapshotRoundtrip(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	_, err := db.ForkchoiceSnapshot(ctx)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	snapshot := &dbval.ForkchoiceSnapshot{
+		FinalizedCheckpoint: &dbval.ForkchoiceCheckpoint{Epoch: 2, Root: bytesutil.PadTo([]byte("finalized"), 32)},
+		Nodes: []*dbval.ForkchoiceNode{
+			{Slot: 64, Root: bytesutil.PadTo([]byte("finalized"), 32)},
+			{Slot: 65, Root: bytesutil.PadTo([]byte("child"), 32), ParentRoot: bytesutil.PadTo([]byte("finalized"), 32), Weight: 10},
+		},
+		Votes:          []*dbval.ForkchoiceVote{{NextRoot: bytesutil.PadTo([]byte("child"), 32), NextEpoch: 2}},
+		Balances:       []uint64{32},
+		SlashedIndices: []uint64{3},
+	}
+	require.NoError(t, db.SaveForkchoiceSnapshot(ctx, snapshot))
+	got, err := db.ForkchoiceSnapshot(ctx)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, snapshot, got)
+
+	// A new snapshot replaces the previous one.
+	snapshot.Nodes = snapshot.Nodes[:1]
+	require.NoError(t, db.SaveForkchoiceSnapshot(ctx, snapshot))
+	got, err = db.ForkchoiceSnapshot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(got.Nodes))
+}
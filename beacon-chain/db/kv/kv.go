@@ -107,6 +107,7 @@ var Buckets = [][]byte{
 	powchainBucket,
 	stateSummaryBucket,
 	stateValidatorsBucket,
+	stateDiffBucket,
 	lightClientUpdatesBucket,
 	// Indices buckets.
 	blockSlotIndicesBucket,
@@ -119,6 +120,7 @@ var Buckets = [][]byte{
 
 	feeRecipientBucket,
 	registrationBucket,
+	proposalDecisionsBucket,
 }
 
 // KVStoreOption is a functional option that modifies a kv.Store.
@@ -0,0 +1,86 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveProposalDecision records the outcome of the builder versus local payload selection for a proposal.
+// Decisions are keyed by slot, a later decision for the same slot replaces the earlier one.
+func (s *Store) SaveProposalDecision(ctx context.Context, decision *ethpb.ProposalDecision) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveProposalDecision")
+	defer span.End()
+
+	if decision == nil {
+		return errors.New("nil proposal decision")
+	}
+	enc, err := encode(ctx, decision)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(proposalDecisionsBucket).Put(bytesutil.SlotToBytesBigEndian(decision.Slot), enc)
+	})
+}
+
+// PruneProposalDecisions deletes the decisions older than the block retention window,
+// MIN_EPOCHS_FOR_BLOCK_REQUESTS, ending at the given slot.
+func (s *Store) PruneProposalDecisions(ctx context.Context, slot primitives.Slot) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.PruneProposalDecisions")
+	defer span.End()
+
+	retention, err := params.BeaconConfig().SlotsPerEpoch.SafeMul(uint64(params.BeaconConfig().MinEpochsForBlockRequests))
+	if err != nil || slot <= retention {
+		return nil
+	}
+	cutoff := slot - retention
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(proposalDecisionsBucket)
+		var stale [][]byte
+		c := bkt.Cursor()
+		for k, _ := c.First(); k != nil && primitives.Slot(binary.BigEndian.Uint64(k)) < cutoff; k, _ = c.Next() {
+			stale = append(stale, bytesutil.SafeCopyBytes(k))
+		}
+		for _, k := range stale {
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ProposalDecisions returns the proposal decisions recorded between the start and end slots, inclusive,
+// ordered by slot.
+func (s *Store) ProposalDecisions(ctx context.Context, startSlot, endSlot primitives.Slot) ([]*ethpb.ProposalDecision, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.ProposalDecisions")
+	defer span.End()
+
+	if startSlot > endSlot {
+		return nil, errors.Errorf("start slot %d is greater than end slot %d", startSlot, endSlot)
+	}
+	decisions := make([]*ethpb.ProposalDecision, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(proposalDecisionsBucket).Cursor()
+		for k, v := c.Seek(bytesutil.SlotToBytesBigEndian(startSlot)); k != nil && primitives.Slot(binary.BigEndian.Uint64(k)) <= endSlot; k, v = c.Next() {
+			decision := &ethpb.ProposalDecision{}
+			if err := decode(ctx, v, decision); err != nil {
+				return err
+			}
+			decisions = append(decisions, decision)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decisions, nil
+}
@@ -0,0 +1,68 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_ProposalDecisions(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	require.ErrorContains(t, "nil proposal decision", db.SaveProposalDecision(ctx, nil))
+	for _, slot := range []primitives.Slot{3, 1, 5, 2} {
+		require.NoError(t, db.SaveProposalDecision(ctx, &ethpb.ProposalDecision{
+			Slot:          slot,
+			ProposerIndex: primitives.ValidatorIndex(slot),
+			Reason:        "local_value_higher",
+		}))
+	}
+	// A later decision for the same slot replaces the earlier one.
+	require.NoError(t, db.SaveProposalDecision(ctx, &ethpb.ProposalDecision{
+		Slot:            3,
+		ProposerIndex:   3,
+		BuilderSelected: true,
+		Reason:          "builder_value_higher",
+	}))
+
+	decisions, err := db.ProposalDecisions(ctx, 2, 4)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(decisions))
+	require.Equal(t, primitives.Slot(2), decisions[0].Slot)
+	require.Equal(t, primitives.Slot(3), decisions[1].Slot)
+	require.Equal(t, true, decisions[1].BuilderSelected)
+	require.Equal(t, "builder_value_higher", decisions[1].Reason)
+
+	decisions, err = db.ProposalDecisions(ctx, 6, 10)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(decisions))
+
+	_, err = db.ProposalDecisions(ctx, 4, 2)
+	require.ErrorContains(t, "start slot 4 is greater than end slot 2", err)
+}
+
+func TestStore_ProposalDecisions_Pruned(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	retention := params.BeaconConfig().SlotsPerEpoch * primitives.Slot(params.BeaconConfig().MinEpochsForBlockRequests)
+
+	for _, slot := range []primitives.Slot{1, 2, retention + 1} {
+		require.NoError(t, db.SaveProposalDecision(ctx, &ethpb.ProposalDecision{Slot: slot}))
+	}
+	decisions, err := db.ProposalDecisions(ctx, 0, retention+1)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(decisions))
+
+	// Decisions past the retention window ending at the slot are pruned.
+	require.NoError(t, db.PruneProposalDecisions(ctx, retention+2))
+	decisions, err = db.ProposalDecisions(ctx, 0, retention+1)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(decisions))
+	require.Equal(t, primitives.Slot(2), decisions[0].Slot)
+	require.Equal(t, retention+1, decisions[1].Slot)
+}
@@ -16,6 +16,10 @@ var (
 	stateValidatorsBucket = []byte("state-validators")
 	feeRecipientBucket    = []byte("fee-recipient")
 	registrationBucket    = []byte("registration")
+	stateDiffBucket       = []byte("state-diff")
+
+	// Audit log of the builder versus local payload selection of local proposals.
+	proposalDecisionsBucket = []byte("proposal-decisions")
 
 	// Light Client Updates Bucket
 	lightClientUpdatesBucket = []byte("light-client-updates")
@@ -58,6 +62,8 @@ var (
 	originCheckpointBlockRootKey = []byte("origin-checkpoint-block-root")
 	// tracking data about an ongoing backfill
 	backfillStatusKey = []byte("backfill-status")
+	// latest copy of the fork choice store, used to restore it on restart
+	forkchoiceSnapshotKey = []byte("forkchoice-snapshot")
 
 	// Deprecated: This index key was migrated in PR 6461. Do not use, except for migrations.
 	lastArchivedIndexKey = []byte("last-archived")
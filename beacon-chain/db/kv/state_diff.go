@@ -0,0 +1,60 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SaveStateDiff stores an encoded layer of the hierarchical historical state representation
+// for the given slot. The encoding is owned by stategen, the db only compresses it.
+func (s *Store) SaveStateDiff(ctx context.Context, slot primitives.Slot, enc []byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveStateDiff")
+	defer span.End()
+	if len(enc) == 0 {
+		return errors.New("cannot save empty state diff")
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(stateDiffBucket)
+		return bkt.Put(bytesutil.SlotToBytesBigEndian(slot), snappy.Encode(nil, enc))
+	})
+}
+
+// StateDiff returns the encoded state diff layer saved for the given slot,
+// or an error wrapping ErrNotFoundState if there is none.
+func (s *Store) StateDiff(ctx context.Context, slot primitives.Slot) ([]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.StateDiff")
+	defer span.End()
+	var enc []byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(stateDiffBucket)
+		enc = bytesutil.SafeCopyBytes(bkt.Get(bytesutil.SlotToBytesBigEndian(slot)))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if len(enc) == 0 {
+		return nil, errors.Wrapf(ErrNotFoundState, "no state diff for slot %d", slot)
+	}
+	return snappy.Decode(nil, enc)
+}
+
+// HasStateDiff returns true if a state diff layer exists for the given slot.
+func (s *Store) HasStateDiff(ctx context.Context, slot primitives.Slot) bool {
+	_, span := trace.StartSpan(ctx, "BeaconDB.HasStateDiff")
+	defer span.End()
+	var exists bool
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(stateDiffBucket)
+		exists = bkt.Get(bytesutil.SlotToBytesBigEndian(slot)) != nil
+		return nil
+	}); err != nil { // This view never returns an error, but we'll handle anyway for sanity.
+		panic(err)
+	}
+	return exists
+}
@@ -0,0 +1,26 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_StateDiff(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	require.Equal(t, false, db.HasStateDiff(ctx, 32))
+	_, err := db.StateDiff(ctx, 32)
+	require.ErrorIs(t, err, ErrNotFoundState)
+	require.ErrorContains(t, "empty state diff", db.SaveStateDiff(ctx, 32, nil))
+
+	enc := []byte{1, 2, 3, 4, 5}
+	require.NoError(t, db.SaveStateDiff(ctx, 32, enc))
+	require.Equal(t, true, db.HasStateDiff(ctx, 32))
+	got, err := db.StateDiff(ctx, 32)
+	require.NoError(t, err)
+	require.DeepEqual(t, enc, got)
+	require.Equal(t, false, db.HasStateDiff(ctx, 64))
+}
@@ -0,0 +1,45 @@
+package slasherkv
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// LastBackfilledEpoch returns the last epoch whose blocks were backfilled into the slasher
+// database, and false if no backfill was ever run.
+func (s *Store) LastBackfilledEpoch(ctx context.Context) (primitives.Epoch, bool, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.LastBackfilledEpoch")
+	defer span.End()
+
+	var (
+		epoch primitives.Epoch
+		found bool
+	)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(backfillBucket).Get(backfillProgressKey)
+		if enc == nil {
+			return nil
+		}
+		found = true
+		return epoch.UnmarshalSSZ(enc)
+	})
+	return epoch, found, err
+}
+
+// SaveLastBackfilledEpoch saves the last epoch whose blocks were backfilled into the slasher
+// database, so that an interrupted backfill resumes after it.
+func (s *Store) SaveLastBackfilledEpoch(ctx context.Context, epoch primitives.Epoch) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveLastBackfilledEpoch")
+	defer span.End()
+
+	enc, err := epoch.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(backfillBucket).Put(backfillProgressKey, enc)
+	})
+}
@@ -0,0 +1,29 @@
+package slasherkv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_LastBackfilledEpoch(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	_, found, err := beaconDB.LastBackfilledEpoch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, false, found)
+
+	require.NoError(t, beaconDB.SaveLastBackfilledEpoch(ctx, 0))
+	epoch, found, err := beaconDB.LastBackfilledEpoch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, true, found)
+	require.Equal(t, primitives.Epoch(0), epoch)
+
+	require.NoError(t, beaconDB.SaveLastBackfilledEpoch(ctx, 42))
+	epoch, _, err = beaconDB.LastBackfilledEpoch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Epoch(42), epoch)
+}
@@ -78,6 +78,10 @@ func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
 			attestationDataRootsBucket,
 			proposalRecordsBucket,
 			slasherChunksBucket,
+			backfillBucket,
+			attesterSlashingsBucket,
+			proposerSlashingsBucket,
+			slashingsByValidatorBucket,
 		)
 	}); err != nil {
 		return nil, err
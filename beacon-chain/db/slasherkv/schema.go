@@ -24,4 +24,18 @@ var (
 	// value: (encoded) SignedBlockHeaderWrapper
 	proposalRecordsBucket = []byte("proposal-records")
 	slasherChunksBucket   = []byte("slasher-chunks")
+
+	// key: backfillProgressKey
+	// value: (encoded) Epoch
+	backfillBucket      = []byte("slasher-backfill")
+	backfillProgressKey = []byte("last-backfilled-epoch")
+
+	// key: AttesterSlashing / ProposerSlashing HashTreeRoot
+	// value: (encoded + compressed) AttesterSlashing / ProposerSlashing
+	attesterSlashingsBucket = []byte("attester-slashings")
+	proposerSlashingsBucket = []byte("proposer-slashings")
+
+	// key: (encoded) ValidatorIndex + slashing HashTreeRoot
+	// value: name of the bucket the slashing is stored in
+	slashingsByValidatorBucket = []byte("slashings-by-validator")
 )
@@ -0,0 +1,154 @@
+package slasherkv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/container/slice"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveAttesterSlashings saves the attester slashings detected by the slasher, indexed by
+// the validators they slash. Slashings are never pruned.
+func (s *Store) SaveAttesterSlashings(ctx context.Context, slashings []ethpb.AttSlashing) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveAttesterSlashings")
+	defer span.End()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, slashing := range slashings {
+			// Like the attestation records, only phase0 attestations are supported.
+			if _, ok := slashing.(*ethpb.AttesterSlashing); !ok {
+				return errors.Errorf("unsupported attester slashing type %T", slashing)
+			}
+			indices := slice.IntersectionUint64(
+				slashing.FirstAttestation().GetAttestingIndices(),
+				slashing.SecondAttestation().GetAttestingIndices(),
+			)
+			validators := make([]primitives.ValidatorIndex, len(indices))
+			for i, index := range indices {
+				validators[i] = primitives.ValidatorIndex(index)
+			}
+			if err := saveSlashing(tx, attesterSlashingsBucket, slashing, validators); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SaveProposerSlashings saves the proposer slashings detected by the slasher, indexed by
+// the validators they slash. Slashings are never pruned.
+func (s *Store) SaveProposerSlashings(ctx context.Context, slashings []*ethpb.ProposerSlashing) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveProposerSlashings")
+	defer span.End()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, slashing := range slashings {
+			if slashing.GetHeader_1().GetHeader() == nil {
+				return errors.New("nil proposer slashing header")
+			}
+			validators := []primitives.ValidatorIndex{slashing.Header_1.Header.ProposerIndex}
+			if err := saveSlashing(tx, proposerSlashingsBucket, slashing, validators); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AttesterSlashingsForValidator retrieves the attester slashings saved for a validator.
+func (s *Store) AttesterSlashingsForValidator(
+	ctx context.Context, validatorIdx primitives.ValidatorIndex,
+) ([]ethpb.AttSlashing, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.AttesterSlashingsForValidator")
+	defer span.End()
+
+	var slashings []ethpb.AttSlashing
+	err := s.slashingsForValidator(validatorIdx, attesterSlashingsBucket, func(enc []byte) error {
+		slashing := &ethpb.AttesterSlashing{}
+		if err := slashing.UnmarshalSSZ(enc); err != nil {
+			return err
+		}
+		slashings = append(slashings, slashing)
+		return nil
+	})
+	return slashings, err
+}
+
+// ProposerSlashingsForValidator retrieves the proposer slashings saved for a validator.
+func (s *Store) ProposerSlashingsForValidator(
+	ctx context.Context, validatorIdx primitives.ValidatorIndex,
+) ([]*ethpb.ProposerSlashing, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ProposerSlashingsForValidator")
+	defer span.End()
+
+	var slashings []*ethpb.ProposerSlashing
+	err := s.slashingsForValidator(validatorIdx, proposerSlashingsBucket, func(enc []byte) error {
+		slashing := &ethpb.ProposerSlashing{}
+		if err := slashing.UnmarshalSSZ(enc); err != nil {
+			return err
+		}
+		slashings = append(slashings, slashing)
+		return nil
+	})
+	return slashings, err
+}
+
+type sszSlashing interface {
+	HashTreeRoot() ([32]byte, error)
+	MarshalSSZ() ([]byte, error)
+}
+
+// saveSlashing saves the slashing in the bucket and indexes it by the validators it slashes.
+func saveSlashing(tx *bolt.Tx, bucket []byte, slashing sszSlashing, validators []primitives.ValidatorIndex) error {
+	root, err := slashing.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute slashing root")
+	}
+	enc, err := slashing.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not encode slashing")
+	}
+	if err := tx.Bucket(bucket).Put(root[:], snappy.Encode(nil, enc)); err != nil {
+		return err
+	}
+	byValidatorBkt := tx.Bucket(slashingsByValidatorBucket)
+	for _, validatorIdx := range validators {
+		key := append(encodeValidatorIndex(validatorIdx), root[:]...)
+		if err := byValidatorBkt.Put(key, bucket); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// slashingsForValidator calls f with the decompressed slashings of the bucket saved for the validator.
+func (s *Store) slashingsForValidator(validatorIdx primitives.ValidatorIndex, bucket []byte, f func([]byte) error) error {
+	prefix := encodeValidatorIndex(validatorIdx)
+	return s.db.View(func(tx *bolt.Tx) error {
+		slashingsBkt := tx.Bucket(bucket)
+		c := tx.Bucket(slashingsByValidatorBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			if !bytes.Equal(v, bucket) {
+				continue
+			}
+			compressed := slashingsBkt.Get(k[len(prefix):])
+			if compressed == nil {
+				return errors.Errorf("slashing with root %#x not found", k[len(prefix):])
+			}
+			enc, err := snappy.Decode(nil, compressed)
+			if err != nil {
+				return err
+			}
+			if err := f(enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
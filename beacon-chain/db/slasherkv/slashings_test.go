@@ -0,0 +1,70 @@
+package slasherkv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_AttesterSlashings(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	// Only validators 2 and 3 attested twice.
+	slashing := &ethpb.AttesterSlashing{
+		Attestation_1: createAttestationWrapper(1, 2, []uint64{1, 2, 3}, nil).IndexedAttestation.(*ethpb.IndexedAttestation),
+		Attestation_2: createAttestationWrapper(0, 3, []uint64{2, 3, 4}, nil).IndexedAttestation.(*ethpb.IndexedAttestation),
+	}
+	require.NoError(t, beaconDB.SaveAttesterSlashings(ctx, []ethpb.AttSlashing{slashing}))
+	// Saving the same slashing again is a no-op.
+	require.NoError(t, beaconDB.SaveAttesterSlashings(ctx, []ethpb.AttSlashing{slashing}))
+
+	for _, index := range []primitives.ValidatorIndex{2, 3} {
+		slashings, err := beaconDB.AttesterSlashingsForValidator(ctx, index)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(slashings))
+		require.DeepEqual(t, slashing, slashings[0])
+	}
+	for _, index := range []primitives.ValidatorIndex{1, 4} {
+		slashings, err := beaconDB.AttesterSlashingsForValidator(ctx, index)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(slashings))
+	}
+
+	// Attester and proposer slashings of a validator are kept apart.
+	proposerSlashings, err := beaconDB.ProposerSlashingsForValidator(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(proposerSlashings))
+
+	require.ErrorContains(t, "unsupported attester slashing type", beaconDB.SaveAttesterSlashings(ctx, []ethpb.AttSlashing{&ethpb.AttesterSlashingElectra{}}))
+}
+
+func TestStore_ProposerSlashings(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	slashing := &ethpb.ProposerSlashing{
+		Header_1: createProposalWrapper(t, 4, 5, []byte{1}).SignedBeaconBlockHeader,
+		Header_2: createProposalWrapper(t, 4, 5, []byte{2}).SignedBeaconBlockHeader,
+	}
+	other := &ethpb.ProposerSlashing{
+		Header_1: createProposalWrapper(t, 9, 5, []byte{1}).SignedBeaconBlockHeader,
+		Header_2: createProposalWrapper(t, 9, 5, []byte{2}).SignedBeaconBlockHeader,
+	}
+	require.NoError(t, beaconDB.SaveProposerSlashings(ctx, []*ethpb.ProposerSlashing{slashing, other}))
+
+	slashings, err := beaconDB.ProposerSlashingsForValidator(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(slashings))
+
+	slashings, err = beaconDB.ProposerSlashingsForValidator(ctx, 6)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(slashings))
+
+	attesterSlashings, err := beaconDB.AttesterSlashingsForValidator(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(attesterSlashings))
+}
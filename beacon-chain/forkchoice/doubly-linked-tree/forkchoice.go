@@ -3,10 +3,10 @@ package doublylinkedtree
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
@@ -51,9 +51,18 @@ func (f *ForkChoice) NodeCount() int {
 // It firsts computes validator's balance changes then recalculates block tree from leaves to root.
 func (f *ForkChoice) Head(
 	ctx context.Context,
-) ([32]byte, error) {
+) (head [32]byte, err error) {
 	ctx, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.Head")
 	defer span.End()
+	if f.recorder != nil {
+		defer func() {
+			e := &forkchoicetypes.Event{Kind: forkchoicetypes.EventHead}
+			if err == nil {
+				e.Root = head[:]
+			}
+			f.record(e)
+		}()
+	}
 
 	calledHeadCount.Inc()
 
@@ -71,7 +80,7 @@ func (f *ForkChoice) Head(
 
 	jc := f.JustifiedCheckpoint()
 	fc := f.FinalizedCheckpoint()
-	currentEpoch := slots.EpochsSinceGenesis(time.Unix(int64(f.store.genesisTime), 0))
+	currentEpoch := slots.ToEpoch(f.store.currentSlot())
 	if err := f.store.treeRootNode.updateBestDescendant(ctx, jc.Epoch, fc.Epoch, currentEpoch); err != nil {
 		return [32]byte{}, errors.Wrap(err, "could not update best descendant")
 	}
@@ -100,6 +109,14 @@ func (f *ForkChoice) ProcessAttestation(ctx context.Context, validatorIndices []
 			f.votes[index].nextRoot = blockRoot
 		}
 	}
+	if f.recorder != nil {
+		f.record(&forkchoicetypes.Event{
+			Kind:        forkchoicetypes.EventAttestation,
+			Root:        blockRoot[:],
+			TargetEpoch: targetEpoch,
+			Indices:     validatorIndices,
+		})
+	}
 
 	processedAttestationCount.Inc()
 }
@@ -129,18 +146,43 @@ func (f *ForkChoice) InsertNode(ctx context.Context, state state.BeaconState, ro
 	if jc == nil {
 		return errInvalidNilCheckpoint
 	}
-	justifiedEpoch := jc.Epoch
 	fc := state.FinalizedCheckpoint()
 	if fc == nil {
 		return errInvalidNilCheckpoint
 	}
-	finalizedEpoch := fc.Epoch
-	node, err := f.store.insert(ctx, slot, root, parentRoot, payloadHash, justifiedEpoch, finalizedEpoch)
+	// The unrealized checkpoints are only computed when needed, they are kept to be recorded.
+	var uj, uf *ethpb.Checkpoint
+	unrealized := func() (*ethpb.Checkpoint, *ethpb.Checkpoint, error) {
+		var err error
+		uj, uf, err = precompute.UnrealizedCheckpoints(state)
+		return uj, uf, err
+	}
+	err := f.insertNode(ctx, slot, root, parentRoot, payloadHash, jc, fc, unrealized)
+	if f.recorder != nil {
+		f.record(&forkchoicetypes.Event{
+			Kind:                forkchoicetypes.EventBlock,
+			Slot:                slot,
+			Root:                root[:],
+			ParentRoot:          parentRoot[:],
+			PayloadHash:         payloadHash[:],
+			Justified:           recordedProtoCheckpoint(jc),
+			Finalized:           recordedProtoCheckpoint(fc),
+			UnrealizedJustified: recordedProtoCheckpoint(uj),
+			UnrealizedFinalized: recordedProtoCheckpoint(uf),
+		})
+	}
+	return err
+}
+
+// insertNode inserts a new node and updates the checkpoints of the store, pulling up the
+// unrealized checkpoints of the node if needed.
+func (f *ForkChoice) insertNode(ctx context.Context, slot primitives.Slot, root, parentRoot, payloadHash [32]byte, jc, fc *ethpb.Checkpoint, unrealized unrealizedCheckpointsFn) error {
+	node, err := f.store.insert(ctx, slot, root, parentRoot, payloadHash, jc.Epoch, fc.Epoch)
 	if err != nil {
 		return err
 	}
 
-	jc, fc = f.store.pullTips(state, node, jc, fc)
+	jc, fc = f.store.pullTips(slot, unrealized, node, jc, fc)
 	return f.updateCheckpoints(ctx, jc, fc)
 }
 
@@ -350,6 +392,9 @@ func (f *ForkChoice) SetOptimisticToValid(ctx context.Context, root [fieldparams
 	if !ok || node == nil {
 		return errors.Wrap(ErrNilNode, "could not set node to valid")
 	}
+	if f.recorder != nil {
+		defer f.record(&forkchoicetypes.Event{Kind: forkchoicetypes.EventPayloadValid, Root: root[:]})
+	}
 	return node.setNodeAndParentValidated(ctx)
 }
 
@@ -370,6 +415,14 @@ func (f *ForkChoice) FinalizedCheckpoint() *forkchoicetypes.Checkpoint {
 
 // SetOptimisticToInvalid removes a block with an invalid execution payload from fork choice store
 func (f *ForkChoice) SetOptimisticToInvalid(ctx context.Context, root, parentRoot, payloadHash [fieldparams.RootLength]byte) ([][32]byte, error) {
+	if f.recorder != nil {
+		defer f.record(&forkchoicetypes.Event{
+			Kind:          forkchoicetypes.EventPayloadInvalid,
+			Root:          root[:],
+			ParentRoot:    parentRoot[:],
+			LastValidHash: payloadHash[:],
+		})
+	}
 	return f.store.setOptimisticToInvalid(ctx, root, parentRoot, payloadHash)
 }
 
@@ -382,6 +435,9 @@ func (f *ForkChoice) InsertSlashedIndex(_ context.Context, index primitives.Vali
 		return
 	}
 	f.store.slashedIndices[index] = true
+	if f.recorder != nil {
+		f.record(&forkchoicetypes.Event{Kind: forkchoicetypes.EventSlashing, Indices: []uint64{uint64(index)}})
+	}
 
 	// Subtract last vote from this equivocating validator
 
@@ -410,6 +466,9 @@ func (f *ForkChoice) UpdateJustifiedCheckpoint(ctx context.Context, jc *forkchoi
 	if jc == nil {
 		return errInvalidNilCheckpoint
 	}
+	if f.recorder != nil {
+		defer f.record(&forkchoicetypes.Event{Kind: forkchoicetypes.EventJustified, Justified: recordedCheckpoint(jc)})
+	}
 	f.store.prevJustifiedCheckpoint = f.store.justifiedCheckpoint
 	f.store.justifiedCheckpoint = jc
 	if err := f.updateJustifiedBalances(ctx, jc.Root); err != nil {
@@ -424,6 +483,9 @@ func (f *ForkChoice) UpdateFinalizedCheckpoint(fc *forkchoicetypes.Checkpoint) e
 		return errInvalidNilCheckpoint
 	}
 	f.store.finalizedCheckpoint = fc
+	if f.recorder != nil {
+		f.record(&forkchoicetypes.Event{Kind: forkchoicetypes.EventFinalized, Finalized: recordedCheckpoint(fc)})
+	}
 	return nil
 }
 
@@ -490,18 +552,33 @@ func (f *ForkChoice) InsertChain(ctx context.Context, chain []*forkchoicetypes.B
 		if err != nil {
 			return err
 		}
-		if _, err := f.store.insert(ctx,
-			b.Slot(), r, parentRoot, payloadHash,
-			chain[i].JustifiedCheckpoint.Epoch, chain[i].FinalizedCheckpoint.Epoch); err != nil {
-			return err
+		err = f.insertChainBlock(ctx, b.Slot(), r, parentRoot, payloadHash, chain[i].JustifiedCheckpoint, chain[i].FinalizedCheckpoint)
+		if f.recorder != nil {
+			f.record(&forkchoicetypes.Event{
+				Kind:        forkchoicetypes.EventChainBlock,
+				Slot:        b.Slot(),
+				Root:        r[:],
+				ParentRoot:  parentRoot[:],
+				PayloadHash: payloadHash[:],
+				Justified:   recordedProtoCheckpoint(chain[i].JustifiedCheckpoint),
+				Finalized:   recordedProtoCheckpoint(chain[i].FinalizedCheckpoint),
+			})
 		}
-		if err := f.updateCheckpoints(ctx, chain[i].JustifiedCheckpoint, chain[i].FinalizedCheckpoint); err != nil {
+		if err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// insertChainBlock inserts a node of a chain of blocks and updates the checkpoints of the store.
+func (f *ForkChoice) insertChainBlock(ctx context.Context, slot primitives.Slot, root, parentRoot, payloadHash [32]byte, jc, fc *ethpb.Checkpoint) error {
+	if _, err := f.store.insert(ctx, slot, root, parentRoot, payloadHash, jc.Epoch, fc.Epoch); err != nil {
+		return err
+	}
+	return f.updateCheckpoints(ctx, jc, fc)
+}
+
 // SetGenesisTime sets the genesisTime tracked by forkchoice
 func (f *ForkChoice) SetGenesisTime(genesisTime uint64) {
 	f.store.genesisTime = genesisTime
@@ -617,6 +694,9 @@ func (f *ForkChoice) updateJustifiedBalances(ctx context.Context, root [32]byte)
 	if err != nil {
 		return errors.Wrap(err, "could not get justified balances")
 	}
+	if f.recorder != nil {
+		f.record(&forkchoicetypes.Event{Kind: forkchoicetypes.EventBalances, Root: root[:], Balances: balances})
+	}
 	f.justifiedBalances = balances
 	f.store.committeeWeight = 0
 	f.numActiveValidators = 0
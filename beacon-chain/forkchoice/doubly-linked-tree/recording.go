@@ -0,0 +1,129 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+var errUnrecordedUnrealizedCheckpoints = errors.New("unrealized checkpoints were not recorded")
+
+// SetRecorder sends the inputs of fork choice and its decisions to the given recorder, starting
+// with an anchor event holding a snapshot of the store. A nil recorder stops the recording.
+// The caller must hold the fork choice lock.
+func (f *ForkChoice) SetRecorder(ctx context.Context, r forkchoice.Recorder) error {
+	if r == nil {
+		f.recorder = nil
+		return nil
+	}
+	snapshot, err := f.Snapshot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not snapshot fork choice")
+	}
+	f.recorder = r
+	f.record(&forkchoicetypes.Event{Kind: forkchoicetypes.EventAnchor, Snapshot: snapshot})
+	return nil
+}
+
+// record sends the event to the recorder, stamped with the current time of fork choice.
+func (f *ForkChoice) record(e *forkchoicetypes.Event) {
+	if f.recorder == nil {
+		return
+	}
+	e.TimeMillis = f.store.now().UnixMilli()
+	f.recorder.Record(e)
+}
+
+// ApplyEvent applies a recorded event to fork choice, as if it was handled at the time it was
+// recorded. Fork choice keeps the time of the event until the next one is applied, so that its
+// decisions can be inspected. Blocks are inserted with the unrealized checkpoints that were
+// recorded instead of computing them from a state. Balances events are not applied: the balances
+// they carry have to be served by the handler set with SetBalancesByRooter. Events recording a
+// decision that does not change the store are ignored. The caller must hold the fork choice lock.
+func (f *ForkChoice) ApplyEvent(ctx context.Context, e *forkchoicetypes.Event) error {
+	now := time.UnixMilli(e.TimeMillis)
+	f.store.timeNow = func() time.Time { return now }
+
+	switch e.Kind {
+	case forkchoicetypes.EventAnchor:
+		if e.Snapshot == nil {
+			return errors.New("anchor event without a snapshot")
+		}
+		return f.RestoreSnapshot(ctx, e.Snapshot)
+	case forkchoicetypes.EventBlock:
+		if e.Justified == nil || e.Finalized == nil {
+			return errInvalidNilCheckpoint
+		}
+		unrealized := func() (*ethpb.Checkpoint, *ethpb.Checkpoint, error) {
+			if e.UnrealizedJustified == nil || e.UnrealizedFinalized == nil {
+				return nil, nil, errUnrecordedUnrealizedCheckpoints
+			}
+			return protoCheckpoint(e.UnrealizedJustified), protoCheckpoint(e.UnrealizedFinalized), nil
+		}
+		return f.insertNode(ctx, e.Slot, bytesutil.ToBytes32(e.Root), bytesutil.ToBytes32(e.ParentRoot),
+			bytesutil.ToBytes32(e.PayloadHash), protoCheckpoint(e.Justified), protoCheckpoint(e.Finalized), unrealized)
+	case forkchoicetypes.EventChainBlock:
+		if e.Justified == nil || e.Finalized == nil {
+			return errInvalidNilCheckpoint
+		}
+		return f.insertChainBlock(ctx, e.Slot, bytesutil.ToBytes32(e.Root), bytesutil.ToBytes32(e.ParentRoot),
+			bytesutil.ToBytes32(e.PayloadHash), protoCheckpoint(e.Justified), protoCheckpoint(e.Finalized))
+	case forkchoicetypes.EventAttestation:
+		f.ProcessAttestation(ctx, e.Indices, bytesutil.ToBytes32(e.Root), e.TargetEpoch)
+		return nil
+	case forkchoicetypes.EventSlashing:
+		for _, idx := range e.Indices {
+			f.InsertSlashedIndex(ctx, primitives.ValidatorIndex(idx))
+		}
+		return nil
+	case forkchoicetypes.EventTick:
+		return f.NewSlot(ctx, e.Slot)
+	case forkchoicetypes.EventJustified:
+		if e.Justified == nil {
+			return errInvalidNilCheckpoint
+		}
+		return f.UpdateJustifiedCheckpoint(ctx, checkpointFromRecorded(e.Justified))
+	case forkchoicetypes.EventFinalized:
+		if e.Finalized == nil {
+			return errInvalidNilCheckpoint
+		}
+		return f.UpdateFinalizedCheckpoint(checkpointFromRecorded(e.Finalized))
+	case forkchoicetypes.EventPayloadValid:
+		return f.SetOptimisticToValid(ctx, bytesutil.ToBytes32(e.Root))
+	case forkchoicetypes.EventPayloadInvalid:
+		_, err := f.SetOptimisticToInvalid(ctx, bytesutil.ToBytes32(e.Root), bytesutil.ToBytes32(e.ParentRoot), bytesutil.ToBytes32(e.LastValidHash))
+		return err
+	case forkchoicetypes.EventHead:
+		_, err := f.Head(ctx)
+		return err
+	case forkchoicetypes.EventBalances, forkchoicetypes.EventOverrideFCU, forkchoicetypes.EventProposerHead:
+		return nil
+	default:
+		return errors.Errorf("unknown fork choice event kind %q", e.Kind)
+	}
+}
+
+func recordedCheckpoint(cp *forkchoicetypes.Checkpoint) *forkchoicetypes.RecordedCheckpoint {
+	return &forkchoicetypes.RecordedCheckpoint{Epoch: cp.Epoch, Root: bytesutil.SafeCopyBytes(cp.Root[:])}
+}
+
+func recordedProtoCheckpoint(cp *ethpb.Checkpoint) *forkchoicetypes.RecordedCheckpoint {
+	if cp == nil {
+		return nil
+	}
+	return &forkchoicetypes.RecordedCheckpoint{Epoch: cp.Epoch, Root: bytesutil.SafeCopyBytes(cp.Root)}
+}
+
+func protoCheckpoint(cp *forkchoicetypes.RecordedCheckpoint) *ethpb.Checkpoint {
+	return &ethpb.Checkpoint{Epoch: cp.Epoch, Root: bytesutil.PadTo(cp.Root, 32)}
+}
+
+func checkpointFromRecorded(cp *forkchoicetypes.RecordedCheckpoint) *forkchoicetypes.Checkpoint {
+	return &forkchoicetypes.Checkpoint{Epoch: cp.Epoch, Root: bytesutil.ToBytes32(cp.Root)}
+}
@@ -0,0 +1,117 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"testing"
+
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+type eventCollector struct {
+	events []*forkchoicetypes.Event
+}
+
+func (c *eventCollector) Record(e *forkchoicetypes.Event) {
+	c.events = append(c.events, e)
+}
+
+func TestForkChoice_RecordAndApplyEvents(t *testing.T) {
+	ctx := context.Background()
+	f := setup(0, 0)
+	f.justifiedBalances = []uint64{10, 20, 30, 40}
+	driftGenesisTime(f, 3, 0)
+	collector := &eventCollector{}
+	require.NoError(t, f.SetRecorder(ctx, collector))
+
+	st, root, err := prepareForkchoiceState(ctx, 1, [32]byte{'a'}, params.BeaconConfig().ZeroHash, [32]byte{'A'}, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, root))
+	st, root, err = prepareForkchoiceState(ctx, 2, [32]byte{'b'}, [32]byte{'a'}, [32]byte{'B'}, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, root))
+	st, root, err = prepareForkchoiceState(ctx, 3, [32]byte{'c'}, [32]byte{'a'}, [32]byte{'C'}, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, root))
+	require.NoError(t, f.SetOptimisticToValid(ctx, [32]byte{'a'}))
+	f.ProcessAttestation(ctx, []uint64{0, 1, 2}, [32]byte{'b'}, 0)
+	f.ProcessAttestation(ctx, []uint64{3}, [32]byte{'c'}, 0)
+	require.NoError(t, f.UpdateJustifiedCheckpoint(ctx, &forkchoicetypes.Checkpoint{Root: params.BeaconConfig().ZeroHash}))
+	head, err := f.Head(ctx)
+	require.NoError(t, err)
+	f.InsertSlashedIndex(ctx, 1)
+	require.NoError(t, f.NewSlot(ctx, 4))
+	_, err = f.Head(ctx)
+	require.NoError(t, err)
+	f.ShouldOverrideFCU()
+	f.GetProposerHead()
+
+	kinds := make([]forkchoicetypes.EventKind, len(collector.events))
+	for i, e := range collector.events {
+		kinds[i] = e.Kind
+	}
+	assert.DeepEqual(t, []forkchoicetypes.EventKind{
+		forkchoicetypes.EventAnchor,
+		forkchoicetypes.EventBlock,
+		forkchoicetypes.EventBlock,
+		forkchoicetypes.EventBlock,
+		forkchoicetypes.EventPayloadValid,
+		forkchoicetypes.EventAttestation,
+		forkchoicetypes.EventAttestation,
+		forkchoicetypes.EventBalances,
+		forkchoicetypes.EventJustified,
+		forkchoicetypes.EventHead,
+		forkchoicetypes.EventSlashing,
+		forkchoicetypes.EventTick,
+		forkchoicetypes.EventHead,
+		forkchoicetypes.EventOverrideFCU,
+		forkchoicetypes.EventProposerHead,
+	}, kinds)
+	assert.DeepEqual(t, head[:], []byte(collector.events[9].Root))
+
+	replayed := New()
+	var balances []uint64
+	replayed.SetBalancesByRooter(func(_ context.Context, _ [32]byte) ([]uint64, error) { return balances, nil })
+	for _, e := range collector.events {
+		if e.Kind == forkchoicetypes.EventBalances {
+			balances = e.Balances
+		}
+		require.NoError(t, replayed.ApplyEvent(ctx, e))
+		switch e.Kind {
+		case forkchoicetypes.EventHead:
+			assert.Equal(t, bytesutil.ToBytes32(e.Root), replayed.CachedHeadRoot())
+		case forkchoicetypes.EventOverrideFCU:
+			assert.Equal(t, e.Override, replayed.ShouldOverrideFCU())
+		case forkchoicetypes.EventProposerHead:
+			assert.Equal(t, bytesutil.ToBytes32(e.Root), replayed.GetProposerHead())
+		}
+	}
+
+	assert.Equal(t, f.NodeCount(), replayed.NodeCount())
+	assert.Equal(t, f.CachedHeadRoot(), replayed.CachedHeadRoot())
+	assert.DeepEqual(t, f.votes, replayed.votes)
+	assert.DeepEqual(t, f.balances, replayed.balances)
+	assert.DeepEqual(t, f.store.slashedIndices, replayed.store.slashedIndices)
+	assert.Equal(t, f.store.proposerBoostRoot, replayed.store.proposerBoostRoot)
+	for root, n := range f.store.nodeByRoot {
+		rn, ok := replayed.store.nodeByRoot[root]
+		require.Equal(t, true, ok)
+		assert.Equal(t, n.weight, rn.weight)
+		assert.Equal(t, n.optimistic, rn.optimistic)
+		assert.Equal(t, n.timestamp, rn.timestamp)
+	}
+
+	// Stopping the recording.
+	require.NoError(t, f.SetRecorder(ctx, nil))
+	f.ProcessAttestation(ctx, []uint64{0}, [32]byte{'c'}, 1)
+	assert.Equal(t, len(kinds), len(collector.events))
+}
+
+func TestForkChoice_ApplyEvent_UnknownKind(t *testing.T) {
+	f := setup(0, 0)
+	err := f.ApplyEvent(context.Background(), &forkchoicetypes.Event{Kind: "unknown"})
+	require.ErrorContains(t, "unknown fork choice event kind", err)
+}
@@ -1,8 +1,7 @@
 package doublylinkedtree
 
 import (
-	"time"
-
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
@@ -33,6 +32,11 @@ const orphanLateBlockProposingEarly = 2
 // proposal time by calling GetProposerHead.
 func (f *ForkChoice) ShouldOverrideFCU() (override bool) {
 	override = false
+	if f.recorder != nil {
+		defer func() {
+			f.record(&forkchoicetypes.Event{Kind: forkchoicetypes.EventOverrideFCU, Override: override})
+		}()
+	}
 
 	// We only need to override FCU if our current head is from the current
 	// slot. This differs from the spec implementation in that we assume
@@ -42,7 +46,7 @@ func (f *ForkChoice) ShouldOverrideFCU() (override bool) {
 		return
 	}
 
-	if head.slot != slots.CurrentSlot(f.store.genesisTime) {
+	if head.slot != f.store.currentSlot() {
 		return
 	}
 
@@ -83,7 +87,7 @@ func (f *ForkChoice) ShouldOverrideFCU() (override bool) {
 	}
 
 	// Return early if we are checking before 10 seconds into the slot
-	secs, err := slots.SecondsSinceSlotStart(head.slot, f.store.genesisTime, uint64(time.Now().Unix()))
+	secs, err := slots.SecondsSinceSlotStart(head.slot, f.store.genesisTime, uint64(f.store.now().Unix()))
 	if err != nil {
 		log.WithError(err).Error("could not check current slot")
 		return true
@@ -105,14 +109,19 @@ func (f *ForkChoice) ShouldOverrideFCU() (override bool) {
 //
 // This function needs to be called only when proposing a block and all
 // attestation processing has already happened.
-func (f *ForkChoice) GetProposerHead() [32]byte {
+func (f *ForkChoice) GetProposerHead() (proposerHead [32]byte) {
+	if f.recorder != nil {
+		defer func() {
+			f.record(&forkchoicetypes.Event{Kind: forkchoicetypes.EventProposerHead, Root: proposerHead[:]})
+		}()
+	}
 	head := f.store.headNode
 	if head == nil {
 		return [32]byte{}
 	}
 
 	// Only reorg blocks from the previous slot.
-	if head.slot+1 != slots.CurrentSlot(f.store.genesisTime) {
+	if head.slot+1 != f.store.currentSlot() {
 		return head.root
 	}
 	// Do not reorg on epoch boundaries
@@ -153,7 +162,7 @@ func (f *ForkChoice) GetProposerHead() [32]byte {
 	}
 
 	// Only reorg if we are proposing early
-	secs, err := slots.SecondsSinceSlotStart(head.slot+1, f.store.genesisTime, uint64(time.Now().Unix()))
+	secs, err := slots.SecondsSinceSlotStart(head.slot+1, f.store.genesisTime, uint64(f.store.now().Unix()))
 	if err != nil {
 		log.WithError(err).Error("could not check if proposing early")
 		return head.root
@@ -0,0 +1,225 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// Snapshot returns a copy of the fork choice store, including its nodes, votes, balances,
+// proposer boost and equivocating indices, that can be persisted and loaded with RestoreSnapshot.
+// The caller must hold the fork choice read lock.
+func (f *ForkChoice) Snapshot(ctx context.Context) (*dbval.ForkchoiceSnapshot, error) {
+	if f.store.treeRootNode == nil {
+		return nil, ErrNilNode
+	}
+	nodes, err := f.store.treeRootNode.snapshot(ctx, make([]*dbval.ForkchoiceNode, 0, f.NodeCount()))
+	if err != nil {
+		return nil, err
+	}
+	var headRoot [32]byte
+	if f.store.headNode != nil {
+		headRoot = f.store.headNode.root
+	}
+	votes := make([]*dbval.ForkchoiceVote, len(f.votes))
+	for i, v := range f.votes {
+		votes[i] = &dbval.ForkchoiceVote{
+			CurrentRoot: bytesutil.SafeCopyBytes(v.currentRoot[:]),
+			NextRoot:    bytesutil.SafeCopyBytes(v.nextRoot[:]),
+			NextEpoch:   uint64(v.nextEpoch),
+		}
+	}
+	slashedIndices := make([]uint64, 0, len(f.store.slashedIndices))
+	for idx := range f.store.slashedIndices {
+		slashedIndices = append(slashedIndices, uint64(idx))
+	}
+	return &dbval.ForkchoiceSnapshot{
+		JustifiedCheckpoint:           checkpointToSnapshot(f.store.justifiedCheckpoint),
+		UnrealizedJustifiedCheckpoint: checkpointToSnapshot(f.store.unrealizedJustifiedCheckpoint),
+		UnrealizedFinalizedCheckpoint: checkpointToSnapshot(f.store.unrealizedFinalizedCheckpoint),
+		PreviousJustifiedCheckpoint:   checkpointToSnapshot(f.store.prevJustifiedCheckpoint),
+		FinalizedCheckpoint:           checkpointToSnapshot(f.store.finalizedCheckpoint),
+		ProposerBoostRoot:             bytesutil.SafeCopyBytes(f.store.proposerBoostRoot[:]),
+		PreviousProposerBoostRoot:     bytesutil.SafeCopyBytes(f.store.previousProposerBoostRoot[:]),
+		PreviousProposerBoostScore:    f.store.previousProposerBoostScore,
+		CommitteeWeight:               f.store.committeeWeight,
+		OriginRoot:                    bytesutil.SafeCopyBytes(f.store.originRoot[:]),
+		GenesisTime:                   f.store.genesisTime,
+		HeadRoot:                      headRoot[:],
+		Nodes:                         nodes,
+		Votes:                         votes,
+		Balances:                      slices.Clone(f.balances),
+		JustifiedBalances:             slices.Clone(f.justifiedBalances),
+		NumActiveValidators:           f.numActiveValidators,
+		SlashedIndices:                slashedIndices,
+	}, nil
+}
+
+// RestoreSnapshot replaces the content of the fork choice store with the given snapshot.
+// The handler to obtain balances is kept. The caller must hold the fork choice lock.
+func (f *ForkChoice) RestoreSnapshot(ctx context.Context, snapshot *dbval.ForkchoiceSnapshot) error {
+	if snapshot == nil || len(snapshot.Nodes) == 0 {
+		return errors.New("empty fork choice snapshot")
+	}
+	checkpoints := []*dbval.ForkchoiceCheckpoint{
+		snapshot.JustifiedCheckpoint,
+		snapshot.UnrealizedJustifiedCheckpoint,
+		snapshot.UnrealizedFinalizedCheckpoint,
+		snapshot.PreviousJustifiedCheckpoint,
+		snapshot.FinalizedCheckpoint,
+	}
+	for _, cp := range checkpoints {
+		if cp == nil {
+			return errInvalidNilCheckpoint
+		}
+	}
+
+	s := New().store
+	s.timeNow = f.store.timeNow
+	s.justifiedCheckpoint = checkpointFromSnapshot(snapshot.JustifiedCheckpoint)
+	s.unrealizedJustifiedCheckpoint = checkpointFromSnapshot(snapshot.UnrealizedJustifiedCheckpoint)
+	s.unrealizedFinalizedCheckpoint = checkpointFromSnapshot(snapshot.UnrealizedFinalizedCheckpoint)
+	s.prevJustifiedCheckpoint = checkpointFromSnapshot(snapshot.PreviousJustifiedCheckpoint)
+	s.finalizedCheckpoint = checkpointFromSnapshot(snapshot.FinalizedCheckpoint)
+	s.proposerBoostRoot = bytesutil.ToBytes32(snapshot.ProposerBoostRoot)
+	s.previousProposerBoostRoot = bytesutil.ToBytes32(snapshot.PreviousProposerBoostRoot)
+	s.previousProposerBoostScore = snapshot.PreviousProposerBoostScore
+	s.committeeWeight = snapshot.CommitteeWeight
+	s.originRoot = bytesutil.ToBytes32(snapshot.OriginRoot)
+	s.genesisTime = snapshot.GenesisTime
+
+	for i, sn := range snapshot.Nodes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		root := bytesutil.ToBytes32(sn.Root)
+		if _, ok := s.nodeByRoot[root]; ok {
+			return errors.Errorf("duplicate node %#x in fork choice snapshot", root)
+		}
+		n := &Node{
+			slot:                     primitives.Slot(sn.Slot),
+			root:                     root,
+			payloadHash:              bytesutil.ToBytes32(sn.PayloadHash),
+			justifiedEpoch:           primitives.Epoch(sn.JustifiedEpoch),
+			unrealizedJustifiedEpoch: primitives.Epoch(sn.UnrealizedJustifiedEpoch),
+			finalizedEpoch:           primitives.Epoch(sn.FinalizedEpoch),
+			unrealizedFinalizedEpoch: primitives.Epoch(sn.UnrealizedFinalizedEpoch),
+			balance:                  sn.Balance,
+			weight:                   sn.Weight,
+			optimistic:               sn.Optimistic,
+			timestamp:                sn.Timestamp,
+		}
+		if i == 0 {
+			s.treeRootNode = n
+			s.highestReceivedNode = n
+			if n.slot%params.BeaconConfig().SlotsPerEpoch == 0 {
+				n.target = n
+			}
+		} else {
+			parent, ok := s.nodeByRoot[bytesutil.ToBytes32(sn.ParentRoot)]
+			if !ok {
+				return errors.Wrapf(errInvalidParentRoot, "node %#x in fork choice snapshot", root)
+			}
+			n.parent = parent
+			parent.children = append(parent.children, n)
+			// Set the node's target checkpoint as when inserting it.
+			if n.slot%params.BeaconConfig().SlotsPerEpoch == 0 {
+				n.target = n
+			} else if slots.ToEpoch(n.slot) == slots.ToEpoch(parent.slot) {
+				n.target = parent.target
+			} else {
+				n.target = parent
+			}
+			if n.slot > s.highestReceivedNode.slot {
+				s.highestReceivedNode = n
+			}
+		}
+		s.nodeByRoot[root] = n
+		s.nodeByPayload[n.payloadHash] = n
+	}
+	if _, ok := s.nodeByRoot[s.finalizedCheckpoint.Root]; !ok && s.finalizedCheckpoint.Epoch != params.BeaconConfig().GenesisEpoch {
+		return errors.WithMessagef(errUnknownFinalizedRoot, "%#x", s.finalizedCheckpoint.Root)
+	}
+	s.headNode = s.treeRootNode
+	if head, ok := s.nodeByRoot[bytesutil.ToBytes32(snapshot.HeadRoot)]; ok {
+		s.headNode = head
+	}
+	for _, idx := range snapshot.SlashedIndices {
+		s.slashedIndices[primitives.ValidatorIndex(idx)] = true
+	}
+	currentEpoch := slots.ToEpoch(s.currentSlot())
+	if err := s.treeRootNode.updateBestDescendant(ctx, s.justifiedCheckpoint.Epoch, s.finalizedCheckpoint.Epoch, currentEpoch); err != nil {
+		return errors.Wrap(err, "could not update best descendant")
+	}
+
+	votes := make([]Vote, len(snapshot.Votes))
+	for i, v := range snapshot.Votes {
+		if v == nil {
+			continue
+		}
+		votes[i] = Vote{
+			currentRoot: bytesutil.ToBytes32(v.CurrentRoot),
+			nextRoot:    bytesutil.ToBytes32(v.NextRoot),
+			nextEpoch:   primitives.Epoch(v.NextEpoch),
+		}
+	}
+	f.store = s
+	f.votes = votes
+	f.balances = slices.Clone(snapshot.Balances)
+	if f.balances == nil {
+		f.balances = make([]uint64, 0)
+	}
+	f.justifiedBalances = slices.Clone(snapshot.JustifiedBalances)
+	f.numActiveValidators = snapshot.NumActiveValidators
+	nodeCount.Set(float64(len(s.nodeByRoot)))
+	return nil
+}
+
+// snapshot appends this node and its descendants to nodes, parents first.
+func (n *Node) snapshot(ctx context.Context, nodes []*dbval.ForkchoiceNode) ([]*dbval.ForkchoiceNode, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	var parentRoot [fieldparams.RootLength]byte
+	if n.parent != nil {
+		parentRoot = n.parent.root
+	}
+	nodes = append(nodes, &dbval.ForkchoiceNode{
+		Slot:                     uint64(n.slot),
+		Root:                     bytesutil.SafeCopyBytes(n.root[:]),
+		ParentRoot:               parentRoot[:],
+		PayloadHash:              bytesutil.SafeCopyBytes(n.payloadHash[:]),
+		JustifiedEpoch:           uint64(n.justifiedEpoch),
+		UnrealizedJustifiedEpoch: uint64(n.unrealizedJustifiedEpoch),
+		FinalizedEpoch:           uint64(n.finalizedEpoch),
+		UnrealizedFinalizedEpoch: uint64(n.unrealizedFinalizedEpoch),
+		Balance:                  n.balance,
+		Weight:                   n.weight,
+		Optimistic:               n.optimistic,
+		Timestamp:                n.timestamp,
+	})
+	var err error
+	for _, child := range n.children {
+		nodes, err = child.snapshot(ctx, nodes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func checkpointToSnapshot(cp *forkchoicetypes.Checkpoint) *dbval.ForkchoiceCheckpoint {
+	return &dbval.ForkchoiceCheckpoint{Epoch: uint64(cp.Epoch), Root: bytesutil.SafeCopyBytes(cp.Root[:])}
+}
+
+func checkpointFromSnapshot(cp *dbval.ForkchoiceCheckpoint) *forkchoicetypes.Checkpoint {
+	return &forkchoicetypes.Checkpoint{Epoch: primitives.Epoch(cp.Epoch), Root: bytesutil.ToBytes32(cp.Root)}
+}
@@ -0,0 +1,102 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestForkChoice_SnapshotRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	f := setup(1, 1)
+	st, blkRoot, err := prepareForkchoiceState(ctx, 1, [32]byte{'a'}, params.BeaconConfig().ZeroHash, [32]byte{'A'}, 1, 1)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, blkRoot))
+	st, blkRoot, err = prepareForkchoiceState(ctx, 2, [32]byte{'b'}, [32]byte{'a'}, [32]byte{'B'}, 1, 1)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, blkRoot))
+	st, blkRoot, err = prepareForkchoiceState(ctx, 3, [32]byte{'c'}, [32]byte{'a'}, [32]byte{'C'}, 1, 1)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, blkRoot))
+	require.NoError(t, f.SetOptimisticToValid(ctx, [32]byte{'a'}))
+	f.ProcessAttestation(ctx, []uint64{1, 2}, [32]byte{'b'}, 1)
+	f.ProcessAttestation(ctx, []uint64{3}, [32]byte{'c'}, 1)
+	f.justifiedBalances = []uint64{100, 200, 200, 300}
+	f.InsertSlashedIndex(ctx, 2)
+	head, err := f.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, [32]byte{'c'}, head)
+
+	snapshot, err := f.Snapshot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(snapshot.Nodes))
+	// The snapshot is what is persisted, so it must survive encoding.
+	enc, err := proto.Marshal(snapshot)
+	require.NoError(t, err)
+	decoded := &dbval.ForkchoiceSnapshot{}
+	require.NoError(t, proto.Unmarshal(enc, decoded))
+
+	restored := New()
+	restored.SetBalancesByRooter(func(_ context.Context, _ [32]byte) ([]uint64, error) { return restored.justifiedBalances, nil })
+	require.NoError(t, restored.RestoreSnapshot(ctx, decoded))
+
+	assert.Equal(t, f.NodeCount(), restored.NodeCount())
+	assert.DeepEqual(t, f.store.justifiedCheckpoint, restored.store.justifiedCheckpoint)
+	assert.DeepEqual(t, f.store.finalizedCheckpoint, restored.store.finalizedCheckpoint)
+	assert.DeepEqual(t, f.votes, restored.votes)
+	assert.DeepEqual(t, f.balances, restored.balances)
+	assert.DeepEqual(t, f.store.slashedIndices, restored.store.slashedIndices)
+	assert.Equal(t, f.store.headNode.root, restored.store.headNode.root)
+	assert.Equal(t, f.store.highestReceivedNode.root, restored.store.highestReceivedNode.root)
+	for root, n := range f.store.nodeByRoot {
+		rn, ok := restored.store.nodeByRoot[root]
+		require.Equal(t, true, ok)
+		assert.Equal(t, n.weight, rn.weight)
+		assert.Equal(t, n.balance, rn.balance)
+		assert.Equal(t, n.optimistic, rn.optimistic)
+		if n.parent != nil {
+			assert.Equal(t, n.parent.root, rn.parent.root)
+		}
+		if n.target != nil {
+			assert.Equal(t, n.target.root, rn.target.root)
+		}
+	}
+
+	// The restored store keeps processing blocks and votes the same way.
+	st, blkRoot, err = prepareForkchoiceState(ctx, 4, [32]byte{'d'}, [32]byte{'b'}, [32]byte{'D'}, 1, 1)
+	require.NoError(t, err)
+	for _, fc := range []*ForkChoice{f, restored} {
+		require.NoError(t, fc.InsertNode(ctx, st, blkRoot))
+		fc.ProcessAttestation(ctx, []uint64{0, 3}, [32]byte{'d'}, 2)
+		head, err := fc.Head(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, [32]byte{'d'}, head)
+	}
+	weight, err := restored.Weight([32]byte{'d'})
+	require.NoError(t, err)
+	wanted, err := f.Weight([32]byte{'d'})
+	require.NoError(t, err)
+	assert.Equal(t, wanted, weight)
+}
+
+func TestForkChoice_RestoreSnapshot_Invalid(t *testing.T) {
+	ctx := context.Background()
+	f := setup(1, 1)
+	snapshot, err := f.Snapshot(ctx)
+	require.NoError(t, err)
+
+	require.ErrorContains(t, "empty fork choice snapshot", New().RestoreSnapshot(ctx, &dbval.ForkchoiceSnapshot{}))
+
+	orphan := proto.Clone(snapshot).(*dbval.ForkchoiceSnapshot)
+	orphan.Nodes = append(orphan.Nodes, &dbval.ForkchoiceNode{Slot: 2, Root: []byte{'x'}, ParentRoot: []byte{'y'}})
+	require.ErrorIs(t, New().RestoreSnapshot(ctx, orphan), errInvalidParentRoot)
+
+	noCheckpoint := proto.Clone(snapshot).(*dbval.ForkchoiceSnapshot)
+	noCheckpoint.FinalizedCheckpoint = nil
+	require.ErrorIs(t, New().RestoreSnapshot(ctx, noCheckpoint), errInvalidNilCheckpoint)
+}
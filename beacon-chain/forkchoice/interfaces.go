@@ -8,12 +8,20 @@ import (
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	forkchoice2 "github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
 )
 
 // BalancesByRooter is a handler to obtain the effective balances of the state
 // with the given block root
 type BalancesByRooter func(context.Context, [32]byte) ([]uint64, error)
 
+// Recorder receives the inputs of fork choice, and the decisions made from them, so that
+// they can be replayed. Record is called with the fork choice lock or read lock held, so it must
+// be safe for concurrent use, and it must not retain the event.
+type Recorder interface {
+	Record(*forkchoicetypes.Event)
+}
+
 // ForkChoicer represents the full fork choice interface composed of all the sub-interfaces.
 type ForkChoicer interface {
 	RLocker // separate interface isolates  read locking for ROForkChoice.
@@ -24,6 +32,7 @@ type ForkChoicer interface {
 	AttestationProcessor // to track new attestation for fork choice.
 	Getter               // to retrieve fork choice information.
 	Setter               // to set fork choice information.
+	Snapshotter          // to persist and restore fork choice.
 }
 
 // RLocker represents forkchoice's internal RWMutex read-only lock/unlock methods.
@@ -83,6 +92,12 @@ type FastGetter interface {
 	ParentRoot(root [32]byte) ([32]byte, error)
 }
 
+// Snapshotter saves and restores the content of fork choice, so that it survives restarts.
+type Snapshotter interface {
+	Snapshot(context.Context) (*dbval.ForkchoiceSnapshot, error)
+	RestoreSnapshot(context.Context, *dbval.ForkchoiceSnapshot) error
+}
+
 // Setter allows to set forkchoice information
 type Setter interface {
 	SetOptimisticToValid(context.Context, [fieldparams.RootLength]byte) error
@@ -94,4 +109,5 @@ type Setter interface {
 	NewSlot(context.Context, primitives.Slot) error
 	SetBalancesByRooter(BalancesByRooter)
 	InsertSlashedIndex(context.Context, primitives.ValidatorIndex)
+	SetRecorder(context.Context, Recorder) error
 }
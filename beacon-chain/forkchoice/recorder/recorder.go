@@ -0,0 +1,108 @@
+// Package recorder writes the inputs of fork choice to a file as they are handled by the beacon
+// node, and replays them on a fresh fork choice, so that its decisions can be reproduced.
+//
+// A recording is a sequence of JSON encoded fork choice events, one per line. It starts with an
+// anchor event holding a snapshot of fork choice, followed by the blocks, votes, ticks, equivocations
+// and justified balances that fork choice received, and the decisions it made from them.
+package recorder
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "forkchoice-recorder")
+
+// FileRecorder appends the fork choice events it receives to a file.
+type FileRecorder struct {
+	sync.Mutex
+	f       *os.File
+	w       *bufio.Writer
+	enc     *json.Encoder
+	err     error
+	written uint64
+}
+
+// NewFileRecorder opens the file at the given path to append fork choice events to it.
+// A recording restarted on an existing file starts with a new anchor event.
+func NewFileRecorder(path string) (*FileRecorder, error) {
+	expanded, err := file.ExpandPath(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(expanded, os.O_APPEND|os.O_CREATE|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions) // #nosec G304 -- the path is provided by the user.
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not open fork choice recording file %s", expanded)
+	}
+	w := bufio.NewWriter(f)
+	return &FileRecorder{f: f, w: w, enc: json.NewEncoder(w)}, nil
+}
+
+// Record appends the event to the file. The recording stops at the first write error.
+func (r *FileRecorder) Record(e *forkchoicetypes.Event) {
+	r.Lock()
+	defer r.Unlock()
+	if r.err != nil {
+		return
+	}
+	if err := r.enc.Encode(e); err != nil {
+		r.err = err
+		log.WithError(err).Error("Could not record fork choice event, stopping the recording")
+		return
+	}
+	r.written++
+	// Flush at every anchor and head event so that a recording can be read while it is written.
+	if e.Kind == forkchoicetypes.EventAnchor || e.Kind == forkchoicetypes.EventHead {
+		if err := r.w.Flush(); err != nil {
+			r.err = err
+			log.WithError(err).Error("Could not record fork choice event, stopping the recording")
+		}
+	}
+}
+
+// Close flushes the recorded events and closes the file. Events received afterwards are dropped.
+func (r *FileRecorder) Close() error {
+	r.Lock()
+	defer r.Unlock()
+	if errors.Is(r.err, os.ErrClosed) {
+		return nil
+	}
+	err := r.w.Flush()
+	if closeErr := r.f.Close(); err == nil {
+		err = closeErr
+	}
+	r.err = os.ErrClosed
+	log.WithField("events", r.written).Debug("Closed fork choice recording")
+	return err
+}
+
+// Reader reads the events of a recording.
+type Reader struct {
+	dec *json.Decoder
+}
+
+// NewReader returns a reader of the events recorded in r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{dec: json.NewDecoder(r)}
+}
+
+// Next returns the next event of the recording, or io.EOF when there are none left.
+func (r *Reader) Next() (*forkchoicetypes.Event, error) {
+	e := &forkchoicetypes.Event{}
+	if err := r.dec.Decode(e); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, errors.Wrap(err, "could not decode fork choice event")
+	}
+	return e, nil
+}
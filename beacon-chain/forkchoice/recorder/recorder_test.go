@@ -0,0 +1,135 @@
+package recorder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+const genesisTime = 1_000_000
+
+func root(b byte) []byte {
+	r := make([]byte, 32)
+	r[0] = b
+	return r
+}
+
+// at returns the unix time in milliseconds at the given number of seconds into the slot.
+func at(slot, seconds uint64) int64 {
+	return int64((genesisTime + slot*params.BeaconConfig().SecondsPerSlot + seconds) * 1000)
+}
+
+// simulation returns the events of a short fork: block a is voted for first, then the votes for it
+// are slashed and block b becomes the head.
+func simulation() []*forkchoicetypes.Event {
+	genesis := &forkchoicetypes.RecordedCheckpoint{Root: root('g')}
+	snapshotCheckpoint := &dbval.ForkchoiceCheckpoint{Root: root('g')}
+	block := func(r byte, seconds uint64) *forkchoicetypes.Event {
+		return &forkchoicetypes.Event{
+			Kind:                forkchoicetypes.EventBlock,
+			TimeMillis:          at(1, seconds),
+			Slot:                1,
+			Root:                root(r),
+			ParentRoot:          root('g'),
+			PayloadHash:         root(r),
+			Justified:           genesis,
+			Finalized:           genesis,
+			UnrealizedJustified: genesis,
+			UnrealizedFinalized: genesis,
+		}
+	}
+	return []*forkchoicetypes.Event{
+		{
+			Kind:       forkchoicetypes.EventAnchor,
+			TimeMillis: at(0, 0),
+			Snapshot: &dbval.ForkchoiceSnapshot{
+				JustifiedCheckpoint:           snapshotCheckpoint,
+				UnrealizedJustifiedCheckpoint: snapshotCheckpoint,
+				UnrealizedFinalizedCheckpoint: snapshotCheckpoint,
+				PreviousJustifiedCheckpoint:   snapshotCheckpoint,
+				FinalizedCheckpoint:           snapshotCheckpoint,
+				GenesisTime:                   genesisTime,
+				HeadRoot:                      root('g'),
+				Nodes:                         []*dbval.ForkchoiceNode{{Root: root('g'), PayloadHash: root('g'), Timestamp: genesisTime}},
+				JustifiedBalances:             []uint64{10, 10, 10, 10},
+			},
+		},
+		block('a', 1),
+		block('b', 5),
+		{Kind: forkchoicetypes.EventAttestation, TimeMillis: at(1, 6), Root: root('a'), Indices: []uint64{0, 1}},
+		{Kind: forkchoicetypes.EventHead, TimeMillis: at(1, 6), Root: root('a')},
+		{Kind: forkchoicetypes.EventTick, TimeMillis: at(2, 0), Slot: 2},
+		{Kind: forkchoicetypes.EventSlashing, TimeMillis: at(2, 1), Indices: []uint64{0}},
+		{Kind: forkchoicetypes.EventSlashing, TimeMillis: at(2, 1), Indices: []uint64{1}},
+		{Kind: forkchoicetypes.EventAttestation, TimeMillis: at(2, 2), Root: root('b'), Indices: []uint64{2, 3}},
+		{Kind: forkchoicetypes.EventHead, TimeMillis: at(2, 2), Root: root('b')},
+		// The recorded head is wrong on purpose.
+		{Kind: forkchoicetypes.EventHead, TimeMillis: at(2, 3), Root: root('a')},
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "forkchoice.jsonl")
+	r, err := NewFileRecorder(path)
+	require.NoError(t, err)
+	events := simulation()
+	for _, e := range events {
+		r.Record(e)
+	}
+	require.NoError(t, r.Close())
+	// Events received after closing are dropped.
+	r.Record(events[1])
+	require.NoError(t, r.Close())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	var steps []*Step
+	require.NoError(t, Replay(context.Background(), NewReader(f), func(s *Step) error {
+		steps = append(steps, s)
+		return nil
+	}))
+	require.Equal(t, len(events), len(steps))
+	for _, s := range steps {
+		require.NoError(t, s.Err, "event %d", s.Index)
+	}
+
+	// Block a arrived early and got proposer boost.
+	assert.DeepEqual(t, root('a'), steps[1].ProposerBoostRoot[:])
+	assert.DeepEqual(t, root('a'), steps[4].Head[:])
+	assert.Equal(t, true, steps[4].HeadChanged())
+	assert.Equal(t, false, steps[4].Reorg)
+	assert.Equal(t, uint64(20), steps[4].HeadWeight)
+	assert.Equal(t, 2, len(steps[4].Tips))
+	assert.Equal(t, false, steps[4].Diverged)
+	// The tick resets proposer boost.
+	assert.Equal(t, [32]byte{}, steps[5].ProposerBoostRoot)
+
+	assert.DeepEqual(t, root('b'), steps[9].Head[:])
+	assert.Equal(t, true, steps[9].Reorg)
+	assert.Equal(t, uint64(20), steps[9].HeadWeight)
+	assert.Equal(t, false, steps[9].Diverged)
+	assert.Equal(t, true, steps[10].Diverged)
+}
+
+func TestReplay_NoAnchor(t *testing.T) {
+	err := Replay(context.Background(), NewReader(strings.NewReader(`{"kind":"tick","time_ms":1,"slot":1}`)), func(*Step) error {
+		return nil
+	})
+	require.ErrorContains(t, "instead of an anchor event", err)
+
+	err = Replay(context.Background(), NewReader(strings.NewReader(`{"kind":`)), func(*Step) error {
+		return nil
+	})
+	require.ErrorContains(t, "could not decode fork choice event", err)
+}
@@ -0,0 +1,130 @@
+package recorder
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+)
+
+// Tip is a leaf of the fork choice tree.
+type Tip struct {
+	Root   [32]byte
+	Slot   primitives.Slot
+	Weight uint64
+}
+
+// Step describes fork choice after a recorded event was replayed.
+type Step struct {
+	Index int
+	Event *forkchoicetypes.Event
+	// Err is the error returned by fork choice when applying the event.
+	Err error
+	// Head is the last head computed by fork choice, and PreviousHead the one before the event.
+	// They are equal after an anchor event, which resets fork choice.
+	Head         [32]byte
+	PreviousHead [32]byte
+	HeadSlot     primitives.Slot
+	HeadWeight   uint64
+	// Reorg is set when the head changed to a block that does not descend from the previous head.
+	Reorg     bool
+	Tips      []Tip
+	Justified *forkchoicetypes.Checkpoint
+	Finalized *forkchoicetypes.Checkpoint
+	// ProposerBoostRoot is the root of the block currently receiving proposer boost.
+	ProposerBoostRoot [32]byte
+	// ShouldOverrideFCU and ProposerHead are the reorg decisions fork choice makes at the time of the event.
+	ShouldOverrideFCU bool
+	ProposerHead      [32]byte
+	// Diverged is set when the event records a decision that differs from the replayed one.
+	Diverged bool
+}
+
+// HeadChanged returns whether the head changed during the step.
+func (s *Step) HeadChanged() bool {
+	return s.Head != s.PreviousHead
+}
+
+// Replay applies the events read from r to a fresh fork choice and calls fn with the outcome of
+// every event. Replaying stops at the end of the recording or when fn returns an error. Errors
+// returned by fork choice when applying an event are reported in the step and do not stop the replay.
+func Replay(ctx context.Context, r *Reader, fn func(*Step) error) error {
+	f := doublylinkedtree.New()
+	balancesByRoot := make(map[[32]byte][]uint64)
+	f.SetBalancesByRooter(func(_ context.Context, root [32]byte) ([]uint64, error) {
+		balances, ok := balancesByRoot[root]
+		if !ok {
+			return nil, errors.Errorf("no balances were recorded for root %#x", root)
+		}
+		return balances, nil
+	})
+
+	for i := 0; ; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		e, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "could not read event %d", i)
+		}
+		if i == 0 && e.Kind != forkchoicetypes.EventAnchor {
+			return errors.Errorf("recording starts with a %s event instead of an anchor event", e.Kind)
+		}
+		if e.Kind == forkchoicetypes.EventBalances {
+			balancesByRoot[bytesutil.ToBytes32(e.Root)] = e.Balances
+		}
+
+		step := &Step{Index: i, Event: e, PreviousHead: f.CachedHeadRoot()}
+		step.Err = f.ApplyEvent(ctx, e)
+		describe(ctx, step, f)
+		if err := fn(step); err != nil {
+			return err
+		}
+	}
+}
+
+// describe fills the step with the view of fork choice after the event.
+func describe(ctx context.Context, step *Step, f *doublylinkedtree.ForkChoice) {
+	step.Head = f.CachedHeadRoot()
+	if step.Event.Kind == forkchoicetypes.EventAnchor {
+		step.PreviousHead = step.Head
+	}
+	if step.HeadChanged() && step.PreviousHead != [32]byte{} {
+		ancestor, _, err := f.CommonAncestor(ctx, step.Head, step.PreviousHead)
+		step.Reorg = err == nil && ancestor != step.PreviousHead
+	}
+	step.HeadSlot, _ = f.Slot(step.Head)
+	step.HeadWeight, _ = f.Weight(step.Head)
+	roots, tipSlots := f.Tips()
+	step.Tips = make([]Tip, len(roots))
+	for i, root := range roots {
+		weight, _ := f.Weight(root)
+		step.Tips[i] = Tip{Root: root, Slot: tipSlots[i], Weight: weight}
+	}
+	step.Justified = f.JustifiedCheckpoint()
+	step.Finalized = f.FinalizedCheckpoint()
+	step.ProposerBoostRoot = f.ProposerBoost()
+	step.ShouldOverrideFCU = f.ShouldOverrideFCU()
+	step.ProposerHead = f.GetProposerHead()
+
+	e := step.Event
+	switch e.Kind {
+	case forkchoicetypes.EventHead:
+		if step.Err != nil {
+			step.Diverged = len(e.Root) != 0
+		} else {
+			step.Diverged = bytesutil.ToBytes32(e.Root) != step.Head
+		}
+	case forkchoicetypes.EventOverrideFCU:
+		step.Diverged = e.Override != step.ShouldOverrideFCU
+	case forkchoicetypes.EventProposerHead:
+		step.Diverged = bytesutil.ToBytes32(e.Root) != step.ProposerHead
+	}
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+)
+
+// EventKind is the kind of input of fork choice described by a recorded Event.
+type EventKind string
+
+const (
+	// EventAnchor carries the snapshot of fork choice at the time the recording started.
+	EventAnchor EventKind = "anchor"
+	// EventBlock is a block inserted with InsertNode.
+	EventBlock EventKind = "block"
+	// EventChainBlock is a block inserted with InsertChain.
+	EventChainBlock EventKind = "chain_block"
+	// EventAttestation is a vote processed with ProcessAttestation.
+	EventAttestation EventKind = "attestation"
+	// EventSlashing is an equivocating validator index inserted with InsertSlashedIndex.
+	EventSlashing EventKind = "slashing"
+	// EventTick is a call to NewSlot.
+	EventTick EventKind = "tick"
+	// EventBalances carries the justified balances obtained for a checkpoint root.
+	EventBalances EventKind = "balances"
+	// EventJustified is a call to UpdateJustifiedCheckpoint.
+	EventJustified EventKind = "justified_checkpoint"
+	// EventFinalized is a call to UpdateFinalizedCheckpoint.
+	EventFinalized EventKind = "finalized_checkpoint"
+	// EventPayloadValid is a call to SetOptimisticToValid.
+	EventPayloadValid EventKind = "payload_valid"
+	// EventPayloadInvalid is a call to SetOptimisticToInvalid.
+	EventPayloadInvalid EventKind = "payload_invalid"
+	// EventHead is a head computation, with the head that was obtained.
+	EventHead EventKind = "head"
+	// EventOverrideFCU is a call to ShouldOverrideFCU, with its result.
+	EventOverrideFCU EventKind = "override_fcu"
+	// EventProposerHead is a call to GetProposerHead, with its result.
+	EventProposerHead EventKind = "proposer_head"
+)
+
+// RecordedCheckpoint is a checkpoint in a recorded Event.
+type RecordedCheckpoint struct {
+	Epoch primitives.Epoch `json:"epoch"`
+	Root  hexutil.Bytes    `json:"root"`
+}
+
+// Event is an input of fork choice, or one of its decisions, recorded so that
+// the decisions can be reproduced by replaying the events on a fresh fork choice.
+// Only the fields relevant to the kind of the event are set.
+type Event struct {
+	Kind EventKind `json:"kind"`
+	// TimeMillis is the unix time in milliseconds at which fork choice handled the event.
+	TimeMillis int64 `json:"time_ms"`
+
+	Snapshot *dbval.ForkchoiceSnapshot `json:"snapshot,omitempty"`
+
+	Slot          primitives.Slot  `json:"slot,omitempty"`
+	Root          hexutil.Bytes    `json:"root,omitempty"`
+	ParentRoot    hexutil.Bytes    `json:"parent_root,omitempty"`
+	PayloadHash   hexutil.Bytes    `json:"payload_hash,omitempty"`
+	LastValidHash hexutil.Bytes    `json:"last_valid_hash,omitempty"`
+	TargetEpoch   primitives.Epoch `json:"target_epoch,omitempty"`
+	Indices       []uint64         `json:"indices,omitempty"`
+	Balances      []uint64         `json:"balances,omitempty"`
+	Override      bool             `json:"override,omitempty"`
+
+	Justified           *RecordedCheckpoint `json:"justified,omitempty"`
+	Finalized           *RecordedCheckpoint `json:"finalized,omitempty"`
+	UnrealizedJustified *RecordedCheckpoint `json:"unrealized_justified,omitempty"`
+	UnrealizedFinalized *RecordedCheckpoint `json:"unrealized_finalized,omitempty"`
+}
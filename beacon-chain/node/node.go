@@ -42,7 +42,9 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/synccommittee"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/gossiptrace"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/bandwidth"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
@@ -647,6 +649,23 @@ func (b *BeaconNode) startSlasherDB(cliCtx *cli.Context) error {
 
 func (b *BeaconNode) startStateGen(ctx context.Context, bfs coverage.AvailableBlocker, fc forkchoice.ForkChoicer) error {
 	opts := []stategen.Option{stategen.WithAvailableBlocker(bfs)}
+	if b.cliCtx.Bool(flags.EnableStateDiff.Name) {
+		exponents := make([]uint64, 0, len(b.cliCtx.IntSlice(flags.StateDiffExponents.Name)))
+		for _, e := range b.cliCtx.IntSlice(flags.StateDiffExponents.Name) {
+			if e < 0 {
+				return fmt.Errorf("invalid state diff exponents: exponent %d is negative", e)
+			}
+			exponents = append(exponents, uint64(e))
+		}
+		schedule, err := stategen.NewDiffSchedule(exponents)
+		if err != nil {
+			return errors.Wrap(err, "invalid state diff exponents")
+		}
+		opts = append(opts, stategen.WithStateDiffSchedule(schedule))
+		if b.cliCtx.Bool(flags.PruneMigratedArchivedStates.Name) {
+			opts = append(opts, stategen.WithMigratedArchivedStatesPruning())
+		}
+	}
 	sg := stategen.New(b.db, fc, opts...)
 
 	cp, err := b.db.FinalizedCheckpoint(ctx)
@@ -684,11 +703,37 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 		return errors.Wrapf(err, "could not register p2p service")
 	}
 
+	var gossipTrace *gossiptrace.Config
+	if dir := cliCtx.String(flags.GossipTraceDir.Name); dir != "" {
+		gossipTrace = &gossiptrace.Config{
+			Dir:         dir,
+			Payloads:    cliCtx.Bool(flags.GossipTracePayloads.Name),
+			MaxFileSize: cliCtx.Uint64(flags.GossipTraceMaxFileSize.Name) * 1024 * 1024,
+			MaxFiles:    cliCtx.Int(flags.GossipTraceMaxFiles.Name),
+		}
+	}
+
+	const megabyte = 1024 * 1024
+	bandwidthQuotas := &bandwidth.Config{
+		Window: cliCtx.Duration(flags.PeerQuotaWindow.Name),
+		Trusted: bandwidth.Quota{
+			RPC:    cliCtx.Uint64(flags.TrustedPeerRPCQuota.Name) * megabyte,
+			Gossip: cliCtx.Uint64(flags.TrustedPeerGossipQuota.Name) * megabyte,
+		},
+		Default: bandwidth.Quota{
+			RPC:    cliCtx.Uint64(flags.PeerRPCQuota.Name) * megabyte,
+			Gossip: cliCtx.Uint64(flags.PeerGossipQuota.Name) * megabyte,
+		},
+	}
+
 	svc, err := p2p.NewService(b.ctx, &p2p.Config{
 		NoDiscovery:          cliCtx.Bool(cmd.NoDiscovery.Name),
 		StaticPeers:          slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.StaticPeers.Name)),
 		Discv5BootStrapAddrs: p2p.ParseBootStrapAddrs(bootstrapNodeAddrs),
 		RelayNodeAddr:        cliCtx.String(cmd.RelayNode.Name),
+		DiscoveryFile:        cliCtx.String(flags.DiscoveryFile.Name),
+		DiscoveryDNS:         slice.SplitCommaSeparated(cliCtx.StringSlice(flags.DiscoveryDNS.Name)),
+		DiscoveryMDNS:        cliCtx.Bool(flags.DiscoveryMDNS.Name),
 		DataDir:              dataDir,
 		LocalIP:              cliCtx.String(cmd.P2PIP.Name),
 		HostAddress:          cliCtx.String(cmd.P2PHost.Name),
@@ -707,6 +752,8 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 		StateNotifier:        b,
 		DB:                   b.db,
 		ClockWaiter:          b.clockWaiter,
+		GossipTrace:          gossipTrace,
+		BandwidthQuotas:      bandwidthQuotas,
 	})
 	if err != nil {
 		return err
@@ -909,6 +956,8 @@ func (b *BeaconNode) registerSlasherService() error {
 		SyncChecker:             syncService,
 		HeadStateFetcher:        chainService,
 		ClockWaiter:             b.clockWaiter,
+		BeaconDatabase:          b.db,
+		Backfill:                b.cliCtx.Bool(flags.SlasherBackfill.Name),
 	})
 	if err != nil {
 		return err
@@ -932,11 +981,20 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		return err
 	}
 
-	var slasherService *slasher.Service
+	var slasherQuerier slasher.Querier
 	if features.Get().EnableSlasher {
+		var slasherService *slasher.Service
 		if err := b.services.FetchService(&slasherService); err != nil {
 			return err
 		}
+		slasherQuerier = slasherService
+	}
+
+	// The slasher inputs are only collected, and so served on the event stream, when a slasher consumes them.
+	var slasherAttestationsFeed, slasherBlockHeadersFeed *event.Feed
+	if features.Get().EnableSlasher || features.Get().EnableSlasherStream {
+		slasherAttestationsFeed = b.slasherAttestationsFeed
+		slasherBlockHeadersFeed = b.slasherBlockHeadersFeed
 	}
 
 	genesisValidators := b.cliCtx.Uint64(flags.InteropNumValidatorsFlag.Name)
@@ -1015,6 +1073,9 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		BlobStorage:                   b.BlobStorage,
 		TrackedValidatorsCache:        b.trackedValidatorsCache,
 		PayloadIDCache:                b.payloadIDCache,
+		SlasherAttestationsFeed:       slasherAttestationsFeed,
+		SlasherBlockHeadersFeed:       slasherBlockHeadersFeed,
+		SlasherQuerier:                slasherQuerier,
 	})
 
 	return b.services.RegisterService(rpcService)
@@ -3,6 +3,8 @@ package p2p
 import (
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/gossiptrace"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/bandwidth"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 )
 
@@ -18,6 +20,9 @@ type Config struct {
 	StaticPeers          []string
 	Discv5BootStrapAddrs []string
 	RelayNodeAddr        string
+	DiscoveryFile        string
+	DiscoveryDNS         []string
+	DiscoveryMDNS        bool
 	LocalIP              string
 	HostAddress          string
 	HostDNS              string
@@ -34,6 +39,8 @@ type Config struct {
 	StateNotifier        statefeed.Notifier
 	DB                   db.ReadOnlyDatabase
 	ClockWaiter          startup.ClockWaiter
+	GossipTrace          *gossiptrace.Config
+	BandwidthQuotas      *bandwidth.Config
 }
 
 // validateConfig validates whether the values provided are accurate and will set
@@ -0,0 +1,200 @@
+// Package discovery defines the sources of peers of the p2p service. Besides discv5,
+// peers can be found in a file of ENRs reloaded when it changes, in DNS ENR trees
+// (EIP-1459) and on the local network through mDNS. Backends are combined into a single
+// stream of nodes, which the p2p service filters before dialing them.
+package discovery
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// mixTimeout is how long the combined iterator waits for a backend before taking the
+// next node of any backend, so that an idle backend does not hold up the others.
+const mixTimeout = 100 * time.Millisecond
+
+// Backend is a source of nodes to connect to.
+type Backend interface {
+	// Name identifies the backend in logs.
+	Name() string
+	// Nodes returns a new iterator over the nodes found by the backend. Next blocks
+	// until a node is found or the iterator is closed.
+	Nodes() enode.Iterator
+	// Close stops the backend.
+	Close()
+}
+
+// Mix combines several backends, taking nodes from each of them in turn.
+type Mix struct {
+	backends []Backend
+}
+
+// NewMix combines the given backends.
+func NewMix(backends ...Backend) *Mix {
+	return &Mix{backends: backends}
+}
+
+// Name returns the names of the combined backends.
+func (m *Mix) Name() string {
+	names := make([]string, len(m.backends))
+	for i, b := range m.backends {
+		names[i] = b.Name()
+	}
+	return strings.Join(names, ",")
+}
+
+// Nodes returns an iterator over the nodes of all the backends.
+func (m *Mix) Nodes() enode.Iterator {
+	mix := enode.NewFairMix(mixTimeout)
+	for _, b := range m.backends {
+		mix.AddSource(b.Nodes())
+	}
+	return mix
+}
+
+// Close stops all the backends.
+func (m *Mix) Close() {
+	for _, b := range m.backends {
+		b.Close()
+	}
+}
+
+// DiscV5Listener is the part of the discv5 listener used to find nodes.
+type DiscV5Listener interface {
+	RandomNodes() enode.Iterator
+}
+
+// DiscV5 finds nodes through random lookups in the discv5 DHT.
+type DiscV5 struct {
+	listener DiscV5Listener
+}
+
+// NewDiscV5 creates a backend for a running discv5 listener. The listener is owned by
+// the caller, closing the backend does not close it.
+func NewDiscV5(listener DiscV5Listener) *DiscV5 {
+	return &DiscV5{listener: listener}
+}
+
+// Name of the backend.
+func (*DiscV5) Name() string {
+	return "discv5"
+}
+
+// Nodes returns an iterator over random nodes of the DHT.
+func (d *DiscV5) Nodes() enode.Iterator {
+	return d.listener.RandomNodes()
+}
+
+// Close does nothing, the listener is closed by its owner.
+func (*DiscV5) Close() {}
+
+// nodeSet is a set of nodes maintained by a backend. Its iterators cycle through the
+// nodes of the set, waiting after each full pass for the set to be updated or for the
+// revisit interval to elapse.
+type nodeSet struct {
+	lock      sync.RWMutex
+	nodes     []*enode.Node
+	updated   chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newNodeSet() *nodeSet {
+	return &nodeSet{
+		updated: make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+}
+
+// set replaces the nodes of the set and wakes up the waiting iterators.
+func (s *nodeSet) set(nodes []*enode.Node) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.nodes = nodes
+	close(s.updated)
+	s.updated = make(chan struct{})
+}
+
+// snapshot returns the nodes of the set and a channel closed on the next update.
+func (s *nodeSet) snapshot() ([]*enode.Node, <-chan struct{}) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.nodes, s.updated
+}
+
+func (s *nodeSet) close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+}
+
+func (s *nodeSet) iterator(revisit time.Duration) enode.Iterator {
+	return &setIter{set: s, revisit: revisit, closed: make(chan struct{})}
+}
+
+type setIter struct {
+	set       *nodeSet
+	revisit   time.Duration
+	nodes     []*enode.Node
+	updated   <-chan struct{}
+	pos       int
+	node      *enode.Node
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// Next moves to the next node of the set, blocking at the end of a pass until the set
+// is updated, the revisit interval elapses or the iterator is closed.
+func (it *setIter) Next() bool {
+	for it.pos >= len(it.nodes) {
+		if it.updated != nil {
+			timer := time.NewTimer(it.revisit)
+			select {
+			case <-it.updated:
+			case <-timer.C:
+			case <-it.closed:
+			case <-it.set.closed:
+			}
+			timer.Stop()
+		}
+		if it.isClosed() {
+			it.node = nil
+			return false
+		}
+		it.nodes, it.updated = it.set.snapshot()
+		it.pos = 0
+	}
+	if it.isClosed() {
+		it.node = nil
+		return false
+	}
+	it.node = it.nodes[it.pos]
+	it.pos++
+	return true
+}
+
+// Node returns the current node.
+func (it *setIter) Node() *enode.Node {
+	return it.node
+}
+
+// Close ends the iteration, unblocking Next.
+func (it *setIter) Close() {
+	it.closeOnce.Do(func() {
+		close(it.closed)
+	})
+}
+
+func (it *setIter) isClosed() bool {
+	select {
+	case <-it.closed:
+		return true
+	case <-it.set.closed:
+		return true
+	default:
+		return false
+	}
+}
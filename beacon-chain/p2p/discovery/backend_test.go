@@ -0,0 +1,104 @@
+package discovery
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func testNode(t *testing.T, seq uint64, entries ...enr.Entry) *enode.Node {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	var r enr.Record
+	r.SetSeq(seq)
+	r.Set(enr.IP(net.IPv4(10, 0, 0, 1)))
+	r.Set(enr.TCP(13000))
+	r.Set(enr.UDP(12000))
+	for _, e := range entries {
+		r.Set(e)
+	}
+	require.NoError(t, enode.SignV4(&r, key))
+	node, err := enode.New(enode.ValidSchemes, &r)
+	require.NoError(t, err)
+	return node
+}
+
+func TestNodeSet_IteratorCycles(t *testing.T) {
+	set := newNodeSet()
+	a, b := testNode(t, 1), testNode(t, 1)
+	set.set([]*enode.Node{a, b})
+
+	it := set.iterator(time.Millisecond)
+	defer it.Close()
+	nodes := enode.ReadNodes(it, 4)
+	require.Equal(t, 2, len(nodes), "ReadNodes deduplicates the nodes")
+	assert.Equal(t, a.ID(), nodes[0].ID())
+	assert.Equal(t, b.ID(), nodes[1].ID())
+}
+
+func TestNodeSet_IteratorWaitsForUpdate(t *testing.T) {
+	set := newNodeSet()
+	it := set.iterator(time.Hour)
+	defer it.Close()
+
+	node := testNode(t, 1)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		set.set([]*enode.Node{node})
+	}()
+	require.Equal(t, true, it.Next())
+	assert.Equal(t, node.ID(), it.Node().ID())
+}
+
+func TestNodeSet_CloseUnblocksIterators(t *testing.T) {
+	set := newNodeSet()
+	it := set.iterator(time.Hour)
+	closed := set.iterator(time.Hour)
+
+	done := make(chan bool, 2)
+	go func() { done <- it.Next() }()
+	go func() { done <- closed.Next() }()
+	closed.Close()
+	assert.Equal(t, false, <-done)
+	set.close()
+	assert.Equal(t, false, <-done)
+	assert.Equal(t, false, it.Next())
+}
+
+type iterSource struct {
+	nodes []*enode.Node
+}
+
+func (s *iterSource) RandomNodes() enode.Iterator {
+	return enode.CycleNodes(s.nodes)
+}
+
+func TestMix_CombinesBackends(t *testing.T) {
+	fromDiscV5 := testNode(t, 1)
+	fromFile := testNode(t, 1)
+	set := newNodeSet()
+	set.set([]*enode.Node{fromFile})
+	file := &StaticFile{set: set, cancel: func() {}}
+
+	mix := NewMix(NewDiscV5(&iterSource{nodes: []*enode.Node{fromDiscV5}}), file)
+	assert.Equal(t, "discv5,file", mix.Name())
+
+	it := mix.Nodes()
+	found := make(map[enode.ID]bool)
+	for i := 0; i < 10 && len(found) < 2; i++ {
+		require.Equal(t, true, it.Next())
+		found[it.Node().ID()] = true
+	}
+	it.Close()
+	assert.Equal(t, true, found[fromDiscV5.ID()])
+	assert.Equal(t, true, found[fromFile.ID()])
+
+	mix.Close()
+	assert.Equal(t, false, file.Nodes().Next())
+}
@@ -0,0 +1,54 @@
+package discovery
+
+import (
+	"github.com/ethereum/go-ethereum/p2p/dnsdisc"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/pkg/errors"
+)
+
+// DNS offers the nodes of DNS ENR trees, as defined in EIP-1459. Trees are addressed by
+// enrtree://<public key>@<domain> URLs and their roots are checked for updates
+// periodically, so that the peers of a network can be managed through DNS records.
+type DNS struct {
+	client *dnsdisc.Client
+	urls   []string
+}
+
+// NewDNS creates a backend for the trees at the given URLs.
+func NewDNS(urls []string) (*DNS, error) {
+	return newDNS(urls, dnsdisc.Config{})
+}
+
+func newDNS(urls []string, cfg dnsdisc.Config) (*DNS, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no DNS discovery URL")
+	}
+	for _, url := range urls {
+		if _, _, err := dnsdisc.ParseURL(url); err != nil {
+			return nil, errors.Wrapf(err, "invalid DNS discovery URL %s", url)
+		}
+	}
+	return &DNS{
+		client: dnsdisc.NewClient(cfg),
+		urls:   urls,
+	}, nil
+}
+
+// Name of the backend.
+func (*DNS) Name() string {
+	return "dns"
+}
+
+// Nodes returns an iterator over random nodes of the trees.
+func (d *DNS) Nodes() enode.Iterator {
+	it, err := d.client.NewIterator(d.urls...)
+	if err != nil {
+		// The URLs are validated when the backend is created.
+		log.WithError(err).Error("Could not create DNS discovery iterator")
+		return enode.IterNodes(nil)
+	}
+	return it
+}
+
+// Close does nothing, the lookups stop when the iterators are closed.
+func (*DNS) Close() {}
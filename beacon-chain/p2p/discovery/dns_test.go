@@ -0,0 +1,55 @@
+package discovery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/dnsdisc"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+type mapResolver map[string]string
+
+func (r mapResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	if record, ok := r[name]; ok {
+		return []string{record}, nil
+	}
+	return nil, errors.Errorf("no TXT record for %s", name)
+}
+
+func TestDNS_Nodes(t *testing.T) {
+	nodes := []*enode.Node{testNode(t, 1), testNode(t, 1), testNode(t, 1)}
+	tree, err := dnsdisc.MakeTree(1, nodes, nil)
+	require.NoError(t, err)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	url, err := tree.Sign(key, "nodes.example.org")
+	require.NoError(t, err)
+
+	d, err := newDNS([]string{url}, dnsdisc.Config{Resolver: mapResolver(tree.ToTXT("nodes.example.org"))})
+	require.NoError(t, err)
+	defer d.Close()
+	assert.Equal(t, "dns", d.Name())
+
+	want := make(map[enode.ID]bool)
+	for _, n := range nodes {
+		want[n.ID()] = true
+	}
+	it := d.Nodes()
+	defer it.Close()
+	for i := 0; i < 10; i++ {
+		require.Equal(t, true, it.Next())
+		assert.Equal(t, true, want[it.Node().ID()], "Unexpected node %s", it.Node().ID())
+	}
+}
+
+func TestNewDNS_InvalidURL(t *testing.T) {
+	_, err := NewDNS(nil)
+	assert.ErrorContains(t, "no DNS discovery URL", err)
+	_, err = NewDNS([]string{"https://nodes.example.org"})
+	assert.ErrorContains(t, "invalid DNS discovery URL", err)
+}
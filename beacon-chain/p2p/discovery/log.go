@@ -0,0 +1,5 @@
+package discovery
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "discovery")
@@ -0,0 +1,284 @@
+package discovery
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/pkg/errors"
+	"golang.org/x/net/dns/dnsmessage"
+	"golang.org/x/net/ipv4"
+)
+
+const (
+	// mdnsService is the name of the TXT record holding the ENR of a node.
+	mdnsService = "_eth2-enr._udp.local."
+	// mdnsQueryInterval is how often the local network is queried for nodes.
+	mdnsQueryInterval = 10 * time.Second
+	// mdnsNodeTTL is how long a node is offered after it last answered.
+	mdnsNodeTTL = 3 * mdnsQueryInterval
+	// mdnsRecordTTL is the TTL of the TXT records, in seconds.
+	mdnsRecordTTL = 120
+	// maxTXTStringLength is the length limit of a character string of a TXT record.
+	maxTXTStringLength = 255
+	mdnsMaxPacketSize  = 9000
+)
+
+var mdnsGroup = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+
+type mdnsNode struct {
+	node     *enode.Node
+	lastSeen time.Time
+}
+
+// MDNS finds the nodes of the local network through multicast DNS. Each node answers
+// the queries for the _eth2-enr._udp.local TXT record with its ENR, split in character
+// strings of at most 255 bytes. It is meant for private devnets, where nodes can find
+// each other without bootnodes.
+type MDNS struct {
+	self      func() *enode.Node
+	conn      *net.UDPConn
+	set       *nodeSet
+	quit      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+
+	lock  sync.Mutex
+	nodes map[enode.ID]*mdnsNode
+}
+
+// NewMDNS starts answering the mDNS queries of the local network with the ENR returned
+// by self, and querying for the other nodes.
+func NewMDNS(self func() *enode.Node) (*MDNS, error) {
+	conn, err := net.ListenMulticastUDP("udp4", nil, mdnsGroup)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not join the mDNS multicast group")
+	}
+	// Nodes running on the same host must see each other.
+	if err := ipv4.NewPacketConn(conn).SetMulticastLoopback(true); err != nil {
+		log.WithError(err).Debug("Could not enable multicast loopback")
+	}
+	m := &MDNS{
+		self:  self,
+		conn:  conn,
+		set:   newNodeSet(),
+		quit:  make(chan struct{}),
+		nodes: make(map[enode.ID]*mdnsNode),
+	}
+	m.wg.Add(2)
+	go m.read()
+	go m.query()
+	return m, nil
+}
+
+// Name of the backend.
+func (*MDNS) Name() string {
+	return "mdns"
+}
+
+// Nodes returns an iterator cycling through the nodes of the local network.
+func (m *MDNS) Nodes() enode.Iterator {
+	return m.set.iterator(mdnsQueryInterval)
+}
+
+// Close stops answering and sending queries, and ends the iterators.
+func (m *MDNS) Close() {
+	m.closeOnce.Do(func() {
+		close(m.quit)
+		if err := m.conn.Close(); err != nil {
+			log.WithError(err).Debug("Could not close mDNS connection")
+		}
+		m.wg.Wait()
+		m.set.close()
+	})
+}
+
+func (m *MDNS) query() {
+	defer m.wg.Done()
+	query, err := mdnsQuery()
+	if err != nil {
+		log.WithError(err).Error("Could not create mDNS query")
+		return
+	}
+	ticker := time.NewTicker(mdnsQueryInterval)
+	defer ticker.Stop()
+	m.announce()
+	for {
+		m.send(query)
+		m.prune(time.Now())
+		select {
+		case <-ticker.C:
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+func (m *MDNS) read() {
+	defer m.wg.Done()
+	buf := make([]byte, mdnsMaxPacketSize)
+	for {
+		n, _, err := m.conn.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-m.quit:
+				return
+			default:
+			}
+			log.WithError(err).Debug("Could not read mDNS packet")
+			continue
+		}
+		isQuery, nodes, err := parseMDNS(buf[:n])
+		if err != nil {
+			log.WithError(err).Trace("Could not parse mDNS packet")
+			continue
+		}
+		if isQuery {
+			m.announce()
+		}
+		for _, node := range nodes {
+			m.seen(node, time.Now())
+		}
+	}
+}
+
+// announce sends the ENR of the node to the multicast group.
+func (m *MDNS) announce() {
+	msg, err := mdnsResponse(m.self())
+	if err != nil {
+		log.WithError(err).Error("Could not create mDNS response")
+		return
+	}
+	m.send(msg)
+}
+
+func (m *MDNS) send(msg []byte) {
+	if _, err := m.conn.WriteToUDP(msg, mdnsGroup); err != nil {
+		log.WithError(err).Debug("Could not send mDNS packet")
+	}
+}
+
+// seen records a node found on the local network, keeping its most recent record.
+func (m *MDNS) seen(node *enode.Node, now time.Time) {
+	if node.ID() == m.self().ID() {
+		return
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	known, ok := m.nodes[node.ID()]
+	if ok && known.node.Seq() > node.Seq() {
+		known.lastSeen = now
+		return
+	}
+	m.nodes[node.ID()] = &mdnsNode{node: node, lastSeen: now}
+	if !ok || known.node.Seq() != node.Seq() {
+		m.updateSet()
+	}
+}
+
+// prune forgets the nodes that stopped answering.
+func (m *MDNS) prune(now time.Time) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	pruned := false
+	for id, n := range m.nodes {
+		if now.Sub(n.lastSeen) > mdnsNodeTTL {
+			delete(m.nodes, id)
+			pruned = true
+		}
+	}
+	if pruned {
+		m.updateSet()
+	}
+}
+
+// updateSet must be called with the lock held.
+func (m *MDNS) updateSet() {
+	nodes := make([]*enode.Node, 0, len(m.nodes))
+	for _, n := range m.nodes {
+		nodes = append(nodes, n.node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID().String() < nodes[j].ID().String()
+	})
+	m.set.set(nodes)
+}
+
+func mdnsQuery() ([]byte, error) {
+	name, err := dnsmessage.NewName(mdnsService)
+	if err != nil {
+		return nil, err
+	}
+	msg := dnsmessage.Message{
+		Questions: []dnsmessage.Question{{
+			Name:  name,
+			Type:  dnsmessage.TypeTXT,
+			Class: dnsmessage.ClassINET,
+		}},
+	}
+	return msg.Pack()
+}
+
+func mdnsResponse(node *enode.Node) ([]byte, error) {
+	name, err := dnsmessage.NewName(mdnsService)
+	if err != nil {
+		return nil, err
+	}
+	record := node.String()
+	var txt []string
+	for len(record) > maxTXTStringLength {
+		txt = append(txt, record[:maxTXTStringLength])
+		record = record[maxTXTStringLength:]
+	}
+	txt = append(txt, record)
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{Response: true, Authoritative: true},
+		Answers: []dnsmessage.Resource{{
+			Header: dnsmessage.ResourceHeader{
+				Name:  name,
+				Type:  dnsmessage.TypeTXT,
+				Class: dnsmessage.ClassINET,
+				TTL:   mdnsRecordTTL,
+			},
+			Body: &dnsmessage.TXTResource{TXT: txt},
+		}},
+	}
+	return msg.Pack()
+}
+
+// parseMDNS returns whether the packet is a query for the ENRs of the local network,
+// and the nodes it announces.
+func parseMDNS(packet []byte) (bool, []*enode.Node, error) {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(packet); err != nil {
+		return false, nil, err
+	}
+	if !msg.Response {
+		for _, q := range msg.Questions {
+			if isMDNSService(q.Name) && (q.Type == dnsmessage.TypeTXT || q.Type == dnsmessage.TypeALL) {
+				return true, nil, nil
+			}
+		}
+		return false, nil, nil
+	}
+	var nodes []*enode.Node
+	for _, r := range append(msg.Answers, msg.Additionals...) {
+		txt, ok := r.Body.(*dnsmessage.TXTResource)
+		if !ok || !isMDNSService(r.Header.Name) {
+			continue
+		}
+		node, err := enode.Parse(enode.ValidSchemes, strings.Join(txt.TXT, ""))
+		if err != nil {
+			return false, nil, errors.Wrap(err, "invalid ENR")
+		}
+		nodes = append(nodes, node)
+	}
+	return false, nodes, nil
+}
+
+func isMDNSService(name dnsmessage.Name) bool {
+	return strings.EqualFold(name.String(), mdnsService)
+}
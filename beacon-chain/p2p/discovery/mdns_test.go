@@ -0,0 +1,68 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestMDNS_Messages(t *testing.T) {
+	query, err := mdnsQuery()
+	require.NoError(t, err)
+	isQuery, nodes, err := parseMDNS(query)
+	require.NoError(t, err)
+	assert.Equal(t, true, isQuery)
+	assert.Equal(t, 0, len(nodes))
+
+	node := testNode(t, 3, enr.WithEntry("eth2", make([]byte, 16)), enr.WithEntry("attnets", make([]byte, 8)), enr.WithEntry("padding", make([]byte, 64)))
+	require.Equal(t, true, len(node.String()) > maxTXTStringLength, "The ENR must be split across several strings")
+	response, err := mdnsResponse(node)
+	require.NoError(t, err)
+	isQuery, nodes, err = parseMDNS(response)
+	require.NoError(t, err)
+	assert.Equal(t, false, isQuery)
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, node.ID(), nodes[0].ID())
+	assert.Equal(t, uint64(3), nodes[0].Seq())
+
+	_, _, err = parseMDNS([]byte{1, 2, 3})
+	assert.NotNil(t, err)
+}
+
+func TestMDNS_SeenAndPrune(t *testing.T) {
+	self := testNode(t, 1)
+	m := &MDNS{
+		self:  func() *enode.Node { return self },
+		set:   newNodeSet(),
+		nodes: make(map[enode.ID]*mdnsNode),
+	}
+	now := time.Now()
+	m.seen(self, now)
+	nodes, _ := m.set.snapshot()
+	assert.Equal(t, 0, len(nodes), "The node itself must not be offered")
+
+	peer := testNode(t, 1)
+	m.seen(peer, now)
+	nodes, updated := m.set.snapshot()
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, peer.ID(), nodes[0].ID())
+
+	// Seeing the same record again only refreshes it.
+	m.seen(peer, now.Add(mdnsNodeTTL))
+	select {
+	case <-updated:
+		t.Fatal("The set must not be updated for a known record")
+	default:
+	}
+	m.prune(now.Add(mdnsNodeTTL + time.Second))
+	nodes, _ = m.set.snapshot()
+	assert.Equal(t, 1, len(nodes))
+
+	m.prune(now.Add(2*mdnsNodeTTL + time.Second))
+	nodes, _ = m.set.snapshot()
+	assert.Equal(t, 0, len(nodes))
+}
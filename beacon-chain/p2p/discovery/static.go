@@ -0,0 +1,166 @@
+package discovery
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/async"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// staticRevisitInterval is how often the nodes of the file are offered again for dialing.
+	staticRevisitInterval = 30 * time.Second
+	// staticReloadDebounce groups the bursts of events fired while the file is written.
+	staticReloadDebounce = time.Second
+)
+
+// StaticFile offers the nodes listed in a file, one ENR per line. Empty lines and lines
+// starting with # are ignored. The file is reloaded whenever it changes, so that the
+// peers of a network can be managed without restarting the node.
+type StaticFile struct {
+	path   string
+	set    *nodeSet
+	cancel context.CancelFunc
+}
+
+// NewStaticFile loads the nodes of the file and starts watching it for changes.
+func NewStaticFile(ctx context.Context, path string) (*StaticFile, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := readNodesFile(path)
+	if err != nil {
+		return nil, err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize file watcher")
+	}
+	// Watch the directory rather than the file, which editors and deployment tools
+	// usually replace with a new file.
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		if closeErr := watcher.Close(); closeErr != nil {
+			log.WithError(closeErr).Error("Could not close file watcher")
+		}
+		return nil, errors.Wrapf(err, "could not watch %s", path)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	s := &StaticFile{
+		path:   path,
+		set:    newNodeSet(),
+		cancel: cancel,
+	}
+	s.set.set(nodes)
+	log.WithFields(logrus.Fields{
+		"path":  path,
+		"nodes": len(nodes),
+	}).Info("Loaded discovery file")
+	go s.watch(ctx, watcher)
+	return s, nil
+}
+
+// Name of the backend.
+func (*StaticFile) Name() string {
+	return "file"
+}
+
+// Nodes returns an iterator cycling through the nodes of the file.
+func (s *StaticFile) Nodes() enode.Iterator {
+	return s.set.iterator(staticRevisitInterval)
+}
+
+// Close stops watching the file and ends the iterators.
+func (s *StaticFile) Close() {
+	s.cancel()
+	s.set.close()
+}
+
+func (s *StaticFile) watch(ctx context.Context, watcher *fsnotify.Watcher) {
+	defer func() {
+		if err := watcher.Close(); err != nil {
+			log.WithError(err).Error("Could not close file watcher")
+		}
+	}()
+	changes := make(chan interface{}, 100)
+	go async.Debounce(ctx, staticReloadDebounce, changes, func(interface{}) {
+		s.reload()
+	})
+	for {
+		select {
+		case event := <-watcher.Events:
+			if filepath.Clean(event.Name) != s.path {
+				continue
+			}
+			select {
+			case changes <- event:
+			default:
+			}
+		case err := <-watcher.Errors:
+			log.WithError(err).Errorf("Could not watch for changes of %s", s.path)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reload replaces the nodes with the content of the file. The previous nodes are kept
+// when the file cannot be read, for instance while it is being replaced.
+func (s *StaticFile) reload() {
+	nodes, err := readNodesFile(s.path)
+	if err != nil {
+		log.WithError(err).Error("Could not reload discovery file, keeping the previous nodes")
+		return
+	}
+	s.set.set(nodes)
+	log.WithFields(logrus.Fields{
+		"path":  s.path,
+		"nodes": len(nodes),
+	}).Info("Reloaded discovery file")
+}
+
+func readNodesFile(path string) ([]*enode.Node, error) {
+	content, err := os.ReadFile(path) // #nosec G304 -- The path is provided by the node operator.
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read discovery file")
+	}
+	return parseNodes(content)
+}
+
+// parseNodes parses a list of ENRs, one per line.
+func parseNodes(content []byte) ([]*enode.Node, error) {
+	var nodes []*enode.Node
+	seen := make(map[enode.ID]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if !strings.HasPrefix(text, "enr:") {
+			return nil, errors.Errorf("line %d: expected an ENR starting with enr:", line)
+		}
+		node, err := enode.Parse(enode.ValidSchemes, text)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", line)
+		}
+		if seen[node.ID()] {
+			continue
+		}
+		seen[node.ID()] = true
+		nodes = append(nodes, node)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
@@ -0,0 +1,84 @@
+package discovery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestParseNodes(t *testing.T) {
+	a, b := testNode(t, 1), testNode(t, 1)
+	content := strings.Join([]string{
+		"# Devnet bootstrap peers",
+		a.String(),
+		"",
+		"  " + b.String() + "  ",
+		a.String(),
+	}, "\n")
+	nodes, err := parseNodes([]byte(content))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(nodes))
+	assert.Equal(t, a.ID(), nodes[0].ID())
+	assert.Equal(t, b.ID(), nodes[1].ID())
+
+	_, err = parseNodes([]byte("# comment\nenode://1234@127.0.0.1:30303"))
+	assert.ErrorContains(t, "line 2: expected an ENR", err)
+	_, err = parseNodes([]byte("enr:-invalid"))
+	assert.ErrorContains(t, "line 1", err)
+}
+
+func TestStaticFile_Reloads(t *testing.T) {
+	a, b := testNode(t, 1), testNode(t, 1)
+	path := filepath.Join(t.TempDir(), "peers.txt")
+	require.NoError(t, os.WriteFile(path, []byte(a.String()+"\n"), 0600))
+
+	_, err := NewStaticFile(context.Background(), filepath.Join(t.TempDir(), "missing.txt"))
+	assert.ErrorContains(t, "could not read discovery file", err)
+
+	s, err := NewStaticFile(context.Background(), path)
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Equal(t, "file", s.Name())
+
+	it := s.Nodes()
+	defer it.Close()
+	require.Equal(t, true, it.Next())
+	assert.Equal(t, a.ID(), it.Node().ID())
+
+	// Replace the file like deployment tools do.
+	tmp := path + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte(b.String()+"\n"), 0600))
+	require.NoError(t, os.Rename(tmp, path))
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		nodes, _ := s.set.snapshot()
+		if len(nodes) == 1 && nodes[0].ID() == b.ID() {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Discovery file was not reloaded")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	require.Equal(t, true, it.Next())
+	assert.Equal(t, b.ID(), it.Node().ID())
+
+	// An invalid file keeps the previous nodes.
+	require.NoError(t, os.WriteFile(path, []byte("not an enr\n"), 0600))
+	s.reload()
+	nodes, _ := s.set.snapshot()
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, b.ID(), nodes[0].ID())
+
+	s.Close()
+	require.Equal(t, false, it.Next())
+	assert.Equal(t, (*enode.Node)(nil), it.Node())
+}
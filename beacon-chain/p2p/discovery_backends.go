@@ -0,0 +1,88 @@
+package p2p
+
+import (
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/discovery"
+	prysmnetwork "github.com/prysmaticlabs/prysm/v5/network"
+)
+
+// startDiscovery combines discv5, when it runs, with the discovery backends enabled in the
+// configuration, and starts looking for new peers through them.
+func (s *Service) startDiscovery() error {
+	var backends []discovery.Backend
+	if s.dv5Listener != nil {
+		backends = append(backends, discovery.NewDiscV5(s.dv5Listener))
+	}
+	closeBackends := func() {
+		discovery.NewMix(backends...).Close()
+	}
+	if s.cfg.DiscoveryFile != "" {
+		b, err := discovery.NewStaticFile(s.ctx, s.cfg.DiscoveryFile)
+		if err != nil {
+			closeBackends()
+			return errors.Wrap(err, "could not start file discovery")
+		}
+		backends = append(backends, b)
+	}
+	if len(s.cfg.DiscoveryDNS) > 0 {
+		b, err := discovery.NewDNS(s.cfg.DiscoveryDNS)
+		if err != nil {
+			closeBackends()
+			return errors.Wrap(err, "could not start DNS discovery")
+		}
+		backends = append(backends, b)
+	}
+	if s.cfg.DiscoveryMDNS {
+		self, err := s.mdnsSelf()
+		if err != nil {
+			closeBackends()
+			return errors.Wrap(err, "could not create local node")
+		}
+		b, err := discovery.NewMDNS(self)
+		if err != nil {
+			closeBackends()
+			return errors.Wrap(err, "could not start mDNS discovery")
+		}
+		backends = append(backends, b)
+	}
+	if len(backends) == 0 {
+		return nil
+	}
+	s.discoveryMix = discovery.NewMix(backends...)
+	log.WithField("backends", s.discoveryMix.Name()).Info("Started peer discovery")
+	go s.listenForNewNodes()
+	return nil
+}
+
+// mdnsSelf returns the function providing the record advertised over mDNS. It is the
+// record of discv5 when it runs, otherwise a local node is created for mDNS alone.
+func (s *Service) mdnsSelf() (func() *enode.Node, error) {
+	if s.dv5Listener != nil {
+		return s.dv5Listener.Self, nil
+	}
+	localNode, err := s.createLocalNode(
+		s.privKey,
+		prysmnetwork.IPAddr(),
+		int(s.cfg.UDPPort),
+		int(s.cfg.TCPPort),
+		int(s.cfg.QUICPort),
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.mdnsNode = localNode
+	return localNode.Node, nil
+}
+
+// discoveryIterator returns an iterator over the nodes found by the discovery backends,
+// or nil when no backend runs.
+func (s *Service) discoveryIterator() enode.Iterator {
+	if s.discoveryMix != nil {
+		return s.discoveryMix.Nodes()
+	}
+	if s.dv5Listener != nil {
+		return s.dv5Listener.RandomNodes()
+	}
+	return nil
+}
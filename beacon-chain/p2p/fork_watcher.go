@@ -29,6 +29,11 @@ func (s *Service) forkWatcher() {
 						log.WithError(err).Error("Could not add fork entry")
 					}
 				}
+				if s.mdnsNode != nil {
+					if _, err := addForkEntry(s.mdnsNode, s.genesisTime, s.genesisValidatorsRoot); err != nil {
+						log.WithError(err).Error("Could not add fork entry")
+					}
+				}
 			}
 		case <-s.ctx.Done():
 			log.Debug("Context closed, exiting goroutine")
@@ -5,7 +5,9 @@ import (
 
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -22,6 +24,8 @@ var gossipTopicMappings = map[string]func() proto.Message{
 	SyncCommitteeSubnetTopicFormat:            func() proto.Message { return &ethpb.SyncCommitteeMessage{} },
 	BlsToExecutionChangeSubnetTopicFormat:     func() proto.Message { return &ethpb.SignedBLSToExecutionChange{} },
 	BlobSubnetTopicFormat:                     func() proto.Message { return &ethpb.BlobSidecar{} },
+	LightClientFinalityUpdateTopicFormat:      func() proto.Message { return lightClientFinalityUpdate(version.Altair) },
+	LightClientOptimisticUpdateTopicFormat:    func() proto.Message { return lightClientOptimisticUpdate(version.Altair) },
 }
 
 // GossipTopicMappings is a function to return the assigned data type
@@ -60,6 +64,10 @@ func GossipTopicMappings(topic string, epoch primitives.Epoch) proto.Message {
 			return &ethpb.SignedAggregateAttestationAndProofElectra{}
 		}
 		return gossipMessage(topic)
+	case LightClientFinalityUpdateTopicFormat:
+		return lightClientFinalityUpdate(lightClientVersion(epoch))
+	case LightClientOptimisticUpdateTopicFormat:
+		return lightClientOptimisticUpdate(lightClientVersion(epoch))
 	default:
 		return gossipMessage(topic)
 	}
@@ -73,6 +81,36 @@ func gossipMessage(topic string) proto.Message {
 	return msgGen()
 }
 
+// lightClientVersion returns the fork that determines the schema of light client headers at the epoch.
+func lightClientVersion(epoch primitives.Epoch) int {
+	cfg := params.BeaconConfig()
+	switch {
+	case epoch >= cfg.DenebForkEpoch:
+		return version.Deneb
+	case epoch >= cfg.CapellaForkEpoch:
+		return version.Capella
+	default:
+		return version.Altair
+	}
+}
+
+// The light client update messages carry the header variant of their fork, so that they can be decoded.
+func lightClientFinalityUpdate(v int) *ethpbv2.LightClientFinalityUpdate {
+	u, err := ethpbv2.NewLightClientFinalityUpdate(v)
+	if err != nil {
+		return nil
+	}
+	return u
+}
+
+func lightClientOptimisticUpdate(v int) *ethpbv2.LightClientOptimisticUpdate {
+	u, err := ethpbv2.NewLightClientOptimisticUpdate(v)
+	if err != nil {
+		return nil
+	}
+	return u
+}
+
 // AllTopics returns all topics stored in our
 // gossip mapping.
 func AllTopics() []string {
@@ -0,0 +1,214 @@
+package gossiptrace
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LatencyStats summarizes a distribution of durations.
+type LatencyStats struct {
+	Count int
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+// TopicStats summarizes the messages of a topic. Latency is the time from the start of the
+// slot to the first arrival of each message.
+type TopicStats struct {
+	Topic     string
+	Delivered int
+	Rejected  int
+	Ignored   int
+	Duplicate int
+	Latency   LatencyStats
+}
+
+// PeerStats summarizes the messages received from a peer. First counts the messages the peer
+// was the first to send us, Latency is the time from the start of the slot to every arrival
+// from the peer and Lag is how long after their first arrival the peer sent us messages.
+type PeerStats struct {
+	Peer      string
+	First     int
+	Rejected  int
+	Ignored   int
+	Duplicate int
+	Latency   LatencyStats
+	Lag       LatencyStats
+}
+
+// Analysis is the result of the analysis of gossip trace records.
+type Analysis struct {
+	Records int
+	From    time.Time
+	To      time.Time
+	Topics  []*TopicStats
+	Peers   []*PeerStats
+}
+
+type topicAcc struct {
+	stats     *TopicStats
+	latencies []time.Duration
+}
+
+type peerAcc struct {
+	stats     *PeerStats
+	latencies []time.Duration
+	lags      []time.Duration
+}
+
+// duplicateArrival is a duplicate whose message was not seen yet.
+type duplicateArrival struct {
+	peer *peerAcc
+	time time.Time
+}
+
+// Analyzer computes per topic and per peer propagation statistics of gossip trace records.
+// The first arrival of a message is written once its validation completes, usually after
+// the duplicates received in the meantime, so the lag of a duplicate is computed when the
+// first arrival of its message is added.
+type Analyzer struct {
+	groupSubnets bool
+	records      int
+	from, to     time.Time
+	firstSeen    map[string]time.Time
+	duplicates   map[string][]duplicateArrival
+	topics       map[string]*topicAcc
+	peers        map[string]*peerAcc
+}
+
+// NewAnalyzer creates an analyzer. When groupSubnets is set, the subnets of a topic
+// such as beacon_attestation_{subnet} are reported together.
+func NewAnalyzer(groupSubnets bool) *Analyzer {
+	return &Analyzer{
+		groupSubnets: groupSubnets,
+		firstSeen:    make(map[string]time.Time),
+		duplicates:   make(map[string][]duplicateArrival),
+		topics:       make(map[string]*topicAcc),
+		peers:        make(map[string]*peerAcc),
+	}
+}
+
+// Add accounts for a record.
+func (a *Analyzer) Add(r *Record) {
+	a.records++
+	if a.from.IsZero() || r.Time.Before(a.from) {
+		a.from = r.Time
+	}
+	if r.Time.After(a.to) {
+		a.to = r.Time
+	}
+
+	name := TopicName(r.Topic, a.groupSubnets)
+	t, ok := a.topics[name]
+	if !ok {
+		t = &topicAcc{stats: &TopicStats{Topic: name}}
+		a.topics[name] = t
+	}
+	p, ok := a.peers[r.Peer]
+	if !ok {
+		p = &peerAcc{stats: &PeerStats{Peer: r.Peer}}
+		a.peers[r.Peer] = p
+	}
+
+	id := string(r.MessageID)
+	switch r.Kind {
+	case Duplicate:
+		t.stats.Duplicate++
+		p.stats.Duplicate++
+		if first, ok := a.firstSeen[id]; ok {
+			p.lags = append(p.lags, r.Time.Sub(first))
+		} else {
+			a.duplicates[id] = append(a.duplicates[id], duplicateArrival{peer: p, time: r.Time})
+		}
+	default:
+		switch r.Kind {
+		case Delivered:
+			t.stats.Delivered++
+		case Rejected:
+			t.stats.Rejected++
+			p.stats.Rejected++
+		case Ignored:
+			t.stats.Ignored++
+			p.stats.Ignored++
+		}
+		p.stats.First++
+		a.firstSeen[id] = r.Time
+		for _, d := range a.duplicates[id] {
+			d.peer.lags = append(d.peer.lags, d.time.Sub(r.Time))
+		}
+		delete(a.duplicates, id)
+		t.latencies = append(t.latencies, r.SlotOffset)
+		p.lags = append(p.lags, 0)
+	}
+	p.latencies = append(p.latencies, r.SlotOffset)
+}
+
+// Result returns the statistics of the records added so far. Topics are sorted by name
+// and peers by the number of messages they were first to send.
+func (a *Analyzer) Result() *Analysis {
+	res := &Analysis{
+		Records: a.records,
+		From:    a.from,
+		To:      a.to,
+		Topics:  make([]*TopicStats, 0, len(a.topics)),
+		Peers:   make([]*PeerStats, 0, len(a.peers)),
+	}
+	for _, t := range a.topics {
+		t.stats.Latency = latencyStats(t.latencies)
+		res.Topics = append(res.Topics, t.stats)
+	}
+	for _, p := range a.peers {
+		p.stats.Latency = latencyStats(p.latencies)
+		p.stats.Lag = latencyStats(p.lags)
+		res.Peers = append(res.Peers, p.stats)
+	}
+	sort.Slice(res.Topics, func(i, j int) bool {
+		return res.Topics[i].Topic < res.Topics[j].Topic
+	})
+	sort.Slice(res.Peers, func(i, j int) bool {
+		if res.Peers[i].First != res.Peers[j].First {
+			return res.Peers[i].First > res.Peers[j].First
+		}
+		return res.Peers[i].Peer < res.Peers[j].Peer
+	})
+	return res
+}
+
+func latencyStats(d []time.Duration) LatencyStats {
+	if len(d) == 0 {
+		return LatencyStats{}
+	}
+	sort.Slice(d, func(i, j int) bool { return d[i] < d[j] })
+	percentile := func(p float64) time.Duration {
+		return d[int(math.Ceil(p*float64(len(d))))-1]
+	}
+	return LatencyStats{
+		Count: len(d),
+		P50:   percentile(0.5),
+		P90:   percentile(0.9),
+		P99:   percentile(0.99),
+		Max:   d[len(d)-1],
+	}
+}
+
+var subnetSuffix = regexp.MustCompile(`_\d+$`)
+
+// TopicName returns the name of a gossip topic without its fork digest and encoding,
+// such as beacon_block for /eth2/6a95a1a9/beacon_block/ssz_snappy. When groupSubnets is
+// set, the subnet is replaced by {subnet}.
+func TopicName(topic string, groupSubnets bool) string {
+	parts := strings.Split(strings.Trim(topic, "/"), "/")
+	name := topic
+	if len(parts) == 4 && parts[0] == "eth2" {
+		name = parts[2]
+	}
+	if groupSubnets {
+		name = subnetSuffix.ReplaceAllString(name, "_{subnet}")
+	}
+	return name
+}
@@ -0,0 +1,65 @@
+package gossiptrace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestTopicName(t *testing.T) {
+	assert.Equal(t, "beacon_block", TopicName("/eth2/6a95a1a9/beacon_block/ssz_snappy", true))
+	assert.Equal(t, "beacon_attestation_12", TopicName("/eth2/6a95a1a9/beacon_attestation_12/ssz_snappy", false))
+	assert.Equal(t, "beacon_attestation_{subnet}", TopicName("/eth2/6a95a1a9/beacon_attestation_12/ssz_snappy", true))
+	assert.Equal(t, "custom", TopicName("custom", true))
+}
+
+func TestAnalyzer(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	block := "/eth2/6a95a1a9/beacon_block/ssz_snappy"
+	att := func(subnet string) string { return "/eth2/6a95a1a9/beacon_attestation_" + subnet + "/ssz_snappy" }
+	// The first arrival of a message is written after its validation, behind its duplicates.
+	records := []*Record{
+		{Kind: Duplicate, Time: start.Add(300 * time.Millisecond), SlotOffset: 1300 * time.Millisecond, Topic: block, Peer: "p2", MessageID: []byte("a")},
+		{Kind: Delivered, Time: start, SlotOffset: time.Second, Topic: block, Peer: "p1", MessageID: []byte("a")},
+		{Kind: Delivered, Time: start.Add(time.Second), SlotOffset: 4 * time.Second, Topic: att("1"), Peer: "p2", MessageID: []byte("b")},
+		{Kind: Ignored, Time: start.Add(2 * time.Second), SlotOffset: 5 * time.Second, Topic: att("2"), Peer: "p2", MessageID: []byte("c")},
+		{Kind: Rejected, Time: start.Add(3 * time.Second), SlotOffset: 6 * time.Second, Topic: att("2"), Peer: "p1", MessageID: []byte("d")},
+	}
+	a := NewAnalyzer(true)
+	for _, r := range records {
+		a.Add(r)
+	}
+	res := a.Result()
+	assert.Equal(t, 5, res.Records)
+	assert.Equal(t, start, res.From)
+	assert.Equal(t, start.Add(3*time.Second), res.To)
+
+	require.Equal(t, 2, len(res.Topics))
+	attStats, blockStats := res.Topics[0], res.Topics[1]
+	assert.Equal(t, "beacon_attestation_{subnet}", attStats.Topic)
+	assert.Equal(t, 1, attStats.Delivered)
+	assert.Equal(t, 1, attStats.Ignored)
+	assert.Equal(t, 1, attStats.Rejected)
+	assert.Equal(t, LatencyStats{Count: 3, P50: 5 * time.Second, P90: 6 * time.Second, P99: 6 * time.Second, Max: 6 * time.Second}, attStats.Latency)
+	assert.Equal(t, "beacon_block", blockStats.Topic)
+	assert.Equal(t, 1, blockStats.Delivered)
+	assert.Equal(t, 1, blockStats.Duplicate)
+	// Duplicates do not count towards the first arrival latency.
+	assert.Equal(t, 1, blockStats.Latency.Count)
+	assert.Equal(t, time.Second, blockStats.Latency.Max)
+
+	require.Equal(t, 2, len(res.Peers))
+	p1, p2 := res.Peers[0], res.Peers[1]
+	assert.Equal(t, "p1", p1.Peer)
+	assert.Equal(t, 2, p1.First)
+	assert.Equal(t, 1, p1.Rejected)
+	assert.Equal(t, time.Duration(0), p1.Lag.Max)
+	assert.Equal(t, "p2", p2.Peer)
+	assert.Equal(t, 2, p2.First)
+	assert.Equal(t, 1, p2.Duplicate)
+	assert.Equal(t, 1, p2.Ignored)
+	assert.Equal(t, 3, p2.Latency.Count)
+	assert.Equal(t, 300*time.Millisecond, p2.Lag.Max)
+}
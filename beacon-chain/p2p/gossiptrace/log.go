@@ -0,0 +1,5 @@
+package gossiptrace
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "gossiptrace")
@@ -0,0 +1,17 @@
+package gossiptrace
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	gossipTraceRecords = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_gossip_trace_records_total",
+		Help: "The number of gossip messages written to the gossip trace files, by outcome.",
+	}, []string{"kind"})
+	gossipTraceDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "p2p_gossip_trace_dropped_total",
+		Help: "The number of gossip messages that could not be written to the gossip trace files.",
+	})
+)
@@ -0,0 +1,59 @@
+package gossiptrace
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Files returns the trace files of the directory, oldest first.
+func Files(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), filePrefix) || !strings.HasSuffix(e.Name(), fileSuffix) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, e.Name()))
+	}
+	// File names embed a zero padded creation time, so they sort chronologically.
+	sort.Strings(files)
+	return files, nil
+}
+
+// ReadFile calls fn for every record of the trace file, in the order they were written.
+// A record truncated by a crash of the node at the end of the file is skipped.
+func ReadFile(path string, fn func(*Record) error) (err error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	dec, err := newDecoder(f)
+	if err != nil {
+		return errors.Wrap(err, path)
+	}
+	for {
+		rec, err := dec.decode()
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "could not read %s", path)
+		}
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+}
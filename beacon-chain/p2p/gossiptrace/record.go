@@ -0,0 +1,271 @@
+// Package gossiptrace records the gossip messages processed by the node to rotating
+// files on disk, and reads and analyzes them offline.
+//
+// A trace file starts with a magic header followed by a sequence of entries. To keep
+// the files compact, topics and peer ids are written once per file in dictionary
+// entries and message entries refer to them by index. All integers are varints.
+package gossiptrace
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// Kind is the outcome of the processing of a gossip message.
+type Kind uint8
+
+const (
+	// Delivered messages passed validation and were delivered to the subscribers.
+	Delivered Kind = iota
+	// Rejected messages failed validation, or were refused before validation.
+	Rejected
+	// Ignored messages were ignored by the validators.
+	Ignored
+	// Duplicate messages had already been received from another peer.
+	Duplicate
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case Delivered:
+		return "delivered"
+	case Rejected:
+		return "rejected"
+	case Ignored:
+		return "ignored"
+	case Duplicate:
+		return "duplicate"
+	default:
+		return "unknown"
+	}
+}
+
+// Record is a traced gossip message.
+type Record struct {
+	Kind Kind
+	// Time is when the message was received from the peer.
+	Time time.Time
+	// Slot is the slot at Time, and SlotOffset the time elapsed since the start of that slot.
+	Slot       primitives.Slot
+	SlotOffset time.Duration
+	// ValidationDuration is the time the message spent in validation, zero for duplicates.
+	ValidationDuration time.Duration
+	Topic              string
+	Peer               string
+	MessageID          []byte
+	// Reason is the reason given by pubsub for rejected and ignored messages.
+	Reason string
+	// Payload is the snappy compressed SSZ message, only recorded when payloads are enabled.
+	Payload []byte
+}
+
+var magic = []byte("PRYSMGT\x01")
+
+const (
+	entryTopic byte = iota + 1
+	entryPeer
+	entryRecord
+)
+
+// maxFieldLength bounds the length of variable length fields read from a trace file.
+const maxFieldLength = 1 << 24
+
+var errInvalidFile = errors.New("not a gossip trace file")
+
+// encoder writes records to a trace file.
+type encoder struct {
+	w      *bufio.Writer
+	topics map[string]uint64
+	peers  map[string]uint64
+	buf    []byte
+}
+
+func newEncoder(w io.Writer) (*encoder, int, error) {
+	bw := bufio.NewWriter(w)
+	n, err := bw.Write(magic)
+	if err != nil {
+		return nil, n, err
+	}
+	return &encoder{
+		w:      bw,
+		topics: make(map[string]uint64),
+		peers:  make(map[string]uint64),
+	}, n, nil
+}
+
+// encode writes the record, preceded by the dictionary entries it needs, and returns the number of bytes written.
+func (e *encoder) encode(r *Record) (int, error) {
+	b := e.buf[:0]
+	topic, ok := e.topics[r.Topic]
+	if !ok {
+		topic = uint64(len(e.topics))
+		e.topics[r.Topic] = topic
+		b = append(b, entryTopic)
+		b = binary.AppendUvarint(b, topic)
+		b = appendBytes(b, []byte(r.Topic))
+	}
+	pid, ok := e.peers[r.Peer]
+	if !ok {
+		pid = uint64(len(e.peers))
+		e.peers[r.Peer] = pid
+		b = append(b, entryPeer)
+		b = binary.AppendUvarint(b, pid)
+		b = appendBytes(b, []byte(r.Peer))
+	}
+	b = append(b, entryRecord, byte(r.Kind))
+	b = binary.AppendUvarint(b, topic)
+	b = binary.AppendUvarint(b, pid)
+	b = binary.AppendVarint(b, r.Time.UnixNano())
+	b = binary.AppendUvarint(b, uint64(r.Slot))
+	b = binary.AppendVarint(b, int64(r.SlotOffset))
+	b = binary.AppendVarint(b, int64(r.ValidationDuration))
+	b = appendBytes(b, r.MessageID)
+	b = appendBytes(b, []byte(r.Reason))
+	b = appendBytes(b, r.Payload)
+	e.buf = b
+	return e.w.Write(b)
+}
+
+func (e *encoder) flush() error {
+	return e.w.Flush()
+}
+
+func appendBytes(b, v []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+// decoder reads records from a trace file.
+type decoder struct {
+	r      *bufio.Reader
+	topics []string
+	peers  []string
+}
+
+func newDecoder(r io.Reader) (*decoder, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(magic))
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, errInvalidFile
+	}
+	if string(header) != string(magic) {
+		return nil, errInvalidFile
+	}
+	return &decoder{r: br}, nil
+}
+
+// decode returns the next record, or io.EOF at the end of the file.
+func (d *decoder) decode() (*Record, error) {
+	for {
+		tag, err := d.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		switch tag {
+		case entryTopic, entryPeer:
+			idx, err := binary.ReadUvarint(d.r)
+			if err != nil {
+				return nil, unexpected(err)
+			}
+			v, err := d.readBytes()
+			if err != nil {
+				return nil, err
+			}
+			dict := &d.topics
+			if tag == entryPeer {
+				dict = &d.peers
+			}
+			if idx != uint64(len(*dict)) {
+				return nil, errors.Errorf("unexpected dictionary index %d", idx)
+			}
+			*dict = append(*dict, string(v))
+		case entryRecord:
+			return d.decodeRecord()
+		default:
+			return nil, errors.Errorf("unknown entry type %d", tag)
+		}
+	}
+}
+
+func (d *decoder) decodeRecord() (*Record, error) {
+	kind, err := d.r.ReadByte()
+	if err != nil {
+		return nil, unexpected(err)
+	}
+	topic, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return nil, unexpected(err)
+	}
+	pid, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return nil, unexpected(err)
+	}
+	if topic >= uint64(len(d.topics)) || pid >= uint64(len(d.peers)) {
+		return nil, errors.New("record refers to an unknown topic or peer")
+	}
+	r := &Record{Kind: Kind(kind), Topic: d.topics[topic], Peer: d.peers[pid]}
+	ts, err := binary.ReadVarint(d.r)
+	if err != nil {
+		return nil, unexpected(err)
+	}
+	r.Time = time.Unix(0, ts)
+	slot, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return nil, unexpected(err)
+	}
+	r.Slot = primitives.Slot(slot)
+	offset, err := binary.ReadVarint(d.r)
+	if err != nil {
+		return nil, unexpected(err)
+	}
+	r.SlotOffset = time.Duration(offset)
+	validation, err := binary.ReadVarint(d.r)
+	if err != nil {
+		return nil, unexpected(err)
+	}
+	r.ValidationDuration = time.Duration(validation)
+	if r.MessageID, err = d.readBytes(); err != nil {
+		return nil, err
+	}
+	reason, err := d.readBytes()
+	if err != nil {
+		return nil, err
+	}
+	r.Reason = string(reason)
+	if r.Payload, err = d.readBytes(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (d *decoder) readBytes() ([]byte, error) {
+	l, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return nil, unexpected(err)
+	}
+	if l > maxFieldLength {
+		return nil, errors.Errorf("field length %d exceeds the maximum of %d", l, maxFieldLength)
+	}
+	if l == 0 {
+		return nil, nil
+	}
+	v := make([]byte, l)
+	if _, err := io.ReadFull(d.r, v); err != nil {
+		return nil, unexpected(err)
+	}
+	return v, nil
+}
+
+// unexpected turns an end of file in the middle of an entry into io.ErrUnexpectedEOF.
+func unexpected(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,281 @@
+package gossiptrace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+)
+
+const (
+	filePrefix = "gossip-"
+	fileSuffix = ".trace"
+	// queueSize is the number of records buffered before new ones are dropped.
+	queueSize = 8192
+	// maxPendingValidations bounds the number of messages whose arrival time is tracked during validation.
+	maxPendingValidations = 65536
+	flushInterval         = time.Second
+)
+
+// Config of the gossip trace recorder.
+type Config struct {
+	// Dir is the directory the trace files are written to.
+	Dir string
+	// Payloads enables recording the message payloads.
+	Payloads bool
+	// MaxFileSize is the size in bytes after which a new trace file is started.
+	MaxFileSize uint64
+	// MaxFiles is the number of trace files kept, older ones are deleted. Zero keeps all files.
+	MaxFiles int
+}
+
+// Recorder writes the gossip messages processed by the node to rotating trace files.
+// Records are written asynchronously and dropped when the writer falls behind, so that
+// recording never slows down gossip processing. A nil recorder records nothing.
+type Recorder struct {
+	cfg       Config
+	records   chan *Record
+	quit      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+
+	lock        sync.Mutex
+	genesisTime time.Time
+	arrivals    map[string]time.Time
+
+	// Only accessed by the writer goroutine.
+	file     *os.File
+	enc      *encoder
+	written  uint64
+	lastFile int64
+}
+
+// NewRecorder creates the trace directory and starts writing records to it.
+func NewRecorder(cfg Config) (*Recorder, error) {
+	if cfg.Dir == "" {
+		return nil, errors.New("no gossip trace directory")
+	}
+	if cfg.MaxFileSize == 0 {
+		return nil, errors.New("maximum gossip trace file size must be positive")
+	}
+	if err := file.MkdirAll(cfg.Dir); err != nil {
+		return nil, errors.Wrap(err, "could not create gossip trace directory")
+	}
+	r := &Recorder{
+		cfg:      cfg,
+		records:  make(chan *Record, queueSize),
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+		arrivals: make(map[string]time.Time),
+	}
+	go r.run()
+	return r, nil
+}
+
+// SetGenesisTime sets the genesis time used to compute the slot of the records.
+func (r *Recorder) SetGenesisTime(t time.Time) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.genesisTime = t
+}
+
+// Validate notes the arrival time of a message entering validation.
+func (r *Recorder) Validate(msg *pubsub.Message) {
+	if r == nil || msg.Local {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.arrivals) >= maxPendingValidations {
+		// Messages that never complete validation would otherwise be tracked forever.
+		r.arrivals = make(map[string]time.Time)
+	}
+	r.arrivals[msg.ID] = prysmTime.Now()
+}
+
+// Deliver records a message that passed validation.
+func (r *Recorder) Deliver(msg *pubsub.Message) {
+	r.record(msg, Delivered, "")
+}
+
+// Reject records a message that was rejected or ignored for the given pubsub reason.
+func (r *Recorder) Reject(msg *pubsub.Message, reason string) {
+	kind := Rejected
+	if reason == pubsub.RejectValidationIgnored {
+		kind = Ignored
+	}
+	r.record(msg, kind, reason)
+}
+
+// Duplicate records a message that had already been received.
+func (r *Recorder) Duplicate(msg *pubsub.Message) {
+	r.record(msg, Duplicate, "")
+}
+
+// Close stops the recorder and flushes the pending records to disk.
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.closeOnce.Do(func() {
+		close(r.quit)
+	})
+	<-r.done
+	return r.closeFile()
+}
+
+func (r *Recorder) record(msg *pubsub.Message, kind Kind, reason string) {
+	if r == nil || msg.Local || msg.Topic == nil {
+		return
+	}
+	now := prysmTime.Now()
+	rec := &Record{
+		Kind:      kind,
+		Time:      now,
+		Topic:     *msg.Topic,
+		Peer:      msg.ReceivedFrom.String(),
+		MessageID: []byte(msg.ID),
+		Reason:    reason,
+	}
+	if r.cfg.Payloads {
+		rec.Payload = msg.Data
+	}
+
+	r.lock.Lock()
+	genesis := r.genesisTime
+	if kind != Duplicate {
+		if arrival, ok := r.arrivals[msg.ID]; ok {
+			rec.Time = arrival
+			rec.ValidationDuration = now.Sub(arrival)
+			delete(r.arrivals, msg.ID)
+		}
+	}
+	r.lock.Unlock()
+	rec.Slot, rec.SlotOffset = slotAndOffset(genesis, rec.Time)
+
+	select {
+	case <-r.quit:
+	case r.records <- rec:
+	default:
+		gossipTraceDropped.Inc()
+	}
+}
+
+// slotAndOffset returns the slot at the given time and the time elapsed since its start.
+func slotAndOffset(genesis, t time.Time) (primitives.Slot, time.Duration) {
+	if genesis.IsZero() || t.Before(genesis) {
+		return 0, 0
+	}
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	elapsed := t.Sub(genesis)
+	slot := elapsed / slotDuration
+	return primitives.Slot(slot), elapsed - slot*slotDuration
+}
+
+func (r *Recorder) run() {
+	defer close(r.done)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case rec := <-r.records:
+			r.write(rec)
+		case <-ticker.C:
+			if r.enc != nil {
+				if err := r.enc.flush(); err != nil {
+					log.WithError(err).Error("Could not flush gossip trace file")
+				}
+			}
+		case <-r.quit:
+			// Drain the queued records before stopping.
+			for {
+				select {
+				case rec := <-r.records:
+					r.write(rec)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r *Recorder) write(rec *Record) {
+	if r.enc == nil || r.written >= r.cfg.MaxFileSize {
+		if err := r.rotate(); err != nil {
+			log.WithError(err).Error("Could not rotate gossip trace file")
+			gossipTraceDropped.Inc()
+			return
+		}
+	}
+	n, err := r.enc.encode(rec)
+	r.written += uint64(n)
+	if err != nil {
+		log.WithError(err).Error("Could not write gossip trace record")
+		gossipTraceDropped.Inc()
+		return
+	}
+	gossipTraceRecords.WithLabelValues(rec.Kind.String()).Inc()
+}
+
+func (r *Recorder) rotate() error {
+	if err := r.closeFile(); err != nil {
+		return err
+	}
+	// File names must sort chronologically, even if the clock is coarse or goes backwards.
+	r.lastFile = max(prysmTime.Now().UnixNano(), r.lastFile+1)
+	name := filepath.Join(r.cfg.Dir, fmt.Sprintf("%s%020d%s", filePrefix, r.lastFile, fileSuffix))
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions)
+	if err != nil {
+		return err
+	}
+	enc, n, err := newEncoder(f)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	r.file, r.enc, r.written = f, enc, uint64(n)
+	return r.pruneFiles()
+}
+
+func (r *Recorder) closeFile() error {
+	if r.file == nil {
+		return nil
+	}
+	f := r.file
+	r.file = nil
+	if err := r.enc.flush(); err != nil {
+		return err
+	}
+	r.enc = nil
+	return f.Close()
+}
+
+// pruneFiles deletes the oldest trace files beyond the configured maximum.
+func (r *Recorder) pruneFiles() error {
+	if r.cfg.MaxFiles <= 0 {
+		return nil
+	}
+	files, err := Files(r.cfg.Dir)
+	if err != nil {
+		return err
+	}
+	for len(files) > r.cfg.MaxFiles {
+		if err := os.Remove(files[0]); err != nil {
+			return err
+		}
+		files = files[1:]
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package gossiptrace
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func testMessage(topic, id string, from peer.ID) *pubsub.Message {
+	return &pubsub.Message{
+		Message:      &pubsubpb.Message{Data: []byte("payload-" + id), Topic: &topic},
+		ID:           id,
+		ReceivedFrom: from,
+	}
+}
+
+func readAll(t *testing.T, dir string) []*Record {
+	files, err := Files(dir)
+	require.NoError(t, err)
+	var records []*Record
+	for _, f := range files {
+		require.NoError(t, ReadFile(f, func(r *Record) error {
+			records = append(records, r)
+			return nil
+		}))
+	}
+	return records
+}
+
+func TestRecorder(t *testing.T) {
+	dir := t.TempDir()
+	r, err := NewRecorder(Config{Dir: dir, Payloads: true, MaxFileSize: 1 << 20})
+	require.NoError(t, err)
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	r.SetGenesisTime(time.Now().Add(-10*slotDuration - time.Second))
+
+	topic := "/eth2/6a95a1a9/beacon_block/ssz_snappy"
+	r.Validate(testMessage(topic, "a", "peer1"))
+	r.Deliver(testMessage(topic, "a", "peer1"))
+	r.Duplicate(testMessage(topic, "a", "peer2"))
+	r.Reject(testMessage(topic, "b", "peer2"), pubsub.RejectValidationFailed)
+	r.Reject(testMessage(topic, "c", "peer1"), pubsub.RejectValidationIgnored)
+	local := testMessage(topic, "d", "self")
+	local.Local = true
+	r.Deliver(local)
+	require.NoError(t, r.Close())
+	// Records are dropped once closed.
+	r.Deliver(testMessage(topic, "e", "peer1"))
+
+	records := readAll(t, dir)
+	require.Equal(t, 4, len(records))
+	kinds := []Kind{Delivered, Duplicate, Rejected, Ignored}
+	for i, rec := range records {
+		assert.Equal(t, kinds[i], rec.Kind)
+		assert.Equal(t, topic, rec.Topic)
+		assert.Equal(t, primitives.Slot(10), rec.Slot)
+		assert.Equal(t, true, rec.SlotOffset >= time.Second && rec.SlotOffset < slotDuration)
+	}
+	assert.Equal(t, peer.ID("peer1").String(), records[0].Peer)
+	assert.Equal(t, "a", string(records[0].MessageID))
+	assert.DeepEqual(t, []byte("payload-a"), records[0].Payload)
+	assert.Equal(t, peer.ID("peer2").String(), records[1].Peer)
+	assert.Equal(t, pubsub.RejectValidationFailed, records[2].Reason)
+	assert.Equal(t, pubsub.RejectValidationIgnored, records[3].Reason)
+}
+
+func TestRecorder_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	// Every record goes to a new file.
+	r, err := NewRecorder(Config{Dir: dir, MaxFileSize: 1, MaxFiles: 3})
+	require.NoError(t, err)
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		r.Deliver(testMessage("topic", id, "peer"))
+	}
+	require.NoError(t, r.Close())
+
+	files, err := Files(dir)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(files))
+	records := readAll(t, dir)
+	require.Equal(t, 3, len(records))
+	for i, id := range []string{"c", "d", "e"} {
+		assert.Equal(t, id, string(records[i].MessageID))
+		assert.Equal(t, 0, len(records[i].Payload))
+	}
+}
+
+func TestReadFile_Truncated(t *testing.T) {
+	dir := t.TempDir()
+	r, err := NewRecorder(Config{Dir: dir, MaxFileSize: 1 << 20})
+	require.NoError(t, err)
+	r.Deliver(testMessage("topic", "a", "peer"))
+	r.Deliver(testMessage("topic", "b", "peer"))
+	require.NoError(t, r.Close())
+
+	files, err := Files(dir)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(files))
+	info, err := os.Stat(files[0])
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(files[0], info.Size()-1))
+	records := readAll(t, dir)
+	require.Equal(t, 1, len(records))
+
+	require.NoError(t, os.WriteFile(files[0], []byte("garbage"), 0600))
+	require.ErrorContains(t, "not a gossip trace file", ReadFile(files[0], func(*Record) error { return nil }))
+}
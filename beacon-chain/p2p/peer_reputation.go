@@ -0,0 +1,112 @@
+package p2p
+
+import (
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdb"
+	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+)
+
+var (
+	// peerDBSaveInterval is the period at which peer reputations are flushed to disk.
+	peerDBSaveInterval = 5 * time.Minute
+	// peerDBRetention is how long peers that are not banned are kept after they were last seen.
+	peerDBRetention = 7 * 24 * time.Hour
+)
+
+// openPeerDB opens the peer database in the data directory and restores the persisted peer
+// reputations. Failing to do so is not fatal, the node runs without persistence instead.
+func (s *Service) openPeerDB() {
+	if s.cfg.DataDir == "" {
+		return
+	}
+	db, err := peerdb.NewStore(s.cfg.DataDir)
+	if err != nil {
+		log.WithError(err).Warn("Could not open peer database, peer reputations will not be persisted")
+		return
+	}
+	records, err := db.Records()
+	if err != nil {
+		log.WithError(err).Warn("Could not read peer database")
+	}
+	s.peerDB = db
+	s.knownPeers = records
+	s.peers.OnBanChange(s.savePeerRecord)
+	restored := s.peers.Restore(records)
+	log.WithField("count", restored).Debug("Restored peer reputations")
+}
+
+// savePeerDB persists the reputation of the known peers and prunes stale records.
+func (s *Service) savePeerDB() {
+	if s.peerDB == nil {
+		return
+	}
+	if err := s.peerDB.SaveRecords(s.peers.Records()); err != nil {
+		log.WithError(err).Error("Could not save peer reputations")
+		return
+	}
+	pruned, err := s.peerDB.Prune(prysmTime.Now().Add(-peerDBRetention))
+	if err != nil {
+		log.WithError(err).Error("Could not prune peer database")
+		return
+	}
+	if pruned > 0 {
+		log.WithField("count", pruned).Debug("Pruned stale peer reputations")
+	}
+}
+
+// savePeerRecord persists the reputation of a peer right away, for bans not to wait for the next save.
+func (s *Service) savePeerRecord(r *peerdb.Record) {
+	if err := s.peerDB.SaveRecords([]*peerdb.Record{r}); err != nil {
+		log.WithError(err).WithField("peerID", r.ID).Error("Could not save peer reputation")
+	}
+}
+
+// closePeerDB saves the peer reputations a last time and closes the peer database.
+func (s *Service) closePeerDB() error {
+	if s.peerDB == nil {
+		return nil
+	}
+	s.savePeerDB()
+	return s.peerDB.Close()
+}
+
+// dialKnownPeers dials the peers persisted from previous runs, best scored first,
+// so the node does not have to wait for discovery to find good peers again.
+func (s *Service) dialKnownPeers() {
+	records := make([]*peerdb.Record, 0, len(s.knownPeers))
+	for _, r := range s.knownPeers {
+		if r.Address != "" && r.ActiveBan(prysmTime.Now()) == nil {
+			records = append(records, r)
+		}
+	}
+	s.knownPeers = nil
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Score > records[j].Score
+	})
+	if limit := int(s.cfg.MaxPeers); len(records) > limit {
+		records = records[:limit]
+	}
+	for _, r := range records {
+		pid, err := peer.Decode(r.ID)
+		if err != nil {
+			continue
+		}
+		addr, err := ma.NewMultiaddr(r.Address)
+		if err != nil {
+			continue
+		}
+		if s.peers.IsBad(pid) || s.peers.IsActive(pid) {
+			continue
+		}
+		// make each dial non-blocking
+		go func(info peer.AddrInfo) {
+			if err := s.connectWithPeer(s.ctx, info); err != nil {
+				log.WithError(err).Tracef("Could not connect with known peer %s", info.String())
+			}
+		}(peer.AddrInfo{ID: pid, Addrs: []ma.Multiaddr{addr}})
+	}
+}
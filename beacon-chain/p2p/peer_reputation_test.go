@@ -0,0 +1,44 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestService_BansPersistedRightAway(t *testing.T) {
+	s := &Service{
+		cfg: &Config{DataDir: t.TempDir()},
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:    30,
+			ScorerParams: &scorers.Config{},
+		}),
+	}
+	s.openPeerDB()
+	require.NotNil(t, s.peerDB)
+	defer func() {
+		require.NoError(t, s.closePeerDB())
+	}()
+	id, err := peer.Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	require.NoError(t, err)
+
+	s.peers.Ban(id, time.Hour, "spam")
+	records, err := s.peerDB.Records()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, id.String(), records[0].ID)
+	assert.NotNil(t, records[0].ActiveBan(time.Now()))
+
+	_, err = s.peers.Unban(id)
+	require.NoError(t, err)
+	records, err = s.peerDB.Records()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, true, records[0].ActiveBan(time.Now()) == nil)
+}
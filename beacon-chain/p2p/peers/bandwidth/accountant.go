@@ -0,0 +1,325 @@
+// Package bandwidth accounts the bytes exchanged with each peer, by request/response
+// protocol and by gossip topic, and checks the load every peer puts on the node against
+// the quota of its class over fixed windows.
+//
+// The load of a peer is made of the bytes of the streams it opens, requests and our
+// responses alike, and of the gossip messages it sends us. Responses to our own requests
+// and the gossip we forward to the peer are accounted but do not count against its quota,
+// since the peer did not ask for them.
+package bandwidth
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// DefaultWindow is the default period over which quotas are enforced.
+	DefaultWindow = time.Minute
+	// DefaultRPCQuota is the default number of bytes of streams opened by a peer allowed per window.
+	DefaultRPCQuota = 256 * 1024 * 1024
+	// DefaultGossipQuota is the default number of gossip bytes received from a peer allowed per window.
+	DefaultGossipQuota = 128 * 1024 * 1024
+	// idleWindows is the number of windows without traffic after which a peer is forgotten.
+	idleWindows = 10
+)
+
+// Class of a peer, which determines its quota.
+type Class string
+
+const (
+	// Trusted peers were added through the trusted peers API or with --peer.
+	Trusted Class = "trusted"
+	// Default is the class of all the other peers.
+	Default Class = "default"
+)
+
+// Kind of traffic a quota applies to.
+type Kind string
+
+const (
+	// RPC is the traffic of the request/response streams opened by the peer.
+	RPC Kind = "rpc"
+	// Gossip is the traffic of the gossip messages received from the peer.
+	Gossip Kind = "gossip"
+)
+
+// Quota is the load a peer may put on the node per window, in bytes. Zero is unlimited.
+type Quota struct {
+	RPC    uint64
+	Gossip uint64
+}
+
+// Config of the accountant.
+type Config struct {
+	// Window is the period over which quotas are enforced.
+	Window time.Duration
+	// Trusted is the quota of trusted peers.
+	Trusted Quota
+	// Default is the quota of the other peers.
+	Default Quota
+}
+
+// DefaultConfig returns the default configuration, which does not limit trusted peers.
+func DefaultConfig() *Config {
+	return &Config{
+		Window: DefaultWindow,
+		Default: Quota{
+			RPC:    DefaultRPCQuota,
+			Gossip: DefaultGossipQuota,
+		},
+	}
+}
+
+// Traffic is a number of bytes received and sent.
+type Traffic struct {
+	In  uint64
+	Out uint64
+}
+
+// Load is the load a peer put on the node, in bytes.
+type Load struct {
+	RPC    uint64
+	Gossip uint64
+}
+
+// Violation is a peer that went over its quota during a window.
+type Violation struct {
+	Peer  peer.ID
+	Class Class
+	Kind  Kind
+	Load  uint64
+	Quota uint64
+}
+
+// PeerUsage is the bandwidth used by a peer since it was first seen.
+type PeerUsage struct {
+	Peer  peer.ID
+	Class Class
+	Quota Quota
+	// Total is the traffic exchanged with the peer.
+	Total Traffic
+	// Window is the load of the peer during the current window, and LastWindow during the previous one.
+	Window     Load
+	LastWindow Load
+	// Violations is the number of windows during which the peer went over its quota.
+	Violations uint64
+	// Protocols is the traffic by request/response protocol, Topics by gossip topic.
+	Protocols map[string]Traffic
+	Topics    map[string]Traffic
+}
+
+type account struct {
+	total      Traffic
+	window     Load
+	lastWindow Load
+	violations uint64
+	idle       int
+	protocols  map[string]*Traffic
+	topics     map[string]*Traffic
+}
+
+// Accountant accounts the bandwidth used by each peer.
+type Accountant struct {
+	cfg       Config
+	isTrusted func(peer.ID) bool
+	lock      sync.Mutex
+	accounts  map[peer.ID]*account
+}
+
+// NewAccountant creates an accountant. isTrusted tells the class of a peer.
+func NewAccountant(cfg *Config, isTrusted func(peer.ID) bool) *Accountant {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+	c := *cfg
+	if c.Window == 0 {
+		c.Window = DefaultWindow
+	}
+	return &Accountant{
+		cfg:       c,
+		isTrusted: isTrusted,
+		accounts:  make(map[peer.ID]*account),
+	}
+}
+
+// Window returns the period over which quotas are enforced.
+func (a *Accountant) Window() time.Duration {
+	return a.cfg.Window
+}
+
+// RecordStream accounts bytes read from (in) or written to a request/response stream.
+// inbound tells whether the stream was opened by the peer.
+func (a *Accountant) RecordStream(pid peer.ID, protocol string, inbound, in bool, n int) {
+	if a == nil || n <= 0 {
+		return
+	}
+	rpcBytes.WithLabelValues(protocol, direction(in)).Add(float64(n))
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	acc := a.account(pid)
+	acc.record(acc.protocols, protocol, in, n)
+	if inbound {
+		acc.window.RPC += uint64(n)
+	}
+}
+
+// RecordGossip accounts the bytes of a gossip message received from (in) or sent to a peer.
+func (a *Accountant) RecordGossip(pid peer.ID, topic string, in bool, n int) {
+	if a == nil || n <= 0 {
+		return
+	}
+	gossipBytes.WithLabelValues(topic, direction(in)).Add(float64(n))
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	acc := a.account(pid)
+	acc.record(acc.topics, topic, in, n)
+	if in {
+		acc.window.Gossip += uint64(n)
+	}
+}
+
+// EndWindow closes the current window and returns the peers that went over their quota
+// during it. Peers without traffic for several windows are forgotten.
+func (a *Accountant) EndWindow() []*Violation {
+	if a == nil {
+		return nil
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	var violations []*Violation
+	for pid, acc := range a.accounts {
+		if acc.window == (Load{}) {
+			acc.idle++
+			if acc.idle >= idleWindows {
+				delete(a.accounts, pid)
+				continue
+			}
+		} else {
+			acc.idle = 0
+		}
+		class, quota := a.quota(pid)
+		over := false
+		if quota.RPC > 0 && acc.window.RPC > quota.RPC {
+			violations = append(violations, &Violation{Peer: pid, Class: class, Kind: RPC, Load: acc.window.RPC, Quota: quota.RPC})
+			over = true
+		}
+		if quota.Gossip > 0 && acc.window.Gossip > quota.Gossip {
+			violations = append(violations, &Violation{Peer: pid, Class: class, Kind: Gossip, Load: acc.window.Gossip, Quota: quota.Gossip})
+			over = true
+		}
+		if over {
+			acc.violations++
+		}
+		acc.lastWindow = acc.window
+		acc.window = Load{}
+	}
+	for _, v := range violations {
+		quotaExceeded.WithLabelValues(string(v.Class), string(v.Kind)).Inc()
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].Peer != violations[j].Peer {
+			return violations[i].Peer < violations[j].Peer
+		}
+		return violations[i].Kind < violations[j].Kind
+	})
+	return violations
+}
+
+// Peer returns the bandwidth used by a peer.
+func (a *Accountant) Peer(pid peer.ID) (*PeerUsage, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	acc, ok := a.accounts[pid]
+	if !ok {
+		return nil, false
+	}
+	return a.usage(pid, acc), true
+}
+
+// Peers returns the bandwidth used by every known peer, the heaviest first.
+func (a *Accountant) Peers() []*PeerUsage {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	usages := make([]*PeerUsage, 0, len(a.accounts))
+	for pid, acc := range a.accounts {
+		usages = append(usages, a.usage(pid, acc))
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		ti := usages[i].Total.In + usages[i].Total.Out
+		tj := usages[j].Total.In + usages[j].Total.Out
+		if ti != tj {
+			return ti > tj
+		}
+		return usages[i].Peer < usages[j].Peer
+	})
+	return usages
+}
+
+// account must be called with the lock held.
+func (a *Accountant) account(pid peer.ID) *account {
+	acc, ok := a.accounts[pid]
+	if !ok {
+		acc = &account{
+			protocols: make(map[string]*Traffic),
+			topics:    make(map[string]*Traffic),
+		}
+		a.accounts[pid] = acc
+	}
+	return acc
+}
+
+func (a *Accountant) quota(pid peer.ID) (Class, Quota) {
+	if a.isTrusted != nil && a.isTrusted(pid) {
+		return Trusted, a.cfg.Trusted
+	}
+	return Default, a.cfg.Default
+}
+
+// usage must be called with the lock held.
+func (a *Accountant) usage(pid peer.ID, acc *account) *PeerUsage {
+	class, quota := a.quota(pid)
+	u := &PeerUsage{
+		Peer:       pid,
+		Class:      class,
+		Quota:      quota,
+		Total:      acc.total,
+		Window:     acc.window,
+		LastWindow: acc.lastWindow,
+		Violations: acc.violations,
+		Protocols:  make(map[string]Traffic, len(acc.protocols)),
+		Topics:     make(map[string]Traffic, len(acc.topics)),
+	}
+	for name, t := range acc.protocols {
+		u.Protocols[name] = *t
+	}
+	for name, t := range acc.topics {
+		u.Topics[name] = *t
+	}
+	return u
+}
+
+func (acc *account) record(traffic map[string]*Traffic, name string, in bool, n int) {
+	t, ok := traffic[name]
+	if !ok {
+		t = &Traffic{}
+		traffic[name] = t
+	}
+	if in {
+		t.In += uint64(n)
+		acc.total.In += uint64(n)
+	} else {
+		t.Out += uint64(n)
+		acc.total.Out += uint64(n)
+	}
+}
+
+func direction(in bool) string {
+	if in {
+		return "in"
+	}
+	return "out"
+}
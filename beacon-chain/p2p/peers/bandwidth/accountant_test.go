@@ -0,0 +1,92 @@
+package bandwidth
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+const (
+	blocksByRange = "/eth2/beacon_chain/req/beacon_blocks_by_range/2/ssz_snappy"
+	blockTopic    = "/eth2/6a95a1a9/beacon_block/ssz_snappy"
+)
+
+func TestAccountant_Usage(t *testing.T) {
+	a := NewAccountant(&Config{Default: Quota{RPC: 1000, Gossip: 1000}}, nil)
+	assert.Equal(t, DefaultWindow, a.Window())
+
+	pid := peer.ID("peer1")
+	// A request from the peer and our response count against its quota.
+	a.RecordStream(pid, blocksByRange, true, true, 100)
+	a.RecordStream(pid, blocksByRange, true, false, 400)
+	// The response to our own request does not.
+	a.RecordStream(pid, blocksByRange, false, true, 2000)
+	a.RecordGossip(pid, blockTopic, true, 300)
+	// Nor the gossip we forward.
+	a.RecordGossip(pid, blockTopic, false, 5000)
+
+	u, ok := a.Peer(pid)
+	require.Equal(t, true, ok)
+	assert.Equal(t, Default, u.Class)
+	assert.Equal(t, Traffic{In: 2400, Out: 5400}, u.Total)
+	assert.Equal(t, Load{RPC: 500, Gossip: 300}, u.Window)
+	assert.Equal(t, Traffic{In: 2100, Out: 400}, u.Protocols[blocksByRange])
+	assert.Equal(t, Traffic{In: 300, Out: 5000}, u.Topics[blockTopic])
+
+	_, ok = a.Peer("unknown")
+	assert.Equal(t, false, ok)
+
+	assert.Equal(t, 0, len(a.EndWindow()))
+	u, ok = a.Peer(pid)
+	require.Equal(t, true, ok)
+	assert.Equal(t, Load{}, u.Window)
+	assert.Equal(t, Load{RPC: 500, Gossip: 300}, u.LastWindow)
+}
+
+func TestAccountant_EndWindow(t *testing.T) {
+	trusted := peer.ID("trusted")
+	a := NewAccountant(&Config{
+		Trusted: Quota{RPC: 0, Gossip: 10000},
+		Default: Quota{RPC: 1000, Gossip: 1000},
+	}, func(pid peer.ID) bool {
+		return pid == trusted
+	})
+
+	heavy, light := peer.ID("heavy"), peer.ID("light")
+	a.RecordStream(heavy, blocksByRange, true, false, 1500)
+	a.RecordGossip(heavy, blockTopic, true, 1200)
+	a.RecordStream(light, blocksByRange, true, false, 900)
+	a.RecordStream(trusted, blocksByRange, true, false, 1<<20)
+	a.RecordGossip(trusted, blockTopic, true, 5000)
+
+	violations := a.EndWindow()
+	require.Equal(t, 2, len(violations))
+	assert.DeepEqual(t, &Violation{Peer: heavy, Class: Default, Kind: Gossip, Load: 1200, Quota: 1000}, violations[0])
+	assert.DeepEqual(t, &Violation{Peer: heavy, Class: Default, Kind: RPC, Load: 1500, Quota: 1000}, violations[1])
+
+	usages := a.Peers()
+	require.Equal(t, 3, len(usages))
+	assert.Equal(t, trusted, usages[0].Peer)
+	assert.Equal(t, Trusted, usages[0].Class)
+	assert.Equal(t, heavy, usages[1].Peer)
+	assert.Equal(t, uint64(1), usages[1].Violations)
+	assert.Equal(t, light, usages[2].Peer)
+
+	// Peers are forgotten after a while without traffic.
+	a.RecordGossip(light, blockTopic, true, 10)
+	for i := 0; i < idleWindows; i++ {
+		a.EndWindow()
+	}
+	usages = a.Peers()
+	require.Equal(t, 1, len(usages))
+	assert.Equal(t, light, usages[0].Peer)
+}
+
+func TestAccountant_Nil(t *testing.T) {
+	var a *Accountant
+	a.RecordStream("peer1", blocksByRange, true, true, 10)
+	a.RecordGossip("peer1", blockTopic, true, 10)
+	assert.Equal(t, 0, len(a.EndWindow()))
+}
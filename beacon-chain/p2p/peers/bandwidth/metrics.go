@@ -0,0 +1,21 @@
+package bandwidth
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	rpcBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_rpc_bytes_total",
+		Help: "The number of bytes read from and written to request/response streams, by protocol and direction.",
+	}, []string{"protocol", "direction"})
+	gossipBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_gossip_bytes_total",
+		Help: "The number of bytes of gossip messages received from and sent to peers, by topic and direction.",
+	}, []string{"topic", "direction"})
+	quotaExceeded = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_peer_quota_exceeded_total",
+		Help: "The number of times a peer went over its bandwidth quota during a window, by peer class and kind of traffic.",
+	}, []string{"class", "kind"})
+)
@@ -0,0 +1,43 @@
+package bandwidth
+
+import (
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Stream wraps a request/response stream so that the bytes read from and written to it
+// are accounted. inbound tells whether the stream was opened by the peer.
+func (a *Accountant) Stream(stream network.Stream, inbound bool) network.Stream {
+	if a == nil {
+		return stream
+	}
+	return &accountedStream{
+		Stream:   stream,
+		acc:      a,
+		peer:     stream.Conn().RemotePeer(),
+		protocol: string(stream.Protocol()),
+		inbound:  inbound,
+	}
+}
+
+type accountedStream struct {
+	network.Stream
+	acc      *Accountant
+	peer     peer.ID
+	protocol string
+	inbound  bool
+}
+
+// Read reads from the stream and accounts the bytes read.
+func (s *accountedStream) Read(b []byte) (int, error) {
+	n, err := s.Stream.Read(b)
+	s.acc.RecordStream(s.peer, s.protocol, s.inbound, true, n)
+	return n, err
+}
+
+// Write writes to the stream and accounts the bytes written.
+func (s *accountedStream) Write(b []byte) (int, error) {
+	n, err := s.Stream.Write(b)
+	s.acc.RecordStream(s.peer, s.protocol, s.inbound, false, n)
+	return n, err
+}
@@ -52,6 +52,8 @@ type PeerData struct {
 	ConnState     PeerConnectionState
 	Enr           *enr.Record
 	NextValidTime time.Time
+	LastSeen      time.Time
+	Bans          []*Ban
 	// Chain related data.
 	MetaData                  metadata.Metadata
 	ChainState                *ethpb.Status
@@ -59,6 +61,7 @@ type PeerData struct {
 	ChainStateValidationError error
 	// Scorers internal data.
 	BadResponses         int
+	QuotaViolations      int
 	ProcessedBlocks      uint64
 	BlockProviderUpdated time.Time
 	// Gossip Scoring data.
@@ -67,6 +70,31 @@ type PeerData struct {
 	BehaviourPenalty float64
 }
 
+// Ban records a period during which the node refuses to talk to a peer.
+type Ban struct {
+	Reason string    `json:"reason"`
+	Start  time.Time `json:"start"`
+	// Until is the time the ban expires, a zero value bans the peer indefinitely.
+	Until time.Time `json:"until"`
+	// Lifted is the time the ban was lifted, if it was lifted before it expired.
+	Lifted time.Time `json:"lifted"`
+}
+
+// Active checks whether the ban is in force at the given time.
+func (b *Ban) Active(now time.Time) bool {
+	return b.Lifted.IsZero() && (b.Until.IsZero() || now.Before(b.Until))
+}
+
+// ActiveBan returns the ban of the peer in force at the given time, if any.
+func (p *PeerData) ActiveBan(now time.Time) *Ban {
+	for _, b := range p.Bans {
+		if b.Active(now) {
+			return b
+		}
+	}
+	return nil
+}
+
 // NewStore creates new peer data store.
 func NewStore(ctx context.Context, config *StoreConfig) *Store {
 	return &Store{
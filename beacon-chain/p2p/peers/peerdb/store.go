@@ -0,0 +1,152 @@
+// Package peerdb defines a small bolt-db backed store persisting what the node
+// learned about its peers (addresses, scores and ban history) across restarts.
+package peerdb
+
+import (
+	"encoding/json"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	bolt "go.etcd.io/bbolt"
+)
+
+// DatabaseFileName is the name of the peer database file.
+const DatabaseFileName = "peers.db"
+
+var peersBucket = []byte("peers")
+
+// Record is the persisted reputation of a single peer.
+type Record struct {
+	ID               string          `json:"id"`
+	ENR              []byte          `json:"enr,omitempty"`
+	Address          string          `json:"address,omitempty"`
+	LastSeen         time.Time       `json:"last_seen"`
+	BadResponses     int             `json:"bad_responses"`
+	ProcessedBlocks  uint64          `json:"processed_blocks"`
+	GossipScore      float64         `json:"gossip_score"`
+	BehaviourPenalty float64         `json:"behaviour_penalty"`
+	Score            float64         `json:"score"`
+	Bans             []*peerdata.Ban `json:"bans,omitempty"`
+}
+
+// ActiveBan returns the ban of the peer in force at the given time, if any.
+func (r *Record) ActiveBan(now time.Time) *peerdata.Ban {
+	for _, b := range r.Bans {
+		if b.Active(now) {
+			return b
+		}
+	}
+	return nil
+}
+
+// Store persists peer records in a bolt database.
+type Store struct {
+	db           *bolt.DB
+	databasePath string
+}
+
+// NewStore opens, or creates, the peer database in the given directory.
+func NewStore(dirPath string) (*Store, error) {
+	if err := file.MkdirAll(dirPath); err != nil {
+		return nil, err
+	}
+	boltDB, err := bolt.Open(
+		path.Join(dirPath, DatabaseFileName),
+		params.BeaconIoConfig().ReadWritePermissions,
+		&bolt.Options{Timeout: 1 * time.Second},
+	)
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
+		}
+		return nil, err
+	}
+	if err := boltDB.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(peersBucket)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return &Store{db: boltDB, databasePath: dirPath}, nil
+}
+
+// Close closes the underlying bolt database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// DatabasePath at which this database writes files.
+func (s *Store) DatabasePath() string {
+	return s.databasePath
+}
+
+// SaveRecords inserts or replaces the given peer records.
+func (s *Store) SaveRecords(records []*Record) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(peersBucket)
+		for _, r := range records {
+			if r.ID == "" {
+				return errors.New("peer record without id")
+			}
+			enc, err := json.Marshal(r)
+			if err != nil {
+				return errors.Wrapf(err, "could not encode record of peer %s", r.ID)
+			}
+			if err := bkt.Put([]byte(r.ID), enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Records returns all persisted peer records.
+func (s *Store) Records() ([]*Record, error) {
+	records := make([]*Record, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(peersBucket).ForEach(func(k, v []byte) error {
+			r := &Record{}
+			if err := json.Unmarshal(v, r); err != nil {
+				return errors.Wrapf(err, "could not decode record of peer %s", string(k))
+			}
+			records = append(records, r)
+			return nil
+		})
+	})
+	return records, err
+}
+
+// Prune deletes the records of peers last seen before the cutoff, unless they are still banned.
+// It returns the number of deleted records.
+func (s *Store) Prune(cutoff time.Time) (int, error) {
+	deleted := 0
+	now := time.Now()
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(peersBucket)
+		var stale [][]byte
+		if err := bkt.ForEach(func(k, v []byte) error {
+			r := &Record{}
+			if err := json.Unmarshal(v, r); err != nil {
+				return errors.Wrapf(err, "could not decode record of peer %s", string(k))
+			}
+			if r.LastSeen.Before(cutoff) && r.ActiveBan(now) == nil {
+				stale = append(stale, k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range stale {
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+		}
+		deleted = len(stale)
+		return nil
+	})
+	return deleted, err
+}
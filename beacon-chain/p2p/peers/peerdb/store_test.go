@@ -0,0 +1,72 @@
+package peerdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_SaveRecords(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewStore(dir)
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	records := []*Record{
+		{ID: "a", Address: "/ip4/127.0.0.1/tcp/13000", LastSeen: now, BadResponses: 2, Score: 1.5},
+		{ID: "b", LastSeen: now, Bans: []*peerdata.Ban{{Reason: "spam", Start: now}}},
+	}
+	require.NoError(t, s.SaveRecords(records))
+	require.ErrorContains(t, "peer record without id", s.SaveRecords([]*Record{{}}))
+	require.NoError(t, s.Close())
+
+	// Records survive reopening the database.
+	s, err = NewStore(dir)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	got, err := s.Records()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(got))
+	assert.DeepEqual(t, records, got)
+
+	// Saving a record again replaces it.
+	records[0].BadResponses = 0
+	require.NoError(t, s.SaveRecords(records[:1]))
+	got, err = s.Records()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(got))
+	assert.Equal(t, 0, got[0].BadResponses)
+}
+
+func TestStore_Prune(t *testing.T) {
+	s, err := NewStore(t.TempDir())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+	require.NoError(t, s.SaveRecords([]*Record{
+		{ID: "recent", LastSeen: now},
+		{ID: "stale", LastSeen: old},
+		{ID: "banned", LastSeen: old, Bans: []*peerdata.Ban{{Start: old}}},
+		{ID: "expired-ban", LastSeen: old, Bans: []*peerdata.Ban{{Start: old, Until: old.Add(time.Hour)}}},
+	}))
+
+	deleted, err := s.Prune(now.Add(-24 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	got, err := s.Records()
+	require.NoError(t, err)
+	ids := make(map[string]bool)
+	for _, r := range got {
+		ids[r.ID] = true
+	}
+	assert.DeepEqual(t, map[string]bool{"recent": true, "banned": true}, ids)
+}
@@ -0,0 +1,35 @@
+package peers
+
+import (
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/bandwidth"
+	"github.com/sirupsen/logrus"
+)
+
+// Bandwidth returns the accountant of the bandwidth used by peers.
+func (p *Status) Bandwidth() *bandwidth.Accountant {
+	return p.bandwidth
+}
+
+// EndBandwidthWindow closes the current bandwidth accounting window and penalizes the peers
+// that went over their quota during it. Peers going over quota too often are deemed bad.
+func (p *Status) EndBandwidthWindow() []*bandwidth.Violation {
+	violations := p.bandwidth.EndWindow()
+	penalized := make(map[peer.ID]bool, len(violations))
+	for _, v := range violations {
+		log.WithFields(logrus.Fields{
+			"peer":  v.Peer,
+			"class": v.Class,
+			"kind":  v.Kind,
+			"bytes": v.Load,
+			"quota": v.Quota,
+		}).Debug("Peer went over its bandwidth quota")
+		// A peer over both of its quotas is penalized once.
+		if penalized[v.Peer] {
+			continue
+		}
+		penalized[v.Peer] = true
+		p.scorers.BandwidthScorer().Increment(v.Peer)
+	}
+	return violations
+}
@@ -0,0 +1,43 @@
+package peers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/bandwidth"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStatus_EndBandwidthWindow(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BandwidthScorerConfig: &scorers.BandwidthScorerConfig{Threshold: 2},
+		},
+		BandwidthConfig: &bandwidth.Config{
+			Default: bandwidth.Quota{RPC: 100, Gossip: 100},
+		},
+	})
+	heavy, trusted := peer.ID("heavy"), peer.ID("trusted")
+	p.SetTrustedPeers([]peer.ID{trusted})
+
+	for i := 0; i < 2; i++ {
+		// Going over both quotas in a window is a single violation.
+		p.Bandwidth().RecordStream(heavy, "/eth2/beacon_chain/req/status/1/ssz_snappy", true, true, 200)
+		p.Bandwidth().RecordGossip(heavy, "/eth2/6a95a1a9/beacon_block/ssz_snappy", true, 200)
+		// Trusted peers are not limited by default.
+		p.Bandwidth().RecordGossip(trusted, "/eth2/6a95a1a9/beacon_block/ssz_snappy", true, 200)
+
+		violations := p.EndBandwidthWindow()
+		require.Equal(t, 2, len(violations))
+		count, err := p.Scorers().BandwidthScorer().Count(heavy)
+		require.NoError(t, err)
+		assert.Equal(t, i+1, count)
+	}
+	assert.Equal(t, true, p.IsBad(heavy))
+	assert.Equal(t, false, p.IsBad(trusted))
+}
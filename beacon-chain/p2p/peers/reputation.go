@@ -0,0 +1,214 @@
+package peers
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdb"
+	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+)
+
+// maxBanHistory is the number of bans kept per peer, older ones are dropped.
+const maxBanHistory = 16
+
+// Ban bans the given peer for the given duration, a zero duration bans the peer indefinitely.
+// Banned peers are considered bad regardless of their scores or of being trusted.
+func (p *Status) Ban(pid peer.ID, duration time.Duration, reason string) *peerdata.Ban {
+	p.store.Lock()
+	now := prysmTime.Now()
+	peerData := p.store.PeerDataGetOrCreate(pid)
+	// A new ban replaces the one in force.
+	if b := peerData.ActiveBan(now); b != nil {
+		b.Lifted = now
+	}
+	ban := &peerdata.Ban{Reason: reason, Start: now}
+	if duration > 0 {
+		ban.Until = now.Add(duration)
+	}
+	peerData.Bans = append(peerData.Bans, ban)
+	if len(peerData.Bans) > maxBanHistory {
+		peerData.Bans = peerData.Bans[len(peerData.Bans)-maxBanHistory:]
+	}
+	record, onBanChange := p.recordNoLock(pid, peerData), p.onBanChange
+	p.store.Unlock()
+
+	if onBanChange != nil {
+		onBanChange(record)
+	}
+	return ban
+}
+
+// Unban lifts the ban in force for the given peer, if any, and resets its bad responses count
+// so that the peer can be dialed again. It returns false if the peer was not banned.
+func (p *Status) Unban(pid peer.ID) (bool, error) {
+	p.store.Lock()
+	peerData, ok := p.store.PeerData(pid)
+	if !ok {
+		p.store.Unlock()
+		return false, peerdata.ErrPeerUnknown
+	}
+	now := prysmTime.Now()
+	b := peerData.ActiveBan(now)
+	if b == nil {
+		p.store.Unlock()
+		return false, nil
+	}
+	b.Lifted = now
+	peerData.BadResponses = 0
+	record, onBanChange := p.recordNoLock(pid, peerData), p.onBanChange
+	p.store.Unlock()
+
+	if onBanChange != nil {
+		onBanChange(record)
+	}
+	return true, nil
+}
+
+// OnBanChange sets the function called with the record of a peer each time it is banned or unbanned,
+// so that bans can be persisted right away.
+func (p *Status) OnBanChange(f func(*peerdb.Record)) {
+	p.store.Lock()
+	defer p.store.Unlock()
+	p.onBanChange = f
+}
+
+// IsBanned checks whether the given peer is currently banned.
+func (p *Status) IsBanned(pid peer.ID) bool {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	return p.isBanned(pid)
+}
+
+// isBanned is the lock-free version of IsBanned.
+func (p *Status) isBanned(pid peer.ID) bool {
+	peerData, ok := p.store.PeerData(pid)
+	return ok && peerData.ActiveBan(prysmTime.Now()) != nil
+}
+
+// LastSeen returns the last time the given peer was connected.
+// This will error if the peer does not exist.
+func (p *Status) LastSeen(pid peer.ID) (time.Time, error) {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	if peerData, ok := p.store.PeerData(pid); ok {
+		return peerData.LastSeen, nil
+	}
+	return time.Time{}, peerdata.ErrPeerUnknown
+}
+
+// Records exports the reputation of all known peers that can be dialed or were banned,
+// to be persisted in the peer database.
+func (p *Status) Records() []*peerdb.Record {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	records := make([]*peerdb.Record, 0, len(p.store.Peers()))
+	for pid, peerData := range p.store.Peers() {
+		if peerData.Address == nil && len(peerData.Bans) == 0 {
+			continue
+		}
+		records = append(records, p.recordNoLock(pid, peerData))
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ID < records[j].ID
+	})
+	return records
+}
+
+// recordNoLock exports the reputation of a peer, the store lock must be held.
+func (p *Status) recordNoLock(pid peer.ID, peerData *peerdata.PeerData) *peerdb.Record {
+	r := &peerdb.Record{
+		ID:               pid.String(),
+		LastSeen:         peerData.LastSeen,
+		BadResponses:     peerData.BadResponses,
+		ProcessedBlocks:  peerData.ProcessedBlocks,
+		GossipScore:      peerData.GossipScore,
+		BehaviourPenalty: peerData.BehaviourPenalty,
+		Score:            p.scorers.ScoreNoLock(pid),
+		Bans:             make([]*peerdata.Ban, 0, len(peerData.Bans)),
+	}
+	if peerData.Address != nil {
+		r.Address = peerData.Address.String()
+	}
+	if peerData.Enr != nil {
+		if enc, err := rlp.EncodeToBytes(peerData.Enr); err == nil {
+			r.ENR = enc
+		}
+	}
+	for _, b := range peerData.Bans {
+		ban := *b
+		r.Bans = append(r.Bans, &ban)
+	}
+	return r
+}
+
+// Restore loads persisted peer records into the store, skipping peers that are already known.
+// Banned peers are restored first, then peers with the highest scores, up to the store capacity.
+// It returns the number of restored records.
+func (p *Status) Restore(records []*peerdb.Record) int {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	now := prysmTime.Now()
+	sorted := make([]*peerdb.Record, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		bannedI, bannedJ := sorted[i].ActiveBan(now) != nil, sorted[j].ActiveBan(now) != nil
+		if bannedI != bannedJ {
+			return bannedI
+		}
+		return sorted[i].Score > sorted[j].Score
+	})
+
+	restored := 0
+	for _, r := range sorted {
+		if len(p.store.Peers()) >= p.store.Config().MaxPeers {
+			break
+		}
+		pid, err := peer.Decode(r.ID)
+		if err != nil {
+			log.WithError(err).WithField("peerID", r.ID).Debug("Could not decode persisted peer id")
+			continue
+		}
+		if _, ok := p.store.PeerData(pid); ok {
+			continue
+		}
+		peerData := &peerdata.PeerData{
+			Direction:        network.DirUnknown,
+			ConnState:        PeerDisconnected,
+			LastSeen:         r.LastSeen,
+			Bans:             r.Bans,
+			BadResponses:     r.BadResponses,
+			ProcessedBlocks:  r.ProcessedBlocks,
+			GossipScore:      r.GossipScore,
+			BehaviourPenalty: r.BehaviourPenalty,
+		}
+		if r.Address != "" {
+			address, err := ma.NewMultiaddr(r.Address)
+			if err != nil {
+				log.WithError(err).WithField("peerID", r.ID).Debug("Could not decode persisted peer address")
+				continue
+			}
+			peerData.Address = address
+		}
+		if len(r.ENR) > 0 {
+			record := &enr.Record{}
+			if err := rlp.DecodeBytes(r.ENR, record); err == nil {
+				peerData.Enr = record
+			}
+		}
+		p.store.SetPeerData(pid, peerData)
+		if peerData.Address != nil {
+			p.addIpToTracker(pid)
+		}
+		restored++
+	}
+	return restored
+}
@@ -0,0 +1,137 @@
+package peers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdb"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStatus_BanUnban(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	id, err := peer.Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	require.NoError(t, err)
+
+	_, err = p.Unban(id)
+	require.ErrorIs(t, err, peerdata.ErrPeerUnknown)
+
+	// Bans take precedence over trusted peers.
+	p.SetTrustedPeers([]peer.ID{id})
+	assert.Equal(t, false, p.IsBad(id))
+	ban := p.Ban(id, 0, "misbehaving")
+	assert.Equal(t, true, ban.Until.IsZero())
+	assert.Equal(t, true, p.IsBanned(id))
+	assert.Equal(t, true, p.IsBad(id))
+
+	for i := 0; i < 10; i++ {
+		p.Scorers().BadResponsesScorer().Increment(id)
+	}
+	lifted, err := p.Unban(id)
+	require.NoError(t, err)
+	assert.Equal(t, true, lifted)
+	assert.Equal(t, false, p.IsBanned(id))
+	assert.Equal(t, false, p.IsBad(id))
+	count, err := p.Scorers().BadResponsesScorer().Count(id)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	lifted, err = p.Unban(id)
+	require.NoError(t, err)
+	assert.Equal(t, false, lifted)
+
+	// Temporary bans expire on their own.
+	p.Ban(id, time.Millisecond, "")
+	time.Sleep(2 * time.Millisecond)
+	assert.Equal(t, false, p.IsBanned(id))
+}
+
+func TestStatus_RecordsRestore(t *testing.T) {
+	cfg := &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{Threshold: 5},
+		},
+	}
+	p := peers.NewStatus(context.Background(), cfg)
+
+	good, err := peer.Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	require.NoError(t, err)
+	bad, err := peer.Decode("16Uiu2HAm4HgJ9N1o222xK61o7LSgToYWoAy1wNTJRkh9gLZapVAy")
+	require.NoError(t, err)
+	goodAddr, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	badAddr, err := ma.NewMultiaddr("/ip4/213.202.254.181/tcp/13000")
+	require.NoError(t, err)
+
+	p.Add(nil, good, goodAddr, network.DirOutbound)
+	p.SetConnectionState(good, peers.PeerConnected)
+	p.Scorers().BadResponsesScorer().Increment(good)
+	p.Add(nil, bad, badAddr, network.DirInbound)
+	p.Ban(bad, time.Hour, "spam")
+	// Peers without an address nor bans are not persisted.
+	p.SetNextValidTime("unknown", time.Now())
+
+	records := p.Records()
+	require.Equal(t, 2, len(records))
+
+	restored := peers.NewStatus(context.Background(), cfg)
+	assert.Equal(t, 2, restored.Restore(records))
+	assert.Equal(t, 0, restored.Restore(records))
+
+	addr, err := restored.Address(good)
+	require.NoError(t, err)
+	assert.Equal(t, goodAddr.String(), addr.String())
+	lastSeen, err := restored.LastSeen(good)
+	require.NoError(t, err)
+	assert.Equal(t, false, lastSeen.IsZero())
+	count, err := restored.Scorers().BadResponsesScorer().Count(good)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	state, err := restored.ConnectionState(good)
+	require.NoError(t, err)
+	assert.Equal(t, peers.PeerDisconnected, state)
+
+	assert.Equal(t, true, restored.IsBanned(bad))
+	assert.Equal(t, true, restored.IsBad(bad))
+}
+
+func TestStatus_OnBanChange(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	id, err := peer.Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	require.NoError(t, err)
+	var records []*peerdb.Record
+	p.OnBanChange(func(r *peerdb.Record) {
+		records = append(records, r)
+	})
+
+	p.Ban(id, time.Hour, "spam")
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, id.String(), records[0].ID)
+	assert.NotNil(t, records[0].ActiveBan(time.Now()))
+
+	lifted, err := p.Unban(id)
+	require.NoError(t, err)
+	require.Equal(t, true, lifted)
+	require.Equal(t, 2, len(records))
+	assert.Equal(t, true, records[1].ActiveBan(time.Now()) == nil)
+
+	// Nothing changes when the peer is not banned.
+	_, err = p.Unban(id)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(records))
+}
@@ -0,0 +1,153 @@
+package scorers
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+)
+
+var _ Scorer = (*BandwidthScorer)(nil)
+
+const (
+	// DefaultBandwidthThreshold defines how many windows over quota to tolerate before peer is deemed bad.
+	DefaultBandwidthThreshold = 3
+	// DefaultBandwidthDecayInterval defines how often to decay previous statistics.
+	// Every interval quota violations counter will be decremented by 1.
+	DefaultBandwidthDecayInterval = time.Hour
+	// DefaultBandwidthPenaltyFactor defines the penalty factor applied to a peer based on their
+	// quota violations count.
+	DefaultBandwidthPenaltyFactor = 10
+)
+
+// BandwidthScorer represents the scoring service of peers going over their bandwidth quota.
+type BandwidthScorer struct {
+	config *BandwidthScorerConfig
+	store  *peerdata.Store
+}
+
+// BandwidthScorerConfig holds configuration parameters for bandwidth scoring service.
+type BandwidthScorerConfig struct {
+	// Threshold specifies number of windows over quota tolerated, before peer is banned.
+	Threshold int
+	// DecayInterval specifies how often quota violations stats should be decayed.
+	DecayInterval time.Duration
+}
+
+// newBandwidthScorer creates new bandwidth scoring service.
+func newBandwidthScorer(store *peerdata.Store, config *BandwidthScorerConfig) *BandwidthScorer {
+	if config == nil {
+		config = &BandwidthScorerConfig{}
+	}
+	scorer := &BandwidthScorer{
+		config: config,
+		store:  store,
+	}
+	if scorer.config.Threshold == 0 {
+		scorer.config.Threshold = DefaultBandwidthThreshold
+	}
+	if scorer.config.DecayInterval == 0 {
+		scorer.config.DecayInterval = DefaultBandwidthDecayInterval
+	}
+	return scorer
+}
+
+// Score returns score (penalty) of quota violations of a peer.
+func (s *BandwidthScorer) Score(pid peer.ID) float64 {
+	s.store.RLock()
+	defer s.store.RUnlock()
+	return s.scoreNoLock(pid)
+}
+
+// scoreNoLock is a lock-free version of Score.
+func (s *BandwidthScorer) scoreNoLock(pid peer.ID) float64 {
+	if s.isBadPeerNoLock(pid) {
+		return BadPeerScore
+	}
+	score := float64(0)
+	peerData, ok := s.store.PeerData(pid)
+	if !ok {
+		return score
+	}
+	if peerData.QuotaViolations > 0 {
+		score = float64(peerData.QuotaViolations) / float64(s.config.Threshold)
+		// Since score represents a penalty, negate it and multiply
+		// it by a factor.
+		score *= -DefaultBandwidthPenaltyFactor
+	}
+	return score
+}
+
+// Params exposes scorer's parameters.
+func (s *BandwidthScorer) Params() *BandwidthScorerConfig {
+	return s.config
+}
+
+// Count obtains the number of windows during which the given remote peer went over its quota.
+func (s *BandwidthScorer) Count(pid peer.ID) (int, error) {
+	s.store.RLock()
+	defer s.store.RUnlock()
+	if peerData, ok := s.store.PeerData(pid); ok {
+		return peerData.QuotaViolations, nil
+	}
+	return -1, peerdata.ErrPeerUnknown
+}
+
+// Increment increments the number of quota violations of the given remote peer.
+// If peer doesn't exist it is added to the store.
+func (s *BandwidthScorer) Increment(pid peer.ID) {
+	s.store.Lock()
+	defer s.store.Unlock()
+
+	peerData, ok := s.store.PeerData(pid)
+	if !ok {
+		s.store.SetPeerData(pid, &peerdata.PeerData{
+			QuotaViolations: 1,
+		})
+		return
+	}
+	peerData.QuotaViolations++
+}
+
+// IsBadPeer states if the peer is to be considered bad.
+// If the peer is unknown this will return `false`, which makes using this function easier than returning an error.
+func (s *BandwidthScorer) IsBadPeer(pid peer.ID) bool {
+	s.store.RLock()
+	defer s.store.RUnlock()
+	return s.isBadPeerNoLock(pid)
+}
+
+// isBadPeerNoLock is lock-free version of IsBadPeer.
+func (s *BandwidthScorer) isBadPeerNoLock(pid peer.ID) bool {
+	if peerData, ok := s.store.PeerData(pid); ok {
+		return peerData.QuotaViolations >= s.config.Threshold
+	}
+	return false
+}
+
+// BadPeers returns the peers that are considered bad.
+func (s *BandwidthScorer) BadPeers() []peer.ID {
+	s.store.RLock()
+	defer s.store.RUnlock()
+
+	badPeers := make([]peer.ID, 0)
+	for pid := range s.store.Peers() {
+		if s.isBadPeerNoLock(pid) {
+			badPeers = append(badPeers, pid)
+		}
+	}
+	return badPeers
+}
+
+// Decay reduces the quota violations of all peers, so that a peer that stayed within its quota
+// long enough is given another chance.
+func (s *BandwidthScorer) Decay() {
+	s.store.Lock()
+	defer s.store.Unlock()
+
+	for _, peerData := range s.store.Peers() {
+		if peerData.QuotaViolations > 0 {
+			peerData.QuotaViolations--
+		}
+	}
+}
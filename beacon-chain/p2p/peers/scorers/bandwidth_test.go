@@ -0,0 +1,52 @@
+package scorers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestScorers_Bandwidth_Score(t *testing.T) {
+	const pid = "peer1"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peerStatuses := peers.NewStatus(ctx, &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BandwidthScorerConfig: &scorers.BandwidthScorerConfig{
+				Threshold: 2,
+			},
+		},
+	})
+	scorer := peerStatuses.Scorers().BandwidthScorer()
+
+	assert.Equal(t, 0., scorer.Score(pid), "Unexpected score for unregistered peer")
+	_, err := scorer.Count(pid)
+	assert.ErrorContains(t, peerdata.ErrPeerUnknown.Error(), err)
+
+	scorer.Increment(pid)
+	assert.Equal(t, false, scorer.IsBadPeer(pid))
+	assert.Equal(t, float64(-5), scorer.Score(pid))
+	// A zero weight scorer does not affect the overall score.
+	assert.Equal(t, 0., peerStatuses.Scorers().Score(pid))
+	assert.Equal(t, false, peerStatuses.Scorers().IsBadPeer(pid))
+
+	scorer.Increment(pid)
+	assert.Equal(t, true, scorer.IsBadPeer(pid))
+	assert.Equal(t, -100.0, scorer.Score(pid))
+	assert.Equal(t, true, peerStatuses.Scorers().IsBadPeer(pid))
+	assert.DeepEqual(t, 1, len(scorer.BadPeers()))
+
+	scorer.Decay()
+	count, err := scorer.Count(pid)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, false, peerStatuses.Scorers().IsBadPeer(pid))
+}
@@ -36,6 +36,7 @@ type Service struct {
 		blockProviderScorer *BlockProviderScorer
 		peerStatusScorer    *PeerStatusScorer
 		gossipScorer        *GossipScorer
+		bandwidthScorer     *BandwidthScorer
 	}
 	weights     map[Scorer]float64
 	totalWeight float64
@@ -47,6 +48,7 @@ type Config struct {
 	BlockProviderScorerConfig *BlockProviderScorerConfig
 	PeerStatusScorerConfig    *PeerStatusScorerConfig
 	GossipScorerConfig        *GossipScorerConfig
+	BandwidthScorerConfig     *BandwidthScorerConfig
 }
 
 // NewService provides fully initialized peer scoring service.
@@ -65,6 +67,9 @@ func NewService(ctx context.Context, store *peerdata.Store, config *Config) *Ser
 	s.setScorerWeight(s.scorers.peerStatusScorer, 0.3)
 	s.scorers.gossipScorer = newGossipScorer(store, config.GossipScorerConfig)
 	s.setScorerWeight(s.scorers.gossipScorer, 0.4)
+	// Peers going over their bandwidth quota too often are deemed bad, without otherwise affecting their score.
+	s.scorers.bandwidthScorer = newBandwidthScorer(store, config.BandwidthScorerConfig)
+	s.setScorerWeight(s.scorers.bandwidthScorer, 0.0)
 
 	// Start background tasks.
 	go s.loop(ctx)
@@ -92,6 +97,11 @@ func (s *Service) GossipScorer() *GossipScorer {
 	return s.scorers.gossipScorer
 }
 
+// BandwidthScorer exposes the bandwidth quota scoring service.
+func (s *Service) BandwidthScorer() *BandwidthScorer {
+	return s.scorers.bandwidthScorer
+}
+
 // ActiveScorersCount returns number of scorers that can affect score (have non-zero weight).
 func (s *Service) ActiveScorersCount() int {
 	cnt := 0
@@ -120,6 +130,7 @@ func (s *Service) ScoreNoLock(pid peer.ID) float64 {
 	score += s.scorers.blockProviderScorer.scoreNoLock(pid) * s.scorerWeight(s.scorers.blockProviderScorer)
 	score += s.scorers.peerStatusScorer.scoreNoLock(pid) * s.scorerWeight(s.scorers.peerStatusScorer)
 	score += s.scorers.gossipScorer.scoreNoLock(pid) * s.scorerWeight(s.scorers.gossipScorer)
+	score += s.scorers.bandwidthScorer.scoreNoLock(pid) * s.scorerWeight(s.scorers.bandwidthScorer)
 	return math.Round(score*ScoreRoundingFactor) / ScoreRoundingFactor
 }
 
@@ -138,6 +149,9 @@ func (s *Service) IsBadPeerNoLock(pid peer.ID) bool {
 	if s.scorers.peerStatusScorer.isBadPeerNoLock(pid) {
 		return true
 	}
+	if s.scorers.bandwidthScorer.isBadPeerNoLock(pid) {
+		return true
+	}
 	if features.Get().EnablePeerScorer {
 		if s.scorers.gossipScorer.isBadPeerNoLock(pid) {
 			return true
@@ -179,6 +193,8 @@ func (s *Service) loop(ctx context.Context) {
 	defer decayBadResponsesStats.Stop()
 	decayBlockProviderStats := time.NewTicker(s.scorers.blockProviderScorer.Params().DecayInterval)
 	defer decayBlockProviderStats.Stop()
+	decayBandwidthStats := time.NewTicker(s.scorers.bandwidthScorer.Params().DecayInterval)
+	defer decayBandwidthStats.Stop()
 
 	for {
 		select {
@@ -194,6 +210,12 @@ func (s *Service) loop(ctx context.Context) {
 				return
 			}
 			s.scorers.blockProviderScorer.Decay()
+		case <-decayBandwidthStats.C:
+			// Exit early if context is canceled.
+			if ctx.Err() != nil {
+				return
+			}
+			s.scorers.bandwidthScorer.Decay()
 		case <-ctx.Done():
 			return
 		}
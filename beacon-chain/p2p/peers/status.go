@@ -35,7 +35,9 @@ import (
 	ma "github.com/multiformats/go-multiaddr"
 	manet "github.com/multiformats/go-multiaddr/net"
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/bandwidth"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdb"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -91,6 +93,9 @@ type Status struct {
 	store     *peerdata.Store
 	ipTracker map[string]uint64
 	rand      *rand.Rand
+	bandwidth *bandwidth.Accountant
+	// onBanChange is called with the record of a peer when it is banned or unbanned.
+	onBanChange func(*peerdb.Record)
 }
 
 // StatusConfig represents peer status service params.
@@ -99,6 +104,8 @@ type StatusConfig struct {
 	PeerLimit int
 	// ScorerParams holds peer scorer configuration params.
 	ScorerParams *scorers.Config
+	// BandwidthConfig holds the bandwidth quotas of peers, the defaults are used when nil.
+	BandwidthConfig *bandwidth.Config
 }
 
 // NewStatus creates a new status entity.
@@ -106,7 +113,7 @@ func NewStatus(ctx context.Context, config *StatusConfig) *Status {
 	store := peerdata.NewStore(ctx, &peerdata.StoreConfig{
 		MaxPeers: maxLimitBuffer + config.PeerLimit,
 	})
-	return &Status{
+	p := &Status{
 		ctx:       ctx,
 		store:     store,
 		scorers:   scorers.NewService(ctx, store, config.ScorerParams),
@@ -115,6 +122,8 @@ func NewStatus(ctx context.Context, config *StatusConfig) *Status {
 		// It is ok to use deterministic generator, no need for true entropy.
 		rand: rand.NewDeterministicGenerator(),
 	}
+	p.bandwidth = bandwidth.NewAccountant(config.BandwidthConfig, p.IsTrustedPeers)
+	return p
 }
 
 // Scorers exposes peer scoring management service.
@@ -306,6 +315,9 @@ func (p *Status) SetConnectionState(pid peer.ID, state peerdata.PeerConnectionSt
 	defer p.store.Unlock()
 
 	peerData := p.store.PeerDataGetOrCreate(pid)
+	if state == PeerConnected || peerData.ConnState == PeerConnected {
+		peerData.LastSeen = prysmTime.Now()
+	}
 	peerData.ConnState = state
 }
 
@@ -343,6 +355,10 @@ func (p *Status) IsBad(pid peer.ID) bool {
 
 // isBad is the lock-free version of IsBad.
 func (p *Status) isBad(pid peer.ID) bool {
+	// An explicit ban takes precedence over the trusted peer set.
+	if p.isBanned(pid) {
+		return true
+	}
 	// Do not disconnect from trusted peers.
 	if p.store.IsTrustedPeer(pid) {
 		return false
@@ -336,7 +336,7 @@ func TestService_MonitorsStateForkUpdates(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 	cs := startup.NewClockSynchronizer()
-	s, err := NewService(ctx, &Config{ClockWaiter: cs})
+	s, err := NewService(ctx, &Config{DataDir: t.TempDir(), ClockWaiter: cs})
 	require.NoError(t, err)
 
 	require.Equal(t, false, s.isInitialized())
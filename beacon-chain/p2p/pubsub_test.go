@@ -19,6 +19,7 @@ import (
 func TestService_PublishToTopicConcurrentMapWrite(t *testing.T) {
 	cs := startup.NewClockSynchronizer()
 	s, err := NewService(context.Background(), &Config{
+		DataDir:       t.TempDir(),
 		StateNotifier: &mock.MockStateNotifier{},
 		ClockWaiter:   cs,
 	})
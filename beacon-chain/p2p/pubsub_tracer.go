@@ -6,6 +6,8 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/gossiptrace"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/bandwidth"
 )
 
 var _ = pubsub.RawTracer(gossipTracer{})
@@ -20,9 +22,12 @@ const (
 )
 
 // This tracer is used to implement metrics collection for messages received
-// and broadcasted through gossipsub.
+// and broadcasted through gossipsub, to account the gossip bandwidth of peers,
+// and to record them to disk when gossip tracing is enabled.
 type gossipTracer struct {
-	host host.Host
+	host      host.Host
+	trace     *gossiptrace.Recorder
+	bandwidth *bandwidth.Accountant
 }
 
 // AddPeer .
@@ -58,21 +63,27 @@ func (g gossipTracer) Prune(p peer.ID, topic string) {
 // ValidateMessage .
 func (g gossipTracer) ValidateMessage(msg *pubsub.Message) {
 	pubsubMessageValidate.WithLabelValues(*msg.Topic).Inc()
+	g.recordReceived(msg)
+	g.trace.Validate(msg)
 }
 
 // DeliverMessage .
 func (g gossipTracer) DeliverMessage(msg *pubsub.Message) {
 	pubsubMessageDeliver.WithLabelValues(*msg.Topic).Inc()
+	g.trace.Deliver(msg)
 }
 
 // RejectMessage .
 func (g gossipTracer) RejectMessage(msg *pubsub.Message, reason string) {
 	pubsubMessageReject.WithLabelValues(*msg.Topic, reason).Inc()
+	g.trace.Reject(msg, reason)
 }
 
 // DuplicateMessage .
 func (g gossipTracer) DuplicateMessage(msg *pubsub.Message) {
 	pubsubMessageDuplicate.WithLabelValues(*msg.Topic).Inc()
+	g.recordReceived(msg)
+	g.trace.Duplicate(msg)
 }
 
 // UndeliverableMessage .
@@ -94,6 +105,9 @@ func (g gossipTracer) RecvRPC(rpc *pubsub.RPC) {
 // SendRPC .
 func (g gossipTracer) SendRPC(rpc *pubsub.RPC, p peer.ID) {
 	g.setMetricFromRPC(send, pubsubRPCSubSent, pubsubRPCPubSent, pubsubRPCSent, rpc)
+	for _, msg := range rpc.Publish {
+		g.bandwidth.RecordGossip(p, msg.GetTopic(), false /* in */, msg.Size())
+	}
 }
 
 // DropRPC .
@@ -118,3 +132,11 @@ func (g gossipTracer) setMetricFromRPC(act action, subCtr prometheus.Counter, pu
 		pubCtr.WithLabelValues(*msg.Topic).Inc()
 	}
 }
+
+// recordReceived accounts a message received from a peer, whether or not it was already seen.
+func (g gossipTracer) recordReceived(msg *pubsub.Message) {
+	if msg.Local {
+		return
+	}
+	g.bandwidth.RecordGossip(msg.ReceivedFrom, msg.GetTopic(), true /* in */, msg.Size())
+}
@@ -41,8 +41,9 @@ func (s *Service) Send(ctx context.Context, message interface{}, baseTopic strin
 		tracing.AnnotateError(span, err)
 		return nil, err
 	}
-	// do not encode anything if we are sending a metadata request
-	if baseTopic != RPCMetaDataTopicV1 && baseTopic != RPCMetaDataTopicV2 {
+	stream = s.peers.Bandwidth().Stream(stream, false /* inbound */)
+	// do not encode anything if we are sending a request without payload, like metadata
+	if !EmptyRequestTopics[baseTopic] {
 		castedMsg, ok := message.(ssz.Marshaler)
 		if !ok {
 			return nil, errors.Errorf("%T does not support the ssz marshaller interface", message)
@@ -20,8 +20,11 @@ import (
 	"github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/async"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/discovery"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/encoder"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/gossiptrace"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdb"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
@@ -61,6 +64,9 @@ type Service struct {
 	cancel                context.CancelFunc
 	cfg                   *Config
 	peers                 *peers.Status
+	peerDB                *peerdb.Store
+	knownPeers            []*peerdb.Record
+	gossipTrace           *gossiptrace.Recorder
 	addrFilter            *multiaddr.Filters
 	ipLimiter             *leakybucket.Collector
 	privKey               *ecdsa.PrivateKey
@@ -72,6 +78,8 @@ type Service struct {
 	subnetsLockLock       sync.Mutex // Lock access to subnetsLock
 	initializationLock    sync.Mutex
 	dv5Listener           Listener
+	discoveryMix          *discovery.Mix
+	mdnsNode              *enode.LocalNode
 	startupErr            error
 	ctx                   context.Context
 	host                  host.Host
@@ -135,6 +143,27 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 
 	s.host = h
 
+	if cfg.GossipTrace != nil {
+		s.gossipTrace, err = gossiptrace.NewRecorder(*cfg.GossipTrace)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create gossip trace recorder")
+		}
+		log.WithField("dir", cfg.GossipTrace.Dir).Info("Recording gossip messages")
+	}
+
+	// The peer status is created before the pubsub options, as the gossip tracer
+	// accounts the bandwidth of peers with it.
+	s.peers = peers.NewStatus(ctx, &peers.StatusConfig{
+		PeerLimit: int(s.cfg.MaxPeers),
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold:     maxBadResponses,
+				DecayInterval: time.Hour,
+			},
+		},
+		BandwidthConfig: cfg.BandwidthQuotas,
+	})
+
 	// Gossipsub registration is done before we add in any new peers
 	// due to libp2p's gossipsub implementation not taking into
 	// account previously added peers when creating the gossipsub
@@ -155,15 +184,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 
 	s.pubsub = gs
 
-	s.peers = peers.NewStatus(ctx, &peers.StatusConfig{
-		PeerLimit: int(s.cfg.MaxPeers),
-		ScorerParams: &scorers.Config{
-			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
-				Threshold:     maxBadResponses,
-				DecayInterval: time.Hour,
-			},
-		},
-	})
+	s.openPeerDB()
 
 	// Initialize Data maps.
 	types.InitializeDataMaps()
@@ -209,7 +230,12 @@ func (s *Service) Start() {
 			return
 		}
 		s.dv5Listener = listener
-		go s.listenForNewNodes()
+	}
+
+	if err := s.startDiscovery(); err != nil {
+		log.WithError(err).Error("Could not start peer discovery")
+		s.startupErr = err
+		return
 	}
 
 	s.started = true
@@ -224,6 +250,7 @@ func (s *Service) Start() {
 		s.peers.SetTrustedPeers(pids)
 		s.connectWithAllTrustedPeers(addrs)
 	}
+	s.dialKnownPeers()
 	// Initialize metadata according to the
 	// current epoch.
 	s.RefreshENR()
@@ -233,6 +260,10 @@ func (s *Service) Start() {
 		ensurePeerConnections(s.ctx, s.host, s.peers, relayNodes...)
 	})
 	async.RunEvery(s.ctx, 30*time.Minute, s.Peers().Prune)
+	async.RunEvery(s.ctx, peerDBSaveInterval, s.savePeerDB)
+	async.RunEvery(s.ctx, s.peers.Bandwidth().Window(), func() {
+		s.peers.EndBandwidthWindow()
+	})
 	async.RunEvery(s.ctx, time.Duration(params.BeaconConfig().RespTimeout)*time.Second, s.updateMetrics)
 	async.RunEvery(s.ctx, refreshRate, s.RefreshENR)
 	async.RunEvery(s.ctx, 1*time.Minute, func() {
@@ -279,10 +310,16 @@ func (s *Service) Start() {
 func (s *Service) Stop() error {
 	defer s.cancel()
 	s.started = false
+	if s.discoveryMix != nil {
+		s.discoveryMix.Close()
+	}
 	if s.dv5Listener != nil {
 		s.dv5Listener.Close()
 	}
-	return nil
+	if err := s.gossipTrace.Close(); err != nil {
+		log.WithError(err).Error("Could not close gossip trace recorder")
+	}
+	return s.closePeerDB()
 }
 
 // Status of the p2p service. Will return an error if the service is considered unhealthy to
@@ -327,7 +364,10 @@ func (s *Service) Host() host.Host {
 // SetStreamHandler sets the protocol handler on the p2p host multiplexer.
 // This method is a pass through to libp2pcore.Host.SetStreamHandler.
 func (s *Service) SetStreamHandler(topic string, handler network.StreamHandler) {
-	s.host.SetStreamHandler(protocol.ID(topic), handler)
+	accountant := s.peers.Bandwidth()
+	s.host.SetStreamHandler(protocol.ID(topic), func(stream network.Stream) {
+		handler(accountant.Stream(stream, true /* inbound */))
+	})
 }
 
 // PeerID returns the Peer ID of the local peer.
@@ -413,6 +453,7 @@ func (s *Service) awaitStateInitialized() {
 		log.WithError(err).Fatal("failed to receive initial genesis data")
 	}
 	s.genesisTime = clock.GenesisTime()
+	s.gossipTrace.SetGenesisTime(s.genesisTime)
 	gvr := clock.GenesisValidatorsRoot()
 	s.genesisValidatorsRoot = gvr[:]
 	_, err = s.currentForkDigest() // initialize fork digest cache
@@ -59,13 +59,13 @@ func (s *Service) FindPeersWithSubnet(ctx context.Context, topic string,
 
 	span.AddAttributes(trace.Int64Attribute("index", int64(index))) // lint:ignore uintcast -- It's safe to do this for tracing.
 
-	if s.dv5Listener == nil {
+	iterator := s.discoveryIterator()
+	if iterator == nil {
 		// return if discovery isn't set
 		return false, nil
 	}
 
 	topic += s.Encoding().ProtocolSuffix()
-	iterator := s.dv5Listener.RandomNodes()
 	defer iterator.Close()
 	switch {
 	case strings.Contains(topic, GossipAttestationMessage):
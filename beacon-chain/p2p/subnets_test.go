@@ -87,6 +87,7 @@ func TestStartDiscV5_FindPeersWithSubnet(t *testing.T) {
 	for i := 1; i <= 3; i++ {
 		subnet := uint64(i)
 		service, err := NewService(ctx, &Config{
+			DataDir:              t.TempDir(),
 			Discv5BootStrapAddrs: []string{bootNodeENR},
 			MaxPeers:             30,
 			UDPPort:              uint(2000 + i),
@@ -139,6 +140,7 @@ func TestStartDiscV5_FindPeersWithSubnet(t *testing.T) {
 		QUICPort:             3010,
 	}
 
+	cfg.DataDir = t.TempDir()
 	service, err := NewService(ctx, cfg)
 	require.NoError(t, err)
 
@@ -35,6 +35,8 @@ import (
 // with the main p2p package.
 const metatadataV1Topic = "/eth2/beacon_chain/req/metadata/1"
 const metatadataV2Topic = "/eth2/beacon_chain/req/metadata/2"
+const lightClientFinalityUpdateTopic = "/eth2/beacon_chain/req/light_client_finality_update/1"
+const lightClientOptimisticUpdateTopic = "/eth2/beacon_chain/req/light_client_optimistic_update/1"
 
 // TestP2P represents a p2p implementation that can be used for testing.
 type TestP2P struct {
@@ -319,7 +321,7 @@ func (p *TestP2P) Send(ctx context.Context, msg interface{}, topic string, pid p
 		return nil, err
 	}
 
-	if topic != metatadataV1Topic && topic != metatadataV2Topic {
+	if topic != metatadataV1Topic && topic != metatadataV2Topic && topic != lightClientFinalityUpdateTopic && topic != lightClientOptimisticUpdateTopic {
 		castedMsg, ok := msg.(ssz.Marshaler)
 		if !ok {
 			p.t.Fatalf("%T doesn't support ssz marshaler", msg)
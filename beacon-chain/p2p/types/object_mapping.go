@@ -7,8 +7,10 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/wrapper"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/metadata"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 )
 
 func init() {
@@ -33,6 +35,18 @@ var (
 	// AggregateAttestationMap maps the fork-version to the underlying data type for that
 	// particular fork period.
 	AggregateAttestationMap map[[4]byte]func() (ethpb.SignedAggregateAttAndProof, error)
+	// LightClientBootstrapMap maps the fork-version to the underlying data type for that
+	// particular fork period.
+	LightClientBootstrapMap map[[4]byte]func() (*ethpbv2.LightClientBootstrap, error)
+	// LightClientUpdateMap maps the fork-version to the underlying data type for that
+	// particular fork period.
+	LightClientUpdateMap map[[4]byte]func() (*ethpbv2.LightClientUpdate, error)
+	// LightClientFinalityUpdateMap maps the fork-version to the underlying data type for that
+	// particular fork period.
+	LightClientFinalityUpdateMap map[[4]byte]func() (*ethpbv2.LightClientFinalityUpdate, error)
+	// LightClientOptimisticUpdateMap maps the fork-version to the underlying data type for that
+	// particular fork period.
+	LightClientOptimisticUpdateMap map[[4]byte]func() (*ethpbv2.LightClientOptimisticUpdate, error)
 )
 
 // InitializeDataMaps initializes all the relevant object maps. This function is called to
@@ -137,4 +151,31 @@ func InitializeDataMaps() {
 			return &ethpb.SignedAggregateAttestationAndProofElectra{}, nil
 		},
 	}
+
+	// Reset our light client maps. Light client messages only exist from altair onwards.
+	LightClientBootstrapMap = lightClientMap(ethpbv2.NewLightClientBootstrap)
+	LightClientUpdateMap = lightClientMap(ethpbv2.NewLightClientUpdate)
+	LightClientFinalityUpdateMap = lightClientMap(ethpbv2.NewLightClientFinalityUpdate)
+	LightClientOptimisticUpdateMap = lightClientMap(ethpbv2.NewLightClientOptimisticUpdate)
+}
+
+// lightClientMap maps every fork version from altair onwards to the light client
+// data type of that fork, as constructed by newFn.
+func lightClientMap[T any](newFn func(v int) (T, error)) map[[4]byte]func() (T, error) {
+	cfg := params.BeaconConfig()
+	forks := map[int][]byte{
+		version.Altair:    cfg.AltairForkVersion,
+		version.Bellatrix: cfg.BellatrixForkVersion,
+		version.Capella:   cfg.CapellaForkVersion,
+		version.Deneb:     cfg.DenebForkVersion,
+		version.Electra:   cfg.ElectraForkVersion,
+	}
+	m := make(map[[4]byte]func() (T, error), len(forks))
+	for v, forkVersion := range forks {
+		v := v
+		m[bytesutil.ToBytes4(forkVersion)] = func() (T, error) {
+			return newFn(v)
+		}
+	}
+	return m
 }
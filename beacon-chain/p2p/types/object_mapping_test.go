@@ -79,3 +79,22 @@ func TestInitializeDataMaps(t *testing.T) {
 		})
 	}
 }
+
+func TestInitializeDataMaps_LightClient(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	InitializeDataMaps()
+	cfg := params.BeaconConfig()
+
+	_, ok := LightClientFinalityUpdateMap[bytesutil.ToBytes4(cfg.GenesisForkVersion)]
+	assert.Equal(t, false, ok)
+
+	update, err := LightClientFinalityUpdateMap[bytesutil.ToBytes4(cfg.AltairForkVersion)]()
+	require.NoError(t, err)
+	assert.NotNil(t, update.AttestedHeader.GetHeaderAltair())
+	bootstrap, err := LightClientBootstrapMap[bytesutil.ToBytes4(cfg.CapellaForkVersion)]()
+	require.NoError(t, err)
+	assert.NotNil(t, bootstrap.Header.GetHeaderCapella())
+	optimistic, err := LightClientOptimisticUpdateMap[bytesutil.ToBytes4(cfg.ElectraForkVersion)]()
+	require.NoError(t, err)
+	assert.NotNil(t, optimistic.AttestedHeader.GetHeaderDeneb())
+}
@@ -211,3 +211,67 @@ func init() {
 	sizer := &eth.BlobIdentifier{}
 	blobIdSize = sizer.SizeSSZ()
 }
+
+// LightClientBootstrapReq specifies the light client bootstrap request type, the root of the block to bootstrap from.
+type LightClientBootstrapReq [rootLength]byte
+
+// MarshalSSZTo marshals the light client bootstrap request with the provided byte slice.
+func (r *LightClientBootstrapReq) MarshalSSZTo(dst []byte) ([]byte, error) {
+	return append(dst, r[:]...), nil
+}
+
+// MarshalSSZ marshals the light client bootstrap request into the serialized object.
+func (r *LightClientBootstrapReq) MarshalSSZ() ([]byte, error) {
+	return r.MarshalSSZTo(make([]byte, 0, rootLength))
+}
+
+// SizeSSZ returns the size of the serialized representation.
+func (r *LightClientBootstrapReq) SizeSSZ() int {
+	return rootLength
+}
+
+// UnmarshalSSZ unmarshals the provided bytes buffer into the
+// light client bootstrap request object.
+func (r *LightClientBootstrapReq) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != rootLength {
+		return errors.Wrapf(ssz.ErrIncorrectByteSize, "size=%d", len(buf))
+	}
+	copy(r[:], buf)
+	return nil
+}
+
+// lightClientUpdatesByRangeReqSize is the size of the two uint64 fields of the request.
+const lightClientUpdatesByRangeReqSize = 16
+
+// LightClientUpdatesByRangeReq specifies the light client updates by range request type.
+type LightClientUpdatesByRangeReq struct {
+	StartPeriod uint64
+	Count       uint64
+}
+
+// MarshalSSZTo marshals the light client updates by range request with the provided byte slice.
+func (r *LightClientUpdatesByRangeReq) MarshalSSZTo(dst []byte) ([]byte, error) {
+	dst = ssz.MarshalUint64(dst, r.StartPeriod)
+	return ssz.MarshalUint64(dst, r.Count), nil
+}
+
+// MarshalSSZ marshals the light client updates by range request into the serialized object.
+func (r *LightClientUpdatesByRangeReq) MarshalSSZ() ([]byte, error) {
+	return r.MarshalSSZTo(make([]byte, 0, lightClientUpdatesByRangeReqSize))
+}
+
+// SizeSSZ returns the size of the serialized representation.
+func (r *LightClientUpdatesByRangeReq) SizeSSZ() int {
+	return lightClientUpdatesByRangeReqSize
+}
+
+// UnmarshalSSZ unmarshals the provided bytes buffer into the
+// light client updates by range request object.
+func (r *LightClientUpdatesByRangeReq) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != lightClientUpdatesByRangeReqSize {
+		return errors.Wrapf(ssz.ErrIncorrectByteSize, "size=%d", len(buf))
+	}
+	r.StartPeriod = ssz.UnmarshallUint64(buf[:8])
+	r.Count = ssz.UnmarshallUint64(buf[8:])
+	return nil
+}
@@ -194,3 +194,23 @@ func hexDecodeOrDie(t *testing.T, str string) []byte {
 	require.NoError(t, err)
 	return decoded
 }
+
+func TestLightClientRequests_RoundTrip(t *testing.T) {
+	root := LightClientBootstrapReq(bytesutil.ToBytes32([]byte("root")))
+	enc, err := root.MarshalSSZ()
+	require.NoError(t, err)
+	require.Equal(t, root.SizeSSZ(), len(enc))
+	var gotRoot LightClientBootstrapReq
+	require.NoError(t, gotRoot.UnmarshalSSZ(enc))
+	require.Equal(t, root, gotRoot)
+	require.ErrorIs(t, gotRoot.UnmarshalSSZ(enc[1:]), ssz.ErrIncorrectByteSize)
+
+	req := &LightClientUpdatesByRangeReq{StartPeriod: 10, Count: 3}
+	enc, err = req.MarshalSSZ()
+	require.NoError(t, err)
+	require.Equal(t, req.SizeSSZ(), len(enc))
+	gotReq := &LightClientUpdatesByRangeReq{}
+	require.NoError(t, gotReq.UnmarshalSSZ(enc))
+	require.DeepEqual(t, req, gotReq)
+	require.ErrorIs(t, gotReq.UnmarshalSSZ(append(enc, 0)), ssz.ErrIncorrectByteSize)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
 	beaconprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/beacon"
 	nodeprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/node"
+	slasherprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/slasher"
 	validatorv1alpha1 "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/v1alpha1/validator"
 	validatorprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/validator"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
@@ -71,6 +72,9 @@ func (s *Service) endpoints(
 	endpoints = append(endpoints, s.prysmBeaconEndpoints(ch, stater, coreService)...)
 	endpoints = append(endpoints, s.prysmNodeEndpoints()...)
 	endpoints = append(endpoints, s.prysmValidatorEndpoints(stater, coreService)...)
+	if s.cfg.SlasherQuerier != nil {
+		endpoints = append(endpoints, s.prysmSlasherEndpoints()...)
+	}
 	if enableDebug {
 		endpoints = append(endpoints, s.debugEndpoints(stater)...)
 	}
@@ -690,6 +694,16 @@ func (s *Service) beaconEndpoints(
 			handler: server.SubmitAttesterSlashing,
 			methods: []string{http.MethodPost},
 		},
+		{
+			template: "/eth/v2/beacon/pool/attester_slashings",
+			name:     namespace + ".SubmitAttesterSlashingV2",
+			middleware: []mux.MiddlewareFunc{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.SubmitAttesterSlashingV2,
+			methods: []string{http.MethodPost},
+		},
 		{
 			template: "/eth/v1/beacon/pool/proposer_slashings",
 			name:     namespace + ".GetProposerSlashings",
@@ -905,11 +919,15 @@ func (s *Service) debugEndpoints(stater lookup.Stater) []endpoint {
 
 func (s *Service) eventsEndpoints() []endpoint {
 	server := &events.Server{
-		StateNotifier:          s.cfg.StateNotifier,
-		OperationNotifier:      s.cfg.OperationNotifier,
-		HeadFetcher:            s.cfg.HeadFetcher,
-		ChainInfoFetcher:       s.cfg.ChainInfoFetcher,
-		TrackedValidatorsCache: s.cfg.TrackedValidatorsCache,
+		StateNotifier:           s.cfg.StateNotifier,
+		OperationNotifier:       s.cfg.OperationNotifier,
+		BlockNotifier:           s.cfg.BlockNotifier,
+		HeadFetcher:             s.cfg.HeadFetcher,
+		ChainInfoFetcher:        s.cfg.ChainInfoFetcher,
+		TrackedValidatorsCache:  s.cfg.TrackedValidatorsCache,
+		ServedDutiesCache:       s.servedDutiesCache,
+		SlasherAttestationsFeed: s.cfg.SlasherAttestationsFeed,
+		SlasherBlockHeadersFeed: s.cfg.SlasherBlockHeadersFeed,
 	}
 
 	const namespace = "events"
@@ -1067,14 +1085,57 @@ func (s *Service) prysmNodeEndpoints() []endpoint {
 			handler: server.RemoveTrustedPeer,
 			methods: []string{http.MethodDelete},
 		},
+		{
+			template: "/prysm/v1/node/peer_reputations",
+			name:     namespace + ".ListPeerReputations",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.ListPeerReputations,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/node/peer_bandwidth",
+			name:     namespace + ".ListPeerBandwidth",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.ListPeerBandwidth,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/node/banned_peers",
+			name:     namespace + ".BanPeer",
+			middleware: []mux.MiddlewareFunc{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.BanPeer,
+			methods: []string{http.MethodPost},
+		},
+		{
+			template: "/prysm/v1/node/banned_peers/{peer_id}",
+			name:     namespace + ".UnbanPeer",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.UnbanPeer,
+			methods: []string{http.MethodDelete},
+		},
 	}
 }
 
 func (s *Service) prysmValidatorEndpoints(stater lookup.Stater, coreService *core.Service) []endpoint {
 	server := &validatorprysm.Server{
-		ChainInfoFetcher: s.cfg.ChainInfoFetcher,
-		Stater:           stater,
-		CoreService:      coreService,
+		BeaconDB:              s.cfg.BeaconDB,
+		ChainInfoFetcher:      s.cfg.ChainInfoFetcher,
+		HeadFetcher:           s.cfg.HeadFetcher,
+		TimeFetcher:           s.cfg.GenesisTimeFetcher,
+		OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
+		SyncChecker:           s.cfg.SyncService,
+		ServedDutiesCache:     s.servedDutiesCache,
+		Stater:                stater,
+		CoreService:           coreService,
 	}
 
 	const namespace = "prysm.validator"
@@ -1117,5 +1178,71 @@ func (s *Service) prysmValidatorEndpoints(stater lookup.Stater, coreService *cor
 			handler: server.GetActiveSetChanges,
 			methods: []string{http.MethodGet},
 		},
+		{
+			template: "/prysm/v1/validators/proposal_decisions",
+			name:     namespace + ".GetProposalDecisions",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetProposalDecisions,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/validator/duties/lookahead",
+			name:     namespace + ".GetDutiesLookahead",
+			middleware: []mux.MiddlewareFunc{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetDutiesLookahead,
+			methods: []string{http.MethodPost},
+		},
+	}
+}
+
+func (s *Service) prysmSlasherEndpoints() []endpoint {
+	server := &slasherprysm.Server{
+		Slasher: s.cfg.SlasherQuerier,
+	}
+
+	const namespace = "prysm.slasher"
+	return []endpoint{
+		{
+			template: "/prysm/v1/slasher/validators/{validator_index}/spans",
+			name:     namespace + ".GetSpans",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetSpans,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/slasher/validators/{validator_index}/attestations",
+			name:     namespace + ".GetAttestations",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetAttestations,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/slasher/validators/{validator_index}/slashings",
+			name:     namespace + ".GetSlashings",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetSlashings,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/slasher/attestations/check",
+			name:     namespace + ".CheckAttestation",
+			middleware: []mux.MiddlewareFunc{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.CheckAttestation,
+			methods: []string{http.MethodPost},
+		},
 	}
 }
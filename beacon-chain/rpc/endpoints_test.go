@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 )
 
@@ -40,6 +41,7 @@ func Test_endpoints(t *testing.T) {
 		"/eth/v1/beacon/blinded_blocks/{block_id}":                   {http.MethodGet},
 		"/eth/v1/beacon/pool/attestations":                           {http.MethodGet, http.MethodPost},
 		"/eth/v1/beacon/pool/attester_slashings":                     {http.MethodGet, http.MethodPost},
+		"/eth/v2/beacon/pool/attester_slashings":                     {http.MethodPost},
 		"/eth/v1/beacon/pool/proposer_slashings":                     {http.MethodGet, http.MethodPost},
 		"/eth/v1/beacon/pool/sync_committees":                        {http.MethodPost},
 		"/eth/v1/beacon/pool/voluntary_exits":                        {http.MethodGet, http.MethodPost},
@@ -122,6 +124,10 @@ func Test_endpoints(t *testing.T) {
 		"/prysm/v1/node/trusted_peers":           {http.MethodGet, http.MethodPost},
 		"/prysm/node/trusted_peers/{peer_id}":    {http.MethodDelete},
 		"/prysm/v1/node/trusted_peers/{peer_id}": {http.MethodDelete},
+		"/prysm/v1/node/peer_reputations":        {http.MethodGet},
+		"/prysm/v1/node/peer_bandwidth":          {http.MethodGet},
+		"/prysm/v1/node/banned_peers":            {http.MethodPost},
+		"/prysm/v1/node/banned_peers/{peer_id}":  {http.MethodDelete},
 	}
 
 	prysmValidatorRoutes := map[string][]string{
@@ -129,11 +135,20 @@ func Test_endpoints(t *testing.T) {
 		"/prysm/v1/validators/performance":        {http.MethodPost},
 		"/prysm/v1/validators/participation":      {http.MethodGet},
 		"/prysm/v1/validators/active_set_changes": {http.MethodGet},
+		"/prysm/v1/validators/proposal_decisions": {http.MethodGet},
+		"/prysm/v1/validator/duties/lookahead":    {http.MethodPost},
 	}
 
-	s := &Service{cfg: &Config{}}
+	prysmSlasherRoutes := map[string][]string{
+		"/prysm/v1/slasher/validators/{validator_index}/spans":        {http.MethodGet},
+		"/prysm/v1/slasher/validators/{validator_index}/attestations": {http.MethodGet},
+		"/prysm/v1/slasher/validators/{validator_index}/slashings":    {http.MethodGet},
+		"/prysm/v1/slasher/attestations/check":                        {http.MethodPost},
+	}
+
+	s := &Service{cfg: &Config{SlasherQuerier: &slasher.Service{}}}
 
-	routesMap := combineMaps(beaconRoutes, builderRoutes, configRoutes, debugRoutes, eventsRoutes, nodeRoutes, validatorRoutes, rewardsRoutes, lightClientRoutes, blobRoutes, prysmValidatorRoutes, prysmNodeRoutes, prysmBeaconRoutes)
+	routesMap := combineMaps(beaconRoutes, builderRoutes, configRoutes, debugRoutes, eventsRoutes, nodeRoutes, validatorRoutes, rewardsRoutes, lightClientRoutes, blobRoutes, prysmValidatorRoutes, prysmNodeRoutes, prysmBeaconRoutes, prysmSlasherRoutes)
 	actual := s.endpoints(true, nil, nil, nil, nil, nil, nil)
 	for _, e := range actual {
 		methods, ok := routesMap[e.template]
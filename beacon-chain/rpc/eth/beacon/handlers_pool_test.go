@@ -13,6 +13,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	blockchainmock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
@@ -37,6 +38,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/encoding/ssz"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	ethpbv1alpha1 "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
@@ -1234,6 +1236,176 @@ func TestSubmitAttesterSlashing_Ok(t *testing.T) {
 	assert.Equal(t, true, ok)
 }
 
+func TestSubmitAttesterSlashingV2_Electra(t *testing.T) {
+	ctx := context.Background()
+
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+
+	_, keys, err := util.DeterministicDepositsAndKeys(1)
+	require.NoError(t, err)
+	validator := &ethpbv1alpha1.Validator{
+		PublicKey: keys[0].PublicKey().Marshal(),
+	}
+	bs, err := util.NewBeaconState(func(state *ethpbv1alpha1.BeaconState) error {
+		state.Validators = []*ethpbv1alpha1.Validator{validator}
+		return nil
+	})
+	require.NoError(t, err)
+
+	slashing := &ethpbv1alpha1.AttesterSlashingElectra{
+		Attestation_1: &ethpbv1alpha1.IndexedAttestationElectra{
+			AttestingIndices: []uint64{0},
+			Data: &ethpbv1alpha1.AttestationData{
+				Slot:            1,
+				CommitteeIndex:  1,
+				BeaconBlockRoot: bytesutil.PadTo([]byte("blockroot1"), 32),
+				Source: &ethpbv1alpha1.Checkpoint{
+					Epoch: 1,
+					Root:  bytesutil.PadTo([]byte("sourceroot1"), 32),
+				},
+				Target: &ethpbv1alpha1.Checkpoint{
+					Epoch: 10,
+					Root:  bytesutil.PadTo([]byte("targetroot1"), 32),
+				},
+			},
+			Signature: make([]byte, 96),
+		},
+		Attestation_2: &ethpbv1alpha1.IndexedAttestationElectra{
+			AttestingIndices: []uint64{0},
+			Data: &ethpbv1alpha1.AttestationData{
+				Slot:            1,
+				CommitteeIndex:  1,
+				BeaconBlockRoot: bytesutil.PadTo([]byte("blockroot2"), 32),
+				Source: &ethpbv1alpha1.Checkpoint{
+					Epoch: 1,
+					Root:  bytesutil.PadTo([]byte("sourceroot2"), 32),
+				},
+				Target: &ethpbv1alpha1.Checkpoint{
+					Epoch: 10,
+					Root:  bytesutil.PadTo([]byte("targetroot2"), 32),
+				},
+			},
+			Signature: make([]byte, 96),
+		},
+	}
+
+	for _, att := range []*ethpbv1alpha1.IndexedAttestationElectra{slashing.Attestation_1, slashing.Attestation_2} {
+		sb, err := signing.ComputeDomainAndSign(bs, att.Data.Target.Epoch, att.Data, params.BeaconConfig().DomainBeaconAttester, keys[0])
+		require.NoError(t, err)
+		sig, err := bls.SignatureFromBytes(sb)
+		require.NoError(t, err)
+		att.Signature = sig.Marshal()
+	}
+
+	broadcaster := &p2pMock.MockBroadcaster{}
+	chainmock := &blockchainmock.ChainService{State: bs}
+	s := &Server{
+		ChainInfoFetcher:  chainmock,
+		SlashingsPool:     &slashingsmock.PoolMock{},
+		Broadcaster:       broadcaster,
+		OperationNotifier: chainmock.OperationNotifier(),
+	}
+
+	b, err := json.Marshal(structs.AttesterSlashingElectraFromConsensus(slashing))
+	require.NoError(t, err)
+	var body bytes.Buffer
+	_, err = body.Write(b)
+	require.NoError(t, err)
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/beacon/pool/attester_slashings", &body)
+	request.Header.Set(api.VersionHeader, version.String(version.Electra))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.SubmitAttesterSlashingV2(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	pendingSlashings := s.SlashingsPool.PendingAttesterSlashings(ctx, bs, true)
+	require.Equal(t, 1, len(pendingSlashings))
+	assert.DeepEqual(t, slashing, pendingSlashings[0])
+	assert.Equal(t, true, broadcaster.BroadcastCalled.Load())
+	require.Equal(t, 1, broadcaster.NumMessages())
+	_, ok := broadcaster.BroadcastMessages[0].(*ethpbv1alpha1.AttesterSlashingElectra)
+	assert.Equal(t, true, ok)
+}
+
+func TestSubmitAttesterSlashingV2_Phase0(t *testing.T) {
+	ctx := context.Background()
+
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+
+	bs, keys := util.DeterministicGenesisState(t, 1)
+	atts := make([]*ethpbv1alpha1.IndexedAttestation, 2)
+	for i := range atts {
+		atts[i] = &ethpbv1alpha1.IndexedAttestation{
+			AttestingIndices: []uint64{0},
+			Data: &ethpbv1alpha1.AttestationData{
+				Slot:            1,
+				CommitteeIndex:  1,
+				BeaconBlockRoot: bytesutil.PadTo([]byte(fmt.Sprintf("blockroot%d", i)), 32),
+				Source:          &ethpbv1alpha1.Checkpoint{Epoch: 1, Root: bytesutil.PadTo([]byte("sourceroot"), 32)},
+				Target:          &ethpbv1alpha1.Checkpoint{Epoch: 10, Root: bytesutil.PadTo([]byte("targetroot"), 32)},
+			},
+		}
+		sb, err := signing.ComputeDomainAndSign(bs, atts[i].Data.Target.Epoch, atts[i].Data, params.BeaconConfig().DomainBeaconAttester, keys[0])
+		require.NoError(t, err)
+		atts[i].Signature = sb
+	}
+	slashing := &ethpbv1alpha1.AttesterSlashing{Attestation_1: atts[0], Attestation_2: atts[1]}
+	b, err := json.Marshal(structs.AttesterSlashingFromConsensus(slashing))
+	require.NoError(t, err)
+
+	newServer := func() (*Server, *p2pMock.MockBroadcaster) {
+		broadcaster := &p2pMock.MockBroadcaster{}
+		chainmock := &blockchainmock.ChainService{State: bs}
+		return &Server{
+			ChainInfoFetcher:  chainmock,
+			SlashingsPool:     &slashingsmock.PoolMock{},
+			Broadcaster:       broadcaster,
+			OperationNotifier: chainmock.OperationNotifier(),
+		}, broadcaster
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		s, broadcaster := newServer()
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/beacon/pool/attester_slashings", bytes.NewReader(b))
+		request.Header.Set(api.VersionHeader, version.String(version.Deneb))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SubmitAttesterSlashingV2(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		pendingSlashings := s.SlashingsPool.PendingAttesterSlashings(ctx, bs, true)
+		require.Equal(t, 1, len(pendingSlashings))
+		assert.DeepEqual(t, slashing, pendingSlashings[0])
+		require.Equal(t, 1, broadcaster.NumMessages())
+		_, ok := broadcaster.BroadcastMessages[0].(*ethpbv1alpha1.AttesterSlashing)
+		assert.Equal(t, true, ok)
+	})
+	t.Run("no version header", func(t *testing.T) {
+		s, _ := newServer()
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/beacon/pool/attester_slashings", bytes.NewReader(b))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SubmitAttesterSlashingV2(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, api.VersionHeader+" header is required", writer.Body.String())
+		assert.Equal(t, 0, len(s.SlashingsPool.PendingAttesterSlashings(ctx, bs, true)))
+	})
+	t.Run("invalid version header", func(t *testing.T) {
+		s, _ := newServer()
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/beacon/pool/attester_slashings", bytes.NewReader(b))
+		request.Header.Set(api.VersionHeader, "foo")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SubmitAttesterSlashingV2(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Invalid version", writer.Body.String())
+	})
+}
+
 func TestSubmitAttesterSlashing_AcrossFork(t *testing.T) {
 	ctx := context.Background()
 
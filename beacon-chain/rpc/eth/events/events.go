@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	time2 "time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -13,12 +16,18 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	blockfeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/block"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	rpchelpers "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/validator"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
@@ -57,8 +66,29 @@ const (
 	LightClientFinalityUpdateTopic = "light_client_finality_update"
 	// LightClientOptimisticUpdateTopic represents a new light client optimistic update event topic.
 	LightClientOptimisticUpdateTopic = "light_client_optimistic_update"
+	// DutiesInvalidatedTopic represents a reorg invalidating previously served duties event topic.
+	DutiesInvalidatedTopic = "duties_invalidated"
+	// BlockGossipTopic represents a new block received over gossip, before it is imported, event topic.
+	BlockGossipTopic = "block_gossip"
+	// BlobSidecarAvailabilityTopic represents all the blob sidecars of a block becoming available event topic.
+	BlobSidecarAvailabilityTopic = "blob_sidecar_availability"
+	// DataColumnAvailabilityTopic represents all the sampled data column sidecars of a block becoming available event topic.
+	DataColumnAvailabilityTopic = "data_column_availability"
+	// ValidatorStatusChangeTopic represents a change of the status of a requested validator event topic.
+	ValidatorStatusChangeTopic = "validator_status_change"
+	// ForkChoiceJustifiedTopic represents a new justified checkpoint in fork choice event topic.
+	ForkChoiceJustifiedTopic = "fork_choice_justified"
+	// ExecutionPayloadInvalidTopic represents an execution payload found invalid by the execution layer event topic.
+	ExecutionPayloadInvalidTopic = "execution_payload_invalid"
+	// SlasherAttestationTopic represents a new indexed attestation collected for a slasher event topic.
+	SlasherAttestationTopic = "slasher_attestation"
+	// SlasherBlockHeaderTopic represents a new signed block header collected for a slasher event topic.
+	SlasherBlockHeaderTopic = "slasher_block_header"
 )
 
+// ValidatorIndicesFilter is the query parameter restricting validator related events to the given indices.
+const ValidatorIndicesFilter = "validator_indices"
+
 const topicDataMismatch = "Event data type %T does not correspond to event topic %s"
 
 const chanBuffer = 1000
@@ -78,6 +108,51 @@ var casesHandled = map[string]bool{
 	AttesterSlashingTopic:            true,
 	LightClientFinalityUpdateTopic:   true,
 	LightClientOptimisticUpdateTopic: true,
+	DutiesInvalidatedTopic:           true,
+	BlockGossipTopic:                 true,
+	BlobSidecarAvailabilityTopic:     true,
+	DataColumnAvailabilityTopic:      true,
+	ValidatorStatusChangeTopic:       true,
+	ForkChoiceJustifiedTopic:         true,
+	ExecutionPayloadInvalidTopic:     true,
+	SlasherAttestationTopic:          true,
+	SlasherBlockHeaderTopic:          true,
+}
+
+// streamFilters are the server-side filters of an event stream.
+type streamFilters struct {
+	// validators restricts validator related events to these indices. Every validator matches when empty.
+	validators map[primitives.ValidatorIndex]bool
+	// statuses holds the last observed status of each filtered validator.
+	statuses map[primitives.ValidatorIndex]validator.Status
+}
+
+// parseStreamFilters reads the filters from the query parameters. Indices may be given as repeated
+// parameters or as a comma separated list.
+func parseStreamFilters(r *http.Request) (*streamFilters, error) {
+	f := &streamFilters{
+		validators: make(map[primitives.ValidatorIndex]bool),
+		statuses:   make(map[primitives.ValidatorIndex]validator.Status),
+	}
+	for _, param := range r.URL.Query()[ValidatorIndicesFilter] {
+		for _, raw := range strings.Split(param, ",") {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+			idx, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return nil, errors.Errorf("invalid validator index %s", raw)
+			}
+			f.validators[primitives.ValidatorIndex(idx)] = true
+		}
+	}
+	return f, nil
+}
+
+// matchValidator returns true if events about the validator pass the filter.
+func (f *streamFilters) matchValidator(idx primitives.ValidatorIndex) bool {
+	return len(f.validators) == 0 || f.validators[idx]
 }
 
 // StreamEvents provides an endpoint to subscribe to the beacon node Server-Sent-Events stream.
@@ -107,14 +182,45 @@ func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
 		}
 		topicsMap[topic] = true
 	}
+	filters, err := parseStreamFilters(r)
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if topicsMap[ValidatorStatusChangeTopic] && len(filters.validators) == 0 {
+		httputil.HandleError(w, fmt.Sprintf("%s must be specified for topic %s", ValidatorIndicesFilter, ValidatorStatusChangeTopic), http.StatusBadRequest)
+		return
+	}
+
+	slasherTopicRequested := topicsMap[SlasherAttestationTopic] || topicsMap[SlasherBlockHeaderTopic]
+	if slasherTopicRequested && (s.SlasherAttestationsFeed == nil || s.SlasherBlockHeadersFeed == nil) {
+		httputil.HandleError(w, "Slasher topics require the beacon node to run with --slasher or --slasher-stream", http.StatusBadRequest)
+		return
+	}
 
 	// Subscribe to event feeds from information received in the beacon node runtime.
 	opsChan := make(chan *feed.Event, chanBuffer)
 	opsSub := s.OperationNotifier.OperationFeed().Subscribe(opsChan)
 	stateChan := make(chan *feed.Event, chanBuffer)
 	stateSub := s.StateNotifier.StateFeed().Subscribe(stateChan)
+	blockChan := make(chan *feed.Event, chanBuffer)
+	blockSub := s.BlockNotifier.BlockFeed().Subscribe(blockChan)
 	defer opsSub.Unsubscribe()
 	defer stateSub.Unsubscribe()
+	defer blockSub.Unsubscribe()
+	// The slasher feeds are only subscribed to when requested, receiving from a nil channel blocks forever.
+	var slasherAttChan chan *slashertypes.WrappedIndexedAtt
+	if topicsMap[SlasherAttestationTopic] {
+		slasherAttChan = make(chan *slashertypes.WrappedIndexedAtt, chanBuffer)
+		slasherAttSub := s.SlasherAttestationsFeed.Subscribe(slasherAttChan)
+		defer slasherAttSub.Unsubscribe()
+	}
+	var slasherHeaderChan chan *eth.SignedBeaconBlockHeader
+	if topicsMap[SlasherBlockHeaderTopic] {
+		slasherHeaderChan = make(chan *eth.SignedBeaconBlockHeader, chanBuffer)
+		slasherHeaderSub := s.SlasherBlockHeadersFeed.Subscribe(slasherHeaderChan)
+		defer slasherHeaderSub.Unsubscribe()
+	}
 
 	// Set up SSE response headers
 	w.Header().Set("Content-Type", api.EventStreamMediaType)
@@ -139,7 +245,22 @@ func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 		case event := <-stateChan:
-			if err := s.handleStateEvents(ctx, w, flusher, topicsMap, event); err != nil {
+			if err := s.handleStateEvents(ctx, w, flusher, topicsMap, filters, event); err != nil {
+				httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		case event := <-blockChan:
+			if err := handleBlockEvents(w, flusher, topicsMap, filters, event); err != nil {
+				httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		case att := <-slasherAttChan:
+			if err := sendSlasherAttestation(w, flusher, att); err != nil {
+				httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		case header := <-slasherHeaderChan:
+			if err := send(w, flusher, SlasherBlockHeaderTopic, structs.SignedBeaconBlockHeaderFromConsensus(header)); err != nil {
 				httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
 				return
 			}
@@ -252,7 +373,51 @@ func handleBlockOperationEvents(w http.ResponseWriter, flusher http.Flusher, req
 	return nil
 }
 
-func (s *Server) handleStateEvents(ctx context.Context, w http.ResponseWriter, flusher http.Flusher, requestedTopics map[string]bool, event *feed.Event) error {
+// sendSlasherAttestation sends the indexed attestation with its version, which determines the type of the data.
+func sendSlasherAttestation(w http.ResponseWriter, flusher http.Flusher, att *slashertypes.WrappedIndexedAtt) error {
+	var data interface{}
+	switch a := att.IndexedAtt.(type) {
+	case *eth.IndexedAttestation:
+		data = structs.IndexedAttFromConsensus(a)
+	case *eth.IndexedAttestationElectra:
+		data = structs.IndexedAttElectraFromConsensus(a)
+	default:
+		return write(w, flusher, topicDataMismatch, att.IndexedAtt, SlasherAttestationTopic)
+	}
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return write(w, flusher, "Could not marshal event to JSON: "+err.Error())
+	}
+	return send(w, flusher, SlasherAttestationTopic, &structs.SlasherAttestationEvent{
+		Version: version.String(att.IndexedAtt.Version()),
+		Data:    dataBytes,
+	})
+}
+
+func handleBlockEvents(w http.ResponseWriter, flusher http.Flusher, requestedTopics map[string]bool, filters *streamFilters, event *feed.Event) error {
+	switch event.Type {
+	case blockfeed.GossipValidatedBlock:
+		if _, ok := requestedTopics[BlockGossipTopic]; !ok {
+			return nil
+		}
+		blkData, ok := event.Data.(*blockfeed.GossipValidatedBlockData)
+		if !ok {
+			return write(w, flusher, topicDataMismatch, event.Data, BlockGossipTopic)
+		}
+		blk := blkData.SignedBlock.Block()
+		if !filters.matchValidator(blk.ProposerIndex()) {
+			return nil
+		}
+		return send(w, flusher, BlockGossipTopic, &structs.BlockGossipEvent{
+			Slot:          fmt.Sprintf("%d", blk.Slot()),
+			Block:         hexutil.Encode(blkData.BlockRoot[:]),
+			ProposerIndex: fmt.Sprintf("%d", blk.ProposerIndex()),
+		})
+	}
+	return nil
+}
+
+func (s *Server) handleStateEvents(ctx context.Context, w http.ResponseWriter, flusher http.Flusher, requestedTopics map[string]bool, filters *streamFilters, event *feed.Event) error {
 	switch event.Type {
 	case statefeed.NewHead:
 		if _, ok := requestedTopics[HeadTopic]; ok {
@@ -269,15 +434,87 @@ func (s *Server) handleStateEvents(ctx context.Context, w http.ResponseWriter, f
 				PreviousDutyDependentRoot: hexutil.Encode(headData.PreviousDutyDependentRoot),
 				CurrentDutyDependentRoot:  hexutil.Encode(headData.CurrentDutyDependentRoot),
 			}
-			return send(w, flusher, HeadTopic, head)
+			if err := send(w, flusher, HeadTopic, head); err != nil {
+				return err
+			}
 		}
 		if _, ok := requestedTopics[PayloadAttributesTopic]; ok {
-			return s.sendPayloadAttributes(ctx, w, flusher)
+			if err := s.sendPayloadAttributes(ctx, w, flusher, filters); err != nil {
+				return err
+			}
+		}
+		if _, ok := requestedTopics[ValidatorStatusChangeTopic]; ok {
+			return s.sendValidatorStatusChanges(ctx, w, flusher, filters)
 		}
 	case statefeed.MissedSlot:
 		if _, ok := requestedTopics[PayloadAttributesTopic]; ok {
-			return s.sendPayloadAttributes(ctx, w, flusher)
+			return s.sendPayloadAttributes(ctx, w, flusher, filters)
+		}
+	case statefeed.JustifiedCheckpoint:
+		if _, ok := requestedTopics[ForkChoiceJustifiedTopic]; !ok {
+			return nil
+		}
+		checkpointData, ok := event.Data.(*statefeed.JustifiedCheckpointData)
+		if !ok {
+			return write(w, flusher, topicDataMismatch, event.Data, ForkChoiceJustifiedTopic)
+		}
+		return send(w, flusher, ForkChoiceJustifiedTopic, &structs.JustifiedCheckpointEvent{
+			Block: hexutil.Encode(checkpointData.Root[:]),
+			Epoch: fmt.Sprintf("%d", checkpointData.Epoch),
+		})
+	case statefeed.DataAvailable:
+		daData, ok := event.Data.(*statefeed.DataAvailableData)
+		if !ok {
+			return write(w, flusher, topicDataMismatch, event.Data, BlobSidecarAvailabilityTopic)
+		}
+		if !filters.matchValidator(daData.ProposerIndex) {
+			return nil
+		}
+		// The availability of the data is checked with either data column sidecars or blob sidecars.
+		if len(daData.DataColumns) > 0 {
+			if _, ok := requestedTopics[DataColumnAvailabilityTopic]; !ok {
+				return nil
+			}
+			columns := make([]string, len(daData.DataColumns))
+			for i, c := range daData.DataColumns {
+				columns[i] = fmt.Sprintf("%d", c)
+			}
+			return send(w, flusher, DataColumnAvailabilityTopic, &structs.DataColumnAvailabilityEvent{
+				Slot:          fmt.Sprintf("%d", daData.Slot),
+				Block:         hexutil.Encode(daData.BlockRoot[:]),
+				ProposerIndex: fmt.Sprintf("%d", daData.ProposerIndex),
+				Columns:       columns,
+				WaitedTimeMs:  fmt.Sprintf("%d", daData.WaitedTime.Milliseconds()),
+			})
+		}
+		if _, ok := requestedTopics[BlobSidecarAvailabilityTopic]; !ok {
+			return nil
+		}
+		return send(w, flusher, BlobSidecarAvailabilityTopic, &structs.BlobSidecarAvailabilityEvent{
+			Slot:          fmt.Sprintf("%d", daData.Slot),
+			Block:         hexutil.Encode(daData.BlockRoot[:]),
+			ProposerIndex: fmt.Sprintf("%d", daData.ProposerIndex),
+			BlobCount:     fmt.Sprintf("%d", daData.BlobCount),
+			WaitedTimeMs:  fmt.Sprintf("%d", daData.WaitedTime.Milliseconds()),
+		})
+	case statefeed.InvalidExecutionPayload:
+		if _, ok := requestedTopics[ExecutionPayloadInvalidTopic]; !ok {
+			return nil
+		}
+		payloadData, ok := event.Data.(*statefeed.InvalidExecutionPayloadData)
+		if !ok {
+			return write(w, flusher, topicDataMismatch, event.Data, ExecutionPayloadInvalidTopic)
 		}
+		if !filters.matchValidator(payloadData.ProposerIndex) {
+			return nil
+		}
+		return send(w, flusher, ExecutionPayloadInvalidTopic, &structs.ExecutionPayloadInvalidEvent{
+			Slot:            fmt.Sprintf("%d", payloadData.Slot),
+			Block:           hexutil.Encode(payloadData.BlockRoot[:]),
+			ProposerIndex:   fmt.Sprintf("%d", payloadData.ProposerIndex),
+			BlockHash:       hexutil.Encode(payloadData.BlockHash[:]),
+			LatestValidHash: hexutil.Encode(payloadData.LatestValidHash[:]),
+		})
 	case statefeed.FinalizedCheckpoint:
 		if _, ok := requestedTopics[FinalizedCheckpointTopic]; !ok {
 			return nil
@@ -371,13 +608,23 @@ func (s *Server) handleStateEvents(ctx context.Context, w http.ResponseWriter, f
 		}
 		return send(w, flusher, LightClientOptimisticUpdateTopic, update)
 	case statefeed.Reorg:
-		if _, ok := requestedTopics[ChainReorgTopic]; !ok {
+		_, chainReorgRequested := requestedTopics[ChainReorgTopic]
+		_, dutiesInvalidatedRequested := requestedTopics[DutiesInvalidatedTopic]
+		if !chainReorgRequested && !dutiesInvalidatedRequested {
 			return nil
 		}
 		reorgData, ok := event.Data.(*ethpb.EventChainReorg)
 		if !ok {
 			return write(w, flusher, topicDataMismatch, event.Data, ChainReorgTopic)
 		}
+		if dutiesInvalidatedRequested {
+			if err := s.sendInvalidatedDuties(ctx, w, flusher, reorgData); err != nil {
+				return err
+			}
+		}
+		if !chainReorgRequested {
+			return nil
+		}
 		reorg := &structs.ChainReorgEvent{
 			Slot:                fmt.Sprintf("%d", reorgData.Slot),
 			Depth:               fmt.Sprintf("%d", reorgData.Depth),
@@ -411,9 +658,112 @@ func (s *Server) handleStateEvents(ctx context.Context, w http.ResponseWriter, f
 	return nil
 }
 
+// sendInvalidatedDuties sends an event for every epoch whose served duties have a dependent root
+// that is no longer an ancestor of the new head.
+func (s *Server) sendInvalidatedDuties(ctx context.Context, w http.ResponseWriter, flusher http.Flusher, reorg *ethpb.EventChainReorg) error {
+	if s.ServedDutiesCache == nil {
+		return nil
+	}
+	served := s.ServedDutiesCache.Roots()
+	epochs := make([]primitives.Epoch, 0, len(served))
+	for e := range served {
+		epochs = append(epochs, e)
+	}
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+	for _, e := range epochs {
+		roots := served[e]
+		proposerRoot, err := s.dutyDependentRoot(ctx, reorg.NewHeadBlock, e, roots.Proposer)
+		if err != nil {
+			return write(w, flusher, "Could not get proposer dependent root: "+err.Error())
+		}
+		attesterRoot := roots.Attester
+		if e > 0 {
+			attesterRoot, err = s.dutyDependentRoot(ctx, reorg.NewHeadBlock, e-1, roots.Attester)
+			if err != nil {
+				return write(w, flusher, "Could not get attester dependent root: "+err.Error())
+			}
+		}
+		proposerChanged := proposerRoot != roots.Proposer
+		attesterChanged := attesterRoot != roots.Attester
+		if !proposerChanged && !attesterChanged {
+			continue
+		}
+		if err := send(w, flusher, DutiesInvalidatedTopic, &structs.DutiesInvalidatedEvent{
+			Epoch:                 fmt.Sprintf("%d", e),
+			ProposerDutiesChanged: proposerChanged,
+			AttesterDutiesChanged: attesterChanged,
+			ProposerDependentRoot: hexutil.Encode(proposerRoot[:]),
+			AttesterDependentRoot: hexutil.Encode(attesterRoot[:]),
+			NewHeadBlock:          hexutil.Encode(reorg.NewHeadBlock),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dutyDependentRoot returns the block root at the last slot before the epoch in the chain of the head.
+// The dependent root of the genesis epoch is the genesis block root, so the served root is returned as is.
+func (s *Server) dutyDependentRoot(ctx context.Context, headRoot []byte, epoch primitives.Epoch, served [32]byte) ([32]byte, error) {
+	if epoch == 0 {
+		return served, nil
+	}
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	root, err := s.ChainInfoFetcher.Ancestor(ctx, headRoot, startSlot-1)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return bytesutil.ToBytes32(root), nil
+}
+
+// sendValidatorStatusChanges sends an event for every filtered validator whose status in the head state
+// differs from the previously observed one. The first observation of a validator only records its status.
+func (s *Server) sendValidatorStatusChanges(ctx context.Context, w http.ResponseWriter, flusher http.Flusher, filters *streamFilters) error {
+	st, err := s.HeadFetcher.HeadStateReadOnly(ctx)
+	if err != nil {
+		return write(w, flusher, "Could not get head state: "+err.Error())
+	}
+	epoch := slots.ToEpoch(st.Slot())
+	indices := make([]primitives.ValidatorIndex, 0, len(filters.validators))
+	for idx := range filters.validators {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	for _, idx := range indices {
+		if uint64(idx) >= uint64(st.NumValidators()) {
+			continue
+		}
+		val, err := st.ValidatorAtIndexReadOnly(idx)
+		if err != nil {
+			return write(w, flusher, "Could not get validator: "+err.Error())
+		}
+		status, err := rpchelpers.ValidatorSubStatus(val, epoch)
+		if err != nil {
+			return write(w, flusher, "Could not get validator status: "+err.Error())
+		}
+		prev, seen := filters.statuses[idx]
+		filters.statuses[idx] = status
+		if !seen || prev == status {
+			continue
+		}
+		if err := send(w, flusher, ValidatorStatusChangeTopic, &structs.ValidatorStatusChangeEvent{
+			ValidatorIndex: fmt.Sprintf("%d", idx),
+			Epoch:          fmt.Sprintf("%d", epoch),
+			PreviousStatus: prev.String(),
+			Status:         status.String(),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // This event stream is intended to be used by builders and relays.
 // Parent fields are based on state at N_{current_slot}, while the rest of fields are based on state of N_{current_slot + 1}
-func (s *Server) sendPayloadAttributes(ctx context.Context, w http.ResponseWriter, flusher http.Flusher) error {
+func (s *Server) sendPayloadAttributes(ctx context.Context, w http.ResponseWriter, flusher http.Flusher, filters *streamFilters) error {
 	headRoot, err := s.HeadFetcher.HeadRoot(ctx)
 	if err != nil {
 		return write(w, flusher, "Could not get head root: "+err.Error())
@@ -452,6 +802,9 @@ func (s *Server) sendPayloadAttributes(ctx context.Context, w http.ResponseWrite
 	if err != nil {
 		return write(w, flusher, "Could not get head state proposer index: "+err.Error())
 	}
+	if !filters.matchValidator(proposerIndex) {
+		return nil
+	}
 	feeRecipient := params.BeaconConfig().DefaultFeeRecipient.Bytes()
 	tValidator, exists := s.TrackedValidatorsCache.Validator(proposerIndex)
 	if exists {
@@ -1,6 +1,7 @@
 package events
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"math"
@@ -11,16 +12,22 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/async/event"
 	mockChain "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	blockfeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/block"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/validator"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
@@ -28,6 +35,17 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
 )
 
+// ancestorChainService resolves ancestors from a fixed map of slot to root.
+type ancestorChainService struct {
+	*mockChain.ChainService
+	ancestors map[primitives.Slot][32]byte
+}
+
+func (c *ancestorChainService) Ancestor(_ context.Context, _ []byte, slot primitives.Slot) ([]byte, error) {
+	r := c.ancestors[slot]
+	return r[:], nil
+}
+
 type flushableResponseRecorder struct {
 	*httptest.ResponseRecorder
 	flushed bool
@@ -42,6 +60,7 @@ func TestStreamEvents_OperationsEvents(t *testing.T) {
 		s := &Server{
 			StateNotifier:     &mockChain.MockStateNotifier{},
 			OperationNotifier: &mockChain.MockOperationNotifier{},
+			BlockNotifier:     &mockChain.MockBlockNotifier{},
 		}
 
 		topics := []string{
@@ -207,6 +226,7 @@ func TestStreamEvents_OperationsEvents(t *testing.T) {
 		s := &Server{
 			StateNotifier:     &mockChain.MockStateNotifier{},
 			OperationNotifier: &mockChain.MockOperationNotifier{},
+			BlockNotifier:     &mockChain.MockBlockNotifier{},
 		}
 
 		topics := []string{HeadTopic, FinalizedCheckpointTopic, ChainReorgTopic, BlockTopic}
@@ -371,6 +391,7 @@ func TestStreamEvents_OperationsEvents(t *testing.T) {
 			s := &Server{
 				StateNotifier:          &mockChain.MockStateNotifier{},
 				OperationNotifier:      &mockChain.MockOperationNotifier{},
+				BlockNotifier:          &mockChain.MockBlockNotifier{},
 				HeadFetcher:            mockChainService,
 				ChainInfoFetcher:       mockChainService,
 				TrackedValidatorsCache: cache.NewTrackedValidatorsCache(),
@@ -475,3 +496,245 @@ event: payload_attributes
 data: {"version":"electra","data":{"proposer_index":"0","proposal_slot":"1","parent_block_number":"0","parent_block_root":"0x0000000000000000000000000000000000000000000000000000000000000000","parent_block_hash":"0x0000000000000000000000000000000000000000000000000000000000000000","payload_attributes":{"timestamp":"12","prev_randao":"0x0000000000000000000000000000000000000000000000000000000000000000","suggested_fee_recipient":"0xd2dbd02e4efe087d7d195de828b9dd25f19a89c9","withdrawals":[],"parent_beacon_block_root":"0x66d641f7eae038f2dd28081b09d2ba279462cc47655c7b7e1fd1159a50c8eb32"}}}
 
 `
+
+func TestSendInvalidatedDuties(t *testing.T) {
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	rootA, rootB, rootC, rootD := [32]byte{'a'}, [32]byte{'b'}, [32]byte{'c'}, [32]byte{'d'}
+	served := cache.NewServedDutiesCache()
+	served.Set(0, cache.DutyDependentRoots{Proposer: rootA, Attester: rootA})
+	served.Set(2, cache.DutyDependentRoots{Proposer: rootA, Attester: rootB})
+	served.Set(3, cache.DutyDependentRoots{Proposer: rootC, Attester: rootA})
+	chain := &ancestorChainService{
+		ChainService: &mockChain.ChainService{},
+		ancestors: map[primitives.Slot][32]byte{
+			slotsPerEpoch - 1:   rootB,
+			2*slotsPerEpoch - 1: rootD,
+			3*slotsPerEpoch - 1: rootC,
+		},
+	}
+	s := &Server{
+		ChainInfoFetcher:  chain,
+		ServedDutiesCache: served,
+	}
+	w := &flushableResponseRecorder{
+		ResponseRecorder: httptest.NewRecorder(),
+	}
+	newHead := [32]byte{'h'}
+	require.NoError(t, s.sendInvalidatedDuties(context.Background(), w, w, &ethpb.EventChainReorg{NewHeadBlock: newHead[:]}))
+
+	expected := fmt.Sprintf(`event: duties_invalidated
+data: {"epoch":"2","proposer_duties_changed":true,"attester_duties_changed":false,"proposer_dependent_root":"%[1]s","attester_dependent_root":"%[2]s","new_head_block":"%[4]s"}
+
+event: duties_invalidated
+data: {"epoch":"3","proposer_duties_changed":false,"attester_duties_changed":true,"proposer_dependent_root":"%[3]s","attester_dependent_root":"%[1]s","new_head_block":"%[4]s"}
+
+`, hexutil.Encode(rootD[:]), hexutil.Encode(rootB[:]), hexutil.Encode(rootC[:]), hexutil.Encode(newHead[:]))
+	assert.Equal(t, expected, w.Body.String())
+}
+
+func TestStreamEvents_LifecycleEvents(t *testing.T) {
+	s := &Server{
+		StateNotifier:     &mockChain.MockStateNotifier{},
+		OperationNotifier: &mockChain.MockOperationNotifier{},
+		BlockNotifier:     &mockChain.MockBlockNotifier{},
+	}
+
+	topics := []string{BlockGossipTopic, BlobSidecarAvailabilityTopic, DataColumnAvailabilityTopic, ForkChoiceJustifiedTopic, ExecutionPayloadInvalidTopic}
+	for i, topic := range topics {
+		topics[i] = "topics=" + topic
+	}
+	request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://example.com/eth/v1/events?%s&validator_indices=1,2", strings.Join(topics, "&")), nil)
+	w := &flushableResponseRecorder{
+		ResponseRecorder: httptest.NewRecorder(),
+	}
+
+	go func() {
+		s.StreamEvents(w, request)
+	}()
+	// wait for initiation of StreamEvents
+	time.Sleep(100 * time.Millisecond)
+	for _, proposer := range []primitives.ValidatorIndex{1, 3} {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = 5
+		b.Block.ProposerIndex = proposer
+		sb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		s.BlockNotifier.BlockFeed().Send(&feed.Event{
+			Type: blockfeed.GossipValidatedBlock,
+			Data: &blockfeed.GossipValidatedBlockData{SignedBlock: sb, BlockRoot: [32]byte{'a'}},
+		})
+	}
+	s.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.DataAvailable,
+		Data: &statefeed.DataAvailableData{
+			Slot:          5,
+			BlockRoot:     [32]byte{'a'},
+			ProposerIndex: 2,
+			BlobCount:     3,
+			WaitedTime:    40 * time.Millisecond,
+		},
+	})
+	s.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.DataAvailable,
+		Data: &statefeed.DataAvailableData{
+			Slot:          6,
+			BlockRoot:     [32]byte{'b'},
+			ProposerIndex: 1,
+			BlobCount:     3,
+			DataColumns:   []uint64{4, 70},
+			WaitedTime:    50 * time.Millisecond,
+		},
+	})
+	s.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.JustifiedCheckpoint,
+		Data: &statefeed.JustifiedCheckpointData{Epoch: 4, Root: [32]byte{'j'}},
+	})
+	s.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.InvalidExecutionPayload,
+		Data: &statefeed.InvalidExecutionPayloadData{
+			Slot:            6,
+			BlockRoot:       [32]byte{'b'},
+			ProposerIndex:   1,
+			BlockHash:       [32]byte{'h'},
+			LatestValidHash: [32]byte{'l'},
+		},
+	})
+
+	// wait for feed
+	time.Sleep(1 * time.Second)
+	request.Context().Done()
+
+	resp := w.Result()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	rootA, rootB, rootJ, hashH, hashL := [32]byte{'a'}, [32]byte{'b'}, [32]byte{'j'}, [32]byte{'h'}, [32]byte{'l'}
+	expected := fmt.Sprintf(`:
+
+event: block_gossip
+data: {"slot":"5","block":"%[1]s","proposer_index":"1"}
+
+event: blob_sidecar_availability
+data: {"slot":"5","block":"%[1]s","proposer_index":"2","blob_count":"3","waited_time_ms":"40"}
+
+event: data_column_availability
+data: {"slot":"6","block":"%[3]s","proposer_index":"1","columns":["4","70"],"waited_time_ms":"50"}
+
+event: fork_choice_justified
+data: {"block":"%[2]s","epoch":"4"}
+
+event: execution_payload_invalid
+data: {"slot":"6","block":"%[3]s","proposer_index":"1","block_hash":"%[4]s","latest_valid_hash":"%[5]s"}
+
+`, hexutil.Encode(rootA[:]), hexutil.Encode(rootJ[:]), hexutil.Encode(rootB[:]), hexutil.Encode(hashH[:]), hexutil.Encode(hashL[:]))
+	assert.Equal(t, expected, string(body))
+}
+
+func TestStreamEvents_ValidatorStatusChangeRequiresIndices(t *testing.T) {
+	s := &Server{
+		StateNotifier:     &mockChain.MockStateNotifier{},
+		OperationNotifier: &mockChain.MockOperationNotifier{},
+		BlockNotifier:     &mockChain.MockBlockNotifier{},
+	}
+	request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://example.com/eth/v1/events?topics=%s", ValidatorStatusChangeTopic), nil)
+	w := &flushableResponseRecorder{
+		ResponseRecorder: httptest.NewRecorder(),
+	}
+	s.StreamEvents(w, request)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.StringContains(t, "validator_indices must be specified", w.Body.String())
+
+	request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://example.com/eth/v1/events?topics=%s&validator_indices=foo", ValidatorStatusChangeTopic), nil)
+	w = &flushableResponseRecorder{
+		ResponseRecorder: httptest.NewRecorder(),
+	}
+	s.StreamEvents(w, request)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.StringContains(t, "invalid validator index foo", w.Body.String())
+}
+
+func TestSendValidatorStatusChanges(t *testing.T) {
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	require.NoError(t, st.SetValidators([]*eth.Validator{
+		{ActivationEpoch: 0, ExitEpoch: farFuture, WithdrawableEpoch: farFuture},
+		{ActivationEpoch: 0, ExitEpoch: farFuture, WithdrawableEpoch: farFuture},
+		{ActivationEpoch: 0, ExitEpoch: farFuture, WithdrawableEpoch: farFuture},
+	}))
+	s := &Server{HeadFetcher: &mockChain.ChainService{State: st}}
+	filters := &streamFilters{
+		validators: map[primitives.ValidatorIndex]bool{0: true, 1: true, 10: true},
+		statuses:   make(map[primitives.ValidatorIndex]validator.Status),
+	}
+	w := &flushableResponseRecorder{
+		ResponseRecorder: httptest.NewRecorder(),
+	}
+
+	// The first observation only records the statuses.
+	require.NoError(t, s.sendValidatorStatusChanges(context.Background(), w, w, filters))
+	assert.Equal(t, "", w.Body.String())
+
+	v, err := st.ValidatorAtIndex(1)
+	require.NoError(t, err)
+	v.Slashed = true
+	v.ExitEpoch = 5
+	require.NoError(t, st.UpdateValidatorAtIndex(1, v))
+	v, err = st.ValidatorAtIndex(2)
+	require.NoError(t, err)
+	v.ExitEpoch = 5
+	require.NoError(t, st.UpdateValidatorAtIndex(2, v))
+
+	require.NoError(t, s.sendValidatorStatusChanges(context.Background(), w, w, filters))
+	assert.Equal(t, `event: validator_status_change
+data: {"validator_index":"1","epoch":"0","previous_status":"active_ongoing","status":"active_slashed"}
+
+`, w.Body.String())
+}
+
+func TestStreamEvents_SlasherTopics(t *testing.T) {
+	s := &Server{
+		StateNotifier:     &mockChain.MockStateNotifier{},
+		OperationNotifier: &mockChain.MockOperationNotifier{},
+		BlockNotifier:     &mockChain.MockBlockNotifier{},
+	}
+	url := fmt.Sprintf("http://example.com/eth/v1/events?topics=%s&topics=%s", SlasherAttestationTopic, SlasherBlockHeaderTopic)
+	w := &flushableResponseRecorder{
+		ResponseRecorder: httptest.NewRecorder(),
+	}
+	s.StreamEvents(w, httptest.NewRequest(http.MethodGet, url, nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.StringContains(t, "Slasher topics require", w.Body.String())
+
+	s.SlasherAttestationsFeed = new(event.Feed)
+	s.SlasherBlockHeadersFeed = new(event.Feed)
+	ctx, cancel := context.WithCancel(context.Background())
+	request := httptest.NewRequest(http.MethodGet, url, nil).WithContext(ctx)
+	w = &flushableResponseRecorder{
+		ResponseRecorder: httptest.NewRecorder(),
+	}
+	done := make(chan struct{})
+	go func() {
+		s.StreamEvents(w, request)
+		close(done)
+	}()
+	// wait for initiation of StreamEvents
+	time.Sleep(100 * time.Millisecond)
+	att := util.HydrateIndexedAttestation(&eth.IndexedAttestation{AttestingIndices: []uint64{1, 2}})
+	s.SlasherAttestationsFeed.Send(&slashertypes.WrappedIndexedAtt{IndexedAtt: att})
+	attElectra := &eth.IndexedAttestationElectra{AttestingIndices: []uint64{3}, Data: att.Data, Signature: att.Signature}
+	s.SlasherAttestationsFeed.Send(&slashertypes.WrappedIndexedAtt{IndexedAtt: attElectra})
+	header := util.HydrateSignedBeaconHeader(&eth.SignedBeaconBlockHeader{Header: &eth.BeaconBlockHeader{Slot: 5, ProposerIndex: 7}})
+	s.SlasherBlockHeadersFeed.Send(header)
+	// wait for feed
+	time.Sleep(500 * time.Millisecond)
+	cancel()
+	<-done
+
+	body := w.Body.String()
+	assert.StringContains(t, `event: slasher_attestation
+data: {"version":"phase0","data":{"attesting_indices":["1","2"]`, body)
+	assert.StringContains(t, `event: slasher_attestation
+data: {"version":"electra","data":{"attesting_indices":["3"]`, body)
+	assert.StringContains(t, `event: slasher_block_header
+data: {"message":{"slot":"5","proposer_index":"7"`, body)
+}
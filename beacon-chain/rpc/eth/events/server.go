@@ -4,8 +4,10 @@
 package events
 
 import (
+	"github.com/prysmaticlabs/prysm/v5/async/event"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	blockfeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/block"
 	opfeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
 )
@@ -15,7 +17,13 @@ import (
 type Server struct {
 	StateNotifier          statefeed.Notifier
 	OperationNotifier      opfeed.Notifier
+	BlockNotifier          blockfeed.Notifier
 	HeadFetcher            blockchain.HeadFetcher
 	ChainInfoFetcher       blockchain.ChainInfoFetcher
 	TrackedValidatorsCache *cache.TrackedValidatorsCache
+	ServedDutiesCache      *cache.ServedDutiesCache
+	// SlasherAttestationsFeed and SlasherBlockHeadersFeed carry the inputs of a slasher. They are nil unless the
+	// beacon node collects them, in which case they are served to a standalone slasher on the slasher topics.
+	SlasherAttestationsFeed *event.Feed
+	SlasherBlockHeadersFeed *event.Feed
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -132,6 +133,9 @@ func TestLightClientHandler_GetLightClientBootstrap_Capella(t *testing.T) {
 	err = json.Unmarshal(resp.Data.Header, &respHeader)
 	require.NoError(t, err)
 	require.Equal(t, "capella", resp.Version)
+	bootstrap, err := resp.ToConsensus()
+	require.NoError(t, err)
+	require.NoError(t, lightclient.IsValidLightClientHeader(bootstrap.Header))
 	require.Equal(t, hexutil.Encode(header.Header.BodyRoot), respHeader.Beacon.BodyRoot)
 	require.NotNil(t, resp.Data)
 }
@@ -187,6 +191,9 @@ func TestLightClientHandler_GetLightClientBootstrap_Deneb(t *testing.T) {
 	err = json.Unmarshal(resp.Data.Header, &respHeader)
 	require.NoError(t, err)
 	require.Equal(t, "deneb", resp.Version)
+	bootstrap, err := resp.ToConsensus()
+	require.NoError(t, err)
+	require.NoError(t, lightclient.IsValidLightClientHeader(bootstrap.Header))
 	require.Equal(t, hexutil.Encode(header.Header.BodyRoot), respHeader.Beacon.BodyRoot)
 	require.NotNil(t, resp.Data)
 }
@@ -1359,6 +1366,10 @@ func TestLightClientHandler_GetLightClientFinalityUpdateCapella(t *testing.T) {
 	err = json.Unmarshal(resp.Data.AttestedHeader, &respHeader)
 	require.NoError(t, err)
 	require.Equal(t, "capella", resp.Version)
+	update, err := resp.ToConsensus()
+	require.NoError(t, err)
+	require.NoError(t, lightclient.IsValidLightClientHeader(update.AttestedHeader))
+	require.NoError(t, lightclient.IsValidLightClientHeader(update.FinalizedHeader))
 	require.Equal(t, hexutil.Encode(attestedHeader.BodyRoot), respHeader.Beacon.BodyRoot)
 	require.NotNil(t, resp.Data)
 }
@@ -1469,6 +1480,10 @@ func TestLightClientHandler_GetLightClientFinalityUpdateDeneb(t *testing.T) {
 	err = json.Unmarshal(resp.Data.AttestedHeader, &respHeader)
 	require.NoError(t, err)
 	require.Equal(t, "deneb", resp.Version)
+	update, err := resp.ToConsensus()
+	require.NoError(t, err)
+	require.NoError(t, lightclient.IsValidLightClientHeader(update.AttestedHeader))
+	require.NoError(t, lightclient.IsValidLightClientHeader(update.FinalizedHeader))
 	require.Equal(t, hexutil.Encode(attestedHeader.BodyRoot), respHeader.Beacon.BodyRoot)
 	require.NotNil(t, resp.Data)
 }
@@ -1689,6 +1704,9 @@ func TestLightClientHandler_GetLightClientOptimisticUpdateCapella(t *testing.T)
 	err = json.Unmarshal(resp.Data.AttestedHeader, &respHeader)
 	require.NoError(t, err)
 	require.Equal(t, "capella", resp.Version)
+	update, err := resp.ToConsensus()
+	require.NoError(t, err)
+	require.NoError(t, lightclient.IsValidLightClientHeader(update.AttestedHeader))
 	require.Equal(t, hexutil.Encode(attestedHeader.BodyRoot), respHeader.Beacon.BodyRoot)
 	require.NotNil(t, resp.Data)
 }
@@ -1799,6 +1817,9 @@ func TestLightClientHandler_GetLightClientOptimisticUpdateDeneb(t *testing.T) {
 	err = json.Unmarshal(resp.Data.AttestedHeader, &respHeader)
 	require.NoError(t, err)
 	require.Equal(t, "deneb", resp.Version)
+	update, err := resp.ToConsensus()
+	require.NoError(t, err)
+	require.NoError(t, lightclient.IsValidLightClientHeader(update.AttestedHeader))
 	require.Equal(t, hexutil.Encode(attestedHeader.BodyRoot), respHeader.Beacon.BodyRoot)
 	require.NotNil(t, resp.Data)
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"reflect"
 	"strconv"
 
 	"github.com/pkg/errors"
@@ -21,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
 	v1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	v2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
 	"github.com/prysmaticlabs/prysm/v5/proto/migration"
@@ -180,22 +180,25 @@ func createLightClientBootstrapCapella(ctx context.Context, state state.BeaconSt
 		}
 		withdrawalsRoot = withdrawalsRootArray[:]
 	}
-	executionPayloadHeader := &structs.ExecutionPayloadHeaderCapella{
-		ParentHash:       hexutil.Encode(payloadInterface.ParentHash()),
-		FeeRecipient:     hexutil.Encode(payloadInterface.FeeRecipient()),
-		StateRoot:        hexutil.Encode(payloadInterface.StateRoot()),
-		ReceiptsRoot:     hexutil.Encode(payloadInterface.ReceiptsRoot()),
-		LogsBloom:        hexutil.Encode(payloadInterface.LogsBloom()),
-		PrevRandao:       hexutil.Encode(payloadInterface.PrevRandao()),
-		BlockNumber:      hexutil.EncodeUint64(payloadInterface.BlockNumber()),
-		GasLimit:         hexutil.EncodeUint64(payloadInterface.GasLimit()),
-		GasUsed:          hexutil.EncodeUint64(payloadInterface.GasUsed()),
-		Timestamp:        hexutil.EncodeUint64(payloadInterface.Timestamp()),
-		ExtraData:        hexutil.Encode(payloadInterface.ExtraData()),
-		BaseFeePerGas:    hexutil.Encode(payloadInterface.BaseFeePerGas()),
-		BlockHash:        hexutil.Encode(payloadInterface.BlockHash()),
-		TransactionsRoot: hexutil.Encode(transactionsRoot),
-		WithdrawalsRoot:  hexutil.Encode(withdrawalsRoot),
+	executionPayloadHeader, err := structs.ExecutionPayloadHeaderCapellaFromConsensus(&enginev1.ExecutionPayloadHeaderCapella{
+		ParentHash:       payloadInterface.ParentHash(),
+		FeeRecipient:     payloadInterface.FeeRecipient(),
+		StateRoot:        payloadInterface.StateRoot(),
+		ReceiptsRoot:     payloadInterface.ReceiptsRoot(),
+		LogsBloom:        payloadInterface.LogsBloom(),
+		PrevRandao:       payloadInterface.PrevRandao(),
+		BlockNumber:      payloadInterface.BlockNumber(),
+		GasLimit:         payloadInterface.GasLimit(),
+		GasUsed:          payloadInterface.GasUsed(),
+		Timestamp:        payloadInterface.Timestamp(),
+		ExtraData:        payloadInterface.ExtraData(),
+		BaseFeePerGas:    payloadInterface.BaseFeePerGas(),
+		BlockHash:        payloadInterface.BlockHash(),
+		TransactionsRoot: transactionsRoot,
+		WithdrawalsRoot:  withdrawalsRoot,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert execution payload header")
 	}
 
 	executionPayloadProof, err := blocks.PayloadProof(ctx, block)
@@ -296,22 +299,35 @@ func createLightClientBootstrapDeneb(ctx context.Context, state state.BeaconStat
 		}
 		withdrawalsRoot = withdrawalsRootArray[:]
 	}
-	executionPayloadHeader := &structs.ExecutionPayloadHeaderDeneb{
-		ParentHash:       hexutil.Encode(payloadInterface.ParentHash()),
-		FeeRecipient:     hexutil.Encode(payloadInterface.FeeRecipient()),
-		StateRoot:        hexutil.Encode(payloadInterface.StateRoot()),
-		ReceiptsRoot:     hexutil.Encode(payloadInterface.ReceiptsRoot()),
-		LogsBloom:        hexutil.Encode(payloadInterface.LogsBloom()),
-		PrevRandao:       hexutil.Encode(payloadInterface.PrevRandao()),
-		BlockNumber:      hexutil.EncodeUint64(payloadInterface.BlockNumber()),
-		GasLimit:         hexutil.EncodeUint64(payloadInterface.GasLimit()),
-		GasUsed:          hexutil.EncodeUint64(payloadInterface.GasUsed()),
-		Timestamp:        hexutil.EncodeUint64(payloadInterface.Timestamp()),
-		ExtraData:        hexutil.Encode(payloadInterface.ExtraData()),
-		BaseFeePerGas:    hexutil.Encode(payloadInterface.BaseFeePerGas()),
-		BlockHash:        hexutil.Encode(payloadInterface.BlockHash()),
-		TransactionsRoot: hexutil.Encode(transactionsRoot),
-		WithdrawalsRoot:  hexutil.Encode(withdrawalsRoot),
+	blobGasUsed, err := payloadInterface.BlobGasUsed()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get blob gas used")
+	}
+	excessBlobGas, err := payloadInterface.ExcessBlobGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get excess blob gas")
+	}
+	executionPayloadHeader, err := structs.ExecutionPayloadHeaderDenebFromConsensus(&enginev1.ExecutionPayloadHeaderDeneb{
+		ParentHash:       payloadInterface.ParentHash(),
+		FeeRecipient:     payloadInterface.FeeRecipient(),
+		StateRoot:        payloadInterface.StateRoot(),
+		ReceiptsRoot:     payloadInterface.ReceiptsRoot(),
+		LogsBloom:        payloadInterface.LogsBloom(),
+		PrevRandao:       payloadInterface.PrevRandao(),
+		BlockNumber:      payloadInterface.BlockNumber(),
+		GasLimit:         payloadInterface.GasLimit(),
+		GasUsed:          payloadInterface.GasUsed(),
+		Timestamp:        payloadInterface.Timestamp(),
+		ExtraData:        payloadInterface.ExtraData(),
+		BaseFeePerGas:    payloadInterface.BaseFeePerGas(),
+		BlockHash:        payloadInterface.BlockHash(),
+		TransactionsRoot: transactionsRoot,
+		WithdrawalsRoot:  withdrawalsRoot,
+		BlobGasUsed:      blobGasUsed,
+		ExcessBlobGas:    excessBlobGas,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert execution payload header")
 	}
 
 	executionPayloadProof, err := blocks.PayloadProof(ctx, block)
@@ -466,6 +482,9 @@ func createLightClientUpdate(
 
 	result.NextSyncCommittee = nextSyncCommittee
 	result.NextSyncCommitteeBranch = nextSyncCommitteeBranch
+	if err := verifyLightClientUpdateBranches(result); err != nil {
+		return nil, errors.Wrap(err, "light client update self-check failed")
+	}
 	res, err := newLightClientUpdateToJSON(result)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not convert light client update to JSON")
@@ -473,6 +492,26 @@ func createLightClientUpdate(
 	return res, nil
 }
 
+// verifyLightClientUpdateBranches checks the next sync committee and finality branches of a locally
+// computed update against the attested state root, so that broken proofs are never served.
+func verifyLightClientUpdateBranches(update *v2.LightClientUpdate) error {
+	attested, err := update.AttestedHeader.GetBeacon()
+	if err != nil {
+		return errors.Wrap(err, "could not get attested header beacon")
+	}
+	if lightclient.IsSyncCommitteeUpdate(update) {
+		if err := lightclient.VerifyNextSyncCommitteeBranch(update.NextSyncCommittee, update.NextSyncCommitteeBranch, attested.StateRoot); err != nil {
+			return err
+		}
+	}
+	if lightclient.IsFinalityUpdate(update) {
+		if err := lightclient.VerifyFinalityBranch(update.FinalizedHeader, update.FinalityBranch, attested.StateRoot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func newLightClientFinalityUpdateFromBeaconState(
 	ctx context.Context,
 	state state.BeaconState,
@@ -572,82 +611,3 @@ func newLightClientUpdateToJSON(input *v2.LightClientUpdate) (*structs.LightClie
 
 	return result, nil
 }
-
-func IsSyncCommitteeUpdate(update *v2.LightClientUpdate) bool {
-	nextSyncCommitteeBranch := make([][]byte, fieldparams.NextSyncCommitteeBranchDepth)
-	return !reflect.DeepEqual(update.NextSyncCommitteeBranch, nextSyncCommitteeBranch)
-}
-
-func IsFinalityUpdate(update *v2.LightClientUpdate) bool {
-	finalityBranch := make([][]byte, lightclient.FinalityBranchNumOfLeaves)
-	return !reflect.DeepEqual(update.FinalityBranch, finalityBranch)
-}
-
-func IsBetterUpdate(newUpdate, oldUpdate *v2.LightClientUpdate) (bool, error) {
-	maxActiveParticipants := newUpdate.SyncAggregate.SyncCommitteeBits.Len()
-	newNumActiveParticipants := newUpdate.SyncAggregate.SyncCommitteeBits.Count()
-	oldNumActiveParticipants := oldUpdate.SyncAggregate.SyncCommitteeBits.Count()
-	newHasSupermajority := newNumActiveParticipants*3 >= maxActiveParticipants*2
-	oldHasSupermajority := oldNumActiveParticipants*3 >= maxActiveParticipants*2
-
-	if newHasSupermajority != oldHasSupermajority {
-		return newHasSupermajority, nil
-	}
-	if !newHasSupermajority && newNumActiveParticipants != oldNumActiveParticipants {
-		return newNumActiveParticipants > oldNumActiveParticipants, nil
-	}
-
-	newUpdateAttestedHeaderBeacon, err := newUpdate.AttestedHeader.GetBeacon()
-	if err != nil {
-		return false, errors.Wrap(err, "could not get attested header beacon")
-	}
-	oldUpdateAttestedHeaderBeacon, err := oldUpdate.AttestedHeader.GetBeacon()
-	if err != nil {
-		return false, errors.Wrap(err, "could not get attested header beacon")
-	}
-
-	// Compare presence of relevant sync committee
-	newHasRelevantSyncCommittee := IsSyncCommitteeUpdate(newUpdate) && (slots.SyncCommitteePeriod(slots.ToEpoch(newUpdateAttestedHeaderBeacon.Slot)) == slots.SyncCommitteePeriod(slots.ToEpoch(newUpdate.SignatureSlot)))
-	oldHasRelevantSyncCommittee := IsSyncCommitteeUpdate(oldUpdate) && (slots.SyncCommitteePeriod(slots.ToEpoch(oldUpdateAttestedHeaderBeacon.Slot)) == slots.SyncCommitteePeriod(slots.ToEpoch(oldUpdate.SignatureSlot)))
-
-	if newHasRelevantSyncCommittee != oldHasRelevantSyncCommittee {
-		return newHasRelevantSyncCommittee, nil
-	}
-
-	// Compare indication of any finality
-	newHasFinality := IsFinalityUpdate(newUpdate)
-	oldHasFinality := IsFinalityUpdate(oldUpdate)
-	if newHasFinality != oldHasFinality {
-		return newHasFinality, nil
-	}
-
-	newUpdateFinalizedHeaderBeacon, err := newUpdate.FinalizedHeader.GetBeacon()
-	if err != nil {
-		return false, errors.Wrap(err, "could not get finalized header beacon")
-	}
-	oldUpdateFinalizedHeaderBeacon, err := oldUpdate.FinalizedHeader.GetBeacon()
-	if err != nil {
-		return false, errors.Wrap(err, "could not get finalized header beacon")
-	}
-
-	// Compare sync committee finality
-	if newHasFinality {
-		newHasSyncCommitteeFinality := slots.SyncCommitteePeriod(slots.ToEpoch(newUpdateFinalizedHeaderBeacon.Slot)) == slots.SyncCommitteePeriod(slots.ToEpoch(newUpdateAttestedHeaderBeacon.Slot))
-		oldHasSyncCommitteeFinality := slots.SyncCommitteePeriod(slots.ToEpoch(oldUpdateFinalizedHeaderBeacon.Slot)) == slots.SyncCommitteePeriod(slots.ToEpoch(oldUpdateAttestedHeaderBeacon.Slot))
-
-		if newHasSyncCommitteeFinality != oldHasSyncCommitteeFinality {
-			return newHasSyncCommitteeFinality, nil
-		}
-	}
-
-	// Tiebreaker 1: Sync committee participation beyond supermajority
-	if newNumActiveParticipants != oldNumActiveParticipants {
-		return newNumActiveParticipants > oldNumActiveParticipants, nil
-	}
-
-	// Tiebreaker 2: Prefer older data (fewer changes to best)
-	if newUpdateAttestedHeaderBeacon.Slot != oldUpdateAttestedHeaderBeacon.Slot {
-		return newUpdateAttestedHeaderBeacon.Slot < oldUpdateAttestedHeaderBeacon.Slot, nil
-	}
-	return newUpdate.SignatureSlot < oldUpdate.SignatureSlot, nil
-}
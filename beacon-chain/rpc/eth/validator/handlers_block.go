@@ -166,6 +166,10 @@ func (s *Server) ProduceBlockV3(w http.ResponseWriter, r *http.Request) {
 	if rawBbFactor != "" {
 		bbFactor = &wrapperspb.UInt64Value{Value: bbValue}
 	}
+	builderPolicy, ok := builderPolicyFromQuery(w, r)
+	if !ok {
+		return
+	}
 
 	slot, valid := shared.ValidateUint(w, "slot", rawSlot)
 	if !valid {
@@ -199,9 +203,40 @@ func (s *Server) ProduceBlockV3(w http.ResponseWriter, r *http.Request) {
 		Graffiti:           graffiti,
 		SkipMevBoost:       false,
 		BuilderBoostFactor: bbFactor,
+		BuilderPolicy:      builderPolicy,
 	}, any)
 }
 
+// builderPolicyFromQuery reads the optional builder policy overrides of the proposing validator.
+// It returns nil if none of them is set.
+func builderPolicyFromQuery(w http.ResponseWriter, r *http.Request) (*eth.BuilderPolicy, bool) {
+	var policy *eth.BuilderPolicy
+	fields := []struct {
+		name string
+		set  func(*eth.BuilderPolicy, *uint64)
+	}{
+		{"local_block_value_boost", func(p *eth.BuilderPolicy, v *uint64) { p.LocalBlockValueBoost = v }},
+		{"min_builder_bid", func(p *eth.BuilderPolicy, v *uint64) { p.MinBuilderBid = v }},
+		{"min_builder_to_local_difference", func(p *eth.BuilderPolicy, v *uint64) { p.MinBuilderToLocalDifference = v }},
+		{"max_builder_consecutive_missed_slots", func(p *eth.BuilderPolicy, v *uint64) { p.MaxBuilderConsecutiveMissedSlots = v }},
+		{"max_builder_epoch_missed_slots", func(p *eth.BuilderPolicy, v *uint64) { p.MaxBuilderEpochMissedSlots = v }},
+	}
+	for _, f := range fields {
+		raw, v, ok := shared.UintFromQuery(w, r, f.name, false)
+		if !ok {
+			return nil, false
+		}
+		if raw == "" {
+			continue
+		}
+		if policy == nil {
+			policy = &eth.BuilderPolicy{}
+		}
+		f.set(policy, &v)
+	}
+	return policy, true
+}
+
 func (s *Server) produceBlockV3(ctx context.Context, w http.ResponseWriter, r *http.Request, v1alpha1req *eth.BlockRequest, requiredType blockType) {
 	isSSZ := httputil.RespondWithSsz(r)
 	v1alpha1resp, err := s.V1Alpha1Server.GetBeaconBlock(ctx, v1alpha1req)
@@ -1347,6 +1347,47 @@ func TestProduceBlockV3(t *testing.T) {
 		server.ProduceBlockV3(writer, request)
 		assert.Equal(t, http.StatusBadRequest, writer.Code)
 	})
+	t.Run("builder policy", func(t *testing.T) {
+		var block *structs.SignedBeaconBlock
+		err := json.Unmarshal([]byte(rpctesting.Phase0Block), &block)
+		require.NoError(t, err)
+		minBid, maxMissed := uint64(100), uint64(2)
+		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+		v1alpha1Server.EXPECT().GetBeaconBlock(gomock.Any(), &eth.BlockRequest{
+			Slot:         1,
+			RandaoReveal: bRandao,
+			Graffiti:     bGraffiti,
+			SkipMevBoost: false,
+			BuilderPolicy: &eth.BuilderPolicy{
+				MinBuilderBid:                    &minBid,
+				MaxBuilderConsecutiveMissedSlots: &maxMissed,
+			},
+		}).Return(
+			func() (*eth.GenericBeaconBlock, error) {
+				return block.Message.ToGeneric()
+			}())
+		server := &Server{
+			V1Alpha1Server: v1alpha1Server,
+			SyncChecker:    syncChecker,
+		}
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://foo.example/eth/v3/validator/blocks/1?randao_reveal=%s&graffiti=%s&min_builder_bid=100&max_builder_consecutive_missed_slots=2", randao, graffiti), nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.ProduceBlockV3(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+	})
+	t.Run("invalid query parameter min_builder_bid", func(t *testing.T) {
+		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+		server := &Server{
+			V1Alpha1Server: v1alpha1Server,
+			SyncChecker:    syncChecker,
+		}
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://foo.example/eth/v3/validator/blocks/1?randao_reveal=%s&min_builder_bid=foo", randao), nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.ProduceBlockV3(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
 	t.Run("syncing", func(t *testing.T) {
 		server := &Server{
 			SyncChecker:           &mockSync.Sync{IsSyncing: true},
@@ -0,0 +1,59 @@
+package node
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/bandwidth"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"go.opencensus.io/trace"
+)
+
+// ListPeerBandwidth retrieves the bandwidth used by each peer, by request/response protocol
+// and gossip topic, along with the load it put on the node against its quota.
+// Violations is the number of windows the peer went over its quota since it was first seen,
+// quota_violations the not yet decayed count the peer is scored on.
+func (s *Server) ListPeerBandwidth(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.ListPeerBandwidth")
+	defer span.End()
+
+	peerStatus := s.PeersFetcher.Peers()
+	usages := peerStatus.Bandwidth().Peers()
+	data := make([]*structs.PeerBandwidth, 0, len(usages))
+	for _, u := range usages {
+		// Peers that never went over quota are unknown to the scorer.
+		violations, err := peerStatus.Scorers().BandwidthScorer().Count(u.Peer)
+		if err != nil {
+			violations = 0
+		}
+		data = append(data, &structs.PeerBandwidth{
+			PeerId:          u.Peer.String(),
+			Class:           string(u.Class),
+			RpcQuota:        strconv.FormatUint(u.Quota.RPC, 10),
+			GossipQuota:     strconv.FormatUint(u.Quota.Gossip, 10),
+			RpcLoad:         strconv.FormatUint(u.Window.RPC, 10),
+			GossipLoad:      strconv.FormatUint(u.Window.Gossip, 10),
+			LastRpcLoad:     strconv.FormatUint(u.LastWindow.RPC, 10),
+			LastGossipLoad:  strconv.FormatUint(u.LastWindow.Gossip, 10),
+			BytesIn:         strconv.FormatUint(u.Total.In, 10),
+			BytesOut:        strconv.FormatUint(u.Total.Out, 10),
+			Violations:      strconv.FormatUint(u.Violations, 10),
+			QuotaViolations: strconv.Itoa(violations),
+			Protocols:       bandwidthTraffic(u.Protocols),
+			Topics:          bandwidthTraffic(u.Topics),
+		})
+	}
+	httputil.WriteJson(w, &structs.PeerBandwidthResponse{Data: data})
+}
+
+func bandwidthTraffic(traffic map[string]bandwidth.Traffic) map[string]*structs.BandwidthTraffic {
+	result := make(map[string]*structs.BandwidthTraffic, len(traffic))
+	for name, t := range traffic {
+		result[name] = &structs.BandwidthTraffic{
+			BytesIn:  strconv.FormatUint(t.In, 10),
+			BytesOut: strconv.FormatUint(t.Out, 10),
+		}
+	}
+	return result
+}
@@ -0,0 +1,54 @@
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	libp2ptest "github.com/libp2p/go-libp2p/p2p/host/peerstore/test"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/bandwidth"
+	mockp2p "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestListPeerBandwidth(t *testing.T) {
+	const (
+		protocol = "/eth2/beacon_chain/req/beacon_blocks_by_range/2/ssz_snappy"
+		topic    = "/eth2/6a95a1a9/beacon_block/ssz_snappy"
+	)
+	ids := libp2ptest.GeneratePeerIDs(1)
+	peerFetcher := &mockp2p.MockPeersProvider{}
+	peerFetcher.ClearPeers()
+	accountant := peerFetcher.Peers().Bandwidth()
+	accountant.RecordStream(ids[0], protocol, true, true, 100)
+	accountant.RecordStream(ids[0], protocol, true, false, bandwidth.DefaultRPCQuota)
+	accountant.RecordGossip(ids[0], topic, true, 50)
+	peerFetcher.Peers().EndBandwidthWindow()
+	accountant.RecordGossip(ids[0], topic, false, 70)
+	s := Server{PeersFetcher: peerFetcher}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/peer_bandwidth", nil)
+	writer := httptest.NewRecorder()
+	s.ListPeerBandwidth(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.PeerBandwidthResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data))
+	usage := resp.Data[0]
+	assert.Equal(t, ids[0].String(), usage.PeerId)
+	assert.Equal(t, "default", usage.Class)
+	assert.Equal(t, "0", usage.RpcLoad)
+	assert.Equal(t, "268435556", usage.LastRpcLoad)
+	assert.Equal(t, "50", usage.LastGossipLoad)
+	assert.Equal(t, "150", usage.BytesIn)
+	assert.Equal(t, "268435526", usage.BytesOut)
+	assert.Equal(t, "1", usage.Violations)
+	assert.Equal(t, "1", usage.QuotaViolations)
+	require.NotNil(t, usage.Protocols[protocol])
+	assert.Equal(t, "100", usage.Protocols[protocol].BytesIn)
+	require.NotNil(t, usage.Topics[topic])
+	assert.Equal(t, "70", usage.Topics[topic].BytesOut)
+}
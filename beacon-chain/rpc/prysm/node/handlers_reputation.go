@@ -0,0 +1,136 @@
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"go.opencensus.io/trace"
+)
+
+// ListPeerReputations retrieves the reputation of all peers known to the node,
+// including the ones restored from the peer database and banned peers.
+func (s *Server) ListPeerReputations(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.ListPeerReputations")
+	defer span.End()
+
+	peerStatus := s.PeersFetcher.Peers()
+	now := time.Now()
+	records := peerStatus.Records()
+	data := make([]*structs.PeerReputation, 0, len(records))
+	for _, record := range records {
+		pid, err := peer.Decode(record.ID)
+		if err != nil {
+			httputil.HandleError(w, errors.Wrapf(err, "Could not decode peer id %s", record.ID).Error(), http.StatusInternalServerError)
+			return
+		}
+		state, err := peerStatus.ConnectionState(pid)
+		if err != nil && !errors.Is(err, peerdata.ErrPeerUnknown) {
+			httputil.HandleError(w, errors.Wrap(err, "Could not obtain connection state").Error(), http.StatusInternalServerError)
+			return
+		}
+		rep := &structs.PeerReputation{
+			PeerId:             record.ID,
+			LastSeenP2PAddress: record.Address,
+			State:              eth.ConnectionState(state).String(),
+			LastSeen:           unixString(record.LastSeen),
+			Score:              strconv.FormatFloat(record.Score, 'f', -1, 64),
+			BadResponses:       strconv.Itoa(record.BadResponses),
+			ProcessedBlocks:    strconv.FormatUint(record.ProcessedBlocks, 10),
+			GossipScore:        strconv.FormatFloat(record.GossipScore, 'f', -1, 64),
+			BehaviourPenalty:   strconv.FormatFloat(record.BehaviourPenalty, 'f', -1, 64),
+			Banned:             record.ActiveBan(now) != nil,
+			Bans:               make([]*structs.PeerBan, 0, len(record.Bans)),
+		}
+		if record, err := peerStatus.ENR(pid); err == nil && record != nil {
+			if serializedEnr, err := p2p.SerializeENR(record); err == nil {
+				rep.Enr = "enr:" + serializedEnr
+			}
+		}
+		for _, b := range record.Bans {
+			rep.Bans = append(rep.Bans, &structs.PeerBan{
+				Reason: b.Reason,
+				Start:  unixString(b.Start),
+				Until:  unixString(b.Until),
+				Lifted: unixString(b.Lifted),
+			})
+		}
+		data = append(data, rep)
+	}
+	httputil.WriteJson(w, &structs.PeerReputationsResponse{Data: data})
+}
+
+// BanPeer bans a peer for the requested number of seconds, or indefinitely if no duration is given,
+// and disconnects from it. Bans are persisted across restarts and take precedence over trusted peers.
+func (s *Server) BanPeer(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.BanPeer")
+	defer span.End()
+
+	var req structs.BanPeerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not decode request body").Error(), http.StatusBadRequest)
+		return
+	}
+	pid, err := peer.Decode(req.PeerId)
+	if err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not decode peer id").Error(), http.StatusBadRequest)
+		return
+	}
+	var duration time.Duration
+	if req.Duration != "" {
+		seconds, err := strconv.ParseUint(req.Duration, 10, 32)
+		if err != nil {
+			httputil.HandleError(w, errors.Wrap(err, "Could not parse duration").Error(), http.StatusBadRequest)
+			return
+		}
+		duration = time.Duration(seconds) * time.Second
+	}
+
+	peerStatus := s.PeersFetcher.Peers()
+	peerStatus.Ban(pid, duration, req.Reason)
+	if state, err := peerStatus.ConnectionState(pid); err == nil && state != peers.PeerDisconnected && s.PeerManager != nil {
+		if err := s.PeerManager.Disconnect(pid); err != nil {
+			log.WithError(err).WithField("peerID", pid).Debug("Could not disconnect from banned peer")
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// UnbanPeer lifts the ban in force for a peer, if any.
+func (s *Server) UnbanPeer(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.UnbanPeer")
+	defer span.End()
+
+	segments := strings.Split(r.URL.Path, "/")
+	pid, err := peer.Decode(segments[len(segments)-1])
+	if err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not decode peer id").Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := s.PeersFetcher.Peers().Unban(pid); err != nil {
+		if errors.Is(err, peerdata.ErrPeerUnknown) {
+			httputil.HandleError(w, "Peer not found", http.StatusNotFound)
+			return
+		}
+		httputil.HandleError(w, errors.Wrap(err, "Could not unban peer").Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func unixString(t time.Time) string {
+	if t.IsZero() {
+		return "0"
+	}
+	return strconv.FormatInt(t.Unix(), 10)
+}
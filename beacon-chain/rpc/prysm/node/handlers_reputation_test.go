@@ -0,0 +1,83 @@
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corenet "github.com/libp2p/go-libp2p/core/network"
+	libp2ptest "github.com/libp2p/go-libp2p/p2p/host/peerstore/test"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	mockp2p "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestBanUnbanPeer(t *testing.T) {
+	ids := libp2ptest.GeneratePeerIDs(2)
+	peerFetcher := &mockp2p.MockPeersProvider{}
+	peerFetcher.ClearPeers()
+	addr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/13000")
+	require.NoError(t, err)
+	peerFetcher.Peers().Add(nil, ids[0], addr, corenet.DirOutbound)
+	peerFetcher.Peers().SetConnectionState(ids[0], peers.PeerConnected)
+	s := Server{PeersFetcher: peerFetcher, PeerManager: &mockp2p.MockPeerManager{}}
+
+	t.Run("ban", func(t *testing.T) {
+		body, err := json.Marshal(&structs.BanPeerRequest{PeerId: ids[0].String(), Duration: "3600", Reason: "spam"})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/node/banned_peers", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		s.BanPeer(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, true, peerFetcher.Peers().IsBanned(ids[0]))
+	})
+	t.Run("ban invalid duration", func(t *testing.T) {
+		body, err := json.Marshal(&structs.BanPeerRequest{PeerId: ids[1].String(), Duration: "-1"})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/node/banned_peers", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		s.BanPeer(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.Equal(t, false, peerFetcher.Peers().IsBanned(ids[1]))
+	})
+	t.Run("list", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/peer_reputations", nil)
+		writer := httptest.NewRecorder()
+		s.ListPeerReputations(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.PeerReputationsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		rep := resp.Data[0]
+		assert.Equal(t, ids[0].String(), rep.PeerId)
+		assert.Equal(t, addr.String(), rep.LastSeenP2PAddress)
+		assert.Equal(t, "CONNECTED", rep.State)
+		assert.NotEqual(t, "0", rep.LastSeen)
+		assert.Equal(t, true, rep.Banned)
+		require.Equal(t, 1, len(rep.Bans))
+		assert.Equal(t, "spam", rep.Bans[0].Reason)
+		assert.Equal(t, "0", rep.Bans[0].Lifted)
+	})
+	t.Run("unban", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, "http://example.com/prysm/v1/node/banned_peers/"+ids[0].String(), nil)
+		writer := httptest.NewRecorder()
+		s.UnbanPeer(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, false, peerFetcher.Peers().IsBanned(ids[0]))
+	})
+	t.Run("unban unknown peer", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, "http://example.com/prysm/v1/node/banned_peers/"+ids[1].String(), nil)
+		writer := httptest.NewRecorder()
+		s.UnbanPeer(writer, request)
+		require.Equal(t, http.StatusNotFound, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusNotFound, e.Code)
+	})
+}
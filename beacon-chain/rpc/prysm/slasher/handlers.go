@@ -0,0 +1,207 @@
+package slasher
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"go.opencensus.io/trace"
+)
+
+// maxEpochsPerRequest is the maximum number of epochs of the slasher history that can be requested at once.
+const maxEpochsPerRequest = 1024
+
+// GetSpans returns the min and max spans of a validator stored by the slasher between the start and end epochs.
+// The epochs default to the most recent epochs of the history kept for the validator.
+func (s *Server) GetSpans(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.GetSpans")
+	defer span.End()
+
+	validatorIndex, startEpoch, endEpoch, ok := s.historyRequest(w, r)
+	if !ok {
+		return
+	}
+	spans, err := s.Slasher.Spans(ctx, validatorIndex, startEpoch, endEpoch)
+	if err != nil {
+		handleSlasherError(w, errors.Wrap(err, "Could not get spans"))
+		return
+	}
+	data := make([]*structs.SlasherEpochSpans, len(spans))
+	for i, sp := range spans {
+		data[i] = &structs.SlasherEpochSpans{
+			Epoch:   fmt.Sprintf("%d", sp.Epoch),
+			MinSpan: fmt.Sprintf("%d", sp.MinSpan),
+			MaxSpan: fmt.Sprintf("%d", sp.MaxSpan),
+		}
+	}
+	httputil.WriteJson(w, &structs.GetSlasherSpansResponse{Data: data})
+}
+
+// GetAttestations returns the attestations of a validator recorded by the slasher with target epochs between
+// the start and end epochs. The epochs default to the most recent epochs of the history kept for the validator.
+func (s *Server) GetAttestations(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.GetAttestations")
+	defer span.End()
+
+	validatorIndex, startEpoch, endEpoch, ok := s.historyRequest(w, r)
+	if !ok {
+		return
+	}
+	records, err := s.Slasher.AttestationRecords(ctx, validatorIndex, startEpoch, endEpoch)
+	if err != nil {
+		handleSlasherError(w, errors.Wrap(err, "Could not get attestation records"))
+		return
+	}
+	data := make([]*structs.SlasherAttestationRecord, 0, len(records))
+	for _, record := range records {
+		att, ok := record.IndexedAttestation.(*ethpb.IndexedAttestation)
+		if !ok {
+			httputil.HandleError(w, fmt.Sprintf("Unsupported attestation type %T", record.IndexedAttestation), http.StatusInternalServerError)
+			return
+		}
+		data = append(data, &structs.SlasherAttestationRecord{
+			DataRoot:    hexutil.Encode(record.DataRoot[:]),
+			Attestation: structs.IndexedAttFromConsensus(att),
+		})
+	}
+	httputil.WriteJson(w, &structs.GetSlasherAttestationsResponse{Data: data})
+}
+
+// GetSlashings returns the attester and proposer slashings of a validator detected by the slasher,
+// each with both conflicting messages.
+func (s *Server) GetSlashings(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.GetSlashings")
+	defer span.End()
+
+	_, validatorIndex, ok := shared.UintFromRoute(w, r, "validator_index")
+	if !ok {
+		return
+	}
+	attesterSlashings, proposerSlashings, err := s.Slasher.Slashings(ctx, primitives.ValidatorIndex(validatorIndex))
+	if err != nil {
+		handleSlasherError(w, errors.Wrap(err, "Could not get slashings"))
+		return
+	}
+	data := &structs.SlasherSlashings{
+		AttesterSlashings: make([]*structs.AttesterSlashing, 0, len(attesterSlashings)),
+		ProposerSlashings: make([]*structs.ProposerSlashing, 0, len(proposerSlashings)),
+	}
+	for _, slashing := range attesterSlashings {
+		sl, ok := slashing.(*ethpb.AttesterSlashing)
+		if !ok {
+			httputil.HandleError(w, fmt.Sprintf("Unsupported attester slashing type %T", slashing), http.StatusInternalServerError)
+			return
+		}
+		data.AttesterSlashings = append(data.AttesterSlashings, structs.AttesterSlashingFromConsensus(sl))
+	}
+	for _, slashing := range proposerSlashings {
+		data.ProposerSlashings = append(data.ProposerSlashings, structs.ProposerSlashingFromConsensus(slashing))
+	}
+	httputil.WriteJson(w, &structs.GetSlasherSlashingsResponse{Data: data})
+}
+
+// CheckAttestation returns the attester slashings the indexed attestation in the request body would cause,
+// running the double and surround vote checks of the slasher. This is a dry run, nothing is saved.
+// The signature of the attestation is not verified.
+func (s *Server) CheckAttestation(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.CheckAttestation")
+	defer span.End()
+
+	var req structs.IndexedAttestation
+	err := json.NewDecoder(r.Body).Decode(&req)
+	switch {
+	case errors.Is(err, io.EOF):
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	case err != nil:
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	att, err := req.ToConsensus()
+	if err != nil {
+		httputil.HandleError(w, "Could not convert request attestation to consensus attestation: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	slashings, err := s.Slasher.CheckAttestation(ctx, att)
+	if err != nil {
+		handleSlasherError(w, errors.Wrap(err, "Could not check attestation"))
+		return
+	}
+	data := make([]*structs.AttesterSlashing, 0, len(slashings))
+	for _, slashing := range slashings {
+		sl, ok := slashing.(*ethpb.AttesterSlashing)
+		if !ok {
+			httputil.HandleError(w, fmt.Sprintf("Unsupported attester slashing type %T", slashing), http.StatusInternalServerError)
+			return
+		}
+		data = append(data, structs.AttesterSlashingFromConsensus(sl))
+	}
+	httputil.WriteJson(w, &structs.CheckSlasherAttestationResponse{Slashable: len(data) > 0, Data: data})
+}
+
+// historyRequest parses the validator index and the epochs of a request for the history of a validator.
+func (s *Server) historyRequest(w http.ResponseWriter, r *http.Request) (primitives.ValidatorIndex, primitives.Epoch, primitives.Epoch, bool) {
+	_, rawIndex, ok := shared.UintFromRoute(w, r, "validator_index")
+	if !ok {
+		return 0, 0, 0, false
+	}
+	rawStart, start, ok := shared.UintFromQuery(w, r, "start_epoch", false)
+	if !ok {
+		return 0, 0, 0, false
+	}
+	rawEnd, end, ok := shared.UintFromQuery(w, r, "end_epoch", false)
+	if !ok {
+		return 0, 0, 0, false
+	}
+	validatorIndex := primitives.ValidatorIndex(rawIndex)
+	startEpoch, endEpoch := primitives.Epoch(start), primitives.Epoch(end)
+
+	if rawStart == "" || rawEnd == "" {
+		firstEpoch, lastEpoch, err := s.Slasher.HistoryRange(r.Context(), validatorIndex)
+		if err != nil {
+			handleSlasherError(w, errors.Wrap(err, "Could not get slasher history range"))
+			return 0, 0, 0, false
+		}
+		if rawEnd == "" {
+			endEpoch = lastEpoch
+		}
+		if rawStart == "" {
+			startEpoch = firstEpoch
+			if endEpoch >= firstEpoch+maxEpochsPerRequest {
+				startEpoch = endEpoch - maxEpochsPerRequest + 1
+			}
+		}
+	}
+	if startEpoch > endEpoch {
+		httputil.HandleError(w, "start_epoch cannot be greater than end_epoch", http.StatusBadRequest)
+		return 0, 0, 0, false
+	}
+	if endEpoch-startEpoch >= maxEpochsPerRequest {
+		httputil.HandleError(w, fmt.Sprintf("Cannot request more than %d epochs at once", maxEpochsPerRequest), http.StatusBadRequest)
+		return 0, 0, 0, false
+	}
+	return validatorIndex, startEpoch, endEpoch, true
+}
+
+func handleSlasherError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, slasher.ErrNoHistory):
+		httputil.HandleError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, slasher.ErrOutsideHistory), errors.Is(err, slasher.ErrInvalidAttestation):
+		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, slasher.ErrNotStarted):
+		httputil.HandleError(w, err.Error(), http.StatusServiceUnavailable)
+	default:
+		httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
+	}
+}
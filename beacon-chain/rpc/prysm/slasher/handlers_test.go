@@ -0,0 +1,248 @@
+package slasher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type mockQuerier struct {
+	firstEpoch, lastEpoch primitives.Epoch
+	records               []*slashertypes.IndexedAttestationWrapper
+	attesterSlashings     []ethpb.AttSlashing
+	proposerSlashings     []*ethpb.ProposerSlashing
+	checkedAtt            ethpb.IndexedAtt
+}
+
+func (m *mockQuerier) HistoryRange(_ context.Context, validatorIndex primitives.ValidatorIndex) (primitives.Epoch, primitives.Epoch, error) {
+	if validatorIndex != 1 {
+		return 0, 0, slasher.ErrNoHistory
+	}
+	return m.firstEpoch, m.lastEpoch, nil
+}
+
+func (m *mockQuerier) checkRange(ctx context.Context, validatorIndex primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch) error {
+	first, last, err := m.HistoryRange(ctx, validatorIndex)
+	if err != nil {
+		return err
+	}
+	if startEpoch < first || endEpoch > last {
+		return slasher.ErrOutsideHistory
+	}
+	return nil
+}
+
+func (m *mockQuerier) Spans(ctx context.Context, validatorIndex primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch) ([]*slasher.EpochSpans, error) {
+	if err := m.checkRange(ctx, validatorIndex, startEpoch, endEpoch); err != nil {
+		return nil, err
+	}
+	var spans []*slasher.EpochSpans
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		spans = append(spans, &slasher.EpochSpans{Epoch: epoch, MinSpan: 2, MaxSpan: 1})
+	}
+	return spans, nil
+}
+
+func (m *mockQuerier) AttestationRecords(ctx context.Context, validatorIndex primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch) ([]*slashertypes.IndexedAttestationWrapper, error) {
+	if err := m.checkRange(ctx, validatorIndex, startEpoch, endEpoch); err != nil {
+		return nil, err
+	}
+	return m.records, nil
+}
+
+func (m *mockQuerier) Slashings(context.Context, primitives.ValidatorIndex) ([]ethpb.AttSlashing, []*ethpb.ProposerSlashing, error) {
+	return m.attesterSlashings, m.proposerSlashings, nil
+}
+
+func (m *mockQuerier) CheckAttestation(_ context.Context, att ethpb.IndexedAtt) ([]ethpb.AttSlashing, error) {
+	m.checkedAtt = att
+	if att.GetData().Target.Epoch > m.lastEpoch {
+		return nil, errors.Wrap(slasher.ErrInvalidAttestation, "attestation is not within the slasher history")
+	}
+	return m.attesterSlashings, nil
+}
+
+func indexedAtt(source, target primitives.Epoch, root byte) *ethpb.IndexedAttestation {
+	att := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{1},
+		Data: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{Epoch: source},
+			Target: &ethpb.Checkpoint{Epoch: target},
+		},
+	})
+	att.Data.BeaconBlockRoot[0] = root
+	return att
+}
+
+func TestServer_GetSpans(t *testing.T) {
+	s := &Server{Slasher: &mockQuerier{firstEpoch: 0, lastEpoch: 2000}}
+
+	t.Run("range", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/validators/1/spans?start_epoch=3&end_epoch=4", nil)
+		request = mux.SetURLVars(request, map[string]string{"validator_index": "1"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetSpans(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetSlasherSpansResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.DeepEqual(t, &structs.SlasherEpochSpans{Epoch: "3", MinSpan: "2", MaxSpan: "1"}, resp.Data[0])
+		assert.Equal(t, "4", resp.Data[1].Epoch)
+	})
+	t.Run("default range", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/validators/1/spans", nil)
+		request = mux.SetURLVars(request, map[string]string{"validator_index": "1"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetSpans(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetSlasherSpansResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, maxEpochsPerRequest, len(resp.Data))
+		assert.Equal(t, "977", resp.Data[0].Epoch)
+		assert.Equal(t, "2000", resp.Data[len(resp.Data)-1].Epoch)
+	})
+	t.Run("outside history", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/validators/1/spans?start_epoch=2000&end_epoch=2001", nil)
+		request = mux.SetURLVars(request, map[string]string{"validator_index": "1"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetSpans(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("no history", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/validators/2/spans", nil)
+		request = mux.SetURLVars(request, map[string]string{"validator_index": "2"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetSpans(writer, request)
+		require.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("start after end", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/validators/1/spans?start_epoch=4&end_epoch=3", nil)
+		request = mux.SetURLVars(request, map[string]string{"validator_index": "1"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetSpans(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "start_epoch cannot be greater than end_epoch", writer.Body.String())
+	})
+	t.Run("range too large", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/validators/1/spans?start_epoch=0&end_epoch=2000", nil)
+		request = mux.SetURLVars(request, map[string]string{"validator_index": "1"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetSpans(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
+
+func TestServer_GetAttestations(t *testing.T) {
+	att := indexedAtt(1, 2, 1)
+	dataRoot, err := att.Data.HashTreeRoot()
+	require.NoError(t, err)
+	s := &Server{Slasher: &mockQuerier{
+		lastEpoch: 2,
+		records:   []*slashertypes.IndexedAttestationWrapper{{IndexedAttestation: att, DataRoot: dataRoot}},
+	}}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/validators/1/attestations", nil)
+	request = mux.SetURLVars(request, map[string]string{"validator_index": "1"})
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetAttestations(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.GetSlasherAttestationsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data))
+	assert.DeepEqual(t, structs.IndexedAttFromConsensus(att), resp.Data[0].Attestation)
+}
+
+func TestServer_GetSlashings(t *testing.T) {
+	attesterSlashing := &ethpb.AttesterSlashing{Attestation_1: indexedAtt(1, 2, 1), Attestation_2: indexedAtt(1, 2, 2)}
+	proposerSlashing := &ethpb.ProposerSlashing{
+		Header_1: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{ProposerIndex: 1}}),
+		Header_2: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{ProposerIndex: 1, Slot: 1}}),
+	}
+	s := &Server{Slasher: &mockQuerier{
+		attesterSlashings: []ethpb.AttSlashing{attesterSlashing},
+		proposerSlashings: []*ethpb.ProposerSlashing{proposerSlashing},
+	}}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/validators/1/slashings", nil)
+	request = mux.SetURLVars(request, map[string]string{"validator_index": "1"})
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetSlashings(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.GetSlasherSlashingsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data.AttesterSlashings))
+	assert.DeepEqual(t, structs.AttesterSlashingFromConsensus(attesterSlashing), resp.Data.AttesterSlashings[0])
+	require.Equal(t, 1, len(resp.Data.ProposerSlashings))
+	assert.DeepEqual(t, structs.ProposerSlashingFromConsensus(proposerSlashing), resp.Data.ProposerSlashings[0])
+}
+
+func TestServer_CheckAttestation(t *testing.T) {
+	existing := indexedAtt(1, 2, 1)
+	incoming := indexedAtt(1, 2, 2)
+	querier := &mockQuerier{
+		lastEpoch:         2,
+		attesterSlashings: []ethpb.AttSlashing{&ethpb.AttesterSlashing{Attestation_1: existing, Attestation_2: incoming}},
+	}
+	s := &Server{Slasher: querier}
+
+	t.Run("slashable", func(t *testing.T) {
+		body, err := json.Marshal(structs.IndexedAttFromConsensus(incoming))
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/attestations/check", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.CheckAttestation(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.CheckSlasherAttestationResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, true, resp.Slashable)
+		require.Equal(t, 1, len(resp.Data))
+		assert.DeepEqual(t, incoming, querier.checkedAtt)
+	})
+	t.Run("outside history", func(t *testing.T) {
+		body, err := json.Marshal(structs.IndexedAttFromConsensus(indexedAtt(2, 3, 1)))
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/attestations/check", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.CheckAttestation(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("no body", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/attestations/check", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.CheckAttestation(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "No data submitted", writer.Body.String())
+	})
+	t.Run("invalid attestation", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/attestations/check", bytes.NewReader([]byte(`{"attesting_indices":["a"]}`)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.CheckAttestation(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
@@ -118,6 +118,9 @@ func (vs *Server) packAttestations(ctx context.Context, latestState state.Beacon
 		return nil, err
 	}
 	atts = sorted.limitToMaxAttestations()
+	if features.Get().EnableRewardOptimalPacking {
+		atts = packAttestationsByReward(ctx, latestState, attsById, atts, postElectra)
+	}
 	return vs.filterAttestationBySignature(ctx, atts, latestState)
 }
 
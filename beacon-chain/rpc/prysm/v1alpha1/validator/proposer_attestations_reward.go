@@ -0,0 +1,454 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	coreTime "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	maxCoverPacker = "max_cover"
+	rewardPacker   = "reward"
+)
+
+var (
+	attestationPackingRewardGwei = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "attestation_packing_proposer_reward_gwei",
+		Help: "Proposer reward in gwei of the attestations packed in the last proposed block, by packer and by participation flag",
+	}, []string{"packer", "flag"})
+	attestationPackingVotes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "attestation_packing_new_votes",
+		Help: "Number of participation flags newly set by the attestations packed in the last proposed block, by packer",
+	}, []string{"packer"})
+	attestationPackingDroppedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "attestation_packing_dropped_total",
+		Help: "Number of attestations left out of packing by proposer reward as their reward could not be computed",
+	})
+)
+
+// packingScore is the breakdown of the proposer reward of packed attestations, computed against the pre-state.
+type packingScore struct {
+	attestations int
+	votes        uint64
+	source       uint64 // proposer reward numerator of timely source flags.
+	target       uint64 // proposer reward numerator of timely target flags.
+	head         uint64 // proposer reward numerator of timely head flags.
+}
+
+// numerator returns the proposer reward numerator of all the flags.
+func (s *packingScore) numerator() uint64 {
+	return s.source + s.target + s.head
+}
+
+// reward returns the proposer reward in gwei, computed as in the spec from the sum of the numerators.
+func (s *packingScore) reward() uint64 {
+	return proposerReward(s.numerator())
+}
+
+func (s *packingScore) add(o *packingScore) {
+	s.attestations += o.attestations
+	s.votes += o.votes
+	s.source += o.source
+	s.target += o.target
+	s.head += o.head
+}
+
+func (s *packingScore) fields() logrus.Fields {
+	return logrus.Fields{
+		"attestations": s.attestations,
+		"newVotes":     s.votes,
+		"sourceGwei":   proposerReward(s.source),
+		"targetGwei":   proposerReward(s.target),
+		"headGwei":     proposerReward(s.head),
+		"rewardGwei":   s.reward(),
+	}
+}
+
+func (s *packingScore) record(packer string) {
+	attestationPackingRewardGwei.WithLabelValues(packer, "source").Set(float64(proposerReward(s.source)))
+	attestationPackingRewardGwei.WithLabelValues(packer, "target").Set(float64(proposerReward(s.target)))
+	attestationPackingRewardGwei.WithLabelValues(packer, "head").Set(float64(proposerReward(s.head)))
+	attestationPackingVotes.WithLabelValues(packer).Set(float64(s.votes))
+}
+
+// proposerReward converts a proposer reward numerator to gwei.
+//
+// Spec code:
+//
+//	proposer_reward_denominator = (WEIGHT_DENOMINATOR - PROPOSER_WEIGHT) * WEIGHT_DENOMINATOR // PROPOSER_WEIGHT
+//	proposer_reward = Gwei(proposer_reward_numerator // proposer_reward_denominator)
+func proposerReward(numerator uint64) uint64 {
+	cfg := params.BeaconConfig()
+	return numerator / ((cfg.WeightDenominator - cfg.ProposerWeight) * cfg.WeightDenominator / cfg.ProposerWeight)
+}
+
+// rewardCandidate is an attestation along with the validators and the participation flags it would be rewarded for.
+type rewardCandidate struct {
+	att       ethpb.Att
+	committee primitives.CommitteeIndex
+	indices   []uint64
+	flags     map[uint8]bool
+	epoch     primitives.Epoch
+}
+
+// proposerRewardTracker computes the proposer reward of attestations against the participation of the pre-state,
+// updated with the attestations already packed. The pre-state itself is never modified.
+type proposerRewardTracker struct {
+	st            state.BeaconState
+	totalBalance  uint64
+	participation map[primitives.Epoch][]byte
+	baseRewards   map[uint64]uint64
+}
+
+func newProposerRewardTracker(st state.BeaconState) (*proposerRewardTracker, error) {
+	if st.Version() < version.Altair {
+		return nil, errors.New("proposer rewards can only be computed from Altair")
+	}
+	totalBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get total active balance")
+	}
+	current, err := st.CurrentEpochParticipation()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current epoch participation")
+	}
+	previous, err := st.PreviousEpochParticipation()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get previous epoch participation")
+	}
+	// The previous epoch is the current epoch at genesis, in which case the current participation is used.
+	participation := make(map[primitives.Epoch][]byte, 2)
+	participation[coreTime.PrevEpoch(st)] = bytesutil.SafeCopyBytes(previous)
+	participation[coreTime.CurrentEpoch(st)] = bytesutil.SafeCopyBytes(current)
+	return &proposerRewardTracker{
+		st:            st,
+		totalBalance:  totalBalance,
+		participation: participation,
+		baseRewards:   make(map[uint64]uint64),
+	}, nil
+}
+
+// candidate returns the validators and the participation flags the attestation would be rewarded for.
+func (t *proposerRewardTracker) candidate(ctx context.Context, att ethpb.Att) (*rewardCandidate, error) {
+	delay, err := t.st.Slot().SafeSubSlot(att.GetData().Slot)
+	if err != nil {
+		return nil, fmt.Errorf("attestation slot %d can't be greater than state slot %d", att.GetData().Slot, t.st.Slot())
+	}
+	flags, err := altair.AttestationParticipationFlagIndices(t.st, att.GetData(), delay)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get participation flags")
+	}
+	committees, err := helpers.AttestationCommittees(ctx, t.st, att)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attestation committees")
+	}
+	indices, err := attestation.AttestingIndices(att, committees...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attesting indices")
+	}
+	committee := att.GetData().CommitteeIndex
+	if att.Version() >= version.Electra {
+		committee = helpers.CommitteeIndices(att.CommitteeBitsVal())[0]
+	}
+	return &rewardCandidate{
+		att:       att,
+		committee: committee,
+		indices:   indices,
+		flags:     flags,
+		epoch:     att.GetData().Target.Epoch,
+	}, nil
+}
+
+// score returns the proposer reward of the candidate given the attestations already packed.
+// The participation is updated with the flags of the candidate when apply is true.
+//
+// Spec code:
+//
+//	for index in get_attesting_indices(state, data, attestation.aggregation_bits):
+//	    for flag_index, weight in enumerate(PARTICIPATION_FLAG_WEIGHTS):
+//	        if flag_index in participation_flag_indices and not has_flag(epoch_participation[index], flag_index):
+//	            epoch_participation[index] = add_flag(epoch_participation[index], flag_index)
+//	            proposer_reward_numerator += get_base_reward(state, index) * weight
+func (t *proposerRewardTracker) score(c *rewardCandidate, apply bool) (*packingScore, error) {
+	participation, ok := t.participation[c.epoch]
+	if !ok {
+		return nil, fmt.Errorf("no participation for target epoch %d", c.epoch)
+	}
+	cfg := params.BeaconConfig()
+	flagIndices := [3]uint8{cfg.TimelySourceFlagIndex, cfg.TimelyTargetFlagIndex, cfg.TimelyHeadFlagIndex}
+	weights := [3]uint64{cfg.TimelySourceWeight, cfg.TimelyTargetWeight, cfg.TimelyHeadWeight}
+	var numerators [3]uint64
+	s := &packingScore{attestations: 1}
+	for _, index := range c.indices {
+		if index >= uint64(len(participation)) {
+			return nil, fmt.Errorf("index %d exceeds participation length %d", index, len(participation))
+		}
+		for i, flagIndex := range flagIndices {
+			if !c.flags[flagIndex] {
+				continue
+			}
+			has, err := altair.HasValidatorFlag(participation[index], flagIndex)
+			if err != nil {
+				return nil, err
+			}
+			if has {
+				continue
+			}
+			br, err := t.baseReward(index)
+			if err != nil {
+				return nil, err
+			}
+			numerators[i] += br * weights[i]
+			s.votes++
+			if apply {
+				if participation[index], err = altair.AddValidatorFlag(participation[index], flagIndex); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	s.source, s.target, s.head = numerators[0], numerators[1], numerators[2]
+	return s, nil
+}
+
+func (t *proposerRewardTracker) baseReward(index uint64) (uint64, error) {
+	if br, ok := t.baseRewards[index]; ok {
+		return br, nil
+	}
+	br, err := altair.BaseRewardWithTotalBalance(t.st, primitives.ValidatorIndex(index), t.totalBalance)
+	if err != nil {
+		return 0, err
+	}
+	t.baseRewards[index] = br
+	return br, nil
+}
+
+// rewardGroup holds the remaining candidates sharing the same attestation data, by committee.
+// Only one aggregate per committee can be packed in an attestation: pre-Electra each attestation data has its own
+// committee, post-Electra the aggregates of the committees are combined into one on-chain aggregate.
+type rewardGroup struct {
+	candidates map[primitives.CommitteeIndex][]*rewardCandidate
+	// bound is an upper bound of the reward numerator of the next attestation packed from the group.
+	bound uint64
+}
+
+// best returns the candidate of each committee with the highest reward, along with the sum of their scores.
+func (g *rewardGroup) best(t *proposerRewardTracker) ([]*rewardCandidate, *packingScore, error) {
+	var selected []*rewardCandidate
+	total := &packingScore{}
+	for _, candidates := range g.candidates {
+		var bestCandidate *rewardCandidate
+		var bestScore *packingScore
+		for _, c := range candidates {
+			s, err := t.score(c, false /* apply */)
+			if err != nil {
+				return nil, nil, err
+			}
+			if bestScore == nil || s.numerator() > bestScore.numerator() {
+				bestCandidate, bestScore = c, s
+			}
+		}
+		if bestScore == nil || bestScore.numerator() == 0 {
+			continue
+		}
+		selected = append(selected, bestCandidate)
+		total.add(bestScore)
+	}
+	total.attestations = 1
+	return selected, total, nil
+}
+
+func (g *rewardGroup) remove(selected []*rewardCandidate) {
+	for _, c := range selected {
+		candidates := g.candidates[c.committee]
+		for i := range candidates {
+			if candidates[i] == c {
+				g.candidates[c.committee] = append(candidates[:i], candidates[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// packByProposerReward greedily packs the attestations with the highest proposer reward given the attestations
+// already packed, up to the maximum number of attestations per block. The reward of each aggregate is computed
+// against the participation flags of the pre-state, so votes already included on chain are worth nothing.
+// Aggregates whose bits were all included are not kept in the pool in the first place.
+//
+// Rewards only decrease as attestations are packed, so the reward of a group computed before is an upper bound
+// of its current reward, and groups only need to be scored again when their bound is the highest one.
+func packByProposerReward(
+	ctx context.Context,
+	st state.BeaconState,
+	attsById map[attestation.Id][]ethpb.Att,
+	postElectra bool,
+) (proposerAtts, *packingScore, error) {
+	ctx, span := trace.StartSpan(ctx, "ProposerServer.packByProposerReward")
+	defer span.End()
+
+	t, err := newProposerRewardTracker(st)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	groupsByData := make(map[[32]byte]*rewardGroup)
+	var groups []*rewardGroup
+	dropped := 0
+	for _, atts := range attsById {
+		for _, att := range atts {
+			c, err := t.candidate(ctx, att)
+			if err != nil {
+				log.WithFields(attestationFields(att)).WithError(err).Debug("Could not compute proposer reward of attestation")
+				dropped++
+				continue
+			}
+			dataRoot, err := att.GetData().HashTreeRoot()
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "could not hash attestation data")
+			}
+			g, ok := groupsByData[dataRoot]
+			if !ok {
+				g = &rewardGroup{candidates: make(map[primitives.CommitteeIndex][]*rewardCandidate)}
+				groupsByData[dataRoot] = g
+				groups = append(groups, g)
+			}
+			g.candidates[c.committee] = append(g.candidates[c.committee], c)
+		}
+	}
+	if dropped > 0 {
+		attestationPackingDroppedTotal.Add(float64(dropped))
+		log.WithField("dropped", dropped).Warn("Left attestations out of packing as their proposer reward could not be computed")
+	}
+	for _, g := range groups {
+		_, s, err := g.best(t)
+		if err != nil {
+			return nil, nil, err
+		}
+		g.bound = s.numerator()
+	}
+
+	limit := params.BeaconConfig().MaxAttestations
+	if postElectra {
+		limit = params.BeaconConfig().MaxAttestationsElectra
+	}
+	packed := make(proposerAtts, 0, limit)
+	total := &packingScore{}
+	for uint64(len(packed)) < limit {
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		var top *rewardGroup
+		for _, g := range groups {
+			if g.bound > 0 && (top == nil || g.bound > top.bound) {
+				top = g
+			}
+		}
+		if top == nil {
+			break
+		}
+		selected, s, err := top.best(t)
+		if err != nil {
+			return nil, nil, err
+		}
+		numerator := s.numerator()
+		stale := false
+		for _, g := range groups {
+			if g != top && g.bound > numerator {
+				stale = true
+				break
+			}
+		}
+		top.bound = numerator
+		if stale || numerator == 0 {
+			continue
+		}
+
+		atts := make([]ethpb.Att, len(selected))
+		for i, c := range selected {
+			atts[i] = c.att
+			if _, err := t.score(c, true /* apply */); err != nil {
+				return nil, nil, err
+			}
+		}
+		if postElectra {
+			if atts, err = computeOnChainAggregate(atts); err != nil {
+				return nil, nil, err
+			}
+		}
+		packed = append(packed, atts...)
+		total.add(s)
+		top.remove(selected)
+		if _, s, err = top.best(t); err != nil {
+			return nil, nil, err
+		}
+		top.bound = s.numerator()
+	}
+	return packed, total, nil
+}
+
+// scoreProposerReward returns the proposer reward of the attestations packed in this order.
+func scoreProposerReward(ctx context.Context, st state.BeaconState, atts []ethpb.Att) (*packingScore, error) {
+	t, err := newProposerRewardTracker(st)
+	if err != nil {
+		return nil, err
+	}
+	total := &packingScore{}
+	for _, att := range atts {
+		c, err := t.candidate(ctx, att)
+		if err != nil {
+			return nil, err
+		}
+		s, err := t.score(c, true /* apply */)
+		if err != nil {
+			return nil, err
+		}
+		total.add(s)
+	}
+	return total, nil
+}
+
+// packAttestationsByReward returns the attestations packed by proposer reward, logging and recording their reward
+// along with the reward of the attestations packed by max-cover. The max-cover attestations are returned when
+// the rewards can not be computed.
+func packAttestationsByReward(
+	ctx context.Context,
+	st state.BeaconState,
+	attsById map[attestation.Id][]ethpb.Att,
+	maxCoverAtts proposerAtts,
+	postElectra bool,
+) proposerAtts {
+	packed, score, err := packByProposerReward(ctx, st, attsById, postElectra)
+	if err != nil {
+		log.WithError(err).Error("Could not pack attestations by proposer reward, using max-cover packing")
+		return maxCoverAtts
+	}
+	score.record(rewardPacker)
+	maxCoverScore, err := scoreProposerReward(ctx, st, maxCoverAtts)
+	if err != nil {
+		log.WithError(err).Debug("Could not compute proposer reward of max-cover packing")
+		log.WithFields(score.fields()).WithField("slot", st.Slot()).Debug("Packed attestations by proposer reward")
+		return packed
+	}
+	maxCoverScore.record(maxCoverPacker)
+	log.WithFields(score.fields()).WithFields(logrus.Fields{
+		"slot":               st.Slot(),
+		"maxCoverRewardGwei": maxCoverScore.reward(),
+		"maxCoverNewVotes":   maxCoverScore.votes,
+	}).Debug("Packed attestations by proposer reward")
+	return packed
+}
@@ -0,0 +1,195 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestPackByProposerReward(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	require.NoError(t, st.SetSlot(params.BeaconConfig().MinAttestationInclusionDelay))
+	headRoot, err := helpers.BlockRootAtSlot(st, 0)
+	require.NoError(t, err)
+	committee, err := helpers.BeaconCommitteeFromState(ctx, st, 0, 0)
+	require.NoError(t, err)
+
+	newAtt := func(blockRoot []byte, bits ...uint64) *ethpb.Attestation {
+		aggBits := bitfield.NewBitlist(uint64(len(committee)))
+		for _, b := range bits {
+			aggBits.SetBitAt(b, true)
+		}
+		return &ethpb.Attestation{
+			Data: &ethpb.AttestationData{
+				BeaconBlockRoot: blockRoot,
+				Source:          &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, fieldparams.RootLength)},
+				Target:          &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, fieldparams.RootLength)},
+			},
+			AggregationBits: aggBits,
+			Signature:       make([]byte, fieldparams.BLSSignatureLength),
+		}
+	}
+	// Timely source, target and head votes of two committee members.
+	full := newAtt(headRoot, 0, 1)
+	// Timely source and target votes only, as the head is wrong.
+	wrongHead := newAtt(bytesutil.PadTo([]byte{1}, fieldparams.RootLength), 0, 1)
+	// Votes already in full.
+	subset := newAtt(headRoot, 0)
+
+	attsById := make(map[attestation.Id][]ethpb.Att)
+	for _, att := range []ethpb.Att{wrongHead, subset, full} {
+		id, err := attestation.NewId(att, attestation.Data)
+		require.NoError(t, err)
+		attsById[id] = append(attsById[id], att)
+	}
+	participation, err := st.CurrentEpochParticipation()
+	require.NoError(t, err)
+
+	packed, score, err := packByProposerReward(ctx, st, attsById, false /* postElectra */)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(packed))
+	assert.DeepEqual(t, full, packed[0])
+	assert.Equal(t, uint64(6), score.votes)
+	assert.Equal(t, true, score.head > 0)
+
+	fullScore, err := scoreProposerReward(ctx, st, []ethpb.Att{full})
+	require.NoError(t, err)
+	assert.DeepEqual(t, fullScore, score)
+	wrongHeadScore, err := scoreProposerReward(ctx, st, []ethpb.Att{wrongHead})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), wrongHeadScore.votes)
+	assert.Equal(t, uint64(0), wrongHeadScore.head)
+	assert.Equal(t, true, wrongHeadScore.reward() < score.reward())
+
+	// The pre-state is not modified.
+	after, err := st.CurrentEpochParticipation()
+	require.NoError(t, err)
+	assert.DeepEqual(t, participation, after)
+
+	t.Run("already included votes", func(t *testing.T) {
+		st := st.Copy()
+		require.NoError(t, st.ModifyCurrentParticipationBits(func(val []byte) ([]byte, error) {
+			for _, flag := range []uint8{
+				params.BeaconConfig().TimelySourceFlagIndex,
+				params.BeaconConfig().TimelyTargetFlagIndex,
+				params.BeaconConfig().TimelyHeadFlagIndex,
+			} {
+				val[committee[0]], err = altair.AddValidatorFlag(val[committee[0]], flag)
+				require.NoError(t, err)
+			}
+			return val, nil
+		}))
+		packed, score, err := packByProposerReward(ctx, st, attsById, false /* postElectra */)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(packed))
+		assert.Equal(t, uint64(3), score.votes)
+	})
+	t.Run("unscorable attestation", func(t *testing.T) {
+		hook := logTest.NewGlobal()
+		future := newAtt(headRoot, 2)
+		future.Data.Slot = st.Slot() + 1
+		id, err := attestation.NewId(future, attestation.Data)
+		require.NoError(t, err)
+		withFuture := map[attestation.Id][]ethpb.Att{id: {future}}
+		for id, atts := range attsById {
+			withFuture[id] = atts
+		}
+		packed, _, err := packByProposerReward(ctx, st, withFuture, false /* postElectra */)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(packed))
+		assert.DeepEqual(t, full, packed[0])
+		require.LogsContain(t, hook, "Left attestations out of packing")
+	})
+	t.Run("phase0 state", func(t *testing.T) {
+		st, _ := util.DeterministicGenesisState(t, 64)
+		_, _, err := packByProposerReward(ctx, st, attsById, false /* postElectra */)
+		require.ErrorContains(t, "proposer rewards can only be computed from Altair", err)
+	})
+}
+
+func TestPackByProposerReward_ElectraCommittees(t *testing.T) {
+	ctx := context.Background()
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	// Two committees per slot with 256 validators.
+	cfg.TargetCommitteeSize = 4
+	params.OverrideBeaconConfig(cfg)
+	helpers.ClearCache()
+	defer helpers.ClearCache()
+
+	st, _ := util.DeterministicGenesisStateElectra(t, 256)
+	require.NoError(t, st.SetSlot(params.BeaconConfig().MinAttestationInclusionDelay))
+	headRoot, err := helpers.BlockRootAtSlot(st, 0)
+	require.NoError(t, err)
+	committee0, err := helpers.BeaconCommitteeFromState(ctx, st, 0, 0)
+	require.NoError(t, err)
+	committee1, err := helpers.BeaconCommitteeFromState(ctx, st, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, true, len(committee0) > 3 && len(committee1) > 3)
+	key, err := bls.RandKey()
+	require.NoError(t, err)
+	sig := key.Sign([]byte{'X'}).Marshal()
+
+	newAtt := func(committeeIndex primitives.CommitteeIndex, committeeSize int, bits ...uint64) *ethpb.AttestationElectra {
+		aggBits := bitfield.NewBitlist(uint64(committeeSize))
+		for _, b := range bits {
+			aggBits.SetBitAt(b, true)
+		}
+		committeeBits := primitives.NewAttestationCommitteeBits()
+		committeeBits.SetBitAt(uint64(committeeIndex), true)
+		return &ethpb.AttestationElectra{
+			Data: &ethpb.AttestationData{
+				BeaconBlockRoot: headRoot,
+				Source:          &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, fieldparams.RootLength)},
+				Target:          &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, fieldparams.RootLength)},
+			},
+			AggregationBits: aggBits,
+			CommitteeBits:   committeeBits,
+			Signature:       sig,
+		}
+	}
+	// The aggregates of both committees share the attestation data, and are packed as a single on-chain aggregate.
+	first := newAtt(0, len(committee0), 0, 1)
+	subset := newAtt(0, len(committee0), 1)
+	second := newAtt(1, len(committee1), 0, 1, 2)
+
+	attsById := make(map[attestation.Id][]ethpb.Att)
+	for _, att := range []ethpb.Att{subset, second, first} {
+		id, err := attestation.NewId(att, attestation.Data)
+		require.NoError(t, err)
+		attsById[id] = append(attsById[id], att)
+	}
+
+	packed, score, err := packByProposerReward(ctx, st, attsById, true /* postElectra */)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(packed))
+	assert.DeepEqual(t, []int{0, 1}, packed[0].CommitteeBitsVal().BitIndices())
+	offset := len(committee0)
+	assert.Equal(t, uint64(offset+len(committee1)), packed[0].GetAggregationBits().Len())
+	assert.DeepEqual(t, []int{0, 1, offset, offset + 1, offset + 2}, packed[0].GetAggregationBits().BitIndices())
+	// Timely source, target and head votes of two members of the first committee and three of the second one.
+	assert.Equal(t, uint64(15), score.votes)
+
+	// The on-chain aggregate is scored over the attesters of both committees.
+	onChainScore, err := scoreProposerReward(ctx, st, packed)
+	require.NoError(t, err)
+	assert.DeepEqual(t, score, onChainScore)
+	separateScore, err := scoreProposerReward(ctx, st, []ethpb.Att{first, second})
+	require.NoError(t, err)
+	assert.Equal(t, score.reward(), separateScore.reward())
+}
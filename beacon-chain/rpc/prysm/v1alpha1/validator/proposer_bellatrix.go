@@ -12,7 +12,6 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prysmaticlabs/prysm/v5/api/client/builder"
-	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
@@ -53,7 +52,8 @@ var emptyTransactionsRoot = [32]byte{127, 254, 36, 30, 166, 1, 135, 253, 176, 24
 const blockBuilderTimeout = 1 * time.Second
 
 // Sets the execution data for the block. Execution data can come from local EL client or remote builder depends on validator registration and circuit breaker conditions.
-func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, local *blocks.GetPayloadResponse, bid builder.Bid, builderBoostFactor primitives.Gwei) (primitives.Wei, *enginev1.BlobsBundle, error) {
+// The thresholds of the builder policy of the decision are applied and the outcome is recorded in it.
+func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, local *blocks.GetPayloadResponse, bid builder.Bid, builderBoostFactor primitives.Gwei, d *builderDecision) (primitives.Wei, *enginev1.BlobsBundle, error) {
 	_, span := trace.StartSpan(ctx, "ProposerServer.setExecutionData")
 	defer span.End()
 
@@ -65,6 +65,7 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 	if local == nil {
 		return primitives.ZeroWei(), nil, errors.New("local payload is nil")
 	}
+	d.localValue = primitives.WeiToGwei(local.Bid)
 
 	// Use local payload if builder payload is nil.
 	if bid == nil {
@@ -72,9 +73,11 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 	}
 
 	var builderKzgCommitments [][]byte
+	d.builderValue = primitives.WeiToGwei(bid.Value())
 	builderPayload, err := bid.Header()
 	if err != nil {
 		log.WithError(err).Warn("Proposer: failed to retrieve header from BuilderBid")
+		d.useLocal(decisionBuilderError)
 		return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
 	}
 	if bid.Version() >= version.Deneb {
@@ -90,36 +93,39 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 		if err != nil {
 			tracing.AnnotateError(span, err)
 			log.WithError(err).Warn("Proposer: failed to match withdrawals root")
+			d.useLocal(decisionWithdrawalsMismatch)
 			return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
 		}
 
 		// Compare payload values between local and builder. Default to the local value if it is higher.
 		localValueGwei := primitives.WeiToGwei(local.Bid)
 		builderValueGwei := primitives.WeiToGwei(bid.Value())
-		minBid := primitives.Gwei(params.BeaconConfig().MinBuilderBid)
+		minBid := d.minBuilderBid()
 		// Use local block if min bid is not attained
 		if builderValueGwei < minBid {
 			log.WithFields(logrus.Fields{
 				"minBuilderBid":    minBid,
 				"builderGweiValue": builderValueGwei,
 			}).Warn("Proposer: using local execution payload because min bid not attained")
+			d.useLocal(decisionMinBidNotAttained)
 			return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
 		}
 
 		// Use local block if min difference is not attained
-		minDiff := localValueGwei + primitives.Gwei(params.BeaconConfig().MinBuilderDiff)
+		minDiff := localValueGwei + d.minBuilderDiff()
 		if builderValueGwei < minDiff {
 			log.WithFields(logrus.Fields{
 				"localGweiValue":   localValueGwei,
 				"minBidDiff":       minDiff,
 				"builderGweiValue": builderValueGwei,
 			}).Warn("Proposer: using local execution payload because min difference with local value was not attained")
+			d.useLocal(decisionMinDifferenceNotAttained)
 			return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
 		}
 
 		// Use builder payload if the following in true:
 		// builder_bid_value * builderBoostFactor(default 100) > local_block_value * (local-block-value-boost + 100)
-		boost := primitives.Gwei(params.BeaconConfig().LocalBlockValueBoost)
+		boost := d.localBlockValueBoost()
 		higherValueBuilder := builderValueGwei*builderBoostFactor > localValueGwei*(100+boost)
 		if boost > 0 && builderBoostFactor != defaultBuilderBoostFactor {
 			log.WithFields(logrus.Fields{
@@ -136,12 +142,18 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 		if higherValueBuilder && withdrawalsMatched { // Builder value is higher and withdrawals match.
 			if err := setBuilderExecution(blk, builderPayload, builderKzgCommitments); err != nil {
 				log.WithError(err).Warn("Proposer: failed to set builder payload")
+				d.useLocal(decisionBuilderError)
 				return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
 			} else {
+				d.useBuilder()
 				return bid.Value(), nil, nil
 			}
 		}
+		if !withdrawalsMatched {
+			d.useLocal(decisionWithdrawalsMismatch)
+		}
 		if !higherValueBuilder {
+			d.useLocal(decisionLocalValueHigher)
 			log.WithFields(logrus.Fields{
 				"localGweiValue":       localValueGwei,
 				"localBoostPercentage": boost,
@@ -160,8 +172,10 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 	default: // Bellatrix case.
 		if err := setBuilderExecution(blk, builderPayload, builderKzgCommitments); err != nil {
 			log.WithError(err).Warn("Proposer: failed to set builder payload")
+			d.useLocal(decisionBuilderError)
 			return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
 		} else {
+			d.useBuilder()
 			return bid.Value(), nil, nil
 		}
 	}
@@ -194,6 +208,7 @@ func (vs *Server) getPayloadHeaderFromBuilder(ctx context.Context, slot primitiv
 	ctx, cancel := context.WithTimeout(ctx, blockBuilderTimeout)
 	defer cancel()
 
+	// The builder service only returns bids with a valid builder signature.
 	signedBid, err := vs.BlockBuilder.GetHeader(ctx, slot, bytesutil.ToBytes32(h.BlockHash()), pk)
 	if err != nil {
 		return nil, err
@@ -259,10 +274,6 @@ func (vs *Server) getPayloadHeaderFromBuilder(ctx context.Context, slot primitiv
 		return nil, fmt.Errorf("incorrect timestamp %d != %d", header.Timestamp(), uint64(t.Unix()))
 	}
 
-	if err := validateBuilderSignature(signedBid); err != nil {
-		return nil, errors.Wrap(err, "could not validate builder signature")
-	}
-
 	var kzgCommitments [][]byte
 	if bid.Version() >= version.Deneb {
 		kzgCommitments, err = bid.BlobKzgCommitments()
@@ -301,27 +312,6 @@ func (vs *Server) getPayloadHeaderFromBuilder(ctx context.Context, slot primitiv
 	return bid, nil
 }
 
-// Validates builder signature and returns an error if the signature is invalid.
-func validateBuilderSignature(signedBid builder.SignedBid) error {
-	d, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder,
-		nil, /* fork version */
-		nil /* genesis val root */)
-	if err != nil {
-		return err
-	}
-	if signedBid == nil || signedBid.IsNil() {
-		return errors.New("nil builder bid")
-	}
-	bid, err := signedBid.Message()
-	if err != nil {
-		return errors.Wrap(err, "could not get bid")
-	}
-	if bid == nil || bid.IsNil() {
-		return errors.New("builder returned nil bid")
-	}
-	return signing.VerifySigningRoot(bid, bid.Pubkey(), signedBid.Signature(), d)
-}
-
 func matchingWithdrawalsRoot(local, builder interfaces.ExecutionData) (bool, error) {
 	wds, err := local.Withdrawals()
 	if err != nil {
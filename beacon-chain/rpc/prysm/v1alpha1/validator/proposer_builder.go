@@ -16,23 +16,33 @@ import (
 // Returns true if builder (ie outsourcing block construction) can be used. Both conditions have to meet:
 // - Validator has registered to use builder (ie called registerBuilder API end point)
 // - Circuit breaker has not been activated (ie the liveness of the chain is healthy)
-func (vs *Server) canUseBuilder(ctx context.Context, slot primitives.Slot, idx primitives.ValidatorIndex) (bool, error) {
+// The reason the builder can't be used is recorded in the decision.
+func (vs *Server) canUseBuilder(ctx context.Context, slot primitives.Slot, idx primitives.ValidatorIndex, d *builderDecision) (bool, error) {
 	ctx, span := trace.StartSpan(ctx, "ProposerServer.canUseBuilder")
 	defer span.End()
 
 	if !vs.BlockBuilder.Configured() {
+		d.useLocal(decisionBuilderNotConfigured)
 		return false, nil
 	}
-	activated, err := vs.circuitBreakBuilder(slot)
+	activated, err := vs.circuitBreakBuilder(slot, d)
 	span.AddAttributes(trace.BoolAttribute("circuitBreakerActivated", activated))
 	if err != nil {
 		tracing.AnnotateError(span, err)
 		return false, err
 	}
 	if activated {
+		d.useLocal(decisionCircuitBreaker)
 		return false, nil
 	}
-	return vs.validatorRegistered(ctx, idx)
+	registered, err := vs.validatorRegistered(ctx, idx)
+	if err != nil {
+		return false, err
+	}
+	if !registered {
+		d.useLocal(decisionNotRegistered)
+	}
+	return registered, nil
 }
 
 // validatorRegistered returns true if validator with index `id` was previously registered in the database.
@@ -51,14 +61,15 @@ func (vs *Server) validatorRegistered(ctx context.Context, id primitives.Validat
 }
 
 // circuitBreakBuilder returns true if the builder is not allowed to be used due to circuit breaker conditions.
-func (vs *Server) circuitBreakBuilder(s primitives.Slot) (bool, error) {
+// The missed slot thresholds are taken from the builder policy of the decision.
+func (vs *Server) circuitBreakBuilder(s primitives.Slot, d *builderDecision) (bool, error) {
 	if vs.ForkchoiceFetcher == nil {
 		return true, errors.New("no fork choicer configured")
 	}
 
 	// Circuit breaker is active if the missing consecutive slots greater than `MaxBuilderConsecutiveMissedSlots`.
 	highestReceivedSlot := vs.ForkchoiceFetcher.HighestReceivedBlockSlot()
-	maxConsecutiveSkipSlotsAllowed := params.BeaconConfig().MaxBuilderConsecutiveMissedSlots
+	maxConsecutiveSkipSlotsAllowed := d.maxConsecutiveMissedSlots()
 	diff, err := s.SafeSubSlot(highestReceivedSlot)
 	if err != nil {
 		return true, err
@@ -83,7 +94,7 @@ func (vs *Server) circuitBreakBuilder(s primitives.Slot) (bool, error) {
 	if err != nil {
 		return true, err
 	}
-	maxEpochSkipSlotsAllowed := params.BeaconConfig().MaxBuilderEpochMissedSlots
+	maxEpochSkipSlotsAllowed := d.maxEpochMissedSlots()
 	diff, err = params.BeaconConfig().SlotsPerEpoch.SafeSub(receivedCount)
 	if err != nil {
 		return true, err
@@ -0,0 +1,189 @@
+package validator
+
+import (
+	"context"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+// proposalDecisionsQueueSize is the number of proposal decisions which can wait to be written to the database.
+const proposalDecisionsQueueSize = 64
+
+// Reasons recorded in the proposal decision audit log.
+const (
+	decisionSkipMevBoost             = "skip_mev_boost"
+	decisionLocalOverride            = "local_override"
+	decisionBuilderNotConfigured     = "builder_not_configured"
+	decisionCircuitBreaker           = "circuit_breaker"
+	decisionNotRegistered            = "not_registered"
+	decisionNoBid                    = "no_bid"
+	decisionBuilderError             = "builder_error"
+	decisionMinBidNotAttained        = "min_bid_not_attained"
+	decisionMinDifferenceNotAttained = "min_difference_not_attained"
+	decisionWithdrawalsMismatch      = "withdrawals_mismatch"
+	decisionLocalValueHigher         = "local_value_higher"
+	decisionBuilderValueHigher       = "builder_value_higher"
+)
+
+// builderDecision carries the builder policy in effect for a proposal and the outcome of the
+// builder versus local payload selection made under it.
+type builderDecision struct {
+	policy          *ethpb.BuilderPolicy
+	builderSelected bool
+	reason          string
+	// reasonSet is true once a reason for using the local payload was recorded.
+	reasonSet    bool
+	builderValue primitives.Gwei
+	localValue   primitives.Gwei
+}
+
+// newBuilderDecision returns a decision using the node's builder settings, overridden by any field
+// set in the validator's policy.
+func newBuilderDecision(override *ethpb.BuilderPolicy) *builderDecision {
+	cfg := params.BeaconConfig()
+	boost := cfg.LocalBlockValueBoost
+	minBid := cfg.MinBuilderBid
+	minDiff := cfg.MinBuilderDiff
+	maxConsecutive := uint64(cfg.MaxBuilderConsecutiveMissedSlots)
+	maxEpoch := uint64(cfg.MaxBuilderEpochMissedSlots)
+	if override != nil {
+		if override.LocalBlockValueBoost != nil {
+			boost = *override.LocalBlockValueBoost
+		}
+		if override.MinBuilderBid != nil {
+			minBid = *override.MinBuilderBid
+		}
+		if override.MinBuilderToLocalDifference != nil {
+			minDiff = *override.MinBuilderToLocalDifference
+		}
+		if override.MaxBuilderConsecutiveMissedSlots != nil {
+			maxConsecutive = *override.MaxBuilderConsecutiveMissedSlots
+		}
+		if override.MaxBuilderEpochMissedSlots != nil {
+			maxEpoch = *override.MaxBuilderEpochMissedSlots
+		}
+	}
+	return &builderDecision{
+		policy: &ethpb.BuilderPolicy{
+			LocalBlockValueBoost:             &boost,
+			MinBuilderBid:                    &minBid,
+			MinBuilderToLocalDifference:      &minDiff,
+			MaxBuilderConsecutiveMissedSlots: &maxConsecutive,
+			MaxBuilderEpochMissedSlots:       &maxEpoch,
+		},
+		reason: decisionNoBid,
+	}
+}
+
+func (d *builderDecision) localBlockValueBoost() primitives.Gwei {
+	return primitives.Gwei(d.policy.GetLocalBlockValueBoost())
+}
+
+func (d *builderDecision) minBuilderBid() primitives.Gwei {
+	return primitives.Gwei(d.policy.GetMinBuilderBid())
+}
+
+func (d *builderDecision) minBuilderDiff() primitives.Gwei {
+	return primitives.Gwei(d.policy.GetMinBuilderToLocalDifference())
+}
+
+func (d *builderDecision) maxConsecutiveMissedSlots() primitives.Slot {
+	return primitives.Slot(d.policy.GetMaxBuilderConsecutiveMissedSlots())
+}
+
+func (d *builderDecision) maxEpochMissedSlots() primitives.Slot {
+	return primitives.Slot(d.policy.GetMaxBuilderEpochMissedSlots())
+}
+
+// useLocal records that the local payload was used for the given reason. Only the first reason is kept, as
+// later ones are consequences of it or secondary causes.
+func (d *builderDecision) useLocal(reason string) {
+	d.builderSelected = false
+	if d.reasonSet {
+		return
+	}
+	d.reason = reason
+	d.reasonSet = true
+}
+
+// useBuilder records that the builder payload was used.
+func (d *builderDecision) useBuilder() {
+	d.builderSelected = true
+	d.reason = decisionBuilderValueHigher
+}
+
+// saveProposalDecision persists the outcome of the payload selection of the block to the audit log.
+func (vs *Server) saveProposalDecision(ctx context.Context, blk interfaces.ReadOnlyBeaconBlock, d *builderDecision, builderBoostFactor primitives.Gwei) {
+	decision := &ethpb.ProposalDecision{
+		Slot:               blk.Slot(),
+		ProposerIndex:      blk.ProposerIndex(),
+		BuilderSelected:    d.builderSelected,
+		Reason:             d.reason,
+		BuilderValueGwei:   uint64(d.builderValue),
+		LocalValueGwei:     uint64(d.localValue),
+		BuilderBoostFactor: uint64(builderBoostFactor),
+		Policy:             d.policy,
+	}
+	if d.builderSelected && vs.BlockBuilder != nil {
+		execution, err := blk.Body().Execution()
+		if err == nil {
+			decision.Relay = vs.BlockBuilder.WinningRelay(bytesutil.ToBytes32(execution.BlockHash()))
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"slot":            decision.Slot,
+		"proposerIndex":   decision.ProposerIndex,
+		"builderSelected": decision.BuilderSelected,
+		"reason":          decision.Reason,
+		"relay":           decision.Relay,
+	}).Debug("Selected execution payload")
+	if vs.proposalDecisions == nil {
+		return
+	}
+	// The decision is written to the database in the background, off the block production path.
+	select {
+	case vs.proposalDecisions <- decision:
+	default:
+		log.WithField("slot", decision.Slot).Warn("Proposal decision queue is full, dropping decision")
+	}
+}
+
+// SaveProposalDecisionsRoutine starts the background routine which writes the proposal decisions
+// queued during block production to the database, and prunes the old ones once per epoch.
+// It must be called before the server handles requests.
+func (vs *Server) SaveProposalDecisionsRoutine() {
+	if vs.BeaconDB == nil {
+		return
+	}
+	vs.proposalDecisions = make(chan *ethpb.ProposalDecision, proposalDecisionsQueueSize)
+	go func() {
+		cfg := params.BeaconConfig()
+		ticker := time.NewTicker(time.Duration(uint64(cfg.SlotsPerEpoch)*cfg.SecondsPerSlot) * time.Second)
+		defer ticker.Stop()
+		var latestSlot primitives.Slot
+		for {
+			select {
+			case <-vs.Ctx.Done():
+				return
+			case decision := <-vs.proposalDecisions:
+				if err := vs.BeaconDB.SaveProposalDecision(vs.Ctx, decision); err != nil {
+					log.WithError(err).Error("Could not save proposal decision")
+					continue
+				}
+				if decision.Slot > latestSlot {
+					latestSlot = decision.Slot
+				}
+			case <-ticker.C:
+				if err := vs.BeaconDB.PruneProposalDecisions(vs.Ctx, latestSlot); err != nil {
+					log.WithError(err).Error("Could not prune proposal decisions")
+				}
+			}
+		}
+	}()
+}
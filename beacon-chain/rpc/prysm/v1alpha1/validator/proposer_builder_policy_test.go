@@ -0,0 +1,92 @@
+package validator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	builderTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/builder/testing"
+	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestNewBuilderDecision(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.LocalBlockValueBoost = 10
+	cfg.MinBuilderBid = 20
+	cfg.MinBuilderDiff = 30
+	params.OverrideBeaconConfig(cfg)
+
+	d := newBuilderDecision(nil)
+	require.Equal(t, primitives.Gwei(10), d.localBlockValueBoost())
+	require.Equal(t, primitives.Gwei(20), d.minBuilderBid())
+	require.Equal(t, primitives.Gwei(30), d.minBuilderDiff())
+	require.Equal(t, cfg.MaxBuilderConsecutiveMissedSlots, d.maxConsecutiveMissedSlots())
+	require.Equal(t, cfg.MaxBuilderEpochMissedSlots, d.maxEpochMissedSlots())
+	require.Equal(t, decisionNoBid, d.reason)
+
+	boost, maxEpoch := uint64(0), uint64(2)
+	d = newBuilderDecision(&ethpb.BuilderPolicy{LocalBlockValueBoost: &boost, MaxBuilderEpochMissedSlots: &maxEpoch})
+	require.Equal(t, primitives.Gwei(0), d.localBlockValueBoost())
+	require.Equal(t, primitives.Gwei(20), d.minBuilderBid())
+	require.Equal(t, primitives.Slot(2), d.maxEpochMissedSlots())
+
+	d.useBuilder()
+	require.Equal(t, true, d.builderSelected)
+	require.Equal(t, decisionBuilderValueHigher, d.reason)
+	d.useLocal(decisionBuilderError)
+	require.Equal(t, false, d.builderSelected)
+	require.Equal(t, decisionBuilderError, d.reason)
+
+	// The first reason for using the local payload is kept.
+	d = newBuilderDecision(nil)
+	d.useLocal(decisionWithdrawalsMismatch)
+	d.useLocal(decisionLocalValueHigher)
+	require.Equal(t, decisionWithdrawalsMismatch, d.reason)
+}
+
+func TestServer_saveProposalDecision(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beaconDB := dbTest.SetupDB(t)
+	vs := &Server{
+		Ctx:          ctx,
+		BeaconDB:     beaconDB,
+		BlockBuilder: &builderTest.MockBuilderService{HasConfigured: true, Relay: "relay.example.com"},
+	}
+	vs.SaveProposalDecisionsRoutine()
+	b := util.NewBeaconBlockCapella()
+	b.Block.Slot = 5
+	b.Block.ProposerIndex = 3
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+
+	d := newBuilderDecision(nil)
+	d.builderValue, d.localValue = 7, 6
+	d.useBuilder()
+	vs.saveProposalDecision(ctx, blk.Block(), d, defaultBuilderBoostFactor)
+
+	// The decision is written in the background.
+	var decisions []*ethpb.ProposalDecision
+	for i := 0; i < 100 && len(decisions) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+		decisions, err = beaconDB.ProposalDecisions(ctx, 0, 10)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, len(decisions))
+	require.Equal(t, primitives.Slot(5), decisions[0].Slot)
+	require.Equal(t, primitives.ValidatorIndex(3), decisions[0].ProposerIndex)
+	require.Equal(t, true, decisions[0].BuilderSelected)
+	require.Equal(t, decisionBuilderValueHigher, decisions[0].Reason)
+	require.Equal(t, uint64(7), decisions[0].BuilderValueGwei)
+	require.Equal(t, uint64(6), decisions[0].LocalValueGwei)
+	require.Equal(t, uint64(defaultBuilderBoostFactor), decisions[0].BuilderBoostFactor)
+	require.Equal(t, "relay.example.com", decisions[0].Relay)
+	require.DeepEqual(t, d.policy, decisions[0].Policy)
+}
@@ -25,12 +25,12 @@ import (
 func TestServer_circuitBreakBuilder(t *testing.T) {
 	hook := logTest.NewGlobal()
 	s := &Server{}
-	_, err := s.circuitBreakBuilder(0)
+	_, err := s.circuitBreakBuilder(0, newBuilderDecision(nil))
 	require.ErrorContains(t, "no fork choicer configured", err)
 
 	s.ForkchoiceFetcher = &blockchainTest.ChainService{ForkChoiceStore: doublylinkedtree.New()}
 	s.ForkchoiceFetcher.SetForkChoiceGenesisTime(uint64(time.Now().Unix()))
-	b, err := s.circuitBreakBuilder(params.BeaconConfig().MaxBuilderConsecutiveMissedSlots + 1)
+	b, err := s.circuitBreakBuilder(params.BeaconConfig().MaxBuilderConsecutiveMissedSlots+1, newBuilderDecision(nil))
 	require.NoError(
 		t,
 		err,
@@ -44,10 +44,16 @@ func TestServer_circuitBreakBuilder(t *testing.T) {
 	st, blkRoot, err := createState(1, [32]byte{'a'}, [32]byte{}, params.BeaconConfig().ZeroHash, ojc, ofc)
 	require.NoError(t, err)
 	require.NoError(t, s.ForkchoiceFetcher.InsertNode(ctx, st, blkRoot))
-	b, err = s.circuitBreakBuilder(params.BeaconConfig().MaxBuilderConsecutiveMissedSlots)
+	b, err = s.circuitBreakBuilder(params.BeaconConfig().MaxBuilderConsecutiveMissedSlots, newBuilderDecision(nil))
 	require.NoError(t, err)
 	require.Equal(t, false, b)
 
+	// A stricter validator policy activates the circuit breaker earlier.
+	maxMissed := uint64(1)
+	b, err = s.circuitBreakBuilder(params.BeaconConfig().MaxBuilderConsecutiveMissedSlots, newBuilderDecision(&ethpb.BuilderPolicy{MaxBuilderConsecutiveMissedSlots: &maxMissed}))
+	require.NoError(t, err)
+	require.Equal(t, true, b)
+
 	params.SetupTestConfigCleanup(t)
 	cfg := params.BeaconConfig().Copy()
 	cfg.MaxBuilderEpochMissedSlots = 4
@@ -55,7 +61,7 @@ func TestServer_circuitBreakBuilder(t *testing.T) {
 	st, blkRoot, err = createState(params.BeaconConfig().SlotsPerEpoch, [32]byte{'b'}, [32]byte{'a'}, params.BeaconConfig().ZeroHash, ojc, ofc)
 	require.NoError(t, err)
 	require.NoError(t, s.ForkchoiceFetcher.InsertNode(ctx, st, blkRoot))
-	b, err = s.circuitBreakBuilder(params.BeaconConfig().SlotsPerEpoch + 1)
+	b, err = s.circuitBreakBuilder(params.BeaconConfig().SlotsPerEpoch+1, newBuilderDecision(nil))
 	require.NoError(t, err)
 	require.Equal(t, true, b)
 	require.LogsContain(t, hook, "Circuit breaker activated due to missing enough slots last epoch. Ignore if mev-boost is not used")
@@ -66,7 +72,7 @@ func TestServer_circuitBreakBuilder(t *testing.T) {
 		require.NoError(t, err)
 		require.NoError(t, s.ForkchoiceFetcher.InsertNode(ctx, st, blkRoot))
 	}
-	b, err = s.circuitBreakBuilder(params.BeaconConfig().SlotsPerEpoch + 1)
+	b, err = s.circuitBreakBuilder(params.BeaconConfig().SlotsPerEpoch+1, newBuilderDecision(nil))
 	require.NoError(t, err)
 	require.Equal(t, false, b)
 }
@@ -110,7 +116,7 @@ func TestServer_canUseBuilder(t *testing.T) {
 			HasConfigured: false,
 		},
 	}
-	reg, err := proposerServer.canUseBuilder(context.Background(), 0, 0)
+	reg, err := proposerServer.canUseBuilder(context.Background(), 0, 0, newBuilderDecision(nil))
 	require.NoError(t, err)
 	require.Equal(t, false, reg)
 
@@ -118,7 +124,7 @@ func TestServer_canUseBuilder(t *testing.T) {
 
 	proposerServer.ForkchoiceFetcher = &blockchainTest.ChainService{ForkChoiceStore: doublylinkedtree.New()}
 	proposerServer.ForkchoiceFetcher.SetForkChoiceGenesisTime(uint64(time.Now().Unix()))
-	reg, err = proposerServer.canUseBuilder(ctx, params.BeaconConfig().MaxBuilderConsecutiveMissedSlots+1, 0)
+	reg, err = proposerServer.canUseBuilder(ctx, params.BeaconConfig().MaxBuilderConsecutiveMissedSlots+1, 0, newBuilderDecision(nil))
 	require.NoError(t, err)
 	require.Equal(t, false, reg)
 	db := dbTest.SetupDB(t)
@@ -128,7 +134,7 @@ func TestServer_canUseBuilder(t *testing.T) {
 		Cfg:           &testing2.Config{BeaconDB: db},
 	}
 
-	reg, err = proposerServer.canUseBuilder(ctx, 1, 0)
+	reg, err = proposerServer.canUseBuilder(ctx, 1, 0, newBuilderDecision(nil))
 	require.NoError(t, err)
 	require.Equal(t, false, reg)
 
@@ -137,7 +143,7 @@ func TestServer_canUseBuilder(t *testing.T) {
 	require.NoError(t, db.SaveRegistrationsByValidatorIDs(ctx, []primitives.ValidatorIndex{0},
 		[]*ethpb.ValidatorRegistrationV1{{FeeRecipient: f, Timestamp: uint64(time.Now().Unix()), Pubkey: p}}))
 
-	reg, err = proposerServer.canUseBuilder(ctx, params.BeaconConfig().MaxBuilderConsecutiveMissedSlots-1, 0)
+	reg, err = proposerServer.canUseBuilder(ctx, params.BeaconConfig().MaxBuilderConsecutiveMissedSlots-1, 0, newBuilderDecision(nil))
 	require.NoError(t, err)
 	require.Equal(t, true, reg)
 }
@@ -240,14 +240,15 @@ func (vs *Server) getTerminalBlockHashIfExists(ctx context.Context, transitionTi
 
 func (vs *Server) getBuilderPayloadAndBlobs(ctx context.Context,
 	slot primitives.Slot,
-	vIdx primitives.ValidatorIndex) (builder.Bid, error) {
+	vIdx primitives.ValidatorIndex,
+	d *builderDecision) (builder.Bid, error) {
 	ctx, span := trace.StartSpan(ctx, "ProposerServer.getBuilderPayloadAndBlobs")
 	defer span.End()
 
 	if slots.ToEpoch(slot) < params.BeaconConfig().BellatrixForkEpoch {
 		return nil, nil
 	}
-	canUseBuilder, err := vs.canUseBuilder(ctx, slot, vIdx)
+	canUseBuilder, err := vs.canUseBuilder(ctx, slot, vIdx, d)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to check if we can use the builder")
 	}
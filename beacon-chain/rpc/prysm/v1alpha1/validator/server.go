@@ -79,6 +79,7 @@ type Server struct {
 	BLSChangesPool         blstoexec.PoolManager
 	ClockWaiter            startup.ClockWaiter
 	CoreService            *core.Service
+	proposalDecisions      chan *ethpb.ProposalDecision
 }
 
 // WaitForActivation checks if a validator public key exists in the active validator registry of the current
@@ -0,0 +1,379 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
+	rpchelpers "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"go.opencensus.io/trace"
+)
+
+// maxDutiesLookaheadEpochs is the maximum number of epochs after the current one that duties can be requested for.
+// Each epoch past the next one requires processing the epoch transition of the head state.
+const maxDutiesLookaheadEpochs = 4
+
+// GetDutiesLookahead returns the proposer, attester and sync committee duties of the requested validators
+// for the current epoch and the next `epochs` epochs, at most maxDutiesLookaheadEpochs and by default
+// MIN_SEED_LOOKAHEAD, along with the dependent roots of the proposer and attester duties of each epoch.
+// Duties are only determined up to MIN_SEED_LOOKAHEAD epochs ahead. Duties of later epochs are computed by
+// advancing the head state through empty slots and flagged as tentative, as the blocks of the current epoch
+// still change their seed. The dependent roots of the determined duties are tracked, so that subscribers of
+// the duties_invalidated event topic are notified when a reorg changes them.
+func (s *Server) GetDutiesLookahead(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetDutiesLookahead")
+	defer span.End()
+
+	if shared.IsSyncing(ctx, w, s.SyncChecker, s.HeadFetcher, s.TimeFetcher, s.OptimisticModeFetcher) {
+		return
+	}
+
+	rawEpochs, lookahead, ok := shared.UintFromQuery(w, r, "epochs", false)
+	if !ok {
+		return
+	}
+	if rawEpochs == "" {
+		lookahead = uint64(params.BeaconConfig().MinSeedLookahead)
+	}
+	if lookahead > maxDutiesLookaheadEpochs {
+		httputil.HandleError(w, fmt.Sprintf("Cannot look ahead more than %d epochs", maxDutiesLookaheadEpochs), http.StatusBadRequest)
+		return
+	}
+
+	var indices []string
+	err := json.NewDecoder(r.Body).Decode(&indices)
+	switch {
+	case errors.Is(err, io.EOF):
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	case err != nil:
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(indices) == 0 {
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	}
+	requestedValIndices := make([]primitives.ValidatorIndex, len(indices))
+	for i, ix := range indices {
+		valIx, valid := shared.ValidateUint(w, fmt.Sprintf("ValidatorIndices[%d]", i), ix)
+		if !valid {
+			return
+		}
+		requestedValIndices[i] = primitives.ValidatorIndex(valIx)
+	}
+
+	currentEpoch := slots.ToEpoch(s.TimeFetcher.CurrentSlot())
+	st, headRoot, err := s.lookaheadState(ctx, currentEpoch)
+	if err != nil {
+		httputil.HandleError(w, "Could not get state: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for _, index := range requestedValIndices {
+		if uint64(index) >= uint64(st.NumValidators()) {
+			httputil.HandleError(w, fmt.Sprintf("Invalid validator index %d", index), http.StatusBadRequest)
+			return
+		}
+	}
+	genesisRoot, err := s.BeaconDB.GenesisBlockRoot(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get genesis block root: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := make([]*structs.EpochDuties, 0, lookahead+1)
+	served := make(map[primitives.Epoch]cache.DutyDependentRoots, lookahead+1)
+	for epoch := currentEpoch; epoch <= currentEpoch+primitives.Epoch(lookahead); epoch++ {
+		// epochDuties needs a state in the epoch or the one before.
+		if epoch > slots.ToEpoch(st.Slot())+1 {
+			st, err = advanceState(ctx, st, epoch)
+			if err != nil {
+				httputil.HandleError(w, fmt.Sprintf("Could not advance state to epoch %d: %v", epoch, err), http.StatusInternalServerError)
+				return
+			}
+		}
+		duties, roots, err := epochDuties(ctx, st, headRoot, genesisRoot, epoch, requestedValIndices)
+		if err != nil {
+			httputil.HandleError(w, fmt.Sprintf("Could not compute duties for epoch %d: %v", epoch, err), http.StatusInternalServerError)
+			return
+		}
+		if epoch > currentEpoch+params.BeaconConfig().MinSeedLookahead {
+			duties.Tentative = true
+		} else {
+			served[epoch] = roots
+		}
+		data = append(data, duties)
+	}
+
+	isOptimistic, err := s.OptimisticModeFetcher.IsOptimistic(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if s.ServedDutiesCache != nil {
+		s.ServedDutiesCache.Prune(currentEpoch)
+		for epoch, roots := range served {
+			s.ServedDutiesCache.Set(epoch, roots)
+		}
+	}
+
+	httputil.WriteJson(w, &structs.GetDutiesLookaheadResponse{
+		ExecutionOptimistic: isOptimistic,
+		Data:                data,
+	})
+}
+
+// lookaheadState returns the head state advanced to the start of the epoch, along with the head root.
+func (s *Server) lookaheadState(ctx context.Context, epoch primitives.Epoch) (state.BeaconState, [32]byte, error) {
+	st, err := s.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, [32]byte{}, errors.Wrap(err, "could not get head state")
+	}
+	headRoot, err := s.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		return nil, [32]byte{}, errors.Wrap(err, "could not get head root")
+	}
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	if st.Slot() < startSlot {
+		st, err = transition.ProcessSlotsUsingNextSlotCache(ctx, st, headRoot, startSlot)
+		if err != nil {
+			return nil, [32]byte{}, errors.Wrapf(err, "could not process slots up to %d", startSlot)
+		}
+	}
+	return st, bytesutil.ToBytes32(headRoot), nil
+}
+
+// advanceState returns a copy of the state processed through empty slots up to the start of the epoch.
+func advanceState(ctx context.Context, st state.BeaconState, epoch primitives.Epoch) (state.BeaconState, error) {
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, err
+	}
+	st, err = transition.ProcessSlots(ctx, st.Copy(), startSlot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not process slots up to %d", startSlot)
+	}
+	return st, nil
+}
+
+// epochDuties computes the duties of the validators for the epoch, which must be the epoch of the state
+// or the next one.
+func epochDuties(
+	ctx context.Context,
+	st state.BeaconState,
+	headRoot, genesisRoot [32]byte,
+	epoch primitives.Epoch,
+	valIndices []primitives.ValidatorIndex,
+) (*structs.EpochDuties, cache.DutyDependentRoots, error) {
+	var roots cache.DutyDependentRoots
+	var err error
+	roots.Proposer, err = dependentRoot(st, headRoot, genesisRoot, epoch)
+	if err != nil {
+		return nil, roots, errors.Wrap(err, "could not get proposer dependent root")
+	}
+	if epoch == 0 {
+		roots.Attester = genesisRoot
+	} else {
+		roots.Attester, err = dependentRoot(st, headRoot, genesisRoot, epoch-1)
+		if err != nil {
+			return nil, roots, errors.Wrap(err, "could not get attester dependent root")
+		}
+	}
+
+	proposerDuties, err := lookaheadProposerDuties(ctx, st, epoch, valIndices)
+	if err != nil {
+		return nil, roots, err
+	}
+	attesterDuties, err := lookaheadAttesterDuties(ctx, st, epoch, valIndices)
+	if err != nil {
+		return nil, roots, err
+	}
+	syncDuties, err := lookaheadSyncCommitteeDuties(st, epoch, valIndices)
+	if err != nil {
+		return nil, roots, err
+	}
+	return &structs.EpochDuties{
+		Epoch:                 strconv.FormatUint(uint64(epoch), 10),
+		ProposerDependentRoot: hexutil.Encode(roots.Proposer[:]),
+		AttesterDependentRoot: hexutil.Encode(roots.Attester[:]),
+		ProposerDuties:        proposerDuties,
+		AttesterDuties:        attesterDuties,
+		SyncCommitteeDuties:   syncDuties,
+	}, roots, nil
+}
+
+// dependentRoot is get_block_root_at_slot(state, compute_start_slot_at_epoch(epoch) - 1), or the genesis
+// block root in the case of underflow. Slots the state has not reached yet resolve to the head root.
+func dependentRoot(st state.BeaconState, headRoot, genesisRoot [32]byte, epoch primitives.Epoch) ([32]byte, error) {
+	if epoch == 0 {
+		return genesisRoot, nil
+	}
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	slot := startSlot - 1
+	if slot >= st.Slot() {
+		return headRoot, nil
+	}
+	root, err := helpers.BlockRootAtSlot(st, slot)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not get block root")
+	}
+	return bytesutil.ToBytes32(root), nil
+}
+
+func lookaheadProposerDuties(
+	ctx context.Context,
+	st state.BeaconState,
+	epoch primitives.Epoch,
+	valIndices []primitives.ValidatorIndex,
+) ([]*structs.ProposerDuty, error) {
+	assignments, err := helpers.ProposerAssignments(ctx, st, epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute proposer assignments")
+	}
+	duties := make([]*structs.ProposerDuty, 0)
+	proposalSlots := make(map[*structs.ProposerDuty]primitives.Slot)
+	for _, index := range valIndices {
+		pubkey := st.PubkeyAtIndex(index)
+		for _, slot := range assignments[index] {
+			duty := &structs.ProposerDuty{
+				Pubkey:         hexutil.Encode(pubkey[:]),
+				ValidatorIndex: strconv.FormatUint(uint64(index), 10),
+				Slot:           strconv.FormatUint(uint64(slot), 10),
+			}
+			proposalSlots[duty] = slot
+			duties = append(duties, duty)
+		}
+	}
+	sort.Slice(duties, func(i, j int) bool {
+		return proposalSlots[duties[i]] < proposalSlots[duties[j]]
+	})
+	return duties, nil
+}
+
+func lookaheadAttesterDuties(
+	ctx context.Context,
+	st state.BeaconState,
+	epoch primitives.Epoch,
+	valIndices []primitives.ValidatorIndex,
+) ([]*structs.AttesterDuty, error) {
+	assignments, err := helpers.CommitteeAssignments(ctx, st, epoch, valIndices)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute committee assignments")
+	}
+	activeValidatorCount, err := helpers.ActiveValidatorCount(ctx, st, epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get active validator count")
+	}
+	committeesAtSlot := helpers.SlotCommitteeCount(activeValidatorCount)
+
+	duties := make([]*structs.AttesterDuty, 0, len(valIndices))
+	for _, index := range valIndices {
+		committee := assignments[index]
+		if committee == nil {
+			continue
+		}
+		var valIndexInCommittee int
+		for cIndex, vIndex := range committee.Committee {
+			if vIndex == index {
+				valIndexInCommittee = cIndex
+				break
+			}
+		}
+		pubkey := st.PubkeyAtIndex(index)
+		duties = append(duties, &structs.AttesterDuty{
+			Pubkey:                  hexutil.Encode(pubkey[:]),
+			ValidatorIndex:          strconv.FormatUint(uint64(index), 10),
+			CommitteeIndex:          strconv.FormatUint(uint64(committee.CommitteeIndex), 10),
+			CommitteeLength:         strconv.Itoa(len(committee.Committee)),
+			CommitteesAtSlot:        strconv.FormatUint(committeesAtSlot, 10),
+			ValidatorCommitteeIndex: strconv.Itoa(valIndexInCommittee),
+			Slot:                    strconv.FormatUint(uint64(committee.AttesterSlot), 10),
+		})
+	}
+	return duties, nil
+}
+
+// lookaheadSyncCommitteeDuties returns the sync committee duties of the validators for the epoch and
+// registers the sync subnets of the validators that are part of the sync committee.
+func lookaheadSyncCommitteeDuties(
+	st state.BeaconState,
+	epoch primitives.Epoch,
+	valIndices []primitives.ValidatorIndex,
+) ([]*structs.SyncCommitteeDuty, error) {
+	duties := make([]*structs.SyncCommitteeDuty, 0)
+	if epoch < params.BeaconConfig().AltairForkEpoch || st.Version() < version.Altair {
+		return duties, nil
+	}
+	stateEpoch := slots.ToEpoch(st.Slot())
+	isCurrentPeriod := slots.SyncCommitteePeriod(epoch) == slots.SyncCommitteePeriod(stateEpoch)
+	var committee *ethpb.SyncCommittee
+	var err error
+	registerSyncSubnet := core.RegisterSyncSubnetCurrentPeriod
+	if isCurrentPeriod {
+		committee, err = st.CurrentSyncCommittee()
+	} else {
+		committee, err = st.NextSyncCommittee()
+		registerSyncSubnet = core.RegisterSyncSubnetNextPeriod
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync committee")
+	}
+
+	committeePubkeys := make(map[[fieldparams.BLSPubkeyLength]byte][]string)
+	for j, pubkey := range committee.Pubkeys {
+		pubkey48 := bytesutil.ToBytes48(pubkey)
+		committeePubkeys[pubkey48] = append(committeePubkeys[pubkey48], strconv.FormatUint(uint64(j), 10))
+	}
+	for _, index := range valIndices {
+		pubkey := st.PubkeyAtIndex(index)
+		committeeIndices, ok := committeePubkeys[pubkey]
+		if !ok {
+			continue
+		}
+		duties = append(duties, &structs.SyncCommitteeDuty{
+			Pubkey:                        hexutil.Encode(pubkey[:]),
+			ValidatorIndex:                strconv.FormatUint(uint64(index), 10),
+			ValidatorSyncCommitteeIndices: committeeIndices,
+		})
+		v, err := st.ValidatorAtIndexReadOnly(index)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get validator at index %d", index)
+		}
+		valStatus, err := rpchelpers.ValidatorStatus(v, epoch)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get validator status")
+		}
+		if err := registerSyncSubnet(st, epoch, pubkey[:], valStatus); err != nil {
+			return nil, errors.Wrapf(err, "could not register sync subnet for pubkey %#x", pubkey)
+		}
+	}
+	return duties, nil
+}
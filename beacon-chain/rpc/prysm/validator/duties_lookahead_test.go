@@ -0,0 +1,184 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	mockSync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestServer_GetDutiesLookahead(t *testing.T) {
+	helpers.ClearCache()
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+	ctx := context.Background()
+	const numVals = 64
+	st, _ := util.DeterministicGenesisStateAltair(t, numVals)
+	pubkeys := make([][]byte, params.BeaconConfig().SyncCommitteeSize)
+	for i := range pubkeys {
+		pk := st.PubkeyAtIndex(primitives.ValidatorIndex(i % numVals))
+		pubkeys[i] = pk[:]
+	}
+	require.NoError(t, st.SetCurrentSyncCommittee(&ethpb.SyncCommittee{Pubkeys: pubkeys, AggregatePubkey: make([]byte, 48)}))
+	genesisRoot := [32]byte{'g'}
+	headRoot := [32]byte{'h'}
+	beaconDB := dbTest.SetupDB(t)
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	newServer := func() *Server {
+		chainSlot := primitives.Slot(0)
+		chain := &mock.ChainService{State: st.Copy(), Root: headRoot[:], Slot: &chainSlot}
+		return &Server{
+			BeaconDB:              beaconDB,
+			HeadFetcher:           chain,
+			TimeFetcher:           chain,
+			OptimisticModeFetcher: chain,
+			SyncChecker:           &mockSync.Sync{IsSyncing: false},
+			ServedDutiesCache:     cache.NewServedDutiesCache(),
+		}
+	}
+	body := func(indices ...string) *bytes.Buffer {
+		b, err := json.Marshal(indices)
+		require.NoError(t, err)
+		return bytes.NewBuffer(b)
+	}
+	allIndices := make([]string, numVals)
+	for i := range allIndices {
+		allIndices[i] = strconv.Itoa(i)
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		s := newServer()
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validator/duties/lookahead", body(allIndices...))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetDutiesLookahead(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetDutiesLookaheadResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+
+		current, next := resp.Data[0], resp.Data[1]
+		assert.Equal(t, "0", current.Epoch)
+		assert.Equal(t, hexutil.Encode(genesisRoot[:]), current.ProposerDependentRoot)
+		assert.Equal(t, hexutil.Encode(genesisRoot[:]), current.AttesterDependentRoot)
+		// There is no proposer at the genesis slot.
+		assert.Equal(t, int(params.BeaconConfig().SlotsPerEpoch)-1, len(current.ProposerDuties))
+		assert.Equal(t, numVals, len(current.AttesterDuties))
+		require.Equal(t, numVals, len(current.SyncCommitteeDuties))
+		assert.Equal(t, int(params.BeaconConfig().SyncCommitteeSize)/numVals, len(current.SyncCommitteeDuties[0].ValidatorSyncCommitteeIndices))
+
+		assert.Equal(t, "1", next.Epoch)
+		// The last slot of the current epoch is not reached yet, so the head root is the dependent root.
+		assert.Equal(t, hexutil.Encode(headRoot[:]), next.ProposerDependentRoot)
+		assert.Equal(t, hexutil.Encode(genesisRoot[:]), next.AttesterDependentRoot)
+		assert.Equal(t, int(params.BeaconConfig().SlotsPerEpoch), len(next.ProposerDuties))
+		assert.Equal(t, numVals, len(next.AttesterDuties))
+		for i := 1; i < len(next.ProposerDuties); i++ {
+			prev, err := strconv.Atoi(next.ProposerDuties[i-1].Slot)
+			require.NoError(t, err)
+			cur, err := strconv.Atoi(next.ProposerDuties[i].Slot)
+			require.NoError(t, err)
+			assert.Equal(t, true, prev < cur)
+		}
+
+		served := s.ServedDutiesCache.Roots()
+		require.Equal(t, 2, len(served))
+		assert.Equal(t, headRoot, served[1].Proposer)
+		assert.Equal(t, genesisRoot, served[1].Attester)
+	})
+	t.Run("current epoch only", func(t *testing.T) {
+		s := newServer()
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validator/duties/lookahead?epochs=0", body("1"))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetDutiesLookahead(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetDutiesLookaheadResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		require.Equal(t, 1, len(resp.Data[0].AttesterDuties))
+		assert.Equal(t, "1", resp.Data[0].AttesterDuties[0].ValidatorIndex)
+		for _, d := range resp.Data[0].ProposerDuties {
+			assert.Equal(t, "1", d.ValidatorIndex)
+		}
+	})
+	t.Run("several epochs", func(t *testing.T) {
+		s := newServer()
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validator/duties/lookahead?epochs=3", body(allIndices...))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetDutiesLookahead(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetDutiesLookaheadResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 4, len(resp.Data))
+		for i, duties := range resp.Data {
+			assert.Equal(t, strconv.Itoa(i), duties.Epoch)
+			assert.Equal(t, i > 1, duties.Tentative)
+			assert.Equal(t, numVals, len(duties.AttesterDuties))
+			assert.Equal(t, true, len(duties.ProposerDuties) > 0)
+			startSlot := uint64(i) * uint64(params.BeaconConfig().SlotsPerEpoch)
+			for _, d := range duties.ProposerDuties {
+				slot, err := strconv.ParseUint(d.Slot, 10, 64)
+				require.NoError(t, err)
+				assert.Equal(t, true, slot >= startSlot && slot < startSlot+uint64(params.BeaconConfig().SlotsPerEpoch))
+			}
+		}
+		// Tentative duties are expected to change, so their dependent roots are not tracked.
+		assert.Equal(t, 2, len(s.ServedDutiesCache.Roots()))
+	})
+	t.Run("lookahead too far", func(t *testing.T) {
+		s := newServer()
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validator/duties/lookahead?epochs=5", body("1"))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetDutiesLookahead(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Cannot look ahead more than 4 epochs", writer.Body.String())
+	})
+	t.Run("invalid validator index", func(t *testing.T) {
+		s := newServer()
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validator/duties/lookahead", body("1", strconv.Itoa(numVals)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetDutiesLookahead(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Invalid validator index", writer.Body.String())
+		assert.Equal(t, 0, len(s.ServedDutiesCache.Roots()))
+	})
+	t.Run("no body", func(t *testing.T) {
+		s := newServer()
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validator/duties/lookahead", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetDutiesLookahead(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "No data submitted", writer.Body.String())
+	})
+}
@@ -0,0 +1,60 @@
+package validator
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"go.opencensus.io/trace"
+)
+
+// maxProposalDecisionsEpochs is the maximum number of epochs that can be requested at once.
+const maxProposalDecisionsEpochs = 256
+
+// GetProposalDecisions returns the audit log of the builder versus local payload selection of the blocks
+// proposed through this node between the start and end slots, optionally filtered by proposer index.
+func (s *Server) GetProposalDecisions(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetProposalDecisions")
+	defer span.End()
+
+	_, startSlot, ok := shared.UintFromQuery(w, r, "start_slot", true)
+	if !ok {
+		return
+	}
+	_, endSlot, ok := shared.UintFromQuery(w, r, "end_slot", true)
+	if !ok {
+		return
+	}
+	rawProposer, proposer, ok := shared.UintFromQuery(w, r, "proposer_index", false)
+	if !ok {
+		return
+	}
+	if startSlot > endSlot {
+		httputil.HandleError(w, "start_slot cannot be greater than end_slot", http.StatusBadRequest)
+		return
+	}
+	maxSlots := uint64(params.BeaconConfig().SlotsPerEpoch) * maxProposalDecisionsEpochs
+	if endSlot-startSlot >= maxSlots {
+		httputil.HandleError(w, fmt.Sprintf("Cannot request more than %d slots at once", maxSlots), http.StatusBadRequest)
+		return
+	}
+
+	decisions, err := s.BeaconDB.ProposalDecisions(ctx, primitives.Slot(startSlot), primitives.Slot(endSlot))
+	if err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not get proposal decisions").Error(), http.StatusInternalServerError)
+		return
+	}
+	data := make([]*structs.ProposalDecision, 0, len(decisions))
+	for _, d := range decisions {
+		if rawProposer != "" && d.ProposerIndex != primitives.ValidatorIndex(proposer) {
+			continue
+		}
+		data = append(data, structs.ProposalDecisionFromConsensus(d))
+	}
+	httputil.WriteJson(w, &structs.GetProposalDecisionsResponse{Data: data})
+}
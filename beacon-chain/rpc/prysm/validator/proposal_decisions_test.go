@@ -0,0 +1,85 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestServer_GetProposalDecisions(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	minBid := uint64(5)
+	for i := primitives.Slot(1); i <= 4; i++ {
+		require.NoError(t, beaconDB.SaveProposalDecision(ctx, &ethpb.ProposalDecision{
+			Slot:             i,
+			ProposerIndex:    primitives.ValidatorIndex(i % 2),
+			BuilderSelected:  i%2 == 0,
+			Reason:           "builder_value_higher",
+			BuilderValueGwei: uint64(i),
+			Relay:            "relay.example.com",
+			Policy:           &ethpb.BuilderPolicy{MinBuilderBid: &minBid},
+		}))
+	}
+	s := &Server{BeaconDB: beaconDB}
+
+	t.Run("range", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/proposal_decisions?start_slot=2&end_slot=3", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetProposalDecisions(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetProposalDecisionsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "2", resp.Data[0].Slot)
+		assert.Equal(t, true, resp.Data[0].BuilderSelected)
+		assert.Equal(t, "relay.example.com", resp.Data[0].Relay)
+		assert.Equal(t, "5", resp.Data[0].Policy.MinBuilderBid)
+		assert.Equal(t, "3", resp.Data[1].Slot)
+	})
+	t.Run("proposer filter", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/proposal_decisions?start_slot=0&end_slot=10&proposer_index=1", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetProposalDecisions(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetProposalDecisionsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "1", resp.Data[0].Slot)
+		assert.Equal(t, "3", resp.Data[1].Slot)
+	})
+	t.Run("start after end", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/proposal_decisions?start_slot=3&end_slot=2", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetProposalDecisions(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		require.StringContains(t, "start_slot cannot be greater than end_slot", writer.Body.String())
+	})
+	t.Run("missing end slot", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/proposal_decisions?start_slot=3", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetProposalDecisions(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("range too large", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/proposal_decisions?start_slot=0&end_slot=100000", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetProposalDecisions(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
@@ -2,16 +2,23 @@ package validator
 
 import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
 )
 
 type Server struct {
-	BeaconDB            db.ReadOnlyDatabase
-	Stater              lookup.Stater
-	CanonicalFetcher    blockchain.CanonicalFetcher
-	FinalizationFetcher blockchain.FinalizationFetcher
-	ChainInfoFetcher    blockchain.ChainInfoFetcher
-	CoreService         *core.Service
+	BeaconDB              db.ReadOnlyDatabase
+	Stater                lookup.Stater
+	CanonicalFetcher      blockchain.CanonicalFetcher
+	FinalizationFetcher   blockchain.FinalizationFetcher
+	ChainInfoFetcher      blockchain.ChainInfoFetcher
+	HeadFetcher           blockchain.HeadFetcher
+	TimeFetcher           blockchain.TimeFetcher
+	OptimisticModeFetcher blockchain.OptimisticModeFetcher
+	SyncChecker           sync.Checker
+	ServedDutiesCache     *cache.ServedDutiesCache
+	CoreService           *core.Service
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v5/async/event"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/builder"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
@@ -38,6 +39,7 @@ import (
 	debugv1alpha1 "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/v1alpha1/debug"
 	nodev1alpha1 "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/v1alpha1/node"
 	validatorv1alpha1 "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/v1alpha1/validator"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
 	chainSync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
@@ -86,6 +88,7 @@ type Service struct {
 	connectedRPCClients  map[net.Addr]bool
 	clientConnectionLock sync.Mutex
 	validatorServer      *validatorv1alpha1.Server
+	servedDutiesCache    *cache.ServedDutiesCache
 }
 
 // Config options for the beacon node RPC server.
@@ -139,6 +142,9 @@ type Config struct {
 	BlobStorage                   *filesystem.BlobStorage
 	TrackedValidatorsCache        *cache.TrackedValidatorsCache
 	PayloadIDCache                *cache.PayloadIDCache
+	SlasherAttestationsFeed       *event.Feed
+	SlasherBlockHeadersFeed       *event.Feed
+	SlasherQuerier                slasher.Querier
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -151,6 +157,7 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		cancel:              cancel,
 		incomingAttestation: make(chan *ethpbv1alpha1.Attestation, params.BeaconConfig().DefaultBufferSize),
 		connectedRPCClients: make(map[net.Addr]bool),
+		servedDutiesCache:   cache.NewServedDutiesCache(),
 	}
 
 	address := net.JoinHostPort(s.cfg.Host, s.cfg.Port)
@@ -195,11 +202,15 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 	s.grpcServer = grpc.NewServer(opts...)
 
 	var stateCache stategen.CachedGetter
+	historyOpts := make([]stategen.CanonicalHistoryOption, 0)
 	if s.cfg.StateGen != nil {
 		stateCache = s.cfg.StateGen.CombinedCache()
+		if d := s.cfg.StateGen.StateDiffs(); d != nil {
+			historyOpts = append(historyOpts, stategen.WithStateDiffs(d))
+		}
 	}
-	withCache := stategen.WithCache(stateCache)
-	ch := stategen.NewCanonicalHistory(s.cfg.BeaconDB, s.cfg.ChainInfoFetcher, s.cfg.ChainInfoFetcher, withCache)
+	historyOpts = append(historyOpts, stategen.WithCache(stateCache))
+	ch := stategen.NewCanonicalHistory(s.cfg.BeaconDB, s.cfg.ChainInfoFetcher, s.cfg.ChainInfoFetcher, historyOpts...)
 	stater := &lookup.BeaconDbStater{
 		BeaconDB:           s.cfg.BeaconDB,
 		ChainInfoFetcher:   s.cfg.ChainInfoFetcher,
@@ -345,6 +356,7 @@ var _ stategen.CurrentSlotter = blockchain.ChainInfoFetcher(nil)
 func (s *Service) Start() {
 	grpcprometheus.EnableHandlingTimeHistogram()
 	s.validatorServer.PruneBlobsBundleCacheRoutine()
+	s.validatorServer.SaveProposalDecisionsRoutine()
 	go func() {
 		if s.listener != nil {
 			if err := s.grpcServer.Serve(s.listener); err != nil {
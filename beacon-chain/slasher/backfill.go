@@ -0,0 +1,471 @@
+package slasher
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// backfillLogPeriod is the minimum time between two logs of the backfill progress.
+	backfillLogPeriod = 30 * time.Second
+	// maxQueuedAttestationsDuringBackfill and maxQueuedBlocksDuringBackfill bound the queues filled
+	// from the feeds while the backfill runs, as they are only processed once it completes.
+	maxQueuedAttestationsDuringBackfill = 100_000
+	maxQueuedBlocksDuringBackfill       = 10_000
+)
+
+// BackfillStateFetcher fetches the states from which the committees of the attestations
+// included in backfilled blocks are computed.
+type BackfillStateFetcher interface {
+	StateByRoot(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
+}
+
+// BackfillConfig defines the databases a backfill reads blocks from and writes slasher data to,
+// and the epochs it covers.
+type BackfillConfig struct {
+	BeaconDatabase  db.ReadOnlyDatabase
+	SlasherDatabase db.SlasherDatabase
+	StateFetcher    BackfillStateFetcher
+	// StartEpoch is the first epoch to backfill. When zero, the backfill starts after the last
+	// backfilled epoch, or HistoryLength epochs before the end epoch.
+	StartEpoch primitives.Epoch
+	// EndEpoch is the last epoch to backfill. When zero, the backfill ends at the last epoch
+	// before the finalized checkpoint.
+	EndEpoch primitives.Epoch
+}
+
+// BackfillResult summarizes a backfill. StartEpoch is after EndEpoch when there was nothing to backfill.
+type BackfillResult struct {
+	StartEpoch        primitives.Epoch
+	EndEpoch          primitives.Epoch
+	Blocks            int
+	Attestations      int
+	AttesterSlashings map[[fieldparams.RootLength]byte]ethpb.AttSlashing
+	ProposerSlashings []*ethpb.ProposerSlashing
+}
+
+// Backfill feeds the blocks of the beacon database through slashing detection, so that a slasher
+// enabled on an existing node knows about the attestations and proposals it did not receive over
+// gossip. The proposer headers of every block are checked for double proposals, and the attestations
+// included in finalized blocks for double and surround votes. Progress is saved after every epoch,
+// so that an interrupted backfill resumes where it stopped.
+//
+// Backfill is meant to run while the slasher service is stopped, for example from prysmctl. The
+// detected slashings are returned to the caller instead of being submitted to the operations pool.
+func Backfill(ctx context.Context, cfg *BackfillConfig) (*BackfillResult, error) {
+	s := &Service{
+		params:                         DefaultParams(),
+		serviceCfg:                     &ServiceConfig{Database: cfg.SlasherDatabase},
+		latestEpochUpdatedForValidator: make(map[primitives.ValidatorIndex]primitives.Epoch),
+	}
+	start, end, err := s.backfillRange(ctx, cfg.BeaconDatabase, cfg.StartEpoch, cfg.EndEpoch)
+	if err != nil {
+		return nil, err
+	}
+	if start > end {
+		return &BackfillResult{StartEpoch: start, EndEpoch: end}, nil
+	}
+
+	// Load the last epoch written for all the validators sharing a validator chunk with the validators
+	// known at the end of the backfill, as spans of the other validators in these chunks are updated too.
+	finalized, err := cfg.BeaconDatabase.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	endState, err := cfg.StateFetcher.StateByRoot(ctx, bytesutil.ToBytes32(finalized.Root))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized state")
+	}
+	validatorChunkSize := s.params.validatorChunkSize
+	numVals := (uint64(endState.NumValidators()) + validatorChunkSize - 1) / validatorChunkSize * validatorChunkSize
+	validatorIndices := make([]primitives.ValidatorIndex, numVals)
+	for i := range validatorIndices {
+		validatorIndices[i] = primitives.ValidatorIndex(i)
+	}
+	epochsByValidator, err := s.serviceCfg.Database.LastEpochWrittenForValidators(ctx, validatorIndices)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get last epoch written for validators")
+	}
+	for _, item := range epochsByValidator {
+		s.latestEpochUpdatedForValidator[item.ValidatorIndex] = item.Epoch
+	}
+
+	result, err := s.backfill(ctx, cfg.BeaconDatabase, cfg.StateFetcher, start, end)
+	// Save the epochs written by the backfill even when it was interrupted, as they match
+	// the spans saved to disk.
+	if saveErr := s.serviceCfg.Database.SaveLastEpochWrittenForValidators(
+		context.Background(), s.latestEpochUpdatedForValidator,
+	); saveErr != nil && err == nil {
+		err = errors.Wrap(saveErr, "could not save last epoch written for validators")
+	}
+	return result, err
+}
+
+// runBackfill backfills the slasher database from the beacon database before slashing detection
+// starts, and submits the detected slashings to the operations pool. The blocks after the finalized
+// checkpoint, which the backfill does not cover, are then queued for slashing detection.
+func (s *Service) runBackfill(ctx context.Context) {
+	s.backfillFinalized(ctx)
+	if ctx.Err() != nil {
+		return
+	}
+	if err := s.queueUnfinalizedBlocks(ctx); err != nil {
+		log.WithError(err).Error("Could not queue the blocks after the finalized checkpoint")
+	}
+}
+
+// backfillFinalized backfills the slasher database with the blocks before the finalized checkpoint.
+func (s *Service) backfillFinalized(ctx context.Context) {
+	start, end, err := s.backfillRange(ctx, s.serviceCfg.BeaconDatabase, 0, 0)
+	if err != nil {
+		log.WithError(err).Error("Could not determine the epochs to backfill")
+		return
+	}
+	if start > end {
+		log.WithField("endEpoch", end).Info("Slasher database is already backfilled")
+		return
+	}
+	result, err := s.backfill(ctx, s.serviceCfg.BeaconDatabase, s.serviceCfg.StateGen, start, end)
+	if err != nil {
+		log.WithError(err).Error("Could not backfill slasher database")
+	}
+	if result == nil {
+		return
+	}
+	if _, err := s.processAttesterSlashings(ctx, result.AttesterSlashings); err != nil {
+		log.WithError(err).Error(couldNotProcessAttesterSlashings)
+	}
+	if err := s.processProposerSlashings(ctx, result.ProposerSlashings); err != nil {
+		log.WithError(err).Error("Could not process proposer slashings")
+	}
+}
+
+// queueUnfinalizedBlocks queues the blocks of the beacon database from the finalized checkpoint to the
+// head, with the attestations of those of the canonical chain. The feeds are subscribed to before the
+// backfill, so the blocks received since then are queued already and are not queued a second time.
+func (s *Service) queueUnfinalizedBlocks(ctx context.Context) error {
+	beaconDB := s.serviceCfg.BeaconDatabase
+	finalized, err := beaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	startSlot, err := slots.EpochStart(finalized.Epoch)
+	if err != nil {
+		return err
+	}
+	headSlot := s.serviceCfg.HeadStateFetcher.HeadSlot()
+	if headSlot < startSlot {
+		return nil
+	}
+	headRoot, err := s.serviceCfg.HeadStateFetcher.HeadRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head root")
+	}
+	blks, roots, err := beaconDB.Blocks(ctx, filters.NewFilter().SetStartSlot(startSlot).SetEndSlot(headSlot))
+	if err != nil {
+		return errors.Wrap(err, "could not get blocks")
+	}
+
+	byRoot := make(map[[32]byte]interfaces.ReadOnlySignedBeaconBlock, len(blks))
+	for i, blk := range blks {
+		byRoot[roots[i]] = blk
+	}
+	canonical := make(map[[32]byte]bool)
+	for root := bytesutil.ToBytes32(headRoot); ; {
+		blk, ok := byRoot[root]
+		if !ok {
+			break
+		}
+		canonical[root] = true
+		root = blk.Block().ParentRoot()
+	}
+
+	indices := sortedBySlot(blks)
+	b := &backfiller{s: s, beaconDB: beaconDB, stateFetcher: s.serviceCfg.StateGen}
+	proposals := make([]*slashertypes.SignedBlockHeaderWrapper, 0, len(blks))
+	atts := make([]*slashertypes.IndexedAttestationWrapper, 0)
+	for _, i := range indices {
+		blk, root := blks[i], roots[i]
+		proposal, err := blockHeaderWrapper(blk, root)
+		if err != nil {
+			return err
+		}
+		proposals = append(proposals, proposal)
+		if len(blk.Block().Body().Attestations()) == 0 || !canonical[root] {
+			continue
+		}
+		blockAtts, err := b.indexedAttestations(ctx, slots.ToEpoch(blk.Block().Slot()), blk)
+		if err != nil {
+			return errors.Wrapf(err, "could not get attestations of block %#x", root)
+		}
+		atts = append(atts, blockAtts...)
+	}
+
+	numBlocks := s.blksQueue.extendMissing(proposals)
+	numAtts, err := s.attsQueue.extendMissing(atts)
+	if err != nil {
+		return err
+	}
+	log.WithFields(logrus.Fields{
+		"startSlot":    startSlot,
+		"headSlot":     headSlot,
+		"blocks":       numBlocks,
+		"attestations": numAtts,
+	}).Info("Queued the blocks after the finalized checkpoint for slashing detection")
+	return nil
+}
+
+// backfillRange returns the first and last epochs to backfill. The first epoch is after the last
+// one when there is nothing to backfill.
+func (s *Service) backfillRange(
+	ctx context.Context, beaconDB db.ReadOnlyDatabase, startEpoch, endEpoch primitives.Epoch,
+) (primitives.Epoch, primitives.Epoch, error) {
+	finalized, err := beaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	// Only the blocks of the epochs before the finalized checkpoint are all finalized.
+	if finalized.Epoch == 0 {
+		return 1, 0, nil
+	}
+	end := finalized.Epoch - 1
+	if endEpoch != 0 && endEpoch < end {
+		end = endEpoch
+	}
+	if startEpoch != 0 {
+		return startEpoch, end, nil
+	}
+
+	var start primitives.Epoch
+	if end >= s.params.historyLength {
+		start = end - s.params.historyLength + 1
+	}
+	last, found, err := s.serviceCfg.Database.LastBackfilledEpoch(ctx)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not get last backfilled epoch")
+	}
+	if found && last >= start {
+		start = last + 1
+	}
+	return start, end, nil
+}
+
+// backfill feeds the blocks of the epochs from start to end, both included, through slashing detection.
+func (s *Service) backfill(
+	ctx context.Context, beaconDB db.ReadOnlyDatabase, stateFetcher BackfillStateFetcher, start, end primitives.Epoch,
+) (*BackfillResult, error) {
+	b := &backfiller{s: s, beaconDB: beaconDB, stateFetcher: stateFetcher}
+	result := &BackfillResult{
+		StartEpoch:        start,
+		EndEpoch:          end,
+		AttesterSlashings: make(map[[fieldparams.RootLength]byte]ethpb.AttSlashing),
+	}
+
+	// Spans are updated up to the highest epoch already written, so that the data saved by the slasher
+	// before the backfill is kept. The backfilled attestations are then processed as late attestations.
+	currentEpoch := end
+	for _, epoch := range s.latestEpochUpdatedForValidator {
+		currentEpoch = max(currentEpoch, epoch)
+	}
+
+	log.WithFields(logrus.Fields{
+		"startEpoch": start,
+		"endEpoch":   end,
+	}).Info("Backfilling slasher database from the beacon database")
+	backfillTargetEpoch.Set(float64(end))
+	startTime := time.Now()
+	lastLog := startTime
+	for epoch := start; epoch <= end; epoch++ {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if err := b.backfillEpoch(ctx, epoch, currentEpoch, result); err != nil {
+			return result, errors.Wrapf(err, "could not backfill epoch %d", epoch)
+		}
+		if err := s.serviceCfg.Database.SaveLastBackfilledEpoch(ctx, epoch); err != nil {
+			return result, errors.Wrap(err, "could not save last backfilled epoch")
+		}
+		backfillLastEpoch.Set(float64(epoch))
+
+		if time.Since(lastLog) >= backfillLogPeriod || epoch == end {
+			lastLog = time.Now()
+			log.WithFields(logrus.Fields{
+				"epoch":        epoch,
+				"remaining":    end - epoch,
+				"blocks":       result.Blocks,
+				"attestations": result.Attestations,
+				"slashings":    len(result.AttesterSlashings) + len(result.ProposerSlashings),
+				"elapsed":      time.Since(startTime),
+			}).Info("Backfilling slasher database")
+		}
+		if epoch == end {
+			// Avoids an overflow when the end epoch is the maximum epoch.
+			break
+		}
+	}
+	return result, nil
+}
+
+// backfiller holds the state used to compute the committees of the backfilled attestations.
+type backfiller struct {
+	s            *Service
+	beaconDB     db.ReadOnlyDatabase
+	stateFetcher BackfillStateFetcher
+	// committeeState is a state in committeeEpoch, from which the committees of that epoch and
+	// of the previous one are computed.
+	committeeState state.BeaconState
+	committeeEpoch primitives.Epoch
+}
+
+// backfillEpoch runs slashing detection on the blocks of the given epoch and adds its outcome to the result.
+func (b *backfiller) backfillEpoch(ctx context.Context, epoch, currentEpoch primitives.Epoch, result *BackfillResult) error {
+	blks, roots, err := b.beaconDB.Blocks(ctx, filters.NewFilter().SetStartEpoch(epoch).SetEndEpoch(epoch))
+	if err != nil {
+		return errors.Wrap(err, "could not get blocks")
+	}
+	indices := sortedBySlot(blks)
+	proposals := make([]*slashertypes.SignedBlockHeaderWrapper, 0, len(blks))
+	atts := make([]*slashertypes.IndexedAttestationWrapper, 0)
+	for _, i := range indices {
+		blk, root := blks[i], roots[i]
+		proposal, err := blockHeaderWrapper(blk, root)
+		if err != nil {
+			return err
+		}
+		proposals = append(proposals, proposal)
+
+		// The committees are only known for sure for the blocks of the canonical chain.
+		if len(blk.Block().Body().Attestations()) == 0 || !b.beaconDB.IsFinalizedBlock(ctx, root) {
+			continue
+		}
+		blockAtts, err := b.indexedAttestations(ctx, epoch, blk)
+		if err != nil {
+			return errors.Wrapf(err, "could not get attestations of block %#x", root)
+		}
+		atts = append(atts, blockAtts...)
+	}
+
+	proposerSlashings, err := b.s.detectProposerSlashings(ctx, proposals)
+	if err != nil {
+		return errors.Wrap(err, "could not detect proposer slashings")
+	}
+	result.ProposerSlashings = append(result.ProposerSlashings, proposerSlashings...)
+	result.Blocks += len(blks)
+	backfillBlocksTotal.Add(float64(len(blks)))
+
+	validAtts, _, numDropped := b.s.filterAttestations(atts, currentEpoch)
+	droppedAttestationsTotal.Add(float64(numDropped))
+	attesterSlashings, err := b.s.checkSlashableAttestations(ctx, currentEpoch, validAtts)
+	if err != nil {
+		return errors.Wrap(err, couldNotCheckSlashableAtt)
+	}
+	for root, slashing := range attesterSlashings {
+		result.AttesterSlashings[root] = slashing
+	}
+	result.Attestations += len(validAtts)
+	backfillAttestationsTotal.Add(float64(len(validAtts)))
+	return nil
+}
+
+// sortedBySlot returns the indices of the blocks, ordered by the slot of the blocks.
+func sortedBySlot(blks []interfaces.ReadOnlySignedBeaconBlock) []int {
+	indices := make([]int, len(blks))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return blks[indices[i]].Block().Slot() < blks[indices[j]].Block().Slot()
+	})
+	return indices
+}
+
+// blockHeaderWrapper returns the signed header of the block, as checked for double proposals.
+func blockHeaderWrapper(blk interfaces.ReadOnlySignedBeaconBlock, root [32]byte) (*slashertypes.SignedBlockHeaderWrapper, error) {
+	header, err := blk.Header()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get header of block %#x", root)
+	}
+	headerRoot, err := header.Header.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get hash tree root of header of block %#x", root)
+	}
+	return &slashertypes.SignedBlockHeaderWrapper{
+		SignedBeaconBlockHeader: header,
+		HeaderRoot:              headerRoot,
+	}, nil
+}
+
+// indexedAttestations returns the attestations included in the block, with the indices of their attesters.
+func (b *backfiller) indexedAttestations(
+	ctx context.Context, epoch primitives.Epoch, blk interfaces.ReadOnlySignedBeaconBlock,
+) ([]*slashertypes.IndexedAttestationWrapper, error) {
+	st, err := b.stateForCommittees(ctx, epoch, blk)
+	if err != nil {
+		return nil, err
+	}
+	atts := blk.Block().Body().Attestations()
+	wrapped := make([]*slashertypes.IndexedAttestationWrapper, 0, len(atts))
+	for _, att := range atts {
+		committees, err := helpers.AttestationCommittees(ctx, st, att)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get attestation committees")
+		}
+		indexedAtt, err := attestation.ConvertToIndexed(ctx, att, committees...)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not convert to indexed attestation")
+		}
+		dataRoot, err := indexedAtt.GetData().HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get hash tree root of attestation")
+		}
+		wrapped = append(wrapped, &slashertypes.IndexedAttestationWrapper{
+			IndexedAttestation: indexedAtt,
+			DataRoot:           dataRoot,
+		})
+	}
+	return wrapped, nil
+}
+
+// stateForCommittees returns a state of the given epoch, obtained from the parent of the first
+// canonical block of the epoch that includes attestations.
+func (b *backfiller) stateForCommittees(
+	ctx context.Context, epoch primitives.Epoch, blk interfaces.ReadOnlySignedBeaconBlock,
+) (state.ReadOnlyBeaconState, error) {
+	if b.committeeState != nil && b.committeeEpoch == epoch {
+		return b.committeeState, nil
+	}
+	st, err := b.stateFetcher.StateByRoot(ctx, blk.Block().ParentRoot())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get parent state")
+	}
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, err
+	}
+	if st.Slot() < startSlot {
+		st, err = transition.ProcessSlots(ctx, st, startSlot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not process slots up to %d", startSlot)
+		}
+	}
+	b.committeeState = st
+	b.committeeEpoch = epoch
+	return st, nil
+}
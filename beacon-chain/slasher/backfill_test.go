@@ -0,0 +1,221 @@
+package slasher
+
+import (
+	"context"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestBackfill(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	slasherDB := dbtest.SetupSlasherDB(t)
+
+	st, keys := util.DeterministicGenesisState(t, 64)
+	genesis := util.NewBeaconBlock()
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis.Block.StateRoot = stateRoot[:]
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+	require.NoError(t, beaconDB.SaveState(ctx, st, genesisRoot))
+
+	// Blocks with attestations in epochs 0 and 1, and the block of the finalized checkpoint in epoch 2.
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	var (
+		root   [32]byte
+		epoch1 *ethpb.SignedBeaconBlock
+	)
+	for _, slot := range []primitives.Slot{1, 2, slotsPerEpoch + 1, 2 * slotsPerEpoch} {
+		blk, err := util.GenerateFullBlock(st, keys, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		wsb, err := blocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+		root, err = blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, wsb))
+		require.NoError(t, beaconDB.SaveState(ctx, st, root))
+		if slot == slotsPerEpoch+1 {
+			epoch1 = blk
+		}
+	}
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: root[:]}))
+
+	// A second block proposed in the same slot, off the canonical chain.
+	doubleProposal := epoch1.Copy()
+	doubleProposal.Block.Body.Graffiti = []byte("double proposal")
+	util.SaveBlock(t, ctx, beaconDB, doubleProposal)
+
+	cfg := &BackfillConfig{
+		BeaconDatabase:  beaconDB,
+		SlasherDatabase: slasherDB,
+		StateFetcher:    stategen.New(beaconDB, doublylinkedtree.New()),
+	}
+	result, err := Backfill(ctx, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Epoch(0), result.StartEpoch)
+	assert.Equal(t, primitives.Epoch(1), result.EndEpoch)
+	assert.Equal(t, 5, result.Blocks)
+	assert.Equal(t, 3, result.Attestations)
+	assert.Equal(t, 0, len(result.AttesterSlashings))
+	require.Equal(t, 1, len(result.ProposerSlashings))
+	assert.Equal(t, epoch1.Block.Slot, result.ProposerSlashings[0].Header_1.Header.Slot)
+
+	// The attestation included in the block of epoch 1 was saved.
+	target := epoch1.Block.Body.Attestations[0].Data.Target.Epoch
+	var recorded int
+	for i := primitives.ValidatorIndex(0); i < 64; i++ {
+		record, err := slasherDB.AttestationRecordForValidator(ctx, i, target)
+		require.NoError(t, err)
+		if record != nil {
+			recorded++
+		}
+	}
+	assert.NotEqual(t, 0, recorded)
+
+	last, found, err := slasherDB.LastBackfilledEpoch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, true, found)
+	assert.Equal(t, primitives.Epoch(1), last)
+
+	// Running the backfill again resumes after the last backfilled epoch.
+	result, err = Backfill(ctx, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Epoch(2), result.StartEpoch)
+	assert.Equal(t, 0, result.Blocks)
+}
+
+func TestService_queueUnfinalizedBlocks(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+
+	st, keys := util.DeterministicGenesisState(t, 64)
+	genesis := util.NewBeaconBlock()
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis.Block.StateRoot = stateRoot[:]
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+	require.NoError(t, beaconDB.SaveState(ctx, st, genesisRoot))
+
+	// The block of the finalized checkpoint in epoch 0, followed by two blocks in epochs 1 and 2.
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	var (
+		roots         [][32]byte
+		unfinalized   []*ethpb.SignedBeaconBlock
+		canonicalAtts int
+	)
+	for _, slot := range []primitives.Slot{2, slotsPerEpoch + 1, 2*slotsPerEpoch + 1} {
+		blk, err := util.GenerateFullBlock(st, keys, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		wsb, err := blocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, wsb))
+		require.NoError(t, beaconDB.SaveState(ctx, st, root))
+		roots = append(roots, root)
+		if slot > slotsPerEpoch {
+			unfinalized = append(unfinalized, blk)
+			canonicalAtts += len(blk.Block.Body.Attestations)
+		}
+	}
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: roots[0][:]}))
+	require.NotEqual(t, 0, canonicalAtts)
+
+	// A block off the canonical chain, whose header is queued but not its attestations.
+	fork := unfinalized[0].Copy()
+	fork.Block.Body.Graffiti = []byte("fork")
+	fork.Block.Body.Attestations = nil
+	util.SaveBlock(t, ctx, beaconDB, fork)
+
+	s := &Service{
+		params: DefaultParams(),
+		serviceCfg: &ServiceConfig{
+			BeaconDatabase:   beaconDB,
+			StateGen:         stategen.New(beaconDB, doublylinkedtree.New()),
+			HeadStateFetcher: &mock.ChainService{Root: roots[2][:], State: st},
+		},
+		attsQueue: newAttestationsQueue(),
+		blksQueue: newBlocksQueue(),
+	}
+	// The head block was already received from the feed, so it is not queued twice.
+	wsb, err := blocks.NewSignedBeaconBlock(unfinalized[1])
+	require.NoError(t, err)
+	received, err := blockHeaderWrapper(wsb, roots[2])
+	require.NoError(t, err)
+	s.blksQueue.push(received)
+
+	require.NoError(t, s.queueUnfinalizedBlocks(ctx))
+	assert.Equal(t, 3, s.blksQueue.size())
+	assert.Equal(t, canonicalAtts, s.attsQueue.size())
+}
+
+func TestService_backfillRange(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	slasherDB := dbtest.SetupSlasherDB(t)
+	s := &Service{
+		params:     &Parameters{chunkSize: 2, validatorChunkSize: 2, historyLength: 8},
+		serviceCfg: &ServiceConfig{Database: slasherDB},
+	}
+
+	// Nothing is finalized.
+	start, end, err := s.backfillRange(ctx, beaconDB, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, true, start > end)
+
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = 20 * params.BeaconConfig().SlotsPerEpoch
+	util.SaveBlock(t, ctx, beaconDB, blk)
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, root))
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: blk.Block.Slot, Root: root[:]}))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 20, Root: root[:]}))
+
+	tests := []struct {
+		name                 string
+		lastBackfilled       primitives.Epoch
+		startEpoch, endEpoch primitives.Epoch
+		wantStart, wantEnd   primitives.Epoch
+	}{
+		{name: "history length before the finalized checkpoint", wantStart: 12, wantEnd: 19},
+		{name: "end epoch", endEpoch: 15, wantStart: 8, wantEnd: 15},
+		{name: "end epoch after the finalized checkpoint", endEpoch: 25, wantStart: 12, wantEnd: 19},
+		{name: "start epoch", startEpoch: 3, wantStart: 3, wantEnd: 19},
+		{name: "resumes after the last backfilled epoch", lastBackfilled: 16, wantStart: 17, wantEnd: 19},
+		{name: "start epoch ignores the last backfilled epoch", lastBackfilled: 16, startEpoch: 3, wantStart: 3, wantEnd: 19},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.lastBackfilled != 0 {
+				require.NoError(t, slasherDB.SaveLastBackfilledEpoch(ctx, tt.lastBackfilled))
+			}
+			start, end, err := s.backfillRange(ctx, beaconDB, tt.startEpoch, tt.endEpoch)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
@@ -193,34 +193,9 @@ func (s *Service) checkDoubleVotes(
 			// This is a double vote.
 			doubleVotesTotal.Inc()
 
-			existing, ok := existingAttWrapper.IndexedAttestation.(*ethpb.IndexedAttestation)
-			if !ok {
-				return nil, fmt.Errorf(
-					"existing attestation has wrong type (expected %T, got %T)",
-					&ethpb.IndexedAttestation{},
-					existingAttWrapper.IndexedAttestation,
-				)
-			}
-			incoming, ok := incomingAttWrapper.IndexedAttestation.(*ethpb.IndexedAttestation)
-			if !ok {
-				return nil, fmt.Errorf(
-					"incoming attestation has wrong type (expected %T, got %T)",
-					&ethpb.IndexedAttestation{},
-					incomingAttWrapper.IndexedAttestation,
-				)
-			}
-
-			slashing := &ethpb.AttesterSlashing{
-				Attestation_1: existing,
-				Attestation_2: incoming,
-			}
-
-			// Ensure the attestation with the lower data root is the first attestation.
-			if bytes.Compare(existingAttWrapper.DataRoot[:], incomingAttWrapper.DataRoot[:]) > 0 {
-				slashing = &ethpb.AttesterSlashing{
-					Attestation_1: incoming,
-					Attestation_2: existing,
-				}
+			slashing, err := doubleVoteSlashing(existingAttWrapper, incomingAttWrapper)
+			if err != nil {
+				return nil, err
 			}
 
 			root, err := slashing.HashTreeRoot()
@@ -242,37 +217,9 @@ func (s *Service) checkDoubleVotes(
 	for _, doubleVote := range doubleVotes {
 		doubleVotesTotal.Inc()
 
-		wrapper_1 := doubleVote.Wrapper_1
-		wrapper_2 := doubleVote.Wrapper_2
-
-		att_1, ok := wrapper_1.IndexedAttestation.(*ethpb.IndexedAttestation)
-		if !ok {
-			return nil, fmt.Errorf(
-				"first attestation has wrong type (expected %T, got %T)",
-				&ethpb.IndexedAttestation{},
-				wrapper_1.IndexedAttestation,
-			)
-		}
-		att_2, ok := wrapper_2.IndexedAttestation.(*ethpb.IndexedAttestation)
-		if !ok {
-			return nil, fmt.Errorf(
-				"second attestation has wrong type (expected %T, got %T)",
-				&ethpb.IndexedAttestation{},
-				wrapper_2.IndexedAttestation,
-			)
-		}
-
-		slashing := &ethpb.AttesterSlashing{
-			Attestation_1: att_1,
-			Attestation_2: att_2,
-		}
-
-		// Ensure the attestation with the lower data root is the first attestation.
-		if bytes.Compare(wrapper_1.DataRoot[:], wrapper_2.DataRoot[:]) > 0 {
-			slashing = &ethpb.AttesterSlashing{
-				Attestation_1: att_2,
-				Attestation_2: att_1,
-			}
+		slashing, err := doubleVoteSlashing(doubleVote.Wrapper_1, doubleVote.Wrapper_2)
+		if err != nil {
+			return nil, err
 		}
 
 		root, err := slashing.HashTreeRoot()
@@ -286,6 +233,32 @@ func (s *Service) checkDoubleVotes(
 	return slashings, nil
 }
 
+// Builds the attester slashing of two different attestations for the same target epoch,
+// the attestation with the lower data root being the first attestation.
+func doubleVoteSlashing(wrapper_1, wrapper_2 *slashertypes.IndexedAttestationWrapper) (*ethpb.AttesterSlashing, error) {
+	att_1, ok := wrapper_1.IndexedAttestation.(*ethpb.IndexedAttestation)
+	if !ok {
+		return nil, fmt.Errorf(
+			"first attestation has wrong type (expected %T, got %T)",
+			&ethpb.IndexedAttestation{},
+			wrapper_1.IndexedAttestation,
+		)
+	}
+	att_2, ok := wrapper_2.IndexedAttestation.(*ethpb.IndexedAttestation)
+	if !ok {
+		return nil, fmt.Errorf(
+			"second attestation has wrong type (expected %T, got %T)",
+			&ethpb.IndexedAttestation{},
+			wrapper_2.IndexedAttestation,
+		)
+	}
+
+	if bytes.Compare(wrapper_1.DataRoot[:], wrapper_2.DataRoot[:]) > 0 {
+		return &ethpb.AttesterSlashing{Attestation_1: att_2, Attestation_2: att_1}, nil
+	}
+	return &ethpb.AttesterSlashing{Attestation_1: att_1, Attestation_2: att_2}, nil
+}
+
 // updatedChunkByChunkIndex loads the chunks from the database for validators corresponding to
 // the `validatorChunkIndex`.
 // It then updates the chunks with the neutral element for corresponding validators from
@@ -500,6 +473,12 @@ func (s *Service) updateSpans(
 					continue
 				}
 
+				if kind == slashertypes.MinSpan {
+					surroundingVotesTotal.Inc()
+				} else {
+					surroundedVotesTotal.Inc()
+				}
+
 				root, err := slashing.HashTreeRoot()
 				if err != nil {
 					return nil, errors.Wrap(err, "could not hash tree root for attester slashing")
@@ -33,6 +33,10 @@ var (
 		Name: "slasher_blocks_received_total",
 		Help: "Total number of blocks received by slasher",
 	})
+	droppedBlocksTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_blocks_dropped_total",
+		Help: "Total number of blocks dropped by slasher as its queue was full during the backfill",
+	})
 	processedBlocksTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "slasher_blocks_processed_total",
 		Help: "Total number of blocks successfully processed by slasher",
@@ -53,4 +57,20 @@ var (
 		Name: "slasher_surrounded_votes_total",
 		Help: "Total slashable surrounded votes successfully detected by slasher",
 	})
+	backfillLastEpoch = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "slasher_backfill_last_epoch",
+		Help: "Last epoch backfilled into the slasher database from the beacon database",
+	})
+	backfillTargetEpoch = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "slasher_backfill_target_epoch",
+		Help: "Last epoch to backfill into the slasher database from the beacon database",
+	})
+	backfillBlocksTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_backfill_blocks_total",
+		Help: "Total number of blocks backfilled into the slasher database",
+	})
+	backfillAttestationsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_backfill_attestations_total",
+		Help: "Total number of attestations backfilled into the slasher database",
+	})
 )
@@ -8,6 +8,7 @@ import (
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"golang.org/x/exp/maps"
 )
 
 // Verifies attester slashings, logs them, and submits them to the slashing operations pool
@@ -22,6 +23,13 @@ func (s *Service) processAttesterSlashings(
 		return processedSlashings, nil
 	}
 
+	// A remote beacon node verifies the slashings itself.
+	if s.serviceCfg.RemoteBeaconNode != nil {
+		processedSlashings = s.submitAttesterSlashings(ctx, slashings)
+		s.saveAttesterSlashings(ctx, processedSlashings)
+		return processedSlashings, nil
+	}
+
 	// Get the head state.
 	beaconState, err := s.serviceCfg.HeadStateFetcher.HeadState(ctx)
 	if err != nil {
@@ -56,6 +64,7 @@ func (s *Service) processAttesterSlashings(
 		processedSlashings[root] = slashing
 	}
 
+	s.saveAttesterSlashings(ctx, processedSlashings)
 	return processedSlashings, nil
 }
 
@@ -67,12 +76,20 @@ func (s *Service) processProposerSlashings(ctx context.Context, slashings []*eth
 		return nil
 	}
 
+	// A remote beacon node verifies the slashings itself.
+	if s.serviceCfg.RemoteBeaconNode != nil {
+		s.submitProposerSlashings(ctx, slashings)
+		s.saveProposerSlashings(ctx, slashings)
+		return nil
+	}
+
 	// Get the head state.
 	beaconState, err := s.serviceCfg.HeadStateFetcher.HeadState(ctx)
 	if err != nil {
 		return err
 	}
 
+	processedSlashings := make([]*ethpb.ProposerSlashing, 0, len(slashings))
 	for _, slashing := range slashings {
 		// Verify the signature of the first block.
 		if err := s.verifyBlockSignature(ctx, slashing.Header_1); err != nil {
@@ -97,11 +114,34 @@ func (s *Service) processProposerSlashings(ctx context.Context, slashings []*eth
 		if err := s.serviceCfg.SlashingPoolInserter.InsertProposerSlashing(ctx, beaconState, slashing); err != nil {
 			log.WithError(err).Error("Could not insert proposer slashing into operations pool")
 		}
+
+		processedSlashings = append(processedSlashings, slashing)
 	}
 
+	s.saveProposerSlashings(ctx, processedSlashings)
 	return nil
 }
 
+// Saves the attester slashings to the database, so that they can be queried later on.
+func (s *Service) saveAttesterSlashings(ctx context.Context, slashings map[[fieldparams.RootLength]byte]ethpb.AttSlashing) {
+	if len(slashings) == 0 {
+		return
+	}
+	if err := s.serviceCfg.Database.SaveAttesterSlashings(ctx, maps.Values(slashings)); err != nil {
+		log.WithError(err).Error("Could not save attester slashings")
+	}
+}
+
+// Saves the proposer slashings to the database, so that they can be queried later on.
+func (s *Service) saveProposerSlashings(ctx context.Context, slashings []*ethpb.ProposerSlashing) {
+	if len(slashings) == 0 {
+		return
+	}
+	if err := s.serviceCfg.Database.SaveProposerSlashings(ctx, slashings); err != nil {
+		log.WithError(err).Error("Could not save proposer slashings")
+	}
+}
+
 func (s *Service) verifyBlockSignature(ctx context.Context, header *ethpb.SignedBeaconBlockHeader) error {
 	parentState, err := s.serviceCfg.StateGen.StateByRoot(ctx, bytesutil.ToBytes32(header.Header.ParentRoot))
 	if err != nil {
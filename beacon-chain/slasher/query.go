@@ -0,0 +1,247 @@
+package slasher
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"golang.org/x/exp/maps"
+)
+
+var (
+	// ErrNoHistory is returned when the slasher has no history for a validator.
+	ErrNoHistory = errors.New("no slasher history for validator")
+	// ErrOutsideHistory is returned when epochs are requested outside the history kept for a validator.
+	ErrOutsideHistory = errors.New("epochs are outside the slasher history of the validator")
+	// ErrInvalidAttestation is returned when an attestation can not be checked for slashings.
+	ErrInvalidAttestation = errors.New("attestation can not be checked for slashings")
+	// ErrNotStarted is returned when attestations are checked before the slasher knows the genesis time.
+	ErrNotStarted = errors.New("slasher has not started yet")
+)
+
+// Querier allows inspecting the history and the slashings kept by the slasher.
+type Querier interface {
+	HistoryRange(ctx context.Context, validatorIndex primitives.ValidatorIndex) (primitives.Epoch, primitives.Epoch, error)
+	Spans(ctx context.Context, validatorIndex primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch) ([]*EpochSpans, error)
+	AttestationRecords(ctx context.Context, validatorIndex primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch) ([]*slashertypes.IndexedAttestationWrapper, error)
+	Slashings(ctx context.Context, validatorIndex primitives.ValidatorIndex) ([]ethpb.AttSlashing, []*ethpb.ProposerSlashing, error)
+	CheckAttestation(ctx context.Context, att ethpb.IndexedAtt) ([]ethpb.AttSlashing, error)
+}
+
+var _ Querier = &Service{}
+
+// EpochSpans are the min and max spans of a validator at an epoch, which are the distances
+// from the epoch to the closest and furthest target epochs of its attestations with a source epoch
+// after the epoch. The neutral element of each span is stored when there is no such attestation.
+type EpochSpans struct {
+	Epoch   primitives.Epoch
+	MinSpan uint16
+	MaxSpan uint16
+}
+
+// HistoryRange returns the range of epochs, inclusive, the slasher keeps the history of a validator for.
+func (s *Service) HistoryRange(ctx context.Context, validatorIndex primitives.ValidatorIndex) (primitives.Epoch, primitives.Epoch, error) {
+	s.attsLock.Lock()
+	latestEpoch, ok := s.latestEpochUpdatedForValidator[validatorIndex]
+	s.attsLock.Unlock()
+	if !ok {
+		// The latest epochs are loaded from the database once the slasher starts detecting slashings.
+		epochs, err := s.serviceCfg.Database.LastEpochWrittenForValidators(ctx, []primitives.ValidatorIndex{validatorIndex})
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "could not get last epoch written for validator")
+		}
+		if len(epochs) == 0 {
+			return 0, 0, ErrNoHistory
+		}
+		latestEpoch = epochs[0].Epoch
+	}
+	firstEpoch, err := latestEpoch.SafeSub(uint64(s.params.historyLength - 1))
+	if err != nil {
+		firstEpoch = 0
+	}
+	return firstEpoch, latestEpoch, nil
+}
+
+// Spans returns the min and max spans of a validator from the start to the end epoch, inclusive.
+func (s *Service) Spans(
+	ctx context.Context, validatorIndex primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch,
+) ([]*EpochSpans, error) {
+	if err := s.checkHistoryRange(ctx, validatorIndex, startEpoch, endEpoch); err != nil {
+		return nil, err
+	}
+
+	// Chunks are read while no attestations are being checked, so that they are consistent.
+	s.attsLock.Lock()
+	defer s.attsLock.Unlock()
+
+	validatorChunkIndex := s.params.validatorChunkIndex(validatorIndex)
+	spans := make([]*EpochSpans, 0, endEpoch-startEpoch+1)
+	for epoch := startEpoch; epoch <= endEpoch; {
+		chunkIndex := s.params.chunkIndex(epoch)
+		minChunk, err := s.getChunkFromDatabase(ctx, slashertypes.MinSpan, validatorChunkIndex, chunkIndex)
+		if err != nil {
+			return nil, err
+		}
+		maxChunk, err := s.getChunkFromDatabase(ctx, slashertypes.MaxSpan, validatorChunkIndex, chunkIndex)
+		if err != nil {
+			return nil, err
+		}
+		// Read all the epochs of the chunks.
+		for ; epoch <= endEpoch && s.params.chunkIndex(epoch) == chunkIndex; epoch++ {
+			cellIndex := s.params.cellIndex(validatorIndex, epoch)
+			spans = append(spans, &EpochSpans{
+				Epoch:   epoch,
+				MinSpan: minChunk.Chunk()[cellIndex],
+				MaxSpan: maxChunk.Chunk()[cellIndex],
+			})
+		}
+	}
+	return spans, nil
+}
+
+// AttestationRecords returns the attestations of a validator recorded by the slasher with target epochs
+// from the start to the end epoch, inclusive. Only the first attestation seen for a target epoch is recorded.
+func (s *Service) AttestationRecords(
+	ctx context.Context, validatorIndex primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch,
+) ([]*slashertypes.IndexedAttestationWrapper, error) {
+	if err := s.checkHistoryRange(ctx, validatorIndex, startEpoch, endEpoch); err != nil {
+		return nil, err
+	}
+	var records []*slashertypes.IndexedAttestationWrapper
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		record, err := s.serviceCfg.Database.AttestationRecordForValidator(ctx, validatorIndex, epoch)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get attestation record at target epoch %d", epoch)
+		}
+		if record != nil {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// Slashings returns the slashings of a validator detected by the slasher.
+func (s *Service) Slashings(
+	ctx context.Context, validatorIndex primitives.ValidatorIndex,
+) ([]ethpb.AttSlashing, []*ethpb.ProposerSlashing, error) {
+	attesterSlashings, err := s.serviceCfg.Database.AttesterSlashingsForValidator(ctx, validatorIndex)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get attester slashings")
+	}
+	proposerSlashings, err := s.serviceCfg.Database.ProposerSlashingsForValidator(ctx, validatorIndex)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get proposer slashings")
+	}
+	return attesterSlashings, proposerSlashings, nil
+}
+
+// CheckAttestation returns the slashings the attestation would cause, running the same
+// double and surround vote checks as the attestations received by the slasher.
+// Nothing is saved, neither the attestation nor the spans it would update.
+func (s *Service) CheckAttestation(ctx context.Context, att ethpb.IndexedAtt) ([]ethpb.AttSlashing, error) {
+	if !validateAttestationIntegrity(att) {
+		return nil, errors.Wrap(ErrInvalidAttestation, "attestation is malformed")
+	}
+	if _, ok := att.(*ethpb.IndexedAttestation); !ok {
+		return nil, errors.Wrapf(ErrInvalidAttestation, "unsupported attestation type %T", att)
+	}
+	dataRoot, err := att.GetData().HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get hash tree root of attestation")
+	}
+	attWrapper := &slashertypes.IndexedAttestationWrapper{IndexedAttestation: att, DataRoot: dataRoot}
+
+	s.attsLock.Lock()
+	defer s.attsLock.Unlock()
+
+	if s.genesisTime.IsZero() {
+		return nil, ErrNotStarted
+	}
+	currentEpoch := slots.ToEpoch(slots.CurrentSlot(uint64(s.genesisTime.Unix())))
+	valid, _, _ := s.filterAttestations([]*slashertypes.IndexedAttestationWrapper{attWrapper}, currentEpoch)
+	if len(valid) == 0 {
+		return nil, errors.Wrapf(ErrInvalidAttestation, "attestation is not within the slasher history ending at epoch %d", currentEpoch)
+	}
+
+	slashings := map[[fieldparams.RootLength]byte]ethpb.AttSlashing{}
+
+	// Double votes.
+	doubleVotes, err := s.serviceCfg.Database.CheckAttesterDoubleVotes(ctx, valid)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not check double votes")
+	}
+	for _, doubleVote := range doubleVotes {
+		slashing, err := doubleVoteSlashing(doubleVote.Wrapper_1, doubleVote.Wrapper_2)
+		if err != nil {
+			return nil, err
+		}
+		if err := addSlashing(slashings, slashing); err != nil {
+			return nil, err
+		}
+	}
+
+	// Surrounding and surrounded votes, against the spans updated up to the current epoch in memory only.
+	for _, kind := range []slashertypes.ChunkKind{slashertypes.MinSpan, slashertypes.MaxSpan} {
+		chunksByValidatorChunkIndex := map[uint64]map[uint64]Chunker{}
+		for _, index := range att.GetAttestingIndices() {
+			validatorIndex := primitives.ValidatorIndex(index)
+			validatorChunkIndex := s.params.validatorChunkIndex(validatorIndex)
+			chunks, ok := chunksByValidatorChunkIndex[validatorChunkIndex]
+			if !ok {
+				chunks, err = s.updatedChunkByChunkIndex(ctx, kind, currentEpoch, validatorChunkIndex)
+				if err != nil {
+					return nil, errors.Wrap(err, "could not load chunks")
+				}
+				chunksByValidatorChunkIndex[validatorChunkIndex] = chunks
+			}
+			chunkIndex := s.params.chunkIndex(att.GetData().Source.Epoch)
+			chunk, ok := chunks[chunkIndex]
+			if !ok {
+				chunk, err = s.getChunkFromDatabase(ctx, kind, validatorChunkIndex, chunkIndex)
+				if err != nil {
+					return nil, errors.Wrapf(err, "could not get chunk at index %d", chunkIndex)
+				}
+				chunks[chunkIndex] = chunk
+			}
+			slashing, err := chunk.CheckSlashable(ctx, s.serviceCfg.Database, validatorIndex, attWrapper)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not check if attestation for validator index %d is slashable", validatorIndex)
+			}
+			if slashing == nil {
+				continue
+			}
+			if err := addSlashing(slashings, slashing); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return maps.Values(slashings), nil
+}
+
+// checkHistoryRange checks the epochs, inclusive, are within the history kept for the validator.
+func (s *Service) checkHistoryRange(
+	ctx context.Context, validatorIndex primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch,
+) error {
+	firstEpoch, lastEpoch, err := s.HistoryRange(ctx, validatorIndex)
+	if err != nil {
+		return err
+	}
+	if startEpoch > endEpoch || startEpoch < firstEpoch || endEpoch > lastEpoch {
+		return errors.Wrapf(ErrOutsideHistory, "history of validator %d is within epochs [%d, %d]", validatorIndex, firstEpoch, lastEpoch)
+	}
+	return nil
+}
+
+func addSlashing(slashings map[[fieldparams.RootLength]byte]ethpb.AttSlashing, slashing ethpb.AttSlashing) error {
+	root, err := slashing.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not hash tree root for attester slashing")
+	}
+	slashings[root] = slashing
+	return nil
+}
@@ -0,0 +1,92 @@
+package slasher
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestService_Query(t *testing.T) {
+	ctx := context.Background()
+	s, err := New(ctx, &ServiceConfig{
+		Database:         dbtest.SetupSlasherDB(t),
+		RemoteBeaconNode: &mockRemoteBeaconNode{},
+	})
+	require.NoError(t, err)
+	epochDuration := time.Duration(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot)) * time.Second
+	s.genesisTime = time.Now().Add(-3 * epochDuration)
+
+	currentSlot, err := slots.EpochStart(2)
+	require.NoError(t, err)
+	att := createAttestationWrapperEmptySig(t, 1, 2, []uint64{1}, []byte{1})
+	assert.Equal(t, 0, len(s.processAttestations(ctx, []*slashertypes.IndexedAttestationWrapper{att}, currentSlot)))
+
+	first, last, err := s.HistoryRange(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Epoch(0), first)
+	assert.Equal(t, primitives.Epoch(2), last)
+	_, _, err = s.HistoryRange(ctx, 100_000)
+	require.ErrorIs(t, err, ErrNoHistory)
+
+	spans, err := s.Spans(ctx, 1, 0, 2)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(spans))
+	// The attestation has the closest target of the attestations with a source after epoch 0.
+	assert.DeepEqual(t, &EpochSpans{Epoch: 0, MinSpan: 2, MaxSpan: 0}, spans[0])
+	assert.Equal(t, uint16(math.MaxUint16), spans[1].MinSpan)
+	_, err = s.Spans(ctx, 1, 0, 3)
+	require.ErrorIs(t, err, ErrOutsideHistory)
+
+	records, err := s.AttestationRecords(ctx, 1, 0, 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	assert.DeepEqual(t, att.IndexedAttestation, records[0].IndexedAttestation)
+
+	// Dry runs.
+	doubleVote := createAttestationWrapperEmptySig(t, 1, 2, []uint64{1}, []byte{2})
+	slashings, err := s.CheckAttestation(ctx, doubleVote.IndexedAttestation)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(slashings))
+	surroundVote := createAttestationWrapperEmptySig(t, 0, 3, []uint64{1}, []byte{1})
+	slashings, err = s.CheckAttestation(ctx, surroundVote.IndexedAttestation)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(slashings))
+	// The attestations of a slashing are ordered by data root.
+	pair := []ethpb.IndexedAtt{slashings[0].FirstAttestation(), slashings[0].SecondAttestation()}
+	if bytes.Compare(att.DataRoot[:], surroundVote.DataRoot[:]) > 0 {
+		pair[0], pair[1] = pair[1], pair[0]
+	}
+	assert.DeepEqual(t, att.IndexedAttestation, pair[0])
+	assert.DeepEqual(t, surroundVote.IndexedAttestation, pair[1])
+	slashings, err = s.CheckAttestation(ctx, createAttestationWrapperEmptySig(t, 2, 3, []uint64{1}, nil).IndexedAttestation)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(slashings))
+	_, err = s.CheckAttestation(ctx, createAttestationWrapperEmptySig(t, 3, 4, []uint64{1}, nil).IndexedAttestation)
+	require.ErrorIs(t, err, ErrInvalidAttestation)
+
+	// Nothing was saved by the dry runs.
+	_, last, err = s.HistoryRange(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Epoch(2), last)
+	attesterSlashings, _, err := s.Slashings(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(attesterSlashings))
+
+	// Detected slashings are saved.
+	assert.Equal(t, 1, len(s.processAttestations(ctx, []*slashertypes.IndexedAttestationWrapper{doubleVote}, currentSlot)))
+	attesterSlashings, proposerSlashings, err := s.Slashings(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(attesterSlashings))
+	assert.Equal(t, 0, len(proposerSlashings))
+}
@@ -81,3 +81,51 @@ func (q *blocksQueue) extend(blks []*slashertypes.SignedBlockHeaderWrapper) {
 	defer q.lock.Unlock()
 	q.items = append(q.items, blks...)
 }
+
+// extendMissing appends the attestations that are not queued yet, and returns how many were appended.
+func (q *attestationsQueue) extendMissing(atts []*slashertypes.IndexedAttestationWrapper) (int, error) {
+	q.Lock()
+	defer q.Unlock()
+	queued := make(map[[32]byte]bool, len(q.items))
+	for _, item := range q.items {
+		root, err := item.IndexedAttestation.HashTreeRoot()
+		if err != nil {
+			return 0, err
+		}
+		queued[root] = true
+	}
+	var count int
+	for _, att := range atts {
+		root, err := att.IndexedAttestation.HashTreeRoot()
+		if err != nil {
+			return count, err
+		}
+		if queued[root] {
+			continue
+		}
+		queued[root] = true
+		q.items = append(q.items, att)
+		count++
+	}
+	return count, nil
+}
+
+// extendMissing appends the block headers that are not queued yet, and returns how many were appended.
+func (q *blocksQueue) extendMissing(blks []*slashertypes.SignedBlockHeaderWrapper) int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	queued := make(map[[32]byte]bool, len(q.items))
+	for _, item := range q.items {
+		queued[item.HeaderRoot] = true
+	}
+	var count int
+	for _, blk := range blks {
+		if queued[blk.HeaderRoot] {
+			continue
+		}
+		queued[blk.HeaderRoot] = true
+		q.items = append(q.items, blk)
+		count++
+	}
+	return count
+}
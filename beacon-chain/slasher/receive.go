@@ -38,6 +38,10 @@ func (s *Service) receiveAttestations(ctx context.Context, indexedAttsChan chan
 				log.WithError(err).Error("Could not get hash tree root of attestation")
 				continue
 			}
+			if s.backfilling.Load() && s.attsQueue.size() >= maxQueuedAttestationsDuringBackfill {
+				droppedAttestationsTotal.Inc()
+				continue
+			}
 			attWrapper := &slashertypes.IndexedAttestationWrapper{
 				IndexedAttestation: att.IndexedAtt,
 				DataRoot:           dataRoot,
@@ -69,6 +73,10 @@ func (s *Service) receiveBlocks(ctx context.Context, beaconBlockHeadersChan chan
 				log.WithError(err).Error("Could not get hash tree root of signed block header")
 				continue
 			}
+			if s.backfilling.Load() && s.blksQueue.size() >= maxQueuedBlocksDuringBackfill {
+				droppedBlocksTotal.Inc()
+				continue
+			}
 			wrappedProposal := &slashertypes.SignedBlockHeaderWrapper{
 				SignedBeaconBlockHeader: blockHeader,
 				HeaderRoot:              headerRoot,
@@ -142,7 +150,9 @@ func (s *Service) processAttestations(
 	start := time.Now()
 
 	// Check for attestations slashings (double, surrounding, surrounded votes).
+	s.attsLock.Lock()
 	slashings, err := s.checkSlashableAttestations(ctx, currentEpoch, validAttestations)
+	s.attsLock.Unlock()
 	if err != nil {
 		log.WithError(err).Error(couldNotCheckSlashableAtt)
 		return nil
@@ -216,7 +226,12 @@ func (s *Service) pruneSlasherData(ctx context.Context, slotTicker <-chan primit
 	for {
 		select {
 		case <-slotTicker:
-			headEpoch := slots.ToEpoch(s.serviceCfg.HeadStateFetcher.HeadSlot())
+			headSlot, err := s.headSlot(ctx)
+			if err != nil {
+				log.WithError(err).Error("Could not get head slot")
+				continue
+			}
+			headEpoch := slots.ToEpoch(headSlot)
 			if err := s.pruneSlasherDataWithinSlidingWindow(ctx, headEpoch); err != nil {
 				log.WithError(err).Error("Could not prune slasher data")
 				continue
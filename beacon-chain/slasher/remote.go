@@ -0,0 +1,65 @@
+package slasher
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+// RemoteBeaconNode is the beacon node of a slasher running in its own process. The beacon node streams the
+// attestations and block headers it receives to the slasher, and verifies the slashings submitted to it before
+// inserting them into its slashing operations pool.
+type RemoteBeaconNode interface {
+	HeadSlot(ctx context.Context) (primitives.Slot, error)
+	NumValidators(ctx context.Context) (uint64, error)
+	SubmitAttesterSlashing(ctx context.Context, slashing ethpb.AttSlashing) error
+	SubmitProposerSlashing(ctx context.Context, slashing *ethpb.ProposerSlashing) error
+}
+
+// headSlot returns the slot of the head block of the beacon node.
+func (s *Service) headSlot(ctx context.Context) (primitives.Slot, error) {
+	if s.serviceCfg.RemoteBeaconNode != nil {
+		return s.serviceCfg.RemoteBeaconNode.HeadSlot(ctx)
+	}
+	return s.serviceCfg.HeadStateFetcher.HeadSlot(), nil
+}
+
+// numValidators returns the number of validators in the head state of the beacon node.
+func (s *Service) numValidators(ctx context.Context) (uint64, error) {
+	if s.serviceCfg.RemoteBeaconNode != nil {
+		return s.serviceCfg.RemoteBeaconNode.NumValidators(ctx)
+	}
+	headState, err := s.serviceCfg.HeadStateFetcher.HeadState(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get head state")
+	}
+	return uint64(headState.NumValidators()), nil
+}
+
+// Logs attester slashings and submits them to the remote beacon node.
+func (s *Service) submitAttesterSlashings(
+	ctx context.Context, slashings map[[fieldparams.RootLength]byte]ethpb.AttSlashing,
+) map[[fieldparams.RootLength]byte]ethpb.AttSlashing {
+	processedSlashings := make(map[[fieldparams.RootLength]byte]ethpb.AttSlashing, len(slashings))
+	for root, slashing := range slashings {
+		logAttesterSlashing(slashing)
+		if err := s.serviceCfg.RemoteBeaconNode.SubmitAttesterSlashing(ctx, slashing); err != nil {
+			log.WithError(err).Error("Could not submit attester slashing to the beacon node")
+		}
+		processedSlashings[root] = slashing
+	}
+	return processedSlashings
+}
+
+// Logs proposer slashings and submits them to the remote beacon node.
+func (s *Service) submitProposerSlashings(ctx context.Context, slashings []*ethpb.ProposerSlashing) {
+	for _, slashing := range slashings {
+		logProposerSlashing(slashing)
+		if err := s.serviceCfg.RemoteBeaconNode.SubmitProposerSlashing(ctx, slashing); err != nil {
+			log.WithError(err).Error("Could not submit proposer slashing to the beacon node")
+		}
+	}
+}
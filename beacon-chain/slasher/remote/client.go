@@ -0,0 +1,315 @@
+// Package remote connects a slasher running in its own process to one or more beacon nodes. The beacon nodes,
+// run with --slasher-stream, stream the indexed attestations and block headers they receive over the slasher
+// topics of the beacon API event stream, and the slashings detected are submitted to the slashing pools of all of them.
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	apievent "github.com/prysmaticlabs/prysm/v5/api/client/event"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// seenCacheSize is the number of attestations and block headers remembered to drop the copies
+	// streamed by the other beacon nodes.
+	seenCacheSize = 1 << 17
+	// reconnectDelay is the time waited before connecting again to a beacon node.
+	reconnectDelay = 5 * time.Second
+)
+
+var _ slasher.RemoteBeaconNode = (*Client)(nil)
+
+// Client streams the slasher inputs of beacon nodes and submits slashings to them.
+type Client struct {
+	nodes        []*beaconNode
+	streamClient *http.Client
+	seen         *lru.Cache
+}
+
+// NewClient returns a client for the beacon nodes at the given beacon API endpoints.
+func NewClient(endpoints []string, opts ...client.ClientOpt) (*Client, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no beacon node endpoints provided")
+	}
+	nodes := make([]*beaconNode, len(endpoints))
+	for i, endpoint := range endpoints {
+		c, err := client.NewClient(endpoint, opts...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid beacon node endpoint %s", endpoint)
+		}
+		nodes[i] = &beaconNode{Client: c}
+	}
+	seen, err := lru.New(seenCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		nodes: nodes,
+		// The event streams are long-lived, so they are not subject to the timeout of the other requests.
+		streamClient: &http.Client{},
+		seen:         seen,
+	}, nil
+}
+
+// Clock waits for at least one beacon node to respond and returns the clock of their chain.
+// Beacon nodes that are on another chain than the first one to respond are rejected.
+func (c *Client) Clock(ctx context.Context) (*startup.Clock, error) {
+	ticker := time.NewTicker(reconnectDelay)
+	defer ticker.Stop()
+	for {
+		var clock *startup.Clock
+		for _, n := range c.nodes {
+			genesisTime, root, err := n.genesis(ctx)
+			if err != nil {
+				log.WithError(err).WithField("beaconNode", n.NodeURL()).Warn("Could not get genesis from beacon node")
+				continue
+			}
+			if clock == nil {
+				clock = startup.NewClock(genesisTime, root)
+				continue
+			}
+			if clock.GenesisValidatorsRoot() != root || !clock.GenesisTime().Equal(genesisTime) {
+				return nil, errors.Errorf("beacon node %s is on another chain than the other beacon nodes", n.NodeURL())
+			}
+		}
+		if clock != nil {
+			return clock, nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Stream sends the indexed attestations and block headers streamed by the beacon nodes to the feeds, once
+// for all the beacon nodes, until the context is canceled. Streams are reconnected when they end.
+func (c *Client) Stream(ctx context.Context, attestationsFeed, blockHeadersFeed *event.Feed) {
+	var wg sync.WaitGroup
+	for _, n := range c.nodes {
+		wg.Add(1)
+		go func(n *beaconNode) {
+			defer wg.Done()
+			c.streamFrom(ctx, n, attestationsFeed, blockHeadersFeed)
+		}(n)
+	}
+	wg.Wait()
+}
+
+func (c *Client) streamFrom(ctx context.Context, n *beaconNode, attestationsFeed, blockHeadersFeed *event.Feed) {
+	logger := log.WithField("beaconNode", n.NodeURL())
+	topics := []string{apievent.EventSlasherAttestation, apievent.EventSlasherBlockHeader}
+	for {
+		stream, err := apievent.NewEventStream(ctx, c.streamClient, n.NodeURL(), topics)
+		if err != nil {
+			logger.WithError(err).Error("Could not create event stream")
+			return
+		}
+		events := make(chan *apievent.Event, 1)
+		streamDone := make(chan struct{})
+		go func() {
+			stream.Subscribe(events)
+			close(streamDone)
+		}()
+		c.handleEvents(ctx, logger, events, streamDone, attestationsFeed, blockHeadersFeed)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Warn("Event stream of beacon node ended, reconnecting")
+		select {
+		case <-time.After(reconnectDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// handleEvents handles the events of a stream until it ends.
+func (c *Client) handleEvents(
+	ctx context.Context,
+	logger *logrus.Entry,
+	events <-chan *apievent.Event,
+	streamDone <-chan struct{},
+	attestationsFeed, blockHeadersFeed *event.Feed,
+) {
+	handle := func(e *apievent.Event) {
+		if err := c.handleEvent(e, attestationsFeed, blockHeadersFeed); err != nil {
+			logger.WithError(err).WithField("topic", e.EventType).Error("Could not handle event")
+		}
+	}
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			handle(e)
+		case <-streamDone:
+			// Handle the event sent right before the stream ended, such as the error that ended it.
+			select {
+			case e, ok := <-events:
+				if ok {
+					handle(e)
+				}
+			default:
+			}
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Client) handleEvent(e *apievent.Event, attestationsFeed, blockHeadersFeed *event.Feed) error {
+	switch e.EventType {
+	case apievent.EventSlasherAttestation:
+		att, err := decodeAttestation(e.Data)
+		if err != nil {
+			return err
+		}
+		if c.firstSeen(e.EventType, att.GetSignature()) {
+			attestationsFeed.Send(&types.WrappedIndexedAtt{IndexedAtt: att})
+		}
+	case apievent.EventSlasherBlockHeader:
+		header := &structs.SignedBeaconBlockHeader{}
+		if err := json.Unmarshal(e.Data, header); err != nil {
+			return errors.Wrap(err, "could not decode block header")
+		}
+		h, err := header.ToConsensus()
+		if err != nil {
+			return errors.Wrap(err, "could not convert block header")
+		}
+		if c.firstSeen(e.EventType, h.Signature) {
+			blockHeadersFeed.Send(h)
+		}
+	case apievent.EventConnectionError, apievent.EventError:
+		return errors.New(string(e.Data))
+	}
+	return nil
+}
+
+// firstSeen returns true the first time an object with the signature is streamed.
+func (c *Client) firstSeen(topic string, signature []byte) bool {
+	seen, _ := c.seen.ContainsOrAdd(topic+string(signature), struct{}{})
+	return !seen
+}
+
+func decodeAttestation(data []byte) (ethpb.IndexedAtt, error) {
+	e := &structs.SlasherAttestationEvent{}
+	if err := json.Unmarshal(data, e); err != nil {
+		return nil, errors.Wrap(err, "could not decode attestation event")
+	}
+	v, err := version.FromString(e.Version)
+	if err != nil {
+		return nil, err
+	}
+	if v >= version.Electra {
+		att := &structs.IndexedAttestationElectra{}
+		if err := json.Unmarshal(e.Data, att); err != nil {
+			return nil, errors.Wrap(err, "could not decode attestation")
+		}
+		return att.ToConsensus()
+	}
+	att := &structs.IndexedAttestation{}
+	if err := json.Unmarshal(e.Data, att); err != nil {
+		return nil, errors.Wrap(err, "could not decode attestation")
+	}
+	return att.ToConsensus()
+}
+
+// HeadSlot returns the highest head slot of the beacon nodes.
+func (c *Client) HeadSlot(ctx context.Context) (primitives.Slot, error) {
+	var (
+		headSlot primitives.Slot
+		found    bool
+	)
+	for _, n := range c.nodes {
+		slot, err := n.headSlot(ctx)
+		if err != nil {
+			log.WithError(err).WithField("beaconNode", n.NodeURL()).Debug("Could not get head slot")
+			continue
+		}
+		found = true
+		if slot > headSlot {
+			headSlot = slot
+		}
+	}
+	if !found {
+		return 0, errors.New("could not get head slot from any beacon node")
+	}
+	return headSlot, nil
+}
+
+// NumValidators returns the highest number of validators in the head states of the beacon nodes.
+func (c *Client) NumValidators(ctx context.Context) (uint64, error) {
+	var (
+		numVals uint64
+		found   bool
+	)
+	for _, n := range c.nodes {
+		count, err := n.numValidators(ctx)
+		if err != nil {
+			log.WithError(err).WithField("beaconNode", n.NodeURL()).Debug("Could not get number of validators")
+			continue
+		}
+		found = true
+		if count > numVals {
+			numVals = count
+		}
+	}
+	if !found {
+		return 0, errors.New("could not get number of validators from any beacon node")
+	}
+	return numVals, nil
+}
+
+// SubmitAttesterSlashing submits the attester slashing to every beacon node.
+func (c *Client) SubmitAttesterSlashing(ctx context.Context, slashing ethpb.AttSlashing) error {
+	switch s := slashing.(type) {
+	case *ethpb.AttesterSlashing:
+		return c.submit(ctx, attesterSlashingPath, "", structs.AttesterSlashingFromConsensus(s))
+	case *ethpb.AttesterSlashingElectra:
+		return c.submit(ctx, attesterSlashingV2Path, version.String(s.Version()), structs.AttesterSlashingElectraFromConsensus(s))
+	default:
+		return errors.Errorf("submitting attester slashings of type %T is not supported", slashing)
+	}
+}
+
+// SubmitProposerSlashing submits the proposer slashing to every beacon node.
+func (c *Client) SubmitProposerSlashing(ctx context.Context, slashing *ethpb.ProposerSlashing) error {
+	return c.submit(ctx, proposerSlashingPath, "", structs.ProposerSlashingFromConsensus(slashing))
+}
+
+// submit posts the slashing to every beacon node, with the consensus version header if set.
+// It fails only if no beacon node accepted it.
+func (c *Client) submit(ctx context.Context, path, consensusVersion string, slashing interface{}) error {
+	var failed []string
+	for _, n := range c.nodes {
+		if err := n.post(ctx, path, consensusVersion, slashing); err != nil {
+			log.WithError(err).WithField("beaconNode", n.NodeURL()).Warn("Beacon node did not accept slashing")
+			failed = append(failed, n.NodeURL())
+		}
+	}
+	if len(failed) == len(c.nodes) {
+		return errors.Errorf("no beacon node accepted the slashing: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
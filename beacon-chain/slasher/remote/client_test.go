@@ -0,0 +1,172 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type testBeaconNode struct {
+	headSlot          primitives.Slot
+	validators        uint64
+	rejectSlashings   bool
+	attesterSlashings map[string]int
+	proposerSlashings int
+	events            string
+}
+
+func (n *testBeaconNode) server(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	writeJSON := func(w http.ResponseWriter, v interface{}) {
+		require.NoError(t, json.NewEncoder(w).Encode(v))
+	}
+	mux.HandleFunc(genesisPath, func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, &structs.GetGenesisResponse{Data: &structs.Genesis{
+			GenesisTime:           "1606824023",
+			GenesisValidatorsRoot: fmt.Sprintf("%#x", make([]byte, 32)),
+		}})
+	})
+	mux.HandleFunc(headHeaderPath, func(w http.ResponseWriter, _ *http.Request) {
+		header := structs.SignedBeaconBlockHeaderFromConsensus(util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{
+			Header: &ethpb.BeaconBlockHeader{Slot: n.headSlot},
+		}))
+		writeJSON(w, &structs.GetBlockHeaderResponse{Data: &structs.SignedBeaconBlockHeaderContainer{Header: header}})
+	})
+	mux.HandleFunc(validatorCountPath, func(w http.ResponseWriter, r *http.Request) {
+		assert.DeepEqual(t, validatorStatuses, r.URL.Query()["status"])
+		writeJSON(w, &structs.GetValidatorCountResponse{Data: []*structs.ValidatorCount{
+			{Status: "active", Count: fmt.Sprintf("%d", n.validators-1)},
+			{Status: "exited", Count: "1"},
+		}})
+	})
+	for _, path := range []string{attesterSlashingPath, attesterSlashingV2Path} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if n.rejectSlashings {
+				http.Error(w, "Invalid attester slashing", http.StatusBadRequest)
+				return
+			}
+			if n.attesterSlashings == nil {
+				n.attesterSlashings = make(map[string]int)
+			}
+			if r.URL.Path == attesterSlashingV2Path {
+				assert.Equal(t, version.String(version.Electra), r.Header.Get(api.VersionHeader))
+			}
+			n.attesterSlashings[r.URL.Path]++
+		})
+	}
+	mux.HandleFunc(proposerSlashingPath, func(w http.ResponseWriter, _ *http.Request) {
+		if n.rejectSlashings {
+			http.Error(w, "Invalid proposer slashing", http.StatusBadRequest)
+			return
+		}
+		n.proposerSlashings++
+	})
+	mux.HandleFunc("/eth/v1/events", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, n.events)
+		require.NoError(t, err)
+	})
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	first := &testBeaconNode{headSlot: 10, validators: 5, rejectSlashings: true}
+	second := &testBeaconNode{headSlot: 12, validators: 4}
+	c, err := NewClient([]string{first.server(t).URL, second.server(t).URL})
+	require.NoError(t, err)
+
+	clock, err := c.Clock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1606824023), clock.GenesisTime().Unix())
+
+	headSlot, err := c.HeadSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(12), headSlot)
+
+	numVals, err := c.NumValidators(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), numVals)
+
+	// The slashing is submitted as long as one beacon node accepts it.
+	slashing := &ethpb.ProposerSlashing{
+		Header_1: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+		Header_2: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+	}
+	require.NoError(t, c.SubmitProposerSlashing(ctx, slashing))
+	assert.Equal(t, 1, second.proposerSlashings)
+	second.rejectSlashings = true
+	assert.ErrorContains(t, "no beacon node accepted the slashing", c.SubmitProposerSlashing(ctx, slashing))
+
+	// Attester slashings are submitted to the endpoint of their version.
+	second.rejectSlashings = false
+	attSlashing := &ethpb.AttesterSlashing{
+		Attestation_1: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{}),
+		Attestation_2: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{}),
+	}
+	require.NoError(t, c.SubmitAttesterSlashing(ctx, attSlashing))
+	attSlashingElectra := &ethpb.AttesterSlashingElectra{
+		Attestation_1: &ethpb.IndexedAttestationElectra{
+			Data:      util.HydrateAttestationData(&ethpb.AttestationData{}),
+			Signature: make([]byte, 96),
+		},
+		Attestation_2: &ethpb.IndexedAttestationElectra{
+			Data:      util.HydrateAttestationData(&ethpb.AttestationData{}),
+			Signature: make([]byte, 96),
+		},
+	}
+	require.NoError(t, c.SubmitAttesterSlashing(ctx, attSlashingElectra))
+	assert.Equal(t, 1, second.attesterSlashings[attesterSlashingPath])
+	assert.Equal(t, 1, second.attesterSlashings[attesterSlashingV2Path])
+}
+
+func TestClient_Stream(t *testing.T) {
+	att := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1, 2}})
+	attData, err := json.Marshal(structs.IndexedAttFromConsensus(att))
+	require.NoError(t, err)
+	attEvent, err := json.Marshal(&structs.SlasherAttestationEvent{Version: "phase0", Data: attData})
+	require.NoError(t, err)
+	header := util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: 3}})
+	headerEvent, err := json.Marshal(structs.SignedBeaconBlockHeaderFromConsensus(header))
+	require.NoError(t, err)
+	events := fmt.Sprintf("event: slasher_attestation\ndata: %s\n\nevent: slasher_block_header\ndata: %s\n\n", attEvent, headerEvent)
+
+	// Both beacon nodes stream the same attestation and block header.
+	first := &testBeaconNode{events: events}
+	second := &testBeaconNode{events: events}
+	c, err := NewClient([]string{first.server(t).URL, second.server(t).URL})
+	require.NoError(t, err)
+
+	attsFeed, headersFeed := new(event.Feed), new(event.Feed)
+	atts := make(chan *types.WrappedIndexedAtt, 10)
+	attsSub := attsFeed.Subscribe(atts)
+	defer attsSub.Unsubscribe()
+	headers := make(chan *ethpb.SignedBeaconBlockHeader, 10)
+	headersSub := headersFeed.Subscribe(headers)
+	defer headersSub.Unsubscribe()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	c.Stream(ctx, attsFeed, headersFeed)
+
+	require.Equal(t, 1, len(atts))
+	assert.DeepEqual(t, att, (<-atts).IndexedAtt)
+	require.Equal(t, 1, len(headers))
+	assert.DeepEqual(t, header, <-headers)
+}
@@ -0,0 +1,5 @@
+package remote
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "slasher-remote")
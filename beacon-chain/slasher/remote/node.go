@@ -0,0 +1,131 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+)
+
+const (
+	genesisPath            = "/eth/v1/beacon/genesis"
+	headHeaderPath         = "/eth/v1/beacon/headers/head"
+	validatorCountPath     = "/prysm/v1/beacon/states/head/validator_count"
+	attesterSlashingPath   = "/eth/v1/beacon/pool/attester_slashings"
+	attesterSlashingV2Path = "/eth/v2/beacon/pool/attester_slashings"
+	proposerSlashingPath   = "/eth/v1/beacon/pool/proposer_slashings"
+)
+
+// The top level statuses, which every validator has exactly one of.
+var validatorStatuses = []string{"pending", "active", "exited", "withdrawal"}
+
+// beaconNode calls the beacon API endpoints of a single beacon node.
+type beaconNode struct {
+	*client.Client
+}
+
+// genesis returns the genesis time and genesis validators root of the chain of the beacon node.
+func (n *beaconNode) genesis(ctx context.Context) (time.Time, [32]byte, error) {
+	b, err := n.Get(ctx, genesisPath)
+	if err != nil {
+		return time.Time{}, [32]byte{}, err
+	}
+	resp := &structs.GetGenesisResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return time.Time{}, [32]byte{}, errors.Wrap(err, "could not decode genesis")
+	}
+	if resp.Data == nil {
+		return time.Time{}, [32]byte{}, errors.New("empty genesis response")
+	}
+	genesisTime, err := strconv.ParseInt(resp.Data.GenesisTime, 10, 64)
+	if err != nil {
+		return time.Time{}, [32]byte{}, errors.Wrap(err, "could not parse genesis time")
+	}
+	root, err := hexutil.Decode(resp.Data.GenesisValidatorsRoot)
+	if err != nil {
+		return time.Time{}, [32]byte{}, errors.Wrap(err, "could not decode genesis validators root")
+	}
+	return time.Unix(genesisTime, 0), bytesutil.ToBytes32(root), nil
+}
+
+// headSlot returns the slot of the head block of the beacon node.
+func (n *beaconNode) headSlot(ctx context.Context) (primitives.Slot, error) {
+	b, err := n.Get(ctx, headHeaderPath)
+	if err != nil {
+		return 0, err
+	}
+	resp := &structs.GetBlockHeaderResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return 0, errors.Wrap(err, "could not decode head block header")
+	}
+	if resp.Data == nil || resp.Data.Header == nil || resp.Data.Header.Message == nil {
+		return 0, errors.New("empty head block header response")
+	}
+	slot, err := strconv.ParseUint(resp.Data.Header.Message.Slot, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not parse head slot")
+	}
+	return primitives.Slot(slot), nil
+}
+
+// numValidators returns the number of validators in the head state of the beacon node.
+func (n *beaconNode) numValidators(ctx context.Context) (uint64, error) {
+	b, err := n.Get(ctx, validatorCountPath, client.WithQueryParams(url.Values{"status": validatorStatuses}))
+	if err != nil {
+		return 0, err
+	}
+	resp := &structs.GetValidatorCountResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return 0, errors.Wrap(err, "could not decode validator count")
+	}
+	var total uint64
+	for _, c := range resp.Data {
+		count, err := strconv.ParseUint(c.Count, 10, 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "could not parse count of %s validators", c.Status)
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// post submits the object as JSON to the beacon node.
+func (n *beaconNode) post(ctx context.Context, path, consensusVersion string, obj interface{}) error {
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal JSON")
+	}
+	u := n.BaseURL().ResolveReference(&url.URL{Path: path})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create POST request")
+	}
+	req.Header.Set("Content-Type", api.JsonMediaType)
+	if consensusVersion != "" {
+		req.Header.Set(api.VersionHeader, consensusVersion)
+	}
+	resp, err := n.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Failed to close response body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return client.Non200Err(resp)
+	}
+	return nil
+}
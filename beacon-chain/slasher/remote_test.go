@@ -0,0 +1,83 @@
+package slasher
+
+import (
+	"context"
+	"testing"
+
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type mockRemoteBeaconNode struct {
+	headSlot          primitives.Slot
+	numValidators     uint64
+	attesterSlashings []ethpb.AttSlashing
+	proposerSlashings []*ethpb.ProposerSlashing
+}
+
+func (m *mockRemoteBeaconNode) HeadSlot(_ context.Context) (primitives.Slot, error) {
+	return m.headSlot, nil
+}
+
+func (m *mockRemoteBeaconNode) NumValidators(_ context.Context) (uint64, error) {
+	return m.numValidators, nil
+}
+
+func (m *mockRemoteBeaconNode) SubmitAttesterSlashing(_ context.Context, slashing ethpb.AttSlashing) error {
+	m.attesterSlashings = append(m.attesterSlashings, slashing)
+	return nil
+}
+
+func (m *mockRemoteBeaconNode) SubmitProposerSlashing(_ context.Context, slashing *ethpb.ProposerSlashing) error {
+	m.proposerSlashings = append(m.proposerSlashings, slashing)
+	return nil
+}
+
+func TestService_RemoteBeaconNode(t *testing.T) {
+	ctx := context.Background()
+	remote := &mockRemoteBeaconNode{headSlot: 70, numValidators: 3}
+	slasherDB := dbtest.SetupSlasherDB(t)
+	// No head state fetcher, slashing pool or state fetchers are needed with a remote beacon node.
+	s := &Service{serviceCfg: &ServiceConfig{Database: slasherDB, RemoteBeaconNode: remote}}
+
+	headSlot, err := s.headSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(70), headSlot)
+	numVals, err := s.numValidators(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), numVals)
+
+	// Slashings with invalid signatures are submitted as is, the beacon node verifies them.
+	attesterSlashing := &ethpb.AttesterSlashing{
+		Attestation_1: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1}}),
+		Attestation_2: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1}}),
+	}
+	root, err := attesterSlashing.HashTreeRoot()
+	require.NoError(t, err)
+	processed, err := s.processAttesterSlashings(ctx, map[[fieldparams.RootLength]byte]ethpb.AttSlashing{root: attesterSlashing})
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(processed))
+	require.Equal(t, 1, len(remote.attesterSlashings))
+	assert.DeepEqual(t, attesterSlashing, remote.attesterSlashings[0])
+	saved, err := slasherDB.AttesterSlashingsForValidator(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(saved))
+	assert.DeepEqual(t, attesterSlashing, saved[0])
+
+	proposerSlashing := &ethpb.ProposerSlashing{
+		Header_1: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+		Header_2: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+	}
+	require.NoError(t, s.processProposerSlashings(ctx, []*ethpb.ProposerSlashing{proposerSlashing}))
+	require.Equal(t, 1, len(remote.proposerSlashings))
+	assert.DeepEqual(t, proposerSlashing, remote.proposerSlashings[0])
+	savedProposerSlashings, err := slasherDB.ProposerSlashingsForValidator(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(savedProposerSlashings))
+	assert.DeepEqual(t, proposerSlashing, savedProposerSlashings[0])
+}
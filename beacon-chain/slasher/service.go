@@ -7,6 +7,7 @@ package slasher
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/prysmaticlabs/prysm/v5/async/event"
@@ -42,6 +43,12 @@ type ServiceConfig struct {
 	HeadStateFetcher        blockchain.HeadFetcher
 	SyncChecker             beaconChainSync.Checker
 	ClockWaiter             startup.ClockWaiter
+	// BeaconDatabase is the database the slasher database is backfilled from when Backfill is set.
+	BeaconDatabase db.ReadOnlyDatabase
+	Backfill       bool
+	// RemoteBeaconNode is set when the slasher runs in its own process. It then replaces the head state fetcher,
+	// the sync checker and the slashing pool, and the slashings are verified by the beacon node.
+	RemoteBeaconNode RemoteBeaconNode
 }
 
 // Service defining a slasher implementation as part of
@@ -61,6 +68,11 @@ type Service struct {
 	pruningSlotTicker              *slots.SlotTicker
 	latestEpochUpdatedForValidator map[primitives.ValidatorIndex]primitives.Epoch
 	wg                             sync.WaitGroup
+	// attsLock is held while attestations are checked, as the checks read and update the spans
+	// and the latest epoch updated of the validators.
+	attsLock sync.Mutex
+	// backfilling is set while the backfill runs, during which the queues are bounded.
+	backfilling atomic.Bool
 }
 
 // New instantiates a new slasher from configuration values.
@@ -92,14 +104,13 @@ func (s *Service) run() {
 	log.Info("Completed chain sync, starting slashing detection")
 
 	// Get the latest epoch written for each validator from disk on startup.
-	headState, err := s.serviceCfg.HeadStateFetcher.HeadState(s.ctx)
+	numVals, err := s.numValidators(s.ctx)
 	if err != nil {
-		log.WithError(err).Error("Failed to fetch head state")
+		log.WithError(err).Error("Failed to fetch the number of validators")
 		return
 	}
-	numVals := headState.NumValidators()
 	validatorIndices := make([]primitives.ValidatorIndex, numVals)
-	for i := 0; i < numVals; i++ {
+	for i := uint64(0); i < numVals; i++ {
 		validatorIndices[i] = primitives.ValidatorIndex(i)
 	}
 	start := time.Now()
@@ -111,9 +122,11 @@ func (s *Service) run() {
 		log.Error(err)
 		return
 	}
+	s.attsLock.Lock()
 	for _, item := range epochsByValidator {
 		s.latestEpochUpdatedForValidator[item.ValidatorIndex] = item.Epoch
 	}
+	s.attsLock.Unlock()
 	log.WithField("elapsed", time.Since(start)).Info(
 		"Finished retrieving last epoch written per validator",
 	)
@@ -122,7 +135,11 @@ func (s *Service) run() {
 	beaconBlockHeadersChan := make(chan *ethpb.SignedBeaconBlockHeader, 1)
 
 	// This section can be totally removed once Electra is on mainnet.
-	headSlot := s.serviceCfg.HeadStateFetcher.HeadSlot()
+	headSlot, err := s.headSlot(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch head slot")
+		return
+	}
 	headEpoch := slots.ToEpoch(headSlot)
 
 	maxPruningEpoch := primitives.Epoch(0)
@@ -141,12 +158,26 @@ func (s *Service) run() {
 	}
 	// End of section that can be removed once Electra is on mainnet.
 
+	// The feeds are subscribed to before the backfill, so that the attestations and blocks received
+	// while it runs are queued, up to a limit, and processed once it completes.
+	if s.serviceCfg.Backfill {
+		s.backfilling.Store(true)
+	}
+
 	s.wg.Add(1)
 	go s.receiveAttestations(s.ctx, indexedAttsChan)
 
 	s.wg.Add(1)
 	go s.receiveBlocks(s.ctx, beaconBlockHeadersChan)
 
+	if s.serviceCfg.Backfill {
+		s.runBackfill(s.ctx)
+		s.backfilling.Store(false)
+		if s.ctx.Err() != nil {
+			return
+		}
+	}
+
 	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
 	s.attsSlotTicker = slots.NewSlotTicker(s.genesisTime, secondsPerSlot)
 	s.blocksSlotTicker = slots.NewSlotTicker(s.genesisTime, secondsPerSlot)
@@ -203,13 +234,19 @@ func (s *Service) waitForChainInitialization() {
 	if err != nil {
 		log.WithError(err).Error("Could not receive chain start notification")
 	}
+	s.attsLock.Lock()
 	s.genesisTime = clock.GenesisTime()
+	s.attsLock.Unlock()
 	log.WithField("genesisTime", s.genesisTime).Info(
 		"Slasher received chain initialization event",
 	)
 }
 
 func (s *Service) waitForSync(genesisTime time.Time) {
+	// The attestations and blocks streamed by a remote beacon node are processed whether it is syncing or not.
+	if s.serviceCfg.RemoteBeaconNode != nil {
+		return
+	}
 	if slots.SinceGenesis(genesisTime) < params.BeaconConfig().SlotsPerEpoch || !s.serviceCfg.SyncChecker.Syncing() {
 		return
 	}
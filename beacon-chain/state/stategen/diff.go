@@ -0,0 +1,283 @@
+package stategen
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	statenative "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// A layer of the hierarchical state representation is either a full snapshot of the state, or a diff
+// against the state of a layer at a lower slot. Diffs are computed over the SSZ encoding of the state
+// with its balances zeroed, so that only the bytes of fields that actually changed are kept. Balances
+// change for almost every validator in every epoch, so they are stored separately as varint deltas.
+const (
+	layerSnapshot byte = iota
+	layerDiff
+)
+
+// Changed bytes closer than maxRunGap are stored in a single run, since the header of a new run
+// would cost about as much as the unchanged bytes in between.
+const maxRunGap = 8
+
+var errInvalidStateDiff = errors.New("invalid state diff")
+
+// diffLayer is a decoded layer header, with the remaining bytes of the encoding in body.
+type diffLayer struct {
+	kind    byte
+	version int
+	base    primitives.Slot
+	body    []byte
+}
+
+func encodeSnapshot(st state.ReadOnlyBeaconState) ([]byte, error) {
+	enc, err := st.MarshalSSZ()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal state")
+	}
+	out := make([]byte, 0, len(enc)+2)
+	out = append(out, layerSnapshot, byte(st.Version()))
+	return append(out, enc...), nil
+}
+
+// encodeDiff encodes target as a diff against base, which is the state at slot baseSlot.
+func encodeDiff(baseSlot primitives.Slot, base, target state.BeaconState) ([]byte, error) {
+	if base.Version() != target.Version() {
+		return nil, errors.Errorf("cannot diff a %s state against a %s state", version.String(target.Version()), version.String(base.Version()))
+	}
+	baseEnc, err := marshalWithoutBalances(base)
+	if err != nil {
+		return nil, err
+	}
+	targetEnc, err := marshalWithoutBalances(target)
+	if err != nil {
+		return nil, err
+	}
+	out := []byte{layerDiff, byte(target.Version())}
+	out = binary.AppendUvarint(out, uint64(baseSlot))
+	out = binary.AppendUvarint(out, uint64(len(targetEnc)))
+	out = appendBalanceDiff(out, base.Balances(), target.Balances())
+	return appendPatch(out, baseEnc, targetEnc), nil
+}
+
+func decodeLayer(enc []byte) (*diffLayer, error) {
+	if len(enc) < 2 {
+		return nil, errors.Wrap(errInvalidStateDiff, "encoding too short")
+	}
+	l := &diffLayer{kind: enc[0], version: int(enc[1]), body: enc[2:]}
+	switch l.kind {
+	case layerSnapshot:
+		return l, nil
+	case layerDiff:
+		base, n := binary.Uvarint(l.body)
+		if n <= 0 {
+			return nil, errors.Wrap(errInvalidStateDiff, "could not read base slot")
+		}
+		l.base, l.body = primitives.Slot(base), l.body[n:]
+		return l, nil
+	default:
+		return nil, errors.Wrapf(errInvalidStateDiff, "unknown layer kind %d", l.kind)
+	}
+}
+
+// snapshot returns the state of a snapshot layer.
+func (l *diffLayer) snapshot() (state.BeaconState, error) {
+	if l.kind != layerSnapshot {
+		return nil, errors.Wrap(errInvalidStateDiff, "layer is not a snapshot")
+	}
+	return unmarshalState(l.version, l.body)
+}
+
+// apply returns the state of a diff layer, given the state at its base slot.
+func (l *diffLayer) apply(base state.BeaconState) (state.BeaconState, error) {
+	if l.kind != layerDiff {
+		return nil, errors.Wrap(errInvalidStateDiff, "layer is not a diff")
+	}
+	if base.Version() != l.version {
+		return nil, errors.Wrapf(errInvalidStateDiff, "diff is for a %s state, base is %s", version.String(l.version), version.String(base.Version()))
+	}
+	buf := l.body
+	size, n := binary.Uvarint(buf)
+	if n <= 0 {
+		return nil, errors.Wrap(errInvalidStateDiff, "could not read state size")
+	}
+	buf = buf[n:]
+	balances, buf, err := applyBalanceDiff(buf, base.Balances())
+	if err != nil {
+		return nil, err
+	}
+	baseEnc, err := marshalWithoutBalances(base)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := applyPatch(buf, baseEnc, size)
+	if err != nil {
+		return nil, err
+	}
+	st, err := unmarshalState(l.version, enc)
+	if err != nil {
+		return nil, err
+	}
+	if err := st.SetBalances(balances); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// marshalWithoutBalances returns the SSZ encoding of the state with every balance set to zero.
+func marshalWithoutBalances(st state.BeaconState) ([]byte, error) {
+	cp := st.Copy()
+	if err := cp.SetBalances(make([]uint64, cp.BalancesLength())); err != nil {
+		return nil, err
+	}
+	enc, err := cp.MarshalSSZ()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal state")
+	}
+	return enc, nil
+}
+
+// appendBalanceDiff encodes the number of target balances, followed by the zigzag varint delta of every
+// target balance from the base balance at the same index. Balances of new validators are diffed against zero.
+func appendBalanceDiff(out []byte, base, target []uint64) []byte {
+	out = binary.AppendUvarint(out, uint64(len(target)))
+	for i, b := range target {
+		var prev uint64
+		if i < len(base) {
+			prev = base[i]
+		}
+		out = binary.AppendVarint(out, int64(b-prev))
+	}
+	return out
+}
+
+func applyBalanceDiff(buf []byte, base []uint64) ([]uint64, []byte, error) {
+	count, n := binary.Uvarint(buf)
+	if n <= 0 || count > uint64(len(buf)) {
+		return nil, nil, errors.Wrap(errInvalidStateDiff, "could not read balance count")
+	}
+	buf = buf[n:]
+	balances := make([]uint64, count)
+	for i := range balances {
+		delta, n := binary.Varint(buf)
+		if n <= 0 {
+			return nil, nil, errors.Wrapf(errInvalidStateDiff, "could not read balance delta %d", i)
+		}
+		buf = buf[n:]
+		if i < len(base) {
+			balances[i] = base[i]
+		}
+		balances[i] += uint64(delta)
+	}
+	return balances, buf, nil
+}
+
+// appendPatch encodes the runs of bytes of target that differ from base. Each run is written as the
+// distance from the end of the previous run, its length and its bytes.
+func appendPatch(out, base, target []byte) []byte {
+	type run struct{ start, end int }
+	runs := make([]run, 0)
+	for i := 0; i < len(target); i++ {
+		if i < len(base) && base[i] == target[i] {
+			continue
+		}
+		if last := len(runs) - 1; last >= 0 && i-runs[last].end <= maxRunGap {
+			runs[last].end = i + 1
+			continue
+		}
+		runs = append(runs, run{start: i, end: i + 1})
+	}
+	out = binary.AppendUvarint(out, uint64(len(runs)))
+	prev := 0
+	for _, r := range runs {
+		out = binary.AppendUvarint(out, uint64(r.start-prev))
+		out = binary.AppendUvarint(out, uint64(r.end-r.start))
+		out = append(out, target[r.start:r.end]...)
+		prev = r.end
+	}
+	return out
+}
+
+func applyPatch(buf, base []byte, size uint64) ([]byte, error) {
+	if size > uint64(len(base))+uint64(len(buf)) {
+		return nil, errors.Wrapf(errInvalidStateDiff, "state size %d can't be produced from the diff", size)
+	}
+	out := make([]byte, size)
+	copy(out, base)
+	count, n := binary.Uvarint(buf)
+	if n <= 0 {
+		return nil, errors.Wrap(errInvalidStateDiff, "could not read run count")
+	}
+	buf = buf[n:]
+	pos := uint64(0)
+	for i := uint64(0); i < count; i++ {
+		skip, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return nil, errors.Wrapf(errInvalidStateDiff, "could not read offset of run %d", i)
+		}
+		buf = buf[n:]
+		length, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return nil, errors.Wrapf(errInvalidStateDiff, "could not read length of run %d", i)
+		}
+		buf = buf[n:]
+		pos += skip
+		if length > uint64(len(buf)) || pos+length > size {
+			return nil, errors.Wrapf(errInvalidStateDiff, "run %d is out of bounds", i)
+		}
+		copy(out[pos:], buf[:length])
+		buf = buf[length:]
+		pos += length
+	}
+	if len(buf) != 0 {
+		return nil, errors.Wrapf(errInvalidStateDiff, "%d trailing bytes", len(buf))
+	}
+	return out, nil
+}
+
+func unmarshalState(v int, enc []byte) (state.BeaconState, error) {
+	switch v {
+	case version.Phase0:
+		pb := &ethpb.BeaconState{}
+		if err := pb.UnmarshalSSZ(enc); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal phase0 state")
+		}
+		return statenative.InitializeFromProtoUnsafePhase0(pb)
+	case version.Altair:
+		pb := &ethpb.BeaconStateAltair{}
+		if err := pb.UnmarshalSSZ(enc); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal altair state")
+		}
+		return statenative.InitializeFromProtoUnsafeAltair(pb)
+	case version.Bellatrix:
+		pb := &ethpb.BeaconStateBellatrix{}
+		if err := pb.UnmarshalSSZ(enc); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal bellatrix state")
+		}
+		return statenative.InitializeFromProtoUnsafeBellatrix(pb)
+	case version.Capella:
+		pb := &ethpb.BeaconStateCapella{}
+		if err := pb.UnmarshalSSZ(enc); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal capella state")
+		}
+		return statenative.InitializeFromProtoUnsafeCapella(pb)
+	case version.Deneb:
+		pb := &ethpb.BeaconStateDeneb{}
+		if err := pb.UnmarshalSSZ(enc); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal deneb state")
+		}
+		return statenative.InitializeFromProtoUnsafeDeneb(pb)
+	case version.Electra:
+		pb := &ethpb.BeaconStateElectra{}
+		if err := pb.UnmarshalSSZ(enc); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal electra state")
+		}
+		return statenative.InitializeFromProtoUnsafeElectra(pb)
+	default:
+		return nil, errors.Wrapf(errInvalidStateDiff, "unsupported state version %d", v)
+	}
+}
@@ -0,0 +1,85 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func requireSameState(t *testing.T, want, got state.BeaconState) {
+	wantRoot, err := want.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	gotRoot, err := got.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, wantRoot, gotRoot)
+}
+
+func TestStateDiff_RoundTrip(t *testing.T) {
+	base, _ := util.DeterministicGenesisStateAltair(t, 64)
+	target := base.Copy()
+	require.NoError(t, target.SetSlot(96))
+	balances := target.Balances()
+	balances[3] += 1_000_000
+	balances[10] -= 123
+	require.NoError(t, target.SetBalances(balances))
+	require.NoError(t, target.AppendValidator(&ethpb.Validator{
+		PublicKey:             make([]byte, 48),
+		WithdrawalCredentials: make([]byte, 32),
+		EffectiveBalance:      32_000_000_000,
+	}))
+	require.NoError(t, target.AppendBalance(32_000_000_000))
+	require.NoError(t, target.AppendInactivityScore(0))
+	require.NoError(t, target.AppendCurrentParticipationBits(0))
+	require.NoError(t, target.AppendPreviousParticipationBits(0))
+
+	enc, err := encodeDiff(64, base, target)
+	require.NoError(t, err)
+	full, err := encodeSnapshot(target)
+	require.NoError(t, err)
+	require.Equal(t, true, len(enc) < len(full)/4)
+
+	l, err := decodeLayer(enc)
+	require.NoError(t, err)
+	require.Equal(t, layerDiff, l.kind)
+	require.Equal(t, primitives.Slot(64), l.base)
+	got, err := l.apply(base)
+	require.NoError(t, err)
+	requireSameState(t, target, got)
+
+	l, err = decodeLayer(full)
+	require.NoError(t, err)
+	got, err = l.snapshot()
+	require.NoError(t, err)
+	requireSameState(t, target, got)
+}
+
+func TestStateDiff_Invalid(t *testing.T) {
+	base, _ := util.DeterministicGenesisState(t, 16)
+	target := base.Copy()
+	require.NoError(t, target.SetSlot(4))
+	enc, err := encodeDiff(0, base, target)
+	require.NoError(t, err)
+
+	_, err = decodeLayer(enc[:1])
+	require.ErrorIs(t, err, errInvalidStateDiff)
+	_, err = decodeLayer(append([]byte{7}, enc[1:]...))
+	require.ErrorIs(t, err, errInvalidStateDiff)
+
+	l, err := decodeLayer(enc[:len(enc)-1])
+	require.NoError(t, err)
+	_, err = l.apply(base)
+	require.ErrorIs(t, err, errInvalidStateDiff)
+
+	altair, _ := util.DeterministicGenesisStateAltair(t, 16)
+	l, err = decodeLayer(enc)
+	require.NoError(t, err)
+	_, err = l.apply(altair)
+	require.ErrorIs(t, err, errInvalidStateDiff)
+	_, err = encodeDiff(0, altair, target)
+	require.ErrorContains(t, "cannot diff", err)
+}
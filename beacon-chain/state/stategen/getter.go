@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
@@ -217,11 +218,19 @@ func (s *State) loadStateByRoot(ctx context.Context, blockRoot [32]byte) (state.
 	}
 	targetSlot := summary.Slot
 
-	// Since the requested state is not in caches or DB, start replaying using the last
-	// available ancestor state which is retrieved using input block's root.
-	startState, err := s.latestAncestor(ctx, blockRoot)
+	// Finalized states can be rebuilt from the state diffs, which avoids replaying blocks
+	// from the last saved state.
+	startState, err := s.diffAncestor(ctx, blockRoot, targetSlot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not get ancestor state")
+		return nil, errors.Wrap(err, "could not get state from state diffs")
+	}
+	if startState == nil {
+		// Since the requested state is not in caches or DB, start replaying using the last
+		// available ancestor state which is retrieved using input block's root.
+		startState, err = s.latestAncestor(ctx, blockRoot)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get ancestor state")
+		}
 	}
 	if startState == nil || startState.IsNil() {
 		return nil, errUnknownBoundaryState
@@ -241,6 +250,19 @@ func (s *State) loadStateByRoot(ctx context.Context, blockRoot [32]byte) (state.
 	return s.replayBlocks(ctx, startState, blks, targetSlot)
 }
 
+// diffAncestor returns the state at the closest slot of the state diff schedule at or below the given
+// slot, if state diffs are enabled and the block is finalized. It returns nil otherwise.
+func (s *State) diffAncestor(ctx context.Context, blockRoot [32]byte, slot primitives.Slot) (state.BeaconState, error) {
+	if s.diffs == nil || !s.beaconDB.IsFinalizedBlock(ctx, blockRoot) {
+		return nil, nil
+	}
+	st, err := s.diffs.StateBelow(ctx, slot)
+	if errors.Is(err, db.ErrNotFoundState) {
+		return nil, nil
+	}
+	return st, err
+}
+
 // latestAncestor returns the highest available ancestor state of the input block root.
 // It recursively looks up block's parent until a corresponding state of the block root
 // is found in the caches or DB.
@@ -20,6 +20,14 @@ func WithCache(c CachedGetter) CanonicalHistoryOption {
 	}
 }
 
+// WithStateDiffs lets CanonicalHistory start replaying from the hierarchical state storage,
+// instead of from the closest saved state.
+func WithStateDiffs(d *StateDiffs) CanonicalHistoryOption {
+	return func(h *CanonicalHistory) {
+		h.diffs = d
+	}
+}
+
 type CanonicalHistoryOption func(*CanonicalHistory)
 
 func NewCanonicalHistory(h HistoryAccessor, cc CanonicalChecker, cs CurrentSlotter, opts ...CanonicalHistoryOption) *CanonicalHistory {
@@ -39,6 +47,7 @@ type CanonicalHistory struct {
 	cc    CanonicalChecker
 	cs    CurrentSlotter
 	cache CachedGetter
+	diffs *StateDiffs
 }
 
 func (c *CanonicalHistory) ReplayerForSlot(target primitives.Slot) Replayer {
@@ -114,6 +123,15 @@ func (c *CanonicalHistory) chainForSlot(ctx context.Context, target primitives.S
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "unable to retrieve canonical block for slot, root=%#x", r)
 	}
+	if c.diffs != nil {
+		s, descendants, err := c.diffChain(ctx, b)
+		if err == nil {
+			return s, descendants, nil
+		}
+		if !errors.Is(err, db.ErrNotFoundState) {
+			return nil, nil, errors.Wrap(err, "failed to load state from state diffs")
+		}
+	}
 	s, descendants, err := c.ancestorChain(ctx, b)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to query for ancestor and descendant blocks")
@@ -122,6 +140,36 @@ func (c *CanonicalHistory) chainForSlot(ctx context.Context, target primitives.S
 	return s, descendants, nil
 }
 
+// diffChain rebuilds the state at the closest slot of the state diff schedule at or below the tail block,
+// and returns it together with the blocks after that slot, up to and including the tail, in ascending order.
+// Only finalized states are saved as diffs, so the tail is assumed to be canonical like in ancestorChain.
+func (c *CanonicalHistory) diffChain(ctx context.Context, tail interfaces.ReadOnlySignedBeaconBlock) (state.BeaconState, []interfaces.ReadOnlySignedBeaconBlock, error) {
+	ctx, span := trace.StartSpan(ctx, "canonicalChainer.diffChain")
+	defer span.End()
+	st, err := c.diffs.StateBelow(ctx, tail.Block().Slot())
+	if err != nil {
+		return nil, nil, err
+	}
+	chain := make([]interfaces.ReadOnlySignedBeaconBlock, 0)
+	for tail.Block().Slot() > st.Slot() {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, errors.Wrap(err, "context canceled while collecting blocks after state diff")
+		}
+		chain = append(chain, tail)
+		parent, err := c.h.Block(ctx, tail.Block().ParentRoot())
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "db error when retrieving parent of block at slot=%d", tail.Block().Slot())
+		}
+		if blocks.BeaconBlockIsNil(parent) != nil {
+			msg := fmt.Sprintf("unable to retrieve parent of block at slot=%d by root=%#x", tail.Block().Slot(), tail.Block().ParentRoot())
+			return nil, nil, errors.Wrap(db.ErrNotFound, msg)
+		}
+		tail = parent
+	}
+	reverseChain(chain)
+	return st, chain, nil
+}
+
 func (c *CanonicalHistory) getState(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
 	if c.cache != nil {
 		st, err := c.cache.ByBlockRoot(blockRoot)
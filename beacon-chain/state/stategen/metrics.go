@@ -25,4 +25,17 @@ var (
 			Help: "Time it took to replay to slot",
 		},
 	)
+	stateDiffBytes = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "state_diff_saved_bytes",
+			Help:    "The size of the historical state snapshots and diffs saved to the db",
+			Buckets: prometheus.ExponentialBuckets(1024, 4, 10),
+		},
+	)
+	stateDiffLoadSummary = promauto.NewSummary(
+		prometheus.SummaryOpts{
+			Name: "state_diff_load_milliseconds",
+			Help: "Time it took to rebuild a state from its snapshot and diffs",
+		},
+	)
 )
@@ -5,15 +5,20 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	"github.com/sirupsen/logrus"
 )
 
 // MigrateToCold advances the finalized info in between the cold and hot state sections.
 // It moves the recent finalized states from the hot section to the cold section and
-// only preserves the ones that are on archived point.
+// only preserves the ones that are on archived point, or the ones on the state diff
+// schedule when state diffs are enabled.
 func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "stateGen.MigrateToCold")
 	defer span.End()
@@ -44,6 +49,12 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 			return ctx.Err()
 		}
 
+		if s.diffs != nil {
+			if err := s.saveStateDiff(ctx, slot); err != nil {
+				return errors.Wrapf(err, "could not save state diff for slot %d", slot)
+			}
+		}
+
 		if slot%s.slotsPerArchivedPoint == 0 && slot != 0 {
 			cached, exists, err := s.epochBoundaryStateCache.getBySlot(slot)
 			if err != nil {
@@ -71,9 +82,9 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 					return errUnknownBlock
 				}
 				aRoot = roots[0]
-				// There's no need to generate the state if the state already exists in the DB.
-				// We can skip saving the state.
-				if !s.beaconDB.HasState(ctx, aRoot) {
+				// There's no need to generate the state if the state already exists in the DB,
+				// or if it is not saved as the state diffs hold it. We can skip saving the state.
+				if !s.beaconDB.HasState(ctx, aRoot) && s.diffs == nil {
 					aState, err = s.StateByRoot(ctx, aRoot)
 					if err != nil {
 						return err
@@ -97,6 +108,10 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 				s.saveHotStateDB.lock.Unlock()
 				continue
 			}
+			// The state diffs hold the finalized states, no full state is saved at archived points.
+			if s.diffs != nil {
+				continue
+			}
 
 			if err := s.beaconDB.SaveState(ctx, aState, aRoot); err != nil {
 				return err
@@ -120,3 +135,104 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 
 	return nil
 }
+
+// saveStateDiff stores the finalized state at the slot, if the state diff schedule holds one for it.
+func (s *State) saveStateDiff(ctx context.Context, slot primitives.Slot) error {
+	if !s.diffs.Schedule().Contains(slot) || s.diffs.Has(ctx, slot) {
+		return nil
+	}
+	st, err := s.finalizedStateAtSlot(ctx, slot)
+	if err != nil {
+		return err
+	}
+	if err := s.diffs.Save(ctx, st); err != nil {
+		return err
+	}
+	log.WithField("slot", slot).Debug("Saved state diff")
+	return nil
+}
+
+// finalizedStateAtSlot returns the finalized state at the slot, including the block at that slot if there is one.
+func (s *State) finalizedStateAtSlot(ctx context.Context, slot primitives.Slot) (state.BeaconState, error) {
+	if slot == 0 {
+		return s.beaconDB.GenesisState(ctx)
+	}
+	cached, exists, err := s.epochBoundaryStateCache.getBySlot(slot)
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch boundary state for slot %d", slot)
+	}
+	if exists && cached.state.Slot() == slot {
+		return cached.state, nil
+	}
+	_, roots, err := s.beaconDB.HighestRootsBelowSlot(ctx, slot+1)
+	if err != nil {
+		return nil, err
+	}
+	// Given the block has been finalized, the db should not have more than one block in a given slot.
+	if len(roots) != 1 {
+		return nil, errUnknownBlock
+	}
+	st, err := s.StateByRoot(ctx, roots[0])
+	if err != nil {
+		return nil, err
+	}
+	if st.Slot() == slot {
+		return st, nil
+	}
+	// The state may be shared with a cache, so advance a copy.
+	return ReplayProcessSlots(ctx, st.Copy(), slot)
+}
+
+// migrateArchivedStates moves the full states saved at archived points into the state diff storage,
+// so that enabling state diffs on an existing db keeps its history available. The states are read
+// with the regular db methods, so both the inline and the separate validator bucket layouts of
+// --enable-historical-state-representation are supported. Migrated states are only deleted when
+// pruning is enabled, except the ones the db protects (genesis, justified and finalized) and the
+// checkpoint sync origin.
+func (s *State) migrateArchivedStates(ctx context.Context) error {
+	cp, err := s.beaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	fSlot, err := slots.EpochStart(cp.Epoch)
+	if err != nil {
+		return err
+	}
+	origin, err := s.beaconDB.OriginCheckpointBlockRoot(ctx)
+	if err != nil && !errors.Is(err, db.ErrNotFoundOriginBlockRoot) {
+		return err
+	}
+	migrated := 0
+	for slot := s.slotsPerArchivedPoint; slot < fSlot; slot += s.slotsPerArchivedPoint {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !s.diffs.Schedule().Contains(slot) || !s.beaconDB.HasArchivedPoint(ctx, slot) {
+			continue
+		}
+		root := s.beaconDB.ArchivedPointRoot(ctx, slot)
+		if !s.diffs.Has(ctx, slot) {
+			st, err := s.beaconDB.State(ctx, root)
+			if err != nil {
+				return errors.Wrapf(err, "could not read archived state at slot %d", slot)
+			}
+			if st == nil || st.IsNil() || st.Slot() != slot {
+				continue
+			}
+			if err := s.diffs.Save(ctx, st); err != nil {
+				return errors.Wrapf(err, "could not save archived state at slot %d", slot)
+			}
+			migrated++
+		}
+		if !s.pruneArchivedStates || root == origin {
+			continue
+		}
+		if err := s.beaconDB.DeleteState(ctx, root); err != nil && !errors.Is(err, db.ErrDeleteJustifiedAndFinalized) {
+			return errors.Wrapf(err, "could not delete archived state at slot %d", slot)
+		}
+	}
+	if migrated > 0 {
+		log.WithField("count", migrated).Info("Migrated archived states to state diffs")
+	}
+	return nil
+}
@@ -56,6 +56,8 @@ type State struct {
 	avb                     coverage.AvailableBlocker
 	migrationLock           *sync.Mutex
 	fc                      forkchoice.ForkChoicer
+	diffs                   *StateDiffs
+	pruneArchivedStates     bool
 }
 
 // This tracks the config in the event of long non-finality,
@@ -88,6 +90,22 @@ func WithAvailableBlocker(avb coverage.AvailableBlocker) Option {
 	}
 }
 
+// WithStateDiffSchedule makes stategen store finalized states as snapshots and diffs following the schedule,
+// instead of full states at archived points.
+func WithStateDiffSchedule(schedule *DiffSchedule) Option {
+	return func(sg *State) {
+		sg.diffs = NewStateDiffs(sg.beaconDB, schedule)
+	}
+}
+
+// WithMigratedArchivedStatesPruning deletes the states saved at archived points once they are migrated
+// to state diffs, instead of keeping them alongside the diffs.
+func WithMigratedArchivedStatesPruning() Option {
+	return func(sg *State) {
+		sg.pruneArchivedStates = true
+	}
+}
+
 // New returns a new state management object.
 func New(beaconDB db.NoHeadAccessDatabase, fc forkchoice.ForkChoicer, opts ...Option) *State {
 	s := &State{
@@ -143,6 +161,11 @@ func (s *State) Resume(ctx context.Context, fState state.BeaconState) (state.Bea
 		if err := s.beaconDB.CleanUpDirtyStates(ctx, s.slotsPerArchivedPoint); err != nil {
 			log.WithError(err).Error("Could not clean up dirty states")
 		}
+		if s.diffs != nil {
+			if err := s.migrateArchivedStates(ctx); err != nil {
+				log.WithError(err).Error("Could not migrate archived states to state diffs")
+			}
+		}
 	}()
 
 	s.finalizedInfo = &finalizedInfo{slot: fState.Slot(), root: fRoot, state: fState.Copy()}
@@ -184,6 +207,11 @@ func (s *State) SaveFinalizedState(fSlot primitives.Slot, fRoot [32]byte, fState
 	s.finalizedInfo.slot = fSlot
 }
 
+// StateDiffs returns the hierarchical state storage, or nil when states are saved at archived points.
+func (s *State) StateDiffs() *StateDiffs {
+	return s.diffs
+}
+
 // Returns true if input root equals to cached finalized root.
 func (s *State) isFinalizedRoot(r [32]byte) bool {
 	s.finalizedInfo.lock.RLock()
@@ -0,0 +1,245 @@
+package stategen
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultStateDiffExponents saves a full snapshot every 2^21 slots, with diff layers every 2^18, 2^16,
+// 2^13, 2^11, 2^9 and 2^5 slots below it. Any finalized state at a multiple of 32 slots can then be
+// rebuilt from one snapshot and at most six diffs.
+var DefaultStateDiffExponents = []uint64{21, 18, 16, 13, 11, 9, 5}
+
+// DiffSchedule describes the layers of the hierarchical state representation. Layer i holds a state
+// every 2^exponents[i] slots. The first layer stores full snapshots, and every other layer stores
+// diffs against the state of the closest slot of the layer above it.
+type DiffSchedule struct {
+	exponents []uint64
+}
+
+// NewDiffSchedule creates a DiffSchedule from strictly decreasing power of two exponents.
+func NewDiffSchedule(exponents []uint64) (*DiffSchedule, error) {
+	if len(exponents) == 0 {
+		return nil, errors.New("state diff schedule needs at least one layer")
+	}
+	for i, e := range exponents {
+		if e >= 64 {
+			return nil, errors.Errorf("state diff exponent %d is too large", e)
+		}
+		if i > 0 && e >= exponents[i-1] {
+			return nil, errors.Errorf("state diff exponents must be strictly decreasing, got %v", exponents)
+		}
+	}
+	return &DiffSchedule{exponents: append([]uint64{}, exponents...)}, nil
+}
+
+func (d *DiffSchedule) interval(layer int) primitives.Slot {
+	return primitives.Slot(1) << d.exponents[layer]
+}
+
+// layer returns the coarsest layer holding a state for the slot, and false if no layer does.
+func (d *DiffSchedule) layer(slot primitives.Slot) (int, bool) {
+	for i := range d.exponents {
+		if slot%d.interval(i) == 0 {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Contains returns true if the schedule stores a state for the slot.
+func (d *DiffSchedule) Contains(slot primitives.Slot) bool {
+	_, ok := d.layer(slot)
+	return ok
+}
+
+// Floor returns the highest slot at or below the given one for which the schedule stores a state.
+func (d *DiffSchedule) Floor(slot primitives.Slot) primitives.Slot {
+	return slot - slot%d.interval(len(d.exponents)-1)
+}
+
+// base returns the slot of the state that the layer at the given slot is diffed against.
+func (d *DiffSchedule) base(slot primitives.Slot, layer int) primitives.Slot {
+	return slot - slot%d.interval(layer-1)
+}
+
+// StateDiffDB describes the database methods needed to store the hierarchical state representation.
+type StateDiffDB interface {
+	StateDiff(ctx context.Context, slot primitives.Slot) ([]byte, error)
+	HasStateDiff(ctx context.Context, slot primitives.Slot) bool
+	SaveStateDiff(ctx context.Context, slot primitives.Slot, enc []byte) error
+	GenesisState(ctx context.Context) (state.BeaconState, error)
+}
+
+// StateDiffs stores finalized states as a hierarchy of snapshots and diffs, following a DiffSchedule.
+//
+// When the state a diff should be based on isn't available, for instance below the origin of a checkpoint
+// synced node, a snapshot is saved instead and used as the base of the following diffs until the next
+// snapshot layer slot. Every diff records its base slot, so reads don't depend on how it was chosen.
+type StateDiffs struct {
+	db       StateDiffDB
+	schedule *DiffSchedule
+	lock     sync.Mutex
+	// anchor is the slot of the last snapshot saved outside of the snapshot layer.
+	anchor    primitives.Slot
+	hasAnchor bool
+	// The last snapshot read is kept, since consecutive reads usually share it.
+	snapSlot primitives.Slot
+	snap     state.BeaconState
+}
+
+// NewStateDiffs creates a StateDiffs using the db for storage.
+func NewStateDiffs(db StateDiffDB, schedule *DiffSchedule) *StateDiffs {
+	return &StateDiffs{db: db, schedule: schedule}
+}
+
+// Schedule returns the schedule of the stored layers.
+func (d *StateDiffs) Schedule() *DiffSchedule {
+	return d.schedule
+}
+
+// Has returns true if a state is stored for the slot.
+func (d *StateDiffs) Has(ctx context.Context, slot primitives.Slot) bool {
+	return d.db.HasStateDiff(ctx, slot)
+}
+
+// Save stores the state, which must be the finalized state at a slot of the schedule.
+func (d *StateDiffs) Save(ctx context.Context, st state.BeaconState) error {
+	ctx, span := trace.StartSpan(ctx, "stateGen.StateDiffs.Save")
+	defer span.End()
+
+	slot := st.Slot()
+	layer, ok := d.schedule.layer(slot)
+	if !ok {
+		return errors.Errorf("slot %d is not part of the state diff schedule", slot)
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	enc, err := d.encode(ctx, slot, layer, st)
+	if err != nil {
+		return err
+	}
+	if err := d.db.SaveStateDiff(ctx, slot, enc); err != nil {
+		return errors.Wrapf(err, "could not save state diff for slot %d", slot)
+	}
+	stateDiffBytes.Observe(float64(len(enc)))
+	return nil
+}
+
+func (d *StateDiffs) encode(ctx context.Context, slot primitives.Slot, layer int, st state.BeaconState) ([]byte, error) {
+	if layer == 0 {
+		return encodeSnapshot(st)
+	}
+	baseSlot := d.schedule.base(slot, layer)
+	if !d.available(ctx, baseSlot) {
+		if !d.hasAnchor || d.anchor >= slot || d.anchor < baseSlot {
+			log.WithField("slot", slot).Debug("Base state of diff is not available, saving a snapshot")
+			d.anchor, d.hasAnchor = slot, true
+			return encodeSnapshot(st)
+		}
+		baseSlot = d.anchor
+	}
+	base, err := d.stateAt(ctx, baseSlot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not load base state at slot %d", baseSlot)
+	}
+	if base.Version() != st.Version() {
+		// The fork changed in between, the encodings can't be compared.
+		return encodeSnapshot(st)
+	}
+	return encodeDiff(baseSlot, base, st)
+}
+
+// available returns true if the state at the slot can be loaded, the genesis state standing in for slot 0.
+func (d *StateDiffs) available(ctx context.Context, slot primitives.Slot) bool {
+	if d.db.HasStateDiff(ctx, slot) {
+		return true
+	}
+	if slot != 0 {
+		return false
+	}
+	st, err := d.db.GenesisState(ctx)
+	return err == nil && st != nil && !st.IsNil()
+}
+
+// StateAt rebuilds the state at a slot of the schedule. An error wrapping db.ErrNotFoundState is
+// returned when no state is stored for the slot.
+func (d *StateDiffs) StateAt(ctx context.Context, slot primitives.Slot) (state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "stateGen.StateDiffs.StateAt")
+	defer span.End()
+
+	start := time.Now()
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	st, err := d.stateAt(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+	stateDiffLoadSummary.Observe(float64(time.Since(start).Milliseconds()))
+	return st, nil
+}
+
+// StateBelow returns the state at the highest slot of the schedule at or below the given slot.
+func (d *StateDiffs) StateBelow(ctx context.Context, slot primitives.Slot) (state.BeaconState, error) {
+	return d.StateAt(ctx, d.schedule.Floor(slot))
+}
+
+func (d *StateDiffs) stateAt(ctx context.Context, slot primitives.Slot) (state.BeaconState, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if d.snap != nil && d.snapSlot == slot {
+		return d.snap.Copy(), nil
+	}
+	if slot == 0 && !d.db.HasStateDiff(ctx, slot) {
+		st, err := d.db.GenesisState(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if st == nil || st.IsNil() {
+			return nil, errors.Wrap(db.ErrNotFoundState, "no genesis state")
+		}
+		return st, nil
+	}
+	enc, err := d.db.StateDiff(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+	l, err := decodeLayer(enc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decode state diff at slot %d", slot)
+	}
+	if l.kind == layerSnapshot {
+		st, err := l.snapshot()
+		if err != nil {
+			return nil, err
+		}
+		d.snapSlot, d.snap = slot, st
+		return st.Copy(), nil
+	}
+	if l.base >= slot {
+		return nil, errors.Wrapf(errInvalidStateDiff, "diff at slot %d has base slot %d", slot, l.base)
+	}
+	base, err := d.stateAt(ctx, l.base)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not load base state at slot %d", l.base)
+	}
+	st, err := l.apply(base)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not apply state diff at slot %d", slot)
+	}
+	log.WithFields(logrus.Fields{
+		"slot":     slot,
+		"baseSlot": l.base,
+	}).Trace("Applied state diff")
+	return st, nil
+}
@@ -0,0 +1,291 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+type mockStateDiffDB struct {
+	diffs   map[primitives.Slot][]byte
+	genesis state.BeaconState
+}
+
+func newMockStateDiffDB(genesis state.BeaconState) *mockStateDiffDB {
+	return &mockStateDiffDB{diffs: make(map[primitives.Slot][]byte), genesis: genesis}
+}
+
+func (m *mockStateDiffDB) StateDiff(_ context.Context, slot primitives.Slot) ([]byte, error) {
+	enc, ok := m.diffs[slot]
+	if !ok {
+		return nil, db.ErrNotFoundState
+	}
+	return enc, nil
+}
+
+func (m *mockStateDiffDB) HasStateDiff(_ context.Context, slot primitives.Slot) bool {
+	_, ok := m.diffs[slot]
+	return ok
+}
+
+func (m *mockStateDiffDB) SaveStateDiff(_ context.Context, slot primitives.Slot, enc []byte) error {
+	m.diffs[slot] = enc
+	return nil
+}
+
+func (m *mockStateDiffDB) GenesisState(_ context.Context) (state.BeaconState, error) {
+	return m.genesis, nil
+}
+
+var _ StateDiffDB = &mockStateDiffDB{}
+
+func testSchedule(t *testing.T, exponents ...uint64) *DiffSchedule {
+	s, err := NewDiffSchedule(exponents)
+	require.NoError(t, err)
+	return s
+}
+
+func TestDiffSchedule(t *testing.T) {
+	_, err := NewDiffSchedule(nil)
+	require.ErrorContains(t, "at least one layer", err)
+	_, err = NewDiffSchedule([]uint64{4, 6})
+	require.ErrorContains(t, "strictly decreasing", err)
+	_, err = NewDiffSchedule([]uint64{64})
+	require.ErrorContains(t, "too large", err)
+
+	s := testSchedule(t, 6, 4, 2)
+	l, ok := s.layer(128)
+	require.Equal(t, true, ok)
+	require.Equal(t, 0, l)
+	l, ok = s.layer(80)
+	require.Equal(t, true, ok)
+	require.Equal(t, 1, l)
+	require.Equal(t, primitives.Slot(64), s.base(80, l))
+	l, ok = s.layer(84)
+	require.Equal(t, true, ok)
+	require.Equal(t, 2, l)
+	require.Equal(t, primitives.Slot(80), s.base(84, l))
+	require.Equal(t, false, s.Contains(85))
+	require.Equal(t, primitives.Slot(84), s.Floor(87))
+}
+
+func TestStateDiffs_SaveAndLoad(t *testing.T) {
+	ctx := context.Background()
+	gs, _ := util.DeterministicGenesisState(t, 32)
+	d := NewStateDiffs(newMockStateDiffDB(gs), testSchedule(t, 6, 4, 2))
+
+	st := gs.Copy()
+	saved := make(map[primitives.Slot]state.BeaconState)
+	for _, slot := range []primitives.Slot{4, 16, 20, 64, 68} {
+		require.NoError(t, st.SetSlot(slot))
+		bals := st.Balances()
+		bals[int(slot)%len(bals)] += uint64(slot)
+		require.NoError(t, st.SetBalances(bals))
+		require.NoError(t, d.Save(ctx, st))
+		saved[slot] = st.Copy()
+	}
+	require.ErrorContains(t, "not part of the state diff schedule", d.Save(ctx, func() state.BeaconState {
+		require.NoError(t, st.SetSlot(70))
+		return st
+	}()))
+
+	for slot, want := range saved {
+		got, err := d.StateAt(ctx, slot)
+		require.NoError(t, err)
+		requireSameState(t, want, got)
+	}
+	// The diff at slot 20 is based on slot 16, which is based on genesis.
+	l, err := decodeLayer(d.db.(*mockStateDiffDB).diffs[20])
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(16), l.base)
+	l, err = decodeLayer(d.db.(*mockStateDiffDB).diffs[64])
+	require.NoError(t, err)
+	require.Equal(t, layerSnapshot, l.kind)
+
+	got, err := d.StateBelow(ctx, 23)
+	require.NoError(t, err)
+	requireSameState(t, saved[20], got)
+	_, err = d.StateAt(ctx, 24)
+	require.ErrorIs(t, err, db.ErrNotFoundState)
+}
+
+func TestStateDiffs_MissingBase(t *testing.T) {
+	ctx := context.Background()
+	gs, _ := util.DeterministicGenesisState(t, 32)
+	mdb := newMockStateDiffDB(nil)
+	d := NewStateDiffs(mdb, testSchedule(t, 6, 4, 2))
+
+	// Like a checkpoint synced node, there is no state below slot 20.
+	st := gs.Copy()
+	for _, slot := range []primitives.Slot{20, 24, 28, 32} {
+		require.NoError(t, st.SetSlot(slot))
+		require.NoError(t, d.Save(ctx, st))
+	}
+	l, err := decodeLayer(mdb.diffs[20])
+	require.NoError(t, err)
+	require.Equal(t, layerSnapshot, l.kind)
+	for _, slot := range []primitives.Slot{24, 28} {
+		l, err := decodeLayer(mdb.diffs[slot])
+		require.NoError(t, err)
+		require.Equal(t, layerDiff, l.kind)
+		require.Equal(t, primitives.Slot(20), l.base)
+	}
+	// Slot 32 is diffed against slot 20 as well, since there is no genesis state.
+	l, err = decodeLayer(mdb.diffs[32])
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(20), l.base)
+	got, err := d.StateAt(ctx, 32)
+	require.NoError(t, err)
+	requireSameState(t, st, got)
+}
+
+func TestCanonicalHistory_StateDiffs(t *testing.T) {
+	ctx := context.Background()
+	var first, boundary, middle, end primitives.Slot = 100, 128, 150, 155
+	specs := []mockHistorySpec{
+		{slot: first, canonicalBlock: true},
+		{slot: boundary, canonicalBlock: true},
+		{slot: middle, canonicalBlock: true},
+		{slot: end, canonicalBlock: true},
+	}
+	hist := newMockHistory(t, specs, end+1)
+	d := NewStateDiffs(newMockStateDiffDB(hist.states[hist.slotMap[0]]), testSchedule(t, 6, 5))
+	require.NoError(t, d.Save(ctx, hist.hiddenStates[hist.slotMap[boundary]]))
+
+	ch := NewCanonicalHistory(hist, hist, hist, WithStateDiffs(d))
+	st, descendants, err := ch.chainForSlot(ctx, end)
+	require.NoError(t, err)
+	require.Equal(t, boundary, st.Slot())
+	require.Equal(t, 2, len(descendants))
+
+	st, err = ch.ReplayerForSlot(end).ReplayBlocks(ctx)
+	require.NoError(t, err)
+	requireSameState(t, hist.hiddenStates[hist.slotMap[end]], st)
+}
+
+func TestMigrateToCold_StateDiffs(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	gs, _ := util.DeterministicGenesisState(t, 32)
+	require.NoError(t, beaconDB.SaveGenesisData(ctx, gs))
+	service := New(beaconDB, doublylinkedtree.New(), WithStateDiffSchedule(testSchedule(t, 3, 2)))
+
+	saved := make(map[primitives.Slot]state.BeaconState)
+	for _, slot := range []primitives.Slot{4, 8} {
+		st := gs.Copy()
+		require.NoError(t, st.SetSlot(slot))
+		r := [32]byte{byte(slot)}
+		require.NoError(t, service.epochBoundaryStateCache.put(r, st))
+		saved[slot] = st
+	}
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 9
+	fRoot, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, b)
+	require.NoError(t, service.MigrateToCold(ctx, fRoot))
+
+	for slot, want := range saved {
+		require.Equal(t, true, beaconDB.HasStateDiff(ctx, slot))
+		got, err := service.StateDiffs().StateAt(ctx, slot)
+		require.NoError(t, err)
+		requireSameState(t, want, got)
+	}
+	require.Equal(t, false, beaconDB.HasStateDiff(ctx, 1))
+	lastIndex, err := beaconDB.LastArchivedSlot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(0), lastIndex)
+}
+
+func TestMigrateToCold_StateDiffsStateExistsInDB(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	gs, _ := util.DeterministicGenesisState(t, 32)
+	require.NoError(t, beaconDB.SaveGenesisData(ctx, gs))
+	service := New(beaconDB, doublylinkedtree.New(), WithStateDiffSchedule(testSchedule(t, 3, 2)))
+	service.slotsPerArchivedPoint = 1
+
+	st := gs.Copy()
+	require.NoError(t, st.SetSlot(1))
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 2
+	fRoot, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, b)
+	require.NoError(t, service.epochBoundaryStateCache.put(fRoot, st))
+	require.NoError(t, beaconDB.SaveState(ctx, st, fRoot))
+
+	// The hot state saved in the db at an archived point is still removed from the hot states to delete.
+	service.saveHotStateDB.blockRootsOfSavedStates = [][32]byte{{1}, fRoot}
+	require.NoError(t, service.MigrateToCold(ctx, fRoot))
+	assert.DeepEqual(t, [][32]byte{{1}}, service.saveHotStateDB.blockRootsOfSavedStates)
+	require.Equal(t, true, beaconDB.HasStateDiff(ctx, 0))
+	assert.LogsDoNotContain(t, hook, "Saved state in DB")
+}
+
+func TestMigrateArchivedStates(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	gs, _ := util.DeterministicGenesisState(t, 32)
+	require.NoError(t, beaconDB.SaveGenesisData(ctx, gs))
+	service := New(beaconDB, doublylinkedtree.New(), WithStateDiffSchedule(testSchedule(t, 6, 4)))
+	service.slotsPerArchivedPoint = 16
+
+	parent, err := beaconDB.GenesisBlockRoot(ctx)
+	require.NoError(t, err)
+	roots := make(map[primitives.Slot][32]byte)
+	saved := make(map[primitives.Slot]state.BeaconState)
+	for _, slot := range []primitives.Slot{16, 32, 48} {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = parent[:]
+		r, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, beaconDB, b)
+		st := gs.Copy()
+		require.NoError(t, st.SetSlot(slot))
+		require.NoError(t, beaconDB.SaveState(ctx, st, r))
+		require.Equal(t, true, beaconDB.HasArchivedPoint(ctx, slot))
+		roots[slot], saved[slot] = r, st
+		parent = r
+	}
+	fRoot := roots[48]
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: fRoot[:]}))
+
+	// The archived states are kept unless pruning is enabled.
+	require.NoError(t, service.migrateArchivedStates(ctx))
+	for _, slot := range []primitives.Slot{16, 32} {
+		got, err := service.StateDiffs().StateAt(ctx, slot)
+		require.NoError(t, err)
+		requireSameState(t, saved[slot], got)
+		require.Equal(t, true, beaconDB.HasState(ctx, roots[slot]))
+	}
+
+	service.pruneArchivedStates = true
+	require.NoError(t, service.migrateArchivedStates(ctx))
+	for _, slot := range []primitives.Slot{16, 32} {
+		got, err := service.StateDiffs().StateAt(ctx, slot)
+		require.NoError(t, err)
+		requireSameState(t, saved[slot], got)
+		require.Equal(t, false, beaconDB.HasState(ctx, roots[slot]))
+	}
+	// The state of the finalized block is migrated but kept.
+	got, err := service.StateDiffs().StateAt(ctx, 48)
+	require.NoError(t, err)
+	requireSameState(t, saved[48], got)
+	require.Equal(t, true, beaconDB.HasState(ctx, roots[48]))
+
+	// Running it again is a no-op.
+	require.NoError(t, service.migrateArchivedStates(ctx))
+}
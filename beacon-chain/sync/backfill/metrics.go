@@ -57,6 +57,12 @@ var (
 			Help: "Number of BeaconBlock values downloaded from peers for backfill.",
 		},
 	)
+	backfillBlocksEraCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "backfill_blocks_era_count",
+			Help: "Number of BeaconBlock values read from local era files for backfill.",
+		},
+	)
 	backfillBatchTimeRoundtrip = promauto.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "backfill_batch_time_roundtrip",
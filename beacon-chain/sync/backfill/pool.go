@@ -28,9 +28,9 @@ type worker interface {
 
 type newWorker func(id workerId, in, out chan batch, c *startup.Clock, v *verifier, cm sync.ContextByteVersions, nbv verification.NewBlobVerifier, bfs *filesystem.BlobStorage) worker
 
-func defaultNewWorker(p p2p.P2P) newWorker {
+func defaultNewWorker(p p2p.P2P, es eraBlockSource) newWorker {
 	return func(id workerId, in, out chan batch, c *startup.Clock, v *verifier, cm sync.ContextByteVersions, nbv verification.NewBlobVerifier, bfs *filesystem.BlobStorage) worker {
-		return newP2pWorker(id, p, in, out, c, v, cm, nbv, bfs)
+		return newP2pWorker(id, p, in, out, c, v, cm, nbv, bfs, es)
 	}
 }
 
@@ -49,8 +49,8 @@ type p2pBatchWorkerPool struct {
 
 var _ batchWorkerPool = &p2pBatchWorkerPool{}
 
-func newP2PBatchWorkerPool(p p2p.P2P, maxBatches int) *p2pBatchWorkerPool {
-	nw := defaultNewWorker(p)
+func newP2PBatchWorkerPool(p p2p.P2P, maxBatches int, es eraBlockSource) *p2pBatchWorkerPool {
+	nw := defaultNewWorker(p, es)
 	return &p2pBatchWorkerPool{
 		newWorker:   nw,
 		toRouter:    make(chan batch, maxBatches),
@@ -6,6 +6,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/era"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
@@ -39,6 +40,7 @@ type Service struct {
 	batchImporter   batchImporter
 	blobStore       *filesystem.BlobStorage
 	initSyncWaiter  func() error
+	eraSource       eraBlockSource
 }
 
 var _ runtime.Service = (*Service)(nil)
@@ -102,6 +104,19 @@ func WithInitSyncWaiter(w func() error) ServiceOption {
 	}
 }
 
+// WithEraDir configures a directory of era files that backfill will read batches from before
+// requesting them from peers. Batches which are not covered by the era files are downloaded as usual.
+func WithEraDir(dir string) ServiceOption {
+	return func(s *Service) error {
+		src, err := era.NewBlockSource(dir)
+		if err != nil {
+			return errors.Wrap(err, "could not initialize era file source for backfill")
+		}
+		s.eraSource = src
+		return nil
+	}
+}
+
 // InitializerWaiter is an interface that is satisfied by verification.InitializerWaiter.
 // Using this interface enables node init to satisfy this requirement for the backfill service
 // while also allowing backfill to mock it in tests.
@@ -154,7 +169,7 @@ func NewService(ctx context.Context, su *Store, bStore *filesystem.BlobStorage,
 			return nil, err
 		}
 	}
-	s.pool = newP2PBatchWorkerPool(p, s.nWorkers)
+	s.pool = newP2PBatchWorkerPool(p, s.nWorkers, s.eraSource)
 
 	return s, nil
 }
@@ -331,6 +346,9 @@ func (s *Service) initBatches() error {
 }
 
 func (s *Service) downscore(b batch) {
+	if b.blockPid == "" {
+		return
+	}
 	s.p2p.Peers().Scorers().BadResponsesScorer().Increment(b.blockPid)
 }
 
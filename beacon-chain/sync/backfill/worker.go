@@ -10,6 +10,8 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 )
 
 type workerId int
@@ -24,6 +26,13 @@ type p2pWorker struct {
 	cm   sync.ContextByteVersions
 	nbv  verification.NewBlobVerifier
 	bfs  *filesystem.BlobStorage
+	era  eraBlockSource
+}
+
+// eraBlockSource is satisfied by era.BlockSource, allowing batches to be read from local era files.
+type eraBlockSource interface {
+	Covers(start, end primitives.Slot) bool
+	BlocksByRange(start, end primitives.Slot) ([]interfaces.ReadOnlySignedBeaconBlock, error)
 }
 
 func (w *p2pWorker) run(ctx context.Context) {
@@ -49,15 +58,21 @@ func (w *p2pWorker) handleBlocks(ctx context.Context, b batch) batch {
 	if err != nil {
 		return b.withRetryableError(errors.Wrap(err, "configuration issue, could not compute minimum blob retention slot"))
 	}
-	b.blockPid = b.busy
 	start := time.Now()
-	results, err := sync.SendBeaconBlocksByRangeRequest(ctx, w.c, w.p2p, b.blockPid, b.blockRequest(), blockValidationMetrics)
+	results, ok := w.blocksFromEra(b, blobRetentionStart)
+	if ok {
+		// The batch didn't come from a peer, so a failure to import it must not be held against one.
+		b.blockPid = ""
+	} else {
+		b.blockPid = b.busy
+		results, err = sync.SendBeaconBlocksByRangeRequest(ctx, w.c, w.p2p, b.blockPid, b.blockRequest(), blockValidationMetrics)
+		if err != nil {
+			log.WithError(err).WithFields(b.logFields()).Debug("Batch requesting failed")
+			return b.withRetryableError(err)
+		}
+	}
 	dlt := time.Now()
 	backfillBatchTimeDownloadingBlocks.Observe(float64(dlt.Sub(start).Milliseconds()))
-	if err != nil {
-		log.WithError(err).WithFields(b.logFields()).Debug("Batch requesting failed")
-		return b.withRetryableError(err)
-	}
 	vb, err := w.v.verify(results)
 	backfillBatchTimeVerifying.Observe(float64(time.Since(dlt).Milliseconds()))
 	if err != nil {
@@ -80,6 +95,21 @@ func (w *p2pWorker) handleBlocks(ctx context.Context, b batch) batch {
 	return b.withResults(vb, bs)
 }
 
+// blocksFromEra reads the blocks of the batch from local era files, if they cover it. Era files don't hold
+// blob sidecars, so only batches entirely below the blob retention window are served this way.
+func (w *p2pWorker) blocksFromEra(b batch, retentionStart primitives.Slot) ([]interfaces.ReadOnlySignedBeaconBlock, bool) {
+	if w.era == nil || b.end > retentionStart || !w.era.Covers(b.begin, b.end) {
+		return nil, false
+	}
+	blks, err := w.era.BlocksByRange(b.begin, b.end)
+	if err != nil {
+		log.WithError(err).WithFields(b.logFields()).Warn("Could not read backfill batch from era files, requesting it from peers")
+		return nil, false
+	}
+	backfillBlocksEraCount.Add(float64(len(blks)))
+	return blks, true
+}
+
 func (w *p2pWorker) handleBlobs(ctx context.Context, b batch) batch {
 	b.blobPid = b.busy
 	start := time.Now()
@@ -101,7 +131,7 @@ func (w *p2pWorker) handleBlobs(ctx context.Context, b batch) batch {
 	return b.postBlobSync()
 }
 
-func newP2pWorker(id workerId, p p2p.P2P, todo, done chan batch, c *startup.Clock, v *verifier, cm sync.ContextByteVersions, nbv verification.NewBlobVerifier, bfs *filesystem.BlobStorage) *p2pWorker {
+func newP2pWorker(id workerId, p p2p.P2P, todo, done chan batch, c *startup.Clock, v *verifier, cm sync.ContextByteVersions, nbv verification.NewBlobVerifier, bfs *filesystem.BlobStorage, es eraBlockSource) *p2pWorker {
 	return &p2pWorker{
 		id:   id,
 		todo: todo,
@@ -112,5 +142,6 @@ func newP2pWorker(id workerId, p p2p.P2P, todo, done chan batch, c *startup.Cloc
 		cm:   cm,
 		nbv:  nbv,
 		bfs:  bfs,
+		era:  es,
 	}
 }
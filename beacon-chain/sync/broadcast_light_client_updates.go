@@ -0,0 +1,87 @@
+package sync
+
+import (
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// This routine keeps track of the latest light client updates computed by the blockchain service, so that
+// they can be served to peers, and broadcasts them on the light client gossip topics.
+func (s *Service) lightClientUpdatesRoutine() {
+	if s.cfg.stateNotifier == nil {
+		log.Error("No state notifier configured, not serving light client updates")
+		return
+	}
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.cfg.stateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Could not subscribe to state notifier")
+			return
+		case event := <-stateChannel:
+			s.handleLightClientEvent(event)
+		}
+	}
+}
+
+func (s *Service) handleLightClientEvent(event *feed.Event) {
+	switch event.Type {
+	case statefeed.LightClientFinalityUpdate:
+		data, ok := event.Data.(*ethpbv2.LightClientFinalityUpdateWithVersion)
+		if !ok || data.Data == nil {
+			return
+		}
+		s.setLightClientFinalityUpdate(data.Data)
+		s.broadcastLightClientUpdate(data.Data)
+	case statefeed.LightClientOptimisticUpdate:
+		data, ok := event.Data.(*ethpbv2.LightClientOptimisticUpdateWithVersion)
+		if !ok || data.Data == nil {
+			return
+		}
+		s.setLightClientOptimisticUpdate(data.Data)
+		s.broadcastLightClientUpdate(data.Data)
+	}
+}
+
+// broadcastLightClientUpdate publishes a locally computed update once the node is synced, the update
+// goes through our own gossip validation which records it as forwarded.
+func (s *Service) broadcastLightClientUpdate(update proto.Message) {
+	if s.cfg.initialSync != nil && s.cfg.initialSync.Syncing() {
+		return
+	}
+	if err := s.cfg.p2p.Broadcast(s.ctx, update); err != nil {
+		log.WithError(err).Debug("Could not broadcast light client update")
+	}
+}
+
+func (s *Service) setLightClientFinalityUpdate(update *ethpbv2.LightClientFinalityUpdate) {
+	s.lightClientLock.Lock()
+	defer s.lightClientLock.Unlock()
+	s.lightClientFinalityUpdate = update
+}
+
+func (s *Service) setLightClientOptimisticUpdate(update *ethpbv2.LightClientOptimisticUpdate) {
+	s.lightClientLock.Lock()
+	defer s.lightClientLock.Unlock()
+	s.lightClientOptimisticUpdate = update
+}
+
+// latestLightClientFinalityUpdate returns the latest locally computed finality update, if any.
+func (s *Service) latestLightClientFinalityUpdate() *ethpbv2.LightClientFinalityUpdate {
+	s.lightClientLock.RLock()
+	defer s.lightClientLock.RUnlock()
+	return s.lightClientFinalityUpdate
+}
+
+// latestLightClientOptimisticUpdate returns the latest locally computed optimistic update, if any.
+func (s *Service) latestLightClientOptimisticUpdate() *ethpbv2.LightClientOptimisticUpdate {
+	s.lightClientLock.RLock()
+	defer s.lightClientLock.RUnlock()
+	return s.lightClientOptimisticUpdate
+}
@@ -0,0 +1,219 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	ssz "github.com/prysmaticlabs/fastssz"
+	db "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func newLightClientRPCTestService(t *testing.T) (*Service, *p2ptest.TestP2P, *p2ptest.TestP2P) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	assert.Equal(t, 1, len(p1.BHost.Network().Peers()), "Expected peers to be connected")
+	d := db.SetupDB(t)
+	s := &Service{
+		cfg: &config{
+			beaconDB: d,
+			p2p:      p1,
+			stateGen: stategen.New(d, doublylinkedtree.New()),
+			clock:    startup.NewClock(time.Now(), [32]byte{'A'}),
+		},
+		rateLimiter: newRateLimiter(p1),
+	}
+	return s, p1, p2
+}
+
+func testSyncCommittee() *ethpbv2.SyncCommittee {
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, fieldparams.BLSPubkeyLength)
+	}
+	return &ethpbv2.SyncCommittee{Pubkeys: pubkeys, AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength)}
+}
+
+// readLightClientChunk reads a successful response chunk and checks its context bytes.
+func readLightClientChunk(t *testing.T, s *Service, stream network.Stream, slot uint64, msg ssz.Unmarshaler) {
+	expectSuccess(t, stream)
+	rpcCtx, err := readContextFromStream(stream)
+	require.NoError(t, err)
+	valRoot := s.cfg.clock.GenesisValidatorsRoot()
+	digest, err := forks.ForkDigestFromEpoch(slots.ToEpoch(primitives.Slot(slot)), valRoot[:])
+	require.NoError(t, err)
+	require.DeepEqual(t, digest[:], rpcCtx)
+	require.NoError(t, s.cfg.p2p.Encoding().DecodeWithMaxLength(stream, msg))
+}
+
+func TestLightClientOptimisticUpdateRPCHandler(t *testing.T) {
+	s, p1, p2 := newLightClientRPCTestService(t)
+	pcl := protocol.ID(p2p.RPCLightClientOptimisticUpdateTopicV1 + s.cfg.p2p.Encoding().ProtocolSuffix())
+
+	// Nothing to serve yet.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeResourceUnavailable, types.ErrResourceUnavailable.Error(), stream)
+	})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.ErrorIs(t, s.lightClientOptimisticUpdateRPCHandler(context.Background(), new(interface{}), stream), errLightClientUpdateUnavailable)
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+
+	update := testLightClientOptimisticUpdate(t, 20)
+	s.setLightClientOptimisticUpdate(update)
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		got, err := ethpbv2.NewLightClientOptimisticUpdate(version.Altair)
+		require.NoError(t, err)
+		readLightClientChunk(t, s, stream, 20, got)
+		require.DeepEqual(t, update, got)
+	})
+	stream, err = p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.NoError(t, s.lightClientOptimisticUpdateRPCHandler(context.Background(), new(interface{}), stream))
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientFinalityUpdateRPCHandler(t *testing.T) {
+	s, p1, p2 := newLightClientRPCTestService(t)
+	pcl := protocol.ID(p2p.RPCLightClientFinalityUpdateTopicV1 + s.cfg.p2p.Encoding().ProtocolSuffix())
+
+	update := testLightClientFinalityUpdate(t, 30)
+	s.setLightClientFinalityUpdate(update)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		got, err := ethpbv2.NewLightClientFinalityUpdate(version.Altair)
+		require.NoError(t, err)
+		readLightClientChunk(t, s, stream, 30, got)
+		require.DeepEqual(t, update, got)
+	})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.NoError(t, s.lightClientFinalityUpdateRPCHandler(context.Background(), new(interface{}), stream))
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientUpdatesByRangeRPCHandler(t *testing.T) {
+	s, p1, p2 := newLightClientRPCTestService(t)
+	ctx := context.Background()
+	pcl := protocol.ID(p2p.RPCLightClientUpdatesByRangeTopicV1 + s.cfg.p2p.Encoding().ProtocolSuffix())
+
+	// Periods 1 and 2 are consecutive, period 4 is not served because period 3 is missing.
+	for _, period := range []uint64{1, 2, 4} {
+		finality := testLightClientFinalityUpdate(t, primitives.Slot(period*100))
+		update := &ethpbv2.LightClientUpdate{
+			AttestedHeader:          finality.AttestedHeader,
+			NextSyncCommittee:       testSyncCommittee(),
+			NextSyncCommitteeBranch: finality.FinalityBranch[:5],
+			FinalizedHeader:         finality.FinalizedHeader,
+			FinalityBranch:          finality.FinalityBranch,
+			SyncAggregate:           finality.SyncAggregate,
+			SignatureSlot:           finality.SignatureSlot,
+		}
+		require.NoError(t, s.cfg.beaconDB.SaveLightClientUpdate(ctx, period, &ethpbv2.LightClientUpdateWithVersion{
+			Version: ethpbv2.Version(version.Altair),
+			Data:    update,
+		}))
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		for _, period := range []uint64{1, 2} {
+			got, err := ethpbv2.NewLightClientUpdate(version.Altair)
+			require.NoError(t, err)
+			readLightClientChunk(t, s, stream, period*100, got)
+			beacon, err := got.AttestedHeader.GetBeacon()
+			require.NoError(t, err)
+			require.Equal(t, primitives.Slot(period*100), beacon.Slot)
+		}
+		_, _, err := ReadStatusCode(stream, s.cfg.p2p.Encoding())
+		require.ErrorContains(t, "EOF", err)
+	})
+	stream, err := p1.BHost.NewStream(ctx, p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req := &types.LightClientUpdatesByRangeReq{StartPeriod: 1, Count: 10}
+	require.NoError(t, s.lightClientUpdatesByRangeRPCHandler(ctx, req, stream))
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientBootstrapRPCHandler(t *testing.T) {
+	s, p1, p2 := newLightClientRPCTestService(t)
+	ctx := context.Background()
+	pcl := protocol.ID(p2p.RPCLightClientBootstrapTopicV1 + s.cfg.p2p.Encoding().ProtocolSuffix())
+
+	l := util.NewTestLightClient(t).SetupTestAltair()
+	root, err := l.Block.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, s.cfg.beaconDB.SaveBlock(ctx, l.Block))
+	require.NoError(t, s.cfg.beaconDB.SaveState(ctx, l.State, root))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		got, err := ethpbv2.NewLightClientBootstrap(version.Altair)
+		require.NoError(t, err)
+		readLightClientChunk(t, s, stream, uint64(l.Block.Block().Slot()), got)
+		beacon, err := got.Header.GetBeacon()
+		require.NoError(t, err)
+		headerRoot, err := beacon.HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, root, headerRoot)
+	})
+	stream, err := p1.BHost.NewStream(ctx, p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req := types.LightClientBootstrapReq(root)
+	require.NoError(t, s.lightClientBootstrapRPCHandler(ctx, &req, stream))
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+
+	// Unknown blocks are unavailable.
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeResourceUnavailable, types.ErrResourceUnavailable.Error(), stream)
+	})
+	stream, err = p1.BHost.NewStream(ctx, p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	unknown := types.LightClientBootstrapReq{'b'}
+	require.ErrorIs(t, s.lightClientBootstrapRPCHandler(ctx, &unknown, stream), types.ErrResourceUnavailable)
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
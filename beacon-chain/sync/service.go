@@ -36,10 +36,13 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/backfill/coverage"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 	lruwrpr "github.com/prysmaticlabs/prysm/v5/cache/lru"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	leakybucket "github.com/prysmaticlabs/prysm/v5/container/leaky-bucket"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/runtime"
 	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
@@ -158,6 +161,11 @@ type Service struct {
 	newBlobVerifier                  verification.NewBlobVerifier
 	availableBlocker                 coverage.AvailableBlocker
 	ctxMap                           ContextByteVersions
+	lightClientLock                  sync.RWMutex
+	lightClientFinalityUpdate        *ethpbv2.LightClientFinalityUpdate
+	lightClientOptimisticUpdate      *ethpbv2.LightClientOptimisticUpdate
+	forwardedFinalityUpdateSlot      primitives.Slot
+	forwardedOptimisticUpdateSlot    primitives.Slot
 }
 
 // NewService initializes new regular sync service.
@@ -225,6 +233,9 @@ func (s *Service) Start() {
 
 	go s.verifierRoutine()
 	go s.registerHandlers()
+	if features.Get().EnableLightClient {
+		go s.lightClientUpdatesRoutine()
+	}
 
 	s.cfg.p2p.AddConnectionHandler(s.reValidatePeer, s.sendGoodbye)
 	s.cfg.p2p.AddDisconnectionHandler(func(_ context.Context, _ peer.ID) error {
@@ -0,0 +1,26 @@
+package sync
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// Light client updates are only forwarded when they match the locally computed ones, so there is
+// nothing left to process once they passed validation.
+
+func (s *Service) lightClientFinalityUpdateSubscriber(_ context.Context, msg proto.Message) error {
+	if _, ok := msg.(*ethpbv2.LightClientFinalityUpdate); !ok {
+		return errors.Errorf("incorrect type of message received, wanted %T but got %T", &ethpbv2.LightClientFinalityUpdate{}, msg)
+	}
+	return nil
+}
+
+func (s *Service) lightClientOptimisticUpdateSubscriber(_ context.Context, msg proto.Message) error {
+	if _, ok := msg.(*ethpbv2.LightClientOptimisticUpdate); !ok {
+		return errors.Errorf("incorrect type of message received, wanted %T but got %T", &ethpbv2.LightClientOptimisticUpdate{}, msg)
+	}
+	return nil
+}
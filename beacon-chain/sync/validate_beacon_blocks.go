@@ -81,7 +81,7 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 		},
 	})
 
-	if features.Get().EnableSlasher {
+	if features.Get().EnableSlasher || features.Get().EnableSlasherStream {
 		// Feed the block header to slasher if enabled. This action
 		// is done in the background to avoid adding more load to this critical code path.
 		go func() {
@@ -209,6 +209,15 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 	}
 	msg.ValidatorData = blkPb // Used in downstream subscriber
 
+	// Notify other services of the block before it is imported.
+	s.cfg.blockNotifier.BlockFeed().Send(&feed.Event{
+		Type: blockfeed.GossipValidatedBlock,
+		Data: &blockfeed.GossipValidatedBlockData{
+			SignedBlock: blk,
+			BlockRoot:   blockRoot,
+		},
+	})
+
 	// Log the arrival time of the accepted block
 	graffiti := blk.Block().Body().Graffiti()
 	startTime, err := slots.ToTime(genesisTime, blk.Block().Slot())
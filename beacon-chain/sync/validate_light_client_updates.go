@@ -0,0 +1,124 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// validateLightClientFinalityUpdate validates a light client finality update received over gossip.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/p2p-interface.md#light_client_finality_update
+func (s *Service) validateLightClientFinalityUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	if s.cfg.initialSync.Syncing() {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	_, span := trace.StartSpan(ctx, "sync.validateLightClientFinalityUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+	update, ok := m.(*ethpbv2.LightClientFinalityUpdate)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	finalizedSlot, err := attestedSlot(update.FinalizedHeader)
+	if err != nil {
+		return pubsub.ValidationReject, err
+	}
+
+	s.lightClientLock.Lock()
+	defer s.lightClientLock.Unlock()
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid != s.cfg.p2p.PeerID() {
+		// [IGNORE] The finalized header slot is greater than that of all previously forwarded updates.
+		if finalizedSlot <= s.forwardedFinalityUpdateSlot {
+			return pubsub.ValidationIgnore, nil
+		}
+		// [IGNORE] The block at the signature slot was given enough time to propagate through the network.
+		if !s.lightClientUpdatePropagated(update.SignatureSlot) {
+			return pubsub.ValidationIgnore, nil
+		}
+		// [IGNORE] The update matches the locally computed one exactly.
+		if s.lightClientFinalityUpdate == nil || !proto.Equal(s.lightClientFinalityUpdate, update) {
+			return pubsub.ValidationIgnore, nil
+		}
+	}
+	if finalizedSlot > s.forwardedFinalityUpdateSlot {
+		s.forwardedFinalityUpdateSlot = finalizedSlot
+	}
+	msg.ValidatorData = update // Used in downstream subscriber
+	return pubsub.ValidationAccept, nil
+}
+
+// validateLightClientOptimisticUpdate validates a light client optimistic update received over gossip.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/p2p-interface.md#light_client_optimistic_update
+func (s *Service) validateLightClientOptimisticUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	if s.cfg.initialSync.Syncing() {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	_, span := trace.StartSpan(ctx, "sync.validateLightClientOptimisticUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+	update, ok := m.(*ethpbv2.LightClientOptimisticUpdate)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	slot, err := attestedSlot(update.AttestedHeader)
+	if err != nil {
+		return pubsub.ValidationReject, err
+	}
+
+	s.lightClientLock.Lock()
+	defer s.lightClientLock.Unlock()
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid != s.cfg.p2p.PeerID() {
+		// [IGNORE] The attested header slot is greater than that of all previously forwarded updates.
+		if slot <= s.forwardedOptimisticUpdateSlot {
+			return pubsub.ValidationIgnore, nil
+		}
+		// [IGNORE] The block at the signature slot was given enough time to propagate through the network.
+		if !s.lightClientUpdatePropagated(update.SignatureSlot) {
+			return pubsub.ValidationIgnore, nil
+		}
+		// [IGNORE] The update matches the locally computed one exactly.
+		if s.lightClientOptimisticUpdate == nil || !proto.Equal(s.lightClientOptimisticUpdate, update) {
+			return pubsub.ValidationIgnore, nil
+		}
+	}
+	if slot > s.forwardedOptimisticUpdateSlot {
+		s.forwardedOptimisticUpdateSlot = slot
+	}
+	msg.ValidatorData = update // Used in downstream subscriber
+	return pubsub.ValidationAccept, nil
+}
+
+// lightClientUpdatePropagated returns true once one third of the signature slot has transpired,
+// allowing for the maximum gossip clock disparity.
+func (s *Service) lightClientUpdatePropagated(signatureSlot primitives.Slot) bool {
+	cfg := params.BeaconConfig()
+	start := slots.StartTime(uint64(s.cfg.clock.GenesisTime().Unix()), signatureSlot)
+	due := start.Add(time.Duration(cfg.SecondsPerSlot/cfg.IntervalsPerSlot)*time.Second - cfg.MaximumGossipClockDisparityDuration())
+	return !prysmTime.Now().Before(due)
+}
@@ -0,0 +1,179 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/encoder"
+	p2ptest "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	mockSync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/initial-sync/testing"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func testLightClientHeader(t *testing.T, slot primitives.Slot) *ethpbv2.LightClientHeaderContainer {
+	h, err := ethpbv2.NewLightClientHeaderContainer(version.Altair)
+	require.NoError(t, err)
+	h.GetHeaderAltair().Beacon = &ethpbv1.BeaconBlockHeader{
+		Slot:       slot,
+		ParentRoot: bytes.Repeat([]byte{1}, fieldparams.RootLength),
+		StateRoot:  bytes.Repeat([]byte{2}, fieldparams.RootLength),
+		BodyRoot:   bytes.Repeat([]byte{3}, fieldparams.RootLength),
+	}
+	return h
+}
+
+func testSyncAggregate() *ethpbv1.SyncAggregate {
+	return &ethpbv1.SyncAggregate{
+		SyncCommitteeBits:      bytes.Repeat([]byte{0xff}, fieldparams.SyncAggregateSyncCommitteeBytesLength),
+		SyncCommitteeSignature: make([]byte, fieldparams.BLSSignatureLength),
+	}
+}
+
+func testLightClientFinalityUpdate(t *testing.T, slot primitives.Slot) *ethpbv2.LightClientFinalityUpdate {
+	branch := make([][]byte, 6)
+	for i := range branch {
+		branch[i] = make([]byte, fieldparams.RootLength)
+	}
+	return &ethpbv2.LightClientFinalityUpdate{
+		AttestedHeader:  testLightClientHeader(t, slot),
+		FinalizedHeader: testLightClientHeader(t, slot-1),
+		FinalityBranch:  branch,
+		SyncAggregate:   testSyncAggregate(),
+		SignatureSlot:   slot + 1,
+	}
+}
+
+func testLightClientOptimisticUpdate(t *testing.T, slot primitives.Slot) *ethpbv2.LightClientOptimisticUpdate {
+	return &ethpbv2.LightClientOptimisticUpdate{
+		AttestedHeader: testLightClientHeader(t, slot),
+		SyncAggregate:  testSyncAggregate(),
+		SignatureSlot:  slot + 1,
+	}
+}
+
+func lightClientGossipMessage(t *testing.T, s *Service, topicFormat string, update interface{ MarshalSSZ() ([]byte, error) }) *pubsub.Message {
+	valRoot := s.cfg.clock.GenesisValidatorsRoot()
+	digest, err := signing.ComputeForkDigest(params.BeaconConfig().AltairForkVersion, valRoot[:])
+	require.NoError(t, err)
+	topic := fmt.Sprintf(topicFormat, digest) + "/" + encoder.ProtocolSuffixSSZSnappy
+	enc, err := update.MarshalSSZ()
+	require.NoError(t, err)
+	return &pubsub.Message{
+		Message: &pubsubpb.Message{
+			Data:  snappy.Encode(nil, enc),
+			Topic: &topic,
+		},
+	}
+}
+
+func TestValidateLightClientFinalityUpdate(t *testing.T) {
+	genesis := time.Now().Add(-time.Duration(100*params.BeaconConfig().SecondsPerSlot) * time.Second)
+	newService := func(syncing bool) *Service {
+		return &Service{cfg: &config{
+			p2p:         p2ptest.NewTestP2P(t),
+			initialSync: &mockSync.Sync{IsSyncing: syncing},
+			clock:       startup.NewClock(genesis, [32]byte{'A'}),
+		}}
+	}
+	ctx := context.Background()
+
+	t.Run("syncing", func(t *testing.T) {
+		s := newService(true)
+		msg := lightClientGossipMessage(t, s, p2p.LightClientFinalityUpdateTopicFormat, testLightClientFinalityUpdate(t, 10))
+		res, err := s.validateLightClientFinalityUpdate(ctx, "foo", msg)
+		require.NoError(t, err)
+		require.Equal(t, pubsub.ValidationIgnore, res)
+	})
+	t.Run("no local update", func(t *testing.T) {
+		s := newService(false)
+		msg := lightClientGossipMessage(t, s, p2p.LightClientFinalityUpdateTopicFormat, testLightClientFinalityUpdate(t, 10))
+		res, err := s.validateLightClientFinalityUpdate(ctx, "foo", msg)
+		require.NoError(t, err)
+		require.Equal(t, pubsub.ValidationIgnore, res)
+	})
+	t.Run("different from local update", func(t *testing.T) {
+		s := newService(false)
+		s.setLightClientFinalityUpdate(testLightClientFinalityUpdate(t, 11))
+		msg := lightClientGossipMessage(t, s, p2p.LightClientFinalityUpdateTopicFormat, testLightClientFinalityUpdate(t, 10))
+		res, err := s.validateLightClientFinalityUpdate(ctx, "foo", msg)
+		require.NoError(t, err)
+		require.Equal(t, pubsub.ValidationIgnore, res)
+	})
+	t.Run("matches local update", func(t *testing.T) {
+		s := newService(false)
+		update := testLightClientFinalityUpdate(t, 10)
+		s.setLightClientFinalityUpdate(update)
+		msg := lightClientGossipMessage(t, s, p2p.LightClientFinalityUpdateTopicFormat, update)
+		res, err := s.validateLightClientFinalityUpdate(ctx, "foo", msg)
+		require.NoError(t, err)
+		require.Equal(t, pubsub.ValidationAccept, res)
+		require.Equal(t, true, proto.Equal(update, msg.ValidatorData.(proto.Message)))
+
+		// The same update is not forwarded twice.
+		res, err = s.validateLightClientFinalityUpdate(ctx, "foo", msg)
+		require.NoError(t, err)
+		require.Equal(t, pubsub.ValidationIgnore, res)
+	})
+	t.Run("received too early", func(t *testing.T) {
+		s := newService(false)
+		update := testLightClientFinalityUpdate(t, 10)
+		update.SignatureSlot = 1000
+		s.setLightClientFinalityUpdate(update)
+		msg := lightClientGossipMessage(t, s, p2p.LightClientFinalityUpdateTopicFormat, update)
+		res, err := s.validateLightClientFinalityUpdate(ctx, "foo", msg)
+		require.NoError(t, err)
+		require.Equal(t, pubsub.ValidationIgnore, res)
+	})
+	t.Run("invalid encoding", func(t *testing.T) {
+		s := newService(false)
+		msg := lightClientGossipMessage(t, s, p2p.LightClientFinalityUpdateTopicFormat, testLightClientFinalityUpdate(t, 10))
+		msg.Data = snappy.Encode(nil, []byte{1, 2, 3})
+		res, err := s.validateLightClientFinalityUpdate(ctx, "foo", msg)
+		require.NotNil(t, err)
+		require.Equal(t, pubsub.ValidationReject, res)
+	})
+}
+
+func TestValidateLightClientOptimisticUpdate(t *testing.T) {
+	genesis := time.Now().Add(-time.Duration(100*params.BeaconConfig().SecondsPerSlot) * time.Second)
+	s := &Service{cfg: &config{
+		p2p:         p2ptest.NewTestP2P(t),
+		initialSync: &mockSync.Sync{},
+		clock:       startup.NewClock(genesis, [32]byte{'A'}),
+	}}
+	ctx := context.Background()
+
+	update := testLightClientOptimisticUpdate(t, 20)
+	s.setLightClientOptimisticUpdate(update)
+	res, err := s.validateLightClientOptimisticUpdate(ctx, "foo", lightClientGossipMessage(t, s, p2p.LightClientOptimisticUpdateTopicFormat, update))
+	require.NoError(t, err)
+	require.Equal(t, pubsub.ValidationAccept, res)
+
+	// Older updates are ignored, even when they match the local one.
+	older := testLightClientOptimisticUpdate(t, 19)
+	s.setLightClientOptimisticUpdate(older)
+	res, err = s.validateLightClientOptimisticUpdate(ctx, "foo", lightClientGossipMessage(t, s, p2p.LightClientOptimisticUpdateTopicFormat, older))
+	require.NoError(t, err)
+	require.Equal(t, pubsub.ValidationIgnore, res)
+
+	// Our own updates are always accepted.
+	res, err = s.validateLightClientOptimisticUpdate(ctx, s.cfg.p2p.PeerID(), lightClientGossipMessage(t, s, p2p.LightClientOptimisticUpdateTopicFormat, older))
+	require.NoError(t, err)
+	require.Equal(t, pubsub.ValidationAccept, res)
+}
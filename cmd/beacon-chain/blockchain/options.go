@@ -20,5 +20,8 @@ func FlagOptions(c *cli.Context) ([]blockchain.Option, error) {
 		blockchain.WithMaxGoroutines(maxRoutines),
 		blockchain.WithWeakSubjectivityCheckpoint(wsCheckpt),
 	}
+	if c.IsSet(flags.ForkchoiceRecordingFile.Name) {
+		opts = append(opts, blockchain.WithForkchoiceRecordingFile(c.String(flags.ForkchoiceRecordingFile.Name)))
+	}
 	return opts, nil
 }
@@ -4,6 +4,7 @@ package flags
 
 import (
 	"strings"
+	"time"
 
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -28,6 +29,18 @@ var (
 		Usage: "A MEV builder relay string http endpoint, this will be used to interact MEV builder network using API defined in: https://ethereum.github.io/builder-specs/#/Builder",
 		Value: "",
 	}
+	// MevRelayEndpoints provides HTTP access endpoints to additional MEV builder networks.
+	MevRelayEndpoints = &cli.StringSliceFlag{
+		Name: "http-mev-relays",
+		Usage: "Additional MEV builder relay http endpoints, used along with --http-mev-relay. " +
+			"The flag can be repeated or given a comma separated list, and the highest valid bid of all the relays is selected.",
+	}
+	// MevRelayTimeout sets the time given to each relay to answer a header request.
+	MevRelayTimeout = &cli.DurationFlag{
+		Name:  "mev-relay-timeout",
+		Usage: "Maximum time given to each MEV relay to return a bid for a header request. Relays that do not answer in time are ignored for that slot.",
+		Value: time.Second,
+	}
 	MaxBuilderConsecutiveMissedSlots = &cli.IntFlag{
 		Name:  "max-builder-consecutive-missed-slots",
 		Usage: "Number of consecutive skip slot to fallback from using relay/builder to local execution engine for block construction",
@@ -180,6 +193,26 @@ var (
 		Usage: "The slot durations of when an archived state gets saved in the beaconDB.",
 		Value: 2048,
 	}
+	// EnableStateDiff stores finalized states as a hierarchy of snapshots and diffs instead of archived points.
+	EnableStateDiff = &cli.BoolFlag{
+		Name: "enable-state-diff",
+		Usage: "(Experimental) Stores finalized states as full snapshots and layered diffs, so that any historical " +
+			"state can be rebuilt quickly. States saved at archived points are copied to state diffs on startup.",
+	}
+	// StateDiffExponents specifies the layers of the hierarchical state diff storage.
+	StateDiffExponents = &cli.IntSliceFlag{
+		Name: "state-diff-exponents",
+		Usage: "Strictly decreasing powers of two for the slot intervals of the state diff layers. The first one is " +
+			"the interval of full snapshots, the last one the interval of the finest diffs. Used with --enable-state-diff.",
+		Value: cli.NewIntSlice(21, 18, 16, 13, 11, 9, 5),
+	}
+	// PruneMigratedArchivedStates deletes the states saved at archived points once migrated to state diffs.
+	PruneMigratedArchivedStates = &cli.BoolFlag{
+		Name: "prune-migrated-archived-states",
+		Usage: "Deletes the states saved at archived points once they are migrated to state diffs on startup. " +
+			"Without it they are kept, so that the node can still be run without --enable-state-diff. " +
+			"Used with --enable-state-diff.",
+	}
 	// BlockBatchLimit specifies the requested block batch size.
 	BlockBatchLimit = &cli.IntFlag{
 		Name:  "block-batch-limit",
@@ -251,6 +284,13 @@ var (
 			"If such a sync is not possible, the node will treat it as a critical and irrecoverable failure",
 		Value: "",
 	}
+	// ForkchoiceRecordingFile defines the file to which the inputs of fork choice are recorded, to replay them with prysmctl.
+	ForkchoiceRecordingFile = &cli.StringFlag{
+		Name: "forkchoice-recording-file",
+		Usage: "(Debug) Appends the blocks, attestations, ticks and slashings processed by fork choice, and its decisions, " +
+			"to the given file so that they can be replayed with `prysmctl forkchoice replay`. The file grows quickly, " +
+			"this is meant to investigate reorgs.",
+	}
 	// MinPeersPerSubnet defines a flag to set the minimum number of peers that a node will attempt to peer with for a subnet.
 	MinPeersPerSubnet = &cli.Uint64Flag{
 		Name:  "minimum-peers-per-subnet",
@@ -296,4 +336,79 @@ var (
 		Usage: "Directory for the slasher database",
 		Value: cmd.DefaultDataDir(),
 	}
+	// SlasherBackfill enables the backfill of the slasher database from the beacon database.
+	SlasherBackfill = &cli.BoolFlag{
+		Name: "slasher-backfill",
+		Usage: "Backfills the slasher database with the blocks of the beacon database before slashing detection starts, " +
+			"covering the history length of the slasher up to the finalized checkpoint. The blocks after it are then queued for detection " +
+			"with the attestations and blocks received during the backfill. An interrupted backfill resumes on restart.",
+	}
+	// DiscoveryFile sets a file of ENRs to discover peers from.
+	DiscoveryFile = &cli.StringFlag{
+		Name: "discovery-file",
+		Usage: "Discovers peers from a file of ENRs, one per line. Lines starting with # are ignored. " +
+			"The file is reloaded when it changes. Unlike --peer, these peers are not trusted.",
+	}
+	// DiscoveryDNS sets the DNS ENR trees to discover peers from.
+	DiscoveryDNS = &cli.StringSliceFlag{
+		Name: "discovery-dns",
+		Usage: "Discovers peers from the DNS ENR tree (EIP-1459) at this enrtree://<public key>@<domain> URL. " +
+			"Multiple trees can be added with multiple flags.",
+	}
+	// DiscoveryMDNS enables the discovery of peers on the local network.
+	DiscoveryMDNS = &cli.BoolFlag{
+		Name:  "discovery-mdns",
+		Usage: "Discovers peers on the local network through multicast DNS. Meant for private devnets without bootnodes.",
+	}
+	// PeerQuotaWindow sets the period over which the bandwidth quotas of peers are enforced.
+	PeerQuotaWindow = &cli.DurationFlag{
+		Name:  "peer-quota-window",
+		Usage: "Period over which the bandwidth quotas of peers are enforced. Peers over quota during 3 windows in an hour are disconnected.",
+		Value: time.Minute,
+	}
+	// PeerRPCQuota sets the request/response bandwidth quota of peers.
+	PeerRPCQuota = &cli.Uint64Flag{
+		Name:  "peer-rpc-quota",
+		Usage: "Megabytes of requests and responses a peer may exchange with the node over the streams it opens per quota window. 0 is unlimited.",
+		Value: 256,
+	}
+	// PeerGossipQuota sets the gossip bandwidth quota of peers.
+	PeerGossipQuota = &cli.Uint64Flag{
+		Name:  "peer-gossip-quota",
+		Usage: "Megabytes of gossip messages a peer may send the node per quota window. 0 is unlimited.",
+		Value: 128,
+	}
+	// TrustedPeerRPCQuota sets the request/response bandwidth quota of trusted peers.
+	TrustedPeerRPCQuota = &cli.Uint64Flag{
+		Name:  "trusted-peer-rpc-quota",
+		Usage: "Like --peer-rpc-quota, for the trusted peers added with --peer or the trusted peers API. 0 is unlimited.",
+	}
+	// TrustedPeerGossipQuota sets the gossip bandwidth quota of trusted peers.
+	TrustedPeerGossipQuota = &cli.Uint64Flag{
+		Name:  "trusted-peer-gossip-quota",
+		Usage: "Like --peer-gossip-quota, for the trusted peers added with --peer or the trusted peers API. 0 is unlimited.",
+	}
+	// GossipTraceDir enables the recording of the gossip messages processed by the node to the given directory.
+	GossipTraceDir = &cli.StringFlag{
+		Name: "gossip-trace-dir",
+		Usage: "Records every delivered, rejected, ignored and duplicate gossip message to rotating trace files in this directory. " +
+			"Trace files can be analyzed with `prysmctl p2p trace`.",
+	}
+	// GossipTracePayloads enables recording the payloads of the traced gossip messages.
+	GossipTracePayloads = &cli.BoolFlag{
+		Name:  "gossip-trace-payloads",
+		Usage: "Records the snappy compressed SSZ payloads of the traced gossip messages. Requires --gossip-trace-dir.",
+	}
+	// GossipTraceMaxFileSize sets the size of the gossip trace files.
+	GossipTraceMaxFileSize = &cli.Uint64Flag{
+		Name:  "gossip-trace-max-file-size",
+		Usage: "Size in megabytes after which a new gossip trace file is started.",
+		Value: 256,
+	}
+	// GossipTraceMaxFiles sets the number of gossip trace files kept.
+	GossipTraceMaxFiles = &cli.IntFlag{
+		Name:  "gossip-trace-max-files",
+		Usage: "Number of gossip trace files kept, the oldest ones are deleted. 0 keeps all files.",
+		Value: 16,
+	}
 )
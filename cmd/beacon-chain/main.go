@@ -63,12 +63,16 @@ var appFlags = []cli.Flag{
 	flags.InteropNumValidatorsFlag,
 	flags.InteropGenesisTimeFlag,
 	flags.SlotsPerArchivedPoint,
+	flags.EnableStateDiff,
+	flags.StateDiffExponents,
+	flags.PruneMigratedArchivedStates,
 	flags.DisableDebugRPCEndpoints,
 	flags.SubscribeToAllSubnets,
 	flags.HistoricalSlasherNode,
 	flags.ChainID,
 	flags.NetworkID,
 	flags.WeakSubjectivityCheckpoint,
+	flags.ForkchoiceRecordingFile,
 	flags.Eth1HeaderReqLimit,
 	flags.MinPeersPerSubnet,
 	flags.MaxConcurrentDials,
@@ -77,6 +81,8 @@ var appFlags = []cli.Flag{
 	flags.TerminalBlockHashOverride,
 	flags.TerminalBlockHashActivationEpochOverride,
 	flags.MevRelayEndpoint,
+	flags.MevRelayEndpoints,
+	flags.MevRelayTimeout,
 	flags.MaxBuilderEpochMissedSlots,
 	flags.MaxBuilderConsecutiveMissedSlots,
 	flags.EngineEndpointTimeoutSeconds,
@@ -104,6 +110,18 @@ var appFlags = []cli.Flag{
 	cmd.P2PAllowList,
 	cmd.P2PDenyList,
 	cmd.PubsubQueueSize,
+	flags.DiscoveryFile,
+	flags.DiscoveryDNS,
+	flags.DiscoveryMDNS,
+	flags.PeerQuotaWindow,
+	flags.PeerRPCQuota,
+	flags.PeerGossipQuota,
+	flags.TrustedPeerRPCQuota,
+	flags.TrustedPeerGossipQuota,
+	flags.GossipTraceDir,
+	flags.GossipTracePayloads,
+	flags.GossipTraceMaxFileSize,
+	flags.GossipTraceMaxFiles,
 	cmd.DataDirFlag,
 	cmd.VerbosityFlag,
 	cmd.EnableTracingFlag,
@@ -141,6 +159,7 @@ var appFlags = []cli.Flag{
 	genesis.StatePath,
 	genesis.BeaconAPIURL,
 	flags.SlasherDirFlag,
+	flags.SlasherBackfill,
 	flags.JwtId,
 	storage.BlobStoragePathFlag,
 	storage.BlobRetentionEpochFlag,
@@ -148,6 +167,7 @@ var appFlags = []cli.Flag{
 	bflags.BackfillBatchSize,
 	bflags.BackfillWorkerCount,
 	bflags.BackfillOldestSlot,
+	bflags.BackfillEraDir,
 }
 
 func init() {
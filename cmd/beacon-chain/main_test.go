@@ -0,0 +1,21 @@
+package main
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestAppFlags_Apply(t *testing.T) {
+	// appFlags are wrapped for config file support in init, which panics on unsupported flag types.
+	set := flag.NewFlagSet("beacon-chain", flag.ContinueOnError)
+	for _, f := range appFlags {
+		require.NoError(t, f.Apply(set))
+	}
+	require.NoError(t, set.Parse([]string{"--" + flags.StateDiffExponents.Name, "20,10"}))
+	ctx := cli.NewContext(&cli.App{}, set, nil)
+	require.DeepEqual(t, []int{20, 10}, ctx.IntSlice(flags.StateDiffExponents.Name))
+}
@@ -40,4 +40,11 @@ var (
 		Usage: "Specifies the oldest slot that backfill should download. " +
 			"If this value is greater than current_slot - MIN_EPOCHS_FOR_BLOCK_REQUESTS, it will be ignored with a warning log.",
 	}
+	// BackfillEraDir points backfill at a directory of era files, which are used in place of peers for the
+	// batches they cover.
+	BackfillEraDir = &cli.StringFlag{
+		Name: "backfill-era-dir",
+		Usage: "Directory of era files to backfill history from instead of downloading it from peers. " +
+			"Batches not covered by the era files, or within the blob retention period, are still requested from peers.",
+	}
 )
@@ -22,6 +22,9 @@ func BeaconNodeOptions(c *cli.Context) ([]node.Option, error) {
 			uv := c.Uint64(flags.BackfillBatchSize.Name)
 			bno = append(bno, backfill.WithMinimumSlot(primitives.Slot(uv)))
 		}
+		if c.IsSet(flags.BackfillEraDir.Name) {
+			bno = append(bno, backfill.WithEraDir(c.String(flags.BackfillEraDir.Name)))
+		}
 		node.BackfillOpts = bno
 		return nil
 	}
@@ -111,6 +111,9 @@ var appHelpFlagGroups = []flagGroup{
 			flags.ExecutionJWTSecretFlag,
 			flags.SetGCPercent,
 			flags.SlotsPerArchivedPoint,
+			flags.EnableStateDiff,
+			flags.StateDiffExponents,
+			flags.PruneMigratedArchivedStates,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
 			flags.BlobBatchLimit,
@@ -121,14 +124,18 @@ var appHelpFlagGroups = []flagGroup{
 			flags.ChainID,
 			flags.NetworkID,
 			flags.WeakSubjectivityCheckpoint,
+			flags.ForkchoiceRecordingFile,
 			flags.Eth1HeaderReqLimit,
 			flags.MinPeersPerSubnet,
 			flags.MaxConcurrentDials,
 			flags.MevRelayEndpoint,
+			flags.MevRelayEndpoints,
+			flags.MevRelayTimeout,
 			flags.MaxBuilderEpochMissedSlots,
 			flags.MaxBuilderConsecutiveMissedSlots,
 			flags.EngineEndpointTimeoutSeconds,
 			flags.SlasherDirFlag,
+			flags.SlasherBackfill,
 			flags.LocalBlockValueBoost,
 			flags.MinBuilderBid,
 			flags.MinBuilderDiff,
@@ -144,6 +151,7 @@ var appHelpFlagGroups = []flagGroup{
 			backfill.BackfillWorkerCount,
 			backfill.BackfillBatchSize,
 			backfill.BackfillOldestSlot,
+			backfill.BackfillEraDir,
 		},
 	},
 	{
@@ -168,6 +176,18 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.P2PAllowList,
 			cmd.P2PDenyList,
 			cmd.PubsubQueueSize,
+			flags.DiscoveryFile,
+			flags.DiscoveryDNS,
+			flags.DiscoveryMDNS,
+			flags.PeerQuotaWindow,
+			flags.PeerRPCQuota,
+			flags.PeerGossipQuota,
+			flags.TrustedPeerRPCQuota,
+			flags.TrustedPeerGossipQuota,
+			flags.GossipTraceDir,
+			flags.GossipTracePayloads,
+			flags.GossipTraceMaxFileSize,
+			flags.GossipTraceMaxFiles,
 			cmd.StaticPeers,
 			cmd.EnableUPnPFlag,
 			flags.MinSyncPeers,
@@ -0,0 +1,162 @@
+package db
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/era"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var eraFlags = struct {
+	Path       string
+	EraDir     string
+	ConfigName string
+	StartEra   uint64
+	EndEra     uint64
+}{}
+
+var (
+	eraPathFlag = &cli.StringFlag{
+		Name:        "path",
+		Usage:       "path to directory containing beaconchain.db",
+		Destination: &eraFlags.Path,
+		Required:    true,
+	}
+	eraDirFlag = &cli.StringFlag{
+		Name:        "era-dir",
+		Usage:       "directory holding the era files",
+		Destination: &eraFlags.EraDir,
+		Required:    true,
+	}
+	eraConfigNameFlag = &cli.StringFlag{
+		Name:        "config-name",
+		Usage:       "name of the network the db belongs to, used to name and select era files",
+		Destination: &eraFlags.ConfigName,
+		Value:       params.MainnetName,
+	}
+)
+
+var eraExportCmd = &cli.Command{
+	Name:  "era-export",
+	Usage: "export the finalized history of a beacon db to era files",
+	Action: func(cliCtx *cli.Context) error {
+		if err := eraExportAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not export era files")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		eraPathFlag,
+		eraDirFlag,
+		eraConfigNameFlag,
+		cmd.ChainConfigFileFlag,
+		&cli.Uint64Flag{
+			Name:        "start-era",
+			Usage:       "first era to export",
+			Destination: &eraFlags.StartEra,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-era",
+			Usage:       "last era to export, (default highest finalized era)",
+			Destination: &eraFlags.EndEra,
+			Value:       math.MaxUint64,
+		},
+	},
+}
+
+var eraImportCmd = &cli.Command{
+	Name:  "era-import",
+	Usage: "import era files into a beacon db",
+	Action: func(cliCtx *cli.Context) error {
+		if err := eraImportAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not import era files")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		eraPathFlag,
+		eraDirFlag,
+		eraConfigNameFlag,
+		cmd.ChainConfigFileFlag,
+	},
+}
+
+func setEraConfig(cliCtx *cli.Context) error {
+	if cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		return params.LoadChainConfigFile(cliCtx.String(cmd.ChainConfigFileFlag.Name), nil)
+	}
+	cfg, err := params.ByName(eraFlags.ConfigName)
+	if err != nil {
+		return fmt.Errorf("unable to find config using name %s: %w", eraFlags.ConfigName, err)
+	}
+	return params.SetActive(cfg.Copy())
+}
+
+func eraExportAction(cliCtx *cli.Context) error {
+	if err := setEraConfig(cliCtx); err != nil {
+		return err
+	}
+	ctx := cliCtx.Context
+	d, err := kv.NewKVStore(ctx, eraFlags.Path)
+	if err != nil {
+		return errors.Wrapf(err, "could not open db at path %s", eraFlags.Path)
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close db")
+		}
+	}()
+	e, err := era.NewExporter(d, eraFlags.EraDir)
+	if err != nil {
+		return err
+	}
+	end := eraFlags.EndEra
+	if end == math.MaxUint64 {
+		if end, err = e.MaxEra(ctx); err != nil {
+			return err
+		}
+	}
+	if eraFlags.StartEra > end {
+		return fmt.Errorf("start era %d is after end era %d", eraFlags.StartEra, end)
+	}
+	paths, err := e.Export(ctx, eraFlags.StartEra, end)
+	if err != nil {
+		return err
+	}
+	log.WithField("files", len(paths)).WithField("dir", eraFlags.EraDir).Info("Export complete")
+	return nil
+}
+
+func eraImportAction(cliCtx *cli.Context) error {
+	if err := setEraConfig(cliCtx); err != nil {
+		return err
+	}
+	ctx := cliCtx.Context
+	files, err := era.ListFiles(eraFlags.EraDir, params.BeaconConfig().ConfigName)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no %s era files found in %s", params.BeaconConfig().ConfigName, eraFlags.EraDir)
+	}
+	d, err := kv.NewKVStore(ctx, eraFlags.Path)
+	if err != nil {
+		return errors.Wrapf(err, "could not open db at path %s", eraFlags.Path)
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close db")
+		}
+	}()
+	if err := era.NewImporter(d).Import(ctx, files); err != nil {
+		return err
+	}
+	log.WithField("files", len(files)).Info("Import complete")
+	return nil
+}
@@ -0,0 +1,13 @@
+package forkchoice
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "forkchoice",
+		Usage: "commands to investigate the decisions of fork choice",
+		Subcommands: []*cli.Command{
+			replayCmd,
+		},
+	},
+}
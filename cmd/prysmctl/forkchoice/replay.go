@@ -0,0 +1,205 @@
+package forkchoice
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/recorder"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var replayFlags = struct {
+	Recording string
+	AllEvents bool
+}{}
+
+var replayCmd = &cli.Command{
+	Name: "replay",
+	Usage: "Replay a fork choice recording written by a beacon node started with --forkchoice-recording-file, " +
+		"printing the head changes, weights and reorg decisions of fork choice along the way.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := cliActionReplay(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not replay fork choice recording")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "recording",
+			Usage:       "path to the fork choice recording file",
+			Destination: &replayFlags.Recording,
+			Required:    true,
+		},
+		&cli.BoolFlag{
+			Name:        "all-events",
+			Usage:       "print every replayed event, instead of only blocks, head changes, reorg decisions and errors",
+			Destination: &replayFlags.AllEvents,
+		},
+	},
+}
+
+// replaySummary counts the notable steps of a replay.
+type replaySummary struct {
+	events      int
+	headChanges int
+	reorgs      int
+	divergences int
+	errors      int
+}
+
+func cliActionReplay(_ *cli.Context) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	path, err := file.ExpandPath(replayFlags.Recording)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path) // #nosec G304 -- the path is provided by the user.
+	if err != nil {
+		return errors.Wrapf(err, "could not open fork choice recording %s", path)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close fork choice recording")
+		}
+	}()
+
+	summary, err := replayRecording(ctx, bufio.NewReader(f), replayFlags.AllEvents)
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		"events":      summary.events,
+		"headChanges": summary.headChanges,
+		"reorgs":      summary.reorgs,
+		"divergences": summary.divergences,
+		"errors":      summary.errors,
+	}).Info("Replayed fork choice recording")
+	return nil
+}
+
+// replayRecording replays the recording read from r and logs its steps.
+func replayRecording(ctx context.Context, r io.Reader, allEvents bool) (*replaySummary, error) {
+	summary := &replaySummary{}
+	var genesisTime uint64
+	err := recorder.Replay(ctx, recorder.NewReader(r), func(s *recorder.Step) error {
+		summary.events++
+		e := s.Event
+		if e.Kind == forkchoicetypes.EventAnchor {
+			genesisTime = e.Snapshot.GenesisTime
+		}
+		fields := stepFields(s, genesisTime)
+		switch {
+		case s.Err != nil:
+			summary.errors++
+			log.WithFields(fields).WithError(s.Err).Error("Fork choice could not apply event")
+		case s.Diverged:
+			summary.divergences++
+			log.WithFields(fields).WithField("recorded", recordedDecision(e)).Warn("Replayed decision differs from the recorded one")
+		case s.Reorg:
+			summary.headChanges++
+			summary.reorgs++
+			log.WithFields(fields).Warn("Head reorged")
+		case s.HeadChanged():
+			summary.headChanges++
+			log.WithFields(fields).Info("Head changed")
+		case allEvents || isNotable(e.Kind):
+			log.WithFields(fields).Info("Replayed event")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// isNotable returns whether events of this kind are printed by default.
+func isNotable(kind forkchoicetypes.EventKind) bool {
+	switch kind {
+	case forkchoicetypes.EventAnchor, forkchoicetypes.EventBlock, forkchoicetypes.EventChainBlock,
+		forkchoicetypes.EventPayloadInvalid, forkchoicetypes.EventOverrideFCU, forkchoicetypes.EventProposerHead:
+		return true
+	default:
+		return false
+	}
+}
+
+func stepFields(s *recorder.Step, genesisTime uint64) log.Fields {
+	e := s.Event
+	fields := log.Fields{
+		"step":              s.Index,
+		"event":             e.Kind,
+		"eventTime":         time.UnixMilli(e.TimeMillis).UTC().Format(time.RFC3339Nano),
+		"head":              fmt.Sprintf("%#x", bytesutil.Trunc(s.Head[:])),
+		"headSlot":          s.HeadSlot,
+		"headWeight":        s.HeadWeight,
+		"justifiedEpoch":    s.Justified.Epoch,
+		"finalizedEpoch":    s.Finalized.Epoch,
+		"shouldOverrideFCU": s.ShouldOverrideFCU,
+		"proposerHead":      fmt.Sprintf("%#x", bytesutil.Trunc(s.ProposerHead[:])),
+		"tips":              formatTips(s.Tips),
+	}
+	if genesisTime != 0 {
+		sinceGenesis := time.Duration(e.TimeMillis-int64(genesisTime)*1000) * time.Millisecond // lint:ignore uintcast -- Genesis time will not exceed int64 in your lifetime.
+		slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+		if sinceGenesis >= 0 {
+			fields["currentSlot"] = uint64(sinceGenesis / slotDuration)
+			fields["sinceSlotStart"] = sinceGenesis % slotDuration
+		}
+	}
+	if s.HeadChanged() {
+		fields["previousHead"] = fmt.Sprintf("%#x", bytesutil.Trunc(s.PreviousHead[:]))
+	}
+	if s.ProposerBoostRoot != [32]byte{} {
+		fields["proposerBoostRoot"] = fmt.Sprintf("%#x", bytesutil.Trunc(s.ProposerBoostRoot[:]))
+	}
+	switch e.Kind {
+	case forkchoicetypes.EventBlock, forkchoicetypes.EventChainBlock:
+		fields["blockRoot"] = fmt.Sprintf("%#x", bytesutil.Trunc(e.Root))
+		fields["blockSlot"] = e.Slot
+		fields["parentRoot"] = fmt.Sprintf("%#x", bytesutil.Trunc(e.ParentRoot))
+	case forkchoicetypes.EventAttestation:
+		fields["blockRoot"] = fmt.Sprintf("%#x", bytesutil.Trunc(e.Root))
+		fields["targetEpoch"] = e.TargetEpoch
+		fields["validators"] = len(e.Indices)
+	case forkchoicetypes.EventSlashing:
+		fields["validators"] = e.Indices
+	case forkchoicetypes.EventTick:
+		fields["slot"] = e.Slot
+	case forkchoicetypes.EventPayloadValid, forkchoicetypes.EventPayloadInvalid:
+		fields["blockRoot"] = fmt.Sprintf("%#x", bytesutil.Trunc(e.Root))
+	}
+	return fields
+}
+
+// recordedDecision formats the decision recorded by a head, override_fcu or proposer_head event.
+func recordedDecision(e *forkchoicetypes.Event) string {
+	if e.Kind == forkchoicetypes.EventOverrideFCU {
+		return fmt.Sprintf("%t", e.Override)
+	}
+	return fmt.Sprintf("%#x", bytesutil.Trunc(e.Root))
+}
+
+// formatTips formats the leaves of fork choice as root@slot=weight.
+func formatTips(tips []recorder.Tip) string {
+	formatted := make([]string, len(tips))
+	for i, t := range tips {
+		formatted[i] = fmt.Sprintf("%#x@%d=%d", bytesutil.Trunc(t.Root[:]), t.Slot, t.Weight)
+	}
+	return strings.Join(formatted, ",")
+}
@@ -0,0 +1,62 @@
+package forkchoice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestReplayRecording(t *testing.T) {
+	genesisTime := uint64(1_000_000)
+	slotStart := func(slot uint64) int64 {
+		return int64((genesisTime + slot*params.BeaconConfig().SecondsPerSlot) * 1000)
+	}
+	root := func(b byte) []byte {
+		r := make([]byte, 32)
+		r[0] = b
+		return r
+	}
+	cp := &dbval.ForkchoiceCheckpoint{Root: root('g')}
+	recordedCp := &forkchoicetypes.RecordedCheckpoint{Root: root('g')}
+	block := func(r byte, millis int64) *forkchoicetypes.Event {
+		return &forkchoicetypes.Event{Kind: forkchoicetypes.EventBlock, TimeMillis: slotStart(1) + millis, Slot: 1,
+			Root: root(r), ParentRoot: root('g'), PayloadHash: root(r), Justified: recordedCp, Finalized: recordedCp,
+			UnrealizedJustified: recordedCp, UnrealizedFinalized: recordedCp}
+	}
+	events := []*forkchoicetypes.Event{
+		{Kind: forkchoicetypes.EventAnchor, TimeMillis: slotStart(0), Snapshot: &dbval.ForkchoiceSnapshot{
+			JustifiedCheckpoint: cp, UnrealizedJustifiedCheckpoint: cp, UnrealizedFinalizedCheckpoint: cp,
+			PreviousJustifiedCheckpoint: cp, FinalizedCheckpoint: cp, GenesisTime: genesisTime, HeadRoot: root('g'),
+			Nodes: []*dbval.ForkchoiceNode{{Root: root('g'), PayloadHash: root('g'), Timestamp: genesisTime}}, JustifiedBalances: []uint64{10, 10, 10},
+		}},
+		block('a', 1000),
+		{Kind: forkchoicetypes.EventHead, TimeMillis: slotStart(1) + 2000, Root: root('a')},
+		block('b', 3000),
+		{Kind: forkchoicetypes.EventAttestation, TimeMillis: slotStart(1) + 5000, Root: root('b'), Indices: []uint64{0, 1}},
+		{Kind: forkchoicetypes.EventHead, TimeMillis: slotStart(1) + 5000, Root: root('b')},
+		{Kind: forkchoicetypes.EventProposerHead, TimeMillis: slotStart(1) + 6000, Root: root('a')},
+		{Kind: forkchoicetypes.EventPayloadValid, TimeMillis: slotStart(1) + 6000, Root: root('c')},
+	}
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	for _, e := range events {
+		require.NoError(t, enc.Encode(e))
+	}
+
+	summary, err := replayRecording(context.Background(), buf, false)
+	require.NoError(t, err)
+	assert.DeepEqual(t, &replaySummary{
+		events:      len(events),
+		headChanges: 2,
+		reorgs:      1,
+		divergences: 1,
+		errors:      1,
+	}, summary)
+}
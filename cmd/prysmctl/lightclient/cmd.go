@@ -0,0 +1,14 @@
+package lightclient
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:    "lightclient",
+		Aliases: []string{"lc"},
+		Usage:   "commands for running a light client against a beacon node",
+		Subcommands: []*cli.Command{
+			followCmd,
+		},
+	},
+}
@@ -0,0 +1,97 @@
+package lightclient
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/api/client/lightclient"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var followFlags = struct {
+	BeaconNodeHost   string
+	TrustedBlockRoot string
+	Timeout          time.Duration
+}{}
+
+var followCmd = &cli.Command{
+	Name:  "follow",
+	Usage: "Follow the head of the chain from a trusted block root, verifying every light client update served by the beacon node.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := cliActionFollow(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not follow the chain")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port for beacon node to query",
+			Destination: &followFlags.BeaconNodeHost,
+			Value:       "http://localhost:3500",
+		},
+		&cli.StringFlag{
+			Name:        "trusted-block-root",
+			Usage:       "0x-prefixed root of a trusted block, typically a recent finalized checkpoint block, to bootstrap the light client from",
+			Destination: &followFlags.TrustedBlockRoot,
+			Required:    true,
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to beacon-node-url (uses duration format, ex: 2m31s). default: 2m",
+			Destination: &followFlags.Timeout,
+			Value:       time.Minute * 2,
+		},
+	},
+}
+
+func cliActionFollow(_ *cli.Context) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	f := followFlags
+
+	root, err := bytesutil.DecodeHexWithLength(f.TrustedBlockRoot, fieldparams.RootLength)
+	if err != nil {
+		return errors.Wrapf(err, "invalid trusted block root %s", f.TrustedBlockRoot)
+	}
+	opts := []client.ClientOpt{client.WithTimeout(f.Timeout)}
+	c, err := beacon.NewClient(f.BeaconNodeHost, opts...)
+	if err != nil {
+		return err
+	}
+	follower, err := lightclient.NewFollower(ctx, c, bytesutil.ToBytes32(root))
+	if err != nil {
+		return err
+	}
+	log.WithField("trustedBlockRoot", f.TrustedBlockRoot).Info("Light client bootstrapped")
+	follower.Follow(ctx, func(finalized, optimistic *lightclient.Header) {
+		logHeader("Verified finalized header", finalized)
+		logHeader("Verified optimistic header", optimistic)
+	})
+	return nil
+}
+
+func logHeader(msg string, h *lightclient.Header) {
+	fields := log.Fields{
+		"slot":          h.Beacon.Slot,
+		"proposerIndex": h.Beacon.ProposerIndex,
+	}
+	if root, err := h.Beacon.HashTreeRoot(); err == nil {
+		fields["blockRoot"] = hexutil.Encode(root[:])
+	}
+	if h.Execution != nil {
+		fields["executionBlockHash"] = hexutil.Encode(h.Execution.BlockHash())
+		fields["executionBlockNumber"] = h.Execution.BlockNumber()
+	}
+	log.WithFields(fields).Info(msg)
+}
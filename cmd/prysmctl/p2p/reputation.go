@@ -0,0 +1,166 @@
+package p2p
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	apiclient "github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/urfave/cli/v2"
+)
+
+var peersFlags = struct {
+	BeaconNodeHost string
+	Timeout        time.Duration
+	PeerID         string
+	Duration       time.Duration
+	Reason         string
+	BannedOnly     bool
+}{}
+
+var (
+	beaconNodeHostFlag = &cli.StringFlag{
+		Name:        "beacon-node-host",
+		Usage:       "host:port for beacon node to query",
+		Destination: &peersFlags.BeaconNodeHost,
+		Value:       "http://localhost:3500",
+	}
+	httpTimeoutFlag = &cli.DurationFlag{
+		Name:        "http-timeout",
+		Usage:       "timeout for http requests made to beacon-node-url (uses duration format, ex: 2m31s). default: 2m",
+		Destination: &peersFlags.Timeout,
+		Value:       time.Minute * 2,
+	}
+	peerIDFlag = &cli.StringFlag{
+		Name:        "peer-id",
+		Usage:       "id of the peer, as shown by the peers list command",
+		Destination: &peersFlags.PeerID,
+		Required:    true,
+	}
+)
+
+var peersCmd = &cli.Command{
+	Name:  "peers",
+	Usage: "commands for managing the reputation of the peers of a running beacon node",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "List the peers known to the beacon node with their scores and bans, including peers restored from its peer database",
+			Action: func(cliCtx *cli.Context) error {
+				if err := cliActionListPeers(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not list peers")
+				}
+				return nil
+			},
+			Flags: []cli.Flag{
+				beaconNodeHostFlag,
+				httpTimeoutFlag,
+				&cli.BoolFlag{
+					Name:        "banned",
+					Usage:       "only list peers that are currently banned",
+					Destination: &peersFlags.BannedOnly,
+				},
+			},
+		},
+		{
+			Name:  "ban",
+			Usage: "Ban a peer and disconnect from it, the ban persists across restarts of the beacon node",
+			Action: func(cliCtx *cli.Context) error {
+				if err := cliActionBanPeer(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not ban peer")
+				}
+				return nil
+			},
+			Flags: []cli.Flag{
+				beaconNodeHostFlag,
+				httpTimeoutFlag,
+				peerIDFlag,
+				&cli.DurationFlag{
+					Name:        "duration",
+					Usage:       "how long the peer is banned for (uses duration format, ex: 24h). Bans indefinitely if not set",
+					Destination: &peersFlags.Duration,
+				},
+				&cli.StringFlag{
+					Name:        "reason",
+					Usage:       "reason recorded in the ban history of the peer",
+					Destination: &peersFlags.Reason,
+				},
+			},
+		},
+		{
+			Name:  "unban",
+			Usage: "Lift the ban of a peer",
+			Action: func(cliCtx *cli.Context) error {
+				if err := cliActionUnbanPeer(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not unban peer")
+				}
+				return nil
+			},
+			Flags: []cli.Flag{
+				beaconNodeHostFlag,
+				httpTimeoutFlag,
+				peerIDFlag,
+			},
+		},
+	},
+}
+
+func peersClient() (*beacon.Client, error) {
+	return beacon.NewClient(peersFlags.BeaconNodeHost, apiclient.WithTimeout(peersFlags.Timeout))
+}
+
+func cliActionListPeers(cliCtx *cli.Context) error {
+	c, err := peersClient()
+	if err != nil {
+		return err
+	}
+	reputations, err := c.GetPeerReputations(cliCtx.Context)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "PEER ID\tSTATE\tSCORE\tBAD RESPONSES\tLAST SEEN\tBANNED\tADDRESS")
+	for _, r := range reputations {
+		if peersFlags.BannedOnly && !r.Banned {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\t%s\n",
+			r.PeerId, r.State, r.Score, r.BadResponses, formatUnix(r.LastSeen), r.Banned, r.LastSeenP2PAddress)
+	}
+	return w.Flush()
+}
+
+func cliActionBanPeer(cliCtx *cli.Context) error {
+	c, err := peersClient()
+	if err != nil {
+		return err
+	}
+	if err := c.BanPeer(cliCtx.Context, peersFlags.PeerID, peersFlags.Duration, peersFlags.Reason); err != nil {
+		return err
+	}
+	log.WithField("peerID", peersFlags.PeerID).Info("Banned peer")
+	return nil
+}
+
+func cliActionUnbanPeer(cliCtx *cli.Context) error {
+	c, err := peersClient()
+	if err != nil {
+		return err
+	}
+	if err := c.UnbanPeer(cliCtx.Context, peersFlags.PeerID); err != nil {
+		return err
+	}
+	log.WithField("peerID", peersFlags.PeerID).Info("Lifted ban of peer")
+	return nil
+}
+
+func formatUnix(s string) string {
+	secs, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || secs == 0 {
+		return "never"
+	}
+	return time.Unix(secs, 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,137 @@
+package p2p
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/gossiptrace"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/urfave/cli/v2"
+)
+
+var traceFlags = struct {
+	Dir       string
+	Topic     string
+	PerSubnet bool
+	TopPeers  int
+	StartSlot uint64
+	EndSlot   uint64
+}{}
+
+var traceCmd = &cli.Command{
+	Name:  "trace",
+	Usage: "Analyze the gossip trace files recorded by a beacon node with --gossip-trace-dir, showing propagation latency per topic and per peer",
+	Action: func(cliCtx *cli.Context) error {
+		if err := cliActionTrace(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not analyze gossip trace")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "dir",
+			Usage:       "directory of the gossip trace files",
+			Destination: &traceFlags.Dir,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "topic",
+			Usage:       "only analyze the topics containing this string, ex: beacon_block",
+			Destination: &traceFlags.Topic,
+		},
+		&cli.BoolFlag{
+			Name:        "per-subnet",
+			Usage:       "report the subnets of attestation, sync committee and blob sidecar topics separately",
+			Destination: &traceFlags.PerSubnet,
+		},
+		&cli.IntFlag{
+			Name:        "top-peers",
+			Usage:       "number of peers shown, the peers that were first to send us the most messages are shown first",
+			Destination: &traceFlags.TopPeers,
+			Value:       20,
+		},
+		&cli.Uint64Flag{
+			Name:        "start-slot",
+			Usage:       "only analyze the messages received at or after this slot",
+			Destination: &traceFlags.StartSlot,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-slot",
+			Usage:       "only analyze the messages received at or before this slot",
+			Destination: &traceFlags.EndSlot,
+		},
+	},
+}
+
+func cliActionTrace(_ *cli.Context) error {
+	f := traceFlags
+	files, err := gossiptrace.Files(f.Dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errors.Errorf("no gossip trace files in %s", f.Dir)
+	}
+	a := gossiptrace.NewAnalyzer(!f.PerSubnet)
+	for _, file := range files {
+		if err := gossiptrace.ReadFile(file, func(r *gossiptrace.Record) error {
+			if f.Topic != "" && !strings.Contains(r.Topic, f.Topic) {
+				return nil
+			}
+			if r.Slot < primitives.Slot(f.StartSlot) || (f.EndSlot != 0 && r.Slot > primitives.Slot(f.EndSlot)) {
+				return nil
+			}
+			a.Add(r)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return printAnalysis(os.Stdout, a.Result(), f.TopPeers)
+}
+
+func printAnalysis(out io.Writer, res *gossiptrace.Analysis, topPeers int) error {
+	_, _ = fmt.Fprintf(out, "%d messages received from %s to %s\n\n", res.Records, res.From.UTC().Format(time.RFC3339), res.To.UTC().Format(time.RFC3339))
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TOPIC\tDELIVERED\tREJECTED\tIGNORED\tDUPLICATE\tP50\tP90\tP99\tMAX")
+	for _, t := range res.Topics {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%s\n", t.Topic, t.Delivered, t.Rejected, t.Ignored, t.Duplicate, formatLatency(t.Latency))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(out, "\nLatencies are measured from the start of the slot to the first arrival of each message.")
+
+	_, _ = fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "PEER\tFIRST\tREJECTED\tIGNORED\tDUPLICATE\tP50\tP90\tP99\tMAX\tLAG P50\tLAG P90\tLAG P99\tLAG MAX")
+	for i, p := range res.Peers {
+		if topPeers > 0 && i >= topPeers {
+			break
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%s\t%s\n", p.Peer, p.First, p.Rejected, p.Ignored, p.Duplicate, formatLatency(p.Latency), formatLatency(p.Lag))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(out, "\nLatencies are measured from the start of the slot to every arrival from the peer, lags from the first arrival of the same message from any peer.")
+	return nil
+}
+
+func formatLatency(l gossiptrace.LatencyStats) string {
+	if l.Count == 0 {
+		return "-\t-\t-\t-"
+	}
+	return strings.Join([]string{
+		l.P50.Round(time.Millisecond).String(),
+		l.P90.Round(time.Millisecond).String(),
+		l.P99.Round(time.Millisecond).String(),
+		l.Max.Round(time.Millisecond).String(),
+	}, "\t")
+}
@@ -0,0 +1,159 @@
+package slasher
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/slasherkv"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var backfillFlags = struct {
+	DataDir        string
+	SlasherDataDir string
+	ConfigName     string
+	StartEpoch     uint64
+	EndEpoch       uint64
+}{}
+
+var backfillCmd = &cli.Command{
+	Name: "backfill",
+	Usage: "backfill the slasher database from the blocks of the beacon database while the beacon node is stopped, " +
+		"resuming after the last backfilled epoch",
+	Action: func(cliCtx *cli.Context) error {
+		if err := backfillAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not backfill slasher database")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "datadir",
+			Usage:       "data directory of the beacon node",
+			Destination: &backfillFlags.DataDir,
+			Value:       cmd.DefaultDataDir(),
+		},
+		&cli.StringFlag{
+			Name:        "slasher-datadir",
+			Usage:       "directory of the slasher database, (default the data directory of the beacon node)",
+			Destination: &backfillFlags.SlasherDataDir,
+		},
+		&cli.StringFlag{
+			Name:        "config-name",
+			Usage:       "name of the network the databases belong to",
+			Destination: &backfillFlags.ConfigName,
+			Value:       params.MainnetName,
+		},
+		cmd.ChainConfigFileFlag,
+		&cli.Uint64Flag{
+			Name:        "start-epoch",
+			Usage:       "first epoch to backfill, (default after the last backfilled epoch, within the slasher history length)",
+			Destination: &backfillFlags.StartEpoch,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-epoch",
+			Usage:       "last epoch to backfill, (default the last epoch before the finalized checkpoint)",
+			Destination: &backfillFlags.EndEpoch,
+		},
+	},
+}
+
+func setBackfillConfig(cliCtx *cli.Context) error {
+	if cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		return params.LoadChainConfigFile(cliCtx.String(cmd.ChainConfigFileFlag.Name), nil)
+	}
+	cfg, err := params.ByName(backfillFlags.ConfigName)
+	if err != nil {
+		return fmt.Errorf("unable to find config using name %s: %w", backfillFlags.ConfigName, err)
+	}
+	return params.SetActive(cfg.Copy())
+}
+
+func backfillAction(cliCtx *cli.Context) error {
+	if err := setBackfillConfig(cliCtx); err != nil {
+		return err
+	}
+	// Interrupting the backfill lets it save its progress, so that the next run resumes from it.
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	beaconDBPath := filepath.Join(backfillFlags.DataDir, kv.BeaconNodeDbDirName)
+	beaconDB, err := kv.NewKVStore(ctx, beaconDBPath)
+	if err != nil {
+		return errors.Wrapf(err, "could not open beacon db at path %s", beaconDBPath)
+	}
+	defer func() {
+		if err := beaconDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close beacon db")
+		}
+	}()
+
+	slasherDataDir := backfillFlags.SlasherDataDir
+	if slasherDataDir == "" {
+		slasherDataDir = backfillFlags.DataDir
+	}
+	slasherDBPath := filepath.Join(slasherDataDir, kv.BeaconNodeDbDirName)
+	slasherDB, err := slasherkv.NewKVStore(ctx, slasherDBPath)
+	if err != nil {
+		return errors.Wrapf(err, "could not open slasher db at path %s", slasherDBPath)
+	}
+	defer func() {
+		if err := slasherDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close slasher db")
+		}
+	}()
+
+	result, err := slasher.Backfill(ctx, &slasher.BackfillConfig{
+		BeaconDatabase:  beaconDB,
+		SlasherDatabase: slasherDB,
+		StateFetcher:    stategen.New(beaconDB, doublylinkedtree.New()),
+		StartEpoch:      primitives.Epoch(backfillFlags.StartEpoch),
+		EndEpoch:        primitives.Epoch(backfillFlags.EndEpoch),
+	})
+	if errors.Is(err, context.Canceled) {
+		log.Warn("Backfill interrupted, run the command again to resume it")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if result.StartEpoch > result.EndEpoch {
+		log.WithField("endEpoch", result.EndEpoch).Info("Slasher database is already backfilled")
+		return nil
+	}
+	for _, slashing := range result.AttesterSlashings {
+		log.WithFields(log.Fields{
+			"validatorIndices": slashing.FirstAttestation().GetAttestingIndices(),
+			"firstTarget":      slashing.FirstAttestation().GetData().Target.Epoch,
+			"secondTarget":     slashing.SecondAttestation().GetData().Target.Epoch,
+		}).Warn("Attester slashing detected")
+	}
+	for _, slashing := range result.ProposerSlashings {
+		log.WithFields(log.Fields{
+			"proposerIndex": slashing.Header_1.Header.ProposerIndex,
+			"slot":          slashing.Header_1.Header.Slot,
+		}).Warn("Proposer slashing detected")
+	}
+	log.WithFields(log.Fields{
+		"startEpoch":        result.StartEpoch,
+		"endEpoch":          result.EndEpoch,
+		"blocks":            result.Blocks,
+		"attestations":      result.Attestations,
+		"attesterSlashings": len(result.AttesterSlashings),
+		"proposerSlashings": len(result.ProposerSlashings),
+	}).Info("Backfill complete")
+	return nil
+}
@@ -70,6 +70,105 @@ var (
 		Aliases: []string{"t"},
 		Usage:   "keymanager API bearer token, note: currently required but may be removed in the future, this is the same token as the web ui token.",
 	}
+
+	SlashingProtectionInputsFlag = &cli.StringSliceFlag{
+		Name:    "input",
+		Aliases: []string{"i"},
+		Usage:   "path to an EIP-3076 slashing protection JSON file to merge, can be repeated",
+	}
+
+	SlashingProtectionOutputFlag = &cli.StringFlag{
+		Name:    "output",
+		Aliases: []string{"o"},
+		Usage:   "path to write the merged EIP-3076 slashing protection JSON file to",
+	}
+
+	SourcePublicKeyFlag = &cli.StringFlag{
+		Name:  "source-public-key",
+		Usage: "hex encoded public key of the validator consolidated into the target validator",
+	}
+
+	TargetPublicKeyFlag = &cli.StringFlag{
+		Name:  "target-public-key",
+		Usage: "hex encoded public key of the validator receiving the balance of the source validator",
+	}
+
+	ValidatorPublicKeyFlag = &cli.StringFlag{
+		Name:  "public-key",
+		Usage: "hex encoded public key of the validator to withdraw from",
+	}
+
+	WithdrawalAmountFlag = &cli.Uint64Flag{
+		Name:  "amount-gwei",
+		Usage: "amount in Gwei to partially withdraw, only for validators with compounding withdrawal credentials",
+	}
+
+	FullExitFlag = &cli.BoolFlag{
+		Name:  "full-exit",
+		Usage: "requests the full exit of the validator instead of a partial withdrawal",
+	}
+
+	SourceAddressFlag = &cli.StringFlag{
+		Name:  "source-address",
+		Usage: "expected withdrawal address of the validator, the command fails if the withdrawal credentials do not match it",
+	}
+
+	StateIDFlag = &cli.StringFlag{
+		Name:  "state-id",
+		Usage: "state to check the request against, as accepted by the beacon API (head, finalized, a slot or a state root)",
+		Value: "head",
+	}
+
+	ExecutionEndpointFlag = &cli.StringFlag{
+		Name:  "execution-endpoint",
+		Usage: "http endpoint of an execution node used to read the current fee of the system contract",
+	}
+
+	ContractAddressFlag = &cli.StringFlag{
+		Name:  "contract-address",
+		Usage: "overrides the address of the system contract receiving the request, for devnets",
+	}
+
+	ThresholdKeystoresFlag = &cli.StringSliceFlag{
+		Name:  "keystores",
+		Usage: "paths to the EIP-2335 keystores of the validator keys to split into shares",
+	}
+
+	ThresholdKeystoresPasswordFileFlag = &cli.StringFlag{
+		Name:  "keystores-password-file",
+		Usage: "path to a file with the password of the keystores",
+	}
+
+	ThresholdFlag = &cli.Uint64Flag{
+		Name:  "threshold",
+		Usage: "number of members of the group needed to sign on behalf of the validators",
+	}
+
+	ThresholdMemberURLsFlag = &cli.StringSliceFlag{
+		Name:  "member-urls",
+		Usage: "https urls the members of the group are reached at, the member of share index i at position i",
+	}
+
+	ThresholdWalletPasswordFilesFlag = &cli.StringSliceFlag{
+		Name: "wallet-password-files",
+		Usage: "paths to files with the wallet password of each member, in the order of --member-urls, " +
+			"the shares of a member being encrypted with it. A single file is used for every member",
+	}
+
+	ThresholdGenesisValidatorsRootFlag = &cli.StringFlag{
+		Name:  "genesis-validators-root",
+		Usage: "hex encoded genesis validators root of the network",
+	}
+
+	ThresholdOutputDirFlag = &cli.StringFlag{
+		Name:  "output-dir",
+		Usage: "directory to write the threshold.json file of each member to, in a member-<index> directory",
+	}
+
+	SimulateFlag = &cli.BoolFlag{
+		Name:  "simulate",
+		Usage: "processes the request on top of the state to report its expected queue position and completion epoch",
+	}
 )
 
 var Commands = []*cli.Command{
@@ -166,6 +265,14 @@ var Commands = []*cli.Command{
 					flags.ExitAllFlag,
 					flags.ForceExitFlag,
 					flags.VoluntaryExitJSONOutputPathFlag,
+					flags.ToExitVaultFlag,
+					flags.FromExitVaultFlag,
+					flags.ExitVaultPathFlag,
+					flags.ExitVaultPassphraseFileFlag,
+					flags.OverwriteExitVaultFlag,
+					flags.ExitEpochFlag,
+					cmd.DataDirFlag,
+					features.EnableMinimalSlashingProtection,
 					features.Mainnet,
 					features.SepoliaTestnet,
 					features.HoleskyTestnet,
@@ -190,6 +297,104 @@ var Commands = []*cli.Command{
 					return nil
 				},
 			},
+			{
+				Name: "consolidate",
+				Usage: "Checks an EIP-7251 consolidation request against the beacon state and prints the transaction " +
+					"to send from the withdrawal address of the source validator.",
+				Flags: []cli.Flag{
+					BeaconHostFlag,
+					SourcePublicKeyFlag,
+					TargetPublicKeyFlag,
+					SourceAddressFlag,
+					StateIDFlag,
+					ExecutionEndpointFlag,
+					ContractAddressFlag,
+					SimulateFlag,
+					cmd.ConfigFileFlag,
+				},
+				Before: func(cliCtx *cli.Context) error {
+					return cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+				},
+				Action: func(cliCtx *cli.Context) error {
+					if err := consolidate(cliCtx); err != nil {
+						log.WithError(err).Fatal("Could not build consolidation request")
+					}
+					return nil
+				},
+			},
+			{
+				Name: "el-withdraw",
+				Usage: "Checks an EIP-7002 execution layer triggered withdrawal request against the beacon state and prints " +
+					"the transaction to send from the withdrawal address of the validator.",
+				Flags: []cli.Flag{
+					BeaconHostFlag,
+					ValidatorPublicKeyFlag,
+					WithdrawalAmountFlag,
+					FullExitFlag,
+					SourceAddressFlag,
+					StateIDFlag,
+					ExecutionEndpointFlag,
+					ContractAddressFlag,
+					SimulateFlag,
+					cmd.ConfigFileFlag,
+				},
+				Before: func(cliCtx *cli.Context) error {
+					return cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+				},
+				Action: func(cliCtx *cli.Context) error {
+					if err := elWithdraw(cliCtx); err != nil {
+						log.WithError(err).Fatal("Could not build withdrawal request")
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "threshold",
+				Usage: "Commands to set up threshold wallets.",
+				Subcommands: []*cli.Command{
+					{
+						Name: "split",
+						Usage: "Splits the validator keys of EIP-2335 keystores into shares and writes the threshold.json " +
+							"file of each member of the group, to copy to the threshold directory of its wallet.",
+						Flags: []cli.Flag{
+							ThresholdKeystoresFlag,
+							ThresholdKeystoresPasswordFileFlag,
+							ThresholdFlag,
+							ThresholdMemberURLsFlag,
+							ThresholdWalletPasswordFilesFlag,
+							ThresholdGenesisValidatorsRootFlag,
+							ThresholdOutputDirFlag,
+						},
+						Action: func(cliCtx *cli.Context) error {
+							if err := splitThresholdKeys(cliCtx); err != nil {
+								log.WithError(err).Fatal("Could not split validator keys")
+							}
+							return nil
+						},
+					},
+				},
+			},
+			{
+				Name:  "slashing-protection",
+				Usage: "Commands to manage EIP-3076 slashing protection files.",
+				Subcommands: []*cli.Command{
+					{
+						Name: "merge",
+						Usage: "Merges several EIP-3076 slashing protection files into one, keeping for each key " +
+							"the highest signed proposal slot and attestation source and target epochs.",
+						Flags: []cli.Flag{
+							SlashingProtectionInputsFlag,
+							SlashingProtectionOutputFlag,
+						},
+						Action: func(cliCtx *cli.Context) error {
+							if err := mergeSlashingProtection(cliCtx); err != nil {
+								log.WithError(err).Fatal("Could not merge slashing protection files")
+							}
+							return nil
+						},
+					},
+				},
+			},
 		},
 	},
 }
@@ -0,0 +1,179 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/electra"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// consolidationSimulation is the outcome of a consolidation request processed on top of a state.
+type consolidationSimulation struct {
+	// QueuePosition is the number of pending consolidations ahead of the request.
+	QueuePosition int
+	// ExitEpoch and WithdrawableEpoch of the source validator.
+	ExitEpoch         primitives.Epoch
+	WithdrawableEpoch primitives.Epoch
+	// CompletionEpoch is the epoch from which the balance of the source validator is moved to the target validator.
+	CompletionEpoch primitives.Epoch
+}
+
+func consolidate(c *cli.Context) error {
+	ctx, span := trace.StartSpan(c.Context, "validator.consolidate")
+	defer span.End()
+
+	sourcePublicKey, err := publicKeyFromFlag(c, SourcePublicKeyFlag)
+	if err != nil {
+		return err
+	}
+	targetPublicKey, err := publicKeyFromFlag(c, TargetPublicKeyFlag)
+	if err != nil {
+		return err
+	}
+	contract, err := contractAddress(c, DefaultConsolidationRequestContract)
+	if err != nil {
+		return err
+	}
+	st, err := fetchElectraState(ctx, c.String(BeaconHostFlag.Name), c.String(StateIDFlag.Name))
+	if err != nil {
+		return err
+	}
+	_, source, err := validatorByPublicKey(st, sourcePublicKey)
+	if err != nil {
+		return err
+	}
+	address, err := sourceAddress(c, source)
+	if err != nil {
+		return err
+	}
+	req := &enginev1.ConsolidationRequest{
+		SourceAddress: address,
+		SourcePubkey:  sourcePublicKey,
+		TargetPubkey:  targetPublicKey,
+	}
+	if err := checkConsolidationEligibility(st, req); err != nil {
+		return errors.Wrap(err, "consolidation request would be ignored")
+	}
+	log.WithField("slot", st.Slot()).Info("Consolidation request is eligible against the state")
+
+	if c.Bool(SimulateFlag.Name) {
+		sim, err := simulateConsolidation(ctx, st, req)
+		if err != nil {
+			return err
+		}
+		log.WithFields(log.Fields{
+			"queuePosition":     sim.QueuePosition,
+			"exitEpoch":         sim.ExitEpoch,
+			"withdrawableEpoch": sim.WithdrawableEpoch,
+			"completionEpoch":   sim.CompletionEpoch,
+		}).Info("Expected outcome if the request is included on top of the state")
+	}
+	return writeRequestTransaction(c, address, contract, consolidationRequestCalldata(sourcePublicKey, targetPublicKey))
+}
+
+// checkConsolidationEligibility returns why the consolidation request would be ignored when
+// processed on top of the state, following process_consolidation_request.
+func checkConsolidationEligibility(st state.ReadOnlyBeaconState, req *enginev1.ConsolidationRequest) error {
+	numPending, err := st.NumPendingConsolidations()
+	if err != nil {
+		return err
+	}
+	if numPending >= params.BeaconConfig().PendingConsolidationsLimit {
+		return fmt.Errorf("pending consolidations queue is full (%d)", numPending)
+	}
+	activeBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return err
+	}
+	churnLimit := helpers.ConsolidationChurnLimit(primitives.Gwei(activeBalance))
+	if churnLimit <= primitives.Gwei(params.BeaconConfig().MinActivationBalance) {
+		return fmt.Errorf("consolidation churn limit %d Gwei is not above the minimum activation balance", churnLimit)
+	}
+
+	sourceIdx, source, err := validatorByPublicKey(st, req.SourcePubkey)
+	if err != nil {
+		return err
+	}
+	targetIdx, target, err := validatorByPublicKey(st, req.TargetPubkey)
+	if err != nil {
+		return err
+	}
+	if sourceIdx == targetIdx {
+		return errors.New("source and target validators are the same, a consolidation cannot be used as an exit")
+	}
+	if !helpers.HasExecutionWithdrawalCredentials(source) {
+		return fmt.Errorf("source validator %d does not have execution withdrawal credentials", sourceIdx)
+	}
+	if !bytes.Equal(source.WithdrawalCredentials[12:], req.SourceAddress) {
+		return fmt.Errorf("source validator %d has withdrawal address %#x, not %#x", sourceIdx, source.WithdrawalCredentials[12:], req.SourceAddress)
+	}
+	if !helpers.HasExecutionWithdrawalCredentials(target) {
+		return fmt.Errorf("target validator %d does not have execution withdrawal credentials", targetIdx)
+	}
+	currentEpoch := slots.ToEpoch(st.Slot())
+	if !helpers.IsActiveValidator(source, currentEpoch) {
+		return fmt.Errorf("source validator %d is not active", sourceIdx)
+	}
+	if !helpers.IsActiveValidator(target, currentEpoch) {
+		return fmt.Errorf("target validator %d is not active", targetIdx)
+	}
+	if source.ExitEpoch != params.BeaconConfig().FarFutureEpoch {
+		return fmt.Errorf("source validator %d is already exiting at epoch %d", sourceIdx, source.ExitEpoch)
+	}
+	if target.ExitEpoch != params.BeaconConfig().FarFutureEpoch {
+		return fmt.Errorf("target validator %d is already exiting at epoch %d", targetIdx, target.ExitEpoch)
+	}
+	return nil
+}
+
+// simulateConsolidation processes the consolidation request on a copy of the state.
+func simulateConsolidation(ctx context.Context, st state.BeaconState, req *enginev1.ConsolidationRequest) (*consolidationSimulation, error) {
+	pending, err := st.PendingConsolidations()
+	if err != nil {
+		return nil, err
+	}
+	post := st.Copy()
+	if err := electra.ProcessConsolidationRequests(ctx, post, []*enginev1.ConsolidationRequest{req}); err != nil {
+		return nil, errors.Wrap(err, "could not process consolidation request")
+	}
+	postPending, err := post.PendingConsolidations()
+	if err != nil {
+		return nil, err
+	}
+	if len(postPending) != len(pending)+1 {
+		return nil, errors.New("consolidation request was ignored by the state transition")
+	}
+	sourceIdx := postPending[len(postPending)-1].SourceIndex
+	source, err := post.ValidatorAtIndexReadOnly(sourceIdx)
+	if err != nil {
+		return nil, err
+	}
+	sim := &consolidationSimulation{
+		QueuePosition:     len(pending),
+		ExitEpoch:         source.ExitEpoch(),
+		WithdrawableEpoch: source.WithdrawableEpoch(),
+		CompletionEpoch:   source.WithdrawableEpoch(),
+	}
+	// Pending consolidations are processed in order, so the request also waits for the ones ahead of it.
+	for _, pc := range pending {
+		v, err := post.ValidatorAtIndexReadOnly(pc.SourceIndex)
+		if err != nil {
+			return nil, err
+		}
+		if !v.Slashed() && v.WithdrawableEpoch() > sim.CompletionEpoch {
+			sim.CompletionEpoch = v.WithdrawableEpoch()
+		}
+	}
+	return sim, nil
+}
@@ -0,0 +1,132 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func consolidationRequest(t *testing.T, st state.BeaconState) *enginev1.ConsolidationRequest {
+	address := setWithdrawalCredentials(t, st, 0, params.BeaconConfig().ETH1AddressWithdrawalPrefixByte)
+	setWithdrawalCredentials(t, st, 1, params.BeaconConfig().CompoundingWithdrawalPrefixByte)
+	source, err := st.ValidatorAtIndex(0)
+	require.NoError(t, err)
+	target, err := st.ValidatorAtIndex(1)
+	require.NoError(t, err)
+	return &enginev1.ConsolidationRequest{
+		SourceAddress: address,
+		SourcePubkey:  source.PublicKey,
+		TargetPubkey:  target.PublicKey,
+	}
+}
+
+func TestCheckConsolidationEligibility(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*testing.T, state.BeaconState, *enginev1.ConsolidationRequest)
+		wantErr string
+	}{
+		{
+			name: "eligible",
+		},
+		{
+			name: "source is target",
+			modify: func(_ *testing.T, _ state.BeaconState, req *enginev1.ConsolidationRequest) {
+				req.TargetPubkey = req.SourcePubkey
+			},
+			wantErr: "source and target validators are the same",
+		},
+		{
+			name: "unknown target",
+			modify: func(_ *testing.T, _ state.BeaconState, req *enginev1.ConsolidationRequest) {
+				req.TargetPubkey = make([]byte, 48)
+			},
+			wantErr: "is not in the state",
+		},
+		{
+			name: "target with BLS credentials",
+			modify: func(t *testing.T, st state.BeaconState, _ *enginev1.ConsolidationRequest) {
+				setWithdrawalCredentials(t, st, 1, params.BeaconConfig().BLSWithdrawalPrefixByte)
+			},
+			wantErr: "target validator 1 does not have execution withdrawal credentials",
+		},
+		{
+			name: "wrong source address",
+			modify: func(_ *testing.T, _ state.BeaconState, req *enginev1.ConsolidationRequest) {
+				req.SourceAddress = make([]byte, 20)
+			},
+			wantErr: "source validator 0 has withdrawal address",
+		},
+		{
+			name: "source exiting",
+			modify: func(t *testing.T, st state.BeaconState, _ *enginev1.ConsolidationRequest) {
+				v, err := st.ValidatorAtIndex(0)
+				require.NoError(t, err)
+				v.ExitEpoch = slots.ToEpoch(st.Slot()) + 10
+				require.NoError(t, st.UpdateValidatorAtIndex(0, v))
+			},
+			wantErr: "source validator 0 is already exiting",
+		},
+		{
+			name: "churn limit too low",
+			modify: func(*testing.T, state.BeaconState, *enginev1.ConsolidationRequest) {
+				cfg := params.BeaconConfig().Copy()
+				cfg.MinPerEpochChurnLimitElectra = cfg.MaxPerEpochActivationExitChurnLimit
+				params.OverrideBeaconConfig(cfg)
+			},
+			wantErr: "consolidation churn limit 0 Gwei",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := electraRequestsState(t)
+			req := consolidationRequest(t, st)
+			if tt.modify != nil {
+				tt.modify(t, st, req)
+			}
+			err := checkConsolidationEligibility(st, req)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSimulateConsolidation(t *testing.T) {
+	st := electraRequestsState(t)
+	req := consolidationRequest(t, st)
+
+	// A consolidation ahead in the queue from a source which becomes withdrawable later.
+	v, err := st.ValidatorAtIndex(5)
+	require.NoError(t, err)
+	v.ExitEpoch = 1000
+	v.WithdrawableEpoch = 5000
+	require.NoError(t, st.UpdateValidatorAtIndex(5, v))
+	require.NoError(t, st.AppendPendingConsolidation(&ethpb.PendingConsolidation{SourceIndex: 5, TargetIndex: 6}))
+
+	sim, err := simulateConsolidation(context.Background(), st, req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sim.QueuePosition)
+	assert.Equal(t, sim.ExitEpoch+params.BeaconConfig().MinValidatorWithdrawabilityDelay, sim.WithdrawableEpoch)
+	assert.Equal(t, true, sim.ExitEpoch > slots.ToEpoch(st.Slot()))
+	assert.Equal(t, v.WithdrawableEpoch, sim.CompletionEpoch)
+
+	// The state the request was simulated on is left untouched.
+	n, err := st.NumPendingConsolidations()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), n)
+
+	// A request ignored by the state transition is reported.
+	req.SourceAddress = make([]byte, 20)
+	_, err = simulateConsolidation(context.Background(), st, req)
+	assert.ErrorContains(t, "ignored", err)
+}
@@ -0,0 +1,182 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	// DefaultWithdrawalRequestContract is the address of the EIP-7002 withdrawal request system contract.
+	DefaultWithdrawalRequestContract = "0x00000961Ef480Eb55e80D19ad83579A64c007002"
+	// DefaultConsolidationRequestContract is the address of the EIP-7251 consolidation request system contract.
+	DefaultConsolidationRequestContract = "0x0000BBdDc7CE488642fb579F8B00f3a590007251"
+)
+
+// elRequestTransaction is the transaction to send from the withdrawal address of a validator to
+// submit an execution layer request to a system contract.
+type elRequestTransaction struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Value string `json:"value"`
+	Data  string `json:"data"`
+}
+
+// withdrawalRequestCalldata is the calldata of the EIP-7002 system contract: the public key of the
+// validator followed by the amount in Gwei as a big-endian uint64.
+func withdrawalRequestCalldata(publicKey []byte, amount uint64) []byte {
+	data := make([]byte, 0, fieldparams.BLSPubkeyLength+8)
+	data = append(data, publicKey...)
+	return binary.BigEndian.AppendUint64(data, amount)
+}
+
+// consolidationRequestCalldata is the calldata of the EIP-7251 system contract: the public key of
+// the source validator followed by the public key of the target validator.
+func consolidationRequestCalldata(sourcePublicKey, targetPublicKey []byte) []byte {
+	data := make([]byte, 0, 2*fieldparams.BLSPubkeyLength)
+	data = append(data, sourcePublicKey...)
+	return append(data, targetPublicKey...)
+}
+
+// fetchElectraState fetches the state from the beacon node, which must be at Electra or later.
+func fetchElectraState(ctx context.Context, beaconNodeHost, stateID string) (state.BeaconState, error) {
+	client, err := beacon.NewClient(beaconNodeHost)
+	if err != nil {
+		return nil, err
+	}
+	marshaled, err := client.GetState(ctx, beacon.StateOrBlockId(stateID))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get state %s", stateID)
+	}
+	unmarshaler, err := detect.FromState(marshaled)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not detect the fork of the state")
+	}
+	st, err := unmarshaler.UnmarshalBeaconState(marshaled)
+	if err != nil {
+		return nil, err
+	}
+	if st.Version() < version.Electra {
+		return nil, fmt.Errorf("state %s is at fork %s, execution layer requests require electra", stateID, version.String(st.Version()))
+	}
+	return st, nil
+}
+
+// requestFee reads the current fee of the system contract from the execution node. Without an
+// execution node, the minimum fee of 1 wei is returned: it is only enough while the contract is not
+// congested, and the transaction reverts if the fee is too low.
+func requestFee(ctx context.Context, executionEndpoint string, contract common.Address) (*big.Int, error) {
+	if executionEndpoint == "" {
+		log.Warnf("No --%s provided, using the minimum fee of 1 wei: the transaction reverts if the current fee "+
+			"of the system contract is higher", ExecutionEndpointFlag.Name)
+		return big.NewInt(1), nil
+	}
+	client, err := ethclient.DialContext(ctx, executionEndpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not dial execution node %s", executionEndpoint)
+	}
+	defer client.Close()
+	fee, err := client.CallContract(ctx, ethereum.CallMsg{To: &contract}, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read the fee of system contract %s", contract.Hex())
+	}
+	return new(big.Int).SetBytes(fee), nil
+}
+
+// contractAddress returns the address of the system contract, the default one unless overridden by flag.
+func contractAddress(c *cli.Context, defaultAddress string) (common.Address, error) {
+	address := defaultAddress
+	if c.IsSet(ContractAddressFlag.Name) {
+		address = c.String(ContractAddressFlag.Name)
+	}
+	if !common.IsHexAddress(address) {
+		return common.Address{}, fmt.Errorf("invalid system contract address %s", address)
+	}
+	return common.HexToAddress(address), nil
+}
+
+// publicKeyFromFlag decodes the validator public key provided with the flag.
+func publicKeyFromFlag(c *cli.Context, flag *cli.StringFlag) ([]byte, error) {
+	if !c.IsSet(flag.Name) {
+		return nil, fmt.Errorf("no --%s flag value was provided", flag.Name)
+	}
+	publicKey, err := bytesutil.DecodeHexWithLength(c.String(flag.Name), fieldparams.BLSPubkeyLength)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid --%s", flag.Name)
+	}
+	return publicKey, nil
+}
+
+// sourceAddress returns the address of the execution withdrawal credentials of the validator, which
+// must be the one provided with --source-address if any: only this address can submit requests for it.
+func sourceAddress(c *cli.Context, v *ethpb.Validator) ([]byte, error) {
+	if !helpers.HasExecutionWithdrawalCredentials(v) {
+		return nil, fmt.Errorf("validator %#x does not have execution withdrawal credentials (%#x), "+
+			"set a withdrawal address with `prysmctl validator withdraw` first", v.PublicKey, v.WithdrawalCredentials)
+	}
+	address := v.WithdrawalCredentials[12:]
+	if c.IsSet(SourceAddressFlag.Name) {
+		provided, err := bytesutil.DecodeHexWithLength(c.String(SourceAddressFlag.Name), common.AddressLength)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid --%s", SourceAddressFlag.Name)
+		}
+		if !bytes.Equal(provided, address) {
+			return nil, fmt.Errorf("validator %#x has withdrawal address %#x, only it can submit requests and not %#x",
+				v.PublicKey, address, provided)
+		}
+	}
+	return address, nil
+}
+
+// validatorByPublicKey returns the index and the validator with the public key in the state.
+func validatorByPublicKey(st state.ReadOnlyBeaconState, publicKey []byte) (primitives.ValidatorIndex, *ethpb.Validator, error) {
+	idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(publicKey))
+	if !ok {
+		return 0, nil, fmt.Errorf("validator %#x is not in the state", publicKey)
+	}
+	v, err := st.ValidatorAtIndex(idx)
+	if err != nil {
+		return 0, nil, err
+	}
+	return idx, v, nil
+}
+
+// writeRequestTransaction prints the transaction submitting the request to the system contract.
+func writeRequestTransaction(c *cli.Context, from []byte, contract common.Address, data []byte) error {
+	fee, err := requestFee(c.Context, c.String(ExecutionEndpointFlag.Name), contract)
+	if err != nil {
+		return err
+	}
+	tx := &elRequestTransaction{
+		From:  common.BytesToAddress(from).Hex(),
+		To:    contract.Hex(),
+		Value: hexutil.EncodeBig(fee),
+		Data:  hexutil.Encode(data),
+	}
+	b, err := json.MarshalIndent(tx, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
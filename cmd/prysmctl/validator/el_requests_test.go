@@ -0,0 +1,55 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestWithdrawalRequestCalldata(t *testing.T) {
+	publicKey := bytesutil.PadTo([]byte{0xaa}, 48)
+	data := withdrawalRequestCalldata(publicKey, 0x0102030405060708)
+	require.Equal(t, 56, len(data))
+	assert.DeepEqual(t, publicKey, data[:48])
+	assert.DeepEqual(t, []byte{1, 2, 3, 4, 5, 6, 7, 8}, data[48:])
+}
+
+func TestConsolidationRequestCalldata(t *testing.T) {
+	source := bytesutil.PadTo([]byte{0xaa}, 48)
+	target := bytesutil.PadTo([]byte{0xbb}, 48)
+	data := consolidationRequestCalldata(source, target)
+	require.Equal(t, 96, len(data))
+	assert.DeepEqual(t, source, data[:48])
+	assert.DeepEqual(t, target, data[48:])
+}
+
+// electraRequestsState returns an Electra state past the shard committee period, where the
+// consolidation churn limit allows consolidations.
+func electraRequestsState(t *testing.T) state.BeaconState {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.MinPerEpochChurnLimitElectra = 2 * cfg.MaxPerEpochActivationExitChurnLimit
+	params.OverrideBeaconConfig(cfg)
+
+	st, _ := util.DeterministicGenesisStateElectra(t, 64)
+	slot := primitives.Slot(uint64(cfg.SlotsPerEpoch) * uint64(cfg.ShardCommitteePeriod+1))
+	require.NoError(t, st.SetSlot(slot))
+	return st
+}
+
+// setWithdrawalCredentials sets execution withdrawal credentials with the prefix to the validator
+// and returns its withdrawal address.
+func setWithdrawalCredentials(t *testing.T, st state.BeaconState, idx primitives.ValidatorIndex, prefix byte) []byte {
+	v, err := st.ValidatorAtIndex(idx)
+	require.NoError(t, err)
+	address := bytesutil.PadTo([]byte{byte(idx) + 1}, 20)
+	v.WithdrawalCredentials = append(append([]byte{prefix}, make([]byte, 11)...), address...)
+	require.NoError(t, st.UpdateValidatorAtIndex(idx, v))
+	return address
+}
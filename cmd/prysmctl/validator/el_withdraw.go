@@ -0,0 +1,231 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/electra"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// withdrawalRequestSimulation is the outcome of a withdrawal request processed on top of a state.
+type withdrawalRequestSimulation struct {
+	// QueuePosition is the number of exits, or of pending partial withdrawals, ahead of the request.
+	QueuePosition int
+	// Amount in Gwei actually withdrawn, the whole balance for a full exit.
+	Amount uint64
+	// ExitEpoch of the validator, only set for a full exit.
+	ExitEpoch         primitives.Epoch
+	WithdrawableEpoch primitives.Epoch
+	// CompletionEpoch is the expected epoch from which the withdrawal is swept. For partial withdrawals it
+	// assumes every payload processes the maximum number of pending partial withdrawals.
+	CompletionEpoch primitives.Epoch
+}
+
+func elWithdraw(c *cli.Context) error {
+	ctx, span := trace.StartSpan(c.Context, "validator.elWithdraw")
+	defer span.End()
+
+	fullExit := c.Bool(FullExitFlag.Name)
+	if fullExit == c.IsSet(WithdrawalAmountFlag.Name) {
+		return fmt.Errorf("exactly one of --%s or --%s is required", FullExitFlag.Name, WithdrawalAmountFlag.Name)
+	}
+	amount := params.BeaconConfig().FullExitRequestAmount
+	if !fullExit {
+		amount = c.Uint64(WithdrawalAmountFlag.Name)
+		if amount == params.BeaconConfig().FullExitRequestAmount {
+			return fmt.Errorf("--%s must be positive, use --%s to exit the validator", WithdrawalAmountFlag.Name, FullExitFlag.Name)
+		}
+	}
+	publicKey, err := publicKeyFromFlag(c, ValidatorPublicKeyFlag)
+	if err != nil {
+		return err
+	}
+	contract, err := contractAddress(c, DefaultWithdrawalRequestContract)
+	if err != nil {
+		return err
+	}
+	st, err := fetchElectraState(ctx, c.String(BeaconHostFlag.Name), c.String(StateIDFlag.Name))
+	if err != nil {
+		return err
+	}
+	_, v, err := validatorByPublicKey(st, publicKey)
+	if err != nil {
+		return err
+	}
+	address, err := sourceAddress(c, v)
+	if err != nil {
+		return err
+	}
+	req := &enginev1.WithdrawalRequest{
+		SourceAddress:   address,
+		ValidatorPubkey: publicKey,
+		Amount:          amount,
+	}
+	if err := checkWithdrawalRequestEligibility(st, req); err != nil {
+		return errors.Wrap(err, "withdrawal request would be ignored")
+	}
+	log.WithField("slot", st.Slot()).Info("Withdrawal request is eligible against the state")
+
+	if c.Bool(SimulateFlag.Name) {
+		sim, err := simulateWithdrawalRequest(ctx, st, req)
+		if err != nil {
+			return err
+		}
+		fields := log.Fields{
+			"queuePosition":     sim.QueuePosition,
+			"amountGwei":        sim.Amount,
+			"withdrawableEpoch": sim.WithdrawableEpoch,
+			"completionEpoch":   sim.CompletionEpoch,
+		}
+		if fullExit {
+			fields["exitEpoch"] = sim.ExitEpoch
+		}
+		log.WithFields(fields).Info("Expected outcome if the request is included on top of the state")
+	}
+	return writeRequestTransaction(c, address, contract, withdrawalRequestCalldata(publicKey, amount))
+}
+
+// checkWithdrawalRequestEligibility returns why the withdrawal request would be ignored when
+// processed on top of the state, following process_withdrawal_request.
+func checkWithdrawalRequestEligibility(st state.ReadOnlyBeaconState, req *enginev1.WithdrawalRequest) error {
+	cfg := params.BeaconConfig()
+	isFullExit := req.Amount == cfg.FullExitRequestAmount
+	if !isFullExit {
+		numPending, err := st.NumPendingPartialWithdrawals()
+		if err != nil {
+			return err
+		}
+		if numPending >= cfg.PendingPartialWithdrawalsLimit {
+			return fmt.Errorf("pending partial withdrawals queue is full (%d)", numPending)
+		}
+	}
+	idx, v, err := validatorByPublicKey(st, req.ValidatorPubkey)
+	if err != nil {
+		return err
+	}
+	if !helpers.HasExecutionWithdrawalCredentials(v) {
+		return fmt.Errorf("validator %d does not have execution withdrawal credentials", idx)
+	}
+	if !bytes.Equal(v.WithdrawalCredentials[12:], req.SourceAddress) {
+		return fmt.Errorf("validator %d has withdrawal address %#x, not %#x", idx, v.WithdrawalCredentials[12:], req.SourceAddress)
+	}
+	currentEpoch := slots.ToEpoch(st.Slot())
+	if !helpers.IsActiveValidator(v, currentEpoch) {
+		return fmt.Errorf("validator %d is not active", idx)
+	}
+	if v.ExitEpoch != cfg.FarFutureEpoch {
+		return fmt.Errorf("validator %d is already exiting at epoch %d", idx, v.ExitEpoch)
+	}
+	if eligible := v.ActivationEpoch.AddEpoch(cfg.ShardCommitteePeriod); currentEpoch < eligible {
+		return fmt.Errorf("validator %d has not been active long enough, requests are processed from epoch %d", idx, eligible)
+	}
+	pendingBalanceToWithdraw, err := st.PendingBalanceToWithdraw(idx)
+	if err != nil {
+		return err
+	}
+	if isFullExit {
+		if pendingBalanceToWithdraw != 0 {
+			return fmt.Errorf("validator %d has %d Gwei of pending partial withdrawals, it cannot exit before they are processed",
+				idx, pendingBalanceToWithdraw)
+		}
+		return nil
+	}
+	if !helpers.HasCompoundingWithdrawalCredential(v) {
+		return fmt.Errorf("validator %d does not have compounding withdrawal credentials, only full exits are allowed", idx)
+	}
+	if v.EffectiveBalance < cfg.MinActivationBalance {
+		return fmt.Errorf("validator %d has an effective balance of %d Gwei, below the minimum activation balance", idx, v.EffectiveBalance)
+	}
+	balance, err := st.BalanceAtIndex(idx)
+	if err != nil {
+		return err
+	}
+	if balance <= cfg.MinActivationBalance+pendingBalanceToWithdraw {
+		return fmt.Errorf("validator %d has no balance above the minimum activation balance left to withdraw", idx)
+	}
+	return nil
+}
+
+// simulateWithdrawalRequest processes the withdrawal request on a copy of the state.
+func simulateWithdrawalRequest(ctx context.Context, st state.BeaconState, req *enginev1.WithdrawalRequest) (*withdrawalRequestSimulation, error) {
+	cfg := params.BeaconConfig()
+	idx, _, err := validatorByPublicKey(st, req.ValidatorPubkey)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := st.PendingPartialWithdrawals()
+	if err != nil {
+		return nil, err
+	}
+	post, err := electra.ProcessWithdrawalRequests(ctx, st.Copy(), []*enginev1.WithdrawalRequest{req})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process withdrawal request")
+	}
+
+	if req.Amount == cfg.FullExitRequestAmount {
+		v, err := post.ValidatorAtIndexReadOnly(idx)
+		if err != nil {
+			return nil, err
+		}
+		if v.ExitEpoch() == cfg.FarFutureEpoch {
+			return nil, errors.New("withdrawal request was ignored by the state transition")
+		}
+		balance, err := post.BalanceAtIndex(idx)
+		if err != nil {
+			return nil, err
+		}
+		// Exits are not queued in a list, the validators ahead are the ones that have yet to exit.
+		currentEpoch := slots.ToEpoch(st.Slot())
+		position := 0
+		if err := st.ReadFromEveryValidator(func(_ int, val state.ReadOnlyValidator) error {
+			if val.ExitEpoch() != cfg.FarFutureEpoch && val.ExitEpoch() > currentEpoch {
+				position++
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		return &withdrawalRequestSimulation{
+			QueuePosition:     position,
+			Amount:            balance,
+			ExitEpoch:         v.ExitEpoch(),
+			WithdrawableEpoch: v.WithdrawableEpoch(),
+			CompletionEpoch:   v.WithdrawableEpoch(),
+		}, nil
+	}
+
+	postPending, err := post.PendingPartialWithdrawals()
+	if err != nil {
+		return nil, err
+	}
+	if len(postPending) != len(pending)+1 {
+		return nil, errors.New("withdrawal request was ignored by the state transition")
+	}
+	w := postPending[len(postPending)-1]
+	sim := &withdrawalRequestSimulation{
+		QueuePosition:     len(pending),
+		Amount:            w.Amount,
+		WithdrawableEpoch: w.WithdrawableEpoch,
+		CompletionEpoch:   w.WithdrawableEpoch,
+	}
+	// Pending partial withdrawals are processed in order, so the request also waits for the ones ahead of it.
+	for _, p := range pending {
+		if p.WithdrawableEpoch > sim.CompletionEpoch {
+			sim.CompletionEpoch = p.WithdrawableEpoch
+		}
+	}
+	perEpoch := cfg.MaxPendingPartialsPerWithdrawalsSweep * uint64(cfg.SlotsPerEpoch)
+	sim.CompletionEpoch = sim.CompletionEpoch.Add(uint64(len(pending)) / perEpoch)
+	return sim, nil
+}
@@ -0,0 +1,144 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+const excessBalance = 5_000_000_000
+
+// withdrawalRequest returns a partial withdrawal request for a compounding validator with an excess balance.
+func withdrawalRequest(t *testing.T, st state.BeaconState) *enginev1.WithdrawalRequest {
+	address := setWithdrawalCredentials(t, st, 0, params.BeaconConfig().CompoundingWithdrawalPrefixByte)
+	require.NoError(t, st.UpdateBalancesAtIndex(0, params.BeaconConfig().MinActivationBalance+excessBalance))
+	v, err := st.ValidatorAtIndex(0)
+	require.NoError(t, err)
+	return &enginev1.WithdrawalRequest{
+		SourceAddress:   address,
+		ValidatorPubkey: v.PublicKey,
+		Amount:          excessBalance,
+	}
+}
+
+func TestCheckWithdrawalRequestEligibility(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*testing.T, state.BeaconState, *enginev1.WithdrawalRequest)
+		wantErr string
+	}{
+		{
+			name: "partial withdrawal",
+		},
+		{
+			name: "full exit",
+			modify: func(_ *testing.T, _ state.BeaconState, req *enginev1.WithdrawalRequest) {
+				req.Amount = params.BeaconConfig().FullExitRequestAmount
+			},
+		},
+		{
+			name: "full exit with pending partial withdrawals",
+			modify: func(t *testing.T, st state.BeaconState, req *enginev1.WithdrawalRequest) {
+				req.Amount = params.BeaconConfig().FullExitRequestAmount
+				require.NoError(t, st.AppendPendingPartialWithdrawal(&ethpb.PendingPartialWithdrawal{Index: 0, Amount: 1}))
+			},
+			wantErr: "cannot exit before they are processed",
+		},
+		{
+			name: "partial withdrawal without compounding credentials",
+			modify: func(t *testing.T, st state.BeaconState, req *enginev1.WithdrawalRequest) {
+				req.SourceAddress = setWithdrawalCredentials(t, st, 0, params.BeaconConfig().ETH1AddressWithdrawalPrefixByte)
+			},
+			wantErr: "does not have compounding withdrawal credentials",
+		},
+		{
+			name: "no excess balance",
+			modify: func(t *testing.T, st state.BeaconState, _ *enginev1.WithdrawalRequest) {
+				require.NoError(t, st.UpdateBalancesAtIndex(0, params.BeaconConfig().MinActivationBalance))
+			},
+			wantErr: "no balance above the minimum activation balance",
+		},
+		{
+			name: "wrong source address",
+			modify: func(_ *testing.T, _ state.BeaconState, req *enginev1.WithdrawalRequest) {
+				req.SourceAddress = make([]byte, 20)
+			},
+			wantErr: "validator 0 has withdrawal address",
+		},
+		{
+			name: "not active long enough",
+			modify: func(t *testing.T, st state.BeaconState, _ *enginev1.WithdrawalRequest) {
+				require.NoError(t, st.SetSlot(params.BeaconConfig().SlotsPerEpoch))
+			},
+			wantErr: "has not been active long enough",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := electraRequestsState(t)
+			req := withdrawalRequest(t, st)
+			if tt.modify != nil {
+				tt.modify(t, st, req)
+			}
+			err := checkWithdrawalRequestEligibility(st, req)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSimulateWithdrawalRequest_Partial(t *testing.T) {
+	st := electraRequestsState(t)
+	req := withdrawalRequest(t, st)
+	req.Amount = 2 * excessBalance
+	require.NoError(t, st.AppendPendingPartialWithdrawal(&ethpb.PendingPartialWithdrawal{Index: 3, Amount: 1, WithdrawableEpoch: 9000}))
+
+	sim, err := simulateWithdrawalRequest(context.Background(), st, req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sim.QueuePosition)
+	// Only the excess balance is withdrawn.
+	assert.Equal(t, uint64(excessBalance), sim.Amount)
+	assert.Equal(t, true, sim.WithdrawableEpoch > slots.ToEpoch(st.Slot())+params.BeaconConfig().MinValidatorWithdrawabilityDelay)
+	assert.Equal(t, primitives.Epoch(9000), sim.CompletionEpoch)
+
+	n, err := st.NumPendingPartialWithdrawals()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), n)
+}
+
+func TestSimulateWithdrawalRequest_FullExit(t *testing.T) {
+	st := electraRequestsState(t)
+	req := withdrawalRequest(t, st)
+	req.Amount = params.BeaconConfig().FullExitRequestAmount
+	v, err := st.ValidatorAtIndex(7)
+	require.NoError(t, err)
+	v.ExitEpoch = slots.ToEpoch(st.Slot()) + 5
+	require.NoError(t, st.UpdateValidatorAtIndex(7, v))
+
+	sim, err := simulateWithdrawalRequest(context.Background(), st, req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sim.QueuePosition)
+	assert.Equal(t, params.BeaconConfig().MinActivationBalance+excessBalance, sim.Amount)
+	assert.Equal(t, sim.ExitEpoch+params.BeaconConfig().MinValidatorWithdrawabilityDelay, sim.WithdrawableEpoch)
+	assert.Equal(t, sim.WithdrawableEpoch, sim.CompletionEpoch)
+
+	exiting, err := st.ValidatorAtIndexReadOnly(0)
+	require.NoError(t, err)
+	assert.Equal(t, params.BeaconConfig().FarFutureEpoch, exiting.ExitEpoch())
+
+	// A validator with pending partial withdrawals is not exited.
+	require.NoError(t, st.AppendPendingPartialWithdrawal(&ethpb.PendingPartialWithdrawal{Index: 0, Amount: 1}))
+	_, err = simulateWithdrawalRequest(context.Background(), st, req)
+	assert.ErrorContains(t, "ignored", err)
+}
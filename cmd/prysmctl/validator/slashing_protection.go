@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"bufio"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	history "github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// mergeSlashingProtection merges the EIP-3076 files given as inputs into the output file.
+// The output file is only written once every input was read successfully.
+func mergeSlashingProtection(c *cli.Context) error {
+	inputPaths := c.StringSlice(SlashingProtectionInputsFlag.Name)
+	if len(inputPaths) < 2 {
+		return errors.Errorf("at least two --%s flag values are required", SlashingProtectionInputsFlag.Name)
+	}
+	if !c.IsSet(SlashingProtectionOutputFlag.Name) {
+		return errNoFlag(SlashingProtectionOutputFlag.Name)
+	}
+	outputPath, err := file.ExpandPath(c.String(SlashingProtectionOutputFlag.Name))
+	if err != nil {
+		return err
+	}
+	exists, err := file.Exists(outputPath, file.Regular)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.Errorf("output file %s already exists", outputPath)
+	}
+
+	inputs := make([]io.Reader, len(inputPaths))
+	for i, p := range inputPaths {
+		expanded, err := file.ExpandPath(p)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(expanded) // #nosec G304 -- the path is provided by the user.
+		if err != nil {
+			return errors.Wrapf(err, "could not open slashing protection file %s", p)
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				log.WithError(err).Errorf("Could not close slashing protection file %s", p)
+			}
+		}()
+		inputs[i] = bufio.NewReader(f)
+	}
+
+	tmpPath := outputPath + ".tmp"
+	out, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions)
+	if err != nil {
+		return errors.Wrapf(err, "could not create file %s", tmpPath)
+	}
+	w := bufio.NewWriter(out)
+	count, err := history.MergeStandardProtectionJSON(w, inputs...)
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		if rmErr := os.Remove(tmpPath); rmErr != nil {
+			log.WithError(rmErr).Errorf("Could not remove temporary file %s", tmpPath)
+		}
+		return err
+	}
+	if err := os.Rename(tmpPath, outputPath); err != nil {
+		return errors.Wrapf(err, "could not write file %s", outputPath)
+	}
+
+	log.WithField("validators", count).Infof("Merged %d slashing protection files into %s", len(inputPaths), outputPath)
+	return nil
+}
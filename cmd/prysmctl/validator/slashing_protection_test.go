@@ -0,0 +1,58 @@
+package validator
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
+	"github.com/urfave/cli/v2"
+)
+
+func slashingProtectionCliCtx(t *testing.T, inputs []string, output string) *cli.Context {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.Var(cli.NewStringSlice(inputs...), SlashingProtectionInputsFlag.Name, "")
+	set.String(SlashingProtectionOutputFlag.Name, output, "")
+	require.NoError(t, set.Set(SlashingProtectionOutputFlag.Name, output))
+	return cli.NewContext(&app, set, nil)
+}
+
+func TestMergeSlashingProtection(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.json")
+	second := filepath.Join(dir, "second.json")
+	require.NoError(t, os.WriteFile(first, []byte(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0xaa"},"data":[
+		{"pubkey":"0x01","signed_blocks":[{"slot":"3"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"}]}]}`), 0600))
+	require.NoError(t, os.WriteFile(second, []byte(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0xaa"},"data":[
+		{"pubkey":"0x01","signed_blocks":[{"slot":"2"}],"signed_attestations":[{"source_epoch":"2","target_epoch":"4"}]}]}`), 0600))
+	output := filepath.Join(dir, "merged.json")
+
+	require.NoError(t, mergeSlashingProtection(slashingProtectionCliCtx(t, []string{first, second}, output)))
+	enc, err := os.ReadFile(output)
+	require.NoError(t, err)
+	merged := &format.EIPSlashingProtectionFormat{}
+	require.NoError(t, json.Unmarshal(enc, merged))
+	require.Equal(t, 1, len(merged.Data))
+	assert.DeepEqual(t, []*format.SignedBlock{{Slot: "3"}}, merged.Data[0].SignedBlocks)
+	assert.DeepEqual(t, []*format.SignedAttestation{{SourceEpoch: "2", TargetEpoch: "4"}}, merged.Data[0].SignedAttestations)
+
+	// The output file is never overwritten.
+	err = mergeSlashingProtection(slashingProtectionCliCtx(t, []string{first, second}, output))
+	require.ErrorContains(t, "already exists", err)
+
+	// Files of different chains cannot be merged, and no output is written.
+	other := filepath.Join(dir, "other.json")
+	require.NoError(t, os.WriteFile(other, []byte(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0xbb"},"data":[]}`), 0600))
+	output = filepath.Join(dir, "merged-other.json")
+	err = mergeSlashingProtection(slashingProtectionCliCtx(t, []string{first, other}, output))
+	require.ErrorContains(t, "genesis validators root", err)
+	_, err = os.Stat(output)
+	assert.Equal(t, true, os.IsNotExist(err))
+	_, err = os.Stat(output + ".tmp")
+	assert.Equal(t, true, os.IsNotExist(err))
+}
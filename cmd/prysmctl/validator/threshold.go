@@ -0,0 +1,151 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/threshold"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// splitThresholdKeys splits the validator keys of EIP-2335 keystores into shares, and writes the
+// threshold.json file of each member of the group to its own directory of the output directory.
+// Nothing is written if the file of a member already exists.
+func splitThresholdKeys(c *cli.Context) error {
+	keystorePaths := c.StringSlice(ThresholdKeystoresFlag.Name)
+	if len(keystorePaths) == 0 {
+		return errNoFlag(ThresholdKeystoresFlag.Name)
+	}
+	urls := c.StringSlice(ThresholdMemberURLsFlag.Name)
+	if len(urls) < 2 {
+		return errors.Errorf("at least two --%s flag values are required", ThresholdMemberURLsFlag.Name)
+	}
+	t := c.Uint64(ThresholdFlag.Name)
+	if t < 2 || t > uint64(len(urls)) {
+		return errors.Errorf("--%s must be between 2 and the number of members, %d", ThresholdFlag.Name, len(urls))
+	}
+	gvr, err := bytesutil.DecodeHexWithLength(c.String(ThresholdGenesisValidatorsRootFlag.Name), fieldparams.RootLength)
+	if err != nil {
+		return errors.Wrapf(err, "invalid --%s", ThresholdGenesisValidatorsRootFlag.Name)
+	}
+	if !c.IsSet(ThresholdOutputDirFlag.Name) {
+		return errNoFlag(ThresholdOutputDirFlag.Name)
+	}
+	outputDir, err := file.ExpandPath(c.String(ThresholdOutputDirFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	// A single wallet password file is used for every member when only one is given.
+	passwordFiles := c.StringSlice(ThresholdWalletPasswordFilesFlag.Name)
+	switch len(passwordFiles) {
+	case 0:
+		return errNoFlag(ThresholdWalletPasswordFilesFlag.Name)
+	case 1:
+		for len(passwordFiles) < len(urls) {
+			passwordFiles = append(passwordFiles, passwordFiles[0])
+		}
+	case len(urls):
+	default:
+		return errors.Errorf("got %d --%s flag values for %d members", len(passwordFiles), ThresholdWalletPasswordFilesFlag.Name, len(urls))
+	}
+	passwords := make([]string, len(passwordFiles))
+	for i, p := range passwordFiles {
+		passwords[i], err = readPasswordFile(p)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !c.IsSet(ThresholdKeystoresPasswordFileFlag.Name) {
+		return errNoFlag(ThresholdKeystoresPasswordFileFlag.Name)
+	}
+	keystoresPassword, err := readPasswordFile(c.String(ThresholdKeystoresPasswordFileFlag.Name))
+	if err != nil {
+		return err
+	}
+	secretKeys := make([]bls.SecretKey, len(keystorePaths))
+	for i, p := range keystorePaths {
+		secretKeys[i], err = readKeystore(p, keystoresPassword)
+		if err != nil {
+			return err
+		}
+	}
+
+	configs, err := threshold.NewFileConfigs(secretKeys, t, gvr, urls, passwords)
+	if err != nil {
+		return err
+	}
+	paths := make([]string, len(configs))
+	for i := range configs {
+		paths[i] = filepath.Join(outputDir, fmt.Sprintf("member-%d", i+1), threshold.ConfigFileName)
+		exists, err := file.Exists(paths[i], file.Regular)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errors.Errorf("file %s already exists", paths[i])
+		}
+	}
+	for i, cfg := range configs {
+		enc, err := json.MarshalIndent(cfg, "", "\t")
+		if err != nil {
+			return errors.Wrap(err, "could not marshal threshold configuration")
+		}
+		if err := file.MkdirAll(filepath.Dir(paths[i])); err != nil {
+			return err
+		}
+		if err := file.WriteFile(paths[i], enc); err != nil {
+			return errors.Wrapf(err, "could not write file %s", paths[i])
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"validators": len(secretKeys),
+		"threshold":  t,
+		"members":    len(urls),
+	}).Infof("Wrote the threshold configuration of each member to %s, the listen address, TLS and auth token settings are left to fill in", outputDir)
+	return nil
+}
+
+// readKeystore decrypts the validator key of an EIP-2335 keystore file.
+func readKeystore(path, password string) (bls.SecretKey, error) {
+	enc, err := file.ReadFileAsBytes(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read keystore %s", path)
+	}
+	keystore := &keymanager.Keystore{}
+	if err := json.Unmarshal(enc, keystore); err != nil {
+		return nil, errors.Wrapf(err, "could not decode keystore %s", path)
+	}
+	secret, err := keystorev4.New().Decrypt(keystore.Crypto, password)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decrypt keystore %s", path)
+	}
+	secretKey, err := bls.SecretKeyFromBytes(secret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid secret key in keystore %s", path)
+	}
+	if keystore.Pubkey != "" && keystore.Pubkey != fmt.Sprintf("%x", secretKey.PublicKey().Marshal()) {
+		return nil, errors.Errorf("secret key of keystore %s does not match its public key", path)
+	}
+	return secretKey, nil
+}
+
+func readPasswordFile(path string) (string, error) {
+	enc, err := file.ReadFileAsBytes(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not read password file %s", path)
+	}
+	return strings.TrimRight(string(enc), "\r\n"), nil
+}
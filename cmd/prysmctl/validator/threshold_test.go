@@ -0,0 +1,91 @@
+package validator
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/threshold"
+	"github.com/urfave/cli/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+func thresholdCliCtx(t *testing.T, keystores, urls, passwordFiles []string, keystoresPasswordFile, outputDir string) *cli.Context {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.Var(cli.NewStringSlice(keystores...), ThresholdKeystoresFlag.Name, "")
+	set.Var(cli.NewStringSlice(urls...), ThresholdMemberURLsFlag.Name, "")
+	set.Var(cli.NewStringSlice(passwordFiles...), ThresholdWalletPasswordFilesFlag.Name, "")
+	set.String(ThresholdKeystoresPasswordFileFlag.Name, keystoresPasswordFile, "")
+	require.NoError(t, set.Set(ThresholdKeystoresPasswordFileFlag.Name, keystoresPasswordFile))
+	set.Uint64(ThresholdFlag.Name, 2, "")
+	set.String(ThresholdGenesisValidatorsRootFlag.Name, hexutil.Encode(make([]byte, 32)), "")
+	set.String(ThresholdOutputDirFlag.Name, outputDir, "")
+	require.NoError(t, set.Set(ThresholdOutputDirFlag.Name, outputDir))
+	return cli.NewContext(&app, set, nil)
+}
+
+func writeTestKeystore(t *testing.T, path string, sk bls.SecretKey, password string) {
+	encryptor := keystorev4.New()
+	cryptoFields, err := encryptor.Encrypt(sk.Marshal(), password)
+	require.NoError(t, err)
+	enc, err := json.Marshal(&keymanager.Keystore{
+		Crypto:  cryptoFields,
+		Pubkey:  fmt.Sprintf("%x", sk.PublicKey().Marshal()),
+		Version: encryptor.Version(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, enc, 0600))
+}
+
+func TestSplitThresholdKeys(t *testing.T) {
+	dir := t.TempDir()
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	keystorePath := filepath.Join(dir, "keystore.json")
+	writeTestKeystore(t, keystorePath, sk, "keystore")
+	keystorePasswordFile := filepath.Join(dir, "keystore-password.txt")
+	require.NoError(t, os.WriteFile(keystorePasswordFile, []byte("keystore\n"), 0600))
+	walletPasswordFile := filepath.Join(dir, "wallet-password.txt")
+	require.NoError(t, os.WriteFile(walletPasswordFile, []byte("wallet"), 0600))
+	urls := []string{"https://one:7600", "https://two:7600", "https://three:7600"}
+	outputDir := filepath.Join(dir, "out")
+
+	// The password of the keystores must be the right one.
+	err = splitThresholdKeys(thresholdCliCtx(t, []string{keystorePath}, urls, []string{walletPasswordFile}, walletPasswordFile, outputDir))
+	require.ErrorContains(t, "could not decrypt keystore", err)
+
+	require.NoError(t, splitThresholdKeys(thresholdCliCtx(t, []string{keystorePath}, urls, []string{walletPasswordFile}, keystorePasswordFile, outputDir)))
+	signingRoot := []byte("root")
+	partials := make([]bls.Signature, len(urls))
+	for i := range urls {
+		cfg, err := threshold.ReadFileConfig(filepath.Join(outputDir, fmt.Sprintf("member-%d", i+1), threshold.ConfigFileName))
+		require.NoError(t, err)
+		assert.Equal(t, 2, len(cfg.Peers))
+		require.Equal(t, 1, len(cfg.Shares))
+		share, err := cfg.Shares[0].Decrypt("wallet")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(i+1), share.Index)
+		assert.Equal(t, true, share.PublicKey.Equals(sk.PublicKey()))
+		partials[i] = share.SecretKey.Sign(signingRoot)
+	}
+	sig, err := bls.RecoverSignature([]uint64{2, 3}, partials[1:])
+	require.NoError(t, err)
+	assert.Equal(t, true, sig.Verify(sk.PublicKey(), signingRoot))
+
+	// The configuration of a member is never overwritten.
+	err = splitThresholdKeys(thresholdCliCtx(t, []string{keystorePath}, urls, []string{walletPasswordFile}, keystorePasswordFile, outputDir))
+	require.ErrorContains(t, "already exists", err)
+
+	// Either one wallet password file for all the members or one for each.
+	err = splitThresholdKeys(thresholdCliCtx(t, []string{keystorePath}, urls, []string{walletPasswordFile, walletPasswordFile}, keystorePasswordFile, filepath.Join(dir, "other")))
+	require.ErrorContains(t, "got 2 --wallet-password-files flag values for 3 members", err)
+}
@@ -0,0 +1,38 @@
+// Package flags contains all configuration runtime flags for
+// the standalone slasher.
+package flags
+
+import (
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// BeaconRESTApiProviderFlag defines the beacon API endpoints of the beacon nodes to stream from and submit slashings to.
+	BeaconRESTApiProviderFlag = &cli.StringSliceFlag{
+		Name: "beacon-rest-api-provider",
+		Usage: "Beacon node REST API provider endpoint, run with --slasher-stream. " +
+			"Repeat the flag to ingest from and submit slashings to several beacon nodes.",
+		Value: cli.NewStringSlice("http://127.0.0.1:3500"),
+	}
+	// ApiTimeoutFlag defines the timeout of the requests to the beacon nodes, other than the event streams.
+	ApiTimeoutFlag = &cli.DurationFlag{
+		Name:  "api-timeout",
+		Usage: "Timeout of the requests to the beacon nodes, other than the event streams.",
+		Value: 10 * time.Second,
+	}
+	// ConfigNameFlag defines the name of the network of the beacon nodes.
+	ConfigNameFlag = &cli.StringFlag{
+		Name:  "config-name",
+		Usage: "Name of the network of the beacon nodes, ignored if --chain-config-file is set.",
+		Value: params.MainnetName,
+	}
+	// MonitoringPortFlag defines the http port used to serve prometheus metrics.
+	MonitoringPortFlag = &cli.IntFlag{
+		Name:  "monitoring-port",
+		Usage: "Port used to listening and respond metrics for Prometheus.",
+		Value: 8082,
+	}
+)
@@ -0,0 +1,206 @@
+// Package main runs the slasher in its own process, apart from the beacon nodes it detects slashable
+// offenses for. It ingests the indexed attestations and block headers streamed by beacon nodes run with
+// --slasher-stream, and submits the slashings it detects to their slashing operations pools.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	runtimeDebug "runtime/debug"
+	"syscall"
+
+	joonix "github.com/joonix/log"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/slasherkv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/remote"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/cmd/slasher/flags"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/io/logs"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/journald"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/prometheus"
+	"github.com/prysmaticlabs/prysm/v5/runtime"
+	prefixed "github.com/prysmaticlabs/prysm/v5/runtime/logging/logrus-prefixed-formatter"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var appFlags = []cli.Flag{
+	cmd.VerbosityFlag,
+	cmd.LogFormat,
+	cmd.LogFileName,
+	cmd.ConfigFileFlag,
+	cmd.DataDirFlag,
+	cmd.ChainConfigFileFlag,
+	cmd.MonitoringHostFlag,
+	cmd.DisableMonitoringFlag,
+	flags.BeaconRESTApiProviderFlag,
+	flags.ApiTimeoutFlag,
+	flags.ConfigNameFlag,
+	flags.MonitoringPortFlag,
+}
+
+func init() {
+	appFlags = cmd.WrapFlags(appFlags)
+}
+
+func main() {
+	app := cli.App{}
+	app.Name = "slasher"
+	app.Usage = "standalone slasher detecting slashable offenses for one or more beacon nodes"
+	app.Action = run
+	app.Version = version.Version()
+
+	app.Flags = appFlags
+
+	app.Before = func(ctx *cli.Context) error {
+		// Load flags from config file, if specified.
+		if err := cmd.LoadFlagsFromConfig(ctx, app.Flags); err != nil {
+			return err
+		}
+
+		verbosity := ctx.String(cmd.VerbosityFlag.Name)
+		level, err := logrus.ParseLevel(verbosity)
+		if err != nil {
+			return err
+		}
+		logrus.SetLevel(level)
+
+		format := ctx.String(cmd.LogFormat.Name)
+		switch format {
+		case "text":
+			formatter := new(prefixed.TextFormatter)
+			formatter.TimestampFormat = "2006-01-02 15:04:05"
+			formatter.FullTimestamp = true
+			// If persistent log files are written - we disable the log messages coloring because
+			// the colors are ANSI codes and seen as gibberish in the log files.
+			formatter.DisableColors = ctx.String(cmd.LogFileName.Name) != ""
+			logrus.SetFormatter(formatter)
+		case "fluentd":
+			f := joonix.NewFormatter()
+			if err := joonix.DisableTimestampFormat(f); err != nil {
+				panic(err)
+			}
+			logrus.SetFormatter(f)
+		case "json":
+			logrus.SetFormatter(&logrus.JSONFormatter{})
+		case "journald":
+			if err := journald.Enable(); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown log format %s", format)
+		}
+
+		logFileName := ctx.String(cmd.LogFileName.Name)
+		if logFileName != "" {
+			if err := logs.ConfigurePersistentLogging(logFileName); err != nil {
+				log.WithError(err).Error("Failed to configuring logging to disk.")
+			}
+		}
+		return cmd.ValidateNoArgs(ctx)
+	}
+
+	defer func() {
+		if x := recover(); x != nil {
+			log.Errorf("Runtime panic: %v\n%v", x, string(runtimeDebug.Stack()))
+			panic(x)
+		}
+	}()
+
+	if err := app.Run(os.Args); err != nil {
+		log.Error(err.Error())
+	}
+}
+
+func setChainConfig(cliCtx *cli.Context) error {
+	if cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		return params.LoadChainConfigFile(cliCtx.String(cmd.ChainConfigFileFlag.Name), nil)
+	}
+	name := cliCtx.String(flags.ConfigNameFlag.Name)
+	cfg, err := params.ByName(name)
+	if err != nil {
+		return fmt.Errorf("unable to find config using name %s: %w", name, err)
+	}
+	return params.SetActive(cfg.Copy())
+}
+
+func run(cliCtx *cli.Context) error {
+	if err := setChainConfig(cliCtx); err != nil {
+		return err
+	}
+	ctx, cancel := signal.NotifyContext(cliCtx.Context, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	beaconNodes, err := remote.NewClient(
+		cliCtx.StringSlice(flags.BeaconRESTApiProviderFlag.Name),
+		client.WithTimeout(cliCtx.Duration(flags.ApiTimeoutFlag.Name)),
+	)
+	if err != nil {
+		return err
+	}
+
+	// The database is laid out as the one of the slasher of the beacon node, so that it can be reused.
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.BeaconNodeDbDirName)
+	slasherDB, err := slasherkv.NewKVStore(ctx, dbPath)
+	if err != nil {
+		return errors.Wrapf(err, "could not open slasher db at path %s", dbPath)
+	}
+	defer func() {
+		if err := slasherDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close slasher db")
+		}
+	}()
+
+	// The slasher is only started once the genesis of the beacon nodes is known.
+	clock, err := beaconNodes.Clock(ctx)
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	clockSynchronizer := startup.NewClockSynchronizer()
+	if err := clockSynchronizer.SetClock(clock); err != nil {
+		return err
+	}
+
+	attestationsFeed, blockHeadersFeed := new(event.Feed), new(event.Feed)
+	slasherService, err := slasher.New(ctx, &slasher.ServiceConfig{
+		IndexedAttestationsFeed: attestationsFeed,
+		BeaconBlockHeadersFeed:  blockHeadersFeed,
+		Database:                slasherDB,
+		ClockWaiter:             clockSynchronizer,
+		RemoteBeaconNode:        beaconNodes,
+	})
+	if err != nil {
+		return err
+	}
+	services := runtime.NewServiceRegistry()
+	if err := services.RegisterService(slasherService); err != nil {
+		return err
+	}
+	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
+		addr := fmt.Sprintf("%s:%d", cliCtx.String(cmd.MonitoringHostFlag.Name), cliCtx.Int(flags.MonitoringPortFlag.Name))
+		if err := services.RegisterService(prometheus.NewService(addr, services)); err != nil {
+			return err
+		}
+		logrus.AddHook(prometheus.NewLogrusCollector())
+	}
+	services.StartAll()
+	defer services.StopAll()
+
+	// Streams until interrupted.
+	beaconNodes.Stream(ctx, attestationsFeed, blockHeadersFeed)
+	log.Info("Stopping slasher")
+	return nil
+}
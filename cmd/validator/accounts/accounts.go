@@ -166,6 +166,14 @@ var Commands = &cli.Command{
 				flags.ExitAllFlag,
 				flags.ForceExitFlag,
 				flags.VoluntaryExitJSONOutputPathFlag,
+				flags.ToExitVaultFlag,
+				flags.FromExitVaultFlag,
+				flags.ExitVaultPathFlag,
+				flags.ExitVaultPassphraseFileFlag,
+				flags.OverwriteExitVaultFlag,
+				flags.ExitEpochFlag,
+				cmd.DataDirFlag,
+				features.EnableMinimalSlashingProtection,
 				features.Mainnet,
 				features.SepoliaTestnet,
 				features.HoleskyTestnet,
@@ -21,6 +21,9 @@ import (
 )
 
 func Exit(c *cli.Context, r io.Reader) error {
+	if c.Bool(flags.FromExitVaultFlag.Name) {
+		return exitFromVault(c, r)
+	}
 	var w *wallet.Wallet
 	var km keymanager.IKeymanager
 	var err error
@@ -93,6 +96,9 @@ func Exit(c *cli.Context, r io.Reader) error {
 	if len(validatingPublicKeys) == 0 {
 		return errors.New("wallet is empty, no accounts to delete")
 	}
+	if c.Bool(flags.ToExitVaultFlag.Name) {
+		return exitToVault(c, opts, validatingPublicKeys)
+	}
 	// Filter keys either from CLI flag or from interactive session.
 	rawPubKey, formattedPubKeys, err := accounts.FilterExitAccountsFromUserInput(c, r, validatingPublicKeys, c.Bool(flags.ForceExitFlag.Name))
 	if err != nil {
@@ -0,0 +1,241 @@
+package accounts
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/io/prompt"
+	"github.com/prysmaticlabs/prysm/v5/validator/accounts"
+	"github.com/prysmaticlabs/prysm/v5/validator/accounts/exitvault"
+	"github.com/prysmaticlabs/prysm/v5/validator/accounts/userprompt"
+	"github.com/prysmaticlabs/prysm/v5/validator/client"
+	"github.com/prysmaticlabs/prysm/v5/validator/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/validator/db/iface"
+	"github.com/prysmaticlabs/prysm/v5/validator/db/kv"
+	"github.com/urfave/cli/v2"
+)
+
+// exitToVault pre-signs voluntary exits for the accounts selected with --public-keys, or for all the
+// accounts of the keymanager, and adds them to the exit vault, or replaces it with --overwrite-exit-vault.
+func exitToVault(c *cli.Context, opts []accounts.Option, validatingPublicKeys [][fieldparams.BLSPubkeyLength]byte) error {
+	if c.IsSet(flags.VoluntaryExitPublicKeysFlag.Name) {
+		filteredPubKeys, err := accounts.FilterPublicKeysFromUserInput(
+			c,
+			flags.VoluntaryExitPublicKeysFlag,
+			validatingPublicKeys,
+			userprompt.SelectAccountsVoluntaryExitPromptText,
+		)
+		if err != nil {
+			return errors.Wrap(err, "could not filter public keys for voluntary exit")
+		}
+		validatingPublicKeys = make([][fieldparams.BLSPubkeyLength]byte, len(filteredPubKeys))
+		for i, pk := range filteredPubKeys {
+			validatingPublicKeys[i] = bytesutil.ToBytes48(pk.Marshal())
+		}
+	}
+	rawPubKeys, formattedPubKeys := formatPublicKeys(validatingPublicKeys)
+
+	passphrase, err := prompt.InputPassword(
+		c,
+		flags.ExitVaultPassphraseFileFlag,
+		"New exit vault passphrase",
+		"Confirm exit vault passphrase",
+		true,
+		prompt.ValidatePasswordInput,
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not read exit vault passphrase")
+	}
+	opts = append(opts,
+		accounts.WithRawPubKeys(rawPubKeys),
+		accounts.WithFormattedPubKeys(formattedPubKeys),
+		accounts.WithExitVaultPassphrase(passphrase),
+	)
+	if c.IsSet(flags.ExitEpochFlag.Name) {
+		opts = append(opts, accounts.WithExitEpoch(primitives.Epoch(c.Uint64(flags.ExitEpochFlag.Name))))
+	}
+	var existing *exitvault.Vault
+	if !c.Bool(flags.OverwriteExitVaultFlag.Name) {
+		existing, err = existingExitVault(c)
+		if err != nil {
+			return err
+		}
+	}
+	acc, err := accounts.NewCLIManager(opts...)
+	if err != nil {
+		return err
+	}
+	vault, err := acc.SignExitVault(c.Context, existing)
+	if err != nil {
+		return err
+	}
+	enc, err := vault.Encode()
+	if err != nil {
+		return errors.Wrap(err, "could not encode exit vault")
+	}
+
+	if c.IsSet(flags.ExitVaultPathFlag.Name) {
+		vaultPath := c.String(flags.ExitVaultPathFlag.Name)
+		if err := file.WriteFile(vaultPath, enc); err != nil {
+			return errors.Wrapf(err, "could not write exit vault to %s", vaultPath)
+		}
+		log.WithField("path", vaultPath).Infof("Wrote exit vault with %d voluntary exits", len(vault.PublicKeys))
+		return nil
+	}
+	validatorDB, err := openValidatorDB(c)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := validatorDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close validator DB")
+		}
+	}()
+	if err := validatorDB.SaveExitVault(c.Context, enc); err != nil {
+		return errors.Wrap(err, "could not save exit vault")
+	}
+	log.WithField("path", validatorDB.DatabasePath()).Infof("Saved exit vault with %d voluntary exits in validator database", len(vault.PublicKeys))
+	return nil
+}
+
+// exitFromVault broadcasts voluntary exits pre-signed in an exit vault, without a wallet or signer.
+func exitFromVault(c *cli.Context, r io.Reader) error {
+	vault, err := readExitVault(c)
+	if err != nil {
+		return err
+	}
+	vaultPublicKeys, err := vault.ValidatingPublicKeys()
+	if err != nil {
+		return err
+	}
+	if len(vaultPublicKeys) == 0 {
+		return errors.New("exit vault is empty, no accounts to exit")
+	}
+	// Filter keys either from CLI flag or from interactive session.
+	rawPubKeys, formattedPubKeys, err := accounts.FilterExitAccountsFromUserInput(c, r, vaultPublicKeys, c.Bool(flags.ForceExitFlag.Name))
+	if err != nil {
+		return errors.Wrap(err, "could not filter public keys for voluntary exit")
+	}
+	// User decided to cancel the voluntary exit.
+	if rawPubKeys == nil && formattedPubKeys == nil {
+		return nil
+	}
+	passphrase, err := prompt.InputPassword(
+		c,
+		flags.ExitVaultPassphraseFileFlag,
+		"Exit vault passphrase",
+		"",
+		false,
+		prompt.NotEmpty,
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not read exit vault passphrase")
+	}
+
+	acc, err := accounts.NewCLIManager(
+		accounts.WithGRPCDialOpts(client.ConstructDialOptions(
+			c.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name),
+			c.String(flags.CertFlag.Name),
+			c.Uint(flags.GRPCRetriesFlag.Name),
+			c.Duration(flags.GRPCRetryDelayFlag.Name),
+		)),
+		accounts.WithBeaconRPCProvider(c.String(flags.BeaconRPCProviderFlag.Name)),
+		accounts.WithBeaconRESTApiProvider(c.String(flags.BeaconRESTApiProviderFlag.Name)),
+		accounts.WithGRPCHeaders(strings.Split(c.String(flags.GRPCHeadersFlag.Name), ",")),
+		accounts.WithRawPubKeys(rawPubKeys),
+		accounts.WithFormattedPubKeys(formattedPubKeys),
+		accounts.WithExitVaultPassphrase(passphrase),
+	)
+	if err != nil {
+		return err
+	}
+	return acc.ExitFromVault(c.Context, vault)
+}
+
+// readExitVault reads the exit vault bundle at --exit-vault-path, or the exit vault of the validator database.
+func readExitVault(c *cli.Context) (*exitvault.Vault, error) {
+	vault, err := existingExitVault(c)
+	if err != nil {
+		return nil, err
+	}
+	if vault != nil {
+		return vault, nil
+	}
+	if c.IsSet(flags.ExitVaultPathFlag.Name) {
+		return nil, errors.Errorf("no exit vault at %s", c.String(flags.ExitVaultPathFlag.Name))
+	}
+	return nil, errors.Errorf("no exit vault in validator database at %s", c.String(cmd.DataDirFlag.Name))
+}
+
+// existingExitVault reads the exit vault bundle at --exit-vault-path, or the exit vault of the validator
+// database. It returns nil if there is no exit vault.
+func existingExitVault(c *cli.Context) (*exitvault.Vault, error) {
+	if c.IsSet(flags.ExitVaultPathFlag.Name) {
+		vaultPath := c.String(flags.ExitVaultPathFlag.Name)
+		exists, err := file.Exists(vaultPath, file.Regular)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not check for exit vault at %s", vaultPath)
+		}
+		if !exists {
+			return nil, nil
+		}
+		enc, err := file.ReadFileAsBytes(vaultPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read exit vault at %s", vaultPath)
+		}
+		return exitvault.Decode(enc)
+	}
+	validatorDB, err := openValidatorDB(c)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := validatorDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close validator DB")
+		}
+	}()
+	enc, err := validatorDB.ExitVault(c.Context)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get exit vault")
+	}
+	if enc == nil {
+		return nil, nil
+	}
+	return exitvault.Decode(enc)
+}
+
+// openValidatorDB opens the validator database in --datadir, the minimal one if
+// minimal slashing protection is enabled.
+func openValidatorDB(c *cli.Context) (iface.ValidatorDB, error) {
+	dataDir := c.String(cmd.DataDirFlag.Name)
+	var validatorDB iface.ValidatorDB
+	var err error
+	if c.Bool(features.EnableMinimalSlashingProtection.Name) {
+		validatorDB, err = filesystem.NewStore(dataDir, nil)
+	} else {
+		validatorDB, err = kv.NewKVStore(c.Context, dataDir, nil)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not access validator database at path %s", dataDir)
+	}
+	return validatorDB, nil
+}
+
+func formatPublicKeys(publicKeys [][fieldparams.BLSPubkeyLength]byte) (raw [][]byte, formatted []string) {
+	raw = make([][]byte, len(publicKeys))
+	formatted = make([]string, len(publicKeys))
+	for i, pk := range publicKeys {
+		raw[i] = bytesutil.SafeCopyBytes(pk[:])
+		formatted[i] = fmt.Sprintf("%#x", bytesutil.Trunc(pk[:]))
+	}
+	return raw, formatted
+}
@@ -0,0 +1,79 @@
+package accounts
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/accounts/exitvault"
+	"github.com/urfave/cli/v2"
+)
+
+func exitVaultCtx(t *testing.T, dataDir, vaultPath string, minimal bool) *cli.Context {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, dataDir, "")
+	set.Bool(features.EnableMinimalSlashingProtection.Name, minimal, "")
+	if vaultPath != "" {
+		set.String(flags.ExitVaultPathFlag.Name, vaultPath, "")
+		require.NoError(t, set.Set(flags.ExitVaultPathFlag.Name, vaultPath))
+	}
+	return cli.NewContext(&app, set, nil)
+}
+
+func TestReadExitVault(t *testing.T) {
+	vault, err := exitvault.Seal([]*exitvault.Exit{{
+		PublicKey: bytesutil.ToBytes48([]byte{1}),
+		SignedExit: &ethpb.SignedVoluntaryExit{
+			Exit:      &ethpb.VoluntaryExit{Epoch: 1, ValidatorIndex: 2},
+			Signature: make([]byte, 96),
+		},
+	}}, make([]byte, 32), "passphrase")
+	require.NoError(t, err)
+	enc, err := vault.Encode()
+	require.NoError(t, err)
+
+	t.Run("bundle file", func(t *testing.T) {
+		vaultPath := filepath.Join(t.TempDir(), "vault.json")
+		c := exitVaultCtx(t, t.TempDir(), vaultPath, false)
+		existing, err := existingExitVault(c)
+		require.NoError(t, err)
+		assert.Equal(t, true, existing == nil)
+		_, err = readExitVault(c)
+		assert.ErrorContains(t, "no exit vault at "+vaultPath, err)
+
+		require.NoError(t, os.WriteFile(vaultPath, enc, 0600))
+		read, err := readExitVault(exitVaultCtx(t, t.TempDir(), vaultPath, false))
+		require.NoError(t, err)
+		readEnc, err := read.Encode()
+		require.NoError(t, err)
+		assert.DeepEqual(t, enc, readEnc)
+	})
+
+	for _, minimal := range []bool{false, true} {
+		t.Run("validator database", func(t *testing.T) {
+			c := exitVaultCtx(t, t.TempDir(), "", minimal)
+			_, err := readExitVault(c)
+			assert.ErrorContains(t, "no exit vault in validator database", err)
+
+			validatorDB, err := openValidatorDB(c)
+			require.NoError(t, err)
+			require.NoError(t, validatorDB.SaveExitVault(c.Context, enc))
+			require.NoError(t, validatorDB.Close())
+
+			read, err := readExitVault(c)
+			require.NoError(t, err)
+			readEnc, err := read.Encode()
+			require.NoError(t, err)
+			assert.DeepEqual(t, enc, readEnc)
+		})
+	}
+}
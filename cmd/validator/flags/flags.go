@@ -238,6 +238,42 @@ var (
 			"files. If this flag is provided, voluntary exits will be written to the provided " +
 			"directory and will not be broadcasted.",
 	}
+	// ToExitVaultFlag to pre-sign voluntary exits into an exit vault instead of broadcasting them.
+	ToExitVaultFlag = &cli.BoolFlag{
+		Name: "to-vault",
+		Usage: "Pre-signs voluntary exits for the accounts selected with --" + VoluntaryExitPublicKeysFlag.Name +
+			", or for all accounts, and seals them in an exit vault encrypted with a separate passphrase. " +
+			"The vault is stored in the validator database unless --" + ExitVaultPathFlag.Name + " is provided.",
+	}
+	// FromExitVaultFlag to broadcast voluntary exits pre-signed in an exit vault.
+	FromExitVaultFlag = &cli.BoolFlag{
+		Name: "from-vault",
+		Usage: "Broadcasts voluntary exits pre-signed in an exit vault for the selected accounts. " +
+			"No wallet or signer is needed, only the vault and its passphrase.",
+	}
+	// ExitVaultPathFlag for the file of an exit vault exported as a bundle.
+	ExitVaultPathFlag = &cli.StringFlag{
+		Name: "exit-vault-path",
+		Usage: "Path to an exit vault bundle file to write with --to-vault or to read with --from-vault. " +
+			"If not provided, the exit vault of the validator database in --datadir is used.",
+	}
+	// ExitVaultPassphraseFileFlag for the passphrase encrypting an exit vault.
+	ExitVaultPassphraseFileFlag = &cli.StringFlag{
+		Name:  "exit-vault-passphrase-file",
+		Usage: "Path to a plain-text, .txt file containing the passphrase of the exit vault.",
+	}
+	// OverwriteExitVaultFlag to replace an existing exit vault with --to-vault.
+	OverwriteExitVaultFlag = &cli.BoolFlag{
+		Name: "overwrite-exit-vault",
+		Usage: "Replaces the existing exit vault with the voluntary exits pre-signed with --to-vault. By default, " +
+			"they are added to the existing exit vault, which must be encrypted with the same passphrase.",
+	}
+	// ExitEpochFlag for the epoch voluntary exits are pre-signed for.
+	ExitEpochFlag = &cli.Uint64Flag{
+		Name: "exit-epoch",
+		Usage: "Epoch from which the voluntary exits pre-signed with --to-vault are valid. " +
+			"Defaults to the current epoch.",
+	}
 	// BackupPasswordFileFlag for encrypting accounts a user wishes to back up.
 	BackupPasswordFileFlag = &cli.StringFlag{
 		Name:  "backup-password-file",
@@ -312,7 +348,7 @@ var (
 	// KeymanagerKindFlag defines the kind of keymanager desired by a user during wallet creation.
 	KeymanagerKindFlag = &cli.StringFlag{
 		Name:  "keymanager-kind",
-		Usage: "Kind of keymanager, either imported, derived, or remote, specified during wallet creation.",
+		Usage: "Kind of keymanager, either imported, derived, remote, threshold, composite or remote-grpc, specified during wallet creation.",
 		Value: "",
 	}
 	// SkipDepositConfirmationFlag skips the y/n confirmation userprompt for sending a deposit to the deposit contract.
@@ -389,6 +425,12 @@ var (
 		Usage: "To enable the use of prysm validator client in Distributed Validator Cluster",
 		Value: false,
 	}
+	// BeaconNodesActiveActiveFlag enables the use of all the configured beacon nodes at the same time.
+	BeaconNodesActiveActiveFlag = &cli.BoolFlag{
+		Name: "beacon-nodes-active-active",
+		Usage: "Uses all the beacon nodes given as comma separated endpoints at the same time instead of failing over between them. " +
+			"Duties and attestation data are requested from every node, and signed objects are broadcast to every healthy node.",
+	}
 )
 
 // DefaultValidatorDir returns OS-specific default validator directory.
@@ -1,21 +1,22 @@
 package historycmd
 
 import (
-	"encoding/json"
+	"bufio"
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/cmd/validator/flags"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/io/file"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/userprompt"
 	"github.com/prysmaticlabs/prysm/v5/validator/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/validator/db/iface"
 	"github.com/prysmaticlabs/prysm/v5/validator/db/kv"
 	slashingprotection "github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history"
-	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
 	"github.com/urfave/cli/v2"
 )
 
@@ -30,9 +31,8 @@ const (
 // Steps:
 // 1. Parse a path to the validator's datadir from the CLI context.
 // 2. Open the validator database.
-// 3. Call the function which actually exports the data from
-// the validator's db into an EIP standard slashing protection format
-// 4. Format and save the JSON file to a user's specified output directory.
+// 3. Stream the data from the validator's db into an EIP standard slashing
+// protection JSON file in a user's specified output directory.
 func exportSlashingProtectionJSON(cliCtx *cli.Context) error {
 	var (
 		validatorDB iface.ValidatorDB
@@ -94,32 +94,15 @@ func exportSlashingProtectionJSON(cliCtx *cli.Context) error {
 		}
 	}()
 
-	// Export the slashing protection history from the validator's database.
-	eipJSON, err := slashingprotection.ExportStandardProtectionJSON(cliCtx.Context, validatorDB)
-	if err != nil {
-		return errors.Wrap(err, "could not export slashing protection history")
-	}
-
-	// Check if JSON data is empty and issue a warning about common problems to the user.
-	if eipJSON == nil || len(eipJSON.Data) == 0 {
-		log.Fatal(
-			"No slashing protection data was found in your database. This is likely because an older version of " +
-				"Prysm would place your validator database in your wallet directory as a validator.db file. Now, " +
-				"Prysm keeps its validator database inside the direct/ or derived/ folder in your wallet directory. " +
-				"Try running this command again, but add direct/ or derived/ to the path where your wallet " +
-				"directory is in and you should obtain your slashing protection history",
-		)
-	}
-
-	// Write the result to the output file
-	if err := writeToOutput(cliCtx, eipJSON); err != nil {
+	// Export the slashing protection history from the validator's database into the output file.
+	if err := writeToOutput(cliCtx, validatorDB); err != nil {
 		return errors.Wrap(err, "could not write slashing protection history to output file")
 	}
 
 	return nil
 }
 
-func writeToOutput(cliCtx *cli.Context, eipJSON *format.EIPSlashingProtectionFormat) error {
+func writeToOutput(cliCtx *cli.Context, validatorDB iface.ValidatorDB) error {
 	// Get the output directory where the slashing protection history file will be stored
 	outputDir, err := userprompt.InputDirectory(
 		cliCtx,
@@ -147,16 +130,37 @@ func writeToOutput(cliCtx *cli.Context, eipJSON *format.EIPSlashingProtectionFor
 		}
 	}
 
-	// Write into the output file
+	// The history is streamed into a temporary file, which is only renamed to the output file once complete.
+	outputDir, err = file.ExpandPath(outputDir)
+	if err != nil {
+		return err
+	}
 	outputFilePath := filepath.Join(outputDir, jsonExportFileName)
-	log.Infof("Writing slashing protection export JSON file to %s", outputFilePath)
-
-	encoded, err := json.MarshalIndent(eipJSON, "", "\t")
+	tmpFilePath := outputFilePath + ".tmp"
+	count, err := exportToFile(cliCtx, validatorDB, tmpFilePath)
 	if err != nil {
-		return errors.Wrap(err, "could not JSON marshal slashing protection history")
+		if rmErr := os.Remove(tmpFilePath); rmErr != nil && !os.IsNotExist(rmErr) {
+			log.WithError(rmErr).Errorf("Could not remove temporary file %s", tmpFilePath)
+		}
+		return errors.Wrap(err, "could not export slashing protection history")
+	}
+
+	// Check if JSON data is empty and issue a warning about common problems to the user.
+	if count == 0 {
+		if err := os.Remove(tmpFilePath); err != nil {
+			log.WithError(err).Errorf("Could not remove temporary file %s", tmpFilePath)
+		}
+		log.Fatal(
+			"No slashing protection data was found in your database. This is likely because an older version of " +
+				"Prysm would place your validator database in your wallet directory as a validator.db file. Now, " +
+				"Prysm keeps its validator database inside the direct/ or derived/ folder in your wallet directory. " +
+				"Try running this command again, but add direct/ or derived/ to the path where your wallet " +
+				"directory is in and you should obtain your slashing protection history",
+		)
 	}
 
-	if err := file.WriteFile(outputFilePath, encoded); err != nil {
+	log.Infof("Writing slashing protection export JSON file to %s", outputFilePath)
+	if err := os.Rename(tmpFilePath, outputFilePath); err != nil {
 		return errors.Wrapf(err, "could not write file to path %s", outputFilePath)
 	}
 
@@ -168,3 +172,30 @@ func writeToOutput(cliCtx *cli.Context, eipJSON *format.EIPSlashingProtectionFor
 
 	return nil
 }
+
+// exportToFile streams the slashing protection history of the database into the file at the given path,
+// and returns the number of validators exported.
+func exportToFile(cliCtx *cli.Context, validatorDB iface.ValidatorDB, path string) (int, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not create file %s", path)
+	}
+	w := bufio.NewWriter(f)
+	count, err := slashingprotection.ExportStandardProtectionJSONToWriter(cliCtx.Context, validatorDB, w)
+	if err != nil {
+		if closeErr := f.Close(); closeErr != nil {
+			log.WithError(closeErr).Errorf("Could not close file %s", path)
+		}
+		return 0, err
+	}
+	if err := w.Flush(); err != nil {
+		if closeErr := f.Close(); closeErr != nil {
+			log.WithError(closeErr).Errorf("Could not close file %s", path)
+		}
+		return 0, errors.Wrapf(err, "could not write file %s", path)
+	}
+	if err := f.Close(); err != nil {
+		return 0, errors.Wrapf(err, "could not close file %s", path)
+	}
+	return count, nil
+}
@@ -1,8 +1,8 @@
 package historycmd
 
 import (
-	"bytes"
 	"fmt"
+	"os"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/cmd"
@@ -93,17 +93,24 @@ func importSlashingProtectionJSON(cliCtx *cli.Context) error {
 		)
 	}
 
-	// Read the JSON file from user input.
-	enc, err := file.ReadFileAsBytes(protectionFilePath)
+	// Open the JSON file from user input, which is read as it is imported.
+	expandedPath, err := file.ExpandPath(protectionFilePath)
 	if err != nil {
 		return err
 	}
+	f, err := os.Open(expandedPath) // #nosec G304 -- the path is provided by the user.
+	if err != nil {
+		return errors.Wrapf(err, "could not open slashing protection JSON file %s", protectionFilePath)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Errorf("Could not close slashing protection JSON file")
+		}
+	}()
 
 	// Import the data from the standard slashing protection JSON file into our database.
 	log.Infof("Starting import of slashing protection file %s", protectionFilePath)
-	buf := bytes.NewBuffer(enc)
-
-	if err := valDB.ImportStandardProtectionJSON(cliCtx.Context, buf); err != nil {
+	if err := valDB.ImportStandardProtectionJSON(cliCtx.Context, f); err != nil {
 		return errors.Wrapf(err, "could not import slashing protection JSON file %s", protectionFilePath)
 	}
 
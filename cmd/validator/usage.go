@@ -143,6 +143,7 @@ var appHelpFlagGroups = []flagGroup{
 			flags.DisablePenaltyRewardLogFlag,
 			flags.DisableAccountMetricsFlag,
 			flags.EnableDistributed,
+			flags.BeaconNodesActiveActiveFlag,
 			flags.AuthTokenPathFlag,
 		},
 	},
@@ -49,6 +49,7 @@ type Flags struct {
 	EnableHistoricalSpaceRepresentation bool // EnableHistoricalSpaceRepresentation enables the saving of registry validators in separate buckets to save space
 	EnableBeaconRESTApi                 bool // EnableBeaconRESTApi enables experimental usage of the beacon REST API by the validator when querying a beacon node
 	EnableCommitteeAwarePacking         bool // EnableCommitteeAwarePacking TODO
+	EnableRewardOptimalPacking          bool // EnableRewardOptimalPacking packs the attestations maximizing the proposer reward.
 	// Logging related toggles.
 	DisableGRPCConnectionLogs bool // Disables logging when a new grpc client has connected.
 	EnableFullSSZDataLogging  bool // Enables logging for full ssz data on rejected gossip messages
@@ -60,12 +61,15 @@ type Flags struct {
 	AttestTimely bool // AttestTimely fixes #8185. It is gated behind a flag to ensure beacon node's fix can safely roll out first. We'll invert this in v1.1.0.
 
 	EnableSlasher                   bool // Enable slasher in the beacon node runtime.
+	EnableSlasherStream             bool // Collect slasher inputs and stream them to a standalone slasher.
 	EnableSlashingProtectionPruning bool // Enable slashing protection pruning for the validator client.
 	EnableMinimalSlashingProtection bool // Enable minimal slashing protection database for the validator client.
 
 	SaveFullExecutionPayloads bool // Save full beacon blocks with execution payloads in the database.
 	EnableStartOptimistic     bool // EnableStartOptimistic treats every block as optimistic at startup.
 
+	EnableForkchoicePersistence bool // EnableForkchoicePersistence saves fork choice to the database every epoch and restores it at startup.
+
 	DisableResourceManager     bool // Disables running the node with libp2p's resource manager.
 	DisableStakinContractCheck bool // Disables check for deposit contract when proposing blocks
 
@@ -211,6 +215,10 @@ func ConfigureBeaconChain(ctx *cli.Context) error {
 		log.WithField(enableSlasherFlag.Name, enableSlasherFlag.Usage).Warn(enabledFeatureFlag)
 		cfg.EnableSlasher = true
 	}
+	if ctx.Bool(enableSlasherStreamFlag.Name) {
+		logEnabled(enableSlasherStreamFlag)
+		cfg.EnableSlasherStream = true
+	}
 	if ctx.Bool(enableHistoricalSpaceRepresentation.Name) {
 		log.WithField(enableHistoricalSpaceRepresentation.Name, enableHistoricalSpaceRepresentation.Usage).Warn(enabledFeatureFlag)
 		cfg.EnableHistoricalSpaceRepresentation = true
@@ -260,6 +268,14 @@ func ConfigureBeaconChain(ctx *cli.Context) error {
 		logEnabled(EnableCommitteeAwarePacking)
 		cfg.EnableCommitteeAwarePacking = true
 	}
+	if ctx.IsSet(enableRewardOptimalPacking.Name) {
+		logEnabled(enableRewardOptimalPacking)
+		cfg.EnableRewardOptimalPacking = true
+	}
+	if ctx.IsSet(enableForkchoicePersistence.Name) {
+		logEnabled(enableForkchoicePersistence)
+		cfg.EnableForkchoicePersistence = true
+	}
 
 	cfg.AggregateIntervals = [3]time.Duration{aggregateFirstInterval.Value, aggregateSecondInterval.Value, aggregateThirdInterval.Value}
 	Init(cfg)
@@ -93,6 +93,11 @@ var (
 		Name:  "slasher",
 		Usage: "Enables a slasher in the beacon node for detecting slashable offenses.",
 	}
+	enableSlasherStreamFlag = &cli.BoolFlag{
+		Name: "slasher-stream",
+		Usage: "Collects indexed attestations and block headers for a slasher even when the slasher is not enabled, " +
+			"and serves them on the slasher event stream topics for a standalone slasher.",
+	}
 	enableSlashingProtectionPruning = &cli.BoolFlag{
 		Name:  "enable-slashing-protection-history-pruning",
 		Usage: "Enables the pruning of the validator client's slashing protection database.",
@@ -170,6 +175,16 @@ var (
 		Name:  "enable-committee-aware-packing",
 		Usage: "Changes the attestation packing algorithm to one that is aware of attesting committees.",
 	}
+	enableRewardOptimalPacking = &cli.BoolFlag{
+		Name: "enable-reward-optimal-packing",
+		Usage: "Packs the attestations of proposed blocks maximizing the proposer reward computed against the pre-state, " +
+			"logging and exporting the reward of both this packing and the max-cover packing.",
+	}
+	enableForkchoicePersistence = &cli.BoolFlag{
+		Name: "enable-forkchoice-persistence",
+		Usage: "Saves fork choice to the database every epoch and on shutdown, and restores it at startup " +
+			"so that non-finalized blocks and votes are not lost on restart.",
+	}
 )
 
 // devModeFlags holds list of flags that are set when development mode is on.
@@ -211,6 +226,7 @@ var BeaconChainFlags = append(deprecatedBeaconFlags, append(deprecatedFlags, []c
 	disablePeerScorer,
 	disableBroadcastSlashingFlag,
 	enableSlasherFlag,
+	enableSlasherStreamFlag,
 	enableHistoricalSpaceRepresentation,
 	disableStakinContractCheck,
 	SaveFullExecutionPayloads,
@@ -227,6 +243,8 @@ var BeaconChainFlags = append(deprecatedBeaconFlags, append(deprecatedFlags, []c
 	BlobSaveFsync,
 	EnableQUIC,
 	EnableCommitteeAwarePacking,
+	enableRewardOptimalPacking,
+	enableForkchoicePersistence,
 }...)...)
 
 // E2EBeaconChainFlags contains a list of the beacon chain feature flags to be tested in E2E.
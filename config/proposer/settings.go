@@ -10,7 +10,9 @@ import (
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/validator"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"google.golang.org/protobuf/proto"
 )
 
 // SettingFromConsensus converts struct to Settings while verifying the fields
@@ -78,10 +80,12 @@ func verifyOption(key string, option *validatorpb.ProposerOptionPayload) error {
 
 // BuilderConfig is the struct representation of the JSON config file set in the validator through the CLI.
 // GasLimit is a number set to help the network decide on the maximum gas in each block.
+// Policy optionally overrides the beacon node's builder selection thresholds for the validator.
 type BuilderConfig struct {
-	Enabled  bool             `json:"enabled" yaml:"enabled"`
-	GasLimit validator.Uint64 `json:"gas_limit,omitempty" yaml:"gas_limit,omitempty"`
-	Relays   []string         `json:"relays,omitempty" yaml:"relays,omitempty"`
+	Enabled  bool                 `json:"enabled" yaml:"enabled"`
+	GasLimit validator.Uint64     `json:"gas_limit,omitempty" yaml:"gas_limit,omitempty"`
+	Relays   []string             `json:"relays,omitempty" yaml:"relays,omitempty"`
+	Policy   *ethpb.BuilderPolicy `json:"policy,omitempty" yaml:"policy,omitempty"`
 }
 
 // BuilderConfigFromConsensus converts protobuf to a builder config used in in-memory storage
@@ -98,6 +102,7 @@ func BuilderConfigFromConsensus(from *validatorpb.BuilderConfig) *BuilderConfig
 		copy(relays, from.Relays)
 		c.Relays = relays
 	}
+	c.Policy = copyBuilderPolicy(from.Policy)
 	return c
 }
 
@@ -230,9 +235,17 @@ func (bc *BuilderConfig) Clone() *BuilderConfig {
 		copy(relays, bc.Relays)
 		c.Relays = relays
 	}
+	c.Policy = copyBuilderPolicy(bc.Policy)
 	return c
 }
 
+func copyBuilderPolicy(p *ethpb.BuilderPolicy) *ethpb.BuilderPolicy {
+	if p == nil {
+		return nil
+	}
+	return proto.Clone(p).(*ethpb.BuilderPolicy)
+}
+
 // Clone creates a deep copy of graffiti config
 func (gc *GraffitiConfig) Clone() *GraffitiConfig {
 	if gc == nil {
@@ -255,5 +268,6 @@ func (bc *BuilderConfig) ToConsensus() *validatorpb.BuilderConfig {
 		c.Relays = relays
 	}
 	c.GasLimit = bc.GasLimit
+	c.Policy = copyBuilderPolicy(bc.Policy)
 	return c
 }
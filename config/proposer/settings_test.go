@@ -9,10 +9,12 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/validator"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 )
 
 func Test_Proposer_Setting_Cloning(t *testing.T) {
+	minBid := uint64(100)
 	key1hex := "0xa057816155ad77931185101128655c0191bd0214c201ca48ed887f6c4c6adf334070efcd75140eada5ac83a92506dd7a"
 	key1, err := hexutil.Decode(key1hex)
 	require.NoError(t, err)
@@ -26,6 +28,7 @@ func Test_Proposer_Setting_Cloning(t *testing.T) {
 					Enabled:  true,
 					GasLimit: validator.Uint64(40000000),
 					Relays:   []string{"https://example-relay.com"},
+					Policy:   &ethpb.BuilderPolicy{MinBuilderBid: &minBid},
 				},
 			},
 		},
@@ -66,6 +69,14 @@ func Test_Proposer_Setting_Cloning(t *testing.T) {
 		require.Equal(t, config.Enabled, clone.Enabled)
 		require.Equal(t, config.GasLimit, clone.GasLimit)
 	})
+	t.Run("Happy Path Cloning Builder policy", func(t *testing.T) {
+		option, ok := settings.ProposeConfig[bytesutil.ToBytes48(key1)]
+		require.Equal(t, true, ok)
+		clone := BuilderConfigFromConsensus(option.BuilderConfig.ToConsensus())
+		require.DeepEqual(t, option.BuilderConfig.Policy, clone.Policy)
+		*clone.Policy.MinBuilderBid = 200
+		require.Equal(t, uint64(100), option.BuilderConfig.Policy.GetMinBuilderBid())
+	})
 	t.Run("To Payload and SettingFromConsensus", func(t *testing.T) {
 		payload := settings.ToConsensus()
 		option, ok := settings.ProposeConfig[bytesutil.ToBytes48(key1)]
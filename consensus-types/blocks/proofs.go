@@ -16,10 +16,7 @@ import (
 	"go.opencensus.io/trace"
 )
 
-const (
-	payloadFieldIndex = 9
-	bodyFieldIndex    = 4
-)
+const payloadFieldIndex = 9
 
 func ComputeBlockBodyFieldRoots(ctx context.Context, blockBody *BeaconBlockBody) ([][]byte, error) {
 	_, span := trace.StartSpan(ctx, "blocks.ComputeBlockBodyFieldRoots")
@@ -221,6 +218,8 @@ func ComputeBlockFieldRoots(ctx context.Context, block interfaces.ReadOnlyBeacon
 	return fieldRoots, nil
 }
 
+// PayloadProof returns the merkle proof of the execution payload against the block body root,
+// as used for the execution branch of light client headers.
 func PayloadProof(ctx context.Context, block interfaces.ReadOnlyBeaconBlock) ([][]byte, error) {
 	i := block.Body()
 	blockBody, ok := i.(*BeaconBlockBody)
@@ -234,17 +233,5 @@ func PayloadProof(ctx context.Context, block interfaces.ReadOnlyBeaconBlock) ([]
 	}
 
 	blockBodyFieldRootsTrie := stateutil.Merkleize(blockBodyFieldRoots)
-	blockBodyProof := trie.ProofFromMerkleLayers(blockBodyFieldRootsTrie, payloadFieldIndex)
-
-	beaconBlockFieldRoots, err := ComputeBlockFieldRoots(ctx, block)
-	if err != nil {
-		return nil, err
-	}
-
-	beaconBlockFieldRootsTrie := stateutil.Merkleize(beaconBlockFieldRoots)
-	beaconBlockProof := trie.ProofFromMerkleLayers(beaconBlockFieldRootsTrie, bodyFieldIndex)
-
-	finalProof := append(blockBodyProof, beaconBlockProof...)
-
-	return finalProof, nil
+	return trie.ProofFromMerkleLayers(blockBodyFieldRootsTrie, payloadFieldIndex), nil
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/v5/container/trie"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 )
 
@@ -145,3 +146,45 @@ func TestComputeBlockBodyFieldRoots_Electra(t *testing.T) {
 
 	require.DeepEqual(t, correctHash[:], hash)
 }
+
+func TestPayloadProof(t *testing.T) {
+	tests := []struct {
+		name  string
+		block interface{}
+	}{
+		{
+			name:  "capella",
+			block: &eth.BeaconBlockCapella{ParentRoot: make([]byte, 32), StateRoot: make([]byte, 32), Body: hydrateBeaconBlockBodyCapella()},
+		},
+		{
+			name:  "deneb",
+			block: &eth.BeaconBlockDeneb{ParentRoot: make([]byte, 32), StateRoot: make([]byte, 32), Body: hydrateBeaconBlockBodyDeneb()},
+		},
+		{
+			name:  "electra",
+			block: &eth.BeaconBlockElectra{ParentRoot: make([]byte, 32), StateRoot: make([]byte, 32), Body: hydrateBeaconBlockBodyElectra()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := NewBeaconBlock(tt.block)
+			require.NoError(t, err)
+			proof, err := PayloadProof(context.Background(), b)
+			require.NoError(t, err)
+			require.Equal(t, 4, len(proof))
+
+			bodyRoot, err := b.Body().HashTreeRoot()
+			require.NoError(t, err)
+			execution, err := b.Body().Execution()
+			require.NoError(t, err)
+			payloadRoot, err := execution.HashTreeRoot()
+			require.NoError(t, err)
+			require.Equal(t, true, trie.VerifyMerkleProof(bodyRoot[:], payloadRoot[:], payloadFieldIndex, proof))
+
+			// The proof is against the block body root, not the block root.
+			blockRoot, err := b.HashTreeRoot()
+			require.NoError(t, err)
+			require.Equal(t, false, trie.VerifyMerkleProof(blockRoot[:], payloadRoot[:], payloadFieldIndex, proof))
+		})
+	}
+}
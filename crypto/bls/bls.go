@@ -80,3 +80,21 @@ func NewAggregateSignature() common.Signature {
 func RandKey() (common.SecretKey, error) {
 	return blst.RandKey()
 }
+
+// SplitSecretKey splits a secret key into n shares, any threshold of which can sign on behalf
+// of the key. The share at position i of the result has index i+1.
+func SplitSecretKey(secretKey SecretKey, threshold, n uint64) ([]SecretKey, error) {
+	return blst.SplitSecretKey(secretKey, threshold, n)
+}
+
+// RecoverSignature combines partial signatures made with the shares of the given indices
+// into a signature of the secret key the shares were split from.
+func RecoverSignature(indices []uint64, partials []Signature) (Signature, error) {
+	return blst.RecoverSignature(indices, partials)
+}
+
+// RecoverPublicKey combines the public keys of the shares of the given indices
+// into the public key of the secret key the shares were split from.
+func RecoverPublicKey(indices []uint64, publicKeys []PublicKey) (PublicKey, error) {
+	return blst.RecoverPublicKey(indices, publicKeys)
+}
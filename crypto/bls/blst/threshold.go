@@ -0,0 +1,126 @@
+//go:build ((linux && amd64) || (linux && arm64) || (darwin && amd64) || (darwin && arm64) || (windows && amd64)) && !blst_disabled
+
+package blst
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// curveOrder is the order r of the BLS12-381 groups, secret keys are scalars modulo r.
+var curveOrder, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+// scalarBits is the bit length of curveOrder.
+const scalarBits = 255
+
+// SplitSecretKey splits a secret key into n Shamir shares, any threshold of which can
+// sign on behalf of the key. Share i is the evaluation at x = i+1 of a random polynomial
+// of degree threshold-1 whose constant term is the secret key, so that the share of
+// index i+1 is at position i of the result.
+func SplitSecretKey(secretKey common.SecretKey, threshold, n uint64) ([]common.SecretKey, error) {
+	if threshold == 0 || threshold > n {
+		return nil, fmt.Errorf("invalid threshold %d for %d shares", threshold, n)
+	}
+	coefficients := make([]*big.Int, threshold)
+	coefficients[0] = new(big.Int).SetBytes(secretKey.Marshal())
+	for i := uint64(1); i < threshold; i++ {
+		// A random secret key is a uniform non-zero scalar.
+		k, err := RandKey()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not generate polynomial coefficient")
+		}
+		coefficients[i] = new(big.Int).SetBytes(k.Marshal())
+	}
+	shares := make([]common.SecretKey, n)
+	for i := uint64(0); i < n; i++ {
+		x := new(big.Int).SetUint64(i + 1)
+		// Horner's method.
+		y := new(big.Int)
+		for j := len(coefficients) - 1; j >= 0; j-- {
+			y.Mul(y, x)
+			y.Add(y, coefficients[j])
+			y.Mod(y, curveOrder)
+		}
+		share, err := SecretKeyFromBytes(y.FillBytes(make([]byte, scalarBytes)))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not create share %d", i+1)
+		}
+		shares[i] = share
+	}
+	return shares, nil
+}
+
+// RecoverSignature combines the partial signatures made with the shares of the given indices
+// into the signature of the secret key the shares were split from. The number of partial
+// signatures must be at least the threshold the key was split with for the result to be valid.
+func RecoverSignature(indices []uint64, partials []common.Signature) (common.Signature, error) {
+	if len(indices) != len(partials) {
+		return nil, fmt.Errorf("got %d indices for %d partial signatures", len(indices), len(partials))
+	}
+	scalars, err := lagrangeCoefficients(indices)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]*blstSignature, len(partials))
+	for i, p := range partials {
+		points[i] = p.(*Signature).s
+	}
+	return &Signature{s: blst.P2AffinesMult(points, scalars, scalarBits).ToAffine()}, nil
+}
+
+// RecoverPublicKey combines the public keys of the shares of the given indices into the
+// public key of the secret key the shares were split from.
+func RecoverPublicKey(indices []uint64, publicKeys []common.PublicKey) (common.PublicKey, error) {
+	if len(indices) != len(publicKeys) {
+		return nil, fmt.Errorf("got %d indices for %d public keys", len(indices), len(publicKeys))
+	}
+	scalars, err := lagrangeCoefficients(indices)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]*blstPublicKey, len(publicKeys))
+	for i, p := range publicKeys {
+		points[i] = p.(*PublicKey).p
+	}
+	return &PublicKey{p: blst.P1AffinesMult(points, scalars, scalarBits).ToAffine()}, nil
+}
+
+// lagrangeCoefficients returns the Lagrange basis polynomials of the given indices evaluated at zero.
+func lagrangeCoefficients(indices []uint64) ([]*blst.Scalar, error) {
+	if len(indices) == 0 {
+		return nil, errors.New("no share to combine")
+	}
+	seen := make(map[uint64]bool, len(indices))
+	for _, i := range indices {
+		if i == 0 {
+			return nil, errors.New("share indices start at 1")
+		}
+		if seen[i] {
+			return nil, fmt.Errorf("duplicate share index %d", i)
+		}
+		seen[i] = true
+	}
+	scalars := make([]*blst.Scalar, len(indices))
+	for i, xi := range indices {
+		num, den := big.NewInt(1), big.NewInt(1)
+		for j, xj := range indices {
+			if i == j {
+				continue
+			}
+			num.Mul(num, new(big.Int).SetUint64(xj))
+			num.Mod(num, curveOrder)
+			d := new(big.Int).Sub(new(big.Int).SetUint64(xj), new(big.Int).SetUint64(xi))
+			den.Mul(den, d)
+			den.Mod(den, curveOrder)
+		}
+		den.ModInverse(den, curveOrder)
+		num.Mul(num, den)
+		num.Mod(num, curveOrder)
+		scalars[i] = new(blst.Scalar).FromBEndian(num.FillBytes(make([]byte, scalarBytes)))
+	}
+	return scalars, nil
+}
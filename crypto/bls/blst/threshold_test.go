@@ -0,0 +1,53 @@
+//go:build ((linux && amd64) || (linux && arm64) || (darwin && amd64) || (darwin && arm64) || (windows && amd64)) && !blst_disabled
+
+package blst
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestSplitSecretKey_Recover(t *testing.T) {
+	priv, err := RandKey()
+	require.NoError(t, err)
+	shares, err := SplitSecretKey(priv, 3, 5)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(shares))
+
+	msg := []byte("hello")
+	partial := func(indices ...uint64) ([]common.Signature, []common.PublicKey) {
+		sigs := make([]common.Signature, len(indices))
+		pubs := make([]common.PublicKey, len(indices))
+		for i, idx := range indices {
+			sigs[i] = shares[idx-1].Sign(msg)
+			pubs[i] = shares[idx-1].PublicKey()
+		}
+		return sigs, pubs
+	}
+
+	for _, indices := range [][]uint64{{1, 2, 3}, {5, 2, 4}, {1, 2, 3, 4, 5}} {
+		sigs, pubs := partial(indices...)
+		sig, err := RecoverSignature(indices, sigs)
+		require.NoError(t, err)
+		assert.DeepEqual(t, priv.Sign(msg).Marshal(), sig.Marshal())
+		pub, err := RecoverPublicKey(indices, pubs)
+		require.NoError(t, err)
+		assert.Equal(t, true, pub.Equals(priv.PublicKey()))
+	}
+
+	// Fewer shares than the threshold do not recover the key.
+	sigs, _ := partial(1, 2)
+	sig, err := RecoverSignature([]uint64{1, 2}, sigs)
+	require.NoError(t, err)
+	assert.Equal(t, false, sig.Verify(priv.PublicKey(), msg))
+
+	_, err = RecoverSignature([]uint64{1, 1}, append(sigs[:1], sigs[0]))
+	assert.ErrorContains(t, "duplicate share index 1", err)
+	_, err = RecoverSignature([]uint64{0}, sigs[:1])
+	assert.ErrorContains(t, "share indices start at 1", err)
+	_, err = SplitSecretKey(priv, 4, 3)
+	assert.ErrorContains(t, "invalid threshold", err)
+}
@@ -0,0 +1,620 @@
+package eth
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	v1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// The light client containers hold their headers in a oneof, which the ssz generator doesn't support,
+// so their ssz methods are written by hand. The schema of a header depends on the fork, which is taken
+// from the variant set in the header container. Containers to unmarshal into must therefore be created
+// with the constructors below, which set the header variant of the given fork.
+
+const (
+	executionBranchDepth = 4
+	finalityBranchDepth  = 6
+	beaconHeaderSize     = 112
+	bytesPerOffset       = 4
+)
+
+// variableSize marks a variable size field in a container schema.
+const variableSize = -1
+
+// NewLightClientHeaderContainer returns an empty header container with the header variant of the fork.
+func NewLightClientHeaderContainer(v int) (*LightClientHeaderContainer, error) {
+	switch {
+	case v >= version.Deneb:
+		return &LightClientHeaderContainer{Header: &LightClientHeaderContainer_HeaderDeneb{HeaderDeneb: &LightClientHeaderDeneb{}}}, nil
+	case v >= version.Capella:
+		return &LightClientHeaderContainer{Header: &LightClientHeaderContainer_HeaderCapella{HeaderCapella: &LightClientHeaderCapella{}}}, nil
+	case v >= version.Altair:
+		return &LightClientHeaderContainer{Header: &LightClientHeaderContainer_HeaderAltair{HeaderAltair: &LightClientHeader{}}}, nil
+	default:
+		return nil, errors.Errorf("light client headers are not supported for %s", version.String(v))
+	}
+}
+
+// NewLightClientBootstrap returns an empty bootstrap with the header variant of the fork.
+func NewLightClientBootstrap(v int) (*LightClientBootstrap, error) {
+	h, err := NewLightClientHeaderContainer(v)
+	if err != nil {
+		return nil, err
+	}
+	return &LightClientBootstrap{Header: h}, nil
+}
+
+// NewLightClientUpdate returns an empty update with the header variant of the fork.
+func NewLightClientUpdate(v int) (*LightClientUpdate, error) {
+	attested, err := NewLightClientHeaderContainer(v)
+	if err != nil {
+		return nil, err
+	}
+	finalized, err := NewLightClientHeaderContainer(v)
+	if err != nil {
+		return nil, err
+	}
+	return &LightClientUpdate{AttestedHeader: attested, FinalizedHeader: finalized}, nil
+}
+
+// NewLightClientFinalityUpdate returns an empty finality update with the header variant of the fork.
+func NewLightClientFinalityUpdate(v int) (*LightClientFinalityUpdate, error) {
+	attested, err := NewLightClientHeaderContainer(v)
+	if err != nil {
+		return nil, err
+	}
+	finalized, err := NewLightClientHeaderContainer(v)
+	if err != nil {
+		return nil, err
+	}
+	return &LightClientFinalityUpdate{AttestedHeader: attested, FinalizedHeader: finalized}, nil
+}
+
+// NewLightClientOptimisticUpdate returns an empty optimistic update with the header variant of the fork.
+func NewLightClientOptimisticUpdate(v int) (*LightClientOptimisticUpdate, error) {
+	attested, err := NewLightClientHeaderContainer(v)
+	if err != nil {
+		return nil, err
+	}
+	return &LightClientOptimisticUpdate{AttestedHeader: attested}, nil
+}
+
+// MarshalSSZ ssz marshals the header set in the container.
+func (x *LightClientHeaderContainer) MarshalSSZ() ([]byte, error) {
+	switch h := x.GetHeader().(type) {
+	case *LightClientHeaderContainer_HeaderAltair:
+		if h.HeaderAltair.GetBeacon() == nil {
+			return nil, errors.New("nil beacon header")
+		}
+		return h.HeaderAltair.Beacon.MarshalSSZ()
+	case *LightClientHeaderContainer_HeaderCapella:
+		if h.HeaderCapella.GetExecution() == nil {
+			return nil, errors.New("nil execution payload header")
+		}
+		return marshalExecutionHeader(h.HeaderCapella.GetBeacon(), h.HeaderCapella.Execution, h.HeaderCapella.GetExecutionBranch())
+	case *LightClientHeaderContainer_HeaderDeneb:
+		if h.HeaderDeneb.GetExecution() == nil {
+			return nil, errors.New("nil execution payload header")
+		}
+		return marshalExecutionHeader(h.HeaderDeneb.GetBeacon(), h.HeaderDeneb.Execution, h.HeaderDeneb.GetExecutionBranch())
+	default:
+		return nil, errors.New("light client header container has no header")
+	}
+}
+
+// MarshalSSZTo ssz marshals the header set in the container to a target array.
+func (x *LightClientHeaderContainer) MarshalSSZTo(buf []byte) ([]byte, error) {
+	enc, err := x.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, enc...), nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes of the header set in the container.
+func (x *LightClientHeaderContainer) SizeSSZ() int {
+	switch h := x.GetHeader().(type) {
+	case *LightClientHeaderContainer_HeaderAltair:
+		return beaconHeaderSize
+	case *LightClientHeaderContainer_HeaderCapella:
+		size := beaconHeaderSize + bytesPerOffset + executionBranchDepth*fieldparams.RootLength
+		if e := h.HeaderCapella.GetExecution(); e != nil {
+			size += e.SizeSSZ()
+		}
+		return size
+	case *LightClientHeaderContainer_HeaderDeneb:
+		size := beaconHeaderSize + bytesPerOffset + executionBranchDepth*fieldparams.RootLength
+		if e := h.HeaderDeneb.GetExecution(); e != nil {
+			size += e.SizeSSZ()
+		}
+		return size
+	default:
+		return 0
+	}
+}
+
+// UnmarshalSSZ ssz unmarshals a header of the variant set in the container.
+func (x *LightClientHeaderContainer) UnmarshalSSZ(buf []byte) error {
+	switch h := x.GetHeader().(type) {
+	case *LightClientHeaderContainer_HeaderAltair:
+		beacon := &v1.BeaconBlockHeader{}
+		if err := beacon.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+		h.HeaderAltair = &LightClientHeader{Beacon: beacon}
+		return nil
+	case *LightClientHeaderContainer_HeaderCapella:
+		execution := &enginev1.ExecutionPayloadHeaderCapella{}
+		beacon, branch, err := unmarshalExecutionHeader(buf, execution)
+		if err != nil {
+			return err
+		}
+		h.HeaderCapella = &LightClientHeaderCapella{Beacon: beacon, Execution: execution, ExecutionBranch: branch}
+		return nil
+	case *LightClientHeaderContainer_HeaderDeneb:
+		execution := &enginev1.ExecutionPayloadHeaderDeneb{}
+		beacon, branch, err := unmarshalExecutionHeader(buf, execution)
+		if err != nil {
+			return err
+		}
+		h.HeaderDeneb = &LightClientHeaderDeneb{Beacon: beacon, Execution: execution, ExecutionBranch: branch}
+		return nil
+	default:
+		return errors.New("light client header container has no header variant to unmarshal into")
+	}
+}
+
+// fixedSize returns the size of the header if it has a fixed size, or variableSize.
+func (x *LightClientHeaderContainer) fixedSize() int {
+	if _, ok := x.GetHeader().(*LightClientHeaderContainer_HeaderAltair); ok {
+		return beaconHeaderSize
+	}
+	return variableSize
+}
+
+func marshalExecutionHeader(beacon *v1.BeaconBlockHeader, execution ssz.Marshaler, branch [][]byte) ([]byte, error) {
+	if beacon == nil {
+		return nil, errors.New("nil beacon header")
+	}
+	beaconEnc, err := beacon.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	executionEnc, err := execution.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	branchEnc, err := marshalBranch(branch, executionBranchDepth)
+	if err != nil {
+		return nil, errors.Wrap(err, "execution branch")
+	}
+	return marshalContainer([]sszField{{beaconEnc, false}, {executionEnc, true}, {branchEnc, false}}), nil
+}
+
+func unmarshalExecutionHeader(buf []byte, execution ssz.Unmarshaler) (*v1.BeaconBlockHeader, [][]byte, error) {
+	fields, err := unmarshalContainer(buf, []int{beaconHeaderSize, variableSize, executionBranchDepth * fieldparams.RootLength})
+	if err != nil {
+		return nil, nil, err
+	}
+	beacon := &v1.BeaconBlockHeader{}
+	if err := beacon.UnmarshalSSZ(fields[0]); err != nil {
+		return nil, nil, err
+	}
+	if err := execution.UnmarshalSSZ(fields[1]); err != nil {
+		return nil, nil, err
+	}
+	return beacon, unmarshalBranch(fields[2]), nil
+}
+
+// MarshalSSZ ssz marshals the LightClientBootstrap object.
+func (x *LightClientBootstrap) MarshalSSZ() ([]byte, error) {
+	header, err := headerField(x.GetHeader())
+	if err != nil {
+		return nil, err
+	}
+	if x.GetCurrentSyncCommittee() == nil {
+		return nil, errors.New("nil current sync committee")
+	}
+	committee, err := x.CurrentSyncCommittee.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	branch, err := marshalBranch(x.CurrentSyncCommitteeBranch, fieldparams.NextSyncCommitteeBranchDepth)
+	if err != nil {
+		return nil, errors.Wrap(err, "current sync committee branch")
+	}
+	return marshalContainer([]sszField{header, {committee, false}, {branch, false}}), nil
+}
+
+// MarshalSSZTo ssz marshals the LightClientBootstrap object to a target array.
+func (x *LightClientBootstrap) MarshalSSZTo(buf []byte) ([]byte, error) {
+	enc, err := x.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, enc...), nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientBootstrap object.
+func (x *LightClientBootstrap) SizeSSZ() int {
+	return headerSize(x.GetHeader()) + (&SyncCommittee{}).SizeSSZ() + fieldparams.NextSyncCommitteeBranchDepth*fieldparams.RootLength
+}
+
+// UnmarshalSSZ ssz unmarshals the LightClientBootstrap object, using the header variant already set.
+func (x *LightClientBootstrap) UnmarshalSSZ(buf []byte) error {
+	if x.GetHeader() == nil {
+		return errors.New("light client bootstrap has no header variant to unmarshal into")
+	}
+	fields, err := unmarshalContainer(buf, []int{
+		x.Header.fixedSize(),
+		(&SyncCommittee{}).SizeSSZ(),
+		fieldparams.NextSyncCommitteeBranchDepth * fieldparams.RootLength,
+	})
+	if err != nil {
+		return err
+	}
+	if err := x.Header.UnmarshalSSZ(fields[0]); err != nil {
+		return err
+	}
+	x.CurrentSyncCommittee = &SyncCommittee{}
+	if err := x.CurrentSyncCommittee.UnmarshalSSZ(fields[1]); err != nil {
+		return err
+	}
+	x.CurrentSyncCommitteeBranch = unmarshalBranch(fields[2])
+	return nil
+}
+
+// MarshalSSZ ssz marshals the LightClientUpdate object.
+func (x *LightClientUpdate) MarshalSSZ() ([]byte, error) {
+	attested, err := headerField(x.GetAttestedHeader())
+	if err != nil {
+		return nil, errors.Wrap(err, "attested header")
+	}
+	if x.GetNextSyncCommittee() == nil {
+		return nil, errors.New("nil next sync committee")
+	}
+	committee, err := x.NextSyncCommittee.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	committeeBranch, err := marshalBranch(x.NextSyncCommitteeBranch, fieldparams.NextSyncCommitteeBranchDepth)
+	if err != nil {
+		return nil, errors.Wrap(err, "next sync committee branch")
+	}
+	finalized, err := headerField(x.GetFinalizedHeader())
+	if err != nil {
+		return nil, errors.Wrap(err, "finalized header")
+	}
+	finalityBranch, err := marshalBranch(x.FinalityBranch, finalityBranchDepth)
+	if err != nil {
+		return nil, errors.Wrap(err, "finality branch")
+	}
+	aggregate, err := marshalSyncAggregate(x.GetSyncAggregate())
+	if err != nil {
+		return nil, err
+	}
+	return marshalContainer([]sszField{
+		attested,
+		{committee, false},
+		{committeeBranch, false},
+		finalized,
+		{finalityBranch, false},
+		{aggregate, false},
+		{ssz.MarshalUint64(nil, uint64(x.SignatureSlot)), false},
+	}), nil
+}
+
+// MarshalSSZTo ssz marshals the LightClientUpdate object to a target array.
+func (x *LightClientUpdate) MarshalSSZTo(buf []byte) ([]byte, error) {
+	enc, err := x.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, enc...), nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientUpdate object.
+func (x *LightClientUpdate) SizeSSZ() int {
+	return headerSize(x.GetAttestedHeader()) + (&SyncCommittee{}).SizeSSZ() + fieldparams.NextSyncCommitteeBranchDepth*fieldparams.RootLength +
+		headerSize(x.GetFinalizedHeader()) + finalityBranchDepth*fieldparams.RootLength + (&v1.SyncAggregate{}).SizeSSZ() + 8
+}
+
+// UnmarshalSSZ ssz unmarshals the LightClientUpdate object, using the header variants already set.
+func (x *LightClientUpdate) UnmarshalSSZ(buf []byte) error {
+	if x.GetAttestedHeader() == nil || x.GetFinalizedHeader() == nil {
+		return errors.New("light client update has no header variant to unmarshal into")
+	}
+	fields, err := unmarshalContainer(buf, []int{
+		x.AttestedHeader.fixedSize(),
+		(&SyncCommittee{}).SizeSSZ(),
+		fieldparams.NextSyncCommitteeBranchDepth * fieldparams.RootLength,
+		x.FinalizedHeader.fixedSize(),
+		finalityBranchDepth * fieldparams.RootLength,
+		(&v1.SyncAggregate{}).SizeSSZ(),
+		8,
+	})
+	if err != nil {
+		return err
+	}
+	if err := x.AttestedHeader.UnmarshalSSZ(fields[0]); err != nil {
+		return err
+	}
+	x.NextSyncCommittee = &SyncCommittee{}
+	if err := x.NextSyncCommittee.UnmarshalSSZ(fields[1]); err != nil {
+		return err
+	}
+	x.NextSyncCommitteeBranch = unmarshalBranch(fields[2])
+	if err := x.FinalizedHeader.UnmarshalSSZ(fields[3]); err != nil {
+		return err
+	}
+	x.FinalityBranch = unmarshalBranch(fields[4])
+	x.SyncAggregate = &v1.SyncAggregate{}
+	if err := x.SyncAggregate.UnmarshalSSZ(fields[5]); err != nil {
+		return err
+	}
+	x.SignatureSlot = primitives.Slot(ssz.UnmarshallUint64(fields[6]))
+	return nil
+}
+
+// MarshalSSZ ssz marshals the LightClientFinalityUpdate object.
+func (x *LightClientFinalityUpdate) MarshalSSZ() ([]byte, error) {
+	attested, err := headerField(x.GetAttestedHeader())
+	if err != nil {
+		return nil, errors.Wrap(err, "attested header")
+	}
+	finalized, err := headerField(x.GetFinalizedHeader())
+	if err != nil {
+		return nil, errors.Wrap(err, "finalized header")
+	}
+	branch, err := marshalBranch(x.FinalityBranch, finalityBranchDepth)
+	if err != nil {
+		return nil, errors.Wrap(err, "finality branch")
+	}
+	aggregate, err := marshalSyncAggregate(x.GetSyncAggregate())
+	if err != nil {
+		return nil, err
+	}
+	return marshalContainer([]sszField{
+		attested,
+		finalized,
+		{branch, false},
+		{aggregate, false},
+		{ssz.MarshalUint64(nil, uint64(x.SignatureSlot)), false},
+	}), nil
+}
+
+// MarshalSSZTo ssz marshals the LightClientFinalityUpdate object to a target array.
+func (x *LightClientFinalityUpdate) MarshalSSZTo(buf []byte) ([]byte, error) {
+	enc, err := x.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, enc...), nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientFinalityUpdate object.
+func (x *LightClientFinalityUpdate) SizeSSZ() int {
+	return headerSize(x.GetAttestedHeader()) + headerSize(x.GetFinalizedHeader()) +
+		finalityBranchDepth*fieldparams.RootLength + (&v1.SyncAggregate{}).SizeSSZ() + 8
+}
+
+// UnmarshalSSZ ssz unmarshals the LightClientFinalityUpdate object, using the header variants already set.
+func (x *LightClientFinalityUpdate) UnmarshalSSZ(buf []byte) error {
+	if x.GetAttestedHeader() == nil || x.GetFinalizedHeader() == nil {
+		return errors.New("light client finality update has no header variant to unmarshal into")
+	}
+	fields, err := unmarshalContainer(buf, []int{
+		x.AttestedHeader.fixedSize(),
+		x.FinalizedHeader.fixedSize(),
+		finalityBranchDepth * fieldparams.RootLength,
+		(&v1.SyncAggregate{}).SizeSSZ(),
+		8,
+	})
+	if err != nil {
+		return err
+	}
+	if err := x.AttestedHeader.UnmarshalSSZ(fields[0]); err != nil {
+		return err
+	}
+	if err := x.FinalizedHeader.UnmarshalSSZ(fields[1]); err != nil {
+		return err
+	}
+	x.FinalityBranch = unmarshalBranch(fields[2])
+	x.SyncAggregate = &v1.SyncAggregate{}
+	if err := x.SyncAggregate.UnmarshalSSZ(fields[3]); err != nil {
+		return err
+	}
+	x.SignatureSlot = primitives.Slot(ssz.UnmarshallUint64(fields[4]))
+	return nil
+}
+
+// MarshalSSZ ssz marshals the LightClientOptimisticUpdate object.
+func (x *LightClientOptimisticUpdate) MarshalSSZ() ([]byte, error) {
+	attested, err := headerField(x.GetAttestedHeader())
+	if err != nil {
+		return nil, errors.Wrap(err, "attested header")
+	}
+	aggregate, err := marshalSyncAggregate(x.GetSyncAggregate())
+	if err != nil {
+		return nil, err
+	}
+	return marshalContainer([]sszField{
+		attested,
+		{aggregate, false},
+		{ssz.MarshalUint64(nil, uint64(x.SignatureSlot)), false},
+	}), nil
+}
+
+// MarshalSSZTo ssz marshals the LightClientOptimisticUpdate object to a target array.
+func (x *LightClientOptimisticUpdate) MarshalSSZTo(buf []byte) ([]byte, error) {
+	enc, err := x.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, enc...), nil
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the LightClientOptimisticUpdate object.
+func (x *LightClientOptimisticUpdate) SizeSSZ() int {
+	return headerSize(x.GetAttestedHeader()) + (&v1.SyncAggregate{}).SizeSSZ() + 8
+}
+
+// UnmarshalSSZ ssz unmarshals the LightClientOptimisticUpdate object, using the header variant already set.
+func (x *LightClientOptimisticUpdate) UnmarshalSSZ(buf []byte) error {
+	if x.GetAttestedHeader() == nil {
+		return errors.New("light client optimistic update has no header variant to unmarshal into")
+	}
+	fields, err := unmarshalContainer(buf, []int{x.AttestedHeader.fixedSize(), (&v1.SyncAggregate{}).SizeSSZ(), 8})
+	if err != nil {
+		return err
+	}
+	if err := x.AttestedHeader.UnmarshalSSZ(fields[0]); err != nil {
+		return err
+	}
+	x.SyncAggregate = &v1.SyncAggregate{}
+	if err := x.SyncAggregate.UnmarshalSSZ(fields[1]); err != nil {
+		return err
+	}
+	x.SignatureSlot = primitives.Slot(ssz.UnmarshallUint64(fields[2]))
+	return nil
+}
+
+// sszField is an encoded field of a container, placed in the variable part of the encoding if variable is set.
+type sszField struct {
+	enc      []byte
+	variable bool
+}
+
+func headerField(h *LightClientHeaderContainer) (sszField, error) {
+	if h == nil {
+		return sszField{}, errors.New("nil light client header")
+	}
+	enc, err := h.MarshalSSZ()
+	if err != nil {
+		return sszField{}, err
+	}
+	return sszField{enc: enc, variable: h.fixedSize() == variableSize}, nil
+}
+
+// headerSize returns the space the header takes in a container, including its offset if it has a variable size.
+func headerSize(h *LightClientHeaderContainer) int {
+	if h.fixedSize() == variableSize {
+		return bytesPerOffset + h.SizeSSZ()
+	}
+	return h.SizeSSZ()
+}
+
+func marshalSyncAggregate(a *v1.SyncAggregate) ([]byte, error) {
+	if a == nil {
+		return nil, errors.New("nil sync aggregate")
+	}
+	return a.MarshalSSZ()
+}
+
+func marshalBranch(branch [][]byte, depth int) ([]byte, error) {
+	if len(branch) != depth {
+		return nil, errors.Errorf("expected %d roots, got %d", depth, len(branch))
+	}
+	out := make([]byte, 0, depth*fieldparams.RootLength)
+	for _, r := range branch {
+		if len(r) != fieldparams.RootLength {
+			return nil, errors.Errorf("expected root of %d bytes, got %d", fieldparams.RootLength, len(r))
+		}
+		out = append(out, r...)
+	}
+	return out, nil
+}
+
+func unmarshalBranch(buf []byte) [][]byte {
+	branch := make([][]byte, len(buf)/fieldparams.RootLength)
+	for i := range branch {
+		branch[i] = append([]byte{}, buf[i*fieldparams.RootLength:(i+1)*fieldparams.RootLength]...)
+	}
+	return branch
+}
+
+// marshalContainer writes the fixed size fields and the offsets of the variable size fields,
+// followed by the variable size fields.
+func marshalContainer(fields []sszField) []byte {
+	fixed, size := 0, 0
+	for _, f := range fields {
+		if f.variable {
+			fixed += bytesPerOffset
+		} else {
+			fixed += len(f.enc)
+		}
+		size += len(f.enc)
+	}
+	out := make([]byte, 0, size+fixed)
+	offset := fixed
+	for _, f := range fields {
+		if !f.variable {
+			out = append(out, f.enc...)
+			continue
+		}
+		out = binary.LittleEndian.AppendUint32(out, uint32(offset))
+		offset += len(f.enc)
+	}
+	for _, f := range fields {
+		if f.variable {
+			out = append(out, f.enc...)
+		}
+	}
+	return out
+}
+
+// unmarshalContainer splits the encoding of a container into its fields, given the size of
+// every field or variableSize.
+func unmarshalContainer(buf []byte, sizes []int) ([][]byte, error) {
+	fixed := 0
+	for _, s := range sizes {
+		if s == variableSize {
+			fixed += bytesPerOffset
+		} else {
+			fixed += s
+		}
+	}
+	if len(buf) < fixed {
+		return nil, ssz.ErrSize
+	}
+	fields := make([][]byte, len(sizes))
+	var variable []int
+	var offsets []int
+	pos := 0
+	for i, s := range sizes {
+		if s != variableSize {
+			fields[i] = buf[pos : pos+s]
+			pos += s
+			continue
+		}
+		offsets = append(offsets, int(binary.LittleEndian.Uint32(buf[pos:pos+bytesPerOffset])))
+		variable = append(variable, i)
+		pos += bytesPerOffset
+	}
+	if len(offsets) == 0 {
+		if len(buf) != fixed {
+			return nil, ssz.ErrSize
+		}
+		return fields, nil
+	}
+	if offsets[0] != fixed {
+		return nil, ssz.ErrInvalidVariableOffset
+	}
+	for j, i := range variable {
+		end := len(buf)
+		if j+1 < len(offsets) {
+			end = offsets[j+1]
+		}
+		if offsets[j] > end || end > len(buf) {
+			return nil, ssz.ErrOffset
+		}
+		fields[i] = buf[offsets[j]:end]
+	}
+	return fields, nil
+}
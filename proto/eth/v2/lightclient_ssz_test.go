@@ -0,0 +1,182 @@
+package eth
+
+import (
+	"bytes"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	v1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func testRoots(n int, seed byte) [][]byte {
+	roots := make([][]byte, n)
+	for i := range roots {
+		roots[i] = bytes.Repeat([]byte{seed + byte(i)}, fieldparams.RootLength)
+	}
+	return roots
+}
+
+func testBeaconHeader(slot uint64) *v1.BeaconBlockHeader {
+	return &v1.BeaconBlockHeader{
+		Slot:       primitives.Slot(slot),
+		ParentRoot: bytes.Repeat([]byte{1}, 32),
+		StateRoot:  bytes.Repeat([]byte{2}, 32),
+		BodyRoot:   bytes.Repeat([]byte{byte(slot)}, 32),
+	}
+}
+
+func testHeader(t *testing.T, v int, slot uint64) *LightClientHeaderContainer {
+	h, err := NewLightClientHeaderContainer(v)
+	require.NoError(t, err)
+	switch c := h.Header.(type) {
+	case *LightClientHeaderContainer_HeaderAltair:
+		c.HeaderAltair.Beacon = testBeaconHeader(slot)
+	case *LightClientHeaderContainer_HeaderCapella:
+		c.HeaderCapella.Beacon = testBeaconHeader(slot)
+		c.HeaderCapella.ExecutionBranch = testRoots(executionBranchDepth, 10)
+		c.HeaderCapella.Execution = &enginev1.ExecutionPayloadHeaderCapella{
+			ParentHash:       make([]byte, 32),
+			FeeRecipient:     make([]byte, 20),
+			StateRoot:        make([]byte, 32),
+			ReceiptsRoot:     make([]byte, 32),
+			LogsBloom:        make([]byte, 256),
+			PrevRandao:       make([]byte, 32),
+			ExtraData:        []byte("extra"),
+			BaseFeePerGas:    make([]byte, 32),
+			BlockHash:        make([]byte, 32),
+			TransactionsRoot: make([]byte, 32),
+			WithdrawalsRoot:  make([]byte, 32),
+			BlockNumber:      slot,
+		}
+	case *LightClientHeaderContainer_HeaderDeneb:
+		c.HeaderDeneb.Beacon = testBeaconHeader(slot)
+		c.HeaderDeneb.ExecutionBranch = testRoots(executionBranchDepth, 10)
+		c.HeaderDeneb.Execution = &enginev1.ExecutionPayloadHeaderDeneb{
+			ParentHash:       make([]byte, 32),
+			FeeRecipient:     make([]byte, 20),
+			StateRoot:        make([]byte, 32),
+			ReceiptsRoot:     make([]byte, 32),
+			LogsBloom:        make([]byte, 256),
+			PrevRandao:       make([]byte, 32),
+			ExtraData:        []byte("extra data"),
+			BaseFeePerGas:    make([]byte, 32),
+			BlockHash:        make([]byte, 32),
+			TransactionsRoot: make([]byte, 32),
+			WithdrawalsRoot:  make([]byte, 32),
+			BlockNumber:      slot,
+			BlobGasUsed:      7,
+		}
+	}
+	return h
+}
+
+func testSyncCommittee() *SyncCommittee {
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range pubkeys {
+		pubkeys[i] = bytes.Repeat([]byte{byte(i)}, fieldparams.BLSPubkeyLength)
+	}
+	return &SyncCommittee{Pubkeys: pubkeys, AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength)}
+}
+
+func TestLightClientSSZ_RoundTrip(t *testing.T) {
+	for _, v := range []int{version.Altair, version.Capella, version.Deneb, version.Electra} {
+		t.Run(version.String(v), func(t *testing.T) {
+			committee := testSyncCommittee()
+			aggregate := &v1.SyncAggregate{
+				SyncCommitteeBits:      make([]byte, fieldparams.SyncAggregateSyncCommitteeBytesLength),
+				SyncCommitteeSignature: bytes.Repeat([]byte{9}, fieldparams.BLSSignatureLength),
+			}
+
+			bootstrap := &LightClientBootstrap{
+				Header:                     testHeader(t, v, 1),
+				CurrentSyncCommittee:       committee,
+				CurrentSyncCommitteeBranch: testRoots(fieldparams.NextSyncCommitteeBranchDepth, 20),
+			}
+			enc, err := bootstrap.MarshalSSZ()
+			require.NoError(t, err)
+			require.Equal(t, bootstrap.SizeSSZ(), len(enc))
+			gotBootstrap, err := NewLightClientBootstrap(v)
+			require.NoError(t, err)
+			require.NoError(t, gotBootstrap.UnmarshalSSZ(enc))
+			require.DeepEqual(t, bootstrap, gotBootstrap)
+
+			update := &LightClientUpdate{
+				AttestedHeader:          testHeader(t, v, 2),
+				NextSyncCommittee:       committee,
+				NextSyncCommitteeBranch: testRoots(fieldparams.NextSyncCommitteeBranchDepth, 30),
+				FinalizedHeader:         testHeader(t, v, 3),
+				FinalityBranch:          testRoots(finalityBranchDepth, 40),
+				SyncAggregate:           aggregate,
+				SignatureSlot:           123,
+			}
+			enc, err = update.MarshalSSZ()
+			require.NoError(t, err)
+			require.Equal(t, update.SizeSSZ(), len(enc))
+			gotUpdate, err := NewLightClientUpdate(v)
+			require.NoError(t, err)
+			require.NoError(t, gotUpdate.UnmarshalSSZ(enc))
+			require.DeepEqual(t, update, gotUpdate)
+
+			finality := &LightClientFinalityUpdate{
+				AttestedHeader:  update.AttestedHeader,
+				FinalizedHeader: update.FinalizedHeader,
+				FinalityBranch:  update.FinalityBranch,
+				SyncAggregate:   aggregate,
+				SignatureSlot:   124,
+			}
+			enc, err = finality.MarshalSSZ()
+			require.NoError(t, err)
+			require.Equal(t, finality.SizeSSZ(), len(enc))
+			gotFinality, err := NewLightClientFinalityUpdate(v)
+			require.NoError(t, err)
+			require.NoError(t, gotFinality.UnmarshalSSZ(enc))
+			require.DeepEqual(t, finality, gotFinality)
+
+			optimistic := &LightClientOptimisticUpdate{
+				AttestedHeader: update.AttestedHeader,
+				SyncAggregate:  aggregate,
+				SignatureSlot:  125,
+			}
+			enc, err = optimistic.MarshalSSZ()
+			require.NoError(t, err)
+			require.Equal(t, optimistic.SizeSSZ(), len(enc))
+			gotOptimistic, err := NewLightClientOptimisticUpdate(v)
+			require.NoError(t, err)
+			require.NoError(t, gotOptimistic.UnmarshalSSZ(enc))
+			require.DeepEqual(t, optimistic, gotOptimistic)
+
+			if v == version.Altair {
+				// Altair containers have a fixed size, trailing and missing bytes are rejected.
+				require.NotNil(t, gotOptimistic.UnmarshalSSZ(append(enc, 0)))
+				require.NotNil(t, gotOptimistic.UnmarshalSSZ(enc[:len(enc)-1]))
+			} else {
+				// The offset of the attested header must point right after the fixed part.
+				bad := append([]byte{}, enc...)
+				bad[0]++
+				require.NotNil(t, gotOptimistic.UnmarshalSSZ(bad))
+			}
+		})
+	}
+}
+
+func TestLightClientSSZ_Invalid(t *testing.T) {
+	_, err := NewLightClientHeaderContainer(version.Phase0)
+	require.ErrorContains(t, "not supported", err)
+
+	_, err = (&LightClientOptimisticUpdate{}).MarshalSSZ()
+	require.ErrorContains(t, "nil light client header", err)
+	require.ErrorContains(t, "no header variant", (&LightClientOptimisticUpdate{}).UnmarshalSSZ(make([]byte, 300)))
+
+	update := &LightClientFinalityUpdate{
+		AttestedHeader:  testHeader(t, version.Altair, 1),
+		FinalizedHeader: testHeader(t, version.Altair, 2),
+		FinalityBranch:  testRoots(finalityBranchDepth-1, 0),
+		SyncAggregate:   &v1.SyncAggregate{},
+	}
+	_, err = update.MarshalSSZ()
+	require.ErrorContains(t, "finality branch", err)
+}
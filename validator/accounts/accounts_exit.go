@@ -40,24 +40,10 @@ func (acm *CLIManager) Exit(ctx context.Context) error {
 		return nil
 	}
 
-	validatorClient, nodeClient, err := acm.prepareBeaconClients(ctx)
-	if err != nil {
-		return err
-	}
-	if nodeClient == nil {
-		return errors.New("could not prepare beacon node client")
-	}
-	syncStatus, err := (*nodeClient).SyncStatus(ctx, &emptypb.Empty{})
+	validatorClient, nodeClient, err := acm.syncedBeaconClients(ctx)
 	if err != nil {
 		return err
 	}
-	if syncStatus == nil {
-		return errors.New("could not get sync status")
-	}
-
-	if syncStatus.Syncing {
-		return errors.New("could not perform exit: beacon node is syncing.")
-	}
 
 	cfg := PerformExitCfg{
 		*validatorClient,
@@ -76,6 +62,29 @@ func (acm *CLIManager) Exit(ctx context.Context) error {
 	return nil
 }
 
+// syncedBeaconClients prepares the beacon clients, making sure the beacon node is synced.
+func (acm *CLIManager) syncedBeaconClients(ctx context.Context) (*iface.ValidatorClient, *iface.NodeClient, error) {
+	validatorClient, nodeClient, err := acm.prepareBeaconClients(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if nodeClient == nil {
+		return nil, nil, errors.New("could not prepare beacon node client")
+	}
+	syncStatus, err := (*nodeClient).SyncStatus(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, nil, err
+	}
+	if syncStatus == nil {
+		return nil, nil, errors.New("could not get sync status")
+	}
+
+	if syncStatus.Syncing {
+		return nil, nil, errors.New("could not perform exit: beacon node is syncing.")
+	}
+	return validatorClient, nodeClient, nil
+}
+
 // PerformVoluntaryExit uses gRPC clients to submit a voluntary exit message to a beacon node.
 func PerformVoluntaryExit(
 	ctx context.Context, cfg PerformExitCfg,
@@ -0,0 +1,171 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/validator/accounts/exitvault"
+	"github.com/prysmaticlabs/prysm/v5/validator/client"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// SignExitVault pre-signs voluntary exits for the selected accounts and seals them in an exit vault
+// encrypted with the exit vault passphrase. The exits are valid from the exit epoch, or from the
+// current epoch if none was provided. The exits of the existing vault, if any, are kept for the other
+// accounts, it must be encrypted with the same passphrase. No vault is returned if the exit of an
+// account could not be signed, such as an account the beacon node does not know the index of yet.
+func (acm *CLIManager) SignExitVault(ctx context.Context, existing *exitvault.Vault) (*exitvault.Vault, error) {
+	if len(acm.rawPubKeys) == 0 {
+		return nil, errors.New("no accounts to pre-sign voluntary exits for")
+	}
+	validatorClient, nodeClient, err := acm.syncedBeaconClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	genesisResponse, err := (*nodeClient).Genesis(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get genesis information")
+	}
+	var existingExits []*exitvault.Exit
+	if existing != nil {
+		if err := checkExitVaultChain(existing, genesisResponse.GenesisValidatorsRoot); err != nil {
+			return nil, err
+		}
+		existingExits, err = existing.Open(acm.exitVaultPassphrase)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not open the existing exit vault")
+		}
+	}
+	var epoch primitives.Epoch
+	if acm.exitEpoch != nil {
+		epoch = *acm.exitEpoch
+	} else {
+		epoch, err = client.CurrentEpoch(genesisResponse.GenesisTime)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get current epoch")
+		}
+	}
+
+	exits := PreSignVoluntaryExits(ctx, PerformExitCfg{
+		ValidatorClient:  *validatorClient,
+		Keymanager:       acm.keymanager,
+		RawPubKeys:       acm.rawPubKeys,
+		FormattedPubKeys: acm.formattedPubKeys,
+	}, epoch)
+	if len(exits) < len(acm.rawPubKeys) {
+		return nil, fmt.Errorf("could not pre-sign voluntary exits for %d of %d accounts", len(acm.rawPubKeys)-len(exits), len(acm.rawPubKeys))
+	}
+	log.WithField("epoch", epoch).Infof("Pre-signed voluntary exits for %d accounts", len(exits))
+	return exitvault.Seal(exitvault.Merge(existingExits, exits), genesisResponse.GenesisValidatorsRoot, acm.exitVaultPassphrase)
+}
+
+// checkExitVaultChain returns an error if the exit vault was not signed for the chain of the genesis validators root.
+func checkExitVaultChain(vault *exitvault.Vault, genesisValidatorsRoot []byte) error {
+	vaultGenesisValidatorsRoot, err := hexutil.Decode(vault.GenesisValidatorsRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not decode genesis validators root of exit vault")
+	}
+	if !bytes.Equal(vaultGenesisValidatorsRoot, genesisValidatorsRoot) {
+		return fmt.Errorf(
+			"exit vault was signed for genesis validators root %#x, but the beacon node is on %#x",
+			vaultGenesisValidatorsRoot,
+			genesisValidatorsRoot,
+		)
+	}
+	return nil
+}
+
+// ExitFromVault broadcasts the voluntary exits of the selected accounts pre-signed in the exit vault,
+// opened with the exit vault passphrase. No keymanager is needed.
+func (acm *CLIManager) ExitFromVault(ctx context.Context, vault *exitvault.Vault) error {
+	// User decided to cancel the voluntary exit.
+	if acm.rawPubKeys == nil && acm.formattedPubKeys == nil {
+		return nil
+	}
+
+	exits, err := vault.Open(acm.exitVaultPassphrase)
+	if err != nil {
+		return err
+	}
+	validatorClient, nodeClient, err := acm.syncedBeaconClients(ctx)
+	if err != nil {
+		return err
+	}
+	genesisResponse, err := (*nodeClient).Genesis(ctx, &emptypb.Empty{})
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis information")
+	}
+	if err := checkExitVaultChain(vault, genesisResponse.GenesisValidatorsRoot); err != nil {
+		return err
+	}
+
+	rawExitedKeys, formattedExitedKeys := BroadcastVoluntaryExits(
+		ctx, *validatorClient, exits, acm.rawPubKeys, acm.formattedPubKeys,
+	)
+	displayExitInfo(rawExitedKeys, formattedExitedKeys)
+
+	return nil
+}
+
+// PreSignVoluntaryExits signs voluntary exits valid from the epoch for the accounts with the keymanager,
+// without proposing them. Accounts whose exit could not be signed are logged and left out.
+func PreSignVoluntaryExits(ctx context.Context, cfg PerformExitCfg, epoch primitives.Epoch) []*exitvault.Exit {
+	exits := make([]*exitvault.Exit, 0, len(cfg.RawPubKeys))
+	for i, key := range cfg.RawPubKeys {
+		sve, err := client.CreateSignedVoluntaryExit(ctx, cfg.ValidatorClient, cfg.Keymanager.Sign, key, epoch)
+		if err != nil {
+			log.WithError(err).Errorf("Could not pre-sign voluntary exit for account %s", cfg.FormattedPubKeys[i])
+			continue
+		}
+		exits = append(exits, &exitvault.Exit{
+			PublicKey:  bytesutil.ToBytes48(key),
+			SignedExit: sve,
+		})
+	}
+	return exits
+}
+
+// BroadcastVoluntaryExits proposes the pre-signed exits of the accounts to the beacon node.
+func BroadcastVoluntaryExits(
+	ctx context.Context,
+	validatorClient iface.ValidatorClient,
+	exits []*exitvault.Exit,
+	rawPubKeys [][]byte,
+	formattedPubKeys []string,
+) (rawExitedKeys [][]byte, formattedExitedKeys []string) {
+	exitsByKey := make(map[[fieldparams.BLSPubkeyLength]byte]*exitvault.Exit, len(exits))
+	for _, e := range exits {
+		exitsByKey[e.PublicKey] = e
+	}
+	rawExitedKeys = make([][]byte, 0, len(rawPubKeys))
+	formattedExitedKeys = make([]string, 0, len(rawPubKeys))
+	for i, key := range rawPubKeys {
+		e, ok := exitsByKey[bytesutil.ToBytes48(key)]
+		if !ok {
+			log.Errorf("Exit vault holds no voluntary exit for account %s", formattedPubKeys[i])
+			continue
+		}
+		if _, err := validatorClient.ProposeExit(ctx, e.SignedExit); err != nil {
+			msg := err.Error()
+			if strings.Contains(msg, blocks.ValidatorAlreadyExitedMsg) ||
+				strings.Contains(msg, blocks.ValidatorCannotExitYetMsg) {
+				log.Warningf("Could not perform voluntary exit for account %s: %s", formattedPubKeys[i], msg)
+			} else {
+				log.WithError(err).Errorf("voluntary exit failed for account %s", formattedPubKeys[i])
+			}
+			continue
+		}
+		rawExitedKeys = append(rawExitedKeys, key)
+		formattedExitedKeys = append(formattedExitedKeys, formattedPubKeys[i])
+	}
+	return rawExitedKeys, formattedExitedKeys
+}
@@ -0,0 +1,100 @@
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/v5/testing/validator-mock"
+	"github.com/prysmaticlabs/prysm/v5/validator/accounts/exitvault"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/local"
+	"github.com/sirupsen/logrus/hooks/test"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPreSignVoluntaryExits(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockValidatorClient := validatormock.NewMockValidatorClient(ctrl)
+
+	km, err := local.NewInteropKeymanager(ctx, 0, 3)
+	require.NoError(t, err)
+	validatingPublicKeys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	rawPubKeys, formattedPubKeys := prepareAllKeys(validatingPublicKeys)
+
+	mockValidatorClient.EXPECT().
+		ValidatorIndex(gomock.Any(), &eth.ValidatorIndexRequest{PublicKey: rawPubKeys[0]}).
+		Return(&eth.ValidatorIndexResponse{Index: 10}, nil)
+	mockValidatorClient.EXPECT().
+		ValidatorIndex(gomock.Any(), &eth.ValidatorIndexRequest{PublicKey: rawPubKeys[1]}).
+		Return(nil, errors.New("unknown validator"))
+	mockValidatorClient.EXPECT().
+		ValidatorIndex(gomock.Any(), &eth.ValidatorIndexRequest{PublicKey: rawPubKeys[2]}).
+		Return(&eth.ValidatorIndexResponse{Index: 12}, nil)
+	mockValidatorClient.EXPECT().
+		DomainData(gomock.Any(), &eth.DomainRequest{Epoch: 1000, Domain: []byte{4, 0, 0, 0}}).
+		Times(2).
+		Return(&eth.DomainResponse{SignatureDomain: make([]byte, 32)}, nil)
+
+	logHook := test.NewGlobal()
+	exits := PreSignVoluntaryExits(ctx, PerformExitCfg{
+		ValidatorClient:  mockValidatorClient,
+		Keymanager:       km,
+		RawPubKeys:       rawPubKeys,
+		FormattedPubKeys: formattedPubKeys,
+	}, 1000)
+	assert.LogsContain(t, logHook, "Could not pre-sign voluntary exit for account "+formattedPubKeys[1])
+
+	require.Equal(t, 2, len(exits))
+	assert.Equal(t, validatingPublicKeys[0], exits[0].PublicKey)
+	assert.Equal(t, primitives.ValidatorIndex(10), exits[0].SignedExit.Exit.ValidatorIndex)
+	assert.Equal(t, primitives.Epoch(1000), exits[0].SignedExit.Exit.Epoch)
+	assert.Equal(t, validatingPublicKeys[2], exits[1].PublicKey)
+	assert.Equal(t, primitives.ValidatorIndex(12), exits[1].SignedExit.Exit.ValidatorIndex)
+}
+
+func TestBroadcastVoluntaryExits(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockValidatorClient := validatormock.NewMockValidatorClient(ctrl)
+
+	keys := [][]byte{
+		bytesutil.PadTo([]byte{1}, 48),
+		bytesutil.PadTo([]byte{2}, 48),
+		bytesutil.PadTo([]byte{3}, 48),
+	}
+	formatted := []string{"0x01", "0x02", "0x03"}
+	exits := make([]*exitvault.Exit, 2)
+	for i := range exits {
+		exits[i] = &exitvault.Exit{
+			PublicKey: bytesutil.ToBytes48(keys[i]),
+			SignedExit: &eth.SignedVoluntaryExit{
+				Exit:      &eth.VoluntaryExit{Epoch: 5, ValidatorIndex: primitives.ValidatorIndex(i)},
+				Signature: make([]byte, 96),
+			},
+		}
+	}
+
+	mockValidatorClient.EXPECT().
+		ProposeExit(gomock.Any(), exits[0].SignedExit).
+		Return(&eth.ProposeExitResponse{}, nil)
+	mockValidatorClient.EXPECT().
+		ProposeExit(gomock.Any(), exits[1].SignedExit).
+		Return(nil, errors.New(blocks.ValidatorAlreadyExitedMsg))
+
+	logHook := test.NewGlobal()
+	rawExitedKeys, formattedExitedKeys := BroadcastVoluntaryExits(ctx, mockValidatorClient, exits, keys, formatted)
+	assert.LogsContain(t, logHook, "Could not perform voluntary exit for account 0x02")
+	assert.LogsContain(t, logHook, "Exit vault holds no voluntary exit for account 0x03")
+	require.DeepEqual(t, [][]byte{keys[0]}, rawExitedKeys)
+	require.DeepEqual(t, []string{"0x01"}, formattedExitedKeys)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 	grpcutil "github.com/prysmaticlabs/prysm/v5/api/grpc"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/wallet"
 	beaconApi "github.com/prysmaticlabs/prysm/v5/validator/client/beacon-api"
@@ -61,6 +62,8 @@ type CLIManager struct {
 	rawPubKeys           [][]byte
 	formattedPubKeys     []string
 	exitJSONOutputPath   string
+	exitEpoch            *primitives.Epoch
+	exitVaultPassphrase  string
 	walletDir            string
 	walletPassword       string
 	mnemonic             string
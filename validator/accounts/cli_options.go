@@ -4,6 +4,7 @@ import (
 	"io"
 	"time"
 
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/wallet"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
@@ -205,6 +206,22 @@ func WithExitJSONOutputPath(outputPath string) Option {
 	}
 }
 
+// WithExitEpoch specifies the epoch voluntary exits are pre-signed for.
+func WithExitEpoch(epoch primitives.Epoch) Option {
+	return func(acc *CLIManager) error {
+		acc.exitEpoch = &epoch
+		return nil
+	}
+}
+
+// WithExitVaultPassphrase specifies the passphrase of the exit vault.
+func WithExitVaultPassphrase(passphrase string) Option {
+	return func(acc *CLIManager) error {
+		acc.exitVaultPassphrase = passphrase
+		return nil
+	}
+}
+
 // WithWalletDir specifies the password for backups.
 func WithWalletDir(walletDir string) Option {
 	return func(acc *CLIManager) error {
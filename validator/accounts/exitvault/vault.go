@@ -0,0 +1,175 @@
+// Package exitvault seals voluntary exits pre-signed for validator keys in a vault encrypted with a
+// passphrase, so that the exits can be broadcast once the keys or their signer are no longer available.
+package exitvault
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// Version of the vault format.
+const Version = 1
+
+// ErrWrongPassphrase is returned when a vault cannot be decrypted with the passphrase.
+var ErrWrongPassphrase = errors.New("could not decrypt exit vault, wrong passphrase")
+
+// Vault holds voluntary exits encrypted with a passphrase. The public keys of the exits are in
+// clear, so that the exits to broadcast can be selected before the vault is opened.
+type Vault struct {
+	Version               int                    `json:"version"`
+	GenesisValidatorsRoot string                 `json:"genesis_validators_root"`
+	PublicKeys            []string               `json:"public_keys"`
+	Crypto                map[string]interface{} `json:"crypto"`
+}
+
+// Exit is a voluntary exit signed by a validator key.
+type Exit struct {
+	PublicKey  [fieldparams.BLSPubkeyLength]byte
+	SignedExit *ethpb.SignedVoluntaryExit
+}
+
+// sealedExit is an exit as encrypted in the vault, with the signed exit in the format of the beacon API.
+type sealedExit struct {
+	PublicKey  string                       `json:"public_key"`
+	SignedExit *structs.SignedVoluntaryExit `json:"signed_exit"`
+}
+
+// Seal encrypts the exits of the chain with the genesis validators root in a vault with the passphrase.
+func Seal(exits []*Exit, genesisValidatorsRoot []byte, passphrase string) (*Vault, error) {
+	if passphrase == "" {
+		return nil, errors.New("empty exit vault passphrase")
+	}
+	if len(exits) == 0 {
+		return nil, errors.New("no exits to seal")
+	}
+	if len(genesisValidatorsRoot) != fieldparams.RootLength {
+		return nil, fmt.Errorf("invalid genesis validators root length %d", len(genesisValidatorsRoot))
+	}
+	publicKeys := make([]string, len(exits))
+	sealed := make([]*sealedExit, len(exits))
+	seen := make(map[[fieldparams.BLSPubkeyLength]byte]bool, len(exits))
+	for i, e := range exits {
+		if e.SignedExit == nil || e.SignedExit.Exit == nil {
+			return nil, fmt.Errorf("missing signed exit for public key %#x", e.PublicKey)
+		}
+		if seen[e.PublicKey] {
+			return nil, fmt.Errorf("duplicate exit for public key %#x", e.PublicKey)
+		}
+		seen[e.PublicKey] = true
+		publicKeys[i] = hexutil.Encode(e.PublicKey[:])
+		sealed[i] = &sealedExit{
+			PublicKey:  publicKeys[i],
+			SignedExit: structs.SignedExitFromConsensus(e.SignedExit),
+		}
+	}
+	enc, err := json.Marshal(sealed)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal exits")
+	}
+	cryptoFields, err := keystorev4.New().Encrypt(enc, passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encrypt exits")
+	}
+	return &Vault{
+		Version:               Version,
+		GenesisValidatorsRoot: hexutil.Encode(genesisValidatorsRoot),
+		PublicKeys:            publicKeys,
+		Crypto:                cryptoFields,
+	}, nil
+}
+
+// Merge returns the exits followed by the existing exits of other public keys. The exits replace
+// the existing exits of the same public keys.
+func Merge(existing, exits []*Exit) []*Exit {
+	replaced := make(map[[fieldparams.BLSPubkeyLength]byte]bool, len(exits))
+	for _, e := range exits {
+		replaced[e.PublicKey] = true
+	}
+	merged := make([]*Exit, 0, len(existing)+len(exits))
+	merged = append(merged, exits...)
+	for _, e := range existing {
+		if !replaced[e.PublicKey] {
+			merged = append(merged, e)
+		}
+	}
+	return merged
+}
+
+// Open decrypts the exits of the vault with the passphrase.
+func (v *Vault) Open(passphrase string) ([]*Exit, error) {
+	enc, err := keystorev4.New().Decrypt(v.Crypto, passphrase)
+	if err != nil {
+		return nil, errors.Wrap(ErrWrongPassphrase, err.Error())
+	}
+	var sealed []*sealedExit
+	if err := json.Unmarshal(enc, &sealed); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal exits")
+	}
+	// The public keys in clear are not covered by the encryption: they must be the ones of the exits.
+	if len(sealed) != len(v.PublicKeys) {
+		return nil, fmt.Errorf("exit vault lists %d public keys but holds %d exits", len(v.PublicKeys), len(sealed))
+	}
+	exits := make([]*Exit, len(sealed))
+	for i, s := range sealed {
+		if s.PublicKey != v.PublicKeys[i] {
+			return nil, fmt.Errorf("exit vault lists public key %s but holds an exit for %s", v.PublicKeys[i], s.PublicKey)
+		}
+		publicKey, err := bytesutil.DecodeHexWithLength(s.PublicKey, fieldparams.BLSPubkeyLength)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode public key %s", s.PublicKey)
+		}
+		if s.SignedExit == nil || s.SignedExit.Message == nil {
+			return nil, fmt.Errorf("missing signed exit for public key %s", s.PublicKey)
+		}
+		signedExit, err := s.SignedExit.ToConsensus()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode signed exit for public key %s", s.PublicKey)
+		}
+		exits[i] = &Exit{
+			PublicKey:  bytesutil.ToBytes48(publicKey),
+			SignedExit: signedExit,
+		}
+	}
+	return exits, nil
+}
+
+// ValidatingPublicKeys returns the public keys the vault holds exits for.
+func (v *Vault) ValidatingPublicKeys() ([][fieldparams.BLSPubkeyLength]byte, error) {
+	publicKeys := make([][fieldparams.BLSPubkeyLength]byte, len(v.PublicKeys))
+	for i, pk := range v.PublicKeys {
+		decoded, err := bytesutil.DecodeHexWithLength(pk, fieldparams.BLSPubkeyLength)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode public key %s", pk)
+		}
+		publicKeys[i] = bytesutil.ToBytes48(decoded)
+	}
+	return publicKeys, nil
+}
+
+// Encode encodes the vault as JSON, to be exported as a bundle or saved in the validator database.
+func (v *Vault) Encode() ([]byte, error) {
+	return json.MarshalIndent(v, "", "\t")
+}
+
+// Decode decodes a vault encoded as JSON.
+func Decode(enc []byte) (*Vault, error) {
+	v := &Vault{}
+	if err := json.Unmarshal(enc, v); err != nil {
+		return nil, errors.Wrap(err, "could not decode exit vault")
+	}
+	if v.Version != Version {
+		return nil, fmt.Errorf("unsupported exit vault version %d", v.Version)
+	}
+	if v.Crypto == nil {
+		return nil, errors.New("exit vault holds no encrypted exits")
+	}
+	return v, nil
+}
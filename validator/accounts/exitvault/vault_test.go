@@ -0,0 +1,102 @@
+package exitvault_test
+
+import (
+	"strings"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/accounts/exitvault"
+)
+
+var genesisValidatorsRoot = bytesutil.PadTo([]byte("genesis validators root"), fieldparams.RootLength)
+
+func testExits(n int) []*exitvault.Exit {
+	exits := make([]*exitvault.Exit, n)
+	for i := range exits {
+		exits[i] = &exitvault.Exit{
+			PublicKey: bytesutil.ToBytes48([]byte{byte(i + 1)}),
+			SignedExit: &ethpb.SignedVoluntaryExit{
+				Exit:      &ethpb.VoluntaryExit{Epoch: 100, ValidatorIndex: primitives.ValidatorIndex(10 + i)},
+				Signature: bytesutil.PadTo([]byte{byte(i + 1)}, fieldparams.BLSSignatureLength),
+			},
+		}
+	}
+	return exits
+}
+
+func TestVault_SealOpen(t *testing.T) {
+	exits := testExits(3)
+	vault, err := exitvault.Seal(exits, genesisValidatorsRoot, "passphrase")
+	require.NoError(t, err)
+
+	enc, err := vault.Encode()
+	require.NoError(t, err)
+	assert.Equal(t, false, strings.Contains(string(enc), "signature"), "signed exits are not encrypted")
+	vault, err = exitvault.Decode(enc)
+	require.NoError(t, err)
+
+	publicKeys, err := vault.ValidatingPublicKeys()
+	require.NoError(t, err)
+	require.Equal(t, len(exits), len(publicKeys))
+	for i, e := range exits {
+		assert.Equal(t, e.PublicKey, publicKeys[i])
+	}
+
+	_, err = vault.Open("wrong")
+	require.ErrorIs(t, err, exitvault.ErrWrongPassphrase)
+
+	opened, err := vault.Open("passphrase")
+	require.NoError(t, err)
+	require.DeepEqual(t, exits, opened)
+}
+
+func TestMerge(t *testing.T) {
+	existing := testExits(3)
+	replacement := testExits(4)[1:]
+	replacement[0].SignedExit.Exit.Epoch = 200
+	merged := exitvault.Merge(existing, replacement)
+	require.Equal(t, 4, len(merged))
+	// The new exits come first and replace the existing ones of the same public keys.
+	assert.DeepEqual(t, replacement[0], merged[0])
+	assert.DeepEqual(t, replacement[1], merged[1])
+	assert.DeepEqual(t, replacement[2], merged[2])
+	assert.DeepEqual(t, existing[0], merged[3])
+	assert.Equal(t, primitives.Epoch(200), merged[0].SignedExit.Exit.Epoch)
+}
+
+func TestVault_Open_TamperedPublicKeys(t *testing.T) {
+	exits := testExits(2)
+	vault, err := exitvault.Seal(exits, genesisValidatorsRoot, "passphrase")
+	require.NoError(t, err)
+	vault.PublicKeys[0], vault.PublicKeys[1] = vault.PublicKeys[1], vault.PublicKeys[0]
+	_, err = vault.Open("passphrase")
+	assert.ErrorContains(t, "but holds an exit for", err)
+
+	vault.PublicKeys = vault.PublicKeys[:1]
+	_, err = vault.Open("passphrase")
+	assert.ErrorContains(t, "lists 1 public keys but holds 2 exits", err)
+}
+
+func TestSeal_Invalid(t *testing.T) {
+	exits := testExits(1)
+	_, err := exitvault.Seal(exits, genesisValidatorsRoot, "")
+	assert.ErrorContains(t, "empty exit vault passphrase", err)
+	_, err = exitvault.Seal(nil, genesisValidatorsRoot, "passphrase")
+	assert.ErrorContains(t, "no exits to seal", err)
+	_, err = exitvault.Seal(exits, []byte{1}, "passphrase")
+	assert.ErrorContains(t, "invalid genesis validators root length", err)
+	_, err = exitvault.Seal(append(exits, exits[0]), genesisValidatorsRoot, "passphrase")
+	assert.ErrorContains(t, "duplicate exit", err)
+}
+
+func TestDecode_UnsupportedVersion(t *testing.T) {
+	_, err := exitvault.Decode([]byte(`{"version":2,"crypto":{}}`))
+	assert.ErrorContains(t, "unsupported exit vault version 2", err)
+	_, err = exitvault.Decode([]byte(`{"version":1}`))
+	assert.ErrorContains(t, "holds no encrypted exits", err)
+}
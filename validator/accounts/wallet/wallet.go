@@ -16,9 +16,12 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/iface"
 	accountsprompt "github.com/prysmaticlabs/prysm/v5/validator/accounts/userprompt"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/composite"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/derived"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/local"
+	remotegrpc "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-grpc"
 	remoteweb3signer "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/threshold"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 )
@@ -363,12 +366,96 @@ func (w *Wallet) InitializeKeymanager(ctx context.Context, cfg iface.InitKeymana
 		if err != nil {
 			return nil, errors.Wrap(err, "could not initialize web3signer keymanager")
 		}
+	case keymanager.Threshold:
+		fileConfig, err := threshold.ReadFileConfig(filepath.Join(w.accountsPath, threshold.ConfigFileName))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read threshold keymanager configuration")
+		}
+		config, err := fileConfig.SetupConfig(w.walletPassword)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read threshold keymanager configuration")
+		}
+		km, err = threshold.NewKeymanager(ctx, config)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not initialize threshold keymanager")
+		}
+	case keymanager.Composite:
+		km, err = w.initializeCompositeKeymanager(ctx, cfg)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not initialize composite keymanager")
+		}
+	case keymanager.RemoteGRPC:
+		fileConfig, err := remotegrpc.ReadFileConfig(filepath.Join(w.accountsPath, remotegrpc.ConfigFileName))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read remote-grpc keymanager configuration")
+		}
+		config, err := fileConfig.SetupConfig()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read remote-grpc keymanager configuration")
+		}
+		km, err = remotegrpc.NewKeymanager(ctx, config)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not initialize remote-grpc keymanager")
+		}
 	default:
 		return nil, fmt.Errorf("keymanager kind not supported: %s", w.keymanagerKind)
 	}
 	return km, nil
 }
 
+// initializeCompositeKeymanager signs with the keystore of the local or derived wallet nested
+// in the composite directory of the wallet, and with the web3signers of its configuration file.
+func (w *Wallet) initializeCompositeKeymanager(ctx context.Context, cfg iface.InitKeymanagerConfig) (*composite.Keymanager, error) {
+	fileConfig, err := composite.ReadFileConfig(filepath.Join(w.accountsPath, composite.ConfigFileName))
+	if err != nil {
+		return nil, err
+	}
+	web3SignerConfigs, err := fileConfig.Web3SignerSetupConfigs()
+	if err != nil {
+		return nil, err
+	}
+	setupConfig := &composite.SetupConfig{ListenForChanges: cfg.ListenForChanges}
+	for _, config := range web3SignerConfigs {
+		km, err := remoteweb3signer.NewKeymanager(ctx, config)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not initialize web3signer keymanager for %s", config.BaseEndpoint)
+		}
+		setupConfig.Web3Signers = append(setupConfig.Web3Signers, &composite.Web3Signer{
+			URLs:       append([]string{config.BaseEndpoint}, config.BackupEndpoints...),
+			Keymanager: km,
+		})
+	}
+
+	localWallet := New(&Config{WalletDir: w.accountsPath, KeymanagerKind: keymanager.Local, WalletPassword: w.walletPassword})
+	derivedWallet := New(&Config{WalletDir: w.accountsPath, KeymanagerKind: keymanager.Derived, WalletPassword: w.walletPassword})
+	hasLocalKeys, err := file.Exists(filepath.Join(localWallet.AccountsDir(), local.AccountsPath, local.AccountsKeystoreFileName), file.Regular)
+	if err != nil {
+		return nil, err
+	}
+	hasDerivedKeys, err := file.Exists(filepath.Join(derivedWallet.AccountsDir(), local.AccountsPath, local.AccountsKeystoreFileName), file.Regular)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case hasLocalKeys && hasDerivedKeys:
+		return nil, fmt.Errorf("both a local and a derived wallet found in %s, a composite wallet can only hold one", w.accountsPath)
+	case hasDerivedKeys:
+		setupConfig.Keystore, err = derived.NewKeymanager(ctx, &derived.SetupConfig{
+			Wallet:           derivedWallet,
+			ListenForChanges: cfg.ListenForChanges,
+		})
+	default:
+		setupConfig.Keystore, err = local.NewKeymanager(ctx, &local.SetupConfig{
+			Wallet:           localWallet,
+			ListenForChanges: cfg.ListenForChanges,
+		})
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize keystore")
+	}
+	return composite.NewKeymanager(ctx, setupConfig)
+}
+
 // WriteFileAtPath within the wallet directory given the desired path, filename, and raw data.
 func (w *Wallet) WriteFileAtPath(_ context.Context, filePath, fileName string, data []byte) (bool /* exited previously */, error) {
 	accountPath := filepath.Join(w.accountsPath, filePath)
@@ -3,12 +3,16 @@ package accounts
 import (
 	"context"
 	"encoding/json"
+	"path/filepath"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/wallet"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/composite"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/derived"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/local"
+	remotegrpc "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-grpc"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/threshold"
 )
 
 // WalletCreate creates wallet specified by configuration options.
@@ -55,6 +59,37 @@ func (acm *CLIManager) WalletCreate(ctx context.Context) (*wallet.Wallet, error)
 		)
 	case keymanager.Web3Signer:
 		return nil, errors.New("web3signer keymanager does not require persistent wallets.")
+	case keymanager.Threshold:
+		if err := w.SaveWallet(); err != nil {
+			return nil, errors.Wrap(err, "could not initialize wallet: could not save wallet to disk")
+		}
+		log.WithField("walletDir", acm.walletDir).Infof(
+			"Successfully created threshold wallet, write its shares and peers to %s with `prysmctl validator threshold split`",
+			filepath.Join(w.AccountsDir(), threshold.ConfigFileName),
+		)
+	case keymanager.Composite:
+		if err := w.SaveWallet(); err != nil {
+			return nil, errors.Wrap(err, "could not initialize wallet: could not save wallet to disk")
+		}
+		encodedConfig, err := json.MarshalIndent(&composite.FileConfig{Web3Signers: []*composite.Web3SignerConfig{}}, "", "\t")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.WriteFileAtPath(ctx, "", composite.ConfigFileName, encodedConfig); err != nil {
+			return nil, err
+		}
+		log.WithField("walletDir", acm.walletDir).Infof(
+			"Successfully created composite wallet, import keystores into it and add its web3signers to %s",
+			filepath.Join(w.AccountsDir(), composite.ConfigFileName),
+		)
+	case keymanager.RemoteGRPC:
+		if err := w.SaveWallet(); err != nil {
+			return nil, errors.Wrap(err, "could not initialize wallet: could not save wallet to disk")
+		}
+		log.WithField("walletDir", acm.walletDir).Infof(
+			"Successfully created remote-grpc wallet, add the address of its signer and its TLS files to %s",
+			filepath.Join(w.AccountsDir(), remotegrpc.ConfigFileName),
+		)
 	default:
 		return nil, errors.Wrapf(err, errKeymanagerNotSupported, w.KeymanagerKind())
 	}
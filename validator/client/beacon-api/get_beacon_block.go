@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	neturl "net/url"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
@@ -22,12 +23,13 @@ type abstractProduceBlockResponseJson struct {
 	Data    json.RawMessage `json:"data"`
 }
 
-func (c *beaconApiValidatorClient) beaconBlock(ctx context.Context, slot primitives.Slot, randaoReveal, graffiti []byte) (*ethpb.GenericBeaconBlock, error) {
+func (c *beaconApiValidatorClient) beaconBlock(ctx context.Context, slot primitives.Slot, randaoReveal, graffiti []byte, builderPolicy *ethpb.BuilderPolicy) (*ethpb.GenericBeaconBlock, error) {
 	queryParams := neturl.Values{}
 	queryParams.Add("randao_reveal", hexutil.Encode(randaoReveal))
 	if len(graffiti) > 0 {
 		queryParams.Add("graffiti", hexutil.Encode(graffiti))
 	}
+	addBuilderPolicyParams(queryParams, builderPolicy)
 
 	var ver string
 	var blinded bool
@@ -189,3 +191,25 @@ func (c *beaconApiValidatorClient) fallBackToFull(
 	}
 	return resp, nil
 }
+
+// addBuilderPolicyParams adds the fields set in the builder policy as query parameters of the block request.
+func addBuilderPolicyParams(queryParams neturl.Values, policy *ethpb.BuilderPolicy) {
+	if policy == nil {
+		return
+	}
+	fields := []struct {
+		name  string
+		value *uint64
+	}{
+		{"local_block_value_boost", policy.LocalBlockValueBoost},
+		{"min_builder_bid", policy.MinBuilderBid},
+		{"min_builder_to_local_difference", policy.MinBuilderToLocalDifference},
+		{"max_builder_consecutive_missed_slots", policy.MaxBuilderConsecutiveMissedSlots},
+		{"max_builder_epoch_missed_slots", policy.MaxBuilderEpochMissedSlots},
+	}
+	for _, p := range fields {
+		if p.value != nil {
+			queryParams.Add(p.name, strconv.FormatUint(*p.value, 10))
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package multi_node
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	nodeHealthScore = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_health_score",
+			Help:      "Health score of each beacon node used in active-active mode, between 0 (failing) and 1 (healthy).",
+		},
+		[]string{"host"},
+	)
+	nodeRequestLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_request_latency_seconds",
+			Help:      "Latency of the requests made to each beacon node used in active-active mode in seconds.",
+			Buckets:   []float64{0.001, 0.01, 0.025, 0.1, 0.25, 1, 2.5, 10},
+		},
+		[]string{"host", "method"},
+	)
+	nodeRequestFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_request_failures_total",
+			Help:      "Number of failed requests made to each beacon node used in active-active mode.",
+		},
+		[]string{"host", "method"},
+	)
+	attestationDataDisagreements = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_attestation_data_disagreements_total",
+			Help:      "Number of times the beacon nodes used in active-active mode returned different attestation data.",
+		},
+	)
+)
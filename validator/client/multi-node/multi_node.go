@@ -0,0 +1,199 @@
+// Package multi_node implements a validator client using several beacon nodes at the same time.
+// Duties and attestation data are requested from several nodes, and signed objects are broadcast
+// to every healthy node, so that a single beacon node outage does not cause missed duties.
+package multi_node
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+)
+
+const (
+	// healthyThreshold is the score from which a node is considered healthy.
+	healthyThreshold = 0.5
+	// successWeight is the fraction of the distance to a perfect score recovered on a successful request.
+	successWeight = 0.2
+	// failureWeight is the fraction of the score lost on a failed request.
+	failureWeight = 0.5
+)
+
+// node is a beacon node along with its health score.
+type node struct {
+	client iface.ValidatorClient
+	host   string
+
+	sync.Mutex
+	score float64
+}
+
+func newNode(host string, client iface.ValidatorClient) *node {
+	n := &node{client: client, host: host, score: 1}
+	nodeHealthScore.WithLabelValues(n.host).Set(n.score)
+	return n
+}
+
+// record updates the health score and the metrics of the node with the outcome of a request.
+func (n *node) record(method string, start time.Time, err error) {
+	n.Lock()
+	defer n.Unlock()
+	if err != nil {
+		n.score -= n.score * failureWeight
+		nodeRequestFailures.WithLabelValues(n.host, method).Inc()
+		log.WithError(err).WithField("host", n.host).WithField("method", method).Debug("Beacon node request failed")
+	} else {
+		n.score += (1 - n.score) * successWeight
+		nodeRequestLatency.WithLabelValues(n.host, method).Observe(time.Since(start).Seconds())
+	}
+	nodeHealthScore.WithLabelValues(n.host).Set(n.score)
+}
+
+func (n *node) healthScore() float64 {
+	n.Lock()
+	defer n.Unlock()
+	return n.score
+}
+
+func (n *node) healthy() bool {
+	return n.healthScore() >= healthyThreshold
+}
+
+// client implements iface.ValidatorClient on top of several beacon nodes. The first node is the primary one,
+// used for the requests that can only be served by a single node when it is healthy.
+type client struct {
+	sync.RWMutex
+	nodes     []*node
+	streaming bool
+}
+
+// NewValidatorClient returns a validator client using all the given beacon node clients, the client
+// at each index being connected to the host at the same index. The first one is the primary node.
+func NewValidatorClient(hosts []string, clients []iface.ValidatorClient) (iface.ValidatorClient, error) {
+	if len(clients) == 0 {
+		return nil, errors.New("no beacon node clients provided")
+	}
+	if len(hosts) != len(clients) {
+		return nil, errors.Errorf("got %d hosts for %d beacon node clients", len(hosts), len(clients))
+	}
+	nodes := make([]*node, len(clients))
+	for i, c := range clients {
+		nodes[i] = newNode(hosts[i], c)
+	}
+	return &client{nodes: nodes}, nil
+}
+
+// orderedNodes returns the nodes with the healthy ones first, keeping the configured order otherwise.
+func (c *client) orderedNodes() []*node {
+	c.RLock()
+	nodes := make([]*node, len(c.nodes))
+	copy(nodes, c.nodes)
+	c.RUnlock()
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].healthy() && !nodes[j].healthy()
+	})
+	return nodes
+}
+
+// healthyNodes returns the healthy nodes, or all the nodes if none of them is healthy.
+func (c *client) healthyNodes() []*node {
+	nodes := c.orderedNodes()
+	for i, n := range nodes {
+		if !n.healthy() {
+			if i == 0 {
+				return nodes
+			}
+			return nodes[:i]
+		}
+	}
+	return nodes
+}
+
+// result is the outcome of a request to a node.
+type result[T any] struct {
+	node *node
+	resp T
+	err  error
+}
+
+// failover sends the request to one node at a time, healthy nodes first, until one of them succeeds.
+func failover[T any](ctx context.Context, c *client, method string, f func(context.Context, iface.ValidatorClient) (T, error)) (T, error) {
+	var errs []error
+	for _, n := range c.orderedNodes() {
+		start := time.Now()
+		resp, err := f(ctx, n.client)
+		n.record(method, start, err)
+		if err == nil {
+			return resp, nil
+		}
+		errs = append(errs, errors.Wrap(err, n.host))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	var zero T
+	return zero, allFailed(method, errs)
+}
+
+// firstSuccess sends the request to the given nodes in parallel and returns the first successful response, or
+// an error once all of them have failed, so that a slow or unreachable node does not delay the outcome. The
+// requests still running complete in the background, after which done, when set, is called with the first
+// successful response and the outcomes of all the nodes.
+func firstSuccess[T any](
+	ctx context.Context,
+	nodes []*node,
+	method string,
+	f func(context.Context, iface.ValidatorClient) (T, error),
+	done func(first T, results []result[T]),
+) (T, error) {
+	var zero T
+	results := make(chan result[T], len(nodes))
+	for _, n := range nodes {
+		go func(n *node) {
+			start := time.Now()
+			resp, err := f(ctx, n.client)
+			n.record(method, start, err)
+			results <- result[T]{node: n, resp: resp, err: err}
+		}(n)
+	}
+	received := make([]result[T], 0, len(nodes))
+	var errs []error
+	for range nodes {
+		select {
+		case r := <-results:
+			received = append(received, r)
+			if r.err != nil {
+				errs = append(errs, errors.Wrap(r.err, r.node.host))
+				continue
+			}
+			if done != nil {
+				go func() {
+					for len(received) < len(nodes) {
+						received = append(received, <-results)
+					}
+					done(r.resp, received)
+				}()
+			}
+			return r.resp, nil
+		case <-ctx.Done():
+			return zero, errors.Wrap(ctx.Err(), method)
+		}
+	}
+	return zero, allFailed(method, errs)
+}
+
+// broadcast sends the request to every healthy node and succeeds as soon as one of them succeeds.
+func broadcast[T any](ctx context.Context, c *client, method string, f func(context.Context, iface.ValidatorClient) (T, error)) (T, error) {
+	return firstSuccess(ctx, c.healthyNodes(), method, f, nil)
+}
+
+// allFailed returns the error of the last node tried. The errors of every node are logged when recorded.
+func allFailed(method string, errs []error) error {
+	if len(errs) == 0 {
+		return errors.Errorf("%s: no beacon node available", method)
+	}
+	return errors.Wrapf(errs[len(errs)-1], "%s failed on all %d beacon nodes", method, len(errs))
+}
@@ -0,0 +1,234 @@
+package multi_node
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/api/client/event"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/v5/testing/validator-mock"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+	"go.uber.org/mock/gomock"
+)
+
+func setupClient(t *testing.T, n int) (*client, []*validatormock.MockValidatorClient) {
+	ctrl := gomock.NewController(t)
+	mocks := make([]*validatormock.MockValidatorClient, n)
+	clients := make([]iface.ValidatorClient, n)
+	hosts := make([]string, n)
+	for i := range mocks {
+		mocks[i] = validatormock.NewMockValidatorClient(ctrl)
+		clients[i] = mocks[i]
+		hosts[i] = string(rune('a' + i))
+	}
+	vc, err := NewValidatorClient(hosts, clients)
+	require.NoError(t, err)
+	return vc.(*client), mocks
+}
+
+func TestNewValidatorClient(t *testing.T) {
+	_, err := NewValidatorClient(nil, nil)
+	require.ErrorContains(t, "no beacon node clients provided", err)
+	_, err = NewValidatorClient([]string{"a"}, []iface.ValidatorClient{nil, nil})
+	require.ErrorContains(t, "got 1 hosts for 2 beacon node clients", err)
+}
+
+func TestAttestationData(t *testing.T) {
+	dataA := &ethpb.AttestationData{Slot: 1, BeaconBlockRoot: make([]byte, 32), Source: &ethpb.Checkpoint{Root: make([]byte, 32)}, Target: &ethpb.Checkpoint{Root: make([]byte, 32)}}
+	dataB := &ethpb.AttestationData{Slot: 1, BeaconBlockRoot: make([]byte, 32), Source: &ethpb.Checkpoint{Root: make([]byte, 32)}, Target: &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)}}
+	ctx := context.Background()
+	req := &ethpb.AttestationDataRequest{Slot: 1}
+
+	t.Run("majority", func(t *testing.T) {
+		c, mocks := setupClient(t, 3)
+		mocks[0].EXPECT().AttestationData(gomock.Any(), req).Return(dataB, nil)
+		mocks[1].EXPECT().AttestationData(gomock.Any(), req).Return(dataA, nil)
+		mocks[2].EXPECT().AttestationData(gomock.Any(), req).Return(dataA, nil)
+		data, err := c.AttestationData(ctx, req)
+		require.NoError(t, err)
+		assert.DeepEqual(t, dataA, data)
+	})
+	t.Run("failing node", func(t *testing.T) {
+		c, mocks := setupClient(t, 2)
+		mocks[0].EXPECT().AttestationData(gomock.Any(), req).Return(nil, errors.New("bad"))
+		mocks[1].EXPECT().AttestationData(gomock.Any(), req).Return(dataB, nil)
+		data, err := c.AttestationData(ctx, req)
+		require.NoError(t, err)
+		assert.DeepEqual(t, dataB, data)
+		assert.Equal(t, 0.5, c.nodes[0].healthScore())
+	})
+	t.Run("all nodes failing", func(t *testing.T) {
+		c, mocks := setupClient(t, 2)
+		mocks[0].EXPECT().AttestationData(gomock.Any(), req).Return(nil, errors.New("bad"))
+		mocks[1].EXPECT().AttestationData(gomock.Any(), req).Return(nil, errors.New("bad"))
+		_, err := c.AttestationData(ctx, req)
+		require.ErrorContains(t, "AttestationData failed on all 2 beacon nodes", err)
+	})
+}
+
+func TestBroadcast(t *testing.T) {
+	ctx := context.Background()
+	att := &ethpb.Attestation{}
+
+	t.Run("one node succeeding", func(t *testing.T) {
+		c, mocks := setupClient(t, 3)
+		var wg sync.WaitGroup
+		wg.Add(3)
+		mocks[0].EXPECT().ProposeAttestation(gomock.Any(), att).Do(func(context.Context, *ethpb.Attestation) { wg.Done() }).Return(nil, errors.New("bad"))
+		mocks[1].EXPECT().ProposeAttestation(gomock.Any(), att).Do(func(context.Context, *ethpb.Attestation) { wg.Done() }).Return(&ethpb.AttestResponse{AttestationDataRoot: []byte{'b'}}, nil)
+		mocks[2].EXPECT().ProposeAttestation(gomock.Any(), att).Do(func(context.Context, *ethpb.Attestation) { wg.Done() }).Return(&ethpb.AttestResponse{AttestationDataRoot: []byte{'b'}}, nil)
+		resp, err := c.ProposeAttestation(ctx, att)
+		require.NoError(t, err)
+		assert.DeepEqual(t, []byte{'b'}, resp.AttestationDataRoot)
+		// The attestation is sent to every node, even once one of them succeeded.
+		wg.Wait()
+	})
+	t.Run("slow node", func(t *testing.T) {
+		c, mocks := setupClient(t, 2)
+		release, released := make(chan struct{}), make(chan struct{})
+		mocks[0].EXPECT().ProposeAttestation(gomock.Any(), att).DoAndReturn(func(context.Context, *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+			defer close(released)
+			<-release
+			return nil, errors.New("timeout")
+		})
+		mocks[1].EXPECT().ProposeAttestation(gomock.Any(), att).Return(&ethpb.AttestResponse{}, nil)
+		_, err := c.ProposeAttestation(ctx, att)
+		require.NoError(t, err)
+		close(release)
+		<-released
+	})
+	t.Run("unhealthy node skipped", func(t *testing.T) {
+		c, mocks := setupClient(t, 2)
+		c.nodes[0].score = healthyThreshold / 2
+		mocks[1].EXPECT().ProposeAttestation(gomock.Any(), att).Return(&ethpb.AttestResponse{}, nil)
+		_, err := c.ProposeAttestation(ctx, att)
+		require.NoError(t, err)
+	})
+	t.Run("all nodes failing", func(t *testing.T) {
+		c, mocks := setupClient(t, 2)
+		mocks[0].EXPECT().ProposeAttestation(gomock.Any(), att).Return(nil, errors.New("bad"))
+		mocks[1].EXPECT().ProposeAttestation(gomock.Any(), att).Return(nil, errors.New("bad"))
+		_, err := c.ProposeAttestation(ctx, att)
+		require.ErrorContains(t, "ProposeAttestation failed on all 2 beacon nodes", err)
+	})
+}
+
+func TestDuties(t *testing.T) {
+	ctx := context.Background()
+	req := &ethpb.DutiesRequest{}
+	duties := &ethpb.DutiesResponse{CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{{ValidatorIndex: 1}}}
+	c, mocks := setupClient(t, 3)
+	// The unhealthy node is not queried, and the slow one does not delay the duties.
+	c.nodes[0].score = healthyThreshold / 2
+	release, released := make(chan struct{}), make(chan struct{})
+	mocks[1].EXPECT().Duties(gomock.Any(), req).DoAndReturn(func(context.Context, *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
+		defer close(released)
+		<-release
+		return nil, errors.New("timeout")
+	})
+	mocks[2].EXPECT().Duties(gomock.Any(), req).Return(duties, nil)
+	resp, err := c.Duties(ctx, req)
+	require.NoError(t, err)
+	assert.DeepEqual(t, duties, resp)
+	close(release)
+	<-released
+}
+
+func TestStartEventStream(t *testing.T) {
+	eventStreamCheckInterval = 10 * time.Millisecond
+	c, mocks := setupClient(t, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	topics := []string{event.EventHead}
+
+	// The stream of the first node stops, so the events of the second node are streamed next.
+	mocks[0].EXPECT().StartEventStream(gomock.Any(), topics, gomock.Any()).Do(func(_ context.Context, _ []string, ch chan<- *event.Event) {
+		ch <- &event.Event{EventType: event.EventHead, Data: []byte("a")}
+	})
+	mocks[1].EXPECT().StartEventStream(gomock.Any(), topics, gomock.Any()).Do(func(ctx context.Context, _ []string, ch chan<- *event.Event) {
+		ch <- &event.Event{EventType: event.EventHead, Data: []byte("b")}
+		<-ctx.Done()
+		close(ch)
+	})
+	events := make(chan *event.Event)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.StartEventStream(ctx, topics, events)
+	}()
+	assert.DeepEqual(t, []byte("a"), (<-events).Data)
+	assert.DeepEqual(t, []byte("b"), (<-events).Data)
+	assert.Equal(t, true, c.EventStreamIsRunning())
+	assert.Equal(t, 0.5, c.nodes[0].healthScore())
+	cancel()
+	<-done
+	assert.Equal(t, false, c.EventStreamIsRunning())
+}
+
+func TestStartEventStream_UnhealthyNode(t *testing.T) {
+	eventStreamCheckInterval = 10 * time.Millisecond
+	c, mocks := setupClient(t, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	topics := []string{event.EventHead}
+
+	// The first node becomes unhealthy while its stream is running, so the stream moves to the second node.
+	mocks[0].EXPECT().StartEventStream(gomock.Any(), topics, gomock.Any()).Do(func(ctx context.Context, _ []string, ch chan<- *event.Event) {
+		ch <- &event.Event{EventType: event.EventHead, Data: []byte("a")}
+		<-ctx.Done()
+		close(ch)
+	})
+	mocks[1].EXPECT().StartEventStream(gomock.Any(), topics, gomock.Any()).Do(func(ctx context.Context, _ []string, ch chan<- *event.Event) {
+		ch <- &event.Event{EventType: event.EventHead, Data: []byte("b")}
+		<-ctx.Done()
+		close(ch)
+	})
+	events := make(chan *event.Event)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.StartEventStream(ctx, topics, events)
+	}()
+	assert.DeepEqual(t, []byte("a"), (<-events).Data)
+	c.nodes[0].record("Duties", time.Now(), errors.New("bad"))
+	c.nodes[0].record("Duties", time.Now(), errors.New("bad"))
+	assert.DeepEqual(t, []byte("b"), (<-events).Data)
+	cancel()
+	<-done
+}
+
+func TestFailover(t *testing.T) {
+	ctx := context.Background()
+	req := &ethpb.ValidatorIndexRequest{}
+	c, mocks := setupClient(t, 2)
+
+	mocks[0].EXPECT().ValidatorIndex(gomock.Any(), req).Return(nil, errors.New("bad")).Times(2)
+	mocks[1].EXPECT().ValidatorIndex(gomock.Any(), req).Return(&ethpb.ValidatorIndexResponse{Index: 1}, nil).Times(3)
+	for i := 0; i < 2; i++ {
+		resp, err := c.ValidatorIndex(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, primitives.ValidatorIndex(1), resp.Index)
+	}
+	// The primary node is now unhealthy, so the second node is tried first.
+	assert.Equal(t, false, c.nodes[0].healthy())
+	assert.Equal(t, "b", c.Host())
+	_, err := c.ValidatorIndex(ctx, req)
+	require.NoError(t, err)
+}
+
+func TestSetHost(t *testing.T) {
+	c, _ := setupClient(t, 3)
+	assert.Equal(t, "a", c.Host())
+	c.SetHost("c")
+	assert.Equal(t, "c", c.Host())
+	assert.Equal(t, "a", c.nodes[1].host)
+	assert.Equal(t, "b", c.nodes[2].host)
+	c.SetHost("unknown")
+	assert.Equal(t, "c", c.Host())
+}
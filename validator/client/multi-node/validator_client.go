@@ -0,0 +1,398 @@
+package multi_node
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/event"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+	"google.golang.org/protobuf/proto"
+)
+
+// errEventStreamStopped is recorded as a failure of the node whose event stream stopped.
+var errEventStreamStopped = errors.New("event stream stopped")
+
+// eventStreamCheckInterval is how often the health of the node serving the event stream is checked.
+var eventStreamCheckInterval = 2 * time.Second
+
+// attestationDataQuorumWait is how long to wait for a majority of the nodes to agree on the attestation data
+// once the first response is received, after which the data with the most agreement is used.
+var attestationDataQuorumWait = 500 * time.Millisecond
+
+// Duties requests the duties from every healthy node and returns the first response. The responses of the
+// other nodes are compared with it once they are received.
+func (c *client) Duties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
+	return firstSuccess(ctx, c.healthyNodes(), "Duties", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.DutiesResponse, error) {
+		return vc.Duties(ctx, in)
+	}, func(first *ethpb.DutiesResponse, results []result[*ethpb.DutiesResponse]) {
+		for _, r := range results {
+			if r.err == nil && !proto.Equal(r.resp, first) {
+				log.WithField("host", r.node.host).Warn("Beacon nodes returned different duties")
+			}
+		}
+	})
+}
+
+// AttestationData requests the attestation data from every node and returns the data that a majority of
+// the nodes agree on. When no majority is reached, the data returned by the most nodes is used, the earliest
+// response winning ties.
+func (c *client) AttestationData(ctx context.Context, in *ethpb.AttestationDataRequest) (*ethpb.AttestationData, error) {
+	const method = "AttestationData"
+	nodes := c.orderedNodes()
+	quorum := len(nodes)/2 + 1
+	results := make(chan result[*ethpb.AttestationData], len(nodes))
+	for _, n := range nodes {
+		go func(n *node) {
+			start := time.Now()
+			resp, err := n.client.AttestationData(ctx, in)
+			n.record(method, start, err)
+			results <- result[*ethpb.AttestationData]{node: n, resp: resp, err: err}
+		}(n)
+	}
+
+	votes := make(map[[32]byte]int)
+	var best *ethpb.AttestationData
+	var bestVotes int
+	var errs []error
+	var quorumWait <-chan time.Time
+	for received := 0; received < len(nodes); {
+		select {
+		case r := <-results:
+			received++
+			if r.err != nil {
+				errs = append(errs, r.err)
+				continue
+			}
+			root, err := r.resp.HashTreeRoot()
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			votes[root]++
+			if votes[root] > bestVotes {
+				best, bestVotes = r.resp, votes[root]
+			}
+			if bestVotes >= quorum {
+				reportDisagreement(votes)
+				return best, nil
+			}
+			if quorumWait == nil {
+				quorumWait = time.After(attestationDataQuorumWait)
+			}
+		case <-quorumWait:
+			log.WithField("agreeingNodes", bestVotes).Debug("No majority of beacon nodes agreed on attestation data in time")
+			reportDisagreement(votes)
+			return best, nil
+		}
+	}
+	if best == nil {
+		return nil, allFailed(method, errs)
+	}
+	reportDisagreement(votes)
+	return best, nil
+}
+
+// reportDisagreement reports the beacon nodes returning different attestation data.
+func reportDisagreement(votes map[[32]byte]int) {
+	if len(votes) > 1 {
+		attestationDataDisagreements.Inc()
+		log.WithField("distinctResponses", len(votes)).Warn("Beacon nodes returned different attestation data")
+	}
+}
+
+func (c *client) DomainData(ctx context.Context, in *ethpb.DomainRequest) (*ethpb.DomainResponse, error) {
+	return failover(ctx, c, "DomainData", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.DomainResponse, error) {
+		return vc.DomainData(ctx, in)
+	})
+}
+
+func (c *client) WaitForChainStart(ctx context.Context, in *empty.Empty) (*ethpb.ChainStartResponse, error) {
+	return failover(ctx, c, "WaitForChainStart", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.ChainStartResponse, error) {
+		return vc.WaitForChainStart(ctx, in)
+	})
+}
+
+func (c *client) WaitForActivation(ctx context.Context, in *ethpb.ValidatorActivationRequest) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+	return failover(ctx, c, "WaitForActivation", func(ctx context.Context, vc iface.ValidatorClient) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+		return vc.WaitForActivation(ctx, in)
+	})
+}
+
+func (c *client) ValidatorIndex(ctx context.Context, in *ethpb.ValidatorIndexRequest) (*ethpb.ValidatorIndexResponse, error) {
+	return failover(ctx, c, "ValidatorIndex", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.ValidatorIndexResponse, error) {
+		return vc.ValidatorIndex(ctx, in)
+	})
+}
+
+func (c *client) ValidatorStatus(ctx context.Context, in *ethpb.ValidatorStatusRequest) (*ethpb.ValidatorStatusResponse, error) {
+	return failover(ctx, c, "ValidatorStatus", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.ValidatorStatusResponse, error) {
+		return vc.ValidatorStatus(ctx, in)
+	})
+}
+
+func (c *client) MultipleValidatorStatus(ctx context.Context, in *ethpb.MultipleValidatorStatusRequest) (*ethpb.MultipleValidatorStatusResponse, error) {
+	return failover(ctx, c, "MultipleValidatorStatus", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.MultipleValidatorStatusResponse, error) {
+		return vc.MultipleValidatorStatus(ctx, in)
+	})
+}
+
+func (c *client) BeaconBlock(ctx context.Context, in *ethpb.BlockRequest) (*ethpb.GenericBeaconBlock, error) {
+	return failover(ctx, c, "BeaconBlock", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.GenericBeaconBlock, error) {
+		return vc.BeaconBlock(ctx, in)
+	})
+}
+
+func (c *client) ProposeBeaconBlock(ctx context.Context, in *ethpb.GenericSignedBeaconBlock) (*ethpb.ProposeResponse, error) {
+	return broadcast(ctx, c, "ProposeBeaconBlock", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.ProposeResponse, error) {
+		return vc.ProposeBeaconBlock(ctx, in)
+	})
+}
+
+func (c *client) PrepareBeaconProposer(ctx context.Context, in *ethpb.PrepareBeaconProposerRequest) (*empty.Empty, error) {
+	return broadcast(ctx, c, "PrepareBeaconProposer", func(ctx context.Context, vc iface.ValidatorClient) (*empty.Empty, error) {
+		return vc.PrepareBeaconProposer(ctx, in)
+	})
+}
+
+func (c *client) FeeRecipientByPubKey(ctx context.Context, in *ethpb.FeeRecipientByPubKeyRequest) (*ethpb.FeeRecipientByPubKeyResponse, error) {
+	return failover(ctx, c, "FeeRecipientByPubKey", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.FeeRecipientByPubKeyResponse, error) {
+		return vc.FeeRecipientByPubKey(ctx, in)
+	})
+}
+
+func (c *client) ProposeAttestation(ctx context.Context, in *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+	return broadcast(ctx, c, "ProposeAttestation", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.AttestResponse, error) {
+		return vc.ProposeAttestation(ctx, in)
+	})
+}
+
+func (c *client) ProposeAttestationElectra(ctx context.Context, in *ethpb.AttestationElectra) (*ethpb.AttestResponse, error) {
+	return broadcast(ctx, c, "ProposeAttestationElectra", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.AttestResponse, error) {
+		return vc.ProposeAttestationElectra(ctx, in)
+	})
+}
+
+func (c *client) SubmitAggregateSelectionProof(ctx context.Context, in *ethpb.AggregateSelectionRequest, index primitives.ValidatorIndex, committeeLength uint64) (*ethpb.AggregateSelectionResponse, error) {
+	return failover(ctx, c, "SubmitAggregateSelectionProof", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.AggregateSelectionResponse, error) {
+		return vc.SubmitAggregateSelectionProof(ctx, in, index, committeeLength)
+	})
+}
+
+func (c *client) SubmitAggregateSelectionProofElectra(ctx context.Context, in *ethpb.AggregateSelectionRequest, index primitives.ValidatorIndex, committeeLength uint64) (*ethpb.AggregateSelectionElectraResponse, error) {
+	return failover(ctx, c, "SubmitAggregateSelectionProofElectra", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.AggregateSelectionElectraResponse, error) {
+		return vc.SubmitAggregateSelectionProofElectra(ctx, in, index, committeeLength)
+	})
+}
+
+func (c *client) SubmitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest) (*ethpb.SignedAggregateSubmitResponse, error) {
+	return broadcast(ctx, c, "SubmitSignedAggregateSelectionProof", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.SignedAggregateSubmitResponse, error) {
+		return vc.SubmitSignedAggregateSelectionProof(ctx, in)
+	})
+}
+
+func (c *client) SubmitSignedAggregateSelectionProofElectra(ctx context.Context, in *ethpb.SignedAggregateSubmitElectraRequest) (*ethpb.SignedAggregateSubmitResponse, error) {
+	return broadcast(ctx, c, "SubmitSignedAggregateSelectionProofElectra", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.SignedAggregateSubmitResponse, error) {
+		return vc.SubmitSignedAggregateSelectionProofElectra(ctx, in)
+	})
+}
+
+func (c *client) ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit) (*ethpb.ProposeExitResponse, error) {
+	return broadcast(ctx, c, "ProposeExit", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.ProposeExitResponse, error) {
+		return vc.ProposeExit(ctx, in)
+	})
+}
+
+func (c *client) SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, duties []*ethpb.DutiesResponse_Duty) (*empty.Empty, error) {
+	return broadcast(ctx, c, "SubscribeCommitteeSubnets", func(ctx context.Context, vc iface.ValidatorClient) (*empty.Empty, error) {
+		return vc.SubscribeCommitteeSubnets(ctx, in, duties)
+	})
+}
+
+func (c *client) CheckDoppelGanger(ctx context.Context, in *ethpb.DoppelGangerRequest) (*ethpb.DoppelGangerResponse, error) {
+	return failover(ctx, c, "CheckDoppelGanger", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.DoppelGangerResponse, error) {
+		return vc.CheckDoppelGanger(ctx, in)
+	})
+}
+
+func (c *client) SyncMessageBlockRoot(ctx context.Context, in *empty.Empty) (*ethpb.SyncMessageBlockRootResponse, error) {
+	return failover(ctx, c, "SyncMessageBlockRoot", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.SyncMessageBlockRootResponse, error) {
+		return vc.SyncMessageBlockRoot(ctx, in)
+	})
+}
+
+func (c *client) SubmitSyncMessage(ctx context.Context, in *ethpb.SyncCommitteeMessage) (*empty.Empty, error) {
+	return broadcast(ctx, c, "SubmitSyncMessage", func(ctx context.Context, vc iface.ValidatorClient) (*empty.Empty, error) {
+		return vc.SubmitSyncMessage(ctx, in)
+	})
+}
+
+func (c *client) SyncSubcommitteeIndex(ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+	return failover(ctx, c, "SyncSubcommitteeIndex", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+		return vc.SyncSubcommitteeIndex(ctx, in)
+	})
+}
+
+func (c *client) SyncCommitteeContribution(ctx context.Context, in *ethpb.SyncCommitteeContributionRequest) (*ethpb.SyncCommitteeContribution, error) {
+	return failover(ctx, c, "SyncCommitteeContribution", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.SyncCommitteeContribution, error) {
+		return vc.SyncCommitteeContribution(ctx, in)
+	})
+}
+
+func (c *client) SubmitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof) (*empty.Empty, error) {
+	return broadcast(ctx, c, "SubmitSignedContributionAndProof", func(ctx context.Context, vc iface.ValidatorClient) (*empty.Empty, error) {
+		return vc.SubmitSignedContributionAndProof(ctx, in)
+	})
+}
+
+func (c *client) SubmitValidatorRegistrations(ctx context.Context, in *ethpb.SignedValidatorRegistrationsV1) (*empty.Empty, error) {
+	return broadcast(ctx, c, "SubmitValidatorRegistrations", func(ctx context.Context, vc iface.ValidatorClient) (*empty.Empty, error) {
+		return vc.SubmitValidatorRegistrations(ctx, in)
+	})
+}
+
+func (c *client) AggregatedSelections(ctx context.Context, selections []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	return failover(ctx, c, "AggregatedSelections", func(ctx context.Context, vc iface.ValidatorClient) ([]iface.BeaconCommitteeSelection, error) {
+		return vc.AggregatedSelections(ctx, selections)
+	})
+}
+
+func (c *client) AggregatedSyncSelections(ctx context.Context, selections []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	return failover(ctx, c, "AggregatedSyncSelections", func(ctx context.Context, vc iface.ValidatorClient) ([]iface.SyncCommitteeSelection, error) {
+		return vc.AggregatedSyncSelections(ctx, selections)
+	})
+}
+
+// StartEventStream streams the events of the healthiest node. When the stream of a node stops, or when the node
+// becomes unhealthy while another node is healthy, the stream moves on to the next node. It returns once every
+// node has been tried, after which the validator client restarts it as it does for a single node.
+func (c *client) StartEventStream(ctx context.Context, topics []string, eventsChannel chan<- *event.Event) {
+	c.Lock()
+	c.streaming = true
+	c.Unlock()
+	defer func() {
+		c.Lock()
+		c.streaming = false
+		c.Unlock()
+	}()
+	for _, n := range c.orderedNodes() {
+		if ctx.Err() != nil {
+			return
+		}
+		c.streamEvents(ctx, n, topics, eventsChannel)
+	}
+}
+
+// streamEvents forwards the events of the node until its stream stops, or until the node becomes unhealthy
+// while another node is healthy.
+func (c *client) streamEvents(ctx context.Context, n *node, topics []string, eventsChannel chan<- *event.Event) {
+	// Each node streams to its own channel, as a stream closes its channel when its context is canceled.
+	events := make(chan *event.Event, 1)
+	stopped := make(chan struct{})
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		go drainEvents(events, stopped)
+	}()
+	start := time.Now()
+	go func() {
+		defer close(stopped)
+		n.client.StartEventStream(streamCtx, topics, events)
+	}()
+
+	ticker := time.NewTicker(eventStreamCheckInterval)
+	defer ticker.Stop()
+	in := events
+	for {
+		select {
+		case e, ok := <-in:
+			if !ok {
+				in = nil
+				continue
+			}
+			select {
+			case eventsChannel <- e:
+			case <-ctx.Done():
+				return
+			}
+		case <-stopped:
+			// The events sent before the stream stopped are still forwarded.
+			for _, e := range bufferedEvents(in) {
+				select {
+				case eventsChannel <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+			n.record("StartEventStream", start, errEventStreamStopped)
+			log.WithField("host", n.host).Warn("Event stream of beacon node stopped")
+			return
+		case <-ticker.C:
+			if next := c.orderedNodes()[0]; next != n && next.healthy() && !n.healthy() {
+				log.WithField("host", n.host).WithField("nextHost", next.host).Warn("Moving event stream away from unhealthy beacon node")
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// bufferedEvents returns the events already sent on the channel, without waiting for more.
+func bufferedEvents(events <-chan *event.Event) []*event.Event {
+	var buffered []*event.Event
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return buffered
+			}
+			buffered = append(buffered, e)
+		default:
+			return buffered
+		}
+	}
+}
+
+// drainEvents discards the events of a stream that is no longer forwarded until it stops.
+func drainEvents(events <-chan *event.Event, stopped <-chan struct{}) {
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				<-stopped
+				return
+			}
+		case <-stopped:
+			return
+		}
+	}
+}
+
+func (c *client) EventStreamIsRunning() bool {
+	c.RLock()
+	defer c.RUnlock()
+	return c.streaming
+}
+
+// Host returns the host of the node that is tried first.
+func (c *client) Host() string {
+	return c.orderedNodes()[0].host
+}
+
+// SetHost makes the node with the given host the primary node.
+func (c *client) SetHost(host string) {
+	c.Lock()
+	defer c.Unlock()
+	for i, n := range c.nodes {
+		if n.host == host {
+			nodes := append([]*node{n}, c.nodes[:i]...)
+			c.nodes = append(nodes, c.nodes[i+1:]...)
+			return
+		}
+	}
+	log.WithField("host", host).Warn("Unknown beacon node host")
+}
@@ -80,9 +80,10 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 
 	// Request block from beacon node
 	b, err := v.validatorClient.BeaconBlock(ctx, &ethpb.BlockRequest{
-		Slot:         slot,
-		RandaoReveal: randaoReveal,
-		Graffiti:     g,
+		Slot:          slot,
+		RandaoReveal:  randaoReveal,
+		Graffiti:      g,
+		BuilderPolicy: v.builderPolicy(pubKey),
 	})
 	if err != nil {
 		log.WithField("slot", slot).WithError(err).Error("Failed to request block from beacon node")
@@ -504,6 +505,24 @@ func (v *validator) Graffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyL
 	return []byte{}, nil
 }
 
+// builderPolicy returns the builder policy the beacon node should apply when building a block for the
+// validator. The policy of the key's proposer settings takes precedence over the default one.
+func (v *validator) builderPolicy(pubKey [fieldparams.BLSPubkeyLength]byte) *ethpb.BuilderPolicy {
+	if v.proposerSettings == nil {
+		return nil
+	}
+	if v.proposerSettings.ProposeConfig != nil {
+		option, ok := v.proposerSettings.ProposeConfig[pubKey]
+		if ok && option != nil && option.BuilderConfig != nil {
+			return option.BuilderConfig.Policy
+		}
+	}
+	if v.proposerSettings.DefaultConfig != nil && v.proposerSettings.DefaultConfig.BuilderConfig != nil {
+		return v.proposerSettings.DefaultConfig.BuilderConfig.Policy
+	}
+	return nil
+}
+
 func (v *validator) SetGraffiti(ctx context.Context, pubkey [fieldparams.BLSPubkeyLength]byte, graffiti []byte) error {
 	ctx, span := trace.StartSpan(ctx, "validator.SetGraffiti")
 	defer span.End()
@@ -1273,3 +1273,23 @@ func Test_validator_SetGraffiti(t *testing.T) {
 		})
 	}
 }
+
+func Test_validator_builderPolicy(t *testing.T) {
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	minBid, defaultMinBid := uint64(10), uint64(20)
+	v := &validator{}
+	require.Equal(t, (*ethpb.BuilderPolicy)(nil), v.builderPolicy(pubKey))
+
+	v.proposerSettings = &proposer.Settings{
+		DefaultConfig: &proposer.Option{
+			BuilderConfig: &proposer.BuilderConfig{Enabled: true, Policy: &ethpb.BuilderPolicy{MinBuilderBid: &defaultMinBid}},
+		},
+	}
+	require.Equal(t, defaultMinBid, v.builderPolicy(pubKey).GetMinBuilderBid())
+
+	v.proposerSettings.ProposeConfig = map[[fieldparams.BLSPubkeyLength]byte]*proposer.Option{
+		pubKey: {BuilderConfig: &proposer.BuilderConfig{Enabled: true, Policy: &ethpb.BuilderPolicy{MinBuilderBid: &minBid}}},
+	}
+	require.Equal(t, minBid, v.builderPolicy(pubKey).GetMinBuilderBid())
+	require.Equal(t, defaultMinBid, v.builderPolicy([fieldparams.BLSPubkeyLength]byte{2}).GetMinBuilderBid())
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ import (
 	grpcutil "github.com/prysmaticlabs/prysm/v5/api/grpc"
 	"github.com/prysmaticlabs/prysm/v5/async/event"
 	lruwrpr "github.com/prysmaticlabs/prysm/v5/cache/lru"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/config/proposer"
@@ -24,6 +26,7 @@ import (
 	beaconApi "github.com/prysmaticlabs/prysm/v5/validator/client/beacon-api"
 	beaconChainClientFactory "github.com/prysmaticlabs/prysm/v5/validator/client/beacon-chain-client-factory"
 	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+	multinode "github.com/prysmaticlabs/prysm/v5/validator/client/multi-node"
 	nodeclientfactory "github.com/prysmaticlabs/prysm/v5/validator/client/node-client-factory"
 	validatorclientfactory "github.com/prysmaticlabs/prysm/v5/validator/client/validator-client-factory"
 	"github.com/prysmaticlabs/prysm/v5/validator/db"
@@ -45,6 +48,7 @@ type ValidatorService struct {
 	validator               iface.Validator
 	db                      db.Database
 	conn                    validatorHelpers.NodeConnection
+	nodeConns               []validatorHelpers.NodeConnection
 	wallet                  *wallet.Wallet
 	walletInitializedFeed   *event.Feed
 	graffiti                []byte
@@ -57,6 +61,7 @@ type ValidatorService struct {
 	emitAccountMetrics      bool
 	logValidatorPerformance bool
 	distributed             bool
+	activeActive            bool
 }
 
 // Config for the validator service.
@@ -83,6 +88,7 @@ type Config struct {
 	LogValidatorPerformance bool
 	EmitAccountMetrics      bool
 	Distributed             bool
+	ActiveActive            bool
 }
 
 // NewValidatorService creates a new validator service for the service
@@ -106,6 +112,7 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		emitAccountMetrics:      cfg.EmitAccountMetrics,
 		logValidatorPerformance: cfg.LogValidatorPerformance,
 		distributed:             cfg.Distributed,
+		activeActive:            cfg.ActiveActive,
 	}
 
 	dialOpts := ConstructDialOptions(
@@ -133,6 +140,18 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		cfg.BeaconApiTimeout,
 	)
 
+	// In active-active mode with gRPC, every beacon node needs its own connection
+	// instead of a single connection picking one of the endpoints.
+	if cfg.ActiveActive && !features.Get().EnableBeaconRESTApi {
+		for _, endpoint := range strings.Split(cfg.BeaconNodeGRPCEndpoint, ",") {
+			nodeConn, err := grpc.DialContext(ctx, endpoint, dialOpts...)
+			if err != nil {
+				return s, errors.Wrapf(err, "could not dial beacon node %s", endpoint)
+			}
+			s.nodeConns = append(s.nodeConns, validatorHelpers.NewNodeConnection(nodeConn, cfg.BeaconApiEndpoint, cfg.BeaconApiTimeout))
+		}
+	}
+
 	return s, nil
 }
 
@@ -178,6 +197,14 @@ func (v *ValidatorService) Start() {
 	)
 
 	validatorClient := validatorclientfactory.NewValidatorClient(v.conn, restHandler)
+	if v.activeActive {
+		validatorClient, err = v.activeActiveValidatorClient(hosts)
+		if err != nil {
+			log.WithError(err).Error("Could not create active-active validator client")
+			return
+		}
+		log.Info("Using all beacon nodes in active-active mode")
+	}
 
 	valStruct := &validator{
 		slotFeed:                       new(event.Feed),
@@ -221,16 +248,63 @@ func (v *ValidatorService) Start() {
 	go run(v.ctx, v.validator)
 }
 
+// activeActiveValidatorClient returns a validator client using every configured beacon node at the same time.
+func (v *ValidatorService) activeActiveValidatorClient(restHosts []string) (iface.ValidatorClient, error) {
+	var hosts []string
+	var clients []iface.ValidatorClient
+	if features.Get().EnableBeaconRESTApi {
+		for _, host := range restHosts {
+			restHandler := beaconApi.NewBeaconApiJsonRestHandler(
+				http.Client{Timeout: v.conn.GetBeaconApiTimeout()},
+				host,
+			)
+			hosts = append(hosts, host)
+			clients = append(clients, validatorclientfactory.NewValidatorClient(v.conn, restHandler))
+		}
+	} else {
+		for _, conn := range v.nodeConns {
+			hosts = append(hosts, conn.GetGrpcClientConn().Target())
+			clients = append(clients, validatorclientfactory.NewValidatorClient(conn, nil))
+		}
+	}
+	return multinode.NewValidatorClient(hosts, clients)
+}
+
 // Stop the validator service.
 func (v *ValidatorService) Stop() error {
 	v.cancel()
 	log.Info("Stopping service")
+	v.closeKeymanager()
+	for _, conn := range v.nodeConns {
+		if err := conn.GetGrpcClientConn().Close(); err != nil {
+			log.WithError(err).Error("Could not close beacon node connection")
+		}
+	}
 	if v.conn != nil {
 		return v.conn.GetGrpcClientConn().Close()
 	}
 	return nil
 }
 
+// closeKeymanager closes the keymanager of the validator when it holds resources, such as the
+// connection to a remote signer.
+func (v *ValidatorService) closeKeymanager() {
+	if v.validator == nil {
+		return
+	}
+	km, err := v.validator.Keymanager()
+	if err != nil {
+		return
+	}
+	closer, ok := km.(io.Closer)
+	if !ok {
+		return
+	}
+	if err := closer.Close(); err != nil {
+		log.WithError(err).Error("Could not close keymanager")
+	}
+}
+
 // Status of the validator service.
 func (v *ValidatorService) Status() error {
 	if v.conn == nil {
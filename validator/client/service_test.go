@@ -31,6 +31,29 @@ func TestStop_CancelsContext(t *testing.T) {
 	}
 }
 
+type closingKeymanager struct {
+	*mockKeymanager
+	closed bool
+}
+
+func (km *closingKeymanager) Close() error {
+	km.closed = true
+	return nil
+}
+
+func TestStop_ClosesKeymanager(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	km := &closingKeymanager{mockKeymanager: &mockKeymanager{}}
+	vs := &ValidatorService{
+		ctx:       ctx,
+		cancel:    cancel,
+		validator: &validator{km: km},
+	}
+
+	assert.NoError(t, vs.Stop())
+	assert.Equal(t, true, km.closed)
+}
+
 func TestNew_Insecure(t *testing.T) {
 	hook := logTest.NewGlobal()
 	_, err := NewValidatorService(context.Background(), &Config{})
@@ -137,6 +137,21 @@ func ConvertDatabase(ctx context.Context, sourceDataDir string, targetDataDir st
 		return errors.Wrap(err, "could not get proposer settings from source database")
 	}
 
+	// Exit vault
+	// ----------
+	// Get the pre-signed exit vault.
+	exitVault, err := sourceDatabase.ExitVault(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get exit vault from source database")
+	}
+
+	// Save the pre-signed exit vault.
+	if exitVault != nil {
+		if err := targetDatabase.SaveExitVault(ctx, exitVault); err != nil {
+			return errors.Wrap(err, "could not save exit vault")
+		}
+	}
+
 	// Attestations
 	// ------------
 	// Get all public keys that have attested.
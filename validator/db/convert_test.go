@@ -131,6 +131,11 @@ func TestDB_ConvertDatabase(t *testing.T) {
 					require.NoError(t, err, "could not save proposer settings")
 				}
 
+				// Save an exit vault.
+				expectedExitVault := []byte("exit vault")
+				err = sourceDatabase.SaveExitVault(ctx, expectedExitVault)
+				require.NoError(t, err, "could not save exit vault")
+
 				// Save some attestations.
 				completeAttestations := []*ethpb.IndexedAttestation{
 					{
@@ -234,6 +239,11 @@ func TestDB_ConvertDatabase(t *testing.T) {
 					require.DeepEqual(t, expectedProposerSettings, actualProposerSettings, "proposer settings should match")
 				}
 
+				// Check the exit vault.
+				actualExitVault, err := targetDatabase.ExitVault(ctx)
+				require.NoError(t, err, "could not get exit vault from target database")
+				require.DeepEqual(t, expectedExitVault, actualExitVault, "exit vault should match")
+
 				// Check the attestations.
 				actualAttestationRecords, err := targetDatabase.AttestationHistoryForPubKey(ctx, pubkey1)
 				require.NoError(t, err, "could not get attestations from target database")
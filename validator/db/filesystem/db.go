@@ -48,11 +48,13 @@ type (
 		FileHash     *string
 	}
 
-	// Configuration contains the genesis information, the proposer settings and the graffiti.
+	// Configuration contains the genesis information, the proposer settings, the graffiti
+	// and the pre-signed exit vault.
 	Configuration struct {
 		GenesisValidatorsRoot *string                              `yaml:"genesisValidatorsRoot,omitempty"`
 		ProposerSettings      *validatorpb.ProposerSettingsPayload `yaml:"proposerSettings,omitempty"`
 		Graffiti              *Graffiti                            `yaml:"graffiti,omitempty"`
+		ExitVault             *string                              `yaml:"exitVault,omitempty"`
 	}
 
 	// ValidatorSlashingProtection contains the latest signed block slot, the last signed attestation.
@@ -0,0 +1,57 @@
+package filesystem
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// ExitVault returns the encoded pre-signed exit vault, or nil if none was saved.
+func (s *Store) ExitVault(_ context.Context) ([]byte, error) {
+	// Get configuration.
+	configuration, err := s.configuration()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get configuration")
+	}
+
+	// Return nil if there is no exit vault.
+	if configuration == nil || configuration.ExitVault == nil {
+		return nil, nil
+	}
+
+	// Decode the exit vault.
+	vault, err := hexutil.Decode(*configuration.ExitVault)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode exit vault")
+	}
+
+	return vault, nil
+}
+
+// SaveExitVault saves the encoded pre-signed exit vault, replacing any previous one.
+func (s *Store) SaveExitVault(_ context.Context, vault []byte) error {
+	// Encode the exit vault.
+	vaultHex := hexutil.Encode(vault)
+
+	// Get configuration.
+	configuration, err := s.configuration()
+	if err != nil {
+		return errors.Wrap(err, "could not get configuration")
+	}
+
+	if configuration == nil {
+		// If configuration is nil, create new config.
+		configuration = &Configuration{}
+	}
+
+	// Modify the exit vault.
+	configuration.ExitVault = &vaultHex
+
+	// Save the configuration.
+	if err := s.saveConfiguration(configuration); err != nil {
+		return errors.Wrap(err, "could not save configuration")
+	}
+
+	return nil
+}
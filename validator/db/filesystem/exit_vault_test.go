@@ -0,0 +1,37 @@
+package filesystem
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_ExitVault(t *testing.T) {
+	ctx := context.Background()
+
+	// Create a new store.
+	store, err := NewStore(t.TempDir(), nil)
+	require.NoError(t, err)
+
+	// No exit vault is saved yet.
+	vault, err := store.ExitVault(ctx)
+	require.NoError(t, err)
+	require.IsNil(t, vault)
+
+	// Save a graffiti index, which must be kept when saving the vault.
+	require.NoError(t, store.SaveGraffitiOrderedIndex(ctx, 3))
+
+	// Save the exit vault twice.
+	require.NoError(t, store.SaveExitVault(ctx, []byte("first")))
+	require.NoError(t, store.SaveExitVault(ctx, []byte("second")))
+
+	// Get the exit vault.
+	vault, err = store.ExitVault(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte("second"), vault)
+
+	configuration, err := store.configuration()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), configuration.Graffiti.OrderedIndex)
+}
@@ -2,7 +2,6 @@ package filesystem
 
 import (
 	"context"
-	"encoding/json"
 	"io"
 	"strings"
 
@@ -10,7 +9,6 @@ import (
 	"github.com/pkg/errors"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
-	"github.com/prysmaticlabs/prysm/v5/validator/db/common"
 	"github.com/prysmaticlabs/prysm/v5/validator/db/iface"
 	"github.com/prysmaticlabs/prysm/v5/validator/helpers"
 	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
@@ -20,34 +18,34 @@ import (
 // by Ethereum validators and imports its data into Prysm's internal minimal representation of slashing
 // protection in the validator client's database.
 func (s *Store) ImportStandardProtectionJSON(ctx context.Context, r io.Reader) error {
-	// Read the JSON file
-	encodedJSON, err := io.ReadAll(r)
+	// The JSON file is read one validator record at a time, so that large files do not need to fit in memory.
+	dec, err := format.NewDecoder(r)
 	if err != nil {
-		return errors.Wrap(err, "could not read slashing protection JSON file")
+		return errors.Wrap(err, "could not unmarshal slashing protection JSON file")
 	}
-
-	// Unmarshal the JSON file
-	interchangeJSON := &format.EIPSlashingProtectionFormat{}
-	if err := json.Unmarshal(encodedJSON, interchangeJSON); err != nil {
+	item, err := dec.Next()
+	if err != nil && !errors.Is(err, io.EOF) {
 		return errors.Wrap(err, "could not unmarshal slashing protection JSON file")
 	}
 
 	// If there is no data in the JSON file, we can return early.
-	if interchangeJSON.Data == nil {
+	// An empty data array still has its metadata validated.
+	if !dec.HasData() {
 		return nil
 	}
 
 	// We validate the `MetadataV0` field of the slashing protection JSON file.
-	if err := helpers.ValidateMetadata(ctx, s, interchangeJSON); err != nil {
+	if err := helpers.ValidateMetadata(ctx, s, dec.Metadata()); err != nil {
 		return errors.Wrap(err, "slashing protection JSON metadata was incorrect")
 	}
 
 	// Save blocks proposals and attestations into the database
-	bar := common.InitializeProgressBar(len(interchangeJSON.Data), "Save blocks proposals and attestations:")
-	for _, item := range interchangeJSON.Data {
-		// Update progress bar
-		if err := bar.Add(1); err != nil {
-			return errors.Wrap(err, "could not update progress bar")
+	for ; ; item, err = dec.Next() {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "could not unmarshal slashing protection JSON file")
 		}
 
 		// If item is nil, skip
@@ -73,8 +71,6 @@ func (s *Store) ImportStandardProtectionJSON(ctx context.Context, r io.Reader) e
 			return errors.Wrap(err, "could not import attestations")
 		}
 	}
-
-	return nil
 }
 
 func importBlockProposals(ctx context.Context, pubkey [fieldparams.BLSPubkeyLength]byte, item *format.ProtectionData, validatorDB iface.ValidatorDB) error {
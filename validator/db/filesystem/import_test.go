@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
@@ -45,6 +47,31 @@ func TestStore_ImportInterchangeData_NilData_FailsSilently(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestStore_ImportInterchangeData_EmptyData_ValidatesMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	// Create a new store.
+	s, err := NewStore(t.TempDir(), nil)
+	require.NoError(t, err, "NewStore should not return an error")
+	require.NoError(t, s.SaveGenesisValidatorsRoot(ctx, bytesutil.PadTo([]byte{1}, 32)))
+
+	interchangeJSON := &format.EIPSlashingProtectionFormat{Data: []*format.ProtectionData{}}
+	interchangeJSON.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+	interchangeJSON.Metadata.GenesisValidatorsRoot = fmt.Sprintf("%#x", bytesutil.PadTo([]byte{2}, 32))
+	encoded, err := json.Marshal(interchangeJSON)
+	require.NoError(t, err)
+
+	err = s.ImportStandardProtectionJSON(ctx, bytes.NewBuffer(encoded))
+	require.ErrorContains(t, "genesis validators root doesn't match", err)
+
+	interchangeJSON.Metadata.InterchangeFormatVersion = "1"
+	encoded, err = json.Marshal(interchangeJSON)
+	require.NoError(t, err)
+
+	err = s.ImportStandardProtectionJSON(ctx, bytes.NewBuffer(encoded))
+	require.ErrorContains(t, "is not supported", err)
+}
+
 func TestStore_ImportInterchangeData_BadFormat_PreventsDBWrites(t *testing.T) {
 	ctx := context.Background()
 	numValidators := 10
@@ -80,6 +80,10 @@ type ValidatorDB interface {
 	ProposerSettingsExists(ctx context.Context) (bool, error)
 	SaveProposerSettings(ctx context.Context, settings *proposer.Settings) error
 
+	// Pre-signed exit vault related methods
+	ExitVault(ctx context.Context) ([]byte, error)
+	SaveExitVault(ctx context.Context, vault []byte) error
+
 	// EIP-3076 slashing protection related methods
 	ImportStandardProtectionJSON(ctx context.Context, r io.Reader) error
 }
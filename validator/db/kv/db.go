@@ -160,6 +160,7 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 			migrationsBucket,
 			graffitiBucket,
 			proposerSettingsBucket,
+			exitVaultBucket,
 		)
 	}); err != nil {
 		return nil, err
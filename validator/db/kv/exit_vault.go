@@ -0,0 +1,36 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ExitVault returns the encoded pre-signed exit vault, or nil if none was saved.
+func (s *Store) ExitVault(ctx context.Context) ([]byte, error) {
+	_, span := trace.StartSpan(ctx, "validator.db.ExitVault")
+	defer span.End()
+	var vault []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(exitVaultBucket)
+		enc := bkt.Get(exitVaultKey)
+		if len(enc) == 0 {
+			return nil
+		}
+		vault = make([]byte, len(enc))
+		copy(vault, enc)
+		return nil
+	})
+	return vault, err
+}
+
+// SaveExitVault saves the encoded pre-signed exit vault, replacing any previous one.
+func (s *Store) SaveExitVault(ctx context.Context, vault []byte) error {
+	_, span := trace.StartSpan(ctx, "validator.db.SaveExitVault")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(exitVaultBucket)
+		return bkt.Put(exitVaultKey, vault)
+	})
+}
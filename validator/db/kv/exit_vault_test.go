@@ -0,0 +1,23 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_ExitVault(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, nil)
+
+	vault, err := db.ExitVault(ctx)
+	require.NoError(t, err)
+	require.IsNil(t, vault)
+
+	require.NoError(t, db.SaveExitVault(ctx, []byte("first")))
+	require.NoError(t, db.SaveExitVault(ctx, []byte("second")))
+	vault, err = db.ExitVault(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte("second"), vault)
+}
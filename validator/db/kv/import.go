@@ -3,7 +3,6 @@ package kv
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 
@@ -22,188 +21,203 @@ import (
 // ImportStandardProtection takes in EIP-3076 compliant JSON file used for slashing protection
 // by Ethereum validators and imports its data into Prysm's internal complete representation of slashing
 // protection in the validator client's database.
+//
+// The JSON file is read one validator record at a time, and each record is imported once decoded, so
+// that neither the whole document nor the signing histories of all the validators are kept in memory.
+// When the reader can seek, the records are all parsed before any of them is imported, so that an
+// invalid file leaves the database untouched.
 func (s *Store) ImportStandardProtectionJSON(ctx context.Context, r io.Reader) error {
-	encodedJSON, err := io.ReadAll(r)
-	if err != nil {
-		return errors.Wrap(err, "could not read slashing protection JSON file")
+	if rs, ok := r.(io.ReadSeeker); ok {
+		if err := s.importRecords(ctx, rs, true /* dry run */); err != nil {
+			return err
+		}
+		if _, err := rs.Seek(0, io.SeekStart); err != nil {
+			return errors.Wrap(err, "could not rewind slashing protection JSON file")
+		}
 	}
+	return s.importRecords(ctx, r, false /* dry run */)
+}
 
-	interchangeJSON := &format.EIPSlashingProtectionFormat{}
-	if err := json.Unmarshal(encodedJSON, interchangeJSON); err != nil {
+// importRecords imports the validator records of the JSON file as they are decoded. A dry run only
+// parses the records, without validating the metadata against the database or writing to it.
+func (s *Store) importRecords(ctx context.Context, r io.Reader, dryRun bool) error {
+	dec, err := format.NewDecoder(r)
+	if err != nil {
 		return errors.Wrap(err, "could not unmarshal slashing protection JSON file")
 	}
-
-	if interchangeJSON.Data == nil {
+	item, err := dec.Next()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return errors.Wrap(err, "could not unmarshal slashing protection JSON file")
+	}
+	if dryRun {
+		for ; !errors.Is(err, io.EOF); item, err = dec.Next() {
+			if err != nil {
+				return errors.Wrap(err, "could not unmarshal slashing protection JSON file")
+			}
+			if item == nil {
+				continue
+			}
+			if _, _, _, err := parseRecord(ctx, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if !dec.HasData() {
 		log.Warn("No slashing protection data to import")
 		return nil
 	}
 
 	// We validate the `MetadataV0` field of the slashing protection JSON file.
-	if err := helpers.ValidateMetadata(ctx, s, interchangeJSON); err != nil {
+	if err := helpers.ValidateMetadata(ctx, s, dec.Metadata()); err != nil {
 		return errors.Wrap(err, "slashing protection JSON metadata was incorrect")
 	}
 
 	// We need to handle duplicate public keys in the JSON file, with potentially
-	// different signing histories for both attestations and blocks.
-	signedBlocksByPubKey, err := parseBlocksForUniquePublicKeys(interchangeJSON.Data)
-	if err != nil {
-		return errors.Wrap(err, "could not parse unique entries for blocks by public key")
+	// different signing histories for both attestations and blocks. The records of a public key
+	// seen before are checked against the database, which holds the records imported before them.
+	imported := make(map[[fieldparams.BLSPubkeyLength]byte]bool)
+	for ; !errors.Is(err, io.EOF); item, err = dec.Next() {
+		if err != nil {
+			return errors.Wrap(err, "could not unmarshal slashing protection JSON file")
+		}
+		if item == nil {
+			continue
+		}
+		pubKey, proposalHistory, attestingHistory, err := parseRecord(ctx, item)
+		if err != nil {
+			return err
+		}
+		if err := s.importRecord(ctx, pubKey, proposalHistory, attestingHistory, imported[pubKey]); err != nil {
+			return err
+		}
+		imported[pubKey] = true
 	}
+	log.WithField("publicKeys", len(imported)).Info("Imported slashing protection history")
+	return nil
+}
 
-	signedAttsByPubKey, err := parseAttestationsForUniquePublicKeys(interchangeJSON.Data)
+// parseRecord transforms a validator record of the JSON file into the internal Prysm representation
+// of its proposal and attesting histories.
+func parseRecord(ctx context.Context, item *format.ProtectionData) (
+	[fieldparams.BLSPubkeyLength]byte, *common.ProposalHistoryForPubkey, []*common.AttestationRecord, error,
+) {
+	pubKey, err := helpers.PubKeyFromHex(item.Pubkey)
 	if err != nil {
-		return errors.Wrap(err, "could not parse unique entries for attestations by public key")
+		return pubKey, nil, nil, fmt.Errorf("%s is not a valid public key: %w", item.Pubkey, err)
 	}
-
-	attestingHistoryByPubKey := make(map[[fieldparams.BLSPubkeyLength]byte][]*common.AttestationRecord)
-	proposalHistoryByPubKey := make(map[[fieldparams.BLSPubkeyLength]byte]common.ProposalHistoryForPubkey)
-
-	bar := common.InitializeProgressBar(len(signedBlocksByPubKey), "Transform signed blocks:")
-
-	for pubKey, signedBlocks := range signedBlocksByPubKey {
-		// Transform the processed signed blocks data from the JSON.
-		// file into the internal Prysm representation of proposal history.
-		if err := bar.Add(1); err != nil {
-			log.WithError(err).Debug("Could not increase progress bar")
+	signedBlocks := make([]*format.SignedBlock, 0, len(item.SignedBlocks))
+	for _, sBlock := range item.SignedBlocks {
+		if sBlock != nil {
+			signedBlocks = append(signedBlocks, sBlock)
 		}
-
-		proposalHistory, err := transformSignedBlocks(ctx, signedBlocks)
-		if err != nil {
-			return errors.Wrapf(err, "could not parse signed blocks in JSON file for key %#x", pubKey)
+	}
+	signedAtts := make([]*format.SignedAttestation, 0, len(item.SignedAttestations))
+	for _, sAtt := range item.SignedAttestations {
+		if sAtt != nil {
+			signedAtts = append(signedAtts, sAtt)
 		}
-
-		proposalHistoryByPubKey[pubKey] = *proposalHistory
 	}
+	proposalHistory, err := transformSignedBlocks(ctx, signedBlocks)
+	if err != nil {
+		return pubKey, nil, nil, errors.Wrapf(err, "could not parse signed blocks in JSON file for key %#x", pubKey)
+	}
+	attestingHistory, err := transformSignedAttestations(pubKey, signedAtts)
+	if err != nil {
+		return pubKey, nil, nil, errors.Wrapf(err, "could not parse signed attestations in JSON file for key %#x", pubKey)
+	}
+	return pubKey, proposalHistory, attestingHistory, nil
+}
 
-	bar = common.InitializeProgressBar(len(signedAttsByPubKey), "Transform signed attestations:")
-	for pubKey, signedAtts := range signedAttsByPubKey {
-		// Transform the processed signed attestation data from the JSON.
-		// file into the internal Prysm representation of attesting history.
-		if err := bar.Add(1); err != nil {
-			log.WithError(err).Debug("Could not increase progress bar")
-		}
+// importRecord saves the proposal and attesting histories of a validator record, after checking whether
+// they are slashable. Slashable public keys are still imported, and added to the import blacklist.
+func (s *Store) importRecord(
+	ctx context.Context,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	proposalHistory *common.ProposalHistoryForPubkey,
+	attestingHistory []*common.AttestationRecord,
+	seen bool,
+) error {
+	proposalHistoryByPubKey := map[[fieldparams.BLSPubkeyLength]byte]common.ProposalHistoryForPubkey{pubKey: *proposalHistory}
+	attestingHistoryByPubKey := map[[fieldparams.BLSPubkeyLength]byte][]*common.AttestationRecord{pubKey: attestingHistory}
 
-		historicalAtt, err := transformSignedAttestations(pubKey, signedAtts)
+	// We validate and filter out public keys parsed from JSON to ensure we are
+	// not importing those which are slashable with respect to other data within the same JSON.
+	slashable := len(filterSlashablePubKeysFromBlocks(ctx, proposalHistoryByPubKey)) > 0
+	if seen {
+		var err error
+		if !slashable {
+			slashable, err = s.proposalsConflictWithHistory(ctx, pubKey, proposalHistory)
+			if err != nil {
+				return errors.Wrap(err, "could not check proposals against imported JSON data")
+			}
+		}
+		// Attestations of an earlier record of the same public key are not saved twice.
+		attestingHistoryByPubKey[pubKey], err = s.attestationsMissingFromHistory(ctx, pubKey, attestingHistory)
 		if err != nil {
-			return errors.Wrapf(err, "could not parse signed attestations in JSON file for key %#x", pubKey)
+			return errors.Wrap(err, "could not check attestations against imported JSON data")
 		}
-
-		attestingHistoryByPubKey[pubKey] = historicalAtt
 	}
-
-	// We validate and filter out public keys parsed from JSON to ensure we are
-	// not importing those which are slashable with respect to other data within the same JSON.
-	slashableProposerKeys := filterSlashablePubKeysFromBlocks(ctx, proposalHistoryByPubKey)
 	slashableAttesterKeys, err := filterSlashablePubKeysFromAttestations(ctx, s, attestingHistoryByPubKey)
 	if err != nil {
 		return errors.Wrap(err, "could not filter slashable attester public keys from JSON data")
 	}
-
-	slashablePublicKeysCount := len(slashableProposerKeys) + len(slashableAttesterKeys)
-	slashablePublicKeys := make([][fieldparams.BLSPubkeyLength]byte, 0, slashablePublicKeysCount)
-	slashablePublicKeys = append(slashablePublicKeys, slashableProposerKeys...)
-	slashablePublicKeys = append(slashablePublicKeys, slashableAttesterKeys...)
-
-	if err := s.SaveEIPImportBlacklistedPublicKeys(ctx, slashablePublicKeys); err != nil {
-		return errors.Wrap(err, "could not save slashable public keys to database")
+	if slashable || len(slashableAttesterKeys) > 0 {
+		if err := s.SaveEIPImportBlacklistedPublicKeys(ctx, [][fieldparams.BLSPubkeyLength]byte{pubKey}); err != nil {
+			return errors.Wrap(err, "could not save slashable public keys to database")
+		}
 	}
 
-	// We save the histories to disk as atomic operations, ensuring that this only occurs
-	// until after we successfully parse all data from the JSON file. If there is any error
-	// in parsing the JSON proposal and attesting histories, we will not reach this point.
 	if err := saveProposals(ctx, proposalHistoryByPubKey, s); err != nil {
 		return errors.Wrap(err, "could not save proposals")
 	}
-
 	if err := saveAttestations(ctx, attestingHistoryByPubKey, s); err != nil {
 		return errors.Wrap(err, "could not save attestations")
 	}
-
 	return nil
 }
 
-// We create a map of pubKey -> []*SignedBlock. Then, for each public key we observe,
-// we append to this map. This allows us to handle valid input JSON data such as:
-//
-//	"0x2932232930: {
-//	  SignedBlocks: [Slot: 5, Slot: 6, Slot: 7],
-//	 },
-//
-//	"0x2932232930: {
-//	  SignedBlocks: [Slot: 5, Slot: 10, Slot: 11],
-//	 }
-//
-// Which should be properly parsed as:
-//
-//	"0x2932232930: {
-//	  SignedBlocks: [Slot: 5, Slot: 5, Slot: 6, Slot: 7, Slot: 10, Slot: 11],
-//	 }
-func parseBlocksForUniquePublicKeys(data []*format.ProtectionData) (map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedBlock, error) {
-	bar := common.InitializeProgressBar(
-		len(data),
-		"Parsing blocks for unique public keys:",
-	)
-
-	signedBlocksByPubKey := make(map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedBlock)
-	for _, validatorData := range data {
-		if err := bar.Add(1); err != nil {
-			return nil, errors.Wrap(err, "could not increase progress bar")
-		}
-
-		pubKey, err := helpers.PubKeyFromHex(validatorData.Pubkey)
+// attestationsMissingFromHistory returns the attestations which do not have the same signing root
+// as an attestation saved in the database at their target epoch.
+func (s *Store) attestationsMissingFromHistory(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, attestingHistory []*common.AttestationRecord,
+) ([]*common.AttestationRecord, error) {
+	missing := make([]*common.AttestationRecord, 0, len(attestingHistory))
+	for _, att := range attestingHistory {
+		signingRoot, err := s.SigningRootAtTargetEpoch(ctx, pubKey, att.Target)
 		if err != nil {
-			return nil, fmt.Errorf("%s is not a valid public key: %w", validatorData.Pubkey, err)
+			return nil, err
 		}
-		for _, sBlock := range validatorData.SignedBlocks {
-			if sBlock == nil {
-				continue
-			}
-			signedBlocksByPubKey[pubKey] = append(signedBlocksByPubKey[pubKey], sBlock)
+		if len(att.SigningRoot) > 0 && bytes.Equal(signingRoot, att.SigningRoot) {
+			continue
 		}
+		missing = append(missing, att)
 	}
-	return signedBlocksByPubKey, nil
+	return missing, nil
 }
 
-// We create a map of pubKey -> []*SignedAttestation. Then, for each public key we observe,
-// we append to this map. This allows us to handle valid input JSON data such as:
-//
-//	"0x2932232930: {
-//	  SignedAttestations: [{Source: 5, Target: 6}, {Source: 6, Target: 7}],
-//	 },
-//
-//	"0x2932232930: {
-//	  SignedAttestations: [{Source: 5, Target: 6}],
-//	 }
-//
-// Which should be properly parsed as:
-//
-//	"0x2932232930: {
-//	  SignedAttestations: [{Source: 5, Target: 6}, {Source: 5, Target: 6}, {Source: 6, Target: 7}],
-//	 }
-func parseAttestationsForUniquePublicKeys(data []*format.ProtectionData) (map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedAttestation, error) {
-	bar := common.InitializeProgressBar(
-		len(data),
-		"Parsing attestations for unique public keys:",
-	)
-
-	signedAttestationsByPubKey := make(map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedAttestation)
-	for _, validatorData := range data {
-		if err := bar.Add(1); err != nil {
-			return nil, errors.Wrap(err, "could not increase progress bar")
-		}
-
-		pubKey, err := helpers.PubKeyFromHex(validatorData.Pubkey)
+// proposalsConflictWithHistory returns whether a proposal is at the slot of a proposal saved in the
+// database with a different signing root.
+func (s *Store) proposalsConflictWithHistory(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, proposalHistory *common.ProposalHistoryForPubkey,
+) (bool, error) {
+	for _, proposal := range proposalHistory.Proposals {
+		signingRoot, exists, signingRootExists, err := s.ProposalHistoryForSlot(ctx, pubKey, proposal.Slot)
 		if err != nil {
-			return nil, fmt.Errorf("%s is not a valid public key: %w", validatorData.Pubkey, err)
+			return false, err
 		}
-		for _, sAtt := range validatorData.SignedAttestations {
-			if sAtt == nil {
-				continue
-			}
-			signedAttestationsByPubKey[pubKey] = append(signedAttestationsByPubKey[pubKey], sAtt)
+		if !exists {
+			continue
+		}
+		var existing []byte
+		if signingRootExists {
+			existing = signingRoot[:]
+		}
+		if !bytes.Equal(existing, proposal.SigningRoot) {
+			return true, nil
 		}
 	}
-	return signedAttestationsByPubKey, nil
+	return false, nil
 }
 
 func transformSignedBlocks(_ context.Context, signedBlocks []*format.SignedBlock) (*common.ProposalHistoryForPubkey, error) {
@@ -5,11 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"reflect"
 	"testing"
 
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
@@ -43,6 +43,28 @@ func TestStore_ImportInterchangeData_NilData_FailsSilently(t *testing.T) {
 	require.LogsContain(t, hook, "No slashing protection data to import")
 }
 
+func TestStore_ImportInterchangeData_EmptyData_ValidatesMetadata(t *testing.T) {
+	ctx := context.Background()
+	validatorDB := setupDB(t, nil)
+	require.NoError(t, validatorDB.SaveGenesisValidatorsRoot(ctx, bytesutil.PadTo([]byte{1}, 32)))
+
+	interchangeJSON := &format.EIPSlashingProtectionFormat{Data: []*format.ProtectionData{}}
+	interchangeJSON.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+	interchangeJSON.Metadata.GenesisValidatorsRoot = fmt.Sprintf("%#x", bytesutil.PadTo([]byte{2}, 32))
+	encoded, err := json.Marshal(interchangeJSON)
+	require.NoError(t, err)
+
+	err = validatorDB.ImportStandardProtectionJSON(ctx, bytes.NewBuffer(encoded))
+	require.ErrorContains(t, "genesis validators root doesn't match", err)
+
+	interchangeJSON.Metadata.InterchangeFormatVersion = "1"
+	encoded, err = json.Marshal(interchangeJSON)
+	require.NoError(t, err)
+
+	err = validatorDB.ImportStandardProtectionJSON(ctx, bytes.NewBuffer(encoded))
+	require.ErrorContains(t, "is not supported", err)
+}
+
 func TestStore_ImportInterchangeData_BadFormat_PreventsDBWrites(t *testing.T) {
 	ctx := context.Background()
 	numValidators := 10
@@ -56,13 +78,13 @@ func TestStore_ImportInterchangeData_BadFormat_PreventsDBWrites(t *testing.T) {
 	standardProtectionFormat, err := valtest.MockSlashingProtectionJSON(publicKeys, attestingHistory, proposalHistory)
 	require.NoError(t, err)
 
-	// We replace a slot of one of the blocks with junk data.
-	standardProtectionFormat.Data[0].SignedBlocks[0].Slot = "BadSlot"
+	// We replace a slot of one of the blocks of the last validator with junk data.
+	standardProtectionFormat.Data[numValidators-1].SignedBlocks[0].Slot = "BadSlot"
 
 	// We encode the standard slashing protection struct into a JSON format.
 	blob, err := json.Marshal(standardProtectionFormat)
 	require.NoError(t, err)
-	buf := bytes.NewBuffer(blob)
+	buf := bytes.NewReader(blob)
 
 	// Next, we attempt to import it into our validator database and check that
 	// we obtain an error during the import process.
@@ -168,504 +190,230 @@ func TestStore_ImportInterchangeData_OK(t *testing.T) {
 	}
 }
 
-func Test_parseUniqueSignedBlocksByPubKey(t *testing.T) {
+func Test_importUniqueSignedBlocksByPubKey(t *testing.T) {
 	numValidators := 4
 	publicKeys, err := valtest.CreateRandomPubKeys(numValidators)
 	require.NoError(t, err)
-	roots := valtest.CreateMockRoots(numValidators)
+	roots := [][32]byte{{1}, {2}, {3}, {4}}
+	block := func(slot primitives.Slot, root int) *format.SignedBlock {
+		return &format.SignedBlock{Slot: fmt.Sprintf("%d", slot), SigningRoot: fmt.Sprintf("%#x", roots[root])}
+	}
+	proposal := func(slot primitives.Slot, root int) *common.Proposal {
+		return &common.Proposal{Slot: slot, SigningRoot: roots[root][:]}
+	}
 	tests := []struct {
-		name    string
-		data    []*format.ProtectionData
-		want    map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedBlock
-		wantErr bool
+		name          string
+		data          []*format.ProtectionData
+		want          map[[fieldparams.BLSPubkeyLength]byte][]*common.Proposal
+		wantSlashable [][fieldparams.BLSPubkeyLength]byte
 	}{
 		{
 			name: "nil values are skipped",
 			data: []*format.ProtectionData{
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedBlocks: []*format.SignedBlock{
-						{
-							Slot:        "1",
-							SigningRoot: fmt.Sprintf("%x", roots[0]),
-						},
-						nil,
-					},
-				},
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedBlocks: []*format.SignedBlock{
-						{
-							Slot:        "3",
-							SigningRoot: fmt.Sprintf("%x", roots[2]),
-						},
-					},
-				},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedBlocks: []*format.SignedBlock{block(1, 0), nil}},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedBlocks: []*format.SignedBlock{block(3, 2)}},
 			},
-			want: map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedBlock{
-				publicKeys[0]: {
-					{
-						Slot:        "1",
-						SigningRoot: fmt.Sprintf("%x", roots[0]),
-					},
-					{
-						Slot:        "3",
-						SigningRoot: fmt.Sprintf("%x", roots[2]),
-					},
-				},
+			want: map[[fieldparams.BLSPubkeyLength]byte][]*common.Proposal{
+				publicKeys[0]: {proposal(1, 0), proposal(3, 2)},
 			},
 		},
 		{
 			name: "same blocks but different public keys are parsed correctly",
 			data: []*format.ProtectionData{
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedBlocks: []*format.SignedBlock{
-						{
-							Slot:        "1",
-							SigningRoot: fmt.Sprintf("%x", roots[0]),
-						},
-						{
-							Slot:        "2",
-							SigningRoot: fmt.Sprintf("%x", roots[1]),
-						},
-					},
-				},
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[1]),
-					SignedBlocks: []*format.SignedBlock{
-						{
-							Slot:        "1",
-							SigningRoot: fmt.Sprintf("%x", roots[0]),
-						},
-						{
-							Slot:        "2",
-							SigningRoot: fmt.Sprintf("%x", roots[1]),
-						},
-					},
-				},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedBlocks: []*format.SignedBlock{block(1, 0), block(2, 1)}},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[1]), SignedBlocks: []*format.SignedBlock{block(1, 0), block(2, 1)}},
 			},
-			want: map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedBlock{
-				publicKeys[0]: {
-					{
-						Slot:        "1",
-						SigningRoot: fmt.Sprintf("%x", roots[0]),
-					},
-					{
-						Slot:        "2",
-						SigningRoot: fmt.Sprintf("%x", roots[1]),
-					},
-				},
-				publicKeys[1]: {
-					{
-						Slot:        "1",
-						SigningRoot: fmt.Sprintf("%x", roots[0]),
-					},
-					{
-						Slot:        "2",
-						SigningRoot: fmt.Sprintf("%x", roots[1]),
-					},
-				},
+			want: map[[fieldparams.BLSPubkeyLength]byte][]*common.Proposal{
+				publicKeys[0]: {proposal(1, 0), proposal(2, 1)},
+				publicKeys[1]: {proposal(1, 0), proposal(2, 1)},
 			},
 		},
 		{
 			name: "disjoint sets of signed blocks by the same public key are parsed correctly",
 			data: []*format.ProtectionData{
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedBlocks: []*format.SignedBlock{
-						{
-							Slot:        "1",
-							SigningRoot: fmt.Sprintf("%x", roots[0]),
-						},
-						{
-							Slot:        "2",
-							SigningRoot: fmt.Sprintf("%x", roots[1]),
-						},
-					},
-				},
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedBlocks: []*format.SignedBlock{
-						{
-							Slot:        "3",
-							SigningRoot: fmt.Sprintf("%x", roots[2]),
-						},
-					},
-				},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedBlocks: []*format.SignedBlock{block(1, 0), block(2, 1)}},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedBlocks: []*format.SignedBlock{block(3, 2)}},
 			},
-			want: map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedBlock{
-				publicKeys[0]: {
-					{
-						Slot:        "1",
-						SigningRoot: fmt.Sprintf("%x", roots[0]),
-					},
-					{
-						Slot:        "2",
-						SigningRoot: fmt.Sprintf("%x", roots[1]),
-					},
-					{
-						Slot:        "3",
-						SigningRoot: fmt.Sprintf("%x", roots[2]),
-					},
-				},
+			want: map[[fieldparams.BLSPubkeyLength]byte][]*common.Proposal{
+				publicKeys[0]: {proposal(1, 0), proposal(2, 1), proposal(3, 2)},
 			},
 		},
 		{
 			name: "full duplicate entries are uniquely parsed",
 			data: []*format.ProtectionData{
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedBlocks: []*format.SignedBlock{
-						{
-							Slot:        "1",
-							SigningRoot: fmt.Sprintf("%x", roots[0]),
-						},
-					},
-				},
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedBlocks: []*format.SignedBlock{
-						{
-							Slot:        "1",
-							SigningRoot: fmt.Sprintf("%x", roots[0]),
-						},
-					},
-				},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedBlocks: []*format.SignedBlock{block(1, 0)}},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedBlocks: []*format.SignedBlock{block(1, 0)}},
 			},
-			want: map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedBlock{
-				publicKeys[0]: {
-					{
-						Slot:        "1",
-						SigningRoot: fmt.Sprintf("%x", roots[0]),
-					},
-					{
-						Slot:        "1",
-						SigningRoot: fmt.Sprintf("%x", roots[0]),
-					},
-				},
+			want: map[[fieldparams.BLSPubkeyLength]byte][]*common.Proposal{
+				publicKeys[0]: {proposal(1, 0)},
 			},
 		},
 		{
 			name: "intersecting duplicate public key entries are handled properly",
 			data: []*format.ProtectionData{
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedBlocks: []*format.SignedBlock{
-						{
-							Slot:        "1",
-							SigningRoot: fmt.Sprintf("%x", roots[0]),
-						},
-						{
-							Slot:        "2",
-							SigningRoot: fmt.Sprintf("%x", roots[1]),
-						},
-					},
-				},
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedBlocks: []*format.SignedBlock{
-						{
-							Slot:        "2",
-							SigningRoot: fmt.Sprintf("%x", roots[1]),
-						},
-						{
-							Slot:        "3",
-							SigningRoot: fmt.Sprintf("%x", roots[2]),
-						},
-					},
-				},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedBlocks: []*format.SignedBlock{block(1, 0), block(2, 1)}},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedBlocks: []*format.SignedBlock{block(2, 1), block(3, 2)}},
 			},
-			want: map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedBlock{
-				publicKeys[0]: {
-					{
-						Slot:        "1",
-						SigningRoot: fmt.Sprintf("%x", roots[0]),
-					},
-					{
-						Slot:        "2",
-						SigningRoot: fmt.Sprintf("%x", roots[1]),
-					},
-					{
-						Slot:        "2",
-						SigningRoot: fmt.Sprintf("%x", roots[1]),
-					},
-					{
-						Slot:        "3",
-						SigningRoot: fmt.Sprintf("%x", roots[2]),
-					},
-				},
+			want: map[[fieldparams.BLSPubkeyLength]byte][]*common.Proposal{
+				publicKeys[0]: {proposal(1, 0), proposal(2, 1), proposal(3, 2)},
 			},
 		},
+		{
+			name: "duplicate public key entries with different blocks at a slot are slashable",
+			data: []*format.ProtectionData{
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedBlocks: []*format.SignedBlock{block(1, 0), block(2, 1)}},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[1]), SignedBlocks: []*format.SignedBlock{block(1, 0)}},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedBlocks: []*format.SignedBlock{block(2, 2)}},
+			},
+			want: map[[fieldparams.BLSPubkeyLength]byte][]*common.Proposal{
+				publicKeys[0]: {proposal(1, 0), proposal(2, 2)},
+				publicKeys[1]: {proposal(1, 0)},
+			},
+			wantSlashable: [][fieldparams.BLSPubkeyLength]byte{publicKeys[0]},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseBlocksForUniquePublicKeys(tt.data)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("parseBlocksForUniquePublicKeys() error = %v, wantErr %v", err, tt.wantErr)
-				return
+			ctx := context.Background()
+			validatorDB := importProtectionData(t, tt.data)
+			for pubKey, want := range tt.want {
+				got, err := validatorDB.ProposalHistoryForPubKey(ctx, pubKey)
+				require.NoError(t, err)
+				require.DeepEqual(t, want, got)
 			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("parseBlocksForUniquePublicKeys() got = %v, want %v", got, tt.want)
+			slashable, err := validatorDB.EIPImportBlacklistedPublicKeys(ctx)
+			require.NoError(t, err)
+			require.Equal(t, len(tt.wantSlashable), len(slashable))
+			for i := range tt.wantSlashable {
+				require.Equal(t, tt.wantSlashable[i], slashable[i])
 			}
 		})
 	}
 }
 
-func Test_parseUniqueSignedAttestationsByPubKey(t *testing.T) {
+func Test_importUniqueSignedAttestationsByPubKey(t *testing.T) {
 	numValidators := 4
 	publicKeys, err := valtest.CreateRandomPubKeys(numValidators)
 	require.NoError(t, err)
-	roots := valtest.CreateMockRoots(numValidators)
+	roots := [][32]byte{{1}, {2}, {3}, {4}}
+	att := func(source, target primitives.Epoch, root int) *format.SignedAttestation {
+		return &format.SignedAttestation{
+			SourceEpoch: fmt.Sprintf("%d", source),
+			TargetEpoch: fmt.Sprintf("%d", target),
+			SigningRoot: fmt.Sprintf("%#x", roots[root]),
+		}
+	}
+	record := func(pubKey [fieldparams.BLSPubkeyLength]byte, source, target primitives.Epoch, root int) *common.AttestationRecord {
+		return &common.AttestationRecord{PubKey: pubKey, Source: source, Target: target, SigningRoot: roots[root][:]}
+	}
 	tests := []struct {
-		name    string
-		data    []*format.ProtectionData
-		want    map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedAttestation
-		wantErr bool
+		name          string
+		data          []*format.ProtectionData
+		want          map[[fieldparams.BLSPubkeyLength]byte][]*common.AttestationRecord
+		wantSlashable [][fieldparams.BLSPubkeyLength]byte
 	}{
 		{
 			name: "nil values are skipped",
 			data: []*format.ProtectionData{
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedAttestations: []*format.SignedAttestation{
-						{
-							SourceEpoch: "1",
-							TargetEpoch: "3",
-							SigningRoot: fmt.Sprintf("%x", roots[0]),
-						},
-						nil,
-					},
-				},
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedAttestations: []*format.SignedAttestation{
-						{
-							SourceEpoch: "3",
-							TargetEpoch: "5",
-							SigningRoot: fmt.Sprintf("%x", roots[2]),
-						},
-					},
-				},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedAttestations: []*format.SignedAttestation{att(1, 3, 0), nil}},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedAttestations: []*format.SignedAttestation{att(3, 5, 2)}},
 			},
-			want: map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedAttestation{
-				publicKeys[0]: {
-					{
-						SourceEpoch: "1",
-						TargetEpoch: "3",
-						SigningRoot: fmt.Sprintf("%x", roots[0]),
-					},
-					{
-						SourceEpoch: "3",
-						TargetEpoch: "5",
-						SigningRoot: fmt.Sprintf("%x", roots[2]),
-					},
-				},
+			want: map[[fieldparams.BLSPubkeyLength]byte][]*common.AttestationRecord{
+				publicKeys[0]: {record(publicKeys[0], 1, 3, 0), record(publicKeys[0], 3, 5, 2)},
 			},
 		},
 		{
 			name: "same attestations but different public keys are parsed correctly",
 			data: []*format.ProtectionData{
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedAttestations: []*format.SignedAttestation{
-						{
-							SourceEpoch: "1",
-							SigningRoot: fmt.Sprintf("%x", roots[0]),
-						},
-						{
-							SourceEpoch: "2",
-							SigningRoot: fmt.Sprintf("%x", roots[1]),
-						},
-					},
-				},
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[1]),
-					SignedAttestations: []*format.SignedAttestation{
-						{
-							SourceEpoch: "1",
-							SigningRoot: fmt.Sprintf("%x", roots[0]),
-						},
-						{
-							SourceEpoch: "2",
-							SigningRoot: fmt.Sprintf("%x", roots[1]),
-						},
-					},
-				},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedAttestations: []*format.SignedAttestation{att(1, 2, 0), att(2, 3, 1)}},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[1]), SignedAttestations: []*format.SignedAttestation{att(1, 2, 0), att(2, 3, 1)}},
 			},
-			want: map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedAttestation{
-				publicKeys[0]: {
-					{
-						SourceEpoch: "1",
-						SigningRoot: fmt.Sprintf("%x", roots[0]),
-					},
-					{
-						SourceEpoch: "2",
-						SigningRoot: fmt.Sprintf("%x", roots[1]),
-					},
-				},
-				publicKeys[1]: {
-					{
-						SourceEpoch: "1",
-						SigningRoot: fmt.Sprintf("%x", roots[0]),
-					},
-					{
-						SourceEpoch: "2",
-						SigningRoot: fmt.Sprintf("%x", roots[1]),
-					},
-				},
+			want: map[[fieldparams.BLSPubkeyLength]byte][]*common.AttestationRecord{
+				publicKeys[0]: {record(publicKeys[0], 1, 2, 0), record(publicKeys[0], 2, 3, 1)},
+				publicKeys[1]: {record(publicKeys[1], 1, 2, 0), record(publicKeys[1], 2, 3, 1)},
 			},
 		},
 		{
 			name: "disjoint sets of signed attestations by the same public key are parsed correctly",
 			data: []*format.ProtectionData{
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedAttestations: []*format.SignedAttestation{
-						{
-							SourceEpoch: "1",
-							TargetEpoch: "3",
-							SigningRoot: fmt.Sprintf("%x", roots[0]),
-						},
-						{
-							SourceEpoch: "2",
-							TargetEpoch: "4",
-							SigningRoot: fmt.Sprintf("%x", roots[1]),
-						},
-					},
-				},
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedAttestations: []*format.SignedAttestation{
-						{
-							SourceEpoch: "3",
-							TargetEpoch: "5",
-							SigningRoot: fmt.Sprintf("%x", roots[2]),
-						},
-					},
-				},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedAttestations: []*format.SignedAttestation{att(1, 3, 0), att(2, 4, 1)}},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedAttestations: []*format.SignedAttestation{att(3, 5, 2)}},
 			},
-			want: map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedAttestation{
-				publicKeys[0]: {
-					{
-						SourceEpoch: "1",
-						TargetEpoch: "3",
-						SigningRoot: fmt.Sprintf("%x", roots[0]),
-					},
-					{
-						SourceEpoch: "2",
-						TargetEpoch: "4",
-						SigningRoot: fmt.Sprintf("%x", roots[1]),
-					},
-					{
-						SourceEpoch: "3",
-						TargetEpoch: "5",
-						SigningRoot: fmt.Sprintf("%x", roots[2]),
-					},
-				},
+			want: map[[fieldparams.BLSPubkeyLength]byte][]*common.AttestationRecord{
+				publicKeys[0]: {record(publicKeys[0], 1, 3, 0), record(publicKeys[0], 2, 4, 1), record(publicKeys[0], 3, 5, 2)},
 			},
 		},
 		{
 			name: "full duplicate entries are uniquely parsed",
 			data: []*format.ProtectionData{
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedAttestations: []*format.SignedAttestation{
-						{
-							SourceEpoch: "1",
-							SigningRoot: fmt.Sprintf("%x", roots[0]),
-						},
-					},
-				},
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedAttestations: []*format.SignedAttestation{
-						{
-							SourceEpoch: "1",
-							SigningRoot: fmt.Sprintf("%x", roots[0]),
-						},
-					},
-				},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedAttestations: []*format.SignedAttestation{att(1, 2, 0)}},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedAttestations: []*format.SignedAttestation{att(1, 2, 0)}},
 			},
-			want: map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedAttestation{
-				publicKeys[0]: {
-					{
-						SourceEpoch: "1",
-						SigningRoot: fmt.Sprintf("%x", roots[0]),
-					},
-					{
-						SourceEpoch: "1",
-						SigningRoot: fmt.Sprintf("%x", roots[0]),
-					},
-				},
+			want: map[[fieldparams.BLSPubkeyLength]byte][]*common.AttestationRecord{
+				publicKeys[0]: {record(publicKeys[0], 1, 2, 0)},
 			},
 		},
 		{
 			name: "intersecting duplicate public key entries are handled properly",
 			data: []*format.ProtectionData{
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedAttestations: []*format.SignedAttestation{
-						{
-							SourceEpoch: "1",
-							SigningRoot: fmt.Sprintf("%x", roots[0]),
-						},
-						{
-							SourceEpoch: "2",
-							SigningRoot: fmt.Sprintf("%x", roots[1]),
-						},
-					},
-				},
-				{
-					Pubkey: fmt.Sprintf("%x", publicKeys[0]),
-					SignedAttestations: []*format.SignedAttestation{
-						{
-							SourceEpoch: "2",
-							SigningRoot: fmt.Sprintf("%x", roots[1]),
-						},
-						{
-							SourceEpoch: "3",
-							SigningRoot: fmt.Sprintf("%x", roots[2]),
-						},
-					},
-				},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedAttestations: []*format.SignedAttestation{att(1, 2, 0), att(2, 3, 1)}},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedAttestations: []*format.SignedAttestation{att(2, 3, 1), att(3, 4, 2)}},
 			},
-			want: map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedAttestation{
-				publicKeys[0]: {
-					{
-						SourceEpoch: "1",
-						SigningRoot: fmt.Sprintf("%x", roots[0]),
-					},
-					{
-						SourceEpoch: "2",
-						SigningRoot: fmt.Sprintf("%x", roots[1]),
-					},
-					{
-						SourceEpoch: "2",
-						SigningRoot: fmt.Sprintf("%x", roots[1]),
-					},
-					{
-						SourceEpoch: "3",
-						SigningRoot: fmt.Sprintf("%x", roots[2]),
-					},
-				},
+			want: map[[fieldparams.BLSPubkeyLength]byte][]*common.AttestationRecord{
+				publicKeys[0]: {record(publicKeys[0], 1, 2, 0), record(publicKeys[0], 2, 3, 1), record(publicKeys[0], 3, 4, 2)},
+			},
+		},
+		{
+			name: "duplicate public key entries with slashable attestations are slashable",
+			data: []*format.ProtectionData{
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedAttestations: []*format.SignedAttestation{att(1, 4, 0)}},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[1]), SignedAttestations: []*format.SignedAttestation{att(1, 2, 0)}},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[0]), SignedAttestations: []*format.SignedAttestation{att(2, 3, 1)}},
+				{Pubkey: fmt.Sprintf("%x", publicKeys[1]), SignedAttestations: []*format.SignedAttestation{att(1, 2, 1)}},
+			},
+			want: map[[fieldparams.BLSPubkeyLength]byte][]*common.AttestationRecord{
+				publicKeys[0]: {record(publicKeys[0], 1, 4, 0), record(publicKeys[0], 2, 3, 1)},
+				publicKeys[1]: {record(publicKeys[1], 1, 2, 1), record(publicKeys[1], 1, 2, 1)},
 			},
+			wantSlashable: [][fieldparams.BLSPubkeyLength]byte{publicKeys[0], publicKeys[1]},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseAttestationsForUniquePublicKeys(tt.data)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("parseAttestationsForUniquePublicKeys() error = %v, wantErr %v", err, tt.wantErr)
-				return
+			ctx := context.Background()
+			validatorDB := importProtectionData(t, tt.data)
+			for pubKey, want := range tt.want {
+				got, err := validatorDB.AttestationHistoryForPubKey(ctx, pubKey)
+				require.NoError(t, err)
+				require.DeepEqual(t, want, got)
 			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("parseAttestationsForUniquePublicKeys() got = %v, want %v", got, tt.want)
+			slashable, err := validatorDB.EIPImportBlacklistedPublicKeys(ctx)
+			require.NoError(t, err)
+			require.Equal(t, len(tt.wantSlashable), len(slashable))
+			for _, pubKey := range tt.wantSlashable {
+				found := false
+				for _, k := range slashable {
+					found = found || k == pubKey
+				}
+				require.Equal(t, true, found)
 			}
 		})
 	}
 }
 
+// importProtectionData imports the validator records in a new database, one at a time as they
+// are decoded from the JSON file.
+func importProtectionData(t *testing.T, data []*format.ProtectionData) *Store {
+	validatorDB := setupDB(t, nil)
+	interchangeJSON := &format.EIPSlashingProtectionFormat{Data: data}
+	interchangeJSON.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+	interchangeJSON.Metadata.GenesisValidatorsRoot = fmt.Sprintf("%#x", bytesutil.PadTo([]byte{1}, 32))
+	encoded, err := json.Marshal(interchangeJSON)
+	require.NoError(t, err)
+	require.NoError(t, validatorDB.ImportStandardProtectionJSON(context.Background(), bytes.NewBuffer(encoded)))
+	return validatorDB
+}
+
 func Test_filterSlashablePubKeysFromBlocks(t *testing.T) {
 	var tests = []struct {
 		name     string
@@ -41,6 +41,10 @@ var (
 	// ProposerSettings stores the encoded proposer settings file
 	proposerSettingsBucket = []byte("proposer-settings-bucket")
 	proposerSettingsKey    = []byte("proposer-settings")
+
+	// ExitVault stores the encoded bundle of pre-signed voluntary exits
+	exitVaultBucket = []byte("exit-vault")
+	exitVaultKey    = []byte("vault")
 )
 
 // Attestations:
@@ -157,6 +157,14 @@ func (db *ValidatorDBMock) SaveProposerSettings(ctx context.Context, settings *p
 	panic("not implemented")
 }
 
+// Pre-signed exit vault related methods
+func (db *ValidatorDBMock) ExitVault(context.Context) ([]byte, error) {
+	panic("not implemented")
+}
+func (db *ValidatorDBMock) SaveExitVault(context.Context, []byte) error {
+	panic("not implemented")
+}
+
 // EIP-3076 slashing protection related methods
 func (db *ValidatorDBMock) ImportStandardProtectionJSON(ctx context.Context, r io.Reader) error {
 	panic("not implemented")
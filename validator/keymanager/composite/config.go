@@ -0,0 +1,74 @@
+package composite
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	remoteweb3signer "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer"
+)
+
+// ConfigFileName is the name of the file, in the composite directory of a wallet, configuring its web3signers.
+const ConfigFileName = "composite.json"
+
+// FileConfig is the configuration of a composite wallet, as stored on disk.
+type FileConfig struct {
+	// GenesisValidatorsRoot of the chain, required to sign with web3signers.
+	GenesisValidatorsRoot string              `json:"genesis_validators_root,omitempty"`
+	Web3Signers           []*Web3SignerConfig `json:"web3signers"`
+}
+
+// Web3SignerConfig configures a web3signer, or a group of redundant web3signers sharing the same keys.
+type Web3SignerConfig struct {
+	// URLs of the redundant web3signers, in order of preference.
+	URLs []string `json:"urls"`
+	// PublicKeys the web3signer signs for.
+	PublicKeys []string `json:"public_keys,omitempty"`
+	// PublicKeysURL to fetch the public keys the web3signer signs for from, instead of listing them.
+	PublicKeysURL string `json:"public_keys_url,omitempty"`
+	// KeyFile persisting the keys added through the keymanager API, which are forgotten on restart without it.
+	KeyFile string `json:"key_file,omitempty"`
+}
+
+// ReadFileConfig reads the configuration of a composite wallet.
+func ReadFileConfig(path string) (*FileConfig, error) {
+	enc, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read composite keymanager configuration")
+	}
+	c := &FileConfig{}
+	if err := json.Unmarshal(enc, c); err != nil {
+		return nil, errors.Wrap(err, "could not decode composite keymanager configuration")
+	}
+	return c, nil
+}
+
+// Web3SignerSetupConfigs returns the configurations of the web3signer keymanagers of the wallet.
+func (c *FileConfig) Web3SignerSetupConfigs() ([]*remoteweb3signer.SetupConfig, error) {
+	if len(c.Web3Signers) == 0 {
+		return nil, nil
+	}
+	genesisValidatorsRoot, err := hexutil.Decode(c.GenesisValidatorsRoot)
+	if err != nil || !bytesutil.IsValidRoot(genesisValidatorsRoot) {
+		return nil, fmt.Errorf("invalid genesis validators root %q, required to sign with web3signers", c.GenesisValidatorsRoot)
+	}
+	configs := make([]*remoteweb3signer.SetupConfig, len(c.Web3Signers))
+	for i, w := range c.Web3Signers {
+		if len(w.URLs) == 0 {
+			return nil, fmt.Errorf("no url configured for web3signer %d", i)
+		}
+		configs[i] = &remoteweb3signer.SetupConfig{
+			BaseEndpoint:          w.URLs[0],
+			BackupEndpoints:       w.URLs[1:],
+			GenesisValidatorsRoot: genesisValidatorsRoot,
+			PublicKeysURL:         w.PublicKeysURL,
+			ProvidedPublicKeys:    w.PublicKeys,
+			KeyFilePath:           w.KeyFile,
+		}
+	}
+	return configs, nil
+}
@@ -0,0 +1,44 @@
+package composite_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/composite"
+)
+
+func TestFileConfig_Web3SignerSetupConfigs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), composite.ConfigFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"genesis_validators_root": "0x270d43e74ce340de4bca2b1936beca0f4f5408d9e78aec4850920baf659d5b69",
+		"web3signers": [
+			{"urls": ["http://signer-1a:9000", "http://signer-1b:9000"], "key_file": "/keys.txt"},
+			{"urls": ["http://signer-2:9000"], "public_keys_url": "http://signer-2:9000/api/v1/eth2/publicKeys"}
+		]
+	}`), 0600))
+
+	fileConfig, err := composite.ReadFileConfig(path)
+	require.NoError(t, err)
+	configs, err := fileConfig.Web3SignerSetupConfigs()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(configs))
+	assert.Equal(t, "http://signer-1a:9000", configs[0].BaseEndpoint)
+	assert.DeepEqual(t, []string{"http://signer-1b:9000"}, configs[0].BackupEndpoints)
+	assert.Equal(t, "/keys.txt", configs[0].KeyFilePath)
+	assert.Equal(t, "http://signer-2:9000", configs[1].BaseEndpoint)
+	assert.Equal(t, 0, len(configs[1].BackupEndpoints))
+	assert.Equal(t, "http://signer-2:9000/api/v1/eth2/publicKeys", configs[1].PublicKeysURL)
+	assert.Equal(t, 32, len(configs[1].GenesisValidatorsRoot))
+
+	fileConfig.GenesisValidatorsRoot = ""
+	_, err = fileConfig.Web3SignerSetupConfigs()
+	assert.ErrorContains(t, "invalid genesis validators root", err)
+
+	// Without web3signers, no genesis validators root is needed.
+	configs, err = (&composite.FileConfig{}).Web3SignerSetupConfigs()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(configs))
+}
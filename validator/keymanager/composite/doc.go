@@ -0,0 +1,23 @@
+/*
+Package composite defines a keymanager which signs with several keymanagers at once: the
+keystore of the wallet, holding local or derived keys, and any number of web3signers. Each
+signing request is routed by public key to the keymanager holding the key, so that keys can
+be moved from the wallet to a remote signer one at a time, without stopping the validator
+client.
+
+A web3signer can be a group of redundant web3signers, sharing the same keys and slashing
+protection database. Requests are sent to the first one which responds, and stick to it until
+it stops responding. A web3signer refusing to sign a message because of its slashing
+protection rules is never failed over.
+
+Keys imported through the keymanager API go to the wallet keystore for keystores, and to the
+web3signer matching the url of the remote key, or to the first web3signer, for remote keys.
+A key cannot be imported in both: it must be deleted from one before being imported in the
+other, exporting its slashing protection history when deleted from the keystore.
+
+The keys of a composite wallet are stored in a local or derived wallet in the composite
+directory of the wallet, and the web3signers are configured in its composite.json file. The
+local keymanager keeping its keys in process-wide caches, a composite wallet has a single
+keystore.
+*/
+package composite
@@ -0,0 +1,493 @@
+package composite
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/logrusorgru/aurora"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	remoteweb3signer "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer"
+)
+
+// Keystore is a keymanager storing the validator keys in the wallet, such as a local or derived keymanager.
+type Keystore interface {
+	keymanager.IKeymanager
+	keymanager.Importer
+}
+
+// RemoteSigner is a keymanager signing with a web3signer.
+type RemoteSigner interface {
+	keymanager.IKeymanager
+	keymanager.PublicKeyAdder
+	keymanager.PublicKeyDeleter
+}
+
+// Web3Signer is a web3signer, or a group of redundant web3signers, the keymanager signs with.
+type Web3Signer struct {
+	// URLs of the web3signers, the first one identifying the group in the keymanager API.
+	URLs       []string
+	Keymanager RemoteSigner
+}
+
+// RemoteKey is a public key signed for by a web3signer.
+type RemoteKey struct {
+	// PublicKey as a hex string.
+	PublicKey string
+	// URL of the web3signer. Empty when adding a key means the first web3signer.
+	URL string
+}
+
+// SetupConfig includes the keymanagers a composite keymanager routes signing requests to.
+type SetupConfig struct {
+	// Keystore holding the keys of the wallet, nil if the keymanager only signs with web3signers.
+	Keystore    Keystore
+	Web3Signers []*Web3Signer
+	// ListenForChanges refreshes the keys signed for when the keys of a keymanager change.
+	ListenForChanges bool
+}
+
+// Keymanager routes signing requests by public key to the keystore of the wallet or to a web3signer.
+type Keymanager struct {
+	keystore            Keystore
+	web3Signers         []*Web3Signer
+	accountsChangedFeed *event.Feed
+
+	lock         sync.RWMutex
+	publicKeys   [][fieldparams.BLSPubkeyLength]byte
+	keystoreKeys map[[fieldparams.BLSPubkeyLength]byte]bool
+	remoteKeys   map[[fieldparams.BLSPubkeyLength]byte]*Web3Signer
+}
+
+// NewKeymanager instantiates a composite keymanager over the given keymanagers.
+func NewKeymanager(ctx context.Context, cfg *SetupConfig) (*Keymanager, error) {
+	if cfg.Keystore == nil && len(cfg.Web3Signers) == 0 {
+		return nil, errors.New("no keystore nor web3signer configured")
+	}
+	for i, w := range cfg.Web3Signers {
+		if len(w.URLs) == 0 || w.Keymanager == nil {
+			return nil, fmt.Errorf("web3signer %d has no url or keymanager", i)
+		}
+	}
+	km := &Keymanager{
+		keystore:            cfg.Keystore,
+		web3Signers:         cfg.Web3Signers,
+		accountsChangedFeed: new(event.Feed),
+	}
+	if err := km.refresh(ctx); err != nil {
+		return nil, err
+	}
+	if cfg.ListenForChanges {
+		go km.listenForChanges(ctx)
+	}
+	return km, nil
+}
+
+// refresh rebuilds the routes of the public keys from the keys of the keymanagers. A key held by
+// several keymanagers is signed for by the keystore, or else by the first web3signer holding it.
+func (km *Keymanager) refresh(ctx context.Context) error {
+	var publicKeys [][fieldparams.BLSPubkeyLength]byte
+	keystoreKeys := make(map[[fieldparams.BLSPubkeyLength]byte]bool)
+	remoteKeys := make(map[[fieldparams.BLSPubkeyLength]byte]*Web3Signer)
+	if km.keystore != nil {
+		keys, err := km.keystore.FetchValidatingPublicKeys(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not fetch public keys of the keystore")
+		}
+		for _, key := range keys {
+			if !keystoreKeys[key] {
+				keystoreKeys[key] = true
+				publicKeys = append(publicKeys, key)
+			}
+		}
+	}
+	for _, w := range km.web3Signers {
+		keys, err := w.Keymanager.FetchValidatingPublicKeys(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "could not fetch public keys of web3signer %s", w.URLs[0])
+		}
+		for _, key := range keys {
+			if keystoreKeys[key] {
+				log.WithField("publicKey", fmt.Sprintf("%#x", key)).WithField("url", w.URLs[0]).
+					Warn("Key is both in the keystore and in a web3signer, signing with the keystore")
+				continue
+			}
+			if other, ok := remoteKeys[key]; ok {
+				if other != w {
+					log.WithField("publicKey", fmt.Sprintf("%#x", key)).WithField("url", w.URLs[0]).
+						Warnf("Key is also in web3signer %s, signing with it", other.URLs[0])
+				}
+				continue
+			}
+			remoteKeys[key] = w
+			publicKeys = append(publicKeys, key)
+		}
+	}
+
+	km.lock.Lock()
+	defer km.lock.Unlock()
+	km.publicKeys = publicKeys
+	km.keystoreKeys = keystoreKeys
+	km.remoteKeys = remoteKeys
+	return nil
+}
+
+func (km *Keymanager) listenForChanges(ctx context.Context) {
+	changes := make(chan [][fieldparams.BLSPubkeyLength]byte, 1)
+	var subs []event.Subscription
+	if km.keystore != nil {
+		subs = append(subs, km.keystore.SubscribeAccountChanges(changes))
+	}
+	for _, w := range km.web3Signers {
+		subs = append(subs, w.Keymanager.SubscribeAccountChanges(changes))
+	}
+	defer func() {
+		for _, sub := range subs {
+			sub.Unsubscribe()
+		}
+	}()
+	for {
+		select {
+		case <-changes:
+			km.refreshAndNotify(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (km *Keymanager) refreshAndNotify(ctx context.Context) {
+	if err := km.refresh(ctx); err != nil {
+		log.WithError(err).Error("Could not refresh public keys")
+		return
+	}
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not fetch public keys")
+		return
+	}
+	km.accountsChangedFeed.Send(keys)
+}
+
+// FetchValidatingPublicKeys returns the public keys of the keystore and of the web3signers.
+func (km *Keymanager) FetchValidatingPublicKeys(_ context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	km.lock.RLock()
+	defer km.lock.RUnlock()
+	keys := make([][fieldparams.BLSPubkeyLength]byte, len(km.publicKeys))
+	copy(keys, km.publicKeys)
+	return keys, nil
+}
+
+// Keystore returns the keystore of the wallet, nil if the keymanager only signs with web3signers.
+func (km *Keymanager) Keystore() Keystore {
+	return km.keystore
+}
+
+// FetchKeystorePublicKeys returns the public keys stored in the keystore of the wallet.
+func (km *Keymanager) FetchKeystorePublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	if km.keystore == nil {
+		return nil, nil
+	}
+	return km.keystore.FetchValidatingPublicKeys(ctx)
+}
+
+// FetchRemoteKeys returns the public keys signed for by web3signers, with the url of their web3signer.
+func (km *Keymanager) FetchRemoteKeys(_ context.Context) ([]*RemoteKey, error) {
+	km.lock.RLock()
+	defer km.lock.RUnlock()
+	keys := make([]*RemoteKey, 0, len(km.remoteKeys))
+	for _, key := range km.publicKeys {
+		if w, ok := km.remoteKeys[key]; ok {
+			keys = append(keys, &RemoteKey{PublicKey: hexutil.Encode(key[:]), URL: w.URLs[0]})
+		}
+	}
+	return keys, nil
+}
+
+// Sign signs the message with the keymanager holding its public key.
+func (km *Keymanager) Sign(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	key := bytesutil.ToBytes48(req.PublicKey)
+	km.lock.RLock()
+	inKeystore := km.keystoreKeys[key]
+	w := km.remoteKeys[key]
+	km.lock.RUnlock()
+	switch {
+	case inKeystore:
+		return km.keystore.Sign(ctx, req)
+	case w != nil:
+		return w.Keymanager.Sign(ctx, req)
+	default:
+		return nil, fmt.Errorf("no keystore nor web3signer holds public key %#x", req.PublicKey)
+	}
+}
+
+// SubscribeAccountChanges creates an event subscription for a channel
+// to listen for public key changes at runtime.
+func (km *Keymanager) SubscribeAccountChanges(pubKeysChan chan [][fieldparams.BLSPubkeyLength]byte) event.Subscription {
+	return km.accountsChangedFeed.Subscribe(pubKeysChan)
+}
+
+// ExtractKeystores exports the given keys of the keystore, keys signed for by web3signers cannot be extracted.
+func (km *Keymanager) ExtractKeystores(
+	ctx context.Context, publicKeys []bls.PublicKey, password string,
+) ([]*keymanager.Keystore, error) {
+	if km.keystore == nil {
+		return nil, errors.New("extracting keys is not supported without a keystore")
+	}
+	return km.keystore.ExtractKeystores(ctx, publicKeys, password)
+}
+
+// ImportKeystores imports the keystores into the keystore of the wallet. Keys signed for by
+// a web3signer are refused as duplicates.
+func (km *Keymanager) ImportKeystores(
+	ctx context.Context, keystores []*keymanager.Keystore, passwords []string,
+) ([]*keymanager.KeyStatus, error) {
+	statuses := make([]*keymanager.KeyStatus, len(keystores))
+	if km.keystore == nil {
+		for i := range statuses {
+			statuses[i] = &keymanager.KeyStatus{
+				Status:  keymanager.StatusError,
+				Message: "No keystore to import keys into, keys can only be added to web3signers",
+			}
+		}
+		return statuses, nil
+	}
+	if len(passwords) != len(keystores) {
+		return km.keystore.ImportKeystores(ctx, keystores, passwords)
+	}
+
+	var indices []int
+	var toImport []*keymanager.Keystore
+	var toImportPasswords []string
+	for i, k := range keystores {
+		if w := km.web3SignerOf(k.Pubkey); w != nil {
+			statuses[i] = &keymanager.KeyStatus{
+				Status:  keymanager.StatusDuplicate,
+				Message: fmt.Sprintf("Public key %s is signed for by web3signer %s, delete it from the web3signer first", k.Pubkey, w.URLs[0]),
+			}
+			continue
+		}
+		indices = append(indices, i)
+		toImport = append(toImport, k)
+		toImportPasswords = append(toImportPasswords, passwords[i])
+	}
+	if len(toImport) == 0 {
+		return statuses, nil
+	}
+	imported, err := km.keystore.ImportKeystores(ctx, toImport, toImportPasswords)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range indices {
+		statuses[i] = imported[j]
+	}
+	if err := km.refresh(ctx); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// DeleteKeystores deletes the given keys from the keystore of the wallet.
+func (km *Keymanager) DeleteKeystores(ctx context.Context, publicKeys [][]byte) ([]*keymanager.KeyStatus, error) {
+	if km.keystore == nil {
+		return nil, errors.New("deleting keys is not supported without a keystore")
+	}
+	statuses, err := km.keystore.DeleteKeystores(ctx, publicKeys)
+	if err != nil {
+		return nil, err
+	}
+	if err := km.refresh(ctx); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// AddPublicKeys adds the public keys to the first web3signer.
+func (km *Keymanager) AddPublicKeys(publicKeys []string) ([]*keymanager.KeyStatus, error) {
+	keys := make([]*RemoteKey, len(publicKeys))
+	for i, key := range publicKeys {
+		keys[i] = &RemoteKey{PublicKey: key}
+	}
+	return km.AddRemoteKeys(keys)
+}
+
+// AddRemoteKeys adds the public keys to the web3signer at their url. Keys stored in the keystore
+// or signed for by another web3signer are refused as duplicates.
+func (km *Keymanager) AddRemoteKeys(keys []*RemoteKey) ([]*keymanager.KeyStatus, error) {
+	statuses := make([]*keymanager.KeyStatus, len(keys))
+	added := make(map[*Web3Signer][]int)
+	for i, key := range keys {
+		w := km.web3SignerAt(key.URL)
+		if w == nil {
+			statuses[i] = &keymanager.KeyStatus{
+				Status:  keymanager.StatusError,
+				Message: fmt.Sprintf("No web3signer configured at url %q", key.URL),
+			}
+			continue
+		}
+		if km.inKeystore(key.PublicKey) {
+			statuses[i] = &keymanager.KeyStatus{
+				Status:  keymanager.StatusDuplicate,
+				Message: fmt.Sprintf("Public key %s is stored in the keystore, delete it from the keystore first", key.PublicKey),
+			}
+			continue
+		}
+		if other := km.web3SignerOf(key.PublicKey); other != nil && other != w {
+			statuses[i] = &keymanager.KeyStatus{
+				Status:  keymanager.StatusDuplicate,
+				Message: fmt.Sprintf("Public key %s is signed for by web3signer %s", key.PublicKey, other.URLs[0]),
+			}
+			continue
+		}
+		added[w] = append(added[w], i)
+	}
+	if err := km.forEachWeb3Signer(keys, added, statuses, RemoteSigner.AddPublicKeys); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// DeletePublicKeys deletes the public keys from the web3signers signing for them.
+func (km *Keymanager) DeletePublicKeys(publicKeys []string) ([]*keymanager.KeyStatus, error) {
+	statuses := make([]*keymanager.KeyStatus, len(publicKeys))
+	keys := make([]*RemoteKey, len(publicKeys))
+	deleted := make(map[*Web3Signer][]int)
+	for i, key := range publicKeys {
+		keys[i] = &RemoteKey{PublicKey: key}
+		w := km.web3SignerOf(key)
+		if w == nil {
+			statuses[i] = &keymanager.KeyStatus{
+				Status:  keymanager.StatusNotFound,
+				Message: fmt.Sprintf("Public key %s is not signed for by a web3signer", key),
+			}
+			continue
+		}
+		deleted[w] = append(deleted[w], i)
+	}
+	if err := km.forEachWeb3Signer(keys, deleted, statuses, RemoteSigner.DeletePublicKeys); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// forEachWeb3Signer applies the update to the keys of each web3signer, filling their statuses.
+func (km *Keymanager) forEachWeb3Signer(
+	keys []*RemoteKey,
+	indices map[*Web3Signer][]int,
+	statuses []*keymanager.KeyStatus,
+	update func(RemoteSigner, []string) ([]*keymanager.KeyStatus, error),
+) error {
+	updated := false
+	for _, w := range km.web3Signers {
+		if len(indices[w]) == 0 {
+			continue
+		}
+		publicKeys := make([]string, len(indices[w]))
+		for j, i := range indices[w] {
+			publicKeys[j] = keys[i].PublicKey
+		}
+		sts, err := update(w.Keymanager, publicKeys)
+		if err != nil {
+			return errors.Wrapf(err, "could not update keys of web3signer %s", w.URLs[0])
+		}
+		for j, i := range indices[w] {
+			statuses[i] = sts[j]
+		}
+		updated = true
+	}
+	if !updated {
+		return nil
+	}
+	// Route the keys right away instead of waiting for the change notifications.
+	return km.refresh(context.Background())
+}
+
+// web3SignerAt returns the web3signer at the given url, or the first one if the url is empty.
+func (km *Keymanager) web3SignerAt(url string) *Web3Signer {
+	if len(km.web3Signers) == 0 {
+		return nil
+	}
+	if url == "" {
+		return km.web3Signers[0]
+	}
+	url = strings.TrimSuffix(url, "/")
+	for _, w := range km.web3Signers {
+		for _, u := range w.URLs {
+			if strings.TrimSuffix(u, "/") == url {
+				return w
+			}
+		}
+	}
+	return nil
+}
+
+// web3SignerOf returns the web3signer signing for the public key, nil if none does.
+func (km *Keymanager) web3SignerOf(publicKey string) *Web3Signer {
+	key, ok := decodePublicKey(publicKey)
+	if !ok {
+		return nil
+	}
+	km.lock.RLock()
+	defer km.lock.RUnlock()
+	return km.remoteKeys[key]
+}
+
+func (km *Keymanager) inKeystore(publicKey string) bool {
+	key, ok := decodePublicKey(publicKey)
+	if !ok {
+		return false
+	}
+	km.lock.RLock()
+	defer km.lock.RUnlock()
+	return km.keystoreKeys[key]
+}
+
+// decodePublicKey decodes a hex public key, with or without prefix as in EIP-2335 keystores.
+func decodePublicKey(publicKey string) ([fieldparams.BLSPubkeyLength]byte, bool) {
+	b, err := hex.DecodeString(strings.TrimPrefix(publicKey, "0x"))
+	if err != nil || len(b) != fieldparams.BLSPubkeyLength {
+		return [fieldparams.BLSPubkeyLength]byte{}, false
+	}
+	return bytesutil.ToBytes48(b), true
+}
+
+// ListKeymanagerAccounts lists the keys of the keystore and of each web3signer.
+func (km *Keymanager) ListKeymanagerAccounts(ctx context.Context, cfg keymanager.ListKeymanagerAccountConfig) error {
+	au := aurora.NewAurora(true)
+	fmt.Printf("(keymanager kind) %s\n", au.BrightGreen("composite").Bold())
+	fmt.Printf(
+		"(configuration file path) %s\n",
+		au.BrightGreen(filepath.Join(cfg.WalletAccountsDir, ConfigFileName)).Bold(),
+	)
+	fmt.Println(" ")
+	if km.keystore != nil {
+		if err := km.keystore.ListKeymanagerAccounts(ctx, cfg); err != nil {
+			return err
+		}
+		fmt.Println(" ")
+	}
+	for _, w := range km.web3Signers {
+		keys, err := w.Keymanager.FetchValidatingPublicKeys(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "could not fetch public keys of web3signer %s", w.URLs[0])
+		}
+		fmt.Printf("(web3signer) %s\n", au.BrightGreen(strings.Join(w.URLs, ", ")).Bold())
+		if len(keys) == 1 {
+			fmt.Print("Showing 1 validator account\n")
+		} else {
+			fmt.Printf("Showing %d validator accounts\n", len(keys))
+		}
+		remoteweb3signer.DisplayRemotePublicKeys(keys)
+	}
+	return nil
+}
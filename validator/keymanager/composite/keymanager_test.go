@@ -0,0 +1,265 @@
+package composite_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/composite"
+)
+
+// fakeKeymanager holds public keys and signs any message with its own key.
+type fakeKeymanager struct {
+	sk   bls.SecretKey
+	feed *event.Feed
+	lock sync.Mutex
+	keys [][fieldparams.BLSPubkeyLength]byte
+}
+
+func newFakeKeymanager(t *testing.T, keys ...[fieldparams.BLSPubkeyLength]byte) *fakeKeymanager {
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	return &fakeKeymanager{sk: sk, feed: new(event.Feed), keys: keys}
+}
+
+func (f *fakeKeymanager) setKeys(keys ...[fieldparams.BLSPubkeyLength]byte) {
+	f.lock.Lock()
+	f.keys = keys
+	f.lock.Unlock()
+	f.feed.Send(keys)
+}
+
+func (f *fakeKeymanager) FetchValidatingPublicKeys(context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([][fieldparams.BLSPubkeyLength]byte{}, f.keys...), nil
+}
+
+func (f *fakeKeymanager) Sign(_ context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	return f.sk.Sign(req.SigningRoot), nil
+}
+
+func (f *fakeKeymanager) SubscribeAccountChanges(ch chan [][fieldparams.BLSPubkeyLength]byte) event.Subscription {
+	return f.feed.Subscribe(ch)
+}
+
+func (*fakeKeymanager) ExtractKeystores(context.Context, []bls.PublicKey, string) ([]*keymanager.Keystore, error) {
+	return nil, errors.New("not supported")
+}
+
+func (*fakeKeymanager) ListKeymanagerAccounts(context.Context, keymanager.ListKeymanagerAccountConfig) error {
+	return nil
+}
+
+func (f *fakeKeymanager) ImportKeystores(_ context.Context, keystores []*keymanager.Keystore, _ []string) ([]*keymanager.KeyStatus, error) {
+	statuses := make([]*keymanager.KeyStatus, len(keystores))
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for i, k := range keystores {
+		f.keys = append(f.keys, bytesutil.ToBytes48(hexutil.MustDecode("0x"+k.Pubkey)))
+		statuses[i] = &keymanager.KeyStatus{Status: keymanager.StatusImported}
+	}
+	return statuses, nil
+}
+
+func (f *fakeKeymanager) DeleteKeystores(_ context.Context, publicKeys [][]byte) ([]*keymanager.KeyStatus, error) {
+	statuses := make([]*keymanager.KeyStatus, len(publicKeys))
+	for i, key := range publicKeys {
+		statuses[i] = f.remove(bytesutil.ToBytes48(key))
+	}
+	return statuses, nil
+}
+
+func (f *fakeKeymanager) AddPublicKeys(publicKeys []string) ([]*keymanager.KeyStatus, error) {
+	statuses := make([]*keymanager.KeyStatus, len(publicKeys))
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for i, key := range publicKeys {
+		f.keys = append(f.keys, bytesutil.ToBytes48(hexutil.MustDecode(key)))
+		statuses[i] = &keymanager.KeyStatus{Status: keymanager.StatusImported}
+	}
+	return statuses, nil
+}
+
+func (f *fakeKeymanager) DeletePublicKeys(publicKeys []string) ([]*keymanager.KeyStatus, error) {
+	statuses := make([]*keymanager.KeyStatus, len(publicKeys))
+	for i, key := range publicKeys {
+		statuses[i] = f.remove(bytesutil.ToBytes48(hexutil.MustDecode(key)))
+	}
+	return statuses, nil
+}
+
+func (f *fakeKeymanager) remove(key [fieldparams.BLSPubkeyLength]byte) *keymanager.KeyStatus {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for i, k := range f.keys {
+		if k == key {
+			f.keys = append(f.keys[:i], f.keys[i+1:]...)
+			return &keymanager.KeyStatus{Status: keymanager.StatusDeleted}
+		}
+	}
+	return &keymanager.KeyStatus{Status: keymanager.StatusNotFound}
+}
+
+func key(b byte) [fieldparams.BLSPubkeyLength]byte {
+	return bytesutil.ToBytes48(bytesutil.PadTo([]byte{b}, fieldparams.BLSPubkeyLength))
+}
+
+func sign(t *testing.T, km keymanager.Signer, pub [fieldparams.BLSPubkeyLength]byte) []byte {
+	sig, err := km.Sign(context.Background(), &validatorpb.SignRequest{PublicKey: pub[:], SigningRoot: make([]byte, 32)})
+	require.NoError(t, err)
+	return sig.Marshal()
+}
+
+func setup(t *testing.T) (*composite.Keymanager, *fakeKeymanager, *fakeKeymanager, *fakeKeymanager) {
+	keystore := newFakeKeymanager(t, key(1), key(2))
+	remote1 := newFakeKeymanager(t, key(3), key(2))
+	remote2 := newFakeKeymanager(t, key(4))
+	km, err := composite.NewKeymanager(context.Background(), &composite.SetupConfig{
+		Keystore: keystore,
+		Web3Signers: []*composite.Web3Signer{
+			{URLs: []string{"http://signer-1a:9000", "http://signer-1b:9000"}, Keymanager: remote1},
+			{URLs: []string{"http://signer-2:9000"}, Keymanager: remote2},
+		},
+	})
+	require.NoError(t, err)
+	return km, keystore, remote1, remote2
+}
+
+func TestKeymanager_Sign(t *testing.T) {
+	km, keystore, remote1, remote2 := setup(t)
+
+	keys, err := km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{key(1), key(2), key(3), key(4)}, keys)
+
+	assert.DeepEqual(t, sign(t, keystore, key(1)), sign(t, km, key(1)))
+	// A key in both the keystore and a web3signer is signed for by the keystore.
+	assert.DeepEqual(t, sign(t, keystore, key(2)), sign(t, km, key(2)))
+	assert.DeepEqual(t, sign(t, remote1, key(3)), sign(t, km, key(3)))
+	assert.DeepEqual(t, sign(t, remote2, key(4)), sign(t, km, key(4)))
+
+	unknown := key(5)
+	_, err = km.Sign(context.Background(), &validatorpb.SignRequest{PublicKey: unknown[:], SigningRoot: make([]byte, 32)})
+	assert.ErrorContains(t, "no keystore nor web3signer holds public key", err)
+}
+
+func TestKeymanager_FetchKeys(t *testing.T) {
+	km, _, _, _ := setup(t)
+
+	keys, err := km.FetchKeystorePublicKeys(context.Background())
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{key(1), key(2)}, keys)
+	remoteKeys, err := km.FetchRemoteKeys(context.Background())
+	require.NoError(t, err)
+	k3, k4 := key(3), key(4)
+	assert.DeepEqual(t, []*composite.RemoteKey{
+		{PublicKey: hexutil.Encode(k3[:]), URL: "http://signer-1a:9000"},
+		{PublicKey: hexutil.Encode(k4[:]), URL: "http://signer-2:9000"},
+	}, remoteKeys)
+}
+
+func TestKeymanager_ImportKeystores(t *testing.T) {
+	km, keystore, _, _ := setup(t)
+	k3, k5 := key(3), key(5)
+
+	statuses, err := km.ImportKeystores(context.Background(), []*keymanager.Keystore{
+		{Pubkey: hexutil.Encode(k3[:])[2:]},
+		{Pubkey: hexutil.Encode(k5[:])[2:]},
+	}, []string{"password", "password"})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(statuses))
+	assert.Equal(t, keymanager.StatusDuplicate, statuses[0].Status)
+	assert.StringContains(t, "signed for by web3signer http://signer-1a:9000", statuses[0].Message)
+	assert.Equal(t, keymanager.StatusImported, statuses[1].Status)
+	assert.DeepEqual(t, sign(t, keystore, k5), sign(t, km, k5))
+}
+
+func TestKeymanager_AddRemoteKeys(t *testing.T) {
+	km, _, remote1, remote2 := setup(t)
+	k1, k5, k6, k7 := key(1), key(5), key(6), key(7)
+
+	statuses, err := km.AddRemoteKeys([]*composite.RemoteKey{
+		{PublicKey: hexutil.Encode(k1[:])},
+		{PublicKey: hexutil.Encode(k5[:])},
+		{PublicKey: hexutil.Encode(k6[:]), URL: "http://signer-2:9000/"},
+		{PublicKey: hexutil.Encode(k7[:]), URL: "http://signer-3:9000"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 4, len(statuses))
+	assert.Equal(t, keymanager.StatusDuplicate, statuses[0].Status)
+	assert.Equal(t, keymanager.StatusImported, statuses[1].Status)
+	assert.Equal(t, keymanager.StatusImported, statuses[2].Status)
+	assert.Equal(t, keymanager.StatusError, statuses[3].Status)
+
+	// Keys without url go to the first web3signer.
+	assert.DeepEqual(t, sign(t, remote1, k5), sign(t, km, k5))
+	assert.DeepEqual(t, sign(t, remote2, k6), sign(t, km, k6))
+
+	// A key cannot be added to two web3signers.
+	statuses, err = km.AddRemoteKeys([]*composite.RemoteKey{{PublicKey: hexutil.Encode(k5[:]), URL: "http://signer-2:9000"}})
+	require.NoError(t, err)
+	assert.Equal(t, keymanager.StatusDuplicate, statuses[0].Status)
+}
+
+func TestKeymanager_DeletePublicKeys(t *testing.T) {
+	km, _, _, _ := setup(t)
+	k1, k3, k4 := key(1), key(3), key(4)
+
+	statuses, err := km.DeletePublicKeys([]string{hexutil.Encode(k1[:]), hexutil.Encode(k3[:]), hexutil.Encode(k4[:])})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(statuses))
+	assert.Equal(t, keymanager.StatusNotFound, statuses[0].Status)
+	assert.Equal(t, keymanager.StatusDeleted, statuses[1].Status)
+	assert.Equal(t, keymanager.StatusDeleted, statuses[2].Status)
+
+	keys, err := km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{key(1), key(2)}, keys)
+}
+
+func TestKeymanager_ListenForChanges(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	keystore := newFakeKeymanager(t, key(1))
+	remote := newFakeKeymanager(t, key(2))
+	km, err := composite.NewKeymanager(ctx, &composite.SetupConfig{
+		Keystore:         keystore,
+		Web3Signers:      []*composite.Web3Signer{{URLs: []string{"http://signer:9000"}, Keymanager: remote}},
+		ListenForChanges: true,
+	})
+	require.NoError(t, err)
+	changes := make(chan [][fieldparams.BLSPubkeyLength]byte, 1)
+	sub := km.SubscribeAccountChanges(changes)
+	defer sub.Unsubscribe()
+
+	// Wait for the keymanager to subscribe to the changes of the web3signer, the last one it subscribes to.
+	for remote.feed.Send([][fieldparams.BLSPubkeyLength]byte{key(2)}) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	<-changes
+	remote.setKeys(key(2), key(3))
+	select {
+	case keys := <-changes:
+		assert.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{key(1), key(2), key(3)}, keys)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change notified")
+	}
+	assert.DeepEqual(t, sign(t, remote, key(3)), sign(t, km, key(3)))
+}
+
+func TestNewKeymanager_NoSigner(t *testing.T) {
+	_, err := composite.NewKeymanager(context.Background(), &composite.SetupConfig{})
+	assert.ErrorContains(t, "no keystore nor web3signer configured", err)
+}
@@ -0,0 +1,231 @@
+package remote_grpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+)
+
+// errStreamClosed is returned to the requests of the batches still in flight when their stream closes.
+var errStreamClosed = errors.New("sign batch stream closed")
+
+// batcher gathers the requests signed concurrently into batches sent over a SignBatch stream,
+// so that the requests of all the validators at the start of a slot share a few round trips.
+type batcher struct {
+	client       validatorpb.RemoteSignerClient
+	window       time.Duration
+	maxBatchSize int
+
+	lock    sync.Mutex
+	pending []*call
+	timer   *time.Timer
+	stream  *batchStream
+}
+
+// call is a request waiting for its response.
+type call struct {
+	req  *validatorpb.SignRequest
+	resp *validatorpb.SignResponse
+	err  error
+	done chan struct{}
+}
+
+func (c *call) finish(resp *validatorpb.SignResponse, err error) {
+	c.resp, c.err = resp, err
+	close(c.done)
+}
+
+func newBatcher(client validatorpb.RemoteSignerClient, window time.Duration, maxBatchSize int) *batcher {
+	return &batcher{
+		client:       client,
+		window:       window,
+		maxBatchSize: maxBatchSize,
+	}
+}
+
+// sign adds the request to the next batch, sent once the batch window elapses or the batch is full,
+// and waits for its response.
+func (b *batcher) sign(ctx context.Context, req *validatorpb.SignRequest) (*validatorpb.SignResponse, error) {
+	c := &call{req: req, done: make(chan struct{})}
+	var full []*call
+	b.lock.Lock()
+	b.pending = append(b.pending, c)
+	if len(b.pending) >= b.maxBatchSize {
+		full = b.takePending()
+	} else if len(b.pending) == 1 {
+		b.timer = time.AfterFunc(b.window, b.flush)
+	}
+	b.lock.Unlock()
+	if full != nil {
+		b.send(full)
+	}
+
+	select {
+	case <-c.done:
+		return c.resp, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (b *batcher) flush() {
+	b.lock.Lock()
+	calls := b.takePending()
+	b.lock.Unlock()
+	if len(calls) > 0 {
+		b.send(calls)
+	}
+}
+
+// takePending must be called with the lock held.
+func (b *batcher) takePending() []*call {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	calls := b.pending
+	b.pending = nil
+	return calls
+}
+
+func (b *batcher) send(calls []*call) {
+	s, err := b.openStream()
+	if err != nil {
+		for _, c := range calls {
+			c.finish(nil, err)
+		}
+		return
+	}
+	s.send(calls)
+}
+
+// openStream returns the current stream, opening a new one if it was closed.
+func (b *batcher) openStream() (*batchStream, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.stream != nil && !b.stream.isClosed() {
+		return b.stream, nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := b.client.SignBatch(ctx)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrap(err, "could not open sign batch stream")
+	}
+	b.stream = &batchStream{
+		stream:   stream,
+		cancel:   cancel,
+		inflight: make(map[uint64][]*call),
+	}
+	go b.stream.receive()
+	return b.stream, nil
+}
+
+// close closes the current stream, failing the batches in flight.
+func (b *batcher) close() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.stream != nil {
+		b.stream.close(errStreamClosed)
+	}
+}
+
+// batchStream is a SignBatch stream, with the batches sent over it waiting for their response.
+type batchStream struct {
+	stream   validatorpb.RemoteSigner_SignBatchClient
+	cancel   context.CancelFunc
+	sendLock sync.Mutex
+
+	lock     sync.Mutex
+	nextID   uint64
+	inflight map[uint64][]*call
+	err      error
+}
+
+func (s *batchStream) send(calls []*call) {
+	s.lock.Lock()
+	if s.err != nil {
+		s.lock.Unlock()
+		for _, c := range calls {
+			c.finish(nil, s.err)
+		}
+		return
+	}
+	id := s.nextID
+	s.nextID++
+	s.inflight[id] = calls
+	s.lock.Unlock()
+
+	batch := &validatorpb.SignBatchRequest{
+		Id:       id,
+		Requests: make([]*validatorpb.SignRequest, len(calls)),
+	}
+	for i, c := range calls {
+		batch.Requests[i] = c.req
+	}
+	s.sendLock.Lock()
+	err := s.stream.Send(batch)
+	s.sendLock.Unlock()
+	if err != nil {
+		s.close(errors.Wrap(err, "could not send sign batch"))
+	}
+}
+
+// receive dispatches the responses of the batches to their requests until the stream closes.
+func (s *batchStream) receive() {
+	for {
+		resp, err := s.stream.Recv()
+		if err != nil {
+			s.close(errors.Wrap(err, "could not receive sign batch response"))
+			return
+		}
+		s.lock.Lock()
+		calls, ok := s.inflight[resp.Id]
+		delete(s.inflight, resp.Id)
+		s.lock.Unlock()
+		if !ok {
+			log.WithField("batchID", resp.Id).Warn("Received response to an unknown sign batch")
+			continue
+		}
+		if len(resp.Responses) != len(calls) {
+			err := fmt.Errorf("remote signer answered %d requests of a batch of %d", len(resp.Responses), len(calls))
+			for _, c := range calls {
+				c.finish(nil, err)
+			}
+			continue
+		}
+		for i, c := range calls {
+			c.finish(resp.Responses[i], nil)
+		}
+	}
+}
+
+// close fails the batches in flight with the error, and closes the stream.
+func (s *batchStream) close(err error) {
+	s.lock.Lock()
+	if s.err != nil {
+		s.lock.Unlock()
+		return
+	}
+	s.err = err
+	inflight := s.inflight
+	s.inflight = nil
+	s.lock.Unlock()
+
+	s.cancel()
+	for _, calls := range inflight {
+		for _, c := range calls {
+			c.finish(nil, err)
+		}
+	}
+}
+
+func (s *batchStream) isClosed() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.err != nil
+}
@@ -0,0 +1,96 @@
+package remote_grpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+)
+
+const (
+	// ConfigFileName is the name of the configuration file in the directory of a remote-grpc wallet.
+	ConfigFileName = "remote-grpc.json"
+	// DefaultBatchWindow is how long requests are gathered before being sent as a batch by default.
+	DefaultBatchWindow = 5 * time.Millisecond
+	// DefaultMaxBatchSize is the number of requests sending a batch right away by default.
+	DefaultMaxBatchSize = 1024
+)
+
+// FileConfig is the configuration file of a remote-grpc wallet.
+type FileConfig struct {
+	// Address of the signer, as host:port.
+	Address string `json:"address"`
+	// ServerName, if set, is the host name expected in the certificate of the signer instead of the one of the address.
+	ServerName string `json:"server_name,omitempty"`
+	// CACertFile is the CA certificate which issued the certificate of the signer.
+	CACertFile string `json:"ca_cert_file"`
+	// CertFile and KeyFile authenticate the validator client to the signer.
+	CertFile string `json:"cert_file"`
+	KeyFile  string `json:"key_file"`
+	// PublicKeys, if set, are the only keys of the signer to validate with.
+	PublicKeys []string `json:"public_keys,omitempty"`
+	// BatchWindow is how long requests are gathered before being sent as a batch, as a duration such
+	// as "5ms". Requests are sent one by one if it is "0s".
+	BatchWindow string `json:"batch_window,omitempty"`
+	// MaxBatchSize is the number of requests sending a batch right away.
+	MaxBatchSize int `json:"max_batch_size,omitempty"`
+}
+
+// ReadFileConfig reads the configuration file of a remote-grpc wallet.
+func ReadFileConfig(path string) (*FileConfig, error) {
+	enc, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read remote-grpc keymanager configuration")
+	}
+	c := &FileConfig{}
+	if err := json.Unmarshal(enc, c); err != nil {
+		return nil, errors.Wrap(err, "could not decode remote-grpc keymanager configuration")
+	}
+	return c, nil
+}
+
+// SetupConfig returns the setup configuration of the keymanager, loading the TLS files.
+func (c *FileConfig) SetupConfig() (*SetupConfig, error) {
+	if c.Address == "" {
+		return nil, errors.New("no signer address configured")
+	}
+	tlsConfig, err := ClientTLSConfig(c.CACertFile, c.CertFile, c.KeyFile, c.ServerName)
+	if err != nil {
+		return nil, err
+	}
+	publicKeys := make([][fieldparams.BLSPubkeyLength]byte, len(c.PublicKeys))
+	for i, pk := range c.PublicKeys {
+		decoded, err := hexutil.Decode(pk)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode public key %s", pk)
+		}
+		if len(decoded) != fieldparams.BLSPubkeyLength {
+			return nil, fmt.Errorf("public key %s has invalid length (expected %d, got %d)", pk, fieldparams.BLSPubkeyLength, len(decoded))
+		}
+		publicKeys[i] = bytesutil.ToBytes48(decoded)
+	}
+	batchWindow := DefaultBatchWindow
+	if c.BatchWindow != "" {
+		batchWindow, err = time.ParseDuration(c.BatchWindow)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid batch window")
+		}
+	}
+	maxBatchSize := c.MaxBatchSize
+	if maxBatchSize <= 0 {
+		maxBatchSize = DefaultMaxBatchSize
+	}
+	return &SetupConfig{
+		Address:            c.Address,
+		TLSConfig:          tlsConfig,
+		ProvidedPublicKeys: publicKeys,
+		BatchWindow:        batchWindow,
+		MaxBatchSize:       maxBatchSize,
+	}, nil
+}
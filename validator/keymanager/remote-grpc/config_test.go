@@ -0,0 +1,53 @@
+package remote_grpc_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	remotegrpc "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-grpc"
+	signertesting "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-grpc/testing"
+)
+
+func TestFileConfig_SetupConfig(t *testing.T) {
+	dir := t.TempDir()
+	certs, err := signertesting.GenerateCertificates(dir)
+	require.NoError(t, err)
+	path := filepath.Join(dir, remotegrpc.ConfigFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"address": "signer:4000",
+		"server_name": "signer.example",
+		"ca_cert_file": "`+certs.CACertFile+`",
+		"cert_file": "`+certs.ClientCertFile+`",
+		"key_file": "`+certs.ClientKeyFile+`",
+		"public_keys": ["0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"],
+		"batch_window": "20ms"
+	}`), 0600))
+
+	fileConfig, err := remotegrpc.ReadFileConfig(path)
+	require.NoError(t, err)
+	config, err := fileConfig.SetupConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "signer:4000", config.Address)
+	assert.Equal(t, "signer.example", config.TLSConfig.ServerName)
+	assert.Equal(t, 1, len(config.TLSConfig.Certificates))
+	assert.Equal(t, 1, len(config.ProvidedPublicKeys))
+	assert.Equal(t, 20*time.Millisecond, config.BatchWindow)
+	assert.Equal(t, remotegrpc.DefaultMaxBatchSize, config.MaxBatchSize)
+
+	fileConfig.BatchWindow = ""
+	config, err = fileConfig.SetupConfig()
+	require.NoError(t, err)
+	assert.Equal(t, remotegrpc.DefaultBatchWindow, config.BatchWindow)
+
+	fileConfig.KeyFile = ""
+	_, err = fileConfig.SetupConfig()
+	assert.ErrorContains(t, "required for mutual TLS", err)
+
+	fileConfig.Address = ""
+	_, err = fileConfig.SetupConfig()
+	assert.ErrorContains(t, "no signer address", err)
+}
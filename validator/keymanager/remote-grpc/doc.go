@@ -0,0 +1,20 @@
+/*
+Package remote_grpc defines a keymanager signing with a remote signer over the RemoteSigner gRPC
+service of proto/prysm/v1alpha1/validator-client/signer.proto, as a generic alternative to the
+web3signer HTTP API.
+
+The keymanager and the signer authenticate each other with mutual TLS: the keymanager presents a
+client certificate, and only trusts the signers with a certificate issued by its configured CA.
+
+Signing requests made concurrently are gathered for a short window into batches sent over a single
+SignBatch stream, so that the attestations of thousands of validators at the start of a slot only
+cost a few round trips instead of a request each. Blocks are sent on their own with Sign.
+
+Every request carries the beacon chain object it signs along with its signing root and signature
+domain. Signers are expected to compute the signing root again from the object and the domain and
+to deny the requests whose root does not match, as VerifySigningRoot does, so that they only sign
+what they can check. A reference signer holding keys in memory is provided in the testing package.
+
+A remote-grpc wallet keeps its configuration in the remote-grpc directory of the wallet.
+*/
+package remote_grpc
@@ -0,0 +1,209 @@
+package remote_grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	remoteweb3signer "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ErrDenied is returned when the signer refuses to sign a request.
+var ErrDenied = errors.New("remote signer denied the sign request")
+
+// SetupConfig configures the keymanager.
+type SetupConfig struct {
+	// Address of the signer, as host:port.
+	Address string
+	// TLSConfig authenticating the keymanager and the signer to each other.
+	TLSConfig *tls.Config
+	// ProvidedPublicKeys, if set, are the only keys of the signer to validate with.
+	ProvidedPublicKeys [][fieldparams.BLSPubkeyLength]byte
+	// BatchWindow is how long requests are gathered before being sent as a batch.
+	// Requests are sent one by one if it is zero.
+	BatchWindow time.Duration
+	// MaxBatchSize is the number of requests sending a batch right away.
+	MaxBatchSize int
+}
+
+// Keymanager signs with a remote signer implementing the RemoteSigner gRPC service.
+type Keymanager struct {
+	address             string
+	conn                *grpc.ClientConn
+	client              validatorpb.RemoteSignerClient
+	batcher             *batcher
+	publicKeys          [][fieldparams.BLSPubkeyLength]byte
+	accountsChangedFeed *event.Feed
+}
+
+// NewKeymanager connects to the signer and fetches the public keys it signs for.
+func NewKeymanager(ctx context.Context, cfg *SetupConfig) (*Keymanager, error) {
+	ctx, span := trace.StartSpan(ctx, "remote-grpc-keymanager.NewKeymanager")
+	defer span.End()
+	if cfg.Address == "" {
+		return nil, errors.New("no signer address configured")
+	}
+	if cfg.TLSConfig == nil {
+		return nil, errors.New("a mutual TLS configuration is required to connect to the signer")
+	}
+	conn, err := grpc.DialContext(ctx, cfg.Address, grpc.WithTransportCredentials(credentials.NewTLS(cfg.TLSConfig)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not dial signer at %s", cfg.Address)
+	}
+	km := &Keymanager{
+		address:             cfg.Address,
+		conn:                conn,
+		client:              validatorpb.NewRemoteSignerClient(conn),
+		accountsChangedFeed: new(event.Feed),
+	}
+	if cfg.BatchWindow > 0 {
+		maxBatchSize := cfg.MaxBatchSize
+		if maxBatchSize <= 0 {
+			maxBatchSize = DefaultMaxBatchSize
+		}
+		km.batcher = newBatcher(km.client, cfg.BatchWindow, maxBatchSize)
+	}
+
+	resp, err := km.client.ListValidatingPublicKeys(ctx, &emptypb.Empty{})
+	if err != nil {
+		_ = conn.Close()
+		return nil, errors.Wrapf(err, "could not list the public keys of signer at %s", cfg.Address)
+	}
+	signerKeys := make(map[[fieldparams.BLSPubkeyLength]byte]bool, len(resp.ValidatingPublicKeys))
+	for _, pk := range resp.ValidatingPublicKeys {
+		if len(pk) != fieldparams.BLSPubkeyLength {
+			_ = conn.Close()
+			return nil, fmt.Errorf("signer returned a public key of invalid length %d", len(pk))
+		}
+		key := bytesutil.ToBytes48(pk)
+		if !signerKeys[key] {
+			signerKeys[key] = true
+			if len(cfg.ProvidedPublicKeys) == 0 {
+				km.publicKeys = append(km.publicKeys, key)
+			}
+		}
+	}
+	for _, key := range cfg.ProvidedPublicKeys {
+		if !signerKeys[key] {
+			log.WithField("publicKey", fmt.Sprintf("%#x", key)).Warn("Signer does not hold the public key, not validating with it")
+			continue
+		}
+		km.publicKeys = append(km.publicKeys, key)
+	}
+	log.WithField("address", cfg.Address).Infof("Signing for %d validator keys", len(km.publicKeys))
+	return km, nil
+}
+
+// Close closes the connection to the signer.
+func (km *Keymanager) Close() error {
+	if km.batcher != nil {
+		km.batcher.close()
+	}
+	return km.conn.Close()
+}
+
+// FetchValidatingPublicKeys returns the public keys signed for by the signer.
+func (km *Keymanager) FetchValidatingPublicKeys(_ context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	return km.publicKeys, nil
+}
+
+// Sign sends the request to the signer, as part of the next batch unless it is a block.
+func (km *Keymanager) Sign(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	ctx, span := trace.StartSpan(ctx, "remote-grpc-keymanager.Sign")
+	defer span.End()
+
+	var resp *validatorpb.SignResponse
+	var err error
+	// Blocks are signed once per slot at most, and are the largest requests: they would only delay a batch.
+	if km.batcher != nil && !isBlock(req) {
+		resp, err = km.batcher.sign(ctx, req)
+	} else {
+		resp, err = km.client.Sign(ctx, req)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sign with remote signer")
+	}
+	switch resp.Status {
+	case validatorpb.SignResponse_SUCCEEDED:
+		return bls.SignatureFromBytes(resp.Signature)
+	case validatorpb.SignResponse_DENIED:
+		return nil, ErrDenied
+	default:
+		return nil, fmt.Errorf("remote signer failed to sign with status %s", resp.Status)
+	}
+}
+
+func isBlock(req *validatorpb.SignRequest) bool {
+	switch req.Object.(type) {
+	case *validatorpb.SignRequest_Block,
+		*validatorpb.SignRequest_BlockAltair,
+		*validatorpb.SignRequest_BlockBellatrix,
+		*validatorpb.SignRequest_BlindedBlockBellatrix,
+		*validatorpb.SignRequest_BlockCapella,
+		*validatorpb.SignRequest_BlindedBlockCapella,
+		*validatorpb.SignRequest_BlockDeneb,
+		*validatorpb.SignRequest_BlindedBlockDeneb,
+		*validatorpb.SignRequest_BlockElectra,
+		*validatorpb.SignRequest_BlindedBlockElectra:
+		return true
+	default:
+		return false
+	}
+}
+
+// SubscribeAccountChanges creates an event subscription for a channel
+// to listen for public key changes at runtime, such as when new validator accounts
+// are imported into the keymanager while the validator process is running.
+func (km *Keymanager) SubscribeAccountChanges(pubKeysChan chan [][fieldparams.BLSPubkeyLength]byte) event.Subscription {
+	return km.accountsChangedFeed.Subscribe(pubKeysChan)
+}
+
+// ExtractKeystores is not supported, the keys never leave the signer.
+func (*Keymanager) ExtractKeystores(context.Context, []bls.PublicKey, string) ([]*keymanager.Keystore, error) {
+	return nil, errors.New("extracting keys is not supported for a remote-grpc keymanager")
+}
+
+// DeleteKeystores is not supported, the keys are managed by the signer.
+func (*Keymanager) DeleteKeystores(context.Context, [][]byte) ([]*keymanager.KeyStatus, error) {
+	return nil, errors.New("Wrong wallet type: remote-grpc. Only Imported or Derived wallets can delete accounts")
+}
+
+// ListKeymanagerAccounts lists the public keys signed for by the signer.
+func (km *Keymanager) ListKeymanagerAccounts(ctx context.Context, cfg keymanager.ListKeymanagerAccountConfig) error {
+	au := aurora.NewAurora(true)
+	fmt.Printf("(keymanager kind) %s\n", au.BrightGreen("remote-grpc").Bold())
+	fmt.Printf(
+		"(configuration file path) %s\n",
+		au.BrightGreen(filepath.Join(cfg.WalletAccountsDir, ConfigFileName)).Bold(),
+	)
+	fmt.Printf("(signer address) %s\n", km.address)
+	fmt.Println(" ")
+	validatingPubKeys, err := km.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not fetch validating public keys")
+	}
+	if len(validatingPubKeys) == 1 {
+		fmt.Print("Showing 1 validator account\n")
+	} else if len(validatingPubKeys) == 0 {
+		fmt.Print("No accounts found\n")
+		return nil
+	} else {
+		fmt.Printf("Showing %d validator accounts\n", len(validatingPubKeys))
+	}
+	remoteweb3signer.DisplayRemotePublicKeys(validatingPubKeys)
+	return nil
+}
@@ -0,0 +1,191 @@
+package remote_grpc_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	remotegrpc "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-grpc"
+	signertesting "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-grpc/testing"
+)
+
+var genesisValidatorsRoot = bytesutil.PadTo([]byte("genesis validators root"), 32)
+
+func startSigner(t *testing.T, numKeys int) (*signertesting.Server, []bls.SecretKey) {
+	keys := make([]bls.SecretKey, numKeys)
+	for i := range keys {
+		sk, err := bls.RandKey()
+		require.NoError(t, err)
+		keys[i] = sk
+	}
+	server, err := signertesting.StartServer(t.TempDir(), genesisValidatorsRoot, keys)
+	require.NoError(t, err)
+	t.Cleanup(server.Stop)
+	return server, keys
+}
+
+func newKeymanager(t *testing.T, server *signertesting.Server, batchWindow time.Duration) *remotegrpc.Keymanager {
+	tlsConfig, err := server.Certificates.ClientTLSConfig()
+	require.NoError(t, err)
+	km, err := remotegrpc.NewKeymanager(context.Background(), &remotegrpc.SetupConfig{
+		Address:     server.Address,
+		TLSConfig:   tlsConfig,
+		BatchWindow: batchWindow,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, km.Close()) })
+	return km
+}
+
+func attestationRequest(t *testing.T, publicKey []byte, slot primitives.Slot) *validatorpb.SignRequest {
+	data := &ethpb.AttestationData{
+		Slot:            slot,
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		Target:          &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+	}
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainBeaconAttester, params.BeaconConfig().GenesisForkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	root, err := signing.ComputeSigningRoot(data, domain)
+	require.NoError(t, err)
+	return &validatorpb.SignRequest{
+		PublicKey:       publicKey,
+		SigningRoot:     root[:],
+		SignatureDomain: domain,
+		Object:          &validatorpb.SignRequest_AttestationData{AttestationData: data},
+		SigningSlot:     slot,
+	}
+}
+
+func TestKeymanager_FetchValidatingPublicKeys(t *testing.T) {
+	server, keys := startSigner(t, 3)
+	km := newKeymanager(t, server, 0)
+	publicKeys, err := km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, len(publicKeys))
+	for i, sk := range keys {
+		assert.DeepEqual(t, bytesutil.ToBytes48(sk.PublicKey().Marshal()), publicKeys[i])
+	}
+
+	// Only the provided keys held by the signer are validated with.
+	tlsConfig, err := server.Certificates.ClientTLSConfig()
+	require.NoError(t, err)
+	unknown, err := bls.RandKey()
+	require.NoError(t, err)
+	km, err = remotegrpc.NewKeymanager(context.Background(), &remotegrpc.SetupConfig{
+		Address:   server.Address,
+		TLSConfig: tlsConfig,
+		ProvidedPublicKeys: [][fieldparams.BLSPubkeyLength]byte{
+			bytesutil.ToBytes48(keys[1].PublicKey().Marshal()),
+			bytesutil.ToBytes48(unknown.PublicKey().Marshal()),
+		},
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, km.Close()) }()
+	publicKeys, err = km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(publicKeys))
+	assert.DeepEqual(t, bytesutil.ToBytes48(keys[1].PublicKey().Marshal()), publicKeys[0])
+}
+
+func TestKeymanager_Sign(t *testing.T) {
+	server, keys := startSigner(t, 2)
+	km := newKeymanager(t, server, 0)
+
+	req := attestationRequest(t, keys[0].PublicKey().Marshal(), 1)
+	sig, err := km.Sign(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, true, sig.Verify(keys[0].PublicKey(), req.SigningRoot))
+
+	blk := util.NewBeaconBlockDeneb().Block
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainBeaconProposer, params.BeaconConfig().GenesisForkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	root, err := signing.ComputeSigningRoot(blk, domain)
+	require.NoError(t, err)
+	sig, err = km.Sign(context.Background(), &validatorpb.SignRequest{
+		PublicKey:       keys[1].PublicKey().Marshal(),
+		SigningRoot:     root[:],
+		SignatureDomain: domain,
+		Object:          &validatorpb.SignRequest_BlockDeneb{BlockDeneb: blk},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, true, sig.Verify(keys[1].PublicKey(), root[:]))
+	assert.Equal(t, uint64(0), server.Batches.Load())
+
+	// The signer denies a signing root which is not the one of the object.
+	req = attestationRequest(t, keys[0].PublicKey().Marshal(), 2)
+	req.SigningRoot = make([]byte, 32)
+	_, err = km.Sign(context.Background(), req)
+	require.ErrorIs(t, err, remotegrpc.ErrDenied)
+}
+
+func TestKeymanager_SignBatch(t *testing.T) {
+	server, keys := startSigner(t, 4)
+	km := newKeymanager(t, server, 50*time.Millisecond)
+
+	const numRequests = 64
+	reqs := make([]*validatorpb.SignRequest, numRequests)
+	sigs := make([]bls.Signature, numRequests)
+	errs := make([]error, numRequests)
+	var wg sync.WaitGroup
+	for i := range reqs {
+		reqs[i] = attestationRequest(t, keys[i%len(keys)].PublicKey().Marshal(), primitives.Slot(i))
+		if i == numRequests-1 {
+			reqs[i].SigningRoot = make([]byte, 32)
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sigs[i], errs[i] = km.Sign(context.Background(), reqs[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < numRequests-1; i++ {
+		require.NoError(t, errs[i])
+		assert.Equal(t, true, sigs[i].Verify(keys[i%len(keys)].PublicKey(), reqs[i].SigningRoot))
+	}
+	require.ErrorIs(t, errs[numRequests-1], remotegrpc.ErrDenied)
+	assert.Equal(t, uint64(numRequests), server.Requests.Load())
+	assert.Equal(t, true, server.Batches.Load() < numRequests/2, "requests were not batched")
+
+	// Requests fail rather than wait once the signer is gone.
+	server.Stop()
+	_, err := km.Sign(context.Background(), reqs[0])
+	assert.NotNil(t, err)
+}
+
+func TestNewKeymanager_MutualTLS(t *testing.T) {
+	server, _ := startSigner(t, 1)
+
+	// A client without a certificate issued by the CA of the signer is refused.
+	other, err := signertesting.GenerateCertificates(t.TempDir())
+	require.NoError(t, err)
+	tlsConfig, err := remotegrpc.ClientTLSConfig(server.Certificates.CACertFile, other.ClientCertFile, other.ClientKeyFile, "")
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = remotegrpc.NewKeymanager(ctx, &remotegrpc.SetupConfig{Address: server.Address, TLSConfig: tlsConfig})
+	assert.ErrorContains(t, "could not list the public keys", err)
+
+	// A signer without a certificate issued by the CA of the client is not trusted.
+	tlsConfig, err = other.ClientTLSConfig()
+	require.NoError(t, err)
+	_, err = remotegrpc.NewKeymanager(ctx, &remotegrpc.SetupConfig{Address: server.Address, TLSConfig: tlsConfig})
+	assert.ErrorContains(t, "could not list the public keys", err)
+
+	_, err = remotegrpc.NewKeymanager(ctx, &remotegrpc.SetupConfig{Address: server.Address})
+	assert.ErrorContains(t, "mutual TLS configuration is required", err)
+}
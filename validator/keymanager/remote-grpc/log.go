@@ -0,0 +1,5 @@
+package remote_grpc
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "remote-grpc-keymanager")
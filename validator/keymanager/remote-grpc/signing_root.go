@@ -0,0 +1,18 @@
+package remote_grpc
+
+import (
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/signingroot"
+)
+
+// ErrSigningRoot is returned when the signing root of a request does not match its signature
+// domain and beacon chain object.
+var ErrSigningRoot = signingroot.ErrMismatch
+
+// VerifySigningRoot computes the signing root of a request again from its signature domain and
+// beacon chain object, and returns an error if it does not match the request. If a genesis
+// validators root is given, the signature domain must also be the one of the fork of that network
+// active at the epoch of the object.
+func VerifySigningRoot(req *validatorpb.SignRequest, genesisValidatorsRoot []byte) error {
+	return signingroot.Verify(req, genesisValidatorsRoot)
+}
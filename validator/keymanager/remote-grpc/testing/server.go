@@ -0,0 +1,157 @@
+package testing
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	remotegrpc "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Certificates are the files of a CA and of the certificates it issued to a signer and its client.
+type Certificates struct {
+	CACertFile     string
+	ServerCertFile string
+	ServerKeyFile  string
+	ClientCertFile string
+	ClientKeyFile  string
+}
+
+// ClientTLSConfig returns the TLS configuration of a client of the signer.
+func (c *Certificates) ClientTLSConfig() (*tls.Config, error) {
+	return remotegrpc.ClientTLSConfig(c.CACertFile, c.ClientCertFile, c.ClientKeyFile, "")
+}
+
+// Server serves a Signer over mutual TLS on a local port.
+type Server struct {
+	*Signer
+	// Address the signer is served on.
+	Address      string
+	Certificates *Certificates
+	grpcServer   *grpc.Server
+}
+
+// StartServer serves a signer for the keys on a local port, with a CA and certificates for the server
+// and its client generated in the directory.
+func StartServer(dir string, genesisValidatorsRoot []byte, keys []bls.SecretKey) (*Server, error) {
+	certs, err := GenerateCertificates(dir)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := remotegrpc.ServerTLSConfig(certs.CACertFile, certs.ServerCertFile, certs.ServerKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not listen")
+	}
+	s := &Server{
+		Signer:       NewSigner(genesisValidatorsRoot, keys),
+		Address:      listener.Addr().String(),
+		Certificates: certs,
+		grpcServer:   grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig))),
+	}
+	validatorpb.RegisterRemoteSignerServer(s.grpcServer, s.Signer)
+	go func() {
+		_ = s.grpcServer.Serve(listener)
+	}()
+	return s, nil
+}
+
+// Stop stops serving the signer, closing the streams and connections of its clients.
+func (s *Server) Stop() {
+	s.grpcServer.Stop()
+}
+
+// GenerateCertificates generates a CA in the directory, with a certificate for a server on the local
+// host and a client certificate.
+func GenerateCertificates(dir string) (*Certificates, error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	caTemplate := certificateTemplate(1, "remote signer CA")
+	caTemplate.IsCA = true
+	caTemplate.BasicConstraintsValid = true
+	caTemplate.KeyUsage = x509.KeyUsageCertSign
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, err
+	}
+	certs := &Certificates{
+		CACertFile:     filepath.Join(dir, "ca.crt"),
+		ServerCertFile: filepath.Join(dir, "server.crt"),
+		ServerKeyFile:  filepath.Join(dir, "server.key"),
+		ClientCertFile: filepath.Join(dir, "client.crt"),
+		ClientKeyFile:  filepath.Join(dir, "client.key"),
+	}
+	if err := writePEM(certs.CACertFile, "CERTIFICATE", caDER); err != nil {
+		return nil, err
+	}
+
+	serverTemplate := certificateTemplate(2, "remote signer")
+	serverTemplate.DNSNames = []string{"localhost"}
+	serverTemplate.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+	serverTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	if err := issue(serverTemplate, caCert, caKey, certs.ServerCertFile, certs.ServerKeyFile); err != nil {
+		return nil, err
+	}
+	clientTemplate := certificateTemplate(3, "validator client")
+	clientTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	if err := issue(clientTemplate, caCert, caKey, certs.ClientCertFile, certs.ClientKeyFile); err != nil {
+		return nil, err
+	}
+	return certs, nil
+}
+
+func certificateTemplate(serial int64, commonName string) *x509.Certificate {
+	return &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+}
+
+func issue(template, caCert *x509.Certificate, caKey *ecdsa.PrivateKey, certFile, keyFile string) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err := writePEM(certFile, "CERTIFICATE", der); err != nil {
+		return err
+	}
+	return writePEM(keyFile, "EC PRIVATE KEY", keyDER)
+}
+
+func writePEM(path, blockType string, der []byte) error {
+	return os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600)
+}
@@ -0,0 +1,115 @@
+// Package testing provides a reference RemoteSigner holding keys in memory, served in-process over mutual TLS.
+package testing
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	remotegrpc "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Signer is a RemoteSigner signing with keys held in memory. It denies the requests whose signing
+// root does not match their object, but keeps no slashing protection history.
+type Signer struct {
+	genesisValidatorsRoot []byte
+	publicKeys            [][]byte
+	keys                  map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey
+
+	// Batches counts the batches received over SignBatch streams.
+	Batches atomic.Uint64
+	// Requests counts the requests received, alone or in batches.
+	Requests atomic.Uint64
+}
+
+// NewSigner returns a signer for the keys, checking the signature domains against the genesis validators root.
+func NewSigner(genesisValidatorsRoot []byte, keys []bls.SecretKey) *Signer {
+	s := &Signer{
+		genesisValidatorsRoot: genesisValidatorsRoot,
+		keys:                  make(map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey, len(keys)),
+	}
+	for _, sk := range keys {
+		pk := sk.PublicKey().Marshal()
+		s.publicKeys = append(s.publicKeys, pk)
+		s.keys[bytesutil.ToBytes48(pk)] = sk
+	}
+	return s
+}
+
+// ListValidatingPublicKeys returns the public keys of the signer.
+func (s *Signer) ListValidatingPublicKeys(context.Context, *emptypb.Empty) (*validatorpb.ListPublicKeysResponse, error) {
+	return &validatorpb.ListPublicKeysResponse{ValidatingPublicKeys: s.publicKeys}, nil
+}
+
+// Sign signs a single request.
+func (s *Signer) Sign(_ context.Context, req *validatorpb.SignRequest) (*validatorpb.SignResponse, error) {
+	return s.sign(req), nil
+}
+
+// SignBatch signs the requests of each batch in parallel, answering the batches as they complete.
+func (s *Signer) SignBatch(stream validatorpb.RemoteSigner_SignBatchServer) error {
+	var sendLock sync.Mutex
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		batch, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.Batches.Add(1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := &validatorpb.SignBatchResponse{
+				Id:        batch.Id,
+				Responses: s.signAll(batch.Requests),
+			}
+			sendLock.Lock()
+			defer sendLock.Unlock()
+			// The stream is closed if sending fails, which ends the loop on the next Recv.
+			_ = stream.Send(resp)
+		}()
+	}
+}
+
+func (s *Signer) signAll(reqs []*validatorpb.SignRequest) []*validatorpb.SignResponse {
+	resps := make([]*validatorpb.SignResponse, len(reqs))
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.GOMAXPROCS(0) && w < len(reqs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1) - 1); i < len(reqs); i = int(next.Add(1) - 1) {
+				resps[i] = s.sign(reqs[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return resps
+}
+
+func (s *Signer) sign(req *validatorpb.SignRequest) *validatorpb.SignResponse {
+	s.Requests.Add(1)
+	sk, ok := s.keys[bytesutil.ToBytes48(req.PublicKey)]
+	if !ok {
+		return &validatorpb.SignResponse{Status: validatorpb.SignResponse_FAILED}
+	}
+	if err := remotegrpc.VerifySigningRoot(req, s.genesisValidatorsRoot); err != nil {
+		return &validatorpb.SignResponse{Status: validatorpb.SignResponse_DENIED}
+	}
+	return &validatorpb.SignResponse{
+		Signature: sk.Sign(req.SigningRoot).Marshal(),
+		Status:    validatorpb.SignResponse_SUCCEEDED,
+	}
+}
@@ -0,0 +1,61 @@
+package remote_grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// ClientTLSConfig returns the TLS configuration of a client of a signer, authenticating with the
+// certificate and key files, and trusting the signers with a certificate issued by the CA certificate
+// file. The server name, if set, overrides the host name checked in the certificate of the signer.
+func ClientTLSConfig(caCertFile, certFile, keyFile, serverName string) (*tls.Config, error) {
+	pool, cert, err := loadTLSFiles(caCertFile, certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ServerName:   serverName,
+		MinVersion:   tls.VersionTLS13,
+	}, nil
+}
+
+// ServerTLSConfig returns the TLS configuration of a signer, authenticating with the certificate and
+// key files, and only accepting the clients with a certificate issued by the CA certificate file.
+func ServerTLSConfig(caCertFile, certFile, keyFile string) (*tls.Config, error) {
+	pool, cert, err := loadTLSFiles(caCertFile, certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS13,
+	}, nil
+}
+
+func loadTLSFiles(caCertFile, certFile, keyFile string) (*x509.CertPool, tls.Certificate, error) {
+	if caCertFile == "" || certFile == "" || keyFile == "" {
+		return nil, tls.Certificate{}, errors.New("a CA certificate, a certificate and a key are required for mutual TLS")
+	}
+	caCert, err := os.ReadFile(filepath.Clean(caCertFile))
+	if err != nil {
+		return nil, tls.Certificate{}, errors.Wrap(err, "could not read CA certificate")
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, tls.Certificate{}, fmt.Errorf("no certificate found in %s", caCertFile)
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, tls.Certificate{}, errors.Wrap(err, "could not load certificate and key")
+	}
+	return pool, cert, nil
+}
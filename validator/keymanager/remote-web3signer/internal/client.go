@@ -26,6 +26,9 @@ const (
 	ethApiNamespace = "/api/v1/eth2/sign/"
 )
 
+// ErrSlashingProtection is returned when web3signer refuses to sign a message violating its slashing protection rules.
+var ErrSlashingProtection = errors.New("signing operation failed due to slashing protection rules")
+
 type SignRequestJson []byte
 
 // SignatureResponse is the struct representing the signing request response in json format
@@ -71,7 +74,7 @@ func (client *ApiClient) Sign(ctx context.Context, pubKey string, request SignRe
 		return nil, fmt.Errorf("public key not found")
 	}
 	if resp.StatusCode == http.StatusPreconditionFailed {
-		return nil, fmt.Errorf("signing operation failed due to slashing protection rules,  Signing Request URL: %v, Status: %v", client.BaseURL.String()+requestPath, resp.StatusCode)
+		return nil, errors.Wrapf(ErrSlashingProtection, "Signing Request URL: %v, Status: %v", client.BaseURL.String()+requestPath, resp.StatusCode)
 	}
 	contentType := resp.Header.Get("Content-Type")
 	if strings.HasPrefix(contentType, "application/json") {
@@ -161,7 +164,14 @@ func (client *ApiClient) doRequest(ctx context.Context, httpMethod, fullPath str
 		signRequestDurationSeconds.WithLabelValues(req.Method, strconv.Itoa(resp.StatusCode)).Observe(duration.Seconds())
 	}
 	if resp.StatusCode != http.StatusOK {
-		requestDump, err = httputil.DumpRequestOut(req, true)
+		// The request body was consumed by sending the request, so it is read again for the dump.
+		dumpBody := false
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err == nil {
+				dumpBody = true
+			}
+		}
+		requestDump, err = httputil.DumpRequestOut(req, dumpBody)
 		if err != nil {
 			return nil, err
 		}
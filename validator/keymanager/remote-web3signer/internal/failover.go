@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+)
+
+// FailoverClient sends requests to one of several redundant web3signers, moving on to the next one
+// when a web3signer cannot serve a request. The web3signer which last served a request is tried first.
+type FailoverClient struct {
+	clients []*ApiClient
+	lock    sync.RWMutex
+	active  int
+}
+
+// NewFailoverClient instantiates a client for the web3signers at the given base endpoints, in order of preference.
+func NewFailoverClient(baseEndpoints []string) (*FailoverClient, error) {
+	if len(baseEndpoints) == 0 {
+		return nil, errors.New("no web3signer url provided")
+	}
+	clients := make([]*ApiClient, len(baseEndpoints))
+	for i, endpoint := range baseEndpoints {
+		client, err := NewApiClient(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		clients[i] = client
+	}
+	return &FailoverClient{clients: clients}, nil
+}
+
+// Sign requests a signature from the active web3signer, failing over to the others if it does not respond.
+// A refusal to sign because of slashing protection rules is returned as is.
+func (c *FailoverClient) Sign(ctx context.Context, pubKey string, request SignRequestJson) (bls.Signature, error) {
+	var sig bls.Signature
+	err := c.do(ctx, func(client *ApiClient) error {
+		var err error
+		sig, err = client.Sign(ctx, pubKey, request)
+		return err
+	})
+	return sig, err
+}
+
+// GetPublicKeys fetches the public keys at the given url, failing over to the other web3signers' clients on error.
+func (c *FailoverClient) GetPublicKeys(ctx context.Context, url string) ([]string, error) {
+	var keys []string
+	err := c.do(ctx, func(client *ApiClient) error {
+		var err error
+		keys, err = client.GetPublicKeys(ctx, url)
+		return err
+	})
+	return keys, err
+}
+
+// ActiveURL returns the base url of the web3signer requests are sent to first.
+func (c *FailoverClient) ActiveURL() string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.clients[c.active].BaseURL.String()
+}
+
+func (c *FailoverClient) do(ctx context.Context, request func(client *ApiClient) error) error {
+	c.lock.RLock()
+	start := c.active
+	c.lock.RUnlock()
+
+	var err error
+	for i := 0; i < len(c.clients); i++ {
+		index := (start + i) % len(c.clients)
+		client := c.clients[index]
+		err = request(client)
+		if err == nil {
+			if index != start {
+				c.lock.Lock()
+				c.active = index
+				c.lock.Unlock()
+				log.WithField("url", client.BaseURL.String()).Warn("Failed over to another web3signer")
+			}
+			return nil
+		}
+		if errors.Is(err, ErrSlashingProtection) || ctx.Err() != nil {
+			return err
+		}
+		if len(c.clients) > 1 {
+			log.WithError(err).WithField("url", client.BaseURL.String()).Warn("Web3signer request failed, trying the next web3signer")
+		}
+	}
+	return err
+}
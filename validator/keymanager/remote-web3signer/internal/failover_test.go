@@ -0,0 +1,81 @@
+package internal_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPubKey = "0xa2b5aaad9c6efefe7bb9b1243a043404f3362937cfb6b31833929833173f476630ea2cfeb0d9ddf15f97ca8685948820"
+
+func signerServer(status int, calls *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`0xb3baa751d0a9132cfe93e4e3d5ff9075111100e3789dca219ade5a24d27e19d16b3353149da1833e9b691bb38634e8dc04469be7032132906c927d7e1a49b414730612877bc6b2810c8f202daf793d1ab0d6b5cb21d52f9e52e883859887a5d9`))
+	}))
+}
+
+func TestNewFailoverClient(t *testing.T) {
+	_, err := internal.NewFailoverClient(nil)
+	require.ErrorContains(t, "no web3signer url provided", err)
+	_, err = internal.NewFailoverClient([]string{"http://localhost:9000", "localhost"})
+	require.ErrorContains(t, "invalid format", err)
+}
+
+func TestFailoverClient_Sign(t *testing.T) {
+	var downCalls, upCalls atomic.Int32
+	down := signerServer(http.StatusInternalServerError, &downCalls)
+	defer down.Close()
+	up := signerServer(http.StatusOK, &upCalls)
+	defer up.Close()
+
+	c, err := internal.NewFailoverClient([]string{down.URL, up.URL})
+	require.NoError(t, err)
+	assert.Equal(t, down.URL, c.ActiveURL())
+	_, err = c.Sign(context.Background(), testPubKey, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, up.URL, c.ActiveURL())
+
+	// The web3signer which answered is tried first from then on.
+	_, err = c.Sign(context.Background(), testPubKey, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), downCalls.Load())
+	assert.Equal(t, int32(2), upCalls.Load())
+}
+
+func TestFailoverClient_Sign_SlashingProtection(t *testing.T) {
+	var refusingCalls, upCalls atomic.Int32
+	refusing := signerServer(http.StatusPreconditionFailed, &refusingCalls)
+	defer refusing.Close()
+	up := signerServer(http.StatusOK, &upCalls)
+	defer up.Close()
+
+	c, err := internal.NewFailoverClient([]string{refusing.URL, up.URL})
+	require.NoError(t, err)
+	_, err = c.Sign(context.Background(), testPubKey, []byte(`{}`))
+	require.ErrorIs(t, err, internal.ErrSlashingProtection)
+	// A refusal to sign must not be worked around by asking another web3signer.
+	assert.Equal(t, int32(0), upCalls.Load())
+	assert.Equal(t, refusing.URL, c.ActiveURL())
+}
+
+func TestFailoverClient_AllDown(t *testing.T) {
+	var calls atomic.Int32
+	down1 := signerServer(http.StatusInternalServerError, &calls)
+	defer down1.Close()
+	down2 := signerServer(http.StatusInternalServerError, &calls)
+	defer down2.Close()
+
+	c, err := internal.NewFailoverClient([]string{down1.URL, down2.URL})
+	require.NoError(t, err)
+	_, err = c.Sign(context.Background(), testPubKey, []byte(`{}`))
+	require.ErrorContains(t, "internal Web3Signer server error", err)
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -45,6 +45,10 @@ type SetupConfig struct {
 	BaseEndpoint          string
 	GenesisValidatorsRoot []byte
 
+	// BackupEndpoints are redundant web3signers, sharing the keys and slashing protection database
+	// of the one at BaseEndpoint. Requests fail over to them in order when a web3signer does not respond.
+	BackupEndpoints []string
+
 	// Either URL or keylist must be set.
 	// If the URL is set, the keymanager will fetch the public keys from the URL.
 	// caution: this option is susceptible to slashing if the web3signer's validator keys are shared across validators
@@ -76,13 +80,19 @@ func NewKeymanager(ctx context.Context, cfg *SetupConfig) (*Keymanager, error) {
 	if cfg.BaseEndpoint == "" || !bytesutil.IsValidRoot(cfg.GenesisValidatorsRoot) {
 		return nil, fmt.Errorf("invalid setup config, one or more configs are empty: BaseEndpoint: %v, GenesisValidatorsRoot: %#x", cfg.BaseEndpoint, cfg.GenesisValidatorsRoot)
 	}
-	client, err := internal.NewApiClient(cfg.BaseEndpoint)
+	var client internal.HttpSignerClient
+	var err error
+	if len(cfg.BackupEndpoints) == 0 {
+		client, err = internal.NewApiClient(cfg.BaseEndpoint)
+	} else {
+		client, err = internal.NewFailoverClient(append([]string{cfg.BaseEndpoint}, cfg.BackupEndpoints...))
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create apiClient")
 	}
 
 	km := &Keymanager{
-		client:                internal.HttpSignerClient(client),
+		client:                client,
 		genesisValidatorsRoot: cfg.GenesisValidatorsRoot,
 		accountsChangedFeed:   new(event.Feed),
 		validator:             validator.New(),
@@ -108,6 +108,25 @@ func TestNewKeymanager(t *testing.T) {
 			},
 			wantErr: "has invalid length",
 		},
+		{
+			name: "happy path backup endpoints",
+			args: &SetupConfig{
+				BaseEndpoint:          "http://prysm.xyz/",
+				BackupEndpoints:       []string{"http://backup.prysm.xyz/"},
+				GenesisValidatorsRoot: root,
+				ProvidedPublicKeys:    []string{"0xa2b5aaad9c6efefe7bb9b1243a043404f3362937cfb6b31833929833173f476630ea2cfeb0d9ddf15f97ca8685948820"},
+			},
+			want: []string{"0xa2b5aaad9c6efefe7bb9b1243a043404f3362937cfb6b31833929833173f476630ea2cfeb0d9ddf15f97ca8685948820"},
+		},
+		{
+			name: "bad backup endpoint",
+			args: &SetupConfig{
+				BaseEndpoint:          "http://prysm.xyz/",
+				BackupEndpoints:       []string{"prysm.xyz"},
+				GenesisValidatorsRoot: root,
+			},
+			wantErr: "could not create apiClient",
+		},
 		{
 			name: "happy path key file",
 			args: &SetupConfig{
@@ -0,0 +1,177 @@
+// Package signingroot verifies the signing root of the sign requests received by the remote signers
+// against their signature domain and beacon chain object, so that a signer never signs a root it
+// was not shown the message of.
+package signingroot
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+	fssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// ErrMismatch is returned when the signing root of a request does not match its signature
+// domain and beacon chain object.
+var ErrMismatch = errors.New("signing root does not match the request")
+
+// Verify computes the signing root of a request again from its signature domain and
+// beacon chain object, and returns an error if it is not the signing root of the request or if
+// the signature domain is not of the type of the object. If a genesis validators root is given,
+// the signature domain must also be the one of the fork of that network active at the epoch of the object.
+func Verify(req *validatorpb.SignRequest, genesisValidatorsRoot []byte) error {
+	obj, domainType, err := signingObject(req)
+	if err != nil {
+		return err
+	}
+	if len(req.SignatureDomain) != 32 {
+		return fmt.Errorf("invalid signature domain length %d", len(req.SignatureDomain))
+	}
+	if !bytes.Equal(req.SignatureDomain[:4], domainType[:]) {
+		return errors.Wrapf(ErrMismatch, "signature domain type %#x does not match the object, expected %#x", req.SignatureDomain[:4], domainType)
+	}
+	if len(genesisValidatorsRoot) != 0 {
+		if err := verifyDomain(req, obj, domainType, genesisValidatorsRoot); err != nil {
+			return err
+		}
+	}
+	root, err := signing.ComputeSigningRoot(obj, req.SignatureDomain)
+	if err != nil {
+		return errors.Wrap(err, "could not compute signing root")
+	}
+	if !bytes.Equal(root[:], req.SigningRoot) {
+		return ErrMismatch
+	}
+	return nil
+}
+
+// verifyDomain checks that the signature domain is computed for the fork of the network active at
+// the epoch of the object, or for the genesis fork without a genesis validators root for validator
+// registrations. Voluntary exits may also be signed for the Capella fork, as they are from Deneb on.
+func verifyDomain(req *validatorpb.SignRequest, obj fssz.HashRoot, domainType [4]byte, genesisValidatorsRoot []byte) error {
+	if _, ok := req.Object.(*validatorpb.SignRequest_Registration); ok {
+		d, err := signing.ComputeDomain(domainType, nil /* fork version */, nil /* genesis val root */)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(d, req.SignatureDomain) {
+			return errors.Wrap(ErrMismatch, "signature domain is not the builder domain")
+		}
+		return nil
+	}
+	epoch := messageEpoch(req, obj)
+	fork, err := forks.Fork(epoch)
+	if err != nil {
+		return err
+	}
+	versions := [][]byte{fork.CurrentVersion}
+	if _, ok := req.Object.(*validatorpb.SignRequest_Exit); ok {
+		versions = append(versions, params.BeaconConfig().CapellaForkVersion)
+	}
+	for _, version := range versions {
+		d, err := signing.ComputeDomain(domainType, version, genesisValidatorsRoot)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(d, req.SignatureDomain) {
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrMismatch, "signature domain is not the one of the network at epoch %d", epoch)
+}
+
+// messageEpoch returns the epoch the signature domain of a request is computed at, as the
+// validator client does: the target epoch of attestations, the epoch of the slot of the other
+// objects, and the signing slot for sync committee messages which only carry a block root.
+func messageEpoch(req *validatorpb.SignRequest, obj fssz.HashRoot) primitives.Epoch {
+	switch o := req.Object.(type) {
+	case *validatorpb.SignRequest_AttestationData:
+		return o.AttestationData.GetTarget().GetEpoch()
+	case *validatorpb.SignRequest_AggregateAttestationAndProof:
+		return slots.ToEpoch(o.AggregateAttestationAndProof.GetAggregate().GetData().GetSlot())
+	case *validatorpb.SignRequest_AggregateAttestationAndProofElectra:
+		return slots.ToEpoch(o.AggregateAttestationAndProofElectra.GetAggregate().GetData().GetSlot())
+	case *validatorpb.SignRequest_Exit:
+		return o.Exit.GetEpoch()
+	case *validatorpb.SignRequest_Slot:
+		return slots.ToEpoch(o.Slot)
+	case *validatorpb.SignRequest_Epoch:
+		return o.Epoch
+	case *validatorpb.SignRequest_SyncAggregatorSelectionData:
+		return slots.ToEpoch(o.SyncAggregatorSelectionData.GetSlot())
+	case *validatorpb.SignRequest_ContributionAndProof:
+		return slots.ToEpoch(o.ContributionAndProof.GetContribution().GetSlot())
+	case *validatorpb.SignRequest_SyncMessageBlockRoot:
+		return slots.ToEpoch(req.SigningSlot)
+	}
+	// Every kind of block has a slot.
+	if b, ok := obj.(interface{ GetSlot() primitives.Slot }); ok {
+		return slots.ToEpoch(b.GetSlot())
+	}
+	return 0
+}
+
+// signingObject returns the object signed by a request, and the signature domain type it is signed with.
+func signingObject(req *validatorpb.SignRequest) (fssz.HashRoot, [4]byte, error) {
+	cfg := params.BeaconConfig()
+	var obj fssz.HashRoot
+	var domainType [4]byte
+	var ok bool
+	switch o := req.Object.(type) {
+	case *validatorpb.SignRequest_Block:
+		obj, domainType, ok = o.Block, cfg.DomainBeaconProposer, o.Block != nil
+	case *validatorpb.SignRequest_BlockAltair:
+		obj, domainType, ok = o.BlockAltair, cfg.DomainBeaconProposer, o.BlockAltair != nil
+	case *validatorpb.SignRequest_BlockBellatrix:
+		obj, domainType, ok = o.BlockBellatrix, cfg.DomainBeaconProposer, o.BlockBellatrix != nil
+	case *validatorpb.SignRequest_BlindedBlockBellatrix:
+		obj, domainType, ok = o.BlindedBlockBellatrix, cfg.DomainBeaconProposer, o.BlindedBlockBellatrix != nil
+	case *validatorpb.SignRequest_BlockCapella:
+		obj, domainType, ok = o.BlockCapella, cfg.DomainBeaconProposer, o.BlockCapella != nil
+	case *validatorpb.SignRequest_BlindedBlockCapella:
+		obj, domainType, ok = o.BlindedBlockCapella, cfg.DomainBeaconProposer, o.BlindedBlockCapella != nil
+	case *validatorpb.SignRequest_BlockDeneb:
+		obj, domainType, ok = o.BlockDeneb, cfg.DomainBeaconProposer, o.BlockDeneb != nil
+	case *validatorpb.SignRequest_BlindedBlockDeneb:
+		obj, domainType, ok = o.BlindedBlockDeneb, cfg.DomainBeaconProposer, o.BlindedBlockDeneb != nil
+	case *validatorpb.SignRequest_BlockElectra:
+		obj, domainType, ok = o.BlockElectra, cfg.DomainBeaconProposer, o.BlockElectra != nil
+	case *validatorpb.SignRequest_BlindedBlockElectra:
+		obj, domainType, ok = o.BlindedBlockElectra, cfg.DomainBeaconProposer, o.BlindedBlockElectra != nil
+	case *validatorpb.SignRequest_AttestationData:
+		obj, domainType, ok = o.AttestationData, cfg.DomainBeaconAttester, o.AttestationData != nil
+	case *validatorpb.SignRequest_AggregateAttestationAndProof:
+		obj, domainType, ok = o.AggregateAttestationAndProof, cfg.DomainAggregateAndProof, o.AggregateAttestationAndProof != nil
+	case *validatorpb.SignRequest_AggregateAttestationAndProofElectra:
+		obj, domainType, ok = o.AggregateAttestationAndProofElectra, cfg.DomainAggregateAndProof, o.AggregateAttestationAndProofElectra != nil
+	case *validatorpb.SignRequest_Exit:
+		obj, domainType, ok = o.Exit, cfg.DomainVoluntaryExit, o.Exit != nil
+	case *validatorpb.SignRequest_Slot:
+		slot := primitives.SSZUint64(o.Slot)
+		obj, domainType, ok = &slot, cfg.DomainSelectionProof, true
+	case *validatorpb.SignRequest_Epoch:
+		epoch := primitives.SSZUint64(o.Epoch)
+		obj, domainType, ok = &epoch, cfg.DomainRandao, true
+	case *validatorpb.SignRequest_SyncAggregatorSelectionData:
+		obj, domainType, ok = o.SyncAggregatorSelectionData, cfg.DomainSyncCommitteeSelectionProof, o.SyncAggregatorSelectionData != nil
+	case *validatorpb.SignRequest_ContributionAndProof:
+		obj, domainType, ok = o.ContributionAndProof, cfg.DomainContributionAndProof, o.ContributionAndProof != nil
+	case *validatorpb.SignRequest_SyncMessageBlockRoot:
+		root := primitives.SSZBytes(o.SyncMessageBlockRoot)
+		obj, domainType, ok = &root, cfg.DomainSyncCommittee, true
+	case *validatorpb.SignRequest_Registration:
+		obj, domainType, ok = o.Registration, cfg.DomainApplicationBuilder, o.Registration != nil
+	default:
+		return nil, domainType, fmt.Errorf("unsupported sign request object %T", req.Object)
+	}
+	if !ok {
+		return nil, domainType, fmt.Errorf("nil object in sign request %T", req.Object)
+	}
+	return obj, domainType, nil
+}
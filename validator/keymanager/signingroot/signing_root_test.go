@@ -0,0 +1,157 @@
+package signingroot_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/signingroot"
+)
+
+var genesisValidatorsRoot = bytesutil.PadTo([]byte("genesis validators root"), 32)
+
+func attestationRequest(t *testing.T, publicKey []byte) *validatorpb.SignRequest {
+	data := &ethpb.AttestationData{
+		Slot:            1,
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		Target:          &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+	}
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainBeaconAttester, params.BeaconConfig().GenesisForkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	root, err := signing.ComputeSigningRoot(data, domain)
+	require.NoError(t, err)
+	return &validatorpb.SignRequest{
+		PublicKey:       publicKey,
+		SigningRoot:     root[:],
+		SignatureDomain: domain,
+		Object:          &validatorpb.SignRequest_AttestationData{AttestationData: data},
+	}
+}
+
+func TestVerify(t *testing.T) {
+	cfg := params.BeaconConfig()
+	publicKey := make([]byte, 48)
+
+	req := attestationRequest(t, publicKey)
+	require.NoError(t, signingroot.Verify(req, genesisValidatorsRoot))
+	require.NoError(t, signingroot.Verify(req, nil))
+
+	// A domain of another network.
+	require.ErrorIs(t, signingroot.Verify(req, make([]byte, 32)), signingroot.ErrMismatch)
+
+	// A root other than the one of the object.
+	req.SigningRoot = make([]byte, 32)
+	require.ErrorIs(t, signingroot.Verify(req, genesisValidatorsRoot), signingroot.ErrMismatch)
+
+	// A domain of another type than the one of the object, with a matching root.
+	domain, err := signing.ComputeDomain(cfg.DomainSelectionProof, cfg.GenesisForkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	slot := primitives.SSZUint64(5)
+	root, err := signing.ComputeSigningRoot(&slot, domain)
+	require.NoError(t, err)
+	req = &validatorpb.SignRequest{
+		PublicKey:       publicKey,
+		SigningRoot:     root[:],
+		SignatureDomain: domain,
+		Object:          &validatorpb.SignRequest_Epoch{Epoch: 5},
+	}
+	require.ErrorIs(t, signingroot.Verify(req, genesisValidatorsRoot), signingroot.ErrMismatch)
+	req.Object = &validatorpb.SignRequest_Slot{Slot: 5}
+	require.NoError(t, signingroot.Verify(req, genesisValidatorsRoot))
+
+	// Registrations are signed with the builder domain, independent of the network.
+	reg := &ethpb.ValidatorRegistrationV1{FeeRecipient: make([]byte, 20), GasLimit: 30000000, Timestamp: 1, Pubkey: publicKey}
+	domain, err = signing.ComputeDomain(cfg.DomainApplicationBuilder, nil, nil)
+	require.NoError(t, err)
+	root, err = signing.ComputeSigningRoot(reg, domain)
+	require.NoError(t, err)
+	req = &validatorpb.SignRequest{
+		PublicKey:       publicKey,
+		SigningRoot:     root[:],
+		SignatureDomain: domain,
+		Object:          &validatorpb.SignRequest_Registration{Registration: reg},
+	}
+	require.NoError(t, signingroot.Verify(req, genesisValidatorsRoot))
+
+	req.Object = nil
+	assert.ErrorContains(t, "unsupported sign request object", signingroot.Verify(req, genesisValidatorsRoot))
+	req.Object = &validatorpb.SignRequest_Exit{}
+	assert.ErrorContains(t, "nil object", signingroot.Verify(req, genesisValidatorsRoot))
+}
+
+func TestVerify_ForkAtEpoch(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 2
+	cfg.BellatrixForkEpoch = 4
+	cfg.CapellaForkEpoch = 6
+	cfg.DenebForkEpoch = 8
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+	publicKey := make([]byte, 48)
+
+	attestation := func(targetEpoch primitives.Epoch, version []byte) *validatorpb.SignRequest {
+		data := &ethpb.AttestationData{
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: targetEpoch, Root: make([]byte, 32)},
+		}
+		domain, err := signing.ComputeDomain(cfg.DomainBeaconAttester, version, genesisValidatorsRoot)
+		require.NoError(t, err)
+		root, err := signing.ComputeSigningRoot(data, domain)
+		require.NoError(t, err)
+		return &validatorpb.SignRequest{
+			PublicKey:       publicKey,
+			SigningRoot:     root[:],
+			SignatureDomain: domain,
+			Object:          &validatorpb.SignRequest_AttestationData{AttestationData: data},
+		}
+	}
+	require.NoError(t, signingroot.Verify(attestation(1, cfg.GenesisForkVersion), genesisValidatorsRoot))
+	require.NoError(t, signingroot.Verify(attestation(2, cfg.AltairForkVersion), genesisValidatorsRoot))
+	require.NoError(t, signingroot.Verify(attestation(5, cfg.BellatrixForkVersion), genesisValidatorsRoot))
+	// A fork of the network, but not the one active at the target epoch.
+	require.ErrorIs(t, signingroot.Verify(attestation(1, cfg.AltairForkVersion), genesisValidatorsRoot), signingroot.ErrMismatch)
+	require.ErrorIs(t, signingroot.Verify(attestation(2, cfg.GenesisForkVersion), genesisValidatorsRoot), signingroot.ErrMismatch)
+	require.ErrorIs(t, signingroot.Verify(attestation(5, cfg.DenebForkVersion), genesisValidatorsRoot), signingroot.ErrMismatch)
+
+	// Sync committee messages are checked at their signing slot.
+	blockRoot := primitives.SSZBytes(make([]byte, 32))
+	domain, err := signing.ComputeDomain(cfg.DomainSyncCommittee, cfg.AltairForkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	root, err := signing.ComputeSigningRoot(&blockRoot, domain)
+	require.NoError(t, err)
+	req := &validatorpb.SignRequest{
+		PublicKey:       publicKey,
+		SigningRoot:     root[:],
+		SignatureDomain: domain,
+		Object:          &validatorpb.SignRequest_SyncMessageBlockRoot{SyncMessageBlockRoot: blockRoot},
+		SigningSlot:     primitives.Slot(2 * cfg.SlotsPerEpoch),
+	}
+	require.NoError(t, signingroot.Verify(req, genesisValidatorsRoot))
+	req.SigningSlot = primitives.Slot(4 * cfg.SlotsPerEpoch)
+	require.ErrorIs(t, signingroot.Verify(req, genesisValidatorsRoot), signingroot.ErrMismatch)
+
+	// Voluntary exits are signed for the Capella fork from Deneb on.
+	exit := &ethpb.VoluntaryExit{Epoch: 9, ValidatorIndex: 1}
+	for _, version := range [][]byte{cfg.DenebForkVersion, cfg.CapellaForkVersion} {
+		domain, err := signing.ComputeDomain(cfg.DomainVoluntaryExit, version, genesisValidatorsRoot)
+		require.NoError(t, err)
+		root, err := signing.ComputeSigningRoot(exit, domain)
+		require.NoError(t, err)
+		req := &validatorpb.SignRequest{
+			PublicKey:       publicKey,
+			SigningRoot:     root[:],
+			SignatureDomain: domain,
+			Object:          &validatorpb.SignRequest_Exit{Exit: exit},
+		}
+		require.NoError(t, signingroot.Verify(req, genesisValidatorsRoot))
+	}
+}
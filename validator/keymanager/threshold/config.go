@@ -0,0 +1,252 @@
+package threshold
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+const (
+	// ConfigFileName is the name of the configuration file in the directory of a threshold wallet.
+	ConfigFileName = "threshold.json"
+	// AuthTokenEnvVar is the environment variable the auth token is read from when no auth token file is configured.
+	AuthTokenEnvVar = "THRESHOLD_AUTH_TOKEN"
+)
+
+// FileConfig is the configuration file of a threshold wallet.
+type FileConfig struct {
+	// GenesisValidatorsRoot of the network, used to compute the signing root of slashable messages.
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	// ListenAddress is the address to serve the partial signature requests of peers on, if any.
+	ListenAddress string `json:"listen_address,omitempty"`
+	// TLSCertFile and TLSKeyFile are the certificate and key the requests of peers are served with over TLS.
+	TLSCertFile string `json:"tls_cert_file,omitempty"`
+	TLSKeyFile  string `json:"tls_key_file,omitempty"`
+	// TLSClientCAFile, if set, only accepts the requests of peers with a client certificate issued by it.
+	TLSClientCAFile string `json:"tls_client_ca_file,omitempty"`
+	// AuthTokenFile is the file holding the bearer token shared by the group to authenticate its requests.
+	// The token is read from the THRESHOLD_AUTH_TOKEN environment variable when it is not set.
+	AuthTokenFile string `json:"auth_token_file,omitempty"`
+	// Peers are the other members of the group.
+	Peers []*PeerConfig `json:"peers"`
+	// Shares are the shares held by this member of the group.
+	Shares []*ShareConfig `json:"shares"`
+}
+
+// PeerConfig is a member of the group, holding the shares of an index.
+type PeerConfig struct {
+	Index uint64 `json:"index"`
+	// URL of the peer, which must use https.
+	URL string `json:"url"`
+	// CACertFile, if set, is the CA certificate which issued the certificate of the peer, instead of a CA of the system.
+	CACertFile string `json:"ca_cert_file,omitempty"`
+	// CertFile and KeyFile, if set, authenticate the requests to the peer with a client certificate.
+	CertFile string `json:"cert_file,omitempty"`
+	KeyFile  string `json:"key_file,omitempty"`
+}
+
+// ShareConfig is a share stored in the configuration file.
+type ShareConfig struct {
+	PublicKey       string               `json:"public_key"`
+	Threshold       uint64               `json:"threshold"`
+	Index           uint64               `json:"index"`
+	SharePublicKeys []string             `json:"share_public_keys"`
+	Keystore        *keymanager.Keystore `json:"keystore"`
+}
+
+// EncryptShare returns the configuration of a share, with its secret key encrypted with the password.
+func EncryptShare(share *Share, password string) (*ShareConfig, error) {
+	encryptor := keystorev4.New()
+	cryptoFields, err := encryptor.Encrypt(share.SecretKey.Marshal(), password)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encrypt share")
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	publicKeys := make([]string, len(share.PublicKeys))
+	for i, pk := range share.PublicKeys {
+		publicKeys[i] = hexutil.Encode(pk.Marshal())
+	}
+	return &ShareConfig{
+		PublicKey:       hexutil.Encode(share.PublicKey.Marshal()),
+		Threshold:       share.Threshold,
+		Index:           share.Index,
+		SharePublicKeys: publicKeys,
+		Keystore: &keymanager.Keystore{
+			Crypto:      cryptoFields,
+			ID:          id.String(),
+			Pubkey:      fmt.Sprintf("%x", share.SecretKey.PublicKey().Marshal()),
+			Version:     encryptor.Version(),
+			Description: encryptor.Name(),
+		},
+	}, nil
+}
+
+// NewFileConfigs splits the validator keys into shares and returns the configuration file of
+// each member of the group, the member of index i at position i-1. The members are reached at
+// the urls, and the shares of each member are encrypted with its wallet password. The listen
+// address, TLS and auth token settings are left to each member to fill in.
+func NewFileConfigs(secretKeys []bls.SecretKey, threshold uint64, genesisValidatorsRoot []byte, urls, passwords []string) ([]*FileConfig, error) {
+	n := uint64(len(urls))
+	if n == 0 {
+		return nil, errors.New("no members")
+	}
+	if len(passwords) != len(urls) {
+		return nil, fmt.Errorf("got %d passwords for %d members", len(passwords), n)
+	}
+	for _, u := range urls {
+		if !strings.HasPrefix(u, "https://") {
+			return nil, fmt.Errorf("url %s of a member does not use https", u)
+		}
+	}
+	gvr := hexutil.Encode(genesisValidatorsRoot)
+	configs := make([]*FileConfig, n)
+	for i := range configs {
+		peers := make([]*PeerConfig, 0, n-1)
+		for j, u := range urls {
+			if j != i {
+				peers = append(peers, &PeerConfig{Index: uint64(j + 1), URL: u})
+			}
+		}
+		configs[i] = &FileConfig{GenesisValidatorsRoot: gvr, Peers: peers}
+	}
+	for _, sk := range secretKeys {
+		shares, err := NewShares(sk, threshold, n)
+		if err != nil {
+			return nil, err
+		}
+		for i, share := range shares {
+			shareConfig, err := EncryptShare(share, passwords[i])
+			if err != nil {
+				return nil, err
+			}
+			configs[i].Shares = append(configs[i].Shares, shareConfig)
+		}
+	}
+	return configs, nil
+}
+
+// Decrypt returns the share, decrypting its secret key with the password.
+func (c *ShareConfig) Decrypt(password string) (*Share, error) {
+	publicKey, err := publicKeyFromHex(c.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	publicKeys := make([]bls.PublicKey, len(c.SharePublicKeys))
+	for i, pk := range c.SharePublicKeys {
+		publicKeys[i], err = publicKeyFromHex(pk)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if c.Keystore == nil {
+		return nil, errors.New("missing keystore")
+	}
+	secret, err := keystorev4.New().Decrypt(c.Keystore.Crypto, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decrypt keystore")
+	}
+	secretKey, err := bls.SecretKeyFromBytes(secret)
+	if err != nil {
+		return nil, err
+	}
+	return &Share{
+		PublicKey:  publicKey,
+		Threshold:  c.Threshold,
+		Index:      c.Index,
+		SecretKey:  secretKey,
+		PublicKeys: publicKeys,
+	}, nil
+}
+
+// SetupConfig reads the setup configuration of the keymanager from the configuration file,
+// decrypting the shares with the password.
+func (c *FileConfig) SetupConfig(password string) (*SetupConfig, error) {
+	gvr, err := hexutil.Decode(c.GenesisValidatorsRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid genesis validators root")
+	}
+	token, err := c.authToken()
+	if err != nil {
+		return nil, err
+	}
+	if token == "" && (len(c.Peers) > 0 || c.ListenAddress != "") {
+		return nil, fmt.Errorf("no auth token configured, set auth_token_file or %s", AuthTokenEnvVar)
+	}
+	cfg := &SetupConfig{
+		GenesisValidatorsRoot: gvr,
+		ListenAddress:         c.ListenAddress,
+		TLSCertFile:           c.TLSCertFile,
+		TLSKeyFile:            c.TLSKeyFile,
+		TLSClientCAFile:       c.TLSClientCAFile,
+		AuthToken:             token,
+		Peers:                 make(map[uint64]Peer, len(c.Peers)),
+		Shares:                make([]*Share, len(c.Shares)),
+	}
+	for _, p := range c.Peers {
+		if _, ok := cfg.Peers[p.Index]; ok {
+			return nil, fmt.Errorf("duplicate peer index %d", p.Index)
+		}
+		tlsConfig, err := clientTLSConfig(p.CACertFile, p.CertFile, p.KeyFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not load TLS configuration of peer %d", p.Index)
+		}
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+		cfg.Peers[p.Index], err = NewHTTPPeer(p.URL, token, client)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid peer %d", p.Index)
+		}
+	}
+	for i, s := range c.Shares {
+		cfg.Shares[i], err = s.Decrypt(password)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read share of %s", s.PublicKey)
+		}
+	}
+	return cfg, nil
+}
+
+// authToken reads the auth token from the auth token file, or from the environment when no file is set.
+func (c *FileConfig) authToken() (string, error) {
+	if c.AuthTokenFile == "" {
+		return os.Getenv(AuthTokenEnvVar), nil
+	}
+	enc, err := os.ReadFile(filepath.Clean(c.AuthTokenFile))
+	if err != nil {
+		return "", errors.Wrap(err, "could not read auth token file")
+	}
+	return strings.TrimSpace(string(enc)), nil
+}
+
+// ReadFileConfig reads a configuration file.
+func ReadFileConfig(path string) (*FileConfig, error) {
+	enc, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	cfg := &FileConfig{}
+	if err := json.Unmarshal(enc, cfg); err != nil {
+		return nil, errors.Wrapf(err, "could not decode %s", path)
+	}
+	return cfg, nil
+}
+
+func publicKeyFromHex(s string) (bls.PublicKey, error) {
+	enc, err := hexutil.Decode(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid public key %s", s)
+	}
+	return bls.PublicKeyFromBytes(enc)
+}
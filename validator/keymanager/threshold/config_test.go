@@ -0,0 +1,172 @@
+package threshold_test
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/threshold"
+)
+
+func TestFileConfig_SetupConfig(t *testing.T) {
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	shares, err := threshold.NewShares(sk, 2, 3)
+	require.NoError(t, err)
+	share, err := threshold.EncryptShare(shares[1], "password")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0600))
+	enc, err := json.Marshal(&threshold.FileConfig{
+		GenesisValidatorsRoot: hexutil.Encode(genesisValidatorsRoot),
+		AuthTokenFile:         tokenFile,
+		Peers: []*threshold.PeerConfig{
+			{Index: 1, URL: "https://localhost:7600"},
+			{Index: 3, URL: "https://localhost:7602"},
+		},
+		Shares: []*threshold.ShareConfig{share},
+	})
+	require.NoError(t, err)
+	path := filepath.Join(dir, threshold.ConfigFileName)
+	require.NoError(t, os.WriteFile(path, enc, 0600))
+
+	fileConfig, err := threshold.ReadFileConfig(path)
+	require.NoError(t, err)
+	_, err = fileConfig.SetupConfig("wrong")
+	assert.ErrorContains(t, "could not decrypt keystore", err)
+	cfg, err := fileConfig.SetupConfig("password")
+	require.NoError(t, err)
+	assert.DeepEqual(t, genesisValidatorsRoot, cfg.GenesisValidatorsRoot)
+	assert.Equal(t, "secret", cfg.AuthToken)
+	assert.Equal(t, 2, len(cfg.Peers))
+	require.Equal(t, 1, len(cfg.Shares))
+	assert.Equal(t, uint64(2), cfg.Shares[0].Index)
+	assert.Equal(t, uint64(2), cfg.Shares[0].Threshold)
+	assert.DeepEqual(t, shares[1].SecretKey.Marshal(), cfg.Shares[0].SecretKey.Marshal())
+	assert.Equal(t, true, cfg.Shares[0].PublicKey.Equals(sk.PublicKey()))
+	require.Equal(t, 3, len(cfg.Shares[0].PublicKeys))
+	assert.Equal(t, true, cfg.Shares[0].PublicKeys[2].Equals(shares[2].SecretKey.PublicKey()))
+}
+
+func TestNewFileConfigs(t *testing.T) {
+	sk1, err := bls.RandKey()
+	require.NoError(t, err)
+	sk2, err := bls.RandKey()
+	require.NoError(t, err)
+	urls := []string{"https://one:7600", "https://two:7600", "https://three:7600"}
+	passwords := []string{"first", "second", "third"}
+
+	_, err = threshold.NewFileConfigs([]bls.SecretKey{sk1}, 2, genesisValidatorsRoot, urls, passwords[:1])
+	assert.ErrorContains(t, "got 1 passwords for 3 members", err)
+	_, err = threshold.NewFileConfigs([]bls.SecretKey{sk1}, 2, genesisValidatorsRoot, []string{"http://one:7600", "https://two:7600"}, passwords[:2])
+	assert.ErrorContains(t, "does not use https", err)
+
+	configs, err := threshold.NewFileConfigs([]bls.SecretKey{sk1, sk2}, 2, genesisValidatorsRoot, urls, passwords)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(configs))
+	signingRoot := [32]byte{'r', 'o', 'o', 't'}
+	partials := make([][]bls.Signature, 2)
+	for i, cfg := range configs {
+		assert.Equal(t, hexutil.Encode(genesisValidatorsRoot), cfg.GenesisValidatorsRoot)
+		require.Equal(t, 2, len(cfg.Peers))
+		for _, peer := range cfg.Peers {
+			assert.NotEqual(t, uint64(i+1), peer.Index)
+			assert.Equal(t, urls[peer.Index-1], peer.URL)
+		}
+		require.Equal(t, 2, len(cfg.Shares))
+		_, err := cfg.Shares[0].Decrypt(passwords[(i+1)%3])
+		assert.ErrorContains(t, "could not decrypt keystore", err)
+		for k, shareConfig := range cfg.Shares {
+			share, err := shareConfig.Decrypt(passwords[i])
+			require.NoError(t, err)
+			assert.Equal(t, uint64(i+1), share.Index)
+			partials[k] = append(partials[k], share.SecretKey.Sign(signingRoot[:]))
+		}
+	}
+	// Any two members sign on behalf of each validator key.
+	for k, sk := range []bls.SecretKey{sk1, sk2} {
+		sig, err := bls.RecoverSignature([]uint64{1, 3}, []bls.Signature{partials[k][0], partials[k][2]})
+		require.NoError(t, err)
+		assert.Equal(t, true, sig.Verify(sk.PublicKey(), signingRoot[:]))
+	}
+}
+
+func TestFileConfig_SetupConfig_AuthTokenAndTLS(t *testing.T) {
+	gvr := hexutil.Encode(genesisValidatorsRoot)
+
+	t.Run("auth token from the environment", func(t *testing.T) {
+		t.Setenv(threshold.AuthTokenEnvVar, "from env")
+		cfg, err := (&threshold.FileConfig{GenesisValidatorsRoot: gvr, Peers: []*threshold.PeerConfig{{Index: 1, URL: "https://localhost"}}}).SetupConfig("")
+		require.NoError(t, err)
+		assert.Equal(t, "from env", cfg.AuthToken)
+	})
+	t.Run("no auth token", func(t *testing.T) {
+		t.Setenv(threshold.AuthTokenEnvVar, "")
+		_, err := (&threshold.FileConfig{GenesisValidatorsRoot: gvr, Peers: []*threshold.PeerConfig{{Index: 1, URL: "https://localhost"}}}).SetupConfig("")
+		assert.ErrorContains(t, "no auth token configured", err)
+	})
+	t.Run("peer without https", func(t *testing.T) {
+		t.Setenv(threshold.AuthTokenEnvVar, "secret")
+		_, err := (&threshold.FileConfig{GenesisValidatorsRoot: gvr, Peers: []*threshold.PeerConfig{{Index: 1, URL: "http://localhost"}}}).SetupConfig("")
+		assert.ErrorContains(t, "does not use https", err)
+	})
+	t.Run("peer CA certificate", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		t.Setenv(threshold.AuthTokenEnvVar, "secret")
+		sk, err := bls.RandKey()
+		require.NoError(t, err)
+		shares, err := threshold.NewShares(sk, 2, 2)
+		require.NoError(t, err)
+		unused, err := threshold.NewHTTPPeer("https://localhost", "secret", http.DefaultClient)
+		require.NoError(t, err)
+		server, err := threshold.NewKeymanager(ctx, &threshold.SetupConfig{
+			Shares:                []*threshold.Share{shares[1]},
+			Peers:                 map[uint64]threshold.Peer{1: unused},
+			GenesisValidatorsRoot: genesisValidatorsRoot,
+			AuthToken:             "secret",
+		})
+		require.NoError(t, err)
+		srv := httptest.NewTLSServer(server.Handler())
+		defer srv.Close()
+
+		// The certificate of the test server is only trusted with the CA certificate file of the peer.
+		caFile := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0600))
+		share, err := threshold.EncryptShare(shares[0], "password")
+		require.NoError(t, err)
+		fileConfig := &threshold.FileConfig{
+			GenesisValidatorsRoot: gvr,
+			Peers:                 []*threshold.PeerConfig{{Index: 2, URL: srv.URL, CACertFile: caFile}},
+			Shares:                []*threshold.ShareConfig{share},
+		}
+		cfg, err := fileConfig.SetupConfig("password")
+		require.NoError(t, err)
+		km, err := threshold.NewKeymanager(ctx, cfg)
+		require.NoError(t, err)
+		req := attestationRequest(t, sk.PublicKey(), 1, 2, 'a')
+		sig, err := km.Sign(ctx, req)
+		require.NoError(t, err)
+		assert.DeepEqual(t, sk.Sign(req.SigningRoot).Marshal(), sig.Marshal())
+
+		fileConfig.Peers[0].CACertFile = ""
+		cfg, err = fileConfig.SetupConfig("password")
+		require.NoError(t, err)
+		km, err = threshold.NewKeymanager(ctx, cfg)
+		require.NoError(t, err)
+		// The peer cannot be reached, so its partial signature is missing.
+		_, err = km.Sign(ctx, attestationRequest(t, sk.PublicKey(), 2, 3, 'a'))
+		assert.ErrorContains(t, "got 1 of the 2 partial signatures needed", err)
+	})
+}
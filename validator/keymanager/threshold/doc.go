@@ -0,0 +1,32 @@
+/*
+Package threshold defines a keymanager which holds a Shamir share of each validator key
+instead of the key itself. A group of n validator clients each hold the share of the same
+index for all the keys, and any threshold of them can produce a signature, so that neither
+a single machine nor a single signer is a point of failure.
+
+To sign a message, the keymanager signs it with its own share, requests partial signatures
+from its peers, and combines the first valid ones into the signature of the validator key
+once the threshold is reached. Partial signatures are signatures of the signing root with
+the share, verified against the public key of the share before being combined.
+
+Each keymanager checks the blocks and attestations it signs a partial signature for, be it
+for its own validator client or for a peer, against the history of the messages it signed
+since it started: a share never signs two different blocks for the same slot, two different
+attestations for the same target or surrounding attestations, so that the group can only
+produce a slashable signature if a threshold of its members misbehave. The signing root of
+these messages is computed again from the message, so a peer cannot get a share to sign a
+root other than the one checked. This history is kept in memory, the messages signed before
+a restart are only protected by the slashing protection database of each validator client.
+
+The participants do not agree on the message to sign beforehand: if their validator clients
+build different messages for the same duty, only the first one to reach the threshold is
+signed.
+
+A threshold wallet keeps its configuration in the threshold directory of the wallet, with
+the shares stored as EIP-2335 keystores encrypted with the wallet password. The configuration
+of each member is written by `prysmctl validator threshold split` from the keystores of the
+validator keys. Peers are only reached and served over TLS, optionally with client
+certificates, and the auth token of the group is read from a file or from the
+THRESHOLD_AUTH_TOKEN environment variable rather than from the configuration.
+*/
+package threshold
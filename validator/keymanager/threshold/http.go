@@ -0,0 +1,136 @@
+package threshold
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"google.golang.org/protobuf/proto"
+)
+
+// PartialSignaturePath is the path peers request partial signatures on.
+const PartialSignaturePath = "/threshold/v1/partial_signature"
+
+// maxRequestSize bounds the size of a sign request, a full block at most.
+const maxRequestSize = 10 * 1024 * 1024
+
+type partialSignatureResponse struct {
+	Signature string `json:"signature"`
+}
+
+// Handler serves the partial signature requests of peers. Requests are sign requests encoded
+// in protobuf, authenticated with the bearer token of the group.
+func (km *Keymanager) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PartialSignaturePath, km.servePartialSignature)
+	return mux
+}
+
+func (km *Keymanager) servePartialSignature(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httputil.HandleError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || km.authToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(km.authToken)) != 1 {
+		httputil.HandleError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		httputil.HandleError(w, "Could not read request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	req := &validatorpb.SignRequest{}
+	if err := proto.Unmarshal(body, req); err != nil {
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	sig, err := km.SignPartial(r.Context(), req)
+	switch {
+	case errors.Is(err, ErrUnknownKey):
+		httputil.HandleError(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, ErrSlashable), errors.Is(err, ErrSigningRoot):
+		httputil.HandleError(w, err.Error(), http.StatusForbidden)
+		return
+	case err != nil:
+		httputil.HandleError(w, "Could not sign: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	httputil.WriteJson(w, &partialSignatureResponse{Signature: hexutil.Encode(sig.Marshal())})
+}
+
+type httpPeer struct {
+	url       string
+	authToken string
+	client    *http.Client
+}
+
+// NewHTTPPeer returns a peer serving partial signatures at the given base URL. The URL must use https,
+// as the auth token of the group is sent with every request.
+func NewHTTPPeer(baseURL, authToken string, client *http.Client) (Peer, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid peer URL %s", baseURL)
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("peer URL %s does not use https", baseURL)
+	}
+	return &httpPeer{
+		url:       strings.TrimSuffix(baseURL, "/") + PartialSignaturePath,
+		authToken: authToken,
+		client:    client,
+	}, nil
+}
+
+// SignPartial requests a partial signature from the peer.
+func (p *httpPeer) SignPartial(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode sign request")
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", api.OctetStreamMediaType)
+	httpReq.Header.Set("Authorization", "Bearer "+p.authToken)
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close response body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		e := &httputil.DefaultJsonError{}
+		if err := json.NewDecoder(resp.Body).Decode(e); err != nil {
+			return nil, fmt.Errorf("peer responded with status %d", resp.StatusCode)
+		}
+		return nil, e
+	}
+	r := &partialSignatureResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(r); err != nil {
+		return nil, errors.Wrap(err, "could not decode response")
+	}
+	enc, err := hexutil.Decode(r.Signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature")
+	}
+	return bls.SignatureFromBytes(enc)
+}
@@ -0,0 +1,292 @@
+package threshold
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	remoteweb3signer "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultTimeout is the default time given to peers to return their partial signature.
+const DefaultTimeout = 2 * time.Second
+
+// ErrUnknownKey is returned when asked to sign for a key the keymanager holds no share of.
+var ErrUnknownKey = errors.New("no share of the public key")
+
+// Peer is another member of the group, signing partial signatures with its shares.
+type Peer interface {
+	SignPartial(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error)
+}
+
+// SetupConfig includes configuration values for initializing a threshold keymanager.
+type SetupConfig struct {
+	// Shares held by the keymanager, all of the same index.
+	Shares []*Share
+	// Peers are the other members of the group, by index of their shares.
+	Peers map[uint64]Peer
+	// GenesisValidatorsRoot of the network, used to compute the signing root of slashable messages.
+	GenesisValidatorsRoot []byte
+	// Timeout is the time given to peers to return their partial signature.
+	Timeout time.Duration
+	// ListenAddress is the address to serve the partial signature requests of peers on, if any.
+	ListenAddress string
+	// TLSCertFile and TLSKeyFile are the certificate and key the requests of peers are served with over TLS.
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSClientCAFile, if set, only accepts the requests of peers with a client certificate issued by it.
+	TLSClientCAFile string
+	// AuthToken is the bearer token peers authenticate their requests with.
+	AuthToken string
+}
+
+// Keymanager signs messages with the shares of the keys held by a group of keymanagers.
+type Keymanager struct {
+	index               uint64
+	shares              map[[fieldparams.BLSPubkeyLength]byte]*Share
+	publicKeys          [][fieldparams.BLSPubkeyLength]byte
+	peers               map[uint64]Peer
+	timeout             time.Duration
+	authToken           string
+	protector           *protector
+	accountsChangedFeed *event.Feed
+}
+
+// NewKeymanager instantiates a new threshold keymanager from configuration options.
+// The keymanager serves the requests of its peers until the context is canceled.
+func NewKeymanager(ctx context.Context, cfg *SetupConfig) (*Keymanager, error) {
+	if !bytesutil.IsValidRoot(cfg.GenesisValidatorsRoot) {
+		return nil, errors.New("threshold keymanager requires a genesis validators root value")
+	}
+	km := &Keymanager{
+		shares:              make(map[[fieldparams.BLSPubkeyLength]byte]*Share, len(cfg.Shares)),
+		peers:               cfg.Peers,
+		timeout:             cfg.Timeout,
+		authToken:           cfg.AuthToken,
+		protector:           newProtector(cfg.GenesisValidatorsRoot),
+		accountsChangedFeed: new(event.Feed),
+	}
+	if km.timeout == 0 {
+		km.timeout = DefaultTimeout
+	}
+	if _, ok := km.peers[0]; ok {
+		return nil, errors.New("share indices start at 1")
+	}
+	for _, s := range cfg.Shares {
+		if err := s.validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid share of %#x", s.PublicKey.Marshal())
+		}
+		if km.index == 0 {
+			km.index = s.Index
+		} else if s.Index != km.index {
+			return nil, fmt.Errorf("shares of different indices %d and %d", km.index, s.Index)
+		}
+		key := bytesutil.ToBytes48(s.PublicKey.Marshal())
+		if _, ok := km.shares[key]; ok {
+			return nil, fmt.Errorf("duplicate share of %#x", key)
+		}
+		// Peers holding no share of the key do not count toward the threshold.
+		available := uint64(1)
+		for index := range km.peers {
+			if index != km.index && index <= uint64(len(s.PublicKeys)) {
+				available++
+			}
+		}
+		if available < s.Threshold {
+			return nil, fmt.Errorf("%d members of the group known for a threshold of %d for %#x", available, s.Threshold, key)
+		}
+		km.shares[key] = s
+		km.publicKeys = append(km.publicKeys, key)
+	}
+	if _, ok := km.peers[km.index]; ok {
+		return nil, fmt.Errorf("peer with the index %d of the keymanager", km.index)
+	}
+	if cfg.ListenAddress != "" {
+		if err := km.serve(ctx, cfg); err != nil {
+			return nil, err
+		}
+	}
+	return km, nil
+}
+
+// serve the partial signature requests of peers until the context is canceled.
+func (km *Keymanager) serve(ctx context.Context, cfg *SetupConfig) error {
+	if km.authToken == "" {
+		return errors.New("an auth token is required to serve peers")
+	}
+	tlsConfig, err := serverTLSConfig(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", cfg.ListenAddress)
+	if err != nil {
+		return errors.Wrapf(err, "could not listen on %s", cfg.ListenAddress)
+	}
+	srv := &http.Server{
+		Handler:           km.Handler(),
+		ReadHeaderTimeout: time.Second,
+		TLSConfig:         tlsConfig,
+	}
+	go func() {
+		// The certificate and key are part of the TLS configuration of the server.
+		err := srv.ServeTLS(listener, "", "")
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.WithError(err).Error("Could not serve peers")
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		if err := srv.Close(); err != nil {
+			log.WithError(err).Error("Could not stop serving peers")
+		}
+	}()
+	log.WithField("address", listener.Addr()).Info("Serving partial signatures to peers")
+	return nil
+}
+
+// FetchValidatingPublicKeys returns the public keys of the validators the keymanager holds a share of.
+func (km *Keymanager) FetchValidatingPublicKeys(_ context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	return km.publicKeys, nil
+}
+
+// Sign signs the message with the share of the validator key, and combines the partial signature
+// with the ones of peers into a signature of the validator key.
+func (km *Keymanager) Sign(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	share, ok := km.shares[bytesutil.ToBytes48(req.PublicKey)]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	partial, err := km.signPartial(share, req)
+	if err != nil {
+		return nil, err
+	}
+	indices := []uint64{share.Index}
+	partials := []bls.Signature{partial}
+
+	type result struct {
+		index uint64
+		sig   bls.Signature
+		err   error
+	}
+	ctx, cancel := context.WithTimeout(ctx, km.timeout)
+	defer cancel()
+	results := make(chan *result, len(km.peers))
+	requested := 0
+	for index, p := range km.peers {
+		if index > uint64(len(share.PublicKeys)) {
+			continue
+		}
+		requested++
+		go func(index uint64, p Peer) {
+			sig, err := p.SignPartial(ctx, req)
+			results <- &result{index: index, sig: sig, err: err}
+		}(index, p)
+	}
+	for ; requested > 0 && uint64(len(indices)) < share.Threshold; requested-- {
+		r := <-results
+		if r.err != nil {
+			log.WithError(r.err).WithField("peer", r.index).Debug("Could not get partial signature")
+			continue
+		}
+		if !r.sig.Verify(share.PublicKeys[r.index-1], req.SigningRoot) {
+			log.WithField("peer", r.index).Warn("Invalid partial signature")
+			continue
+		}
+		indices = append(indices, r.index)
+		partials = append(partials, r.sig)
+	}
+	if uint64(len(indices)) < share.Threshold {
+		return nil, fmt.Errorf("got %d of the %d partial signatures needed", len(indices), share.Threshold)
+	}
+	sig, err := bls.RecoverSignature(indices, partials)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not combine partial signatures")
+	}
+	if !sig.Verify(share.PublicKey, req.SigningRoot) {
+		return nil, errors.New("combined signature is invalid")
+	}
+	log.WithFields(logrus.Fields{
+		"publicKey": fmt.Sprintf("%#x", req.PublicKey),
+		"shares":    indices,
+	}).Debug("Combined partial signatures")
+	return sig, nil
+}
+
+// SignPartial signs the message with the share of the validator key, unless the message is slashable.
+// It serves the requests of peers.
+func (km *Keymanager) SignPartial(_ context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	share, ok := km.shares[bytesutil.ToBytes48(req.PublicKey)]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return km.signPartial(share, req)
+}
+
+func (km *Keymanager) signPartial(share *Share, req *validatorpb.SignRequest) (bls.Signature, error) {
+	if err := km.protector.check(req); err != nil {
+		return nil, err
+	}
+	return share.SecretKey.Sign(req.SigningRoot), nil
+}
+
+// SubscribeAccountChanges creates an event subscription for a channel
+// to listen for public key changes at runtime, such as when new validator accounts
+// are imported into the keymanager while the validator process is running.
+func (km *Keymanager) SubscribeAccountChanges(pubKeysChan chan [][fieldparams.BLSPubkeyLength]byte) event.Subscription {
+	return km.accountsChangedFeed.Subscribe(pubKeysChan)
+}
+
+// ExtractKeystores is not supported, the keymanager only holds shares of the keys.
+func (*Keymanager) ExtractKeystores(context.Context, []bls.PublicKey, string) ([]*keymanager.Keystore, error) {
+	return nil, errors.New("extracting keys is not supported for a threshold keymanager")
+}
+
+// DeleteKeystores is not supported, shares are removed from the configuration file.
+func (*Keymanager) DeleteKeystores(context.Context, [][]byte) ([]*keymanager.KeyStatus, error) {
+	return nil, errors.New("Wrong wallet type: threshold. Only Imported or Derived wallets can delete accounts")
+}
+
+// ListKeymanagerAccounts lists the validator keys the keymanager holds a share of.
+func (km *Keymanager) ListKeymanagerAccounts(ctx context.Context, cfg keymanager.ListKeymanagerAccountConfig) error {
+	au := aurora.NewAurora(true)
+	fmt.Printf("(keymanager kind) %s\n", au.BrightGreen("threshold").Bold())
+	fmt.Printf(
+		"(configuration file path) %s\n",
+		au.BrightGreen(filepath.Join(cfg.WalletAccountsDir, ConfigFileName)).Bold(),
+	)
+	fmt.Printf("(share index) %d\n", km.index)
+	peers := make([]uint64, 0, len(km.peers))
+	for index := range km.peers {
+		peers = append(peers, index)
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i] < peers[j] })
+	fmt.Printf("(peers) %v\n", peers)
+	fmt.Println(" ")
+	validatingPubKeys, err := km.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not fetch validating public keys")
+	}
+	if len(validatingPubKeys) == 1 {
+		fmt.Print("Showing 1 validator account\n")
+	} else if len(validatingPubKeys) == 0 {
+		fmt.Print("No accounts found\n")
+		return nil
+	} else {
+		fmt.Printf("Showing %d validator accounts\n", len(validatingPubKeys))
+	}
+	remoteweb3signer.DisplayRemotePublicKeys(validatingPubKeys)
+	return nil
+}
@@ -0,0 +1,261 @@
+package threshold_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	fssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/threshold"
+	thresholdtesting "github.com/prysmaticlabs/prysm/v5/validator/keymanager/threshold/testing"
+)
+
+var genesisValidatorsRoot = bytesutil.PadTo([]byte("genesis"), 32)
+
+func signingRoot(t *testing.T, obj fssz.HashRoot, epoch primitives.Epoch, domainType [4]byte) ([]byte, []byte) {
+	fork, err := forks.Fork(epoch)
+	require.NoError(t, err)
+	domain, err := signing.Domain(fork, epoch, domainType, genesisValidatorsRoot)
+	require.NoError(t, err)
+	root, err := signing.ComputeSigningRoot(obj, domain)
+	require.NoError(t, err)
+	return root[:], domain
+}
+
+func attestationRequest(t *testing.T, pub bls.PublicKey, source, target primitives.Epoch, blockRoot byte) *validatorpb.SignRequest {
+	data := &ethpb.AttestationData{
+		Slot:            params.BeaconConfig().SlotsPerEpoch.Mul(uint64(target)),
+		BeaconBlockRoot: bytesutil.PadTo([]byte{blockRoot}, 32),
+		Source:          &ethpb.Checkpoint{Epoch: source, Root: make([]byte, 32)},
+		Target:          &ethpb.Checkpoint{Epoch: target, Root: make([]byte, 32)},
+	}
+	root, domain := signingRoot(t, data, target, params.BeaconConfig().DomainBeaconAttester)
+	return &validatorpb.SignRequest{
+		PublicKey:       pub.Marshal(),
+		SigningRoot:     root,
+		SignatureDomain: domain,
+		Object:          &validatorpb.SignRequest_AttestationData{AttestationData: data},
+	}
+}
+
+func blockRequest(t *testing.T, pub bls.PublicKey, slot primitives.Slot, graffiti byte) *validatorpb.SignRequest {
+	blk := util.NewBeaconBlock().Block
+	blk.Slot = slot
+	blk.Body.Graffiti = bytesutil.PadTo([]byte{graffiti}, 32)
+	root, domain := signingRoot(t, blk, slots.ToEpoch(slot), params.BeaconConfig().DomainBeaconProposer)
+	return &validatorpb.SignRequest{
+		PublicKey:       pub.Marshal(),
+		SigningRoot:     root,
+		SignatureDomain: domain,
+		SigningSlot:     slot,
+		Object:          &validatorpb.SignRequest_Block{Block: blk},
+	}
+}
+
+func randaoRequest(t *testing.T, pub bls.PublicKey, epoch primitives.Epoch) *validatorpb.SignRequest {
+	sszEpoch := primitives.SSZUint64(epoch)
+	root, domain := signingRoot(t, &sszEpoch, epoch, params.BeaconConfig().DomainRandao)
+	return &validatorpb.SignRequest{
+		PublicKey:       pub.Marshal(),
+		SigningRoot:     root,
+		SignatureDomain: domain,
+		Object:          &validatorpb.SignRequest_Epoch{Epoch: epoch},
+	}
+}
+
+func TestKeymanager_Sign(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, err := thresholdtesting.NewCluster(ctx, genesisValidatorsRoot, 2, 3, 4)
+	require.NoError(t, err)
+
+	keys, err := c.Keymanagers[0].FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(keys))
+	assert.DeepEqual(t, bytesutil.ToBytes48(c.SecretKeys[0].PublicKey().Marshal()), keys[0])
+
+	pub := c.SecretKeys[1].PublicKey()
+	req := attestationRequest(t, pub, 1, 2, 'a')
+	sig, err := c.Keymanagers[0].Sign(ctx, req)
+	require.NoError(t, err)
+	// The combined signature is the one of the validator key.
+	assert.DeepEqual(t, c.SecretKeys[1].Sign(req.SigningRoot).Marshal(), sig.Marshal())
+
+	// Any member can sign as long as a threshold of them is online.
+	c.SetOffline(1, true)
+	req = attestationRequest(t, pub, 2, 3, 'a')
+	sig, err = c.Keymanagers[3].Sign(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, true, sig.Verify(pub, req.SigningRoot))
+
+	c.SetOffline(2, true)
+	_, err = c.Keymanagers[3].Sign(ctx, attestationRequest(t, pub, 3, 4, 'a'))
+	assert.ErrorContains(t, "got 2 of the 3 partial signatures needed", err)
+
+	unknown, err := bls.RandKey()
+	require.NoError(t, err)
+	_, err = c.Keymanagers[3].Sign(ctx, attestationRequest(t, unknown.PublicKey(), 1, 2, 'a'))
+	require.ErrorIs(t, err, threshold.ErrUnknownKey)
+}
+
+func TestKeymanager_SlashingProtection(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, err := thresholdtesting.NewCluster(ctx, genesisValidatorsRoot, 1, 2, 3)
+	require.NoError(t, err)
+	pub := c.SecretKeys[0].PublicKey()
+
+	// Members 1 and 2 sign an attestation, member 3 being offline.
+	c.SetOffline(3, true)
+	_, err = c.Keymanagers[0].Sign(ctx, attestationRequest(t, pub, 2, 3, 'a'))
+	require.NoError(t, err)
+	c.SetOffline(3, false)
+
+	// Member 2 refuses to sign another attestation for the same target, for itself or for a peer.
+	_, err = c.Keymanagers[1].Sign(ctx, attestationRequest(t, pub, 2, 3, 'b'))
+	require.ErrorIs(t, err, threshold.ErrSlashable)
+	_, err = c.Keymanagers[1].SignPartial(ctx, attestationRequest(t, pub, 2, 3, 'b'))
+	require.ErrorIs(t, err, threshold.ErrSlashable)
+	// Member 3 has not seen the first attestation, but cannot reach the threshold alone:
+	// member 1 refuses too.
+	_, err = c.Keymanagers[2].Sign(ctx, attestationRequest(t, pub, 2, 3, 'b'))
+	assert.ErrorContains(t, "got 1 of the 2 partial signatures needed", err)
+
+	// Signing the same attestation again is fine.
+	_, err = c.Keymanagers[1].Sign(ctx, attestationRequest(t, pub, 2, 3, 'a'))
+	require.NoError(t, err)
+
+	// Surround votes.
+	_, err = c.Keymanagers[0].Sign(ctx, attestationRequest(t, pub, 1, 4, 'a'))
+	require.ErrorIs(t, err, threshold.ErrSlashable)
+	_, err = c.Keymanagers[0].Sign(ctx, attestationRequest(t, pub, 4, 6, 'a'))
+	require.NoError(t, err)
+	_, err = c.Keymanagers[0].Sign(ctx, attestationRequest(t, pub, 5, 5, 'a'))
+	require.ErrorIs(t, err, threshold.ErrSlashable)
+
+	// Double proposals.
+	_, err = c.Keymanagers[0].Sign(ctx, blockRequest(t, pub, 10, 'a'))
+	require.NoError(t, err)
+	_, err = c.Keymanagers[0].Sign(ctx, blockRequest(t, pub, 10, 'b'))
+	require.ErrorIs(t, err, threshold.ErrSlashable)
+	_, err = c.Keymanagers[0].Sign(ctx, blockRequest(t, pub, 11, 'b'))
+	require.NoError(t, err)
+
+	// A signing root not matching the message is refused.
+	req := attestationRequest(t, pub, 6, 7, 'a')
+	req.SigningRoot = attestationRequest(t, pub, 6, 7, 'b').SigningRoot
+	_, err = c.Keymanagers[0].SignPartial(ctx, req)
+	require.ErrorIs(t, err, threshold.ErrSigningRoot)
+
+	// Messages which are not slashable are signed as they come.
+	req = randaoRequest(t, pub, 7)
+	sig, err := c.Keymanagers[2].Sign(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, true, sig.Verify(pub, req.SigningRoot))
+	// But their signing root must match the message too.
+	req = randaoRequest(t, pub, 7)
+	req.SigningRoot = randaoRequest(t, pub, 8).SigningRoot
+	_, err = c.Keymanagers[2].SignPartial(ctx, req)
+	require.ErrorIs(t, err, threshold.ErrSigningRoot)
+
+	// Requests without a message are refused.
+	req = randaoRequest(t, pub, 7)
+	req.Object = nil
+	_, err = c.Keymanagers[2].SignPartial(ctx, req)
+	assert.ErrorContains(t, "unsupported sign request object", err)
+	req.Object = &validatorpb.SignRequest_Exit{}
+	_, err = c.Keymanagers[2].SignPartial(ctx, req)
+	assert.ErrorContains(t, "nil object in sign request", err)
+}
+
+func TestKeymanager_HTTP(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	shares, err := threshold.NewShares(sk, 2, 2)
+	require.NoError(t, err)
+
+	const token = "secret"
+	// The server only serves its peer in this test.
+	unused, err := threshold.NewHTTPPeer("https://localhost", token, http.DefaultClient)
+	require.NoError(t, err)
+	server, err := threshold.NewKeymanager(ctx, &threshold.SetupConfig{
+		Shares:                []*threshold.Share{shares[1]},
+		Peers:                 map[uint64]threshold.Peer{1: unused},
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		AuthToken:             token,
+	})
+	require.NoError(t, err)
+	srv := httptest.NewTLSServer(server.Handler())
+	defer srv.Close()
+
+	peer, err := threshold.NewHTTPPeer(srv.URL, token, srv.Client())
+	require.NoError(t, err)
+	km, err := threshold.NewKeymanager(ctx, &threshold.SetupConfig{
+		Shares:                []*threshold.Share{shares[0]},
+		Peers:                 map[uint64]threshold.Peer{2: peer},
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	})
+	require.NoError(t, err)
+	req := attestationRequest(t, sk.PublicKey(), 1, 2, 'a')
+	sig, err := km.Sign(ctx, req)
+	require.NoError(t, err)
+	assert.DeepEqual(t, sk.Sign(req.SigningRoot).Marshal(), sig.Marshal())
+
+	_, err = peer.SignPartial(ctx, attestationRequest(t, sk.PublicKey(), 1, 2, 'b'))
+	assert.ErrorContains(t, "403", err)
+	wrongToken, err := threshold.NewHTTPPeer(srv.URL, "wrong", srv.Client())
+	require.NoError(t, err)
+	_, err = wrongToken.SignPartial(ctx, req)
+	assert.ErrorContains(t, "401", err)
+
+	// The auth token is never sent in clear.
+	_, err = threshold.NewHTTPPeer("http://localhost", token, http.DefaultClient)
+	assert.ErrorContains(t, "does not use https", err)
+	// Peers are only served over TLS.
+	_, err = threshold.NewKeymanager(ctx, &threshold.SetupConfig{
+		Shares:                []*threshold.Share{shares[1]},
+		Peers:                 map[uint64]threshold.Peer{1: unused},
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		AuthToken:             token,
+		ListenAddress:         "127.0.0.1:0",
+	})
+	assert.ErrorContains(t, "a TLS certificate and key are required to serve peers", err)
+}
+
+func TestNewKeymanager_Validation(t *testing.T) {
+	ctx := context.Background()
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	shares, err := threshold.NewShares(sk, 2, 3)
+	require.NoError(t, err)
+
+	_, err = threshold.NewKeymanager(ctx, &threshold.SetupConfig{
+		Shares:                []*threshold.Share{shares[0]},
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	})
+	assert.ErrorContains(t, "1 members of the group known for a threshold of 2", err)
+
+	other, err := bls.RandKey()
+	require.NoError(t, err)
+	shares[0].SecretKey = other
+	_, err = threshold.NewKeymanager(ctx, &threshold.SetupConfig{
+		Shares:                []*threshold.Share{shares[0]},
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	})
+	assert.ErrorContains(t, "secret key does not match the public key of share 1", err)
+}
@@ -0,0 +1,5 @@
+package threshold
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "threshold-keymanager")
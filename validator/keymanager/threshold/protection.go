@@ -0,0 +1,188 @@
+package threshold
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	fssz "github.com/prysmaticlabs/fastssz"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/signingroot"
+)
+
+// historyEpochs is how far back the blocks and attestations signed are remembered.
+// Older messages are refused.
+const historyEpochs = primitives.Epoch(4096)
+
+var (
+	// ErrSlashable is returned when signing a message would make the share sign a slashable message.
+	ErrSlashable = errors.New("refusing to sign slashable message")
+	// ErrSigningRoot is returned when the signing root of a message does not match the message.
+	ErrSigningRoot = signingroot.ErrMismatch
+)
+
+type beaconBlock interface {
+	fssz.HashRoot
+	GetSlot() primitives.Slot
+}
+
+type attestationRecord struct {
+	source primitives.Epoch
+	root   [32]byte
+}
+
+// history of the slashable messages signed with a share.
+type history struct {
+	blocks       map[primitives.Slot][32]byte
+	attestations map[primitives.Epoch]*attestationRecord
+	minSlot      primitives.Slot
+	minTarget    primitives.Epoch
+}
+
+// protector refuses to sign slashable messages.
+type protector struct {
+	genesisValidatorsRoot []byte
+	lock                  sync.Mutex
+	histories             map[[fieldparams.BLSPubkeyLength]byte]*history
+}
+
+func newProtector(genesisValidatorsRoot []byte) *protector {
+	return &protector{
+		genesisValidatorsRoot: genesisValidatorsRoot,
+		histories:             make(map[[fieldparams.BLSPubkeyLength]byte]*history),
+	}
+}
+
+// check returns an error if the message must not be signed, and otherwise records it.
+// The signing root of every message is computed again from its object and signature domain,
+// so that a share never signs a root it was not shown the message of.
+func (p *protector) check(req *validatorpb.SignRequest) error {
+	if err := signingroot.Verify(req, p.genesisValidatorsRoot); err != nil {
+		return err
+	}
+	var blk beaconBlock
+	var ok bool
+	switch o := req.Object.(type) {
+	case *validatorpb.SignRequest_AttestationData:
+		return p.checkAttestation(req, o.AttestationData)
+	case *validatorpb.SignRequest_Block:
+		blk, ok = o.Block, o.Block != nil
+	case *validatorpb.SignRequest_BlockAltair:
+		blk, ok = o.BlockAltair, o.BlockAltair != nil
+	case *validatorpb.SignRequest_BlockBellatrix:
+		blk, ok = o.BlockBellatrix, o.BlockBellatrix != nil
+	case *validatorpb.SignRequest_BlindedBlockBellatrix:
+		blk, ok = o.BlindedBlockBellatrix, o.BlindedBlockBellatrix != nil
+	case *validatorpb.SignRequest_BlockCapella:
+		blk, ok = o.BlockCapella, o.BlockCapella != nil
+	case *validatorpb.SignRequest_BlindedBlockCapella:
+		blk, ok = o.BlindedBlockCapella, o.BlindedBlockCapella != nil
+	case *validatorpb.SignRequest_BlockDeneb:
+		blk, ok = o.BlockDeneb, o.BlockDeneb != nil
+	case *validatorpb.SignRequest_BlindedBlockDeneb:
+		blk, ok = o.BlindedBlockDeneb, o.BlindedBlockDeneb != nil
+	case *validatorpb.SignRequest_BlockElectra:
+		blk, ok = o.BlockElectra, o.BlockElectra != nil
+	case *validatorpb.SignRequest_BlindedBlockElectra:
+		blk, ok = o.BlindedBlockElectra, o.BlindedBlockElectra != nil
+	default:
+		// Other messages are not slashable, and their signing root was verified above.
+		return nil
+	}
+	if !ok {
+		return errors.New("nil block")
+	}
+	return p.checkBlock(req, blk)
+}
+
+func (p *protector) checkBlock(req *validatorpb.SignRequest, blk beaconBlock) error {
+	slot := blk.GetSlot()
+	root := bytesutil.ToBytes32(req.SigningRoot)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	h := p.history(req.PublicKey)
+	if slot < h.minSlot {
+		return errors.Wrapf(ErrSlashable, "block at slot %d is older than the signing history", slot)
+	}
+	if prev, ok := h.blocks[slot]; ok {
+		if prev != root {
+			return errors.Wrapf(ErrSlashable, "another block was signed at slot %d", slot)
+		}
+		return nil
+	}
+	h.blocks[slot] = root
+
+	historySlots := primitives.Slot(uint64(historyEpochs) * uint64(params.BeaconConfig().SlotsPerEpoch))
+	if slot > historySlots && slot-historySlots > h.minSlot {
+		h.minSlot = slot - historySlots
+		for s := range h.blocks {
+			if s < h.minSlot {
+				delete(h.blocks, s)
+			}
+		}
+	}
+	return nil
+}
+
+func (p *protector) checkAttestation(req *validatorpb.SignRequest, data *ethpb.AttestationData) error {
+	if data == nil || data.Source == nil || data.Target == nil {
+		return errors.New("nil attestation data")
+	}
+	source, target := data.Source.Epoch, data.Target.Epoch
+	if source > target {
+		return fmt.Errorf("source epoch %d is after target epoch %d", source, target)
+	}
+	root := bytesutil.ToBytes32(req.SigningRoot)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	h := p.history(req.PublicKey)
+	if target < h.minTarget {
+		return errors.Wrapf(ErrSlashable, "attestation for target %d is older than the signing history", target)
+	}
+	if prev, ok := h.attestations[target]; ok {
+		if prev.root != root {
+			return errors.Wrapf(ErrSlashable, "another attestation was signed for target %d", target)
+		}
+		return nil
+	}
+	for t, a := range h.attestations {
+		if a.source < source && t > target {
+			return errors.Wrapf(ErrSlashable, "attestation %d=>%d is surrounded by %d=>%d", source, target, a.source, t)
+		}
+		if a.source > source && t < target {
+			return errors.Wrapf(ErrSlashable, "attestation %d=>%d surrounds %d=>%d", source, target, a.source, t)
+		}
+	}
+	h.attestations[target] = &attestationRecord{source: source, root: root}
+
+	if target > historyEpochs && target-historyEpochs > h.minTarget {
+		h.minTarget = target - historyEpochs
+		for t := range h.attestations {
+			if t < h.minTarget {
+				delete(h.attestations, t)
+			}
+		}
+	}
+	return nil
+}
+
+// history must be called with the lock held.
+func (p *protector) history(publicKey []byte) *history {
+	key := bytesutil.ToBytes48(publicKey)
+	h, ok := p.histories[key]
+	if !ok {
+		h = &history{
+			blocks:       make(map[primitives.Slot][32]byte),
+			attestations: make(map[primitives.Epoch]*attestationRecord),
+		}
+		p.histories[key] = h
+	}
+	return h
+}
@@ -0,0 +1,77 @@
+package threshold
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+)
+
+// Share is the share of a validator key held by a keymanager.
+type Share struct {
+	// PublicKey is the public key of the validator.
+	PublicKey bls.PublicKey
+	// Threshold is the number of partial signatures needed to sign on behalf of the validator.
+	Threshold uint64
+	// Index of the share, from 1 to the number of shares.
+	Index uint64
+	// SecretKey is the secret key of the share.
+	SecretKey bls.SecretKey
+	// PublicKeys are the public keys of all the shares, the one of index i at position i-1.
+	PublicKeys []bls.PublicKey
+}
+
+// NewShares splits a validator key into n shares, any threshold of which can sign on behalf of
+// the validator. The share of index i, at position i-1 of the result, goes to the i-th member
+// of the group.
+func NewShares(secretKey bls.SecretKey, threshold, n uint64) ([]*Share, error) {
+	secretKeys, err := bls.SplitSecretKey(secretKey, threshold, n)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not split secret key")
+	}
+	publicKeys := make([]bls.PublicKey, n)
+	for i, sk := range secretKeys {
+		publicKeys[i] = sk.PublicKey()
+	}
+	shares := make([]*Share, n)
+	for i, sk := range secretKeys {
+		shares[i] = &Share{
+			PublicKey:  secretKey.PublicKey(),
+			Threshold:  threshold,
+			Index:      uint64(i + 1),
+			SecretKey:  sk,
+			PublicKeys: publicKeys,
+		}
+	}
+	return shares, nil
+}
+
+// validate checks that the share belongs to the validator key.
+func (s *Share) validate() error {
+	if s.PublicKey == nil || s.SecretKey == nil {
+		return errors.New("missing key")
+	}
+	n := uint64(len(s.PublicKeys))
+	if s.Threshold == 0 || s.Threshold > n {
+		return fmt.Errorf("invalid threshold %d for %d shares", s.Threshold, n)
+	}
+	if s.Index == 0 || s.Index > n {
+		return fmt.Errorf("invalid index %d for %d shares", s.Index, n)
+	}
+	if !s.SecretKey.PublicKey().Equals(s.PublicKeys[s.Index-1]) {
+		return fmt.Errorf("secret key does not match the public key of share %d", s.Index)
+	}
+	// Any threshold of shares recovers the validator key, the first ones will do.
+	indices := make([]uint64, s.Threshold)
+	for i := range indices {
+		indices[i] = uint64(i + 1)
+	}
+	publicKey, err := bls.RecoverPublicKey(indices, s.PublicKeys[:s.Threshold])
+	if err != nil {
+		return errors.Wrap(err, "could not recover public key")
+	}
+	if !publicKey.Equals(s.PublicKey) {
+		return errors.New("share public keys do not recover the validator public key")
+	}
+	return nil
+}
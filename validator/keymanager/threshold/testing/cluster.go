@@ -0,0 +1,92 @@
+// Package testing provides a group of threshold keymanagers signing with each other in-process.
+package testing
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/threshold"
+)
+
+// ErrOffline is returned by the members of the cluster which were taken offline.
+var ErrOffline = errors.New("peer is offline")
+
+// Cluster is a group of threshold keymanagers holding the shares of the same keys.
+type Cluster struct {
+	// SecretKeys are the validator keys the members hold a share of.
+	SecretKeys []bls.SecretKey
+	// Keymanagers are the members of the group, the one of index i at position i-1.
+	Keymanagers []*threshold.Keymanager
+
+	lock    sync.RWMutex
+	offline map[uint64]bool
+}
+
+// NewCluster creates a group of n keymanagers holding the shares of numKeys random keys,
+// any t of which can sign.
+func NewCluster(ctx context.Context, genesisValidatorsRoot []byte, numKeys int, t, n uint64) (*Cluster, error) {
+	c := &Cluster{
+		SecretKeys:  make([]bls.SecretKey, numKeys),
+		Keymanagers: make([]*threshold.Keymanager, n),
+		offline:     make(map[uint64]bool),
+	}
+	shares := make([][]*threshold.Share, n)
+	for i := range c.SecretKeys {
+		sk, err := bls.RandKey()
+		if err != nil {
+			return nil, err
+		}
+		c.SecretKeys[i] = sk
+		keyShares, err := threshold.NewShares(sk, t, n)
+		if err != nil {
+			return nil, err
+		}
+		for j, s := range keyShares {
+			shares[j] = append(shares[j], s)
+		}
+	}
+	for i := uint64(1); i <= n; i++ {
+		peers := make(map[uint64]threshold.Peer, n-1)
+		for j := uint64(1); j <= n; j++ {
+			if j != i {
+				peers[j] = &peer{cluster: c, index: j}
+			}
+		}
+		km, err := threshold.NewKeymanager(ctx, &threshold.SetupConfig{
+			Shares:                shares[i-1],
+			Peers:                 peers,
+			GenesisValidatorsRoot: genesisValidatorsRoot,
+		})
+		if err != nil {
+			return nil, err
+		}
+		c.Keymanagers[i-1] = km
+	}
+	return c, nil
+}
+
+// SetOffline makes the member of the given index unreachable to the others, or reachable again.
+func (c *Cluster) SetOffline(index uint64, offline bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.offline[index] = offline
+}
+
+type peer struct {
+	cluster *Cluster
+	index   uint64
+}
+
+// SignPartial requests a partial signature from the member of the cluster.
+func (p *peer) SignPartial(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	p.cluster.lock.RLock()
+	offline := p.cluster.offline[p.index]
+	p.cluster.lock.RUnlock()
+	if offline {
+		return nil, ErrOffline
+	}
+	return p.cluster.Keymanagers[p.index-1].SignPartial(ctx, req)
+}
@@ -0,0 +1,70 @@
+package threshold
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// clientTLSConfig returns the TLS configuration of the requests to a peer. The peer is trusted if its
+// certificate is issued by the CA certificate file, or by a CA of the system when no file is set. The
+// certificate and key files, if set, authenticate the requests with a client certificate.
+func clientTLSConfig(caCertFile, certFile, keyFile string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caCertFile != "" {
+		pool, err := loadCertPool(caCertFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not load client certificate and key")
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// serverTLSConfig returns the TLS configuration the requests of peers are served with. When the client
+// CA certificate file is set, only the peers with a client certificate issued by it are accepted.
+func serverTLSConfig(certFile, keyFile, clientCACertFile string) (*tls.Config, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("a TLS certificate and key are required to serve peers")
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load TLS certificate and key")
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientCACertFile != "" {
+		pool, err := loadCertPool(clientCACertFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+func loadCertPool(caCertFile string) (*x509.CertPool, error) {
+	caCert, err := os.ReadFile(filepath.Clean(caCertFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read CA certificate")
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("no certificate found in %s", caCertFile)
+	}
+	return pool, nil
+}
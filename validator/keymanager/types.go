@@ -120,6 +120,12 @@ const (
 	Derived
 	// Web3Signer keymanager capable of signing data using a remote signer called Web3Signer.
 	Web3Signer
+	// Threshold keymanager holding a share of each key, signing with peer validator clients holding the other shares.
+	Threshold
+	// Composite keymanager routing signing requests by public key to a local keystore or to web3signers.
+	Composite
+	// RemoteGRPC keymanager signing with a remote signer over the gRPC RemoteSigner protocol.
+	RemoteGRPC
 )
 
 // IncorrectPasswordErrMsg defines a common error string representing an EIP-2335
@@ -139,6 +145,12 @@ func (k Kind) String() string {
 		return "direct"
 	case Web3Signer:
 		return "web3signer"
+	case Threshold:
+		return "threshold"
+	case Composite:
+		return "composite"
+	case RemoteGRPC:
+		return "remote-grpc"
 	default:
 		return fmt.Sprintf("%d", int(k))
 	}
@@ -153,6 +165,12 @@ func ParseKind(k string) (Kind, error) {
 		return Local, nil
 	case "web3signer":
 		return Web3Signer, nil
+	case "threshold":
+		return Threshold, nil
+	case "composite":
+		return Composite, nil
+	case "remote-grpc":
+		return RemoteGRPC, nil
 	default:
 		return 0, fmt.Errorf("%s is not an allowed keymanager", k)
 	}
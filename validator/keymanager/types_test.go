@@ -8,14 +8,20 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/composite"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/derived"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/local"
+	remotegrpc "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-grpc"
 	remoteweb3signer "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/threshold"
 )
 
 var (
 	_ = keymanager.IKeymanager(&local.Keymanager{})
 	_ = keymanager.IKeymanager(&derived.Keymanager{})
+	_ = keymanager.IKeymanager(&threshold.Keymanager{})
+	_ = keymanager.IKeymanager(&composite.Keymanager{})
+	_ = keymanager.IKeymanager(&remotegrpc.Keymanager{})
 
 	// More granular assertions.
 	_ = keymanager.KeysFetcher(&local.Keymanager{})
@@ -24,9 +30,15 @@ var (
 	_ = keymanager.Importer(&derived.Keymanager{})
 	_ = keymanager.Deleter(&local.Keymanager{})
 	_ = keymanager.Deleter(&derived.Keymanager{})
+	_ = keymanager.Importer(&composite.Keymanager{})
+	_ = composite.Keystore(&local.Keymanager{})
+	_ = composite.Keystore(&derived.Keymanager{})
 
 	_ = keymanager.PublicKeyAdder(&remoteweb3signer.Keymanager{})
 	_ = keymanager.PublicKeyDeleter(&remoteweb3signer.Keymanager{})
+	_ = keymanager.PublicKeyAdder(&composite.Keymanager{})
+	_ = keymanager.PublicKeyDeleter(&composite.Keymanager{})
+	_ = composite.RemoteSigner(&remoteweb3signer.Keymanager{})
 )
 
 func TestKeystoreContainsPath(t *testing.T) {
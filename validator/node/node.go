@@ -522,6 +522,7 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		LogValidatorPerformance: !c.cliCtx.Bool(flags.DisablePenaltyRewardLogFlag.Name),
 		EmitAccountMetrics:      !c.cliCtx.Bool(flags.DisableAccountMetricsFlag.Name),
 		Distributed:             c.cliCtx.Bool(flags.EnableDistributed.Name),
+		ActiveActive:            c.cliCtx.Bool(flags.BeaconNodesActiveActiveFlag.Name),
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize validator service")
@@ -1,7 +1,6 @@
 package rpc
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -25,6 +24,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	"github.com/prysmaticlabs/prysm/v5/validator/client"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/composite"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/derived"
 	slashingprotection "github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history"
 	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
@@ -49,11 +49,20 @@ func (s *Server) ListKeystores(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	if s.wallet.KeymanagerKind() != keymanager.Derived && s.wallet.KeymanagerKind() != keymanager.Local {
+	kind := s.wallet.KeymanagerKind()
+	if kind != keymanager.Derived && kind != keymanager.Local && kind != keymanager.Composite {
 		httputil.HandleError(w, errors.Wrap(err, "Prysm validator keys are not stored locally with this keymanager type").Error(), http.StatusInternalServerError)
 		return
 	}
-	pubKeys, err := km.FetchValidatingPublicKeys(ctx)
+	var pubKeys [][fieldparams.BLSPubkeyLength]byte
+	isDerived := kind == keymanager.Derived
+	if ckm, ok := km.(*composite.Keymanager); ok {
+		// Only the keys of the keystore, the others are listed as remote keys.
+		pubKeys, err = ckm.FetchKeystorePublicKeys(ctx)
+		_, isDerived = ckm.Keystore().(*derived.Keymanager)
+	} else {
+		pubKeys, err = km.FetchValidatingPublicKeys(ctx)
+	}
 	if err != nil {
 		httputil.HandleError(w, errors.Wrap(err, "Could not retrieve keystores").Error(), http.StatusInternalServerError)
 		return
@@ -63,7 +72,7 @@ func (s *Server) ListKeystores(w http.ResponseWriter, r *http.Request) {
 		keystoreResponse[i] = &Keystore{
 			ValidatingPubkey: hexutil.Encode(pubKeys[i][:]),
 		}
-		if s.wallet.KeymanagerKind() == keymanager.Derived {
+		if isDerived {
 			keystoreResponse[i].DerivationPath = fmt.Sprintf(derived.ValidatingKeyDerivationPathTemplate, i)
 		}
 	}
@@ -133,7 +142,7 @@ func (s *Server) ImportKeystores(w http.ResponseWriter, r *http.Request) {
 		keystores[i] = k
 	}
 	if req.SlashingProtection != "" {
-		if s.db == nil || s.db.ImportStandardProtectionJSON(ctx, bytes.NewBufferString(req.SlashingProtection)) != nil {
+		if s.db == nil || s.db.ImportStandardProtectionJSON(ctx, strings.NewReader(req.SlashingProtection)) != nil {
 			statuses := make([]*keymanager.KeyStatus, len(req.Keystores))
 			for i := 0; i < len(req.Keystores); i++ {
 				statuses[i] = &keymanager.KeyStatus{
@@ -401,21 +410,38 @@ func (s *Server) ListRemoteKeys(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	if s.wallet.KeymanagerKind() != keymanager.Web3Signer {
+	if !hasRemoteKeys(s.wallet.KeymanagerKind()) {
 		httputil.HandleError(w, "Prysm Wallet is not of type Web3Signer. Please execute validator client with web3signer flags.", http.StatusInternalServerError)
 		return
 	}
-	pubKeys, err := km.FetchValidatingPublicKeys(ctx)
-	if err != nil {
-		httputil.HandleError(w, errors.Errorf("Could not retrieve public keys: %v", err).Error(), http.StatusInternalServerError)
-		return
-	}
-	keystoreResponse := make([]*RemoteKey, len(pubKeys))
-	for i := 0; i < len(pubKeys); i++ {
-		keystoreResponse[i] = &RemoteKey{
-			Pubkey:   hexutil.Encode(pubKeys[i][:]),
-			Url:      s.validatorService.RemoteSignerConfig().BaseEndpoint,
-			Readonly: true,
+	var keystoreResponse []*RemoteKey
+	if ckm, ok := km.(*composite.Keymanager); ok {
+		remoteKeys, err := ckm.FetchRemoteKeys(ctx)
+		if err != nil {
+			httputil.HandleError(w, errors.Errorf("Could not retrieve public keys: %v", err).Error(), http.StatusInternalServerError)
+			return
+		}
+		keystoreResponse = make([]*RemoteKey, len(remoteKeys))
+		for i, key := range remoteKeys {
+			keystoreResponse[i] = &RemoteKey{
+				Pubkey:   key.PublicKey,
+				Url:      key.URL,
+				Readonly: true,
+			}
+		}
+	} else {
+		pubKeys, err := km.FetchValidatingPublicKeys(ctx)
+		if err != nil {
+			httputil.HandleError(w, errors.Errorf("Could not retrieve public keys: %v", err).Error(), http.StatusInternalServerError)
+			return
+		}
+		keystoreResponse = make([]*RemoteKey, len(pubKeys))
+		for i := 0; i < len(pubKeys); i++ {
+			keystoreResponse[i] = &RemoteKey{
+				Pubkey:   hexutil.Encode(pubKeys[i][:]),
+				Url:      s.validatorService.RemoteSignerConfig().BaseEndpoint,
+				Readonly: true,
+			}
 		}
 	}
 
@@ -443,7 +469,7 @@ func (s *Server) ImportRemoteKeys(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	if s.wallet.KeymanagerKind() != keymanager.Web3Signer {
+	if !hasRemoteKeys(s.wallet.KeymanagerKind()) {
 		httputil.HandleError(w, "Prysm Wallet is not of type Web3Signer. Please execute validator client with web3signer flags.", http.StatusInternalServerError)
 		return
 	}
@@ -472,6 +498,21 @@ func (s *Server) ImportRemoteKeys(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if ckm, ok := km.(*composite.Keymanager); ok {
+		// The composite keymanager adds each key to the web3signer at its url.
+		keys := make([]*composite.RemoteKey, len(req.RemoteKeys))
+		for i, obj := range req.RemoteKeys {
+			keys[i] = &composite.RemoteKey{PublicKey: obj.Pubkey, URL: obj.Url}
+		}
+		ks, err := ckm.AddRemoteKeys(keys)
+		if err != nil {
+			httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteJson(w, &RemoteKeysResponse{Data: ks})
+		return
+	}
+
 	remoteKeys := make([]string, len(req.RemoteKeys))
 	isUrlUsed := false
 	for i, obj := range req.RemoteKeys {
@@ -510,7 +551,7 @@ func (s *Server) DeleteRemoteKeys(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	if s.wallet.KeymanagerKind() != keymanager.Web3Signer {
+	if !hasRemoteKeys(s.wallet.KeymanagerKind()) {
 		httputil.HandleError(w, "Prysm Wallet is not of type Web3Signer. Please execute validator client with web3signer flags.", http.StatusInternalServerError)
 		return
 	}
@@ -546,6 +587,11 @@ func (s *Server) DeleteRemoteKeys(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJson(w, RemoteKeysResponse{Data: data})
 }
 
+// hasRemoteKeys returns whether the keymanager kind signs with remote keys managed through the remote keys API.
+func hasRemoteKeys(kind keymanager.Kind) bool {
+	return kind == keymanager.Web3Signer || kind == keymanager.Composite
+}
+
 // ListFeeRecipientByPubkey returns the public key to eth address mapping object to the end user.
 func (s *Server) ListFeeRecipientByPubkey(w http.ResponseWriter, r *http.Request) {
 	_, span := trace.StartSpan(r.Context(), "validator.keymanagerAPI.ListFeeRecipientByPubkey")
@@ -850,6 +896,136 @@ func (s *Server) DeleteGasLimit(w http.ResponseWriter, r *http.Request) {
 	httputil.HandleError(w, fmt.Sprintf("No gas limit found for pubkey %q", rawPubkey), http.StatusNotFound)
 }
 
+// GetBuilderPolicy returns the builder policy the beacon node applies to blocks proposed by the public key.
+// The policy of the key takes precedence over the default one, unset fields fall back to the beacon node's settings.
+func (s *Server) GetBuilderPolicy(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "validator.keymanagerAPI.GetBuilderPolicy")
+	defer span.End()
+
+	if s.validatorService == nil {
+		httputil.HandleError(w, "Validator service not ready", http.StatusServiceUnavailable)
+		return
+	}
+	rawPubkey, pubkey, ok := shared.HexFromRoute(w, r, "pubkey", fieldparams.BLSPubkeyLength)
+	if !ok {
+		return
+	}
+
+	resp := &GetBuilderPolicyResponse{
+		Data: &BuilderPolicyData{
+			Pubkey: rawPubkey,
+			Policy: &structs.BuilderPolicy{},
+		},
+	}
+	settings := s.validatorService.ProposerSettings()
+	if settings != nil {
+		proposerOption, found := settings.ProposeConfig[bytesutil.ToBytes48(pubkey)]
+		if found {
+			if proposerOption.BuilderConfig != nil && proposerOption.BuilderConfig.Policy != nil {
+				resp.Data.Policy = structs.BuilderPolicyFromConsensus(proposerOption.BuilderConfig.Policy)
+			}
+		} else if settings.DefaultConfig != nil && settings.DefaultConfig.BuilderConfig != nil && settings.DefaultConfig.BuilderConfig.Policy != nil {
+			resp.Data.Policy = structs.BuilderPolicyFromConsensus(settings.DefaultConfig.BuilderConfig.Policy)
+		}
+	}
+	httputil.WriteJson(w, resp)
+}
+
+// SetBuilderPolicy updates the builder policy of the public key.
+func (s *Server) SetBuilderPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.keymanagerAPI.SetBuilderPolicy")
+	defer span.End()
+
+	if s.validatorService == nil {
+		httputil.HandleError(w, "Validator service not ready", http.StatusServiceUnavailable)
+		return
+	}
+	_, pubkey, ok := shared.HexFromRoute(w, r, "pubkey", fieldparams.BLSPubkeyLength)
+	if !ok {
+		return
+	}
+
+	var req structs.BuilderPolicy
+	err := json.NewDecoder(r.Body).Decode(&req)
+	switch {
+	case errors.Is(err, io.EOF):
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	case err != nil:
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	policy, err := req.ToConsensus()
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	settings := s.validatorService.ProposerSettings()
+	if settings == nil {
+		httputil.HandleError(w, "No proposer settings were found to update", http.StatusInternalServerError)
+		return
+	}
+	if settings.ProposeConfig == nil {
+		settings.ProposeConfig = make(map[[fieldparams.BLSPubkeyLength]byte]*proposer.Option)
+	}
+	proposerOption, found := settings.ProposeConfig[bytesutil.ToBytes48(pubkey)]
+	if !found {
+		if settings.DefaultConfig == nil {
+			httputil.HandleError(w, "Builder policy changes only apply when builder is enabled", http.StatusInternalServerError)
+			return
+		}
+		proposerOption = settings.DefaultConfig.Clone()
+	}
+	if proposerOption.BuilderConfig == nil || !proposerOption.BuilderConfig.Enabled {
+		httputil.HandleError(w, "Builder policy changes only apply when builder is enabled", http.StatusInternalServerError)
+		return
+	}
+	proposerOption.BuilderConfig.Policy = policy
+	settings.ProposeConfig[bytesutil.ToBytes48(pubkey)] = proposerOption
+	// save the settings
+	if err := s.validatorService.SetProposerSettings(ctx, settings); err != nil {
+		httputil.HandleError(w, "Could not set proposer settings: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// DeleteBuilderPolicy removes the builder policy of the public key, so that the default policy applies again.
+func (s *Server) DeleteBuilderPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.keymanagerAPI.DeleteBuilderPolicy")
+	defer span.End()
+
+	if s.validatorService == nil {
+		httputil.HandleError(w, "Validator service not ready", http.StatusServiceUnavailable)
+		return
+	}
+	rawPubkey, pubkey, ok := shared.HexFromRoute(w, r, "pubkey", fieldparams.BLSPubkeyLength)
+	if !ok {
+		return
+	}
+
+	settings := s.validatorService.ProposerSettings()
+	if settings != nil && settings.ProposeConfig != nil {
+		proposerOption, found := settings.ProposeConfig[bytesutil.ToBytes48(pubkey)]
+		if found && proposerOption.BuilderConfig != nil && proposerOption.BuilderConfig.Policy != nil {
+			if settings.DefaultConfig != nil && settings.DefaultConfig.BuilderConfig != nil {
+				proposerOption.BuilderConfig.Policy = settings.DefaultConfig.BuilderConfig.Clone().Policy
+			} else {
+				proposerOption.BuilderConfig.Policy = nil
+			}
+			// save the settings
+			if err := s.validatorService.SetProposerSettings(ctx, settings); err != nil {
+				httputil.HandleError(w, "Could not set proposer settings: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	httputil.HandleError(w, fmt.Sprintf("No builder policy found for pubkey %q", rawPubkey), http.StatusNotFound)
+}
+
 func (s *Server) GetGraffiti(w http.ResponseWriter, r *http.Request) {
 	ctx, span := trace.StartSpan(r.Context(), "validator.keymanagerAPI.Graffiti")
 	defer span.End()
@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/cmd/validator/flags"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -39,7 +40,9 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/validator/db/kv"
 	dbtest "github.com/prysmaticlabs/prysm/v5/validator/db/testing"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/composite"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/derived"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/local"
 	remoteweb3signer "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer"
 	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
 	mocks "github.com/prysmaticlabs/prysm/v5/validator/testing"
@@ -120,6 +123,35 @@ func TestServer_ListKeystores(t *testing.T) {
 			)
 		}
 	})
+
+	t.Run("returns the derivation paths of the derived keystore of a composite wallet", func(t *testing.T) {
+		ckm, err := composite.NewKeymanager(ctx, &composite.SetupConfig{Keystore: dr})
+		require.NoError(t, err)
+		vs, err := client.NewValidatorService(ctx, &client.Config{
+			Wallet: w,
+			Validator: &mock.Validator{
+				Km: ckm,
+			},
+		})
+		require.NoError(t, err)
+		s := &Server{
+			walletInitialized: true,
+			wallet:            wallet.New(&wallet.Config{WalletDir: localWalletDir, KeymanagerKind: keymanager.Composite}),
+			validatorService:  vs,
+		}
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/eth/v1/keystores"), nil)
+		wr := httptest.NewRecorder()
+		wr.Body = &bytes.Buffer{}
+		s.ListKeystores(wr, req)
+		require.Equal(t, http.StatusOK, wr.Code)
+		resp := &ListKeystoresResponse{}
+		require.NoError(t, json.Unmarshal(wr.Body.Bytes(), resp))
+		require.Equal(t, numAccounts, len(resp.Data))
+		for i := 0; i < numAccounts; i++ {
+			require.DeepEqual(t, hexutil.Encode(expectedKeys[i][:]), resp.Data[i].ValidatingPubkey)
+			require.Equal(t, fmt.Sprintf(derived.ValidatingKeyDerivationPathTemplate, i), resp.Data[i].DerivationPath)
+		}
+	})
 }
 
 func TestServer_ImportKeystores(t *testing.T) {
@@ -964,6 +996,78 @@ func TestServer_GetGasLimit(t *testing.T) {
 	}
 }
 
+func TestServer_BuilderPolicy(t *testing.T) {
+	ctx := context.Background()
+	pubkey1, err := hexutil.Decode("0xaf2e7ba294e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2c06bd3713cb442072ae591493")
+	require.NoError(t, err)
+	pubkey2, err := hexutil.Decode("0xbedefeaa94e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2cdddddddddddddddddddddddd")
+	require.NoError(t, err)
+	defaultMinBid := uint64(10)
+
+	m := &mock.Validator{}
+	require.NoError(t, m.SetProposerSettings(ctx, &proposer.Settings{
+		DefaultConfig: &proposer.Option{
+			BuilderConfig: &proposer.BuilderConfig{
+				Enabled:  true,
+				GasLimit: 30000000,
+				Policy:   &eth.BuilderPolicy{MinBuilderBid: &defaultMinBid},
+			},
+		},
+	}))
+	vs, err := client.NewValidatorService(ctx, &client.Config{Validator: m})
+	require.NoError(t, err)
+	s := &Server{validatorService: vs}
+
+	getPolicy := func(t *testing.T, pubkey []byte) *structs.BuilderPolicy {
+		req := httptest.NewRequest(http.MethodGet, "/eth/v1/validator/{pubkey}/builder_policy", nil)
+		req = mux.SetURLVars(req, map[string]string{"pubkey": hexutil.Encode(pubkey)})
+		w := httptest.NewRecorder()
+		w.Body = &bytes.Buffer{}
+		s.GetBuilderPolicy(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		resp := &GetBuilderPolicyResponse{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+		return resp.Data.Policy
+	}
+
+	// The default policy applies to keys without their own policy.
+	require.Equal(t, "10", getPolicy(t, pubkey1).MinBuilderBid)
+
+	req := httptest.NewRequest(http.MethodPost, "/eth/v1/validator/{pubkey}/builder_policy", bytes.NewBufferString(`{"min_builder_bid":"100","local_block_value_boost":"5"}`))
+	req = mux.SetURLVars(req, map[string]string{"pubkey": hexutil.Encode(pubkey1)})
+	w := httptest.NewRecorder()
+	w.Body = &bytes.Buffer{}
+	s.SetBuilderPolicy(w, req)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	policy := getPolicy(t, pubkey1)
+	require.Equal(t, "100", policy.MinBuilderBid)
+	require.Equal(t, "5", policy.LocalBlockValueBoost)
+	require.Equal(t, "10", getPolicy(t, pubkey2).MinBuilderBid)
+	require.Equal(t, uint64(30000000), uint64(m.ProposerSettings().ProposeConfig[bytesutil.ToBytes48(pubkey1)].BuilderConfig.GasLimit))
+
+	req = httptest.NewRequest(http.MethodPost, "/eth/v1/validator/{pubkey}/builder_policy", bytes.NewBufferString(`{"min_builder_bid":"foo"}`))
+	req = mux.SetURLVars(req, map[string]string{"pubkey": hexutil.Encode(pubkey1)})
+	w = httptest.NewRecorder()
+	w.Body = &bytes.Buffer{}
+	s.SetBuilderPolicy(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest(http.MethodDelete, "/eth/v1/validator/{pubkey}/builder_policy", nil)
+	req = mux.SetURLVars(req, map[string]string{"pubkey": hexutil.Encode(pubkey1)})
+	w = httptest.NewRecorder()
+	w.Body = &bytes.Buffer{}
+	s.DeleteBuilderPolicy(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Equal(t, "10", getPolicy(t, pubkey1).MinBuilderBid)
+
+	req = httptest.NewRequest(http.MethodDelete, "/eth/v1/validator/{pubkey}/builder_policy", nil)
+	req = mux.SetURLVars(req, map[string]string{"pubkey": hexutil.Encode(pubkey2)})
+	w = httptest.NewRecorder()
+	w.Body = &bytes.Buffer{}
+	s.DeleteBuilderPolicy(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
 func TestServer_SetGasLimit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1491,6 +1595,101 @@ func TestServer_DeleteRemoteKeys(t *testing.T) {
 	})
 }
 
+func TestServer_CompositeKeymanager(t *testing.T) {
+	ctx := context.Background()
+	local.ResetCaches()
+	opts := []accounts.Option{
+		accounts.WithWalletDir(setupWalletDir(t)),
+		accounts.WithKeymanagerType(keymanager.Composite),
+		accounts.WithWalletPassword(strongPass),
+	}
+	acc, err := accounts.NewCLIManager(opts...)
+	require.NoError(t, err)
+	w, err := acc.WalletCreate(ctx)
+	require.NoError(t, err)
+	remotePubkey := "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a"
+	config, err := json.Marshal(&composite.FileConfig{
+		GenesisValidatorsRoot: hexutil.Encode(bytesutil.PadTo([]byte{1}, fieldparams.RootLength)),
+		Web3Signers: []*composite.Web3SignerConfig{
+			{URLs: []string{"http://signer-1.example.com"}, PublicKeys: []string{remotePubkey}},
+			{URLs: []string{"http://signer-2a.example.com", "http://signer-2b.example.com"}},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(w.AccountsDir(), composite.ConfigFileName), config, 0600))
+	km, err := w.InitializeKeymanager(ctx, iface.InitKeymanagerConfig{ListenForChanges: false})
+	require.NoError(t, err)
+	vs, err := client.NewValidatorService(ctx, &client.Config{
+		Wallet: w,
+		Validator: &mock.Validator{
+			Km: km,
+		},
+	})
+	require.NoError(t, err)
+	s := &Server{
+		walletInitialized: true,
+		wallet:            w,
+		validatorService:  vs,
+	}
+
+	// Keystores are imported into the wallet while remote keys are added to web3signers.
+	keystore := createRandomKeystore(t, "12345678")
+	enc, err := json.Marshal(keystore)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, json.NewEncoder(&buf).Encode(&ImportKeystoresRequest{Keystores: []string{string(enc)}, Passwords: []string{"12345678"}}))
+	wr := httptest.NewRecorder()
+	wr.Body = &bytes.Buffer{}
+	s.ImportKeystores(wr, httptest.NewRequest(http.MethodPost, "/eth/v1/keystores", &buf))
+	require.Equal(t, http.StatusOK, wr.Code)
+	importResp := &ImportKeystoresResponse{}
+	require.NoError(t, json.Unmarshal(wr.Body.Bytes(), importResp))
+	require.Equal(t, 1, len(importResp.Data))
+	require.Equal(t, keymanager.StatusImported, importResp.Data[0].Status)
+
+	addedPubkey := "0xa2b5aaad9c6efefe7bb9b1243a043404f3362937cfb6b31833929833173f476630ea2cfeb0d9ddf15f97ca8685948820"
+	buf.Reset()
+	require.NoError(t, json.NewEncoder(&buf).Encode(&ImportRemoteKeysRequest{RemoteKeys: []*RemoteKey{
+		{Pubkey: addedPubkey, Url: "http://signer-2b.example.com"},
+		{Pubkey: "0x" + keystore.Pubkey},
+	}}))
+	wr = httptest.NewRecorder()
+	wr.Body = &bytes.Buffer{}
+	s.ImportRemoteKeys(wr, httptest.NewRequest(http.MethodPost, "/eth/v1/remotekeys", &buf))
+	require.Equal(t, http.StatusOK, wr.Code)
+	remoteResp := &RemoteKeysResponse{}
+	require.NoError(t, json.Unmarshal(wr.Body.Bytes(), remoteResp))
+	require.Equal(t, 2, len(remoteResp.Data))
+	assert.Equal(t, keymanager.StatusImported, remoteResp.Data[0].Status)
+	// A key of the wallet cannot be signed for by a web3signer as well.
+	assert.Equal(t, keymanager.StatusDuplicate, remoteResp.Data[1].Status)
+
+	wr = httptest.NewRecorder()
+	wr.Body = &bytes.Buffer{}
+	s.ListKeystores(wr, httptest.NewRequest(http.MethodGet, "/eth/v1/keystores", nil))
+	require.Equal(t, http.StatusOK, wr.Code)
+	listResp := &ListKeystoresResponse{}
+	require.NoError(t, json.Unmarshal(wr.Body.Bytes(), listResp))
+	require.Equal(t, 1, len(listResp.Data))
+	assert.Equal(t, "0x"+keystore.Pubkey, listResp.Data[0].ValidatingPubkey)
+
+	wr = httptest.NewRecorder()
+	wr.Body = &bytes.Buffer{}
+	s.ListRemoteKeys(wr, httptest.NewRequest(http.MethodGet, "/eth/v1/remotekeys", nil))
+	require.Equal(t, http.StatusOK, wr.Code)
+	listRemoteResp := &ListRemoteKeysResponse{}
+	require.NoError(t, json.Unmarshal(wr.Body.Bytes(), listRemoteResp))
+	require.Equal(t, 2, len(listRemoteResp.Data))
+	assert.Equal(t, remotePubkey, listRemoteResp.Data[0].Pubkey)
+	assert.Equal(t, "http://signer-1.example.com", listRemoteResp.Data[0].Url)
+	assert.Equal(t, addedPubkey, listRemoteResp.Data[1].Pubkey)
+	assert.Equal(t, "http://signer-2a.example.com", listRemoteResp.Data[1].Url)
+
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(keys))
+}
+
 func TestServer_ListFeeRecipientByPubkey(t *testing.T) {
 	ctx := context.Background()
 	pubkey := "0xaf2e7ba294e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2c06bd3713cb442072ae591493"
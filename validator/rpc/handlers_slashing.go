@@ -1,10 +1,10 @@
 package rpc
 
 import (
-	"bytes"
 	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
@@ -74,9 +74,7 @@ func (s *Server) ImportSlashingProtection(w http.ResponseWriter, r *http.Request
 		httputil.HandleError(w, "empty slashing_protection_json specified", http.StatusBadRequest)
 		return
 	}
-	enc := []byte(req.SlashingProtectionJson)
-	buf := bytes.NewBuffer(enc)
-	if err := s.db.ImportStandardProtectionJSON(ctx, buf); err != nil {
+	if err := s.db.ImportStandardProtectionJSON(ctx, strings.NewReader(req.SlashingProtectionJson)); err != nil {
 		httputil.HandleError(w, errors.Wrap(err, "could not import slashing protection history").Error(), http.StatusInternalServerError)
 		return
 	}
@@ -62,6 +62,16 @@ type SetGasLimitRequest struct {
 	GasLimit string `json:"gas_limit"`
 }
 
+// builder policy keymanager api
+type BuilderPolicyData struct {
+	Pubkey string                 `json:"pubkey"`
+	Policy *structs.BuilderPolicy `json:"policy"`
+}
+
+type GetBuilderPolicyResponse struct {
+	Data *BuilderPolicyData `json:"data"`
+}
+
 // remote keymanager api
 type ListRemoteKeysResponse struct {
 	Data []*RemoteKey `json:"data"`
@@ -3,6 +3,7 @@ package history
 import (
 	"context"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 
@@ -23,18 +24,9 @@ func ExportStandardProtectionJSON(
 	filteredKeys ...[]byte,
 ) (*format.EIPSlashingProtectionFormat, error) {
 	interchangeJSON := &format.EIPSlashingProtectionFormat{}
-	genesisValidatorsRoot, err := validatorDB.GenesisValidatorsRoot(ctx)
+	genesisRootHex, err := genesisValidatorsRootHex(ctx, validatorDB)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not get genesis validators root from DB")
-	}
-	if genesisValidatorsRoot == nil || !bytesutil.IsValidRoot(genesisValidatorsRoot) {
-		return nil, errors.New(
-			"genesis validators root is empty, perhaps you are not connected to your beacon node",
-		)
-	}
-	genesisRootHex, err := helpers.RootToHexString(genesisValidatorsRoot)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not convert genesis validators root to hex string")
+		return nil, err
 	}
 	interchangeJSON.Metadata.GenesisValidatorsRoot = genesisRootHex
 	interchangeJSON.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
@@ -127,6 +119,106 @@ func ExportStandardProtectionJSON(
 	return interchangeJSON, nil
 }
 
+// ExportStandardProtectionJSONToWriter writes all slashing protection data from a validator database
+// to w as an EIP-3076 compliant JSON document, one validator at a time so that the whole document is
+// never held in memory. It returns the number of validators written.
+func ExportStandardProtectionJSONToWriter(
+	ctx context.Context,
+	validatorDB db.Database,
+	w io.Writer,
+	filteredKeys ...[]byte,
+) (int, error) {
+	genesisRootHex, err := genesisValidatorsRootHex(ctx, validatorDB)
+	if err != nil {
+		return 0, err
+	}
+
+	// Allow for filtering data for the keys we wish to export.
+	filteredKeysMap := make(map[string]bool, len(filteredKeys))
+	for _, k := range filteredKeys {
+		filteredKeysMap[string(k)] = true
+	}
+
+	// Extract the existing public keys in our database, sorted as in the in-memory export.
+	proposedPublicKeys, err := validatorDB.ProposedPublicKeys(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not retrieve proposer public keys from DB")
+	}
+	attestedPublicKeys, err := validatorDB.AttestedPublicKeys(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not retrieve attested public keys from DB")
+	}
+	publicKeys := make(map[string][fieldparams.BLSPubkeyLength]byte)
+	for _, pubKey := range append(proposedPublicKeys, attestedPublicKeys...) {
+		if _, ok := filteredKeysMap[string(pubKey[:])]; len(filteredKeys) > 0 && !ok {
+			continue
+		}
+		pubKeyHex, err := helpers.PubKeyToHexString(pubKey[:])
+		if err != nil {
+			return 0, errors.Wrap(err, "could not convert public key to hex string")
+		}
+		publicKeys[pubKeyHex] = pubKey
+	}
+	publicKeysHex := make([]string, 0, len(publicKeys))
+	for pubKeyHex := range publicKeys {
+		publicKeysHex = append(publicKeysHex, pubKeyHex)
+	}
+	sort.Strings(publicKeysHex)
+
+	enc, err := format.NewEncoder(w, genesisRootHex)
+	if err != nil {
+		return 0, err
+	}
+	bar := progress.InitializeProgressBar(
+		len(publicKeysHex), "Exporting slashing protection history by validator public key",
+	)
+	for _, pubKeyHex := range publicKeysHex {
+		pubKey := publicKeys[pubKeyHex]
+		signedBlocks, err := signedBlocksByPubKey(ctx, validatorDB, pubKey)
+		if err != nil {
+			return 0, errors.Wrapf(err, "could not retrieve signed blocks for public key %s", pubKeyHex)
+		}
+		signedAttestations, err := signedAttestationsByPubKey(ctx, validatorDB, pubKey)
+		if err != nil {
+			return 0, errors.Wrapf(err, "could not retrieve signed attestations for public key %s", pubKeyHex)
+		}
+		if signedAttestations == nil {
+			signedAttestations = make([]*format.SignedAttestation, 0)
+		}
+		if err := enc.Encode(&format.ProtectionData{
+			Pubkey:             pubKeyHex,
+			SignedBlocks:       signedBlocks,
+			SignedAttestations: signedAttestations,
+		}); err != nil {
+			return 0, err
+		}
+		if err := bar.Add(1); err != nil {
+			return 0, err
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return 0, err
+	}
+	return enc.Count(), nil
+}
+
+func genesisValidatorsRootHex(ctx context.Context, validatorDB db.Database) (string, error) {
+	genesisValidatorsRoot, err := validatorDB.GenesisValidatorsRoot(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "could not get genesis validators root from DB")
+	}
+	if genesisValidatorsRoot == nil || !bytesutil.IsValidRoot(genesisValidatorsRoot) {
+		return "", errors.New(
+			"genesis validators root is empty, perhaps you are not connected to your beacon node",
+		)
+	}
+	genesisRootHex, err := helpers.RootToHexString(genesisValidatorsRoot)
+	if err != nil {
+		return "", errors.Wrap(err, "could not convert genesis validators root to hex string")
+	}
+	return genesisRootHex, nil
+}
+
 func signedAttestationsByPubKey(ctx context.Context, validatorDB db.Database, pubKey [fieldparams.BLSPubkeyLength]byte) ([]*format.SignedAttestation, error) {
 	// If a key does not have an attestation history in our database, we return nil.
 	// This way, a user will be able to export their slashing protection history
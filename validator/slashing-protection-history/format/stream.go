@@ -0,0 +1,203 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// Decoder reads an EIP-3076 interchange document one validator record at a time,
+// so that the whole document never needs to be held in memory.
+type Decoder struct {
+	dec      *json.Decoder
+	header   *EIPSlashingProtectionFormat
+	buffered []*ProtectionData
+	inData   bool
+	hasData  bool
+	done     bool
+}
+
+// NewDecoder returns a decoder having read the metadata of the document. The metadata is expected
+// before the data as in every known export, otherwise the data is buffered until the metadata is found.
+func NewDecoder(r io.Reader) (*Decoder, error) {
+	d := &Decoder{
+		dec:    json.NewDecoder(r),
+		header: &EIPSlashingProtectionFormat{},
+	}
+	if err := d.expectDelim('{'); err != nil {
+		return nil, err
+	}
+	for {
+		key, err := d.nextKey()
+		if err != nil {
+			return nil, err
+		}
+		switch key {
+		case "":
+			return d, nil
+		case "metadata":
+			if err := d.dec.Decode(&d.header.Metadata); err != nil {
+				return nil, errors.Wrap(err, "could not decode metadata")
+			}
+			return d, nil
+		case "data":
+			if err := d.dec.Decode(&d.buffered); err != nil {
+				return nil, errors.Wrap(err, "could not decode data")
+			}
+			d.hasData = d.buffered != nil
+		default:
+			if err := d.skipValue(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// Metadata returns the document with its metadata only.
+func (d *Decoder) Metadata() *EIPSlashingProtectionFormat {
+	return d.header
+}
+
+// HasData reports whether a data array, even an empty one, was read from the document so far.
+func (d *Decoder) HasData() bool {
+	return d.hasData
+}
+
+// Next returns the next validator record of the document, or io.EOF once all of them were read.
+func (d *Decoder) Next() (*ProtectionData, error) {
+	if len(d.buffered) > 0 {
+		item := d.buffered[0]
+		d.buffered = d.buffered[1:]
+		return item, nil
+	}
+	for !d.done {
+		if d.inData {
+			if d.dec.More() {
+				item := &ProtectionData{}
+				if err := d.dec.Decode(item); err != nil {
+					return nil, errors.Wrap(err, "could not decode validator record")
+				}
+				return item, nil
+			}
+			if err := d.expectDelim(']'); err != nil {
+				return nil, err
+			}
+			d.inData = false
+			continue
+		}
+		key, err := d.nextKey()
+		if err != nil {
+			return nil, err
+		}
+		switch key {
+		case "":
+		case "data":
+			tok, err := d.dec.Token()
+			if err != nil {
+				return nil, errors.Wrap(err, "could not read data")
+			}
+			if tok == nil {
+				continue
+			}
+			if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+				return nil, fmt.Errorf("expected data to be an array, got %v", tok)
+			}
+			d.inData = true
+			d.hasData = true
+		default:
+			if err := d.skipValue(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return nil, io.EOF
+}
+
+// nextKey returns the next key of the top level object, or an empty string at the end of the object.
+func (d *Decoder) nextKey() (string, error) {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return "", errors.Wrap(err, "could not read interchange document")
+	}
+	if delim, ok := tok.(json.Delim); ok && delim == '}' {
+		d.done = true
+		return "", nil
+	}
+	key, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("expected object key, got %v", tok)
+	}
+	return key, nil
+}
+
+func (d *Decoder) expectDelim(delim json.Delim) error {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return errors.Wrap(err, "could not read interchange document")
+	}
+	if got, ok := tok.(json.Delim); !ok || got != delim {
+		return fmt.Errorf("expected %v, got %v", delim, tok)
+	}
+	return nil
+}
+
+func (d *Decoder) skipValue() error {
+	var skipped json.RawMessage
+	return errors.Wrap(d.dec.Decode(&skipped), "could not read interchange document")
+}
+
+// Encoder writes an EIP-3076 interchange document one validator record at a time.
+type Encoder struct {
+	w     io.Writer
+	count int
+}
+
+// NewEncoder writes the metadata of a document for the given genesis validators root
+// and returns an encoder for its validator records. Close must be called once all the records are written.
+func NewEncoder(w io.Writer, genesisValidatorsRoot string) (*Encoder, error) {
+	header := &EIPSlashingProtectionFormat{}
+	header.Metadata.InterchangeFormatVersion = InterchangeFormatVersion
+	header.Metadata.GenesisValidatorsRoot = genesisValidatorsRoot
+	metadata, err := json.MarshalIndent(header.Metadata, "\t", "\t")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode metadata")
+	}
+	if _, err := fmt.Fprintf(w, "{\n\t\"metadata\": %s,\n\t\"data\": [", metadata); err != nil {
+		return nil, errors.Wrap(err, "could not write metadata")
+	}
+	return &Encoder{w: w}, nil
+}
+
+// Encode writes a validator record.
+func (e *Encoder) Encode(item *ProtectionData) error {
+	encoded, err := json.MarshalIndent(item, "\t\t", "\t")
+	if err != nil {
+		return errors.Wrap(err, "could not encode validator record")
+	}
+	separator := ","
+	if e.count == 0 {
+		separator = ""
+	}
+	if _, err := fmt.Fprintf(e.w, "%s\n\t\t%s", separator, encoded); err != nil {
+		return errors.Wrap(err, "could not write validator record")
+	}
+	e.count++
+	return nil
+}
+
+// Count returns the number of validator records written.
+func (e *Encoder) Count() int {
+	return e.count
+}
+
+// Close ends the document.
+func (e *Encoder) Close() error {
+	end := "\n\t]\n}\n"
+	if e.count == 0 {
+		end = "]\n}\n"
+	}
+	_, err := io.WriteString(e.w, end)
+	return errors.Wrap(err, "could not end interchange document")
+}
@@ -0,0 +1,155 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func decodeAll(t *testing.T, d *Decoder) []*ProtectionData {
+	var items []*ProtectionData
+	for {
+		item, err := d.Next()
+		if err == io.EOF {
+			return items
+		}
+		require.NoError(t, err)
+		items = append(items, item)
+	}
+}
+
+func TestEncoder_RoundTrip(t *testing.T) {
+	items := []*ProtectionData{
+		{
+			Pubkey:             "0x01",
+			SignedBlocks:       []*SignedBlock{{Slot: "1", SigningRoot: "0x02"}},
+			SignedAttestations: []*SignedAttestation{{SourceEpoch: "1", TargetEpoch: "2"}},
+		},
+		{
+			Pubkey:             "0x03",
+			SignedBlocks:       []*SignedBlock{},
+			SignedAttestations: []*SignedAttestation{{SourceEpoch: "3", TargetEpoch: "4"}},
+		},
+	}
+	buf := &bytes.Buffer{}
+	enc, err := NewEncoder(buf, "0xaa")
+	require.NoError(t, err)
+	for _, item := range items {
+		require.NoError(t, enc.Encode(item))
+	}
+	require.NoError(t, enc.Close())
+	assert.Equal(t, 2, enc.Count())
+
+	// The output is a regular interchange document.
+	doc := &EIPSlashingProtectionFormat{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), doc))
+	assert.Equal(t, InterchangeFormatVersion, doc.Metadata.InterchangeFormatVersion)
+	assert.Equal(t, "0xaa", doc.Metadata.GenesisValidatorsRoot)
+	assert.DeepEqual(t, items, doc.Data)
+
+	dec, err := NewDecoder(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, "0xaa", dec.Metadata().Metadata.GenesisValidatorsRoot)
+	assert.DeepEqual(t, items, decodeAll(t, dec))
+}
+
+func TestEncoder_Empty(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc, err := NewEncoder(buf, "0xaa")
+	require.NoError(t, err)
+	require.NoError(t, enc.Close())
+	doc := &EIPSlashingProtectionFormat{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), doc))
+	assert.Equal(t, 0, len(doc.Data))
+}
+
+func TestDecoder(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		root    string
+		pubkeys []string
+		noData  bool
+		wantErr string
+	}{
+		{
+			name:    "metadata first",
+			input:   `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0xaa"},"data":[{"pubkey":"0x01"},{"pubkey":"0x02"}]}`,
+			root:    "0xaa",
+			pubkeys: []string{"0x01", "0x02"},
+		},
+		{
+			name:    "data first",
+			input:   `{"data":[{"pubkey":"0x01"}],"metadata":{"interchange_format_version":"5","genesis_validators_root":"0xaa"}}`,
+			root:    "0xaa",
+			pubkeys: []string{"0x01"},
+		},
+		{
+			name:   "null data",
+			input:  `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0xaa"},"data":null}`,
+			root:   "0xaa",
+			noData: true,
+		},
+		{
+			name:  "empty data",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0xaa"},"data":[]}`,
+			root:  "0xaa",
+		},
+		{
+			name:  "empty data first",
+			input: `{"data":[],"metadata":{"interchange_format_version":"5","genesis_validators_root":"0xaa"}}`,
+			root:  "0xaa",
+		},
+		{
+			name:    "unknown fields",
+			input:   `{"extra":{"a":[1,2]},"metadata":{"interchange_format_version":"5","genesis_validators_root":"0xaa"},"more":1,"data":[{"pubkey":"0x01"}]}`,
+			root:    "0xaa",
+			pubkeys: []string{"0x01"},
+		},
+		{
+			name:    "not an object",
+			input:   `[]`,
+			wantErr: "expected {",
+		},
+		{
+			name:    "data not an array",
+			input:   `{"metadata":{},"data":1}`,
+			wantErr: "expected data to be an array",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec, err := NewDecoder(strings.NewReader(tt.input))
+			var items []*ProtectionData
+			if err == nil {
+				for {
+					var item *ProtectionData
+					item, err = dec.Next()
+					if err != nil {
+						break
+					}
+					items = append(items, item)
+				}
+				if err == io.EOF {
+					err = nil
+				}
+			}
+			if tt.wantErr != "" {
+				require.ErrorContains(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.root, dec.Metadata().Metadata.GenesisValidatorsRoot)
+			assert.Equal(t, !tt.noData, dec.HasData())
+			require.Equal(t, len(tt.pubkeys), len(items))
+			for i, item := range items {
+				assert.Equal(t, tt.pubkeys[i], item.Pubkey)
+			}
+		})
+	}
+}
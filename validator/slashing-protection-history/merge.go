@@ -0,0 +1,151 @@
+package history
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
+)
+
+// mergedHistory is the highest signed slot and epochs seen for a public key across the merged documents.
+type mergedHistory struct {
+	hasBlocks       bool
+	maxSlot         uint64
+	hasAttestations bool
+	maxSourceEpoch  uint64
+	maxTargetEpoch  uint64
+}
+
+// MergeStandardProtectionJSON merges several EIP-3076 documents into a single one written to w, and returns
+// the number of validators written. Every document must have the same genesis validators root.
+//
+// For each public key, the merged document only keeps a block at the highest signed slot and an attestation
+// with the highest signed source and target epochs, without signing roots. Importing it is enough for a
+// validator client to refuse anything that any of the merged documents would have refused.
+func MergeStandardProtectionJSON(w io.Writer, inputs ...io.Reader) (int, error) {
+	if len(inputs) == 0 {
+		return 0, errors.New("no slashing protection files to merge")
+	}
+	var genesisValidatorsRoot string
+	histories := make(map[string]*mergedHistory)
+	for i, r := range inputs {
+		dec, err := format.NewDecoder(r)
+		if err != nil {
+			return 0, errors.Wrapf(err, "could not read slashing protection file %d", i)
+		}
+		metadata := dec.Metadata().Metadata
+		if metadata.InterchangeFormatVersion != format.InterchangeFormatVersion {
+			return 0, errors.Errorf(
+				"slashing protection file %d has interchange format version %q, expected %q",
+				i, metadata.InterchangeFormatVersion, format.InterchangeFormatVersion,
+			)
+		}
+		if i == 0 {
+			genesisValidatorsRoot = metadata.GenesisValidatorsRoot
+		} else if !strings.EqualFold(metadata.GenesisValidatorsRoot, genesisValidatorsRoot) {
+			return 0, errors.Errorf(
+				"slashing protection file %d has genesis validators root %s, expected %s",
+				i, metadata.GenesisValidatorsRoot, genesisValidatorsRoot,
+			)
+		}
+		for {
+			item, err := dec.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return 0, errors.Wrapf(err, "could not read slashing protection file %d", i)
+			}
+			if item == nil {
+				continue
+			}
+			if err := mergeProtectionData(histories, item); err != nil {
+				return 0, errors.Wrapf(err, "could not merge public key %s of slashing protection file %d", item.Pubkey, i)
+			}
+		}
+	}
+
+	pubKeys := make([]string, 0, len(histories))
+	for pubKey := range histories {
+		pubKeys = append(pubKeys, pubKey)
+	}
+	sort.Strings(pubKeys)
+
+	enc, err := format.NewEncoder(w, genesisValidatorsRoot)
+	if err != nil {
+		return 0, err
+	}
+	for _, pubKey := range pubKeys {
+		h := histories[pubKey]
+		item := &format.ProtectionData{
+			Pubkey:             pubKey,
+			SignedBlocks:       make([]*format.SignedBlock, 0),
+			SignedAttestations: make([]*format.SignedAttestation, 0),
+		}
+		if h.hasBlocks {
+			item.SignedBlocks = append(item.SignedBlocks, &format.SignedBlock{
+				Slot: strconv.FormatUint(h.maxSlot, 10),
+			})
+		}
+		if h.hasAttestations {
+			item.SignedAttestations = append(item.SignedAttestations, &format.SignedAttestation{
+				SourceEpoch: strconv.FormatUint(h.maxSourceEpoch, 10),
+				TargetEpoch: strconv.FormatUint(h.maxTargetEpoch, 10),
+			})
+		}
+		if err := enc.Encode(item); err != nil {
+			return 0, err
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return 0, err
+	}
+	return enc.Count(), nil
+}
+
+func mergeProtectionData(histories map[string]*mergedHistory, item *format.ProtectionData) error {
+	// Public keys are compared case-insensitively, as hex encodings may differ between clients.
+	pubKey := strings.ToLower(item.Pubkey)
+	h, ok := histories[pubKey]
+	if !ok {
+		h = &mergedHistory{}
+		histories[pubKey] = h
+	}
+	for _, block := range item.SignedBlocks {
+		if block == nil {
+			continue
+		}
+		slot, err := strconv.ParseUint(block.Slot, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "%s is not a valid slot", block.Slot)
+		}
+		if !h.hasBlocks || slot > h.maxSlot {
+			h.maxSlot = slot
+		}
+		h.hasBlocks = true
+	}
+	for _, att := range item.SignedAttestations {
+		if att == nil {
+			continue
+		}
+		source, err := strconv.ParseUint(att.SourceEpoch, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "%s is not a valid source epoch", att.SourceEpoch)
+		}
+		target, err := strconv.ParseUint(att.TargetEpoch, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "%s is not a valid target epoch", att.TargetEpoch)
+		}
+		if !h.hasAttestations || source > h.maxSourceEpoch {
+			h.maxSourceEpoch = source
+		}
+		if !h.hasAttestations || target > h.maxTargetEpoch {
+			h.maxTargetEpoch = target
+		}
+		h.hasAttestations = true
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
+)
+
+func TestMergeStandardProtectionJSON(t *testing.T) {
+	first := `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0xaa"},"data":[
+		{"pubkey":"0x01","signed_blocks":[{"slot":"10"},{"slot":"5"}],"signed_attestations":[{"source_epoch":"2","target_epoch":"3"}]},
+		{"pubkey":"0x02","signed_blocks":[{"slot":"1"}],"signed_attestations":[]}
+	]}`
+	second := `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0xAA"},"data":[
+		{"pubkey":"0x01","signed_blocks":[{"slot":"7"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"6"}]},
+		{"pubkey":"0x03","signed_attestations":[{"source_epoch":"4","target_epoch":"5"}]}
+	]}`
+	buf := &bytes.Buffer{}
+	count, err := MergeStandardProtectionJSON(buf, strings.NewReader(first), strings.NewReader(second))
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	merged := &format.EIPSlashingProtectionFormat{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), merged))
+	assert.Equal(t, "0xaa", merged.Metadata.GenesisValidatorsRoot)
+	assert.Equal(t, format.InterchangeFormatVersion, merged.Metadata.InterchangeFormatVersion)
+	wanted := []*format.ProtectionData{
+		{
+			Pubkey:             "0x01",
+			SignedBlocks:       []*format.SignedBlock{{Slot: "10"}},
+			SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "2", TargetEpoch: "6"}},
+		},
+		{
+			Pubkey:             "0x02",
+			SignedBlocks:       []*format.SignedBlock{{Slot: "1"}},
+			SignedAttestations: []*format.SignedAttestation{},
+		},
+		{
+			Pubkey:             "0x03",
+			SignedBlocks:       []*format.SignedBlock{},
+			SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "4", TargetEpoch: "5"}},
+		},
+	}
+	assert.DeepEqual(t, wanted, merged.Data)
+}
+
+func TestMergeStandardProtectionJSON_Errors(t *testing.T) {
+	valid := `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0xaa"},"data":[]}`
+	tests := []struct {
+		name    string
+		inputs  []string
+		wantErr string
+	}{
+		{
+			name:    "no inputs",
+			wantErr: "no slashing protection files to merge",
+		},
+		{
+			name:    "different genesis validators root",
+			inputs:  []string{valid, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0xbb"},"data":[]}`},
+			wantErr: "slashing protection file 1 has genesis validators root 0xbb, expected 0xaa",
+		},
+		{
+			name:    "wrong version",
+			inputs:  []string{`{"metadata":{"interchange_format_version":"4","genesis_validators_root":"0xaa"},"data":[]}`},
+			wantErr: "has interchange format version",
+		},
+		{
+			name:    "invalid slot",
+			inputs:  []string{valid, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0xaa"},"data":[{"pubkey":"0x01","signed_blocks":[{"slot":"a"}]}]}`},
+			wantErr: "a is not a valid slot",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputs := make([]io.Reader, len(tt.inputs))
+			for i, input := range tt.inputs {
+				inputs[i] = strings.NewReader(input)
+			}
+			_, err := MergeStandardProtectionJSON(&bytes.Buffer{}, inputs...)
+			require.ErrorContains(t, tt.wantErr, err)
+		})
+	}
+}
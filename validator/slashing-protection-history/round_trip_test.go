@@ -419,3 +419,31 @@ func TestStore_ImportInterchangeData_BadFormat_PreventsDBWrites(t *testing.T) {
 		)
 	}
 }
+
+// TestImportExport_RoundTrip_Streaming tests that the streamed export of a database
+// is the same document as the in-memory export.
+func TestImportExport_RoundTrip_Streaming(t *testing.T) {
+	ctx := context.Background()
+	numValidators := 10
+	publicKeys, err := slashtest.CreateRandomPubKeys(numValidators)
+	require.NoError(t, err)
+	validatorDB := dbtest.SetupDB(t, publicKeys, false)
+
+	attestingHistory, proposalHistory := slashtest.MockAttestingAndProposalHistories(publicKeys)
+	wanted, err := slashtest.MockSlashingProtectionJSON(publicKeys, attestingHistory, proposalHistory)
+	require.NoError(t, err)
+	blob, err := json.Marshal(wanted)
+	require.NoError(t, err)
+	require.NoError(t, validatorDB.ImportStandardProtectionJSON(ctx, bytes.NewBuffer(blob)))
+
+	inMemory, err := history.ExportStandardProtectionJSON(ctx, validatorDB)
+	require.NoError(t, err)
+	streamed := &bytes.Buffer{}
+	count, err := history.ExportStandardProtectionJSONToWriter(ctx, validatorDB, streamed)
+	require.NoError(t, err)
+	assert.Equal(t, numValidators, count)
+
+	decoded := &format.EIPSlashingProtectionFormat{}
+	require.NoError(t, json.Unmarshal(streamed.Bytes(), decoded))
+	require.DeepEqual(t, inMemory, decoded)
+}